@@ -17,16 +17,24 @@ import (
 	"logbull/internal/features/api_keys"
 	"logbull/internal/features/audit_logs"
 	"logbull/internal/features/disk"
+	logs_annotations "logbull/internal/features/logs/annotations"
+	logs_attachments "logbull/internal/features/logs/attachments"
 	logs_cleanup "logbull/internal/features/logs/cleanup"
 	logs_core "logbull/internal/features/logs/core"
+	logs_exports "logbull/internal/features/logs/exports"
 	logs_querying "logbull/internal/features/logs/querying"
 	logs_receiving "logbull/internal/features/logs/receiving"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
 
 	// logs_cleanup "logbull/internal/features/logs/cleanup"
 	// logs_querying "logbull/internal/features/logs/querying"
 	// logs_receiving "logbull/internal/features/logs/receiving"
 	projects_controllers "logbull/internal/features/projects/controllers"
+	system_diagnostics "logbull/internal/features/system/diagnostics"
 	system_healthcheck "logbull/internal/features/system/healthcheck"
+	system_info "logbull/internal/features/system/info"
+	system_maintenance "logbull/internal/features/system/maintenance"
+	system_webhooks "logbull/internal/features/system/webhooks"
 	users_controllers "logbull/internal/features/users/controllers"
 	users_middleware "logbull/internal/features/users/middleware"
 	users_services "logbull/internal/features/users/services"
@@ -135,32 +143,70 @@ func setUpRoutes(r *gin.Engine) {
 	// Public routes (only user auth routes should be public)
 	userController := users_controllers.GetUserController()
 	userController.RegisterRoutes(v1)
-	logs_receiving.GetReceivingController().RegisterRoutes(v1)
 	downdetect.GetDowndetectController().RegisterRoutes(v1)
 	system_healthcheck.GetHealthcheckController().RegisterRoutes(v1)
 
+	// Writes are rejected in read-only mode, queries are registered without it
+	readOnlyMiddleware := system_maintenance.ReadOnlyMiddleware(system_maintenance.GetMaintenanceService())
+
+	ingestion := v1.Group("")
+	ingestion.Use(readOnlyMiddleware)
+	logs_receiving.GetReceivingController().RegisterRoutes(ingestion)
+
 	// Setup auth middleware
 	userService := users_services.GetUserService()
 	authMiddleware := users_middleware.AuthMiddleware(userService)
 
+	// Admins turn read-only mode off and cancel runaway queries, so these
+	// routes are not affected by it
+	maintenance := v1.Group("")
+	maintenance.Use(authMiddleware)
+	system_maintenance.GetMaintenanceController().RegisterRoutes(maintenance)
+	logs_querying.GetLogQueryController().RegisterAdminRoutes(maintenance)
+
 	// Protected routes
 	protected := v1.Group("")
-	protected.Use(authMiddleware)
+	protected.Use(authMiddleware, readOnlyMiddleware)
 
 	disk.GetDiskController().RegisterRoutes(protected)
 	audit_logs.GetAuditLogController().RegisterRoutes(protected)
+	system_diagnostics.GetDiagnosticsController().RegisterRoutes(protected)
+	system_webhooks.GetWebhookController().RegisterRoutes(protected)
+	system_info.GetSystemInfoController().RegisterRoutes(protected)
 	userController.RegisterProtectedRoutes(protected)
 	users_controllers.GetSettingsController().RegisterRoutes(protected)
 	users_controllers.GetManagementController().RegisterRoutes(protected)
 	projects_controllers.GetProjectController().RegisterRoutes(protected)
 	projects_controllers.GetMembershipController().RegisterRoutes(protected)
 	api_keys.GetApiKeyController().RegisterRoutes(protected)
-	logs_querying.GetLogQueryController().RegisterRoutes(protected)
+	users_controllers.GetPersonalAccessTokenController().RegisterRoutes(protected)
+	logs_annotations.GetLogAnnotationController().RegisterRoutes(protected)
+	logs_receiving.GetReceivingController().RegisterProtectedRoutes(protected)
+	logs_saved_queries.GetSavedQueryController().RegisterRoutes(protected)
+	logs_exports.GetScheduledExportController().RegisterRoutes(protected)
+	logs_exports.GetSinkExportController().RegisterRoutes(protected)
+	logs_cleanup.GetLogCleanupController().RegisterRoutes(protected)
+
+	// Read-only routes which also accept personal access tokens
+	queryable := v1.Group("")
+	queryable.Use(users_middleware.QueryAuthMiddleware(
+		userService,
+		users_services.GetPersonalAccessTokenService(),
+		users_services.GetQueryTokenService(),
+	))
+
+	logs_querying.GetLogQueryController().RegisterRoutes(queryable)
+	logs_saved_queries.GetSavedQueryController().RegisterQueryRoutes(queryable)
+	logs_attachments.GetAttachmentController().RegisterRoutes(queryable)
 }
 
 func setUpDependencies() {
 	audit_logs.SetupDependencies()
 	logs_core.SetupDependencies()
+	logs_receiving.SetupDependencies()
+	logs_attachments.SetupDependencies()
+	logs_cleanup.SetupDependencies()
+	logs_saved_queries.SetupDependencies()
 }
 
 func runBackgroundTasks(log *slog.Logger) {
@@ -172,6 +218,7 @@ func runBackgroundTasks(log *slog.Logger) {
 
 	logs_receiving.GetLogWorkerService().StartWorkers()
 	logs_cleanup.GetLogCleanupBackgroundService().StartWorkers()
+	logs_exports.GetScheduledExportBackgroundService().StartWorkers()
 
 	log.Info("Background tasks started successfully")
 }
@@ -24,6 +24,10 @@ type EnvVariables struct {
 	DatabaseDsn     string            `env:"DATABASE_DSN"              required:"true"`
 	EnvMode         env_utils.EnvMode `env:"ENV_MODE"                  required:"true"`
 	BackendRootPath string            `env:"BACKEND_ROOT_PATH"         required:"true"`
+	// build info, set by the Docker image (empty version means dev, empty commit
+	// falls back to the revision recorded by go build)
+	AppVersion string `env:"APP_VERSION" required:"false"`
+	AppCommit  string `env:"APP_COMMIT"  required:"false"`
 	// cache
 	ValkeyHost     string `env:"VALKEY_HOST"               required:"true"`
 	ValkeyPort     string `env:"VALKEY_PORT"               required:"true"`
@@ -34,6 +38,77 @@ type EnvVariables struct {
 	OpenSearchURL           string `env:"OPENSEARCH_URL"            required:"true"`
 	OpenSearchAPIPort       string `env:"OPENSEARCH_API_PORT"       required:"true"`
 	OpenSearchTransportPort string `env:"OPENSEARCH_TRANSPORT_PORT" required:"true"`
+	// enrichment, directory of an extracted MaxMind GeoLite2 or GeoIP2 City or Country CSV database
+	GeoIPDatabasePath string `env:"GEOIP_DB_PATH"             required:"false"`
+	// log workers (0 means CPU based default)
+	LogStorageWorkersCount int `env:"LOG_STORAGE_WORKERS_COUNT" required:"false"`
+	LogFlushWorkersCount   int `env:"LOG_FLUSH_WORKERS_COUNT"   required:"false"`
+	// quiet shards flushing at most this many logs flush right away (0 means 100, negative disables)
+	LogLowLatencyFlushMaxLogs int `env:"LOG_LOW_LATENCY_FLUSH_MAX_LOGS" required:"false"`
+	// maximum number of logs accepted in one ingest request (0 means 1000)
+	LogMaxBatchSize int `env:"LOG_MAX_BATCH_SIZE" required:"false"`
+	// longest accepted custom field name in characters (0 means 128)
+	LogMaxFieldNameLength int `env:"LOG_MAX_FIELD_NAME_LENGTH" required:"false"`
+	// logs with field names which are too long or have control characters, "=" or empty
+	// dot segments are rejected, otherwise such names are sanitized
+	IsInvalidFieldNameRejected bool `env:"LOG_REJECT_INVALID_FIELD_NAMES" required:"false"`
+	// lookback applied to queries without timeRange.from (0 means 24 hours)
+	QueryDefaultLookbackHours int `env:"QUERY_DEFAULT_LOOKBACK_HOURS" required:"false"`
+	// deepest offset plus limit of a query page, should not exceed index.max_result_window
+	// of the log indices (0 means 10000)
+	QueryMaxResultWindow int `env:"QUERY_MAX_RESULT_WINDOW" required:"false"`
+	// queries run at the same time by this instance, the rest wait briefly
+	// and are rejected with 503 (0 means 50)
+	QueryMaxConcurrent int `env:"QUERY_MAX_CONCURRENT" required:"false"`
+	// query complexity limits, 0 means the default and values above the ceiling
+	// are lowered to it: depth 10 (max 32), nodes 50 (max 500), children per
+	// logical node 20 (max 200), IN values 100 (max 1000), value length 1000 (max 10000)
+	QueryMaxDepth       int `env:"QUERY_MAX_DEPTH"        required:"false"`
+	QueryMaxNodes       int `env:"QUERY_MAX_NODES"        required:"false"`
+	QueryMaxChildren    int `env:"QUERY_MAX_CHILDREN"     required:"false"`
+	QueryMaxArrayValues int `env:"QUERY_MAX_ARRAY_VALUES" required:"false"`
+	QueryMaxValueLength int `env:"QUERY_MAX_VALUE_LENGTH" required:"false"`
+	// projects the cleanup workers process at the same time (0 means 1, at most 32) and
+	// the pause of each worker between projects in milliseconds (0 means none)
+	CleanupConcurrency    int `env:"CLEANUP_CONCURRENCY"      required:"false"`
+	CleanupProjectDelayMs int `env:"CLEANUP_PROJECT_DELAY_MS" required:"false"`
+	// first log and cleanup webhooks: timeout of each attempt in milliseconds (0 means 10 seconds),
+	// attempts including the first one (0 means 3) and the backoff before the first retry in
+	// milliseconds, doubled for each next one (0 means 1 second)
+	WebhookTimeoutMs        int `env:"WEBHOOK_TIMEOUT_MS"         required:"false"`
+	WebhookMaxAttempts      int `env:"WEBHOOK_MAX_ATTEMPTS"       required:"false"`
+	WebhookInitialBackoffMs int `env:"WEBHOOK_INITIAL_BACKOFF_MS" required:"false"`
+	// directory of offloaded log fields (empty means <backend root>/attachments-data)
+	AttachmentsStoragePath string `env:"ATTACHMENTS_STORAGE_PATH" required:"false"`
+	// S3 compatible storage of offloaded log fields, used instead of the directory when the endpoint is set
+	// (empty region means us-east-1)
+	AttachmentsS3Endpoint        string `env:"ATTACHMENTS_S3_ENDPOINT"          required:"false"`
+	AttachmentsS3Region          string `env:"ATTACHMENTS_S3_REGION"            required:"false"`
+	AttachmentsS3Bucket          string `env:"ATTACHMENTS_S3_BUCKET"            required:"false"`
+	AttachmentsS3AccessKeyID     string `env:"ATTACHMENTS_S3_ACCESS_KEY_ID"     required:"false"`
+	AttachmentsS3SecretAccessKey string `env:"ATTACHMENTS_S3_SECRET_ACCESS_KEY" required:"false"`
+	// S3 compatible storage of scheduled exports (empty endpoint disables exports, empty region means us-east-1)
+	ExportsS3Endpoint        string `env:"EXPORTS_S3_ENDPOINT"          required:"false"`
+	ExportsS3Region          string `env:"EXPORTS_S3_REGION"            required:"false"`
+	ExportsS3AccessKeyID     string `env:"EXPORTS_S3_ACCESS_KEY_ID"     required:"false"`
+	ExportsS3SecretAccessKey string `env:"EXPORTS_S3_SECRET_ACCESS_KEY" required:"false"`
+	// lets webhooks and exports to HTTP sinks reach loopback and private addresses,
+	// e.g. a receiver next to a self-hosted instance (off by default)
+	IsSinkExportPrivateNetworkAllowed bool `env:"SINK_EXPORT_ALLOW_PRIVATE_NETWORKS" required:"false"`
+	// length of new API key secrets in hex characters, 32 to 128 (0 means 32)
+	ApiKeySecretLength int `env:"API_KEY_SECRET_LENGTH" required:"false"`
+	// API key secrets are stored as HMAC-SHA256 with this secret instead of plain
+	// SHA-256, existing keys are rehashed when they are next used
+	ApiKeyHashSecret string `env:"API_KEY_HASH_SECRET" required:"false"`
+	// longest lifetime of bearer ingest tokens in minutes (0 means a day)
+	IngestTokenMaxLifetimeMinutes int `env:"INGEST_TOKEN_MAX_LIFETIME_MINUTES" required:"false"`
+	// sign-in sessions unused for this many minutes expire before their token does (0 disables)
+	SessionInactivityTimeoutMinutes int `env:"SESSION_INACTIVITY_TIMEOUT_MINUTES" required:"false"`
+	// rejects writes while queries keep working, the mode can also be toggled by admins
+	IsReadOnlyMode bool `env:"READ_ONLY_MODE" required:"false"`
+	// project update audit logs only say "Project updated" instead of listing
+	// the changed settings with their old and new values
+	IsAuditSettingsDiffDisabled bool `env:"AUDIT_SETTINGS_DIFF_DISABLED" required:"false"`
 }
 
 var (
@@ -10,13 +10,16 @@ import (
 )
 
 type ApiKeyController struct {
-	apiKeyService *ApiKeyService
+	apiKeyService      *ApiKeyService
+	ingestTokenService *IngestTokenService
 }
 
 func (c *ApiKeyController) RegisterRoutes(router *gin.RouterGroup) {
 	apiKeyRoutes := router.Group("/projects/api-keys/:projectId")
 
 	apiKeyRoutes.POST("", c.CreateApiKey)
+	apiKeyRoutes.POST("/bulk", c.BulkCreateApiKeys)
+	apiKeyRoutes.POST("/ingest-tokens", c.CreateIngestToken)
 	apiKeyRoutes.GET("", c.GetApiKeys)
 	apiKeyRoutes.PUT("/:apiKeyId", c.UpdateApiKey)
 	apiKeyRoutes.DELETE("/:apiKeyId", c.DeleteApiKey)
@@ -35,6 +38,7 @@ func (c *ApiKeyController) RegisterRoutes(router *gin.RouterGroup) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/api-keys/{projectId} [post]
 func (c *ApiKeyController) CreateApiKey(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -58,6 +62,62 @@ func (c *ApiKeyController) CreateApiKey(ctx *gin.Context) {
 
 	response, err := c.apiKeyService.CreateApiKey(projectID, &request, user)
 	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to create API keys" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// BulkCreateApiKeys
+// @Summary Create API keys in bulk
+// @Description Create several API keys for the project at once. Tokens are returned only in this response
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Param request body BulkCreateApiKeysRequestDTO true "API keys creation data"
+// @Success 200 {object} BulkCreateApiKeysResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/api-keys/{projectId}/bulk [post]
+func (c *ApiKeyController) BulkCreateApiKeys(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectIDStr := ctx.Param("projectId")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request BulkCreateApiKeysRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.apiKeyService.BulkCreateApiKeys(projectID, &request, user)
+	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to create API keys" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -80,6 +140,7 @@ func (c *ApiKeyController) CreateApiKey(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/api-keys/{projectId} [get]
 func (c *ApiKeyController) GetApiKeys(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -97,6 +158,10 @@ func (c *ApiKeyController) GetApiKeys(ctx *gin.Context) {
 
 	response, err := c.apiKeyService.GetProjectApiKeys(projectID, user)
 	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to view API keys" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -122,6 +187,7 @@ func (c *ApiKeyController) GetApiKeys(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/api-keys/{projectId}/{apiKeyId} [put]
 func (c *ApiKeyController) UpdateApiKey(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -151,6 +217,10 @@ func (c *ApiKeyController) UpdateApiKey(ctx *gin.Context) {
 	}
 
 	if err := c.apiKeyService.UpdateApiKey(projectID, apiKeyID, &request, user); err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to update API keys" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -173,6 +243,7 @@ func (c *ApiKeyController) UpdateApiKey(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/api-keys/{projectId}/{apiKeyId} [delete]
 func (c *ApiKeyController) DeleteApiKey(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -196,6 +267,10 @@ func (c *ApiKeyController) DeleteApiKey(ctx *gin.Context) {
 	}
 
 	if err := c.apiKeyService.DeleteApiKey(projectID, apiKeyID, user); err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to delete API keys" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -206,3 +281,58 @@ func (c *ApiKeyController) DeleteApiKey(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "API key deleted successfully"})
 }
+
+// CreateIngestToken
+// @Summary Create a bearer ingest token
+// @Description Create a short-lived token for sending logs to the project with "Authorization: Bearer <token>"
+// @Description instead of an API key. The token is valid for ingestion into this project only and can't be
+// @Description revoked, it expires after expiresInMinutes (an hour by default)
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Param request body CreateIngestTokenRequestDTO false "Token lifetime"
+// @Success 200 {object} IngestTokenResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/api-keys/{projectId}/ingest-tokens [post]
+func (c *ApiKeyController) CreateIngestToken(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request CreateIngestTokenRequestDTO
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	response, err := c.ingestTokenService.CreateIngestToken(projectID, &request, user)
+	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to create ingest tokens" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
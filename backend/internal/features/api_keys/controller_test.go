@@ -1,8 +1,11 @@
 package api_keys
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	projects_controllers "logbull/internal/features/projects/controllers"
 	projects_testing "logbull/internal/features/projects/testing"
@@ -10,6 +13,7 @@ import (
 	users_testing "logbull/internal/features/users/testing"
 	test_utils "logbull/internal/util/testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -565,3 +569,362 @@ func Test_DeleteApiKey_WithApiKeyFromDifferentProject_ReturnsBadRequest(t *testi
 
 	assert.Contains(t, string(resp.Body), "API key does not belong to this project")
 }
+
+// BulkCreateApiKeys Tests
+
+func Test_BulkCreateApiKeys_WhenUserIsProjectOwner_AllKeysCreatedWithTokens(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+
+	expiresAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
+	request := BulkCreateApiKeysRequestDTO{
+		Keys: []CreateApiKeyRequestDTO{
+			{Name: "Service A"},
+			{Name: "Service B", ExpiresAt: &expiresAt},
+			{Name: "Service C", Scope: ApiKeyScopeIngest},
+		},
+	}
+
+	var response BulkCreateApiKeysResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/api-keys/"+project.ID.String()+"/bulk",
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.ApiKeys, 3)
+
+	tokens := make(map[string]bool)
+	for i, key := range response.ApiKeys {
+		assert.Equal(t, request.Keys[i].Name, key.Name)
+		assert.Equal(t, project.ID, key.ProjectID)
+		assert.Equal(t, ApiKeyScopeIngest, key.Scope)
+		assert.Equal(t, ApiKeyStatusActive, key.Status)
+		assert.Contains(t, key.Token, "lb_")
+		tokens[key.Token] = true
+	}
+	assert.Len(t, tokens, 3)
+
+	assert.Nil(t, response.ApiKeys[0].ExpiresAt)
+	if assert.NotNil(t, response.ApiKeys[1].ExpiresAt) {
+		assert.True(t, expiresAt.Equal(*response.ApiKeys[1].ExpiresAt))
+	}
+}
+
+func Test_GetApiKeys_AfterBulkCreation_ReturnsMetadataWithoutTokens(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	createdKeys := bulkCreateTestApiKeys(t, project.ID, owner.Token, router, []CreateApiKeyRequestDTO{
+		{Name: "Listed Key 1", ExpiresAt: &expiresAt},
+		{Name: "Listed Key 2"},
+	})
+
+	_, err := GetApiKeyService().ValidateApiKey(createdKeys[0].Token, project.ID)
+	assert.NoError(t, err)
+
+	resp := test_utils.MakeGetRequest(
+		t, router, "/api/v1/projects/api-keys/"+project.ID.String(), "Bearer "+owner.Token, http.StatusOK,
+	)
+	for _, createdKey := range createdKeys {
+		assert.NotContains(t, string(resp.Body), createdKey.Token)
+	}
+
+	var response GetApiKeysResponseDTO
+	assert.NoError(t, json.Unmarshal(resp.Body, &response))
+	assert.Len(t, response.ApiKeys, 2)
+
+	keysByID := make(map[uuid.UUID]*ApiKey)
+	for _, key := range response.ApiKeys {
+		assert.Empty(t, key.Token)
+		assert.Equal(t, ApiKeyScopeIngest, key.Scope)
+		assert.False(t, key.CreatedAt.IsZero())
+		keysByID[key.ID] = key
+	}
+
+	usedKey := keysByID[createdKeys[0].ID]
+	if assert.NotNil(t, usedKey) {
+		assert.Equal(t, "Listed Key 1", usedKey.Name)
+		assert.NotNil(t, usedKey.LastUsedAt)
+		if assert.NotNil(t, usedKey.ExpiresAt) {
+			assert.True(t, expiresAt.Equal(*usedKey.ExpiresAt))
+		}
+	}
+
+	unusedKey := keysByID[createdKeys[1].ID]
+	if assert.NotNil(t, unusedKey) {
+		assert.Nil(t, unusedKey.LastUsedAt)
+		assert.Nil(t, unusedKey.ExpiresAt)
+	}
+}
+
+func Test_BulkCreateApiKeys_WhenUserIsProjectAdmin_ApiKeysCreated(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+	projects_testing.AddMemberToProject(project, admin, users_enums.ProjectRoleAdmin, owner.Token, router)
+
+	createdKeys := bulkCreateTestApiKeys(t, project.ID, admin.Token, router, []CreateApiKeyRequestDTO{
+		{Name: "Admin Key 1"},
+		{Name: "Admin Key 2"},
+	})
+
+	assert.Len(t, createdKeys, 2)
+}
+
+func Test_BulkCreateApiKeys_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	request := BulkCreateApiKeysRequestDTO{
+		Keys: []CreateApiKeyRequestDTO{{Name: "Member Key"}},
+	}
+
+	resp := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/projects/api-keys/"+project.ID.String()+"/bulk",
+		"Bearer "+member.Token,
+		request,
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "insufficient permissions to create API keys")
+}
+
+func Test_BulkCreateApiKeys_WithInvalidKey_ReturnsBadRequestAndCreatesNoKeys(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+
+	pastExpiresAt := time.Now().UTC().Add(-time.Hour)
+	invalidRequests := []BulkCreateApiKeysRequestDTO{
+		{Keys: []CreateApiKeyRequestDTO{}},
+		{Keys: []CreateApiKeyRequestDTO{{Name: "Valid Key"}, {Name: ""}}},
+		{Keys: []CreateApiKeyRequestDTO{{Name: "Valid Key"}, {Name: "Expired Key", ExpiresAt: &pastExpiresAt}}},
+		{Keys: []CreateApiKeyRequestDTO{{Name: "Valid Key"}, {Name: "Unknown Scope", Scope: "ADMIN"}}},
+	}
+
+	for _, request := range invalidRequests {
+		test_utils.MakePostRequest(
+			t,
+			router,
+			"/api/v1/projects/api-keys/"+project.ID.String()+"/bulk",
+			"Bearer "+owner.Token,
+			request,
+			http.StatusBadRequest,
+		)
+	}
+
+	var response GetApiKeysResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/api-keys/"+project.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+	assert.Empty(t, response.ApiKeys)
+}
+
+func Test_ValidateApiKey_WhenApiKeyExpired_ReturnsInvalid(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+
+	service := GetApiKeyService()
+	fullToken, tokenPrefix, tokenHash, err := service.generateSecureToken()
+	assert.NoError(t, err)
+
+	expiredAt := time.Now().UTC().Add(-time.Minute)
+	apiKey := &ApiKey{
+		Name:        "Expired Key",
+		ProjectID:   project.ID,
+		TokenPrefix: tokenPrefix,
+		TokenHash:   tokenHash,
+		Status:      ApiKeyStatusActive,
+		Scope:       ApiKeyScopeIngest,
+		ExpiresAt:   &expiredAt,
+	}
+	assert.NoError(t, apiKeyRepository.CreateApiKey(apiKey))
+
+	result, err := service.ValidateApiKey(fullToken, project.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+
+	// Keys cached before expiring are rejected as well
+	service.apiKeyCacheUtil.Set(tokenHash, toCachedApiKey(apiKey))
+
+	result, err = service.ValidateApiKey(fullToken, project.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+}
+
+// Hashing Tests
+
+func Test_CreateApiKey_StoresOnlyHash_PlaintextSecretAuthenticates(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+
+	service := GetApiKeyService()
+	apiKey := CreateTestApiKey("Hashed Key", project.ID, owner.Token, router)
+
+	storedKey, err := apiKeyRepository.GetApiKeyByID(apiKey.ID)
+	assert.NoError(t, err)
+
+	secret := strings.TrimPrefix(apiKey.Token, TokenPrefix)
+	assert.Len(t, storedKey.TokenHash, 64)
+	assert.NotContains(t, storedKey.TokenHash, secret)
+	assert.Equal(t, service.GetTokenHasher().Hash(apiKey.Token), storedKey.TokenHash)
+	assert.Equal(t, service.GetTokenHasher().Algorithm(), storedKey.HashAlgorithm)
+
+	result, err := service.ValidateApiKey(apiKey.Token, project.ID)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.Equal(t, apiKey.ID, result.ApiKeyID)
+
+	// The stored hash itself is not a usable secret
+	result, err = service.ValidateApiKey(TokenPrefix+storedKey.TokenHash, project.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.IsValid)
+}
+
+func Test_TokenHasher_WithDifferentSecrets_ProducesDifferentHashes(t *testing.T) {
+	token := TokenPrefix + strings.Repeat("ab", TokenLength/2)
+
+	plainHasher := NewTokenHasher("")
+	firstHasher := NewTokenHasher("first-secret")
+	secondHasher := NewTokenHasher("second-secret")
+
+	assert.Equal(t, ApiKeyHashAlgorithmSHA256, plainHasher.Algorithm())
+	assert.Equal(t, ApiKeyHashAlgorithmHMACSHA256, firstHasher.Algorithm())
+
+	hashes := map[string]bool{
+		plainHasher.Hash(token):  true,
+		firstHasher.Hash(token):  true,
+		secondHasher.Hash(token): true,
+	}
+	assert.Len(t, hashes, 3)
+	assert.Equal(t, firstHasher.Hash(token), firstHasher.Hash(token))
+
+	assert.True(t, firstHasher.Matches(token, ApiKeyHashAlgorithmHMACSHA256, firstHasher.Hash(token)))
+	assert.False(t, firstHasher.Matches(token, ApiKeyHashAlgorithmHMACSHA256, secondHasher.Hash(token)))
+	assert.False(t, firstHasher.Matches(token+"0", ApiKeyHashAlgorithmHMACSHA256, firstHasher.Hash(token)))
+	assert.False(t, plainHasher.Matches(token, ApiKeyHashAlgorithmHMACSHA256, firstHasher.Hash(token)))
+}
+
+func Test_ValidateApiKey_WhenHashSecretConfiguredLater_RehashesLegacyKey(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+
+	service := GetApiKeyService()
+	originalHasher := service.GetTokenHasher()
+	t.Cleanup(func() { service.SetTokenHasher(originalHasher) })
+
+	service.SetTokenHasher(NewTokenHasher(""))
+	apiKey := CreateTestApiKey("Legacy Key", project.ID, owner.Token, router)
+
+	hmacHasher := NewTokenHasher("hash-secret-" + uuid.New().String())
+	service.SetTokenHasher(hmacHasher)
+
+	result, err := service.ValidateApiKey(apiKey.Token, project.ID)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+
+	storedKey, err := apiKeyRepository.GetApiKeyByID(apiKey.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ApiKeyHashAlgorithmHMACSHA256, storedKey.HashAlgorithm)
+	assert.Equal(t, hmacHasher.Hash(apiKey.Token), storedKey.TokenHash)
+
+	// Once rehashed, the key is found by its new hash without the fallback
+	service.apiKeyCacheUtil.Invalidate(storedKey.TokenHash)
+
+	result, err = service.ValidateApiKey(apiKey.Token, project.ID)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+}
+
+func Test_CreateApiKey_WithConfiguredSecretLength_GeneratesSecretOfLength(t *testing.T) {
+	router := CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectViaAPI("Test Project", owner, router)
+
+	service := GetApiKeyService()
+	originalLength := service.GetSecretLength()
+	t.Cleanup(func() { service.SetSecretLength(originalLength) })
+
+	service.SetSecretLength(64)
+	apiKey := CreateTestApiKey("Long Key", project.ID, owner.Token, router)
+	assert.Len(t, apiKey.Token, len(TokenPrefix)+64)
+
+	result, err := service.ValidateApiKey(apiKey.Token, project.ID)
+	assert.NoError(t, err)
+	assert.True(t, result.IsValid)
+
+	// Lengths out of range are clamped
+	service.SetSecretLength(8)
+	assert.Equal(t, MinSecretLength, service.GetSecretLength())
+	service.SetSecretLength(1000)
+	assert.Equal(t, MaxSecretLength, service.GetSecretLength())
+}
+
+func bulkCreateTestApiKeys(
+	t *testing.T,
+	projectID uuid.UUID,
+	token string,
+	router *gin.Engine,
+	keys []CreateApiKeyRequestDTO,
+) []*ApiKey {
+	var response BulkCreateApiKeysResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/api-keys/"+projectID.String()+"/bulk",
+		"Bearer "+token,
+		BulkCreateApiKeysRequestDTO{Keys: keys},
+		http.StatusOK,
+		&response,
+	)
+
+	return response.ApiKeys
+}
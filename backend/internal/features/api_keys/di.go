@@ -1,10 +1,16 @@
 package api_keys
 
 import (
+	"sync"
+	"time"
+
 	"logbull/internal/cache"
+	"logbull/internal/config"
 	audit_logs "logbull/internal/features/audit_logs"
 	projects_services "logbull/internal/features/projects/services"
+	users_repositories "logbull/internal/features/users/repositories"
 	cache_utils "logbull/internal/util/cache"
+	"logbull/internal/util/logger"
 
 	"golang.org/x/sync/singleflight"
 )
@@ -17,16 +23,41 @@ var apiKeyService = &ApiKeyService{
 	audit_logs.GetAuditLogService(),
 	cache_utils.NewCacheUtil[CachedApiKey](cache.GetCache(), "lb_apikey:"),
 	singleflight.Group{},
+	logger.GetLogger(),
+	sync.Map{},
+	NewTokenHasher(config.GetEnv().ApiKeyHashSecret),
+	normalizeSecretLength(config.GetEnv().ApiKeySecretLength),
+}
+
+var ingestTokenService = &IngestTokenService{
+	secretKeyRepository: &users_repositories.SecretKeyRepository{},
+	projectService:      projects_services.GetProjectService(),
+	auditLogService:     audit_logs.GetAuditLogService(),
+	maxLifetime:         getIngestTokenMaxLifetimeFromConfig(),
 }
 
 var apiKeyController = &ApiKeyController{
 	apiKeyService,
+	ingestTokenService,
 }
 
 func GetApiKeyService() *ApiKeyService {
 	return apiKeyService
 }
 
+func GetIngestTokenService() *IngestTokenService {
+	return ingestTokenService
+}
+
 func GetApiKeyController() *ApiKeyController {
 	return apiKeyController
 }
+
+func getIngestTokenMaxLifetimeFromConfig() time.Duration {
+	minutes := config.GetEnv().IngestTokenMaxLifetimeMinutes
+	if minutes <= 0 {
+		return DefaultMaxIngestTokenLifetime
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
@@ -1,11 +1,23 @@
 package api_keys
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type CreateApiKeyRequestDTO struct {
-	Name string `json:"name" binding:"required,min=1,max=100"`
+	Name      string      `json:"name"                binding:"required,min=1,max=100"`
+	Scope     ApiKeyScope `json:"scope,omitempty"`
+	ExpiresAt *time.Time  `json:"expiresAt,omitempty"`
+}
+
+type BulkCreateApiKeysRequestDTO struct {
+	Keys []CreateApiKeyRequestDTO `json:"keys" binding:"required,min=1,max=100,dive"`
+}
+
+type BulkCreateApiKeysResponseDTO struct {
+	ApiKeys []*ApiKey `json:"apiKeys"`
 }
 
 type GetApiKeysResponseDTO struct {
@@ -28,8 +40,20 @@ type ValidateTokenResponse struct {
 	ProjectID uuid.UUID `json:"projectId,omitempty"`
 }
 
+type CreateIngestTokenRequestDTO struct {
+	// Lifetime of the token, 0 means an hour
+	ExpiresInMinutes int `json:"expiresInMinutes" binding:"omitempty,min=1"`
+}
+
+type IngestTokenResponseDTO struct {
+	Token     string    `json:"token"`
+	ProjectID uuid.UUID `json:"projectId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type CachedApiKey struct {
 	ID        uuid.UUID    `json:"id"`
 	ProjectID uuid.UUID    `json:"projectId"`
 	Status    ApiKeyStatus `json:"status"`
+	ExpiresAt *time.Time   `json:"expiresAt,omitempty"`
 }
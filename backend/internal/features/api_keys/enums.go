@@ -7,3 +7,27 @@ const (
 	ApiKeyStatusDisabled ApiKeyStatus = "DISABLED"
 	ApiKeyStatusNotFound ApiKeyStatus = "NOT_FOUND"
 )
+
+type ApiKeyScope string
+
+const (
+	// Keys with this scope may only send logs to their project
+	ApiKeyScopeIngest ApiKeyScope = "INGEST"
+)
+
+func (s ApiKeyScope) IsValid() bool {
+	switch s {
+	case ApiKeyScopeIngest:
+		return true
+	default:
+		return false
+	}
+}
+
+type ApiKeyHashAlgorithm string
+
+const (
+	// Keys created before hashing became configurable use plain SHA-256
+	ApiKeyHashAlgorithmSHA256     ApiKeyHashAlgorithm = "SHA256"
+	ApiKeyHashAlgorithmHMACSHA256 ApiKeyHashAlgorithm = "HMAC_SHA256"
+)
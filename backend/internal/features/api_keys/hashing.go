@@ -0,0 +1,77 @@
+package api_keys
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+const (
+	// Shortest and longest configurable secret, in hex characters after the prefix
+	MinSecretLength = 32
+	MaxSecretLength = 128
+)
+
+// TokenHasher turns API key secrets into the hashes stored in the database.
+// Only hashes are stored, with a configured secret they are HMACs, so leaked
+// hashes can't even be brute forced without the server secret
+type TokenHasher struct {
+	algorithm ApiKeyHashAlgorithm
+	secret    []byte
+}
+
+// NewTokenHasher returns an HMAC-SHA256 hasher when hashSecret is set,
+// otherwise a plain SHA-256 one
+func NewTokenHasher(hashSecret string) *TokenHasher {
+	if hashSecret == "" {
+		return &TokenHasher{algorithm: ApiKeyHashAlgorithmSHA256}
+	}
+
+	return &TokenHasher{
+		algorithm: ApiKeyHashAlgorithmHMACSHA256,
+		secret:    []byte(hashSecret),
+	}
+}
+
+func (h *TokenHasher) Algorithm() ApiKeyHashAlgorithm {
+	return h.algorithm
+}
+
+func (h *TokenHasher) Hash(token string) string {
+	return h.hashWith(h.algorithm, token)
+}
+
+// Matches compares the token with a stored hash in constant time. Hashes of
+// other algorithms never match
+func (h *TokenHasher) Matches(token string, algorithm ApiKeyHashAlgorithm, storedHash string) bool {
+	if algorithm != h.algorithm && algorithm != ApiKeyHashAlgorithmSHA256 {
+		return false
+	}
+
+	computedHash := h.hashWith(algorithm, token)
+	return subtle.ConstantTimeCompare([]byte(computedHash), []byte(storedHash)) == 1
+}
+
+func (h *TokenHasher) hashWith(algorithm ApiKeyHashAlgorithm, token string) string {
+	if algorithm == ApiKeyHashAlgorithmHMACSHA256 {
+		mac := hmac.New(sha256.New, h.secret)
+		mac.Write([]byte(token))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	hasher := sha256.New()
+	hasher.Write([]byte(token))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// normalizeSecretLength keeps configured lengths within the allowed range
+// and even, as secrets are hex encoded bytes (0 means TokenLength)
+func normalizeSecretLength(length int) int {
+	if length <= 0 {
+		return TokenLength
+	}
+
+	length = max(MinSecretLength, min(MaxSecretLength, length))
+	return length + length%2
+}
@@ -0,0 +1,157 @@
+package api_keys
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	projects_services "logbull/internal/features/projects/services"
+	users_models "logbull/internal/features/users/models"
+	users_repositories "logbull/internal/features/users/repositories"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	IngestTokenPrefix = "lbi_"
+
+	DefaultIngestTokenLifetime = time.Hour
+	// Default upper bound of the lifetime, INGEST_TOKEN_MAX_LIFETIME_MINUTES changes it
+	DefaultMaxIngestTokenLifetime = 24 * time.Hour
+
+	ingestTokenType = "ingest"
+)
+
+// IngestTokenService mints bearer tokens for log ingestion, for clients which
+// prefer OAuth-style short-lived tokens to long-lived API keys. A token only
+// allows sending logs to its project. Tokens are signed, not stored, so they
+// can't be revoked and expire instead
+type IngestTokenService struct {
+	secretKeyRepository *users_repositories.SecretKeyRepository
+	projectService      *projects_services.ProjectService
+	auditLogService     *audit_logs.AuditLogService
+	maxLifetime         time.Duration
+
+	// The secret never changes once created, keeping it saves a query per ingest request
+	secretKey atomic.Pointer[string]
+}
+
+func (s *IngestTokenService) SetMaxLifetime(maxLifetime time.Duration) {
+	s.maxLifetime = maxLifetime
+}
+
+func (s *IngestTokenService) GetMaxLifetime() time.Duration {
+	return s.maxLifetime
+}
+
+func (s *IngestTokenService) IsIngestToken(token string) bool {
+	return strings.HasPrefix(token, IngestTokenPrefix)
+}
+
+func (s *IngestTokenService) CreateIngestToken(
+	projectID uuid.UUID,
+	request *CreateIngestTokenRequestDTO,
+	creator *users_models.User,
+) (*IngestTokenResponseDTO, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, creator)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to create ingest tokens")
+	}
+
+	lifetime := DefaultIngestTokenLifetime
+	if request.ExpiresInMinutes != 0 {
+		lifetime = time.Duration(request.ExpiresInMinutes) * time.Minute
+	}
+	if lifetime <= 0 || lifetime > s.maxLifetime {
+		return nil, fmt.Errorf("token lifetime must be between 1 and %d minutes", int(s.maxLifetime.Minutes()))
+	}
+
+	secretKey, err := s.getSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(lifetime).Truncate(time.Second)
+
+	claims := jwt.MapClaims{
+		"typ": ingestTokenType,
+		"pid": projectID.String(),
+		"jti": uuid.New().String(),
+		"exp": expiresAt.Unix(),
+		"iat": now.Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secretKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Ingest token created, expires at %s", expiresAt.Format(time.RFC3339)),
+		&creator.ID,
+		&projectID,
+	)
+
+	return &IngestTokenResponseDTO{
+		Token:     IngestTokenPrefix + token,
+		ProjectID: projectID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ValidateIngestToken reports whether the token is an unexpired ingest token
+// of the project. Tokens of other projects and other token types are invalid
+func (s *IngestTokenService) ValidateIngestToken(token string, projectID uuid.UUID) (bool, error) {
+	if !s.IsIngestToken(token) {
+		return false, nil
+	}
+
+	secretKey, err := s.getSecretKey()
+	if err != nil {
+		return false, err
+	}
+
+	parsedToken, err := jwt.Parse(
+		strings.TrimPrefix(token, IngestTokenPrefix),
+		func(token *jwt.Token) (any, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return []byte(secretKey), nil
+		},
+	)
+	if err != nil {
+		return false, nil
+	}
+
+	// Parsing checks the expiration only when it is present, ingest tokens must have it
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || !parsedToken.Valid || claims["typ"] != ingestTokenType || !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return false, nil
+	}
+
+	tokenProjectID, _ := claims["pid"].(string)
+	return tokenProjectID == projectID.String(), nil
+}
+
+func (s *IngestTokenService) getSecretKey() (string, error) {
+	if secretKey := s.secretKey.Load(); secretKey != nil {
+		return *secretKey, nil
+	}
+
+	secretKey, err := s.secretKeyRepository.GetSecretKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	s.secretKey.Store(&secretKey)
+	return secretKey, nil
+}
@@ -7,13 +7,17 @@ import (
 )
 
 type ApiKey struct {
-	ID          uuid.UUID    `json:"id"          gorm:"column:id"`
-	Name        string       `json:"name"        gorm:"column:name"`
-	ProjectID   uuid.UUID    `json:"projectId"   gorm:"column:project_id"`
-	TokenPrefix string       `json:"tokenPrefix" gorm:"column:token_prefix"`
-	TokenHash   string       `json:"-"           gorm:"column:token_hash"` // Never expose in JSON
-	Status      ApiKeyStatus `json:"status"      gorm:"column:status"`
-	CreatedAt   time.Time    `json:"createdAt"   gorm:"column:created_at"`
+	ID            uuid.UUID           `json:"id"          gorm:"column:id"`
+	Name          string              `json:"name"        gorm:"column:name"`
+	ProjectID     uuid.UUID           `json:"projectId"   gorm:"column:project_id"`
+	TokenPrefix   string              `json:"tokenPrefix" gorm:"column:token_prefix"`
+	TokenHash     string              `json:"-"           gorm:"column:token_hash"` // Never expose in JSON
+	HashAlgorithm ApiKeyHashAlgorithm `json:"-"           gorm:"column:hash_algorithm"`
+	Status        ApiKeyStatus        `json:"status"      gorm:"column:status"`
+	Scope         ApiKeyScope         `json:"scope"       gorm:"column:scope"`
+	CreatedAt     time.Time           `json:"createdAt"   gorm:"column:created_at"`
+	ExpiresAt     *time.Time          `json:"expiresAt"   gorm:"column:expires_at"`
+	LastUsedAt    *time.Time          `json:"lastUsedAt"  gorm:"column:last_used_at"`
 
 	Token string `json:"token,omitempty" gorm:"-"` //  Temporary field only populated during creation
 }
@@ -21,3 +25,7 @@ type ApiKey struct {
 func (ApiKey) TableName() string {
 	return "api_keys"
 }
+
+func (k *ApiKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
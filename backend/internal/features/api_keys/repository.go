@@ -22,6 +22,24 @@ func (r *ApiKeyRepository) CreateApiKey(apiKey *ApiKey) error {
 	return storage.GetDb().Create(apiKey).Error
 }
 
+// CreateApiKeys inserts all keys in a single statement, so either every key
+// is created or none of them
+func (r *ApiKeyRepository) CreateApiKeys(apiKeys []*ApiKey) error {
+	now := time.Now().UTC()
+
+	for _, apiKey := range apiKeys {
+		if apiKey.ID == uuid.Nil {
+			apiKey.ID = uuid.New()
+		}
+
+		if apiKey.CreatedAt.IsZero() {
+			apiKey.CreatedAt = now
+		}
+	}
+
+	return storage.GetDb().Create(&apiKeys).Error
+}
+
 func (r *ApiKeyRepository) GetApiKeysByProjectID(projectID uuid.UUID) ([]*ApiKey, error) {
 	var apiKeys []*ApiKey
 
@@ -68,3 +86,20 @@ func (r *ApiKeyRepository) UpdateApiKey(apiKey *ApiKey) error {
 func (r *ApiKeyRepository) DeleteApiKey(apiKeyID uuid.UUID) error {
 	return storage.GetDb().Delete(&ApiKey{}, apiKeyID).Error
 }
+
+func (r *ApiKeyRepository) UpdateLastUsedAt(apiKeyID uuid.UUID, lastUsedAt time.Time) error {
+	return storage.GetDb().
+		Model(&ApiKey{}).
+		Where("id = ?", apiKeyID).
+		Update("last_used_at", lastUsedAt).Error
+}
+
+func (r *ApiKeyRepository) UpdateTokenHash(apiKeyID uuid.UUID, tokenHash string, algorithm ApiKeyHashAlgorithm) error {
+	return storage.GetDb().
+		Model(&ApiKey{}).
+		Where("id = ?", apiKeyID).
+		Updates(map[string]any{
+			"token_hash":     tokenHash,
+			"hash_algorithm": algorithm,
+		}).Error
+}
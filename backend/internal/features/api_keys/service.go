@@ -2,11 +2,13 @@ package api_keys
 
 import (
 	"crypto/rand"
-	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
+	"sync"
+	"time"
 
 	audit_logs "logbull/internal/features/audit_logs"
 	projects_services "logbull/internal/features/projects/services"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/google/uuid"
 	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
 )
 
 type ApiKeyService struct {
@@ -24,62 +27,76 @@ type ApiKeyService struct {
 
 	apiKeyCacheUtil *cache_utils.CacheUtil[CachedApiKey]
 	singleflight    singleflight.Group // Prevents thundering herd on DB calls
+
+	logger         *slog.Logger
+	lastUsedWrites sync.Map // API key ID -> time of the last stored usage
+
+	tokenHasher  *TokenHasher
+	secretLength int
 }
 
 const (
 	TokenPrefix = "lb_"
+	// Default length of the secret after the prefix, in hex characters
 	TokenLength = 32
+
+	// Maximum amount of keys created by one bulk request
+	MaxBulkApiKeys = 100
+	// Usage of a key is stored at most once per this interval per instance
+	LastUsedUpdateInterval = time.Minute
 )
 
+func (s *ApiKeyService) SetTokenHasher(tokenHasher *TokenHasher) {
+	s.tokenHasher = tokenHasher
+}
+
+func (s *ApiKeyService) GetTokenHasher() *TokenHasher {
+	return s.tokenHasher
+}
+
+func (s *ApiKeyService) SetSecretLength(secretLength int) {
+	s.secretLength = normalizeSecretLength(secretLength)
+}
+
+func (s *ApiKeyService) GetSecretLength() int {
+	return s.secretLength
+}
+
 func (s *ApiKeyService) CreateApiKey(
 	projectID uuid.UUID,
 	request *CreateApiKeyRequestDTO,
 	creator *users_models.User,
 ) (*ApiKey, error) {
-	canManage, err := s.projectService.CanUserManageProject(projectID, creator)
+	apiKeys, err := s.createApiKeys(projectID, []CreateApiKeyRequestDTO{*request}, creator)
 	if err != nil {
 		return nil, err
 	}
-	if !canManage {
-		return nil, errors.New("insufficient permissions to create API keys")
-	}
 
-	fullToken, tokenPrefix, tokenHash, err := s.generateSecureToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
-	}
+	return apiKeys[0], nil
+}
 
-	apiKey := &ApiKey{
-		ID:          uuid.New(),
-		Name:        request.Name,
-		ProjectID:   projectID,
-		TokenPrefix: tokenPrefix,
-		TokenHash:   tokenHash,
-		Status:      ApiKeyStatusActive,
+// BulkCreateApiKeys creates all requested keys at once, for provisioning many
+// services. Like for single keys, tokens are returned only in this response
+func (s *ApiKeyService) BulkCreateApiKeys(
+	projectID uuid.UUID,
+	request *BulkCreateApiKeysRequestDTO,
+	creator *users_models.User,
+) (*BulkCreateApiKeysResponseDTO, error) {
+	if len(request.Keys) == 0 {
+		return nil, errors.New("at least one API key is required")
 	}
-
-	if err := s.apiKeyRepository.CreateApiKey(apiKey); err != nil {
-		return nil, fmt.Errorf("failed to create API key: %w", err)
+	if len(request.Keys) > MaxBulkApiKeys {
+		return nil, fmt.Errorf("at most %d API keys can be created at once", MaxBulkApiKeys)
 	}
 
-	// Pre-warm cache with new API key for immediate availability
-	cachedKey := &CachedApiKey{
-		ID:        apiKey.ID,
-		ProjectID: apiKey.ProjectID,
-		Status:    apiKey.Status,
+	apiKeys, err := s.createApiKeys(projectID, request.Keys, creator)
+	if err != nil {
+		return nil, err
 	}
-	s.apiKeyCacheUtil.Set(tokenHash, cachedKey)
 
-	s.auditLogService.WriteAuditLog(
-		fmt.Sprintf("API key created: %s (%s)", request.Name, tokenPrefix),
-		&creator.ID,
-		&projectID,
-	)
-
-	// Set the full token in the response (only returned once)
-	apiKey.Token = fullToken
-
-	return apiKey, nil
+	return &BulkCreateApiKeysResponseDTO{
+		ApiKeys: apiKeys,
+	}, nil
 }
 
 func (s *ApiKeyService) GetProjectApiKeys(
@@ -187,12 +204,95 @@ func (s *ApiKeyService) DeleteApiKey(
 	return nil
 }
 
+func (s *ApiKeyService) createApiKeys(
+	projectID uuid.UUID,
+	requests []CreateApiKeyRequestDTO,
+	creator *users_models.User,
+) ([]*ApiKey, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, creator)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to create API keys")
+	}
+
+	now := time.Now().UTC()
+	for _, request := range requests {
+		if request.Scope != "" && !request.Scope.IsValid() {
+			return nil, fmt.Errorf("invalid API key scope: %s", request.Scope)
+		}
+		if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+			return nil, errors.New("API key expiration must be in the future")
+		}
+	}
+
+	apiKeys := make([]*ApiKey, 0, len(requests))
+	fullTokens := make([]string, 0, len(requests))
+
+	for _, request := range requests {
+		fullToken, tokenPrefix, tokenHash, err := s.generateSecureToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate token: %w", err)
+		}
+
+		scope := request.Scope
+		if scope == "" {
+			scope = ApiKeyScopeIngest
+		}
+
+		var expiresAt *time.Time
+		if request.ExpiresAt != nil {
+			utcExpiresAt := request.ExpiresAt.UTC()
+			expiresAt = &utcExpiresAt
+		}
+
+		apiKeys = append(apiKeys, &ApiKey{
+			ID:            uuid.New(),
+			Name:          request.Name,
+			ProjectID:     projectID,
+			TokenPrefix:   tokenPrefix,
+			TokenHash:     tokenHash,
+			HashAlgorithm: s.tokenHasher.Algorithm(),
+			Status:        ApiKeyStatusActive,
+			Scope:         scope,
+			ExpiresAt:     expiresAt,
+		})
+		fullTokens = append(fullTokens, fullToken)
+	}
+
+	if len(apiKeys) == 1 {
+		err = s.apiKeyRepository.CreateApiKey(apiKeys[0])
+	} else {
+		err = s.apiKeyRepository.CreateApiKeys(apiKeys)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	for i, apiKey := range apiKeys {
+		// Pre-warm cache with new API key for immediate availability
+		s.apiKeyCacheUtil.Set(apiKey.TokenHash, toCachedApiKey(apiKey))
+
+		s.auditLogService.WriteAuditLog(
+			fmt.Sprintf("API key created: %s (%s)", apiKey.Name, apiKey.TokenPrefix),
+			&creator.ID,
+			&projectID,
+		)
+
+		// Set the full token in the response (only returned once)
+		apiKey.Token = fullTokens[i]
+	}
+
+	return apiKeys, nil
+}
+
 func (s *ApiKeyService) ValidateApiKey(token string, projectID uuid.UUID) (*ValidateTokenResponse, error) {
 	if !strings.HasPrefix(token, TokenPrefix) {
 		return &ValidateTokenResponse{IsValid: false}, nil
 	}
 
-	tokenHash := s.hashToken(token)
+	tokenHash := s.tokenHasher.Hash(token)
 
 	// Tier 1: Check cache
 	if cachedKey := s.apiKeyCacheUtil.Get(tokenHash); cachedKey != nil {
@@ -200,6 +300,12 @@ func (s *ApiKeyService) ValidateApiKey(token string, projectID uuid.UUID) (*Vali
 			return &ValidateTokenResponse{IsValid: false}, nil
 		}
 
+		if cachedKey.ExpiresAt != nil && !time.Now().UTC().Before(*cachedKey.ExpiresAt) {
+			return &ValidateTokenResponse{IsValid: false}, nil
+		}
+
+		s.recordApiKeyUsage(cachedKey.ID)
+
 		return &ValidateTokenResponse{
 			IsValid:   true,
 			ApiKeyID:  cachedKey.ID,
@@ -209,7 +315,7 @@ func (s *ApiKeyService) ValidateApiKey(token string, projectID uuid.UUID) (*Vali
 
 	// Tier 2: Database lookup with singleflight protection (prevents thundering herd)
 	result, err, _ := s.singleflight.Do(tokenHash, func() (any, error) {
-		return s.apiKeyRepository.GetApiKeyByTokenHash(tokenHash)
+		return s.findApiKeyByToken(token, tokenHash)
 	})
 
 	if err != nil {
@@ -229,17 +335,13 @@ func (s *ApiKeyService) ValidateApiKey(token string, projectID uuid.UUID) (*Vali
 		return &ValidateTokenResponse{IsValid: false}, fmt.Errorf("failed to cast result to ApiKey")
 	}
 
-	// Verify project matches and is active
-	if apiKey.ProjectID != projectID || apiKey.Status != ApiKeyStatusActive {
+	// Verify project matches, is active and not expired
+	if apiKey.ProjectID != projectID || apiKey.Status != ApiKeyStatusActive || apiKey.IsExpired(time.Now().UTC()) {
 		return &ValidateTokenResponse{IsValid: false}, nil
 	}
 
-	cachedKey := &CachedApiKey{
-		ID:        apiKey.ID,
-		ProjectID: apiKey.ProjectID,
-		Status:    apiKey.Status,
-	}
-	s.apiKeyCacheUtil.Set(tokenHash, cachedKey)
+	s.apiKeyCacheUtil.Set(tokenHash, toCachedApiKey(apiKey))
+	s.recordApiKeyUsage(apiKey.ID)
 
 	return &ValidateTokenResponse{
 		IsValid:   true,
@@ -248,9 +350,63 @@ func (s *ApiKeyService) ValidateApiKey(token string, projectID uuid.UUID) (*Vali
 	}, nil
 }
 
+// recordApiKeyUsage stores when the key was last used. Writes are throttled,
+// so the value shown in listings may lag behind by the update interval
+func (s *ApiKeyService) recordApiKeyUsage(apiKeyID uuid.UUID) {
+	now := time.Now().UTC()
+
+	if lastWrite, ok := s.lastUsedWrites.Load(apiKeyID); ok {
+		if now.Sub(lastWrite.(time.Time)) < LastUsedUpdateInterval {
+			return
+		}
+	}
+	s.lastUsedWrites.Store(apiKeyID, now)
+
+	if err := s.apiKeyRepository.UpdateLastUsedAt(apiKeyID, now); err != nil {
+		s.logger.Warn("Failed to record API key usage", "apiKeyId", apiKeyID.String(), "error", err)
+	}
+}
+
+// findApiKeyByToken looks the key up by the hash of the configured algorithm.
+// Keys stored with plain SHA-256 before a hash secret was configured are still
+// accepted and rehashed, so they migrate on first use
+func (s *ApiKeyService) findApiKeyByToken(token, tokenHash string) (*ApiKey, error) {
+	apiKey, err := s.apiKeyRepository.GetApiKeyByTokenHash(tokenHash)
+	if err == nil {
+		if !s.tokenHasher.Matches(token, apiKey.HashAlgorithm, apiKey.TokenHash) {
+			return nil, gorm.ErrRecordNotFound
+		}
+
+		return apiKey, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) || s.tokenHasher.Algorithm() == ApiKeyHashAlgorithmSHA256 {
+		return nil, err
+	}
+
+	legacyHash := s.tokenHasher.hashWith(ApiKeyHashAlgorithmSHA256, token)
+	apiKey, err = s.apiKeyRepository.GetApiKeyByTokenHash(legacyHash)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.HashAlgorithm != ApiKeyHashAlgorithmSHA256 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := s.apiKeyRepository.UpdateTokenHash(apiKey.ID, tokenHash, s.tokenHasher.Algorithm()); err != nil {
+		s.logger.Warn("Failed to rehash API key", "apiKeyId", apiKey.ID.String(), "error", err)
+	} else {
+		s.apiKeyCacheUtil.Invalidate(legacyHash)
+		apiKey.TokenHash = tokenHash
+		apiKey.HashAlgorithm = s.tokenHasher.Algorithm()
+	}
+
+	return apiKey, nil
+}
+
 func (s *ApiKeyService) generateSecureToken() (fullToken, prefix, hash string, err error) {
 	// Generate random bytes
-	tokenBytes := make([]byte, TokenLength/2) // hex encoding doubles the length
+	tokenBytes := make([]byte, s.secretLength/2) // hex encoding doubles the length
 	if _, err := rand.Read(tokenBytes); err != nil {
 		return "", "", "", err
 	}
@@ -259,13 +415,16 @@ func (s *ApiKeyService) generateSecureToken() (fullToken, prefix, hash string, e
 	tokenSuffix := hex.EncodeToString(tokenBytes)
 	fullToken = TokenPrefix + tokenSuffix
 	prefix = TokenPrefix + tokenSuffix[:6] + "..."
-	hash = s.hashToken(fullToken)
+	hash = s.tokenHasher.Hash(fullToken)
 
 	return fullToken, prefix, hash, nil
 }
 
-func (s *ApiKeyService) hashToken(token string) string {
-	hasher := sha256.New()
-	hasher.Write([]byte(token))
-	return hex.EncodeToString(hasher.Sum(nil))
+func toCachedApiKey(apiKey *ApiKey) *CachedApiKey {
+	return &CachedApiKey{
+		ID:        apiKey.ID,
+		ProjectID: apiKey.ProjectID,
+		Status:    apiKey.Status,
+		ExpiresAt: apiKey.ExpiresAt,
+	}
 }
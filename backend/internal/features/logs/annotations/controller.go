@@ -0,0 +1,173 @@
+package logs_annotations
+
+import (
+	"net/http"
+	"strings"
+
+	users_middleware "logbull/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LogAnnotationController struct {
+	logAnnotationService *LogAnnotationService
+}
+
+func (c *LogAnnotationController) RegisterRoutes(router *gin.RouterGroup) {
+	annotationRoutes := router.Group("/logs/annotations/:projectId")
+
+	annotationRoutes.POST("", c.AnnotateLogs)
+	annotationRoutes.GET("", c.GetAnnotations)
+	annotationRoutes.DELETE("/:logId", c.DeleteAnnotation)
+}
+
+// AnnotateLogs
+// @Summary Mark logs as reviewed
+// @Description Mark one or more logs as reviewed with an optional note. Logs are not modified,
+// @Description an existing annotation of the same log is replaced
+// @Tags logs-annotations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Param request body AnnotateLogsRequestDTO true "Logs to annotate"
+// @Success 200 {object} AnnotationsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/annotations/{projectId} [post]
+func (c *LogAnnotationController) AnnotateLogs(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request AnnotateLogsRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	annotations, err := c.logAnnotationService.AnnotateLogs(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AnnotationsResponseDTO{Annotations: annotations})
+}
+
+// GetAnnotations
+// @Summary Get log annotations
+// @Description Get review annotations of the given logs. Logs without annotation are omitted
+// @Tags logs-annotations
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Param logIds query string true "Comma separated log IDs"
+// @Success 200 {object} AnnotationsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/annotations/{projectId} [get]
+func (c *LogAnnotationController) GetAnnotations(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request GetAnnotationsRequestDTO
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "logIds query parameter is required"})
+		return
+	}
+
+	var logIDs []uuid.UUID
+	for _, logIDStr := range strings.Split(request.LogIDs, ",") {
+		logID, err := uuid.Parse(strings.TrimSpace(logIDStr))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid log ID: " + logIDStr})
+			return
+		}
+		logIDs = append(logIDs, logID)
+	}
+
+	annotations, err := c.logAnnotationService.GetAnnotations(projectID, logIDs, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AnnotationsResponseDTO{Annotations: annotations})
+}
+
+// DeleteAnnotation
+// @Summary Remove log annotation
+// @Description Remove the review annotation of a log
+// @Tags logs-annotations
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Param logId path string true "Log ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/annotations/{projectId}/{logId} [delete]
+func (c *LogAnnotationController) DeleteAnnotation(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	logID, err := uuid.Parse(ctx.Param("logId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid log ID"})
+		return
+	}
+
+	if err := c.logAnnotationService.DeleteAnnotation(projectID, logID, user); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Log annotation removed successfully"})
+}
+
+func (c *LogAnnotationController) handleError(ctx *gin.Context, err error) {
+	if err.Error() == "project not found" {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.HasPrefix(err.Error(), "insufficient permissions") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
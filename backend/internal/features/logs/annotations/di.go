@@ -0,0 +1,26 @@
+package logs_annotations
+
+import (
+	audit_logs "logbull/internal/features/audit_logs"
+	projects_services "logbull/internal/features/projects/services"
+)
+
+var logAnnotationRepository = &LogAnnotationRepository{}
+
+var logAnnotationService = &LogAnnotationService{
+	logAnnotationRepository,
+	projects_services.GetProjectService(),
+	audit_logs.GetAuditLogService(),
+}
+
+var logAnnotationController = &LogAnnotationController{
+	logAnnotationService,
+}
+
+func GetLogAnnotationService() *LogAnnotationService {
+	return logAnnotationService
+}
+
+func GetLogAnnotationController() *LogAnnotationController {
+	return logAnnotationController
+}
@@ -0,0 +1,17 @@
+package logs_annotations
+
+import "github.com/google/uuid"
+
+type AnnotateLogsRequestDTO struct {
+	LogIDs []uuid.UUID `json:"logIds" binding:"required,min=1,max=500"`
+	Note   string      `json:"note"   binding:"max=1000"`
+}
+
+type GetAnnotationsRequestDTO struct {
+	// Comma separated log IDs
+	LogIDs string `form:"logIds" binding:"required"`
+}
+
+type AnnotationsResponseDTO struct {
+	Annotations []*LogAnnotation `json:"annotations"`
+}
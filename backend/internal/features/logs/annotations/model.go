@@ -0,0 +1,22 @@
+package logs_annotations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogAnnotation marks a stored log as reviewed without touching the log itself.
+// There is at most one annotation per log, re-annotating replaces it.
+type LogAnnotation struct {
+	ID         uuid.UUID  `json:"id"         gorm:"column:id"`
+	ProjectID  uuid.UUID  `json:"projectId"  gorm:"column:project_id"`
+	LogID      uuid.UUID  `json:"logId"      gorm:"column:log_id"`
+	Note       string     `json:"note"       gorm:"column:note"`
+	ReviewerID *uuid.UUID `json:"reviewerId" gorm:"column:reviewer_id"`
+	ReviewedAt time.Time  `json:"reviewedAt" gorm:"column:reviewed_at"`
+}
+
+func (LogAnnotation) TableName() string {
+	return "log_annotations"
+}
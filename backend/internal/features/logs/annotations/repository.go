@@ -0,0 +1,61 @@
+package logs_annotations
+
+import (
+	"time"
+
+	"logbull/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type LogAnnotationRepository struct{}
+
+// UpsertAnnotations creates annotations or replaces existing ones for the same
+// project and log
+func (r *LogAnnotationRepository) UpsertAnnotations(annotations []*LogAnnotation) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	for _, annotation := range annotations {
+		if annotation.ID == uuid.Nil {
+			annotation.ID = uuid.New()
+		}
+
+		if annotation.ReviewedAt.IsZero() {
+			annotation.ReviewedAt = time.Now().UTC()
+		}
+	}
+
+	return storage.GetDb().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}, {Name: "log_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"note", "reviewer_id", "reviewed_at"}),
+		}).
+		Create(&annotations).Error
+}
+
+func (r *LogAnnotationRepository) GetAnnotationsByLogIDs(
+	projectID uuid.UUID,
+	logIDs []uuid.UUID,
+) ([]*LogAnnotation, error) {
+	var annotations []*LogAnnotation
+
+	if len(logIDs) == 0 {
+		return annotations, nil
+	}
+
+	err := storage.GetDb().
+		Where("project_id = ? AND log_id IN ?", projectID, logIDs).
+		Order("reviewed_at DESC").
+		Find(&annotations).Error
+
+	return annotations, err
+}
+
+func (r *LogAnnotationRepository) DeleteAnnotation(projectID, logID uuid.UUID) error {
+	return storage.GetDb().
+		Where("project_id = ? AND log_id = ?", projectID, logID).
+		Delete(&LogAnnotation{}).Error
+}
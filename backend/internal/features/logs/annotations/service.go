@@ -0,0 +1,150 @@
+package logs_annotations
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	projects_services "logbull/internal/features/projects/services"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/google/uuid"
+)
+
+const maxAnnotationsPerRequest = 500
+
+type LogAnnotationService struct {
+	annotationRepository *LogAnnotationRepository
+	projectService       *projects_services.ProjectService
+	auditLogService      *audit_logs.AuditLogService
+}
+
+// AnnotateLogs marks logs as reviewed by the user. Any project member may
+// annotate, since reviewing logs is part of regular incident triage
+func (s *LogAnnotationService) AnnotateLogs(
+	projectID uuid.UUID,
+	request *AnnotateLogsRequestDTO,
+	user *users_models.User,
+) ([]*LogAnnotation, error) {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to annotate project logs")
+	}
+
+	logIDs := s.uniqueLogIDs(request.LogIDs)
+	if len(logIDs) == 0 {
+		return nil, errors.New("at least one log ID is required")
+	}
+	if len(logIDs) > maxAnnotationsPerRequest {
+		return nil, fmt.Errorf("cannot annotate more than %d logs at once", maxAnnotationsPerRequest)
+	}
+
+	note := strings.TrimSpace(request.Note)
+	annotations := make([]*LogAnnotation, 0, len(logIDs))
+	for _, logID := range logIDs {
+		annotations = append(annotations, &LogAnnotation{
+			ProjectID:  projectID,
+			LogID:      logID,
+			Note:       note,
+			ReviewerID: &user.ID,
+		})
+	}
+
+	if err := s.annotationRepository.UpsertAnnotations(annotations); err != nil {
+		return nil, fmt.Errorf("failed to save log annotations: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Logs marked as reviewed: %d logs", len(logIDs)),
+		&user.ID,
+		&projectID,
+	)
+
+	// Re-read so existing annotations come back with their original IDs
+	return s.annotationRepository.GetAnnotationsByLogIDs(projectID, logIDs)
+}
+
+func (s *LogAnnotationService) GetAnnotations(
+	projectID uuid.UUID,
+	logIDs []uuid.UUID,
+	user *users_models.User,
+) ([]*LogAnnotation, error) {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view project log annotations")
+	}
+
+	if len(logIDs) > maxAnnotationsPerRequest {
+		return nil, fmt.Errorf("cannot get more than %d annotations at once", maxAnnotationsPerRequest)
+	}
+
+	return s.annotationRepository.GetAnnotationsByLogIDs(projectID, s.uniqueLogIDs(logIDs))
+}
+
+func (s *LogAnnotationService) DeleteAnnotation(
+	projectID uuid.UUID,
+	logID uuid.UUID,
+	user *users_models.User,
+) error {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return errors.New("insufficient permissions to annotate project logs")
+	}
+
+	if err := s.annotationRepository.DeleteAnnotation(projectID, logID); err != nil {
+		return fmt.Errorf("failed to delete log annotation: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Log review mark removed: %s", logID.String()),
+		&user.ID,
+		&projectID,
+	)
+
+	return nil
+}
+
+// GetAnnotationsByLogIDs returns annotations keyed by log ID without checking
+// permissions, callers must verify project access themselves
+func (s *LogAnnotationService) GetAnnotationsByLogIDs(
+	projectID uuid.UUID,
+	logIDs []uuid.UUID,
+) (map[uuid.UUID]*LogAnnotation, error) {
+	annotations, err := s.annotationRepository.GetAnnotationsByLogIDs(projectID, logIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	annotationsByLogID := make(map[uuid.UUID]*LogAnnotation, len(annotations))
+	for _, annotation := range annotations {
+		annotationsByLogID[annotation.LogID] = annotation
+	}
+
+	return annotationsByLogID, nil
+}
+
+func (s *LogAnnotationService) uniqueLogIDs(logIDs []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(logIDs))
+	unique := make([]uuid.UUID, 0, len(logIDs))
+
+	for _, logID := range logIDs {
+		if logID == uuid.Nil || seen[logID] {
+			continue
+		}
+
+		seen[logID] = true
+		unique = append(unique, logID)
+	}
+
+	return unique
+}
@@ -0,0 +1,68 @@
+package logs_attachments
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AttachmentController struct {
+	attachmentService *AttachmentService
+}
+
+func (c *AttachmentController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/logs/attachments/:projectId/:hash", c.GetAttachment)
+}
+
+// GetAttachment
+// @Summary Get log attachment
+// @Description Get the full value of a log field which was offloaded because it exceeded the project
+// @Description attachment threshold. The field keeps a reference "attachment:sha256:<hash>" in the log.
+// @Tags logs
+// @Produce plain
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param hash path string true "SHA-256 hash from the attachment reference"
+// @Success 200 {string} string "Attachment content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/attachments/{projectId}/{hash} [get]
+func (c *AttachmentController) GetAttachment(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	data, err := c.attachmentService.GetAttachment(projectID, ctx.Param("hash"), user)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrAttachmentNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "project not found"):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		case strings.Contains(err.Error(), "insufficient permissions"):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "invalid attachment reference"):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get attachment"})
+		}
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", data)
+}
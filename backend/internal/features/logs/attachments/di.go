@@ -0,0 +1,61 @@
+package logs_attachments
+
+import (
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"logbull/internal/config"
+	projects_services "logbull/internal/features/projects/services"
+	s3_utils "logbull/internal/util/s3"
+)
+
+var attachmentService = &AttachmentService{
+	newAttachmentStorageFromConfig(),
+	projects_services.GetProjectService(),
+}
+
+var attachmentController = &AttachmentController{
+	attachmentService,
+}
+
+func GetAttachmentService() *AttachmentService {
+	return attachmentService
+}
+
+func GetAttachmentController() *AttachmentController {
+	return attachmentController
+}
+
+func SetupDependencies() {
+	projects_services.GetProjectService().AddProjectDeletionListener(attachmentService)
+}
+
+// newAttachmentStorageFromConfig keeps attachments in the object storage when
+// it is configured and on the local disk otherwise
+func newAttachmentStorageFromConfig() AttachmentStorage {
+	env := config.GetEnv()
+	if env.AttachmentsS3Endpoint == "" {
+		return NewFileAttachmentStorage(getStoragePathFromConfig())
+	}
+
+	return NewS3AttachmentStorage(
+		s3_utils.NewClient(
+			&http.Client{Timeout: time.Minute},
+			env.AttachmentsS3Endpoint,
+			env.AttachmentsS3Region,
+			env.AttachmentsS3AccessKeyID,
+			env.AttachmentsS3SecretAccessKey,
+		),
+		env.AttachmentsS3Bucket,
+	)
+}
+
+func getStoragePathFromConfig() string {
+	storagePath := config.GetEnv().AttachmentsStoragePath
+	if storagePath == "" {
+		return filepath.Join(config.GetEnv().BackendRootPath, "attachments-data")
+	}
+
+	return storagePath
+}
@@ -0,0 +1,101 @@
+package logs_attachments
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	projects_services "logbull/internal/features/projects/services"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ReferencePrefix starts the value kept in place of an offloaded field
+	ReferencePrefix = "attachment:sha256:"
+
+	// Values above this size are left inline and rejected by the log size limit
+	MaxAttachmentSizeBytes = 5 * 1024 * 1024
+)
+
+var hashPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+type AttachmentService struct {
+	attachmentStorage AttachmentStorage
+	projectService    *projects_services.ProjectService
+}
+
+// OffloadLargeFields moves string fields longer than thresholdBytes to the
+// attachment storage and replaces them with a reference to the stored value
+func (s *AttachmentService) OffloadLargeFields(
+	projectID uuid.UUID,
+	thresholdBytes int,
+	fields map[string]any,
+) error {
+	if thresholdBytes <= 0 {
+		return nil
+	}
+
+	for fieldName, fieldValue := range fields {
+		value, ok := fieldValue.(string)
+		if !ok || len(value) <= thresholdBytes || len(value) > MaxAttachmentSizeBytes {
+			continue
+		}
+
+		checksum := sha256.Sum256([]byte(value))
+		hash := hex.EncodeToString(checksum[:])
+
+		if err := s.attachmentStorage.Put(projectID, hash, []byte(value)); err != nil {
+			return fmt.Errorf("failed to offload field %s: %w", fieldName, err)
+		}
+
+		fields[fieldName] = ReferencePrefix + hash
+	}
+
+	return nil
+}
+
+// GetAttachment returns an offloaded value by its hash or by the reference
+// stored in the log
+func (s *AttachmentService) GetAttachment(
+	projectID uuid.UUID,
+	reference string,
+	user *users_models.User,
+) ([]byte, error) {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view project attachments")
+	}
+
+	hash := strings.TrimPrefix(reference, ReferencePrefix)
+	if !hashPattern.MatchString(hash) {
+		return nil, errors.New("invalid attachment reference")
+	}
+
+	return s.attachmentStorage.Get(projectID, hash)
+}
+
+func (s *AttachmentService) SetAttachmentStorage(attachmentStorage AttachmentStorage) {
+	s.attachmentStorage = attachmentStorage
+}
+
+func (s *AttachmentService) GetAttachmentStorage() AttachmentStorage {
+	return s.attachmentStorage
+}
+
+// DeleteProjectAttachments removes every stored value of the project, logs
+// referencing them cannot be resolved afterwards
+func (s *AttachmentService) DeleteProjectAttachments(projectID uuid.UUID) error {
+	return s.attachmentStorage.DeleteProject(projectID)
+}
+
+func (s *AttachmentService) OnBeforeProjectDeletion(projectID uuid.UUID) error {
+	return s.DeleteProjectAttachments(projectID)
+}
@@ -0,0 +1,138 @@
+package logs_attachments
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	s3_utils "logbull/internal/util/s3"
+
+	"github.com/google/uuid"
+)
+
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// AttachmentStorage keeps offloaded field values. Attachments are content
+// addressed, so storing the same value twice is a no-op
+type AttachmentStorage interface {
+	Put(projectID uuid.UUID, hash string, data []byte) error
+	Get(projectID uuid.UUID, hash string) ([]byte, error)
+	DeleteProject(projectID uuid.UUID) error
+}
+
+// FileAttachmentStorage stores attachments as files under
+// <rootPath>/<projectID>/<hash>
+type FileAttachmentStorage struct {
+	rootPath string
+}
+
+func NewFileAttachmentStorage(rootPath string) *FileAttachmentStorage {
+	return &FileAttachmentStorage{rootPath: rootPath}
+}
+
+func (s *FileAttachmentStorage) Put(projectID uuid.UUID, hash string, data []byte) error {
+	projectPath := filepath.Join(s.rootPath, projectID.String())
+	filePath := filepath.Join(projectPath, hash)
+
+	if _, err := os.Stat(filePath); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(projectPath, 0o750); err != nil {
+		return fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see partial content
+	tempFile, err := os.CreateTemp(projectPath, hash+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create attachment file: %w", err)
+	}
+
+	if _, err := tempFile.Write(data); err != nil {
+		_ = tempFile.Close()
+		_ = os.Remove(tempFile.Name())
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		_ = os.Remove(tempFile.Name())
+		return fmt.Errorf("failed to close attachment file: %w", err)
+	}
+
+	if err := os.Rename(tempFile.Name(), filePath); err != nil {
+		_ = os.Remove(tempFile.Name())
+		return fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileAttachmentStorage) Get(projectID uuid.UUID, hash string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.rootPath, projectID.String(), hash))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrAttachmentNotFound
+		}
+
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *FileAttachmentStorage) DeleteProject(projectID uuid.UUID) error {
+	return os.RemoveAll(filepath.Join(s.rootPath, projectID.String()))
+}
+
+// S3AttachmentStorage stores attachments as <projectID>/<hash> objects of an
+// S3 compatible bucket, so every backend instance can serve them
+type S3AttachmentStorage struct {
+	client *s3_utils.Client
+	bucket string
+}
+
+func NewS3AttachmentStorage(client *s3_utils.Client, bucket string) *S3AttachmentStorage {
+	return &S3AttachmentStorage{client: client, bucket: bucket}
+}
+
+func (s *S3AttachmentStorage) Put(projectID uuid.UUID, hash string, data []byte) error {
+	// The key is the hash of the content, overwriting it stores the same bytes
+	if err := s.client.PutObject(s.bucket, attachmentKey(projectID, hash), "text/plain", data); err != nil {
+		return fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3AttachmentStorage) Get(projectID uuid.UUID, hash string) ([]byte, error) {
+	data, err := s.client.GetObject(s.bucket, attachmentKey(projectID, hash))
+	if err != nil {
+		if errors.Is(err, s3_utils.ErrObjectNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *S3AttachmentStorage) DeleteProject(projectID uuid.UUID) error {
+	keys, err := s.client.ListObjectKeys(s.bucket, projectID.String()+"/")
+	if err != nil {
+		return fmt.Errorf("failed to list project attachments: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := s.client.DeleteObject(s.bucket, key); err != nil {
+			return fmt.Errorf("failed to delete attachment %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func attachmentKey(projectID uuid.UUID, hash string) string {
+	return projectID.String() + "/" + hash
+}
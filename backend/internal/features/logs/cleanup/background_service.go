@@ -2,9 +2,11 @@ package logs_cleanup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"logbull/internal/config"
@@ -19,6 +21,12 @@ type LogCleanupBackgroundService struct {
 	logCoreRepository *logs_core.LogCoreRepository
 	projectService    *projects_services.ProjectService
 	logger            *slog.Logger
+	cleanupNotifier   *CleanupNotifier
+	cleanupMetrics    *CleanupMetrics
+	// projects cleaned up at the same time, and the pause of each worker between
+	// its projects, so cleanup of many projects does not overload OpenSearch
+	concurrency  int
+	projectDelay time.Duration
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -28,14 +36,36 @@ type LogCleanupBackgroundService struct {
 const (
 	quotaEnforcementInterval = 1 * time.Minute
 	retentionCleanupInterval = 1 * time.Minute
+
+	// Projects are cleaned up one by one unless CLEANUP_CONCURRENCY raises it
+	DefaultCleanupConcurrency = 1
+	MaxCleanupConcurrency     = 32
 )
 
+func (s *LogCleanupBackgroundService) SetConcurrency(concurrency int) {
+	s.concurrency = min(max(concurrency, 1), MaxCleanupConcurrency)
+}
+
+func (s *LogCleanupBackgroundService) GetConcurrency() int {
+	return s.concurrency
+}
+
+func (s *LogCleanupBackgroundService) SetProjectDelay(projectDelay time.Duration) {
+	s.projectDelay = max(projectDelay, 0)
+}
+
+func (s *LogCleanupBackgroundService) GetProjectDelay() time.Duration {
+	return s.projectDelay
+}
+
 func (s *LogCleanupBackgroundService) StartWorkers() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 
 	s.logger.Info("Starting log cleanup background workers",
 		slog.Duration("quotaInterval", quotaEnforcementInterval),
-		slog.Duration("retentionInterval", retentionCleanupInterval))
+		slog.Duration("retentionInterval", retentionCleanupInterval),
+		slog.Int("concurrency", s.concurrency),
+		slog.Duration("projectDelay", s.projectDelay))
 
 	s.wg.Add(2)
 	go s.quotaEnforcerWorker()
@@ -45,12 +75,18 @@ func (s *LogCleanupBackgroundService) StartWorkers() {
 }
 
 func (s *LogCleanupBackgroundService) ExecuteAllTasksForTest() error {
-	if err := s.enforceAllProjectQuotas(); err != nil {
+	return s.ExecuteAllTasksForTestAt(time.Now().UTC())
+}
+
+// ExecuteAllTasksForTestAt runs cleanup as if it was the given time, so tests
+// can pass the cleanup notice window without waiting for it
+func (s *LogCleanupBackgroundService) ExecuteAllTasksForTestAt(now time.Time) error {
+	if err := s.enforceAllProjectQuotas(now); err != nil {
 		s.logger.Error("Error during quota enforcement in test execution", slog.String("error", err.Error()))
 		return err
 	}
 
-	if err := s.enforceAllProjectsRetention(); err != nil {
+	if err := s.enforceAllProjectsRetention(now); err != nil {
 		s.logger.Error("Error during retention cleanup in test execution", slog.String("error", err.Error()))
 		return err
 	}
@@ -79,7 +115,7 @@ func (s *LogCleanupBackgroundService) quotaEnforcerWorker() {
 			return
 
 		case <-ticker.C:
-			if err := s.enforceAllProjectQuotas(); err != nil {
+			if err := s.enforceAllProjectQuotas(time.Now().UTC()); err != nil {
 				s.logger.Error("Error during quota enforcement", slog.String("error", err.Error()))
 			}
 		}
@@ -107,14 +143,14 @@ func (s *LogCleanupBackgroundService) retentionWorker() {
 			return
 
 		case <-ticker.C:
-			if err := s.enforceAllProjectsRetention(); err != nil {
+			if err := s.enforceAllProjectsRetention(time.Now().UTC()); err != nil {
 				s.logger.Error("Error during retention cleanup", slog.String("error", err.Error()))
 			}
 		}
 	}
 }
 
-func (s *LogCleanupBackgroundService) enforceAllProjectQuotas() error {
+func (s *LogCleanupBackgroundService) enforceAllProjectQuotas(now time.Time) error {
 	projects, err := s.projectService.GetAllProjects()
 	if err != nil {
 		return fmt.Errorf("failed to get all projects: %w", err)
@@ -122,18 +158,17 @@ func (s *LogCleanupBackgroundService) enforceAllProjectQuotas() error {
 
 	s.logger.Info(fmt.Sprintf("Enforcing quota for %d projects", len(projects)))
 
-	quotaViolations := 0
-	processedProjects := 0
-
-	for _, project := range projects {
-		if err := s.enforceProjectQuotas(project.ID, project); err != nil {
-			quotaViolations++
+	processedProjects := len(projects)
+	quotaViolations := s.forEachProject(projects, func(project *projects_models.Project) error {
+		if err := s.enforceProjectQuotas(project.ID, project, now); err != nil {
 			s.logger.Error("Failed to enforce quotas for project",
 				slog.String("projectId", project.ID.String()),
 				slog.String("error", err.Error()))
+			return err
 		}
-		processedProjects++
-	}
+
+		return nil
+	})
 
 	s.logger.Info("Quota enforcement completed",
 		slog.Int("processedProjects", processedProjects),
@@ -146,34 +181,46 @@ func (s *LogCleanupBackgroundService) enforceAllProjectQuotas() error {
 	return nil
 }
 
-func (s *LogCleanupBackgroundService) enforceAllProjectsRetention() error {
+func (s *LogCleanupBackgroundService) enforceAllProjectsRetention(now time.Time) error {
 	projects, err := s.projectService.GetAllProjects()
 	if err != nil {
 		return fmt.Errorf("failed to get all projects: %w", err)
 	}
 
-	cleanupFailures := 0
-	processedProjects := 0
-	totalCleaned := 0
+	var totalCleaned atomic.Int64
+
+	processedProjects := len(projects)
+	cleanupFailures := s.forEachProject(projects, func(project *projects_models.Project) error {
+		// Retention runs first and regardless of archiving, so logs past their
+		// lifetime are not copied to the archive just to be deleted, and a failing
+		// archive never keeps them beyond it
+		var retentionErr error
+		if project.MaxLogsLifeDays <= 0 {
+			s.cleanupNotifier.ClearScheduledDeletion(project.ID, CleanupReasonRetention)
+		} else if retentionErr = s.enforceLogRetention(project, now); retentionErr != nil {
+			s.logger.Error("Failed to enforce retention for project",
+				slog.String("projectId", project.ID.String()),
+				slog.String("error", retentionErr.Error()))
+		} else {
+			totalCleaned.Add(1)
+		}
 
-	for _, project := range projects {
-		if project.MaxLogsLifeDays > 0 {
-			if err := s.enforceLogRetention(project.ID, project.MaxLogsLifeDays); err != nil {
-				cleanupFailures++
-				s.logger.Error("Failed to enforce retention for project",
+		var archiveErr error
+		if project.ArchiveAfterDays > 0 {
+			if archiveErr = s.archiveOldLogs(project, now); archiveErr != nil {
+				s.logger.Error("Failed to archive logs for project",
 					slog.String("projectId", project.ID.String()),
-					slog.String("error", err.Error()))
-			} else {
-				totalCleaned++
+					slog.String("error", archiveErr.Error()))
 			}
 		}
-		processedProjects++
-	}
+
+		return errors.Join(retentionErr, archiveErr)
+	})
 
 	s.logger.Info("Retention cleanup completed",
 		slog.Int("processedProjects", processedProjects),
 		slog.Int("cleanupFailures", cleanupFailures),
-		slog.Int("projectsCleaned", totalCleaned))
+		slog.Int64("projectsCleaned", totalCleaned.Load()))
 
 	if cleanupFailures > 0 {
 		return fmt.Errorf("retention cleanup failed for %d projects", cleanupFailures)
@@ -182,12 +229,72 @@ func (s *LogCleanupBackgroundService) enforceAllProjectsRetention() error {
 	return nil
 }
 
+// forEachProject runs process for every project on up to concurrency workers, each
+// pausing projectDelay between its projects. Workers stop taking projects once the
+// service is stopped. Returns how many projects failed
+func (s *LogCleanupBackgroundService) forEachProject(
+	projects []*projects_models.Project,
+	process func(project *projects_models.Project) error,
+) int {
+	projectsQueue := make(chan *projects_models.Project, len(projects))
+	for _, project := range projects {
+		projectsQueue <- project
+	}
+	close(projectsQueue)
+
+	var failures atomic.Int64
+	var workersWg sync.WaitGroup
+
+	workersCount := min(max(s.concurrency, 1), len(projects))
+	for range workersCount {
+		workersWg.Add(1)
+
+		go func() {
+			defer workersWg.Done()
+
+			isFirstProject := true
+			for project := range projectsQueue {
+				if !isFirstProject && !s.waitProjectDelay() {
+					return
+				}
+				isFirstProject = false
+
+				if err := process(project); err != nil {
+					failures.Add(1)
+				}
+			}
+		}()
+	}
+
+	workersWg.Wait()
+	return int(failures.Load())
+}
+
+// waitProjectDelay pauses between projects, returns false when the service was
+// stopped meanwhile
+func (s *LogCleanupBackgroundService) waitProjectDelay() bool {
+	if s.projectDelay <= 0 {
+		return true
+	}
+
+	if s.ctx == nil {
+		time.Sleep(s.projectDelay)
+		return true
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return false
+	case <-time.After(s.projectDelay):
+		return true
+	}
+}
+
 func (s *LogCleanupBackgroundService) enforceProjectQuotas(
 	projectID uuid.UUID,
 	project *projects_models.Project,
+	now time.Time,
 ) error {
-	cleanupPercentage := s.calculateCleanupPercentage(project.MaxLogsSizeMB)
-
 	stats, err := s.logCoreRepository.GetProjectLogStats(projectID)
 	if err != nil {
 		return fmt.Errorf("failed to get project log stats: %w", err)
@@ -195,52 +302,46 @@ func (s *LogCleanupBackgroundService) enforceProjectQuotas(
 
 	quotaViolated := false
 
-	if project.MaxLogsAmount > 0 && stats.TotalLogs > project.MaxLogsAmount {
+	if cutoffTime, isExceeded := s.calculateCountQuotaCutoff(project, stats, now); isExceeded {
 		s.logger.Info("Project exceeds log count quota, cleanup needed",
 			slog.String("projectId", projectID.String()),
 			slog.Int64("currentLogs", stats.TotalLogs),
 			slog.Int64("maxLogs", project.MaxLogsAmount))
 
-		targetLogs := int64(float64(project.MaxLogsAmount) * cleanupPercentage)
-		logsToDelete := stats.TotalLogs - targetLogs
-
-		if logsToDelete > 0 {
-			cutoffTime := s.calculateCutoffTimeForLogCount(logsToDelete, stats)
-			if err := s.logCoreRepository.DeleteOldLogs(projectID, cutoffTime); err != nil {
-				s.logger.Error("Failed to delete old logs for count quota",
-					slog.String("projectId", projectID.String()),
-					slog.String("error", err.Error()))
-				quotaViolated = true
-			} else {
-				s.logger.Info("Deleted logs to enforce count quota",
-					slog.String("projectId", projectID.String()),
-					slog.Int64("deletedLogs", logsToDelete))
-			}
+		isDeleted, err := s.deleteOldLogsWhenDue(project, CleanupReasonCountQuota, cutoffTime, now)
+		if err != nil {
+			s.logger.Error("Failed to delete old logs for count quota",
+				slog.String("projectId", projectID.String()),
+				slog.String("error", err.Error()))
+			quotaViolated = true
+		} else if isDeleted {
+			s.logger.Info("Deleted logs to enforce count quota",
+				slog.String("projectId", projectID.String()),
+				slog.Time("deletedOlderThan", cutoffTime))
 		}
+	} else {
+		s.cleanupNotifier.ClearScheduledDeletion(projectID, CleanupReasonCountQuota)
 	}
 
-	if project.MaxLogsSizeMB > 0 && stats.TotalSizeMB > float64(project.MaxLogsSizeMB) {
+	if cutoffTime, isExceeded := s.calculateSizeQuotaCutoff(project, stats, now); isExceeded {
 		s.logger.Info("Project exceeds storage size quota, cleanup needed",
 			slog.String("projectId", projectID.String()),
 			slog.Float64("currentSizeMB", stats.TotalSizeMB),
 			slog.Int("maxSizeMB", project.MaxLogsSizeMB))
 
-		targetSizeMB := float64(project.MaxLogsSizeMB) * cleanupPercentage
-		excessSizeMB := stats.TotalSizeMB - targetSizeMB
-
-		if excessSizeMB > 0 {
-			cutoffTime := s.calculateCutoffTimeForSize(excessSizeMB, stats)
-			if err := s.logCoreRepository.DeleteOldLogs(projectID, cutoffTime); err != nil {
-				s.logger.Error("Failed to delete old logs for size quota",
-					slog.String("projectId", projectID.String()),
-					slog.String("error", err.Error()))
-				quotaViolated = true
-			} else {
-				s.logger.Info("Deleted logs to enforce size quota",
-					slog.String("projectId", projectID.String()),
-					slog.Float64("freedSizeMB", excessSizeMB))
-			}
+		isDeleted, err := s.deleteOldLogsWhenDue(project, CleanupReasonSizeQuota, cutoffTime, now)
+		if err != nil {
+			s.logger.Error("Failed to delete old logs for size quota",
+				slog.String("projectId", projectID.String()),
+				slog.String("error", err.Error()))
+			quotaViolated = true
+		} else if isDeleted {
+			s.logger.Info("Deleted logs to enforce size quota",
+				slog.String("projectId", projectID.String()),
+				slog.Time("deletedOlderThan", cutoffTime))
 		}
+	} else {
+		s.cleanupNotifier.ClearScheduledDeletion(projectID, CleanupReasonSizeQuota)
 	}
 
 	if quotaViolated {
@@ -250,32 +351,200 @@ func (s *LogCleanupBackgroundService) enforceProjectQuotas(
 	return nil
 }
 
-func (s *LogCleanupBackgroundService) enforceLogRetention(projectID uuid.UUID, maxLifeDays int) error {
-	if maxLifeDays <= 0 {
+func (s *LogCleanupBackgroundService) enforceLogRetention(project *projects_models.Project, now time.Time) error {
+	if project.MaxLogsLifeDays <= 0 {
 		return nil
 	}
 
-	cutoffTime := time.Now().UTC().AddDate(0, 0, -maxLifeDays)
+	cutoffTime, _ := s.calculateRetentionCutoff(project, now)
 
-	err := s.logCoreRepository.DeleteOldLogs(projectID, cutoffTime)
-	if err != nil {
+	if _, err := s.deleteOldLogsWhenDue(project, CleanupReasonRetention, cutoffTime, now); err != nil {
 		return fmt.Errorf("failed to delete old logs: %w", err)
 	}
 
 	return nil
 }
 
+// archiveOldLogs moves logs past the searchable period of the project to the
+// archived tier. Logs are kept, so archiving does not wait for a cleanup notice
+func (s *LogCleanupBackgroundService) archiveOldLogs(project *projects_models.Project, now time.Time) error {
+	cutoffTime := now.AddDate(0, 0, -project.ArchiveAfterDays)
+
+	archivedLogs, err := s.logCoreRepository.ArchiveOldLogs(project.ID, cutoffTime)
+	if err != nil {
+		return fmt.Errorf("failed to archive old logs: %w", err)
+	}
+
+	if archivedLogs > 0 {
+		s.logger.Info("Archived old logs",
+			slog.String("projectId", project.ID.String()),
+			slog.Int64("archivedLogs", archivedLogs),
+			slog.Time("archivedOlderThan", cutoffTime))
+	}
+
+	return nil
+}
+
+// deleteOldLogsWhenDue deletes logs older than the cutoff. With the cleanup notice
+// enabled, the project webhook is notified first and the logs are deleted only
+// once the notice window has passed. Returns whether the logs were deleted
+func (s *LogCleanupBackgroundService) deleteOldLogsWhenDue(
+	project *projects_models.Project,
+	reason CleanupReason,
+	cutoffTime time.Time,
+	now time.Time,
+) (bool, error) {
+	if project.CleanupNoticeMinutes > 0 {
+		isDue, err := s.isNoticedDeletionDue(project, reason, cutoffTime, now)
+		if err != nil || !isDue {
+			return false, err
+		}
+	}
+
+	// Deletion runs in the background on OpenSearch, so what it deletes is
+	// measured right before it starts
+	deletedStats, err := s.logCoreRepository.GetProjectLogStatsOlderThan(project.ID, cutoffTime)
+	if err != nil {
+		s.logger.Warn("Failed to measure logs to delete for cleanup metrics",
+			slog.String("projectId", project.ID.String()),
+			slog.String("reason", string(reason)),
+			slog.String("error", err.Error()))
+	}
+
+	if err := s.logCoreRepository.DeleteOldLogs(project.ID, cutoffTime); err != nil {
+		return false, err
+	}
+
+	if deletedStats != nil && deletedStats.TotalLogs > 0 {
+		s.cleanupMetrics.RecordDeletion(project.ID, reason, deletedStats, now)
+	}
+
+	return true, nil
+}
+
+func (s *LogCleanupBackgroundService) isNoticedDeletionDue(
+	project *projects_models.Project,
+	reason CleanupReason,
+	cutoffTime time.Time,
+	now time.Time,
+) (bool, error) {
+	if scheduledAt, isScheduled := s.cleanupNotifier.GetScheduledDeletion(project.ID, reason); isScheduled {
+		if now.Before(scheduledAt) {
+			return false, nil
+		}
+
+		s.cleanupNotifier.ClearScheduledDeletion(project.ID, reason)
+		return true, nil
+	}
+
+	logsToDelete, err := s.countLogsOlderThan(project.ID, cutoffTime)
+	if err != nil {
+		return false, err
+	}
+	if logsToDelete == 0 {
+		return false, nil
+	}
+
+	notice := &CleanupNoticeDTO{
+		ProjectID:       project.ID,
+		ProjectName:     project.Name,
+		Reason:          reason,
+		LogsToDelete:    logsToDelete,
+		DeleteOlderThan: cutoffTime,
+		ScheduledAt:     now.Add(time.Duration(project.CleanupNoticeMinutes) * time.Minute),
+	}
+	s.cleanupNotifier.ScheduleDeletion(project.CleanupWebhookURL, notice)
+
+	s.logger.Info("Scheduled logs deletion after cleanup notice",
+		slog.String("projectId", project.ID.String()),
+		slog.String("reason", string(reason)),
+		slog.Int64("logsToDelete", logsToDelete),
+		slog.Time("scheduledAt", notice.ScheduledAt))
+
+	return false, nil
+}
+
+func (s *LogCleanupBackgroundService) countLogsOlderThan(projectID uuid.UUID, cutoffTime time.Time) (int64, error) {
+	response, err := s.logCoreRepository.ExecuteQueryForProject(projectID, &logs_core.LogQueryRequestDTO{
+		TimeRange:       &logs_core.TimeRangeDTO{To: &cutoffTime},
+		Limit:           1,
+		TrackTotal:      true,
+		IncludeArchived: true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count logs to delete: %w", err)
+	}
+
+	return response.Total, nil
+}
+
+// calculateCountQuotaCutoff returns the time logs older than which the count quota
+// cleanup deletes, and whether the project is over its count quota at all
+func (s *LogCleanupBackgroundService) calculateCountQuotaCutoff(
+	project *projects_models.Project,
+	stats *logs_core.ProjectLogStats,
+	now time.Time,
+) (time.Time, bool) {
+	if project.MaxLogsAmount <= 0 || stats.TotalLogs <= project.MaxLogsAmount {
+		return time.Time{}, false
+	}
+
+	cleanupPercentage := s.calculateCleanupPercentage(project.MaxLogsSizeMB)
+	targetLogs := int64(float64(project.MaxLogsAmount) * cleanupPercentage)
+	logsToDelete := stats.TotalLogs - targetLogs
+	if logsToDelete <= 0 {
+		return time.Time{}, false
+	}
+
+	return s.calculateCutoffTimeForLogCount(logsToDelete, stats, now), true
+}
+
+// calculateSizeQuotaCutoff returns the time logs older than which the size quota
+// cleanup deletes, and whether the project is over its size quota at all
+func (s *LogCleanupBackgroundService) calculateSizeQuotaCutoff(
+	project *projects_models.Project,
+	stats *logs_core.ProjectLogStats,
+	now time.Time,
+) (time.Time, bool) {
+	if project.MaxLogsSizeMB <= 0 || stats.TotalSizeMB <= float64(project.MaxLogsSizeMB) {
+		return time.Time{}, false
+	}
+
+	cleanupPercentage := s.calculateCleanupPercentage(project.MaxLogsSizeMB)
+	targetSizeMB := float64(project.MaxLogsSizeMB) * cleanupPercentage
+	excessSizeMB := stats.TotalSizeMB - targetSizeMB
+	if excessSizeMB <= 0 {
+		return time.Time{}, false
+	}
+
+	return s.calculateCutoffTimeForSize(excessSizeMB, stats, now), true
+}
+
+// calculateRetentionCutoff returns the time logs older than which the retention
+// cleanup deletes, and whether the project has a retention period
+func (s *LogCleanupBackgroundService) calculateRetentionCutoff(
+	project *projects_models.Project,
+	now time.Time,
+) (time.Time, bool) {
+	if project.MaxLogsLifeDays <= 0 {
+		return time.Time{}, false
+	}
+
+	return now.AddDate(0, 0, -project.MaxLogsLifeDays), true
+}
+
 func (s *LogCleanupBackgroundService) calculateCutoffTimeForLogCount(
 	logsToDelete int64,
 	stats *logs_core.ProjectLogStats,
+	now time.Time,
 ) time.Time {
 	if stats.TotalLogs == 0 {
-		return time.Now().UTC()
+		return now
 	}
 
 	logLifespan := stats.NewestLogTime.Sub(stats.OldestLogTime)
 	if logLifespan <= 0 {
-		return time.Now().UTC().Add(-24 * time.Hour)
+		return now.Add(-24 * time.Hour)
 	}
 
 	percentageToDelete := float64(logsToDelete) / float64(stats.TotalLogs)
@@ -287,14 +556,15 @@ func (s *LogCleanupBackgroundService) calculateCutoffTimeForLogCount(
 func (s *LogCleanupBackgroundService) calculateCutoffTimeForSize(
 	sizeMBToDelete float64,
 	stats *logs_core.ProjectLogStats,
+	now time.Time,
 ) time.Time {
 	if stats.TotalSizeMB == 0 {
-		return time.Now().UTC()
+		return now
 	}
 
 	logLifespan := stats.NewestLogTime.Sub(stats.OldestLogTime)
 	if logLifespan <= 0 {
-		return time.Now().UTC().Add(-24 * time.Hour)
+		return now.Add(-24 * time.Hour)
 	}
 
 	percentageToDelete := sizeMBToDelete / stats.TotalSizeMB
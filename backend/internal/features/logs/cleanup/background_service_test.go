@@ -0,0 +1,130 @@
+package logs_cleanup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	projects_models "logbull/internal/features/projects/models"
+	"logbull/internal/util/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ForEachProject_WithConcurrencyCap_ProcessesAllProjectsWithinCap(t *testing.T) {
+	service := createTestCleanupService(3, 0)
+	projects := createTestProjects(12)
+
+	var runningProjects atomic.Int32
+	var maxRunningProjects atomic.Int32
+	var processedProjects sync.Map
+
+	failures := service.forEachProject(projects, func(project *projects_models.Project) error {
+		running := runningProjects.Add(1)
+		defer runningProjects.Add(-1)
+
+		for {
+			maxRunning := maxRunningProjects.Load()
+			if running <= maxRunning || maxRunningProjects.CompareAndSwap(maxRunning, running) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		processedProjects.Store(project.ID, true)
+		return nil
+	})
+
+	assert.Equal(t, 0, failures)
+	assert.Equal(t, int32(3), maxRunningProjects.Load())
+	for _, project := range projects {
+		_, isProcessed := processedProjects.Load(project.ID)
+		assert.True(t, isProcessed, "project %s was not processed", project.ID)
+	}
+}
+
+func Test_ForEachProject_WithFailingProjects_CountsFailuresAndProcessesTheRest(t *testing.T) {
+	service := createTestCleanupService(2, 0)
+	projects := createTestProjects(6)
+
+	var processedCount atomic.Int32
+	failures := service.forEachProject(projects, func(project *projects_models.Project) error {
+		processedCount.Add(1)
+		if project.ID == projects[1].ID || project.ID == projects[4].ID {
+			return errors.New("cleanup failed")
+		}
+		return nil
+	})
+
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, int32(6), processedCount.Load())
+}
+
+func Test_ForEachProject_WithProjectDelay_PausesEachWorkerBetweenProjects(t *testing.T) {
+	projectDelay := 50 * time.Millisecond
+	service := createTestCleanupService(2, projectDelay)
+	projects := createTestProjects(6)
+
+	startedAt := time.Now()
+	failures := service.forEachProject(projects, func(project *projects_models.Project) error {
+		return nil
+	})
+
+	// each of the 2 workers takes 3 projects and pauses twice
+	assert.Equal(t, 0, failures)
+	assert.GreaterOrEqual(t, time.Since(startedAt), 2*projectDelay)
+	assert.Less(t, time.Since(startedAt), 6*projectDelay)
+}
+
+func Test_ForEachProject_WhenServiceIsStopped_StopsTakingProjects(t *testing.T) {
+	service := createTestCleanupService(1, time.Hour)
+	service.ctx, service.cancel = context.WithCancel(context.Background())
+	projects := createTestProjects(3)
+
+	var processedCount atomic.Int32
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		service.cancel()
+	}()
+
+	service.forEachProject(projects, func(project *projects_models.Project) error {
+		processedCount.Add(1)
+		return nil
+	})
+
+	assert.Equal(t, int32(1), processedCount.Load())
+}
+
+func Test_SetConcurrency_WithOutOfRangeValues_ClampsToLimits(t *testing.T) {
+	service := createTestCleanupService(1, 0)
+
+	service.SetConcurrency(0)
+	assert.Equal(t, 1, service.GetConcurrency())
+
+	service.SetConcurrency(MaxCleanupConcurrency + 1)
+	assert.Equal(t, MaxCleanupConcurrency, service.GetConcurrency())
+
+	service.SetConcurrency(4)
+	assert.Equal(t, 4, service.GetConcurrency())
+}
+
+func createTestCleanupService(concurrency int, projectDelay time.Duration) *LogCleanupBackgroundService {
+	return &LogCleanupBackgroundService{
+		logger:       logger.GetLogger(),
+		concurrency:  concurrency,
+		projectDelay: projectDelay,
+	}
+}
+
+func createTestProjects(count int) []*projects_models.Project {
+	projects := make([]*projects_models.Project, 0, count)
+	for range count {
+		projects = append(projects, &projects_models.Project{ID: uuid.New()})
+	}
+
+	return projects
+}
@@ -0,0 +1,234 @@
+package logs_cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	users_enums "logbull/internal/features/users/enums"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+)
+
+const (
+	cleanupMetricsKeyPrefix    = "cleanup_metrics:"
+	cleanupMetricsProjectsKey  = "cleanup_metrics:projects"
+	cleanupMetricsCacheTimeout = 2 * time.Second
+
+	cleanupMetricsDeletionsField     = "deletions"
+	cleanupMetricsDeletedLogsField   = "deleted_logs"
+	cleanupMetricsDeletedBytesField  = "deleted_bytes"
+	cleanupMetricsLastDeletedAtField = "last_deleted_at"
+)
+
+// CleanupMetrics counts what the cleanup workers deleted per project and reason
+// in the cache, so counters of every instance running cleanup workers add up
+// and survive restarts. Counters of a project are one hash with
+// "<reason>:<counter>" fields, projects with counters are listed in a set
+type CleanupMetrics struct {
+	client valkey.Client
+	logger *slog.Logger
+}
+
+func NewCleanupMetrics(client valkey.Client, logger *slog.Logger) *CleanupMetrics {
+	return &CleanupMetrics{
+		client: client,
+		logger: logger,
+	}
+}
+
+// RecordDeletion adds logs deleted by one cleanup run, deleted are the stats of
+// the logs older than the cutoff taken right before the deletion. Failures are
+// logged only, metrics never fail the cleanup
+func (m *CleanupMetrics) RecordDeletion(
+	projectID uuid.UUID,
+	reason CleanupReason,
+	deleted *logs_core.ProjectLogStats,
+	deletedAt time.Time,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupMetricsCacheTimeout)
+	defer cancel()
+
+	projectKey := cleanupMetricsKeyPrefix + projectID.String()
+	deletedBytes := int64(math.Round(deleted.TotalSizeMB * 1024 * 1024))
+
+	results := m.client.DoMulti(ctx,
+		m.client.B().Hincrby().Key(projectKey).
+			Field(cleanupMetricsField(reason, cleanupMetricsDeletionsField)).Increment(1).Build(),
+		m.client.B().Hincrby().Key(projectKey).
+			Field(cleanupMetricsField(reason, cleanupMetricsDeletedLogsField)).Increment(deleted.TotalLogs).Build(),
+		m.client.B().Hincrby().Key(projectKey).
+			Field(cleanupMetricsField(reason, cleanupMetricsDeletedBytesField)).Increment(deletedBytes).Build(),
+		m.client.B().Hset().Key(projectKey).FieldValue().
+			FieldValue(cleanupMetricsField(reason, cleanupMetricsLastDeletedAtField),
+				strconv.FormatInt(deletedAt.UnixMilli(), 10)).Build(),
+		m.client.B().Sadd().Key(cleanupMetricsProjectsKey).Member(projectID.String()).Build(),
+	)
+
+	for _, result := range results {
+		if err := result.Error(); err != nil {
+			m.logger.Error("Failed to record cleanup metrics",
+				"projectId", projectID.String(),
+				"reason", string(reason),
+				"error", err)
+			return
+		}
+	}
+}
+
+func (m *CleanupMetrics) GetProjectMetrics(projectID uuid.UUID) (*CleanupMetricsDTO, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupMetricsCacheTimeout)
+	defer cancel()
+
+	result := m.client.Do(ctx, m.client.B().Hgetall().Key(cleanupMetricsKeyPrefix+projectID.String()).Build())
+	countersByField, err := result.AsIntMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup metrics: %w", err)
+	}
+
+	return buildProjectCleanupMetrics(projectID, countersByField), nil
+}
+
+// GetAllMetrics returns metrics of the projects the cleanup deleted logs from
+func (m *CleanupMetrics) GetAllMetrics() ([]CleanupMetricsDTO, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupMetricsCacheTimeout)
+	defer cancel()
+
+	members, err := m.client.Do(ctx, m.client.B().Smembers().Key(cleanupMetricsProjectsKey).Build()).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup metrics projects: %w", err)
+	}
+
+	projectIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		projectID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+
+		projectIDs = append(projectIDs, projectID)
+	}
+
+	commands := make(valkey.Commands, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		commands = append(commands, m.client.B().Hgetall().Key(cleanupMetricsKeyPrefix+projectID.String()).Build())
+	}
+
+	allMetrics := make([]CleanupMetricsDTO, 0, len(projectIDs))
+	for i, result := range m.client.DoMulti(ctx, commands...) {
+		countersByField, err := result.AsIntMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cleanup metrics: %w", err)
+		}
+
+		allMetrics = append(allMetrics, *buildProjectCleanupMetrics(projectIDs[i], countersByField))
+	}
+
+	slices.SortFunc(allMetrics, func(a, b CleanupMetricsDTO) int {
+		return strings.Compare(a.ProjectID.String(), b.ProjectID.String())
+	})
+
+	return allMetrics, nil
+}
+
+func (m *CleanupMetrics) OnBeforeProjectDeletion(projectID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupMetricsCacheTimeout)
+	defer cancel()
+
+	results := m.client.DoMulti(ctx,
+		m.client.B().Del().Key(cleanupMetricsKeyPrefix+projectID.String()).Build(),
+		m.client.B().Srem().Key(cleanupMetricsProjectsKey).Member(projectID.String()).Build(),
+	)
+
+	for _, result := range results {
+		if err := result.Error(); err != nil {
+			return fmt.Errorf("failed to delete cleanup metrics: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func buildProjectCleanupMetrics(projectID uuid.UUID, countersByField map[string]int64) *CleanupMetricsDTO {
+	metrics := &CleanupMetricsDTO{
+		ProjectID: projectID,
+		Reasons:   []CleanupReasonMetricsDTO{},
+	}
+
+	reasons := map[CleanupReason]*CleanupReasonMetricsDTO{}
+	for field, value := range countersByField {
+		reasonName, counter, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+
+		reason := CleanupReason(reasonName)
+		reasonMetrics, exists := reasons[reason]
+		if !exists {
+			reasonMetrics = &CleanupReasonMetricsDTO{Reason: reason}
+			reasons[reason] = reasonMetrics
+		}
+
+		switch counter {
+		case cleanupMetricsDeletionsField:
+			reasonMetrics.Deletions = value
+		case cleanupMetricsDeletedLogsField:
+			reasonMetrics.DeletedLogs = value
+		case cleanupMetricsDeletedBytesField:
+			reasonMetrics.DeletedBytes = value
+		case cleanupMetricsLastDeletedAtField:
+			reasonMetrics.LastDeletedAt = time.UnixMilli(value).UTC()
+		}
+	}
+
+	for _, reasonMetrics := range reasons {
+		metrics.DeletedLogs += reasonMetrics.DeletedLogs
+		metrics.DeletedBytes += reasonMetrics.DeletedBytes
+		metrics.Reasons = append(metrics.Reasons, *reasonMetrics)
+	}
+
+	slices.SortFunc(metrics.Reasons, func(a, b CleanupReasonMetricsDTO) int {
+		return strings.Compare(string(a.Reason), string(b.Reason))
+	})
+
+	return metrics
+}
+
+func cleanupMetricsField(reason CleanupReason, counter string) string {
+	return string(reason) + ":" + counter
+}
+
+// GetCleanupMetrics returns what the cleanup deleted from the project
+func (s *LogCleanupBackgroundService) GetCleanupMetrics(
+	projectID uuid.UUID,
+	user *users_models.User,
+) (*CleanupMetricsDTO, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to view project cleanup metrics")
+	}
+
+	return s.cleanupMetrics.GetProjectMetrics(projectID)
+}
+
+// GetAllCleanupMetrics returns cleanup metrics of every project, for operators
+// correlating retention with disk usage
+func (s *LogCleanupBackgroundService) GetAllCleanupMetrics(user *users_models.User) ([]CleanupMetricsDTO, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("insufficient permissions to view cleanup metrics of all projects")
+	}
+
+	return s.cleanupMetrics.GetAllMetrics()
+}
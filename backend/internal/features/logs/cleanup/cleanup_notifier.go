@@ -0,0 +1,98 @@
+package logs_cleanup
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"logbull/internal/util/webhook"
+
+	"github.com/google/uuid"
+)
+
+// CleanupReason tells which project limit requires the deletion
+type CleanupReason string
+
+const (
+	CleanupReasonCountQuota CleanupReason = "COUNT_QUOTA"
+	CleanupReasonSizeQuota  CleanupReason = "SIZE_QUOTA"
+	CleanupReasonRetention  CleanupReason = "RETENTION"
+)
+
+// CleanupNoticeDTO is sent to the project cleanup webhook before logs are deleted
+type CleanupNoticeDTO struct {
+	ProjectID   uuid.UUID     `json:"projectId"`
+	ProjectName string        `json:"projectName"`
+	Reason      CleanupReason `json:"reason"`
+	// Logs older than DeleteOlderThan at the time of the notice
+	LogsToDelete    int64     `json:"logsToDelete"`
+	DeleteOlderThan time.Time `json:"deleteOlderThan"`
+	ScheduledAt     time.Time `json:"scheduledAt"`
+}
+
+type scheduledDeletionKey struct {
+	projectID uuid.UUID
+	reason    CleanupReason
+}
+
+// CleanupNotifier notifies project webhooks about upcoming deletions and
+// remembers when the deletions are due. Schedules are kept in memory of the
+// instance running cleanup workers, so a restart notifies again
+type CleanupNotifier struct {
+	webhookSender *webhook.Sender
+	logger        *slog.Logger
+
+	mu                 sync.Mutex
+	scheduledDeletions map[scheduledDeletionKey]time.Time
+}
+
+func NewCleanupNotifier(webhookSender *webhook.Sender, logger *slog.Logger) *CleanupNotifier {
+	return &CleanupNotifier{
+		webhookSender:      webhookSender,
+		logger:             logger,
+		scheduledDeletions: make(map[scheduledDeletionKey]time.Time),
+	}
+}
+
+func (n *CleanupNotifier) GetScheduledDeletion(projectID uuid.UUID, reason CleanupReason) (time.Time, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	scheduledAt, exists := n.scheduledDeletions[scheduledDeletionKey{projectID, reason}]
+	return scheduledAt, exists
+}
+
+// ScheduleDeletion records the deletion and sends the notice. The deletion
+// stays scheduled when the webhook fails, so limits are still enforced
+func (n *CleanupNotifier) ScheduleDeletion(webhookURL string, notice *CleanupNoticeDTO) {
+	n.mu.Lock()
+	n.scheduledDeletions[scheduledDeletionKey{notice.ProjectID, notice.Reason}] = notice.ScheduledAt
+	n.mu.Unlock()
+
+	if err := n.webhookSender.Send("cleanup", webhookURL, notice); err != nil {
+		n.logger.Error("Failed to send cleanup notice",
+			slog.String("projectId", notice.ProjectID.String()),
+			slog.String("reason", string(notice.Reason)),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (n *CleanupNotifier) ClearScheduledDeletion(projectID uuid.UUID, reason CleanupReason) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.scheduledDeletions, scheduledDeletionKey{projectID, reason})
+}
+
+func (n *CleanupNotifier) OnBeforeProjectDeletion(projectID uuid.UUID) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for key := range n.scheduledDeletions {
+		if key.projectID == projectID {
+			delete(n.scheduledDeletions, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package logs_cleanup
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/google/uuid"
+)
+
+// PreviewCleanup returns the cutoffs the cleanup workers would use for the project
+// now. They come from the same calculations as the real run, so quota cutoffs
+// match it as long as no logs are received or deleted in between
+func (s *LogCleanupBackgroundService) PreviewCleanup(
+	projectID uuid.UUID,
+	user *users_models.User,
+) (*CleanupPreviewDTO, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to preview project cleanup")
+	}
+
+	project, err := s.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.logCoreRepository.GetProjectLogStats(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project log stats: %w", err)
+	}
+
+	now := time.Now().UTC()
+	preview := &CleanupPreviewDTO{
+		ProjectID:         projectID,
+		CalculatedAt:      now,
+		Stats:             *stats,
+		CleanupPercentage: s.calculateCleanupPercentage(project.MaxLogsSizeMB),
+		Cutoffs:           []CleanupCutoffDTO{},
+	}
+
+	if cutoffTime, isExceeded := s.calculateCountQuotaCutoff(project, stats, now); isExceeded {
+		preview.Cutoffs = append(preview.Cutoffs, s.newCleanupCutoff(projectID, CleanupReasonCountQuota, cutoffTime))
+	}
+
+	if cutoffTime, isExceeded := s.calculateSizeQuotaCutoff(project, stats, now); isExceeded {
+		preview.Cutoffs = append(preview.Cutoffs, s.newCleanupCutoff(projectID, CleanupReasonSizeQuota, cutoffTime))
+	}
+
+	if cutoffTime, hasRetention := s.calculateRetentionCutoff(project, now); hasRetention {
+		preview.Cutoffs = append(preview.Cutoffs, s.newCleanupCutoff(projectID, CleanupReasonRetention, cutoffTime))
+	}
+
+	return preview, nil
+}
+
+func (s *LogCleanupBackgroundService) newCleanupCutoff(
+	projectID uuid.UUID,
+	reason CleanupReason,
+	cutoffTime time.Time,
+) CleanupCutoffDTO {
+	cutoff := CleanupCutoffDTO{
+		Reason:          reason,
+		DeleteOlderThan: cutoffTime,
+	}
+
+	if scheduledAt, isScheduled := s.cleanupNotifier.GetScheduledDeletion(projectID, reason); isScheduled {
+		cutoff.ScheduledAt = &scheduledAt
+	}
+
+	return cutoff
+}
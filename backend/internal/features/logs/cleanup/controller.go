@@ -0,0 +1,189 @@
+package logs_cleanup
+
+import (
+	"net/http"
+	"strings"
+
+	users_middleware "logbull/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LogCleanupController struct {
+	logPurgeService             *LogPurgeService
+	logCleanupBackgroundService *LogCleanupBackgroundService
+}
+
+func (c *LogCleanupController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/logs/purge/:projectId", c.PurgeProjectLogs)
+	router.GET("/logs/cleanup/preview/:projectId", c.PreviewCleanup)
+	router.GET("/logs/cleanup/metrics", c.GetAllCleanupMetrics)
+	router.GET("/logs/cleanup/metrics/:projectId", c.GetCleanupMetrics)
+}
+
+// PurgeProjectLogs
+// @Summary Purge project logs
+// @Description Delete all logs of the project with their attachments, the project settings, members and
+// @Description API keys are kept. Requires project owner or admin role and the project name as confirmation
+// @Tags logs-cleanup
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Param request body PurgeProjectLogsRequestDTO true "Purge confirmation"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /logs/purge/{projectId} [post]
+func (c *LogCleanupController) PurgeProjectLogs(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request PurgeProjectLogsRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := c.logPurgeService.PurgeProjectLogs(projectID, &request, user); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project logs purged successfully"})
+}
+
+// PreviewCleanup
+// @Summary Preview project cleanup
+// @Description Show the cutoff time the cleanup would use for each limit of the project which currently requires
+// @Description deletion (count quota, size quota, retention), calculated from the current log stats the same way
+// @Description as the cleanup run. Logs older than a cutoff are deleted. Requires project owner or admin role
+// @Tags logs-cleanup
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} CleanupPreviewDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /logs/cleanup/preview/{projectId} [get]
+func (c *LogCleanupController) PreviewCleanup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	preview, err := c.logCleanupBackgroundService.PreviewCleanup(projectID, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preview)
+}
+
+// GetCleanupMetrics
+// @Summary Get project cleanup metrics
+// @Description Logs and estimated bytes the cleanup deleted from the project per reason (count quota, size quota,
+// @Description retention), counted across all instances running cleanup workers. Requires project owner or admin
+// @Description role
+// @Tags logs-cleanup
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} CleanupMetricsDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /logs/cleanup/metrics/{projectId} [get]
+func (c *LogCleanupController) GetCleanupMetrics(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	metrics, err := c.logCleanupBackgroundService.GetCleanupMetrics(projectID, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metrics)
+}
+
+// GetAllCleanupMetrics
+// @Summary Get cleanup metrics of all projects (ADMIN only)
+// @Description Cleanup metrics of every project the cleanup deleted logs from, so operators can see retention
+// @Description working and correlate it with disk usage
+// @Tags logs-cleanup
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} CleanupMetricsDTO
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /logs/cleanup/metrics [get]
+func (c *LogCleanupController) GetAllCleanupMetrics(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	metrics, err := c.logCleanupBackgroundService.GetAllCleanupMetrics(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metrics)
+}
+
+func (c *LogCleanupController) handleError(ctx *gin.Context, err error) {
+	if err.Error() == "project not found" {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.HasPrefix(err.Error(), "insufficient permissions") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.HasPrefix(err.Error(), "failed to") {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -1,21 +1,73 @@
 package logs_cleanup
 
 import (
+	"logbull/internal/cache"
+	"logbull/internal/config"
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_attachments "logbull/internal/features/logs/attachments"
 	logs_core "logbull/internal/features/logs/core"
 	projects_services "logbull/internal/features/projects/services"
+	system_webhooks "logbull/internal/features/system/webhooks"
 	"logbull/internal/util/logger"
 	"sync"
+	"time"
 )
 
+var cleanupNotifier = NewCleanupNotifier(system_webhooks.GetWebhookSender(), logger.GetLogger())
+
+var cleanupMetrics = NewCleanupMetrics(cache.GetCache(), logger.GetLogger())
+
 var logCleanupBackgroundService = &LogCleanupBackgroundService{
 	logs_core.GetLogCoreRepository(),
 	projects_services.GetProjectService(),
 	logger.GetLogger(),
+	cleanupNotifier,
+	cleanupMetrics,
+	getCleanupConcurrencyFromConfig(),
+	getCleanupProjectDelayFromConfig(),
 	nil,
 	nil,
 	sync.WaitGroup{},
 }
 
+var logPurgeService = &LogPurgeService{
+	logs_core.GetLogCoreRepository(),
+	projects_services.GetProjectService(),
+	logs_attachments.GetAttachmentService(),
+	audit_logs.GetAuditLogService(),
+}
+
+var logCleanupController = &LogCleanupController{
+	logPurgeService,
+	logCleanupBackgroundService,
+}
+
 func GetLogCleanupBackgroundService() *LogCleanupBackgroundService {
 	return logCleanupBackgroundService
 }
+
+func GetLogPurgeService() *LogPurgeService {
+	return logPurgeService
+}
+
+func GetLogCleanupController() *LogCleanupController {
+	return logCleanupController
+}
+
+func getCleanupConcurrencyFromConfig() int {
+	concurrency := config.GetEnv().CleanupConcurrency
+	if concurrency <= 0 {
+		return DefaultCleanupConcurrency
+	}
+
+	return min(concurrency, MaxCleanupConcurrency)
+}
+
+func getCleanupProjectDelayFromConfig() time.Duration {
+	return time.Duration(max(config.GetEnv().CleanupProjectDelayMs, 0)) * time.Millisecond
+}
+
+func SetupDependencies() {
+	projects_services.GetProjectService().AddProjectDeletionListener(cleanupNotifier)
+	projects_services.GetProjectService().AddProjectDeletionListener(cleanupMetrics)
+}
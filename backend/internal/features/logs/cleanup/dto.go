@@ -0,0 +1,54 @@
+package logs_cleanup
+
+import (
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/google/uuid"
+)
+
+type PurgeProjectLogsRequestDTO struct {
+	// Must repeat the project name, so logs are not purged by a mistaken ID
+	ConfirmProjectName string `json:"confirmProjectName" binding:"required"`
+}
+
+// CleanupPreviewDTO tells what the next cleanup run would delete from the project
+// based on its current log stats
+type CleanupPreviewDTO struct {
+	ProjectID    uuid.UUID                 `json:"projectId"`
+	CalculatedAt time.Time                 `json:"calculatedAt"`
+	Stats        logs_core.ProjectLogStats `json:"stats"`
+	// share of the count or size quota the quota cleanup brings the project down to
+	CleanupPercentage float64 `json:"cleanupPercentage"`
+	// one cutoff per limit which currently requires deletion, empty when none does
+	Cutoffs []CleanupCutoffDTO `json:"cutoffs"`
+}
+
+type CleanupCutoffDTO struct {
+	Reason CleanupReason `json:"reason"`
+	// logs older than this are deleted
+	DeleteOlderThan time.Time `json:"deleteOlderThan"`
+	// set when the project was notified of the deletion, which waits until then
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+}
+
+// CleanupMetricsDTO tells what the cleanup deleted from the project, counted
+// across all instances running cleanup workers
+type CleanupMetricsDTO struct {
+	ProjectID    uuid.UUID `json:"projectId"`
+	DeletedLogs  int64     `json:"deletedLogs"`
+	DeletedBytes int64     `json:"deletedBytes"`
+	// one entry per reason which deleted logs, empty when none did
+	Reasons []CleanupReasonMetricsDTO `json:"reasons"`
+}
+
+type CleanupReasonMetricsDTO struct {
+	Reason CleanupReason `json:"reason"`
+	// cleanup runs which deleted logs for the reason
+	Deletions   int64 `json:"deletions"`
+	DeletedLogs int64 `json:"deletedLogs"`
+	// estimated the same way as the size quota, so it matches what the quota sees
+	DeletedBytes  int64     `json:"deletedBytes"`
+	LastDeletedAt time.Time `json:"lastDeletedAt"`
+}
@@ -0,0 +1,65 @@
+package logs_cleanup
+
+import (
+	"errors"
+	"fmt"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_attachments "logbull/internal/features/logs/attachments"
+	logs_core "logbull/internal/features/logs/core"
+	projects_services "logbull/internal/features/projects/services"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/google/uuid"
+)
+
+// LogPurgeService clears the logs of a project on request. Unlike project
+// deletion the project itself with its settings, members and API keys stays
+type LogPurgeService struct {
+	logCoreRepository *logs_core.LogCoreRepository
+	projectService    *projects_services.ProjectService
+	attachmentService *logs_attachments.AttachmentService
+	auditLogService   *audit_logs.AuditLogService
+}
+
+// PurgeProjectLogs deletes every log of the project together with its
+// offloaded attachments. Allowed for project owners, project admins and
+// global admins, the request must confirm the project name
+func (s *LogPurgeService) PurgeProjectLogs(
+	projectID uuid.UUID,
+	request *PurgeProjectLogsRequestDTO,
+	user *users_models.User,
+) error {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to purge project logs")
+	}
+
+	project, err := s.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return err
+	}
+
+	if request.ConfirmProjectName != project.Name {
+		return errors.New("confirmProjectName does not match the project name")
+	}
+
+	if err := s.logCoreRepository.DeleteLogsByProject(projectID); err != nil {
+		return fmt.Errorf("failed to purge project logs: %w", err)
+	}
+
+	if err := s.attachmentService.DeleteProjectAttachments(projectID); err != nil {
+		return fmt.Errorf("failed to purge project attachments: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Project logs purged: %s", project.Name),
+		&user.ID,
+		&projectID,
+	)
+
+	return nil
+}
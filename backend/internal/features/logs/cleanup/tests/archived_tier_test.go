@@ -0,0 +1,133 @@
+package logs_cleanup_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	logs_cleanup "logbull/internal/features/logs/cleanup"
+	logs_core "logbull/internal/features/logs/core"
+	logs_core_tests "logbull/internal/features/logs/core/tests"
+	projects_controllers "logbull/internal/features/projects/controllers"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnforceRetention_WhenArchiveAfterDaysIsSet_MovesOlderLogsToArchivedTier(t *testing.T) {
+	router := projects_testing.CreateTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project := projects_testing.CreateTestProject("Archived Tier "+uuid.New().String()[:8], owner, router)
+	projects_testing.UpdateProject(project, &projects_models.Project{
+		Name:                  project.Name,
+		MaxLogsLifeDays:       30,
+		ArchiveAfterDays:      7,
+		IsConfirmQuotaDisable: true,
+	}, owner.Token, router)
+
+	// 3 logs past the searchable period and 2 within it
+	repository := logs_core.GetLogCoreRepository()
+	now := time.Now().UTC()
+	storeCleanupMetricsTestLogs(t, repository, project.ID, now.AddDate(0, 0, -10), time.Minute, 3)
+	storeCleanupMetricsTestLogs(t, repository, project.ID, now.AddDate(0, 0, -1), time.Minute, 2)
+
+	cleanupService := logs_cleanup.GetLogCleanupBackgroundService()
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTestAt(now))
+
+	from := now.AddDate(0, 0, -30)
+	archiveCutoff := now.AddDate(0, 0, -7)
+
+	// Regular queries search the searchable tier only
+	hotLogs, err := repository.ExecuteQueryForProject(project.ID, &logs_core.LogQueryRequestDTO{
+		TimeRange:  &logs_core.TimeRangeDTO{From: &from},
+		Limit:      10,
+		TrackTotal: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), hotLogs.Total)
+	for _, log := range hotLogs.Logs {
+		assert.True(t, log.Timestamp.After(archiveCutoff), "Searchable logs must be within the searchable period")
+	}
+
+	// Archived logs are kept and returned when included
+	allLogs, err := repository.ExecuteQueryForProject(project.ID, &logs_core.LogQueryRequestDTO{
+		TimeRange:       &logs_core.TimeRangeDTO{From: &from},
+		Limit:           10,
+		TrackTotal:      true,
+		IncludeArchived: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), allLogs.Total)
+
+	archivedLogs, err := repository.ExecuteQueryForProject(project.ID, &logs_core.LogQueryRequestDTO{
+		TimeRange:       &logs_core.TimeRangeDTO{From: &from, To: &archiveCutoff},
+		Limit:           10,
+		TrackTotal:      true,
+		IncludeArchived: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), archivedLogs.Total)
+
+	// Quotas are measured on the searchable tier
+	stats, err := repository.GetProjectLogStats(project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), stats.TotalLogs)
+
+	// Retention deletes archived logs as well, 25 days later the old logs are past it
+	// while the recent ones get archived
+	later := now.AddDate(0, 0, 25)
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTestAt(later))
+
+	retentionCutoff := later.AddDate(0, 0, -30)
+	logs_core_tests.WaitForLogsDeletion(t, repository, project.ID, &logs_core.LogQueryRequestDTO{
+		TimeRange:       &logs_core.TimeRangeDTO{To: &retentionCutoff},
+		Limit:           10,
+		IncludeArchived: true,
+	}, 60*time.Second)
+
+	allLogs, err = repository.ExecuteQueryForProject(project.ID, &logs_core.LogQueryRequestDTO{
+		TimeRange:       &logs_core.TimeRangeDTO{From: &from},
+		Limit:           10,
+		TrackTotal:      true,
+		IncludeArchived: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), allLogs.Total)
+	assertProjectLogsCount(t, repository, project.ID, 0)
+}
+
+func Test_UpdateProject_WithArchiveAfterRetention_ReturnsBadRequest(t *testing.T) {
+	router := projects_testing.CreateTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProject("Archived Tier Validation "+uuid.New().String()[:8], owner, router)
+
+	invalidUpdates := []*projects_models.Project{
+		{Name: project.Name, MaxLogsLifeDays: 30, ArchiveAfterDays: 30},
+		{Name: project.Name, MaxLogsLifeDays: 30, ArchiveAfterDays: 45},
+		{Name: project.Name, MaxLogsLifeDays: 30, ArchiveAfterDays: -1},
+	}
+
+	for _, update := range invalidUpdates {
+		update.IsConfirmQuotaDisable = true
+		w := projects_testing.MakeAPIRequest(
+			router,
+			"PUT",
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			update,
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,235 @@
+package logs_cleanup_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"logbull/internal/cache"
+	logs_cleanup "logbull/internal/features/logs/cleanup"
+	logs_core "logbull/internal/features/logs/core"
+	logs_core_tests "logbull/internal/features/logs/core/tests"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	"logbull/internal/util/logger"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetCleanupMetrics_AfterCountQuotaCleanup_CountsDeletedLogsAndBytes(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()[:8]
+
+	project := projects_testing.CreateTestProject("Cleanup Metrics Count "+uniqueID, owner, router)
+	projects_testing.UpdateProject(project, &projects_models.Project{
+		Name:                  project.Name,
+		MaxLogsAmount:         10,
+		IsConfirmQuotaDisable: true,
+	}, owner.Token, router)
+
+	// 15 logs a minute apart, over the quota of 10
+	repository := logs_core.GetLogCoreRepository()
+	storeCleanupMetricsTestLogs(t, repository, project.ID, time.Now().UTC().Add(-2*time.Hour), time.Minute, 15)
+
+	metrics := getCleanupMetrics(t, router, project.ID, owner.Token, http.StatusOK)
+	assert.Equal(t, int64(0), metrics.DeletedLogs)
+	assert.Empty(t, metrics.Reasons)
+
+	// The preview cutoff is the one the run uses, the logs older than it are the deleted ones
+	countCutoff := findCleanupCutoff(
+		previewCleanup(t, router, project.ID, owner.Token, http.StatusOK),
+		logs_cleanup.CleanupReasonCountQuota,
+	)
+	if !assert.NotNil(t, countCutoff) {
+		return
+	}
+	expectedDeleted, err := repository.GetProjectLogStatsOlderThan(project.ID, countCutoff.DeleteOlderThan)
+	assert.NoError(t, err)
+	assert.Positive(t, expectedDeleted.TotalLogs)
+
+	cleanupService := logs_cleanup.GetLogCleanupBackgroundService()
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTest())
+
+	metrics = getCleanupMetrics(t, router, project.ID, owner.Token, http.StatusOK)
+	assert.Equal(t, project.ID, metrics.ProjectID)
+	assert.Equal(t, expectedDeleted.TotalLogs, metrics.DeletedLogs)
+	assert.Positive(t, metrics.DeletedBytes)
+	if assert.Len(t, metrics.Reasons, 1) {
+		reasonMetrics := metrics.Reasons[0]
+		assert.Equal(t, logs_cleanup.CleanupReasonCountQuota, reasonMetrics.Reason)
+		assert.Equal(t, int64(1), reasonMetrics.Deletions)
+		assert.Equal(t, expectedDeleted.TotalLogs, reasonMetrics.DeletedLogs)
+		assert.Equal(t, metrics.DeletedBytes, reasonMetrics.DeletedBytes)
+		assert.WithinDuration(t, time.Now().UTC(), reasonMetrics.LastDeletedAt, time.Minute)
+	}
+
+	// The counted logs are the ones which are gone
+	logs_core_tests.WaitForLogsDeletion(t, repository, project.ID, &logs_core.LogQueryRequestDTO{
+		TimeRange: &logs_core.TimeRangeDTO{To: &countCutoff.DeleteOlderThan},
+		Limit:     10,
+	}, 60*time.Second)
+	assertProjectLogsCount(t, repository, project.ID, 15-metrics.DeletedLogs)
+}
+
+func Test_GetCleanupMetrics_AfterRetentionCleanup_CountsDeletionsPerReason(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project := projects_testing.CreateTestProject("Cleanup Metrics Retention "+uuid.New().String()[:8], owner, router)
+	projects_testing.UpdateProject(project, &projects_models.Project{
+		Name:                  project.Name,
+		MaxLogsLifeDays:       7,
+		IsConfirmQuotaDisable: true,
+	}, owner.Token, router)
+
+	// 4 logs older than the retention period and 3 within it
+	repository := logs_core.GetLogCoreRepository()
+	now := time.Now().UTC()
+	storeCleanupMetricsTestLogs(t, repository, project.ID, now.AddDate(0, 0, -10), time.Minute, 4)
+	storeCleanupMetricsTestLogs(t, repository, project.ID, now.AddDate(0, 0, -2), time.Minute, 3)
+
+	cleanupService := logs_cleanup.GetLogCleanupBackgroundService()
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTest())
+
+	metrics := getCleanupMetrics(t, router, project.ID, owner.Token, http.StatusOK)
+	assert.Equal(t, int64(4), metrics.DeletedLogs)
+	if assert.Len(t, metrics.Reasons, 1) {
+		assert.Equal(t, logs_cleanup.CleanupReasonRetention, metrics.Reasons[0].Reason)
+		assert.Equal(t, int64(1), metrics.Reasons[0].Deletions)
+		assert.Equal(t, int64(4), metrics.Reasons[0].DeletedLogs)
+		assert.Positive(t, metrics.Reasons[0].DeletedBytes)
+	}
+
+	// Once the old logs are gone, further runs find nothing to delete and do not count
+	retentionCutoff := now.AddDate(0, 0, -7)
+	logs_core_tests.WaitForLogsDeletion(t, repository, project.ID, &logs_core.LogQueryRequestDTO{
+		TimeRange: &logs_core.TimeRangeDTO{To: &retentionCutoff},
+		Limit:     10,
+	}, 60*time.Second)
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTest())
+
+	metrics = getCleanupMetrics(t, router, project.ID, owner.Token, http.StatusOK)
+	assert.Equal(t, int64(4), metrics.DeletedLogs)
+	if assert.Len(t, metrics.Reasons, 1) {
+		assert.Equal(t, int64(1), metrics.Reasons[0].Deletions)
+	}
+
+	// Operators see the project among all projects
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	var allMetrics []logs_cleanup.CleanupMetricsDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t, router, "/api/v1/logs/cleanup/metrics", "Bearer "+admin.Token, http.StatusOK, &allMetrics,
+	)
+
+	var projectMetrics *logs_cleanup.CleanupMetricsDTO
+	for i := range allMetrics {
+		if allMetrics[i].ProjectID == project.ID {
+			projectMetrics = &allMetrics[i]
+		}
+	}
+	if assert.NotNil(t, projectMetrics) {
+		assert.Equal(t, int64(4), projectMetrics.DeletedLogs)
+	}
+}
+
+func Test_GetCleanupMetrics_WhenRecordedByAnotherInstance_MetricsAddUp(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProject("Cleanup Metrics Shared "+uuid.New().String()[:8], owner, router)
+
+	deletedAt := time.Now().UTC().Truncate(time.Millisecond)
+	firstInstanceMetrics := logs_cleanup.NewCleanupMetrics(cache.GetCache(), logger.GetLogger())
+	secondInstanceMetrics := logs_cleanup.NewCleanupMetrics(cache.GetCache(), logger.GetLogger())
+	firstInstanceMetrics.RecordDeletion(
+		project.ID,
+		logs_cleanup.CleanupReasonRetention,
+		&logs_core.ProjectLogStats{TotalLogs: 4, TotalSizeMB: 1},
+		deletedAt.Add(-time.Minute),
+	)
+	secondInstanceMetrics.RecordDeletion(
+		project.ID,
+		logs_cleanup.CleanupReasonRetention,
+		&logs_core.ProjectLogStats{TotalLogs: 6, TotalSizeMB: 1},
+		deletedAt,
+	)
+
+	metrics := getCleanupMetrics(t, router, project.ID, owner.Token, http.StatusOK)
+	assert.Equal(t, int64(10), metrics.DeletedLogs)
+	assert.Equal(t, int64(2*1024*1024), metrics.DeletedBytes)
+	if assert.Len(t, metrics.Reasons, 1) {
+		assert.Equal(t, int64(2), metrics.Reasons[0].Deletions)
+		assert.True(t, deletedAt.Equal(metrics.Reasons[0].LastDeletedAt))
+	}
+
+	// Deleted projects leave no counters behind
+	assert.NoError(t, firstInstanceMetrics.OnBeforeProjectDeletion(project.ID))
+	projectMetrics, err := secondInstanceMetrics.GetProjectMetrics(project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, projectMetrics.Reasons)
+}
+
+func Test_GetCleanupMetrics_WhenUserIsNotAllowed_ReturnsForbidden(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project := projects_testing.CreateTestProject("Cleanup Metrics Member "+uuid.New().String()[:8], owner, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	getCleanupMetrics(t, router, project.ID, member.Token, http.StatusForbidden)
+
+	// Metrics of all projects are for instance admins only
+	test_utils.MakeGetRequest(t, router, "/api/v1/logs/cleanup/metrics", "Bearer "+owner.Token, http.StatusForbidden)
+}
+
+func storeCleanupMetricsTestLogs(
+	t *testing.T,
+	repository *logs_core.LogCoreRepository,
+	projectID uuid.UUID,
+	firstLogTime time.Time,
+	interval time.Duration,
+	count int,
+) {
+	var allEntries map[uuid.UUID][]*logs_core.LogItem
+	for i := range count {
+		entries := logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+			projectID,
+			firstLogTime.Add(time.Duration(i)*interval),
+			"Log message for cleanup metrics test",
+			map[string]any{"log_index": i},
+		)
+		if allEntries == nil {
+			allEntries = entries
+		} else {
+			allEntries = logs_core_tests.MergeLogEntries(allEntries, entries)
+		}
+	}
+
+	logs_core_tests.StoreTestLogsAndFlush(t, repository, allEntries)
+}
+
+func getCleanupMetrics(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	expectedStatus int,
+) *logs_cleanup.CleanupMetricsDTO {
+	var metrics logs_cleanup.CleanupMetricsDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/logs/cleanup/metrics/"+projectID.String(),
+		"Bearer "+token,
+		expectedStatus,
+		&metrics,
+	)
+
+	return &metrics
+}
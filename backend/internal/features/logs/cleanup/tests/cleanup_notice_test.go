@@ -0,0 +1,216 @@
+package logs_cleanup_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	logs_cleanup "logbull/internal/features/logs/cleanup"
+	logs_core "logbull/internal/features/logs/core"
+	logs_core_tests "logbull/internal/features/logs/core/tests"
+	projects_controllers "logbull/internal/features/projects/controllers"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	system_webhooks "logbull/internal/features/system/webhooks"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnforceProjectQuotas_WithCleanupNotice_NotifiesAndDeletesAfterNoticeWindow(t *testing.T) {
+	router := projects_testing.CreateTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()[:8]
+
+	webhook := newCleanupWebhookRecorder(t)
+
+	project := projects_testing.CreateTestProject("Cleanup Notice Test "+uniqueID, owner, router)
+	projects_testing.UpdateProject(project, &projects_models.Project{
+		Name:                  project.Name,
+		MaxLogsAmount:         10,
+		CleanupWebhookURL:     webhook.server.URL,
+		CleanupNoticeMinutes:  30,
+		IsConfirmQuotaDisable: true,
+	}, owner.Token, router)
+
+	repository := logs_core.GetLogCoreRepository()
+	cleanupService := logs_cleanup.GetLogCleanupBackgroundService()
+
+	now := time.Now().UTC()
+	var allEntries map[uuid.UUID][]*logs_core.LogItem
+	for i := range 15 {
+		entries := logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+			project.ID,
+			now.Add(-2*time.Hour).Add(time.Duration(i)*time.Minute),
+			"Log message for cleanup notice test",
+			map[string]any{"test_session": uniqueID, "log_index": i},
+		)
+		if allEntries == nil {
+			allEntries = entries
+		} else {
+			allEntries = logs_core_tests.MergeLogEntries(allEntries, entries)
+		}
+	}
+	logs_core_tests.StoreTestLogsAndFlush(t, repository, allEntries)
+
+	// Crossing the quota only sends the notice
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTestAt(now))
+	assert.NoError(t, repository.ForceFlush())
+
+	notices := webhook.getNotices()
+	if assert.Len(t, notices, 1) {
+		assert.Equal(t, project.ID, notices[0].ProjectID)
+		assert.Equal(t, logs_cleanup.CleanupReasonCountQuota, notices[0].Reason)
+		assert.Greater(t, notices[0].LogsToDelete, int64(0))
+		assert.WithinDuration(t, now.Add(30*time.Minute), notices[0].ScheduledAt, time.Second)
+	}
+	assertProjectLogsCount(t, repository, project.ID, 15)
+
+	// Within the notice window nothing is deleted and the notice is not repeated
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTestAt(now.Add(10*time.Minute)))
+	assert.NoError(t, repository.ForceFlush())
+
+	assert.Len(t, webhook.getNotices(), 1)
+	assertProjectLogsCount(t, repository, project.ID, 15)
+
+	// After the notice window the quota is enforced
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTestAt(now.Add(31*time.Minute)))
+	assert.NoError(t, repository.ForceFlush())
+	time.Sleep(100 * time.Millisecond)
+
+	stats, err := repository.GetProjectLogStats(project.ID)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, stats.TotalLogs, int64(10), "Log count should not exceed quota after notice window")
+	assert.Len(t, webhook.getNotices(), 1)
+}
+
+func Test_UpdateProject_WithCleanupNoticeWithoutWebhook_ReturnsBadRequest(t *testing.T) {
+	router := projects_testing.CreateTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProject("Cleanup Notice Validation "+uuid.New().String()[:8], owner, router)
+
+	invalidUpdates := []*projects_models.Project{
+		{Name: project.Name, CleanupNoticeMinutes: 30},
+		{Name: project.Name, CleanupNoticeMinutes: 30, CleanupWebhookURL: "ftp://example.com/hook"},
+		{Name: project.Name, CleanupNoticeMinutes: -1, CleanupWebhookURL: "https://example.com/hook"},
+	}
+
+	for _, update := range invalidUpdates {
+		update.IsConfirmQuotaDisable = true
+		w := projects_testing.MakeAPIRequest(
+			router,
+			"PUT",
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			update,
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
+func Test_UpdateProject_WithPrivateOrMetadataCleanupWebhook_ReturnsBadRequest(t *testing.T) {
+	router := projects_testing.CreateTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProject("Cleanup Webhook SSRF "+uuid.New().String()[:8], owner, router)
+	setWebhookPrivateNetworkAllowed(t, false)
+
+	webhookURLs := []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://192.168.1.10/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+	}
+
+	for _, webhookURL := range webhookURLs {
+		w := projects_testing.MakeAPIRequest(
+			router,
+			"PUT",
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			&projects_models.Project{
+				Name:                  project.Name,
+				CleanupWebhookURL:     webhookURL,
+				CleanupNoticeMinutes:  30,
+				IsConfirmQuotaDisable: true,
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, webhookURL)
+		assert.Contains(t, w.Body.String(), "cleanup webhook url is not allowed")
+	}
+}
+
+type cleanupWebhookRecorder struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	notices []logs_cleanup.CleanupNoticeDTO
+}
+
+func newCleanupWebhookRecorder(t *testing.T) *cleanupWebhookRecorder {
+	setWebhookPrivateNetworkAllowed(t, true)
+
+	recorder := &cleanupWebhookRecorder{}
+	recorder.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notice logs_cleanup.CleanupNoticeDTO
+		if err := json.NewDecoder(r.Body).Decode(&notice); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recorder.mu.Lock()
+		recorder.notices = append(recorder.notices, notice)
+		recorder.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(recorder.server.Close)
+
+	return recorder
+}
+
+func (r *cleanupWebhookRecorder) getNotices() []logs_cleanup.CleanupNoticeDTO {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]logs_cleanup.CleanupNoticeDTO{}, r.notices...)
+}
+
+// setWebhookPrivateNetworkAllowed lets webhooks reach the test receivers on
+// loopback, the setting is restored after the test
+func setWebhookPrivateNetworkAllowed(t *testing.T, isAllowed bool) {
+	destinationGuard := system_webhooks.GetDestinationGuard()
+	wasAllowed := destinationGuard.IsPrivateNetworkAllowed()
+
+	destinationGuard.SetPrivateNetworkAllowed(isAllowed)
+	t.Cleanup(func() {
+		destinationGuard.SetPrivateNetworkAllowed(wasAllowed)
+	})
+}
+
+func assertProjectLogsCount(
+	t *testing.T,
+	repository *logs_core.LogCoreRepository,
+	projectID uuid.UUID,
+	expectedCount int64,
+) {
+	stats, err := repository.GetProjectLogStats(projectID)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCount, stats.TotalLogs)
+}
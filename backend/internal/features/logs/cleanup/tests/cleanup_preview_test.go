@@ -0,0 +1,161 @@
+package logs_cleanup_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	logs_cleanup "logbull/internal/features/logs/cleanup"
+	logs_core "logbull/internal/features/logs/core"
+	logs_core_tests "logbull/internal/features/logs/core/tests"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PreviewCleanup_WithExceededLimits_ReturnsCutoffsUsedByCleanupRun(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()[:8]
+	webhook := newCleanupWebhookRecorder(t)
+
+	// With the cleanup notice the run only notifies, the notices carry the cutoffs it used
+	project := projects_testing.CreateTestProject("Cleanup Preview Test "+uniqueID, owner, router)
+	projects_testing.UpdateProject(project, &projects_models.Project{
+		Name:                  project.Name,
+		MaxLogsAmount:         10,
+		MaxLogsLifeDays:       1,
+		CleanupWebhookURL:     webhook.server.URL,
+		CleanupNoticeMinutes:  30,
+		IsConfirmQuotaDisable: true,
+	}, owner.Token, router)
+
+	// 15 logs 4 hours apart, the 12 oldest are older than a day
+	repository := logs_core.GetLogCoreRepository()
+	firstLogTime := time.Now().UTC().Add(-72 * time.Hour)
+	var allEntries map[uuid.UUID][]*logs_core.LogItem
+	for i := range 15 {
+		entries := logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+			project.ID,
+			firstLogTime.Add(time.Duration(i)*4*time.Hour),
+			"Log message for cleanup preview test",
+			map[string]any{"test_session": uniqueID, "log_index": i},
+		)
+		if allEntries == nil {
+			allEntries = entries
+		} else {
+			allEntries = logs_core_tests.MergeLogEntries(allEntries, entries)
+		}
+	}
+	logs_core_tests.StoreTestLogsAndFlush(t, repository, allEntries)
+
+	preview := previewCleanup(t, router, project.ID, owner.Token, http.StatusOK)
+	assert.Equal(t, project.ID, preview.ProjectID)
+	assert.Equal(t, int64(15), preview.Stats.TotalLogs)
+
+	countCutoff := findCleanupCutoff(preview, logs_cleanup.CleanupReasonCountQuota)
+	retentionCutoff := findCleanupCutoff(preview, logs_cleanup.CleanupReasonRetention)
+	assert.Nil(t, findCleanupCutoff(preview, logs_cleanup.CleanupReasonSizeQuota))
+	if !assert.NotNil(t, countCutoff) || !assert.NotNil(t, retentionCutoff) {
+		return
+	}
+	assert.Nil(t, countCutoff.ScheduledAt)
+	assert.True(t, countCutoff.DeleteOlderThan.After(firstLogTime))
+	assert.WithinDuration(t, preview.CalculatedAt.AddDate(0, 0, -1), retentionCutoff.DeleteOlderThan, time.Second)
+
+	cleanupService := logs_cleanup.GetLogCleanupBackgroundService()
+	assert.NoError(t, cleanupService.ExecuteAllTasksForTestAt(time.Now().UTC()))
+
+	notices := map[logs_cleanup.CleanupReason]logs_cleanup.CleanupNoticeDTO{}
+	for _, notice := range webhook.getNotices() {
+		notices[notice.Reason] = notice
+	}
+
+	// Quota cutoffs depend only on the stats, retention on the time of the run
+	if assert.Contains(t, notices, logs_cleanup.CleanupReasonCountQuota) {
+		countNotice := notices[logs_cleanup.CleanupReasonCountQuota]
+		assert.True(t, countCutoff.DeleteOlderThan.Equal(countNotice.DeleteOlderThan),
+			"previewed %s, cleanup used %s", countCutoff.DeleteOlderThan, countNotice.DeleteOlderThan)
+	}
+	if assert.Contains(t, notices, logs_cleanup.CleanupReasonRetention) {
+		retentionNotice := notices[logs_cleanup.CleanupReasonRetention]
+		assert.WithinDuration(t, retentionNotice.DeleteOlderThan, retentionCutoff.DeleteOlderThan, 5*time.Second)
+	}
+
+	// Once notified, the preview tells when the deletion is due
+	preview = previewCleanup(t, router, project.ID, owner.Token, http.StatusOK)
+	countCutoff = findCleanupCutoff(preview, logs_cleanup.CleanupReasonCountQuota)
+	if assert.NotNil(t, countCutoff) && assert.NotNil(t, countCutoff.ScheduledAt) {
+		assert.WithinDuration(
+			t, notices[logs_cleanup.CleanupReasonCountQuota].ScheduledAt, *countCutoff.ScheduledAt, time.Second,
+		)
+	}
+}
+
+func Test_PreviewCleanup_WithoutLimits_ReturnsNoCutoffs(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	repository := logs_core.GetLogCoreRepository()
+
+	project := projects_testing.CreateTestProject("Cleanup Preview No Limits "+uuid.New().String()[:8], owner, router)
+	projects_testing.UpdateProject(project, &projects_models.Project{
+		Name:                  project.Name,
+		IsConfirmQuotaDisable: true,
+	}, owner.Token, router)
+	storePurgeTestLogs(t, repository, project.ID, 3)
+
+	preview := previewCleanup(t, router, project.ID, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(3), preview.Stats.TotalLogs)
+	assert.Empty(t, preview.Cutoffs)
+}
+
+func Test_PreviewCleanup_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project := projects_testing.CreateTestProject("Cleanup Preview Member "+uuid.New().String()[:8], owner, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	previewCleanup(t, router, project.ID, member.Token, http.StatusForbidden)
+}
+
+func previewCleanup(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	expectedStatus int,
+) *logs_cleanup.CleanupPreviewDTO {
+	var preview logs_cleanup.CleanupPreviewDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/logs/cleanup/preview/"+projectID.String(),
+		"Bearer "+token,
+		expectedStatus,
+		&preview,
+	)
+
+	return &preview
+}
+
+func findCleanupCutoff(
+	preview *logs_cleanup.CleanupPreviewDTO,
+	reason logs_cleanup.CleanupReason,
+) *logs_cleanup.CleanupCutoffDTO {
+	for i := range preview.Cutoffs {
+		if preview.Cutoffs[i].Reason == reason {
+			return &preview.Cutoffs[i]
+		}
+	}
+
+	return nil
+}
@@ -32,8 +32,9 @@ func Test_EnforceProjectQuotas_WhenLogCountExceedsMaxLogsAmount_DeletesOldestLog
 
 	// Update project to set MaxLogsAmount to 10 logs
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsAmount: 10, // 10 logs limit
+		Name:                  project.Name,
+		MaxLogsAmount:         10, // 10 logs limit
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -142,8 +143,9 @@ func Test_EnforceProjectQuotas_WhenLogCountIsWithinMaxLogsAmount_NoLogsDeleted(t
 
 	// Update project to set MaxLogsAmount to 50 logs (large enough to not trigger cleanup)
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsAmount: 50, // 50 logs limit - large enough to not trigger cleanup
+		Name:                  project.Name,
+		MaxLogsAmount:         50, // 50 logs limit - large enough to not trigger cleanup
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -248,8 +250,9 @@ func Test_EnforceProjectQuotas_WhenMaxLogsAmountIsZero_NoQuotaEnforcement(t *tes
 
 	// Update project to set MaxLogsAmount to 0 (no count-based quota)
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsAmount: 0, // No count quota enforcement
+		Name:                  project.Name,
+		MaxLogsAmount:         0, // No count quota enforcement
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -356,8 +359,9 @@ func Test_EnforceProjectQuotas_WhenLogCountExceedsQuota_DeletesToNinetyPercentOf
 	// Update project to set MaxLogsAmount to 100 logs
 	// According to calculateCleanupPercentage with no size quota (0), it should target 85%
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsAmount: 100, // 100 logs limit, should clean up to 85% = 85 logs
+		Name:                  project.Name,
+		MaxLogsAmount:         100, // 100 logs limit, should clean up to 85% = 85 logs
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -489,15 +493,17 @@ func Test_EnforceProjectQuotas_WithDifferentProjectsCountQuotas_DeletesOnlyTarge
 	// Set different MaxLogsAmount for each project
 	// Project 1: 10 logs quota (will exceed and trigger cleanup)
 	updateData1 := &projects_models.Project{
-		Name:          project1.Name,
-		MaxLogsAmount: 10, // 10 logs limit - will be exceeded
+		Name:                  project1.Name,
+		MaxLogsAmount:         10, // 10 logs limit - will be exceeded
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project1, updateData1, owner1.Token, router)
 
 	// Project 2: 100 logs quota (will NOT exceed)
 	updateData2 := &projects_models.Project{
-		Name:          project2.Name,
-		MaxLogsAmount: 100, // 100 logs limit - will not be exceeded
+		Name:                  project2.Name,
+		MaxLogsAmount:         100, // 100 logs limit - will not be exceeded
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project2, updateData2, owner2.Token, router)
 
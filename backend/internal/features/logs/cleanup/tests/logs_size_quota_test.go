@@ -34,8 +34,9 @@ func Test_EnforceProjectQuotas_WhenStorageSizeExceedsMaxLogsSizeMB_DeletesOldest
 
 	// Update project to set MaxLogsSizeMB to a small value (1 MB)
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsSizeMB: 1, // 1 MB limit
+		Name:                  project.Name,
+		MaxLogsSizeMB:         1, // 1 MB limit
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -151,8 +152,9 @@ func Test_EnforceProjectQuotas_WhenStorageSizeIsWithinMaxLogsSizeMB_NoLogsDelete
 
 	// Update project to set MaxLogsSizeMB to a large value (10 MB)
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsSizeMB: 10, // 10 MB limit - large enough to not trigger cleanup
+		Name:                  project.Name,
+		MaxLogsSizeMB:         10, // 10 MB limit - large enough to not trigger cleanup
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -266,8 +268,9 @@ func Test_EnforceProjectQuotas_WhenMaxLogsSizeMBIsZero_NoSizeQuotaEnforcement(t
 
 	// Update project to set MaxLogsSizeMB to 0 (no size-based quota)
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsSizeMB: 0, // No size quota enforcement
+		Name:                  project.Name,
+		MaxLogsSizeMB:         0, // No size quota enforcement
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -386,8 +389,9 @@ func Test_EnforceProjectQuotas_WhenStorageSizeExceedsQuota_DeletesToNinetyPercen
 	// Update project to set MaxLogsSizeMB to 1 MB (small quota)
 	// According to calculateCleanupPercentage, quotas <= 10MB target 85%
 	updateData := &projects_models.Project{
-		Name:          project.Name,
-		MaxLogsSizeMB: 1, // 1 MB limit, should clean up to 85% = 0.85MB
+		Name:                  project.Name,
+		MaxLogsSizeMB:         1, // 1 MB limit, should clean up to 85% = 0.85MB
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -525,15 +529,17 @@ func Test_EnforceProjectQuotas_WithDifferentProjectsSizeQuotas_DeletesOnlyTarget
 	// Set different MaxLogsSizeMB for each project
 	// Project 1: 1 MB quota (will exceed and trigger cleanup)
 	updateData1 := &projects_models.Project{
-		Name:          project1.Name,
-		MaxLogsSizeMB: 1, // 1 MB limit - will be exceeded
+		Name:                  project1.Name,
+		MaxLogsSizeMB:         1, // 1 MB limit - will be exceeded
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project1, updateData1, owner1.Token, router)
 
 	// Project 2: 10 MB quota (will NOT exceed)
 	updateData2 := &projects_models.Project{
-		Name:          project2.Name,
-		MaxLogsSizeMB: 10, // 10 MB limit - will not be exceeded
+		Name:                  project2.Name,
+		MaxLogsSizeMB:         10, // 10 MB limit - will not be exceeded
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project2, updateData2, owner2.Token, router)
 
@@ -32,8 +32,9 @@ func Test_EnforceLogRetention_WhenMaxLogsLifeDaysIsSet_DeletesLogsOlderThanReten
 
 	// Update project to set MaxLogsLifeDays to 7 days
 	updateData := &projects_models.Project{
-		Name:            project.Name,
-		MaxLogsLifeDays: 7,
+		Name:                  project.Name,
+		MaxLogsLifeDays:       7,
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -116,8 +117,9 @@ func Test_EnforceLogRetention_WhenMaxLogsLifeDaysIsZero_NoRetentionEnforcement(t
 
 	// Update project to set MaxLogsLifeDays to 0 (no retention)
 	updateData := &projects_models.Project{
-		Name:            project.Name,
-		MaxLogsLifeDays: 0,
+		Name:                  project.Name,
+		MaxLogsLifeDays:       0,
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -193,8 +195,9 @@ func Test_EnforceLogRetention_WhenMaxLogsLifeDaysIsNegative_NoRetentionEnforceme
 
 	// Update project to set MaxLogsLifeDays to -1 (no retention)
 	updateData := &projects_models.Project{
-		Name:            project.Name,
-		MaxLogsLifeDays: -1,
+		Name:                  project.Name,
+		MaxLogsLifeDays:       -1,
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project, updateData, owner.Token, router)
 
@@ -283,15 +286,17 @@ func Test_EnforceProjectQuotas_WithDifferentProjectsTimeQuotas_DeletesOnlyTarget
 	// Set different MaxLogsLifeDays for each project
 	// Project 1: 7 days retention (should delete old logs)
 	updateData1 := &projects_models.Project{
-		Name:            project1.Name,
-		MaxLogsLifeDays: 7,
+		Name:                  project1.Name,
+		MaxLogsLifeDays:       7,
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project1, updateData1, owner1.Token, router)
 
 	// Project 2: 30 days retention (should NOT delete old logs)
 	updateData2 := &projects_models.Project{
-		Name:            project2.Name,
-		MaxLogsLifeDays: 30,
+		Name:                  project2.Name,
+		MaxLogsLifeDays:       30,
+		IsConfirmQuotaDisable: true,
 	}
 	projects_testing.UpdateProject(project2, updateData2, owner2.Token, router)
 
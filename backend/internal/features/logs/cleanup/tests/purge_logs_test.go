@@ -0,0 +1,171 @@
+package logs_cleanup_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"logbull/internal/features/api_keys"
+	logs_cleanup "logbull/internal/features/logs/cleanup"
+	logs_core "logbull/internal/features/logs/core"
+	logs_core_tests "logbull/internal/features/logs/core/tests"
+	projects_controllers "logbull/internal/features/projects/controllers"
+	projects_dto "logbull/internal/features/projects/dto"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PurgeProjectLogs_WithConfirmedName_RemovesLogsAndKeepsProject(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	repository := logs_core.GetLogCoreRepository()
+
+	project := projects_testing.CreateTestProject("Purge Logs Test "+uuid.New().String()[:8], owner, router)
+	projects_testing.UpdateProject(project, &projects_models.Project{
+		Name:                  project.Name,
+		MaxLogsAmount:         5000,
+		IsConfirmQuotaDisable: true,
+	}, owner.Token, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+	apiKey := api_keys.CreateTestApiKey("Purge Test Key", project.ID, owner.Token, router)
+
+	storePurgeTestLogs(t, repository, project.ID, 5)
+	assertProjectLogsCount(t, repository, project.ID, 5)
+
+	purgeProjectLogs(t, router, project.ID, project.Name, owner.Token, http.StatusOK)
+
+	logs_core_tests.WaitForLogsDeletion(
+		t, repository, project.ID, &logs_core.LogQueryRequestDTO{Limit: 10}, 60*time.Second,
+	)
+
+	var projectAfterPurge projects_models.Project
+	test_utils.MakeGetRequestAndUnmarshal(
+		t, router, "/api/v1/projects/"+project.ID.String(), "Bearer "+owner.Token, http.StatusOK, &projectAfterPurge,
+	)
+	assert.Equal(t, project.Name, projectAfterPurge.Name)
+	assert.Equal(t, int64(5000), projectAfterPurge.MaxLogsAmount)
+
+	members := projects_testing.GetProjectMembers(project, owner.Token, router)
+	assert.Len(t, members.Members, 2)
+	assert.True(t, hasProjectMember(members, member.UserID))
+
+	var apiKeys api_keys.GetApiKeysResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t, router, "/api/v1/projects/api-keys/"+project.ID.String(), "Bearer "+owner.Token, http.StatusOK, &apiKeys,
+	)
+	if assert.Len(t, apiKeys.ApiKeys, 1) {
+		assert.Equal(t, apiKey.ID, apiKeys.ApiKeys[0].ID)
+	}
+}
+
+func Test_PurgeProjectLogs_WithWrongConfirmation_ReturnsBadRequestAndKeepsLogs(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	repository := logs_core.GetLogCoreRepository()
+
+	project := projects_testing.CreateTestProject("Purge Confirm Test "+uuid.New().String()[:8], owner, router)
+	storePurgeTestLogs(t, repository, project.ID, 3)
+
+	purgeProjectLogs(t, router, project.ID, "Another Project", owner.Token, http.StatusBadRequest)
+	purgeProjectLogs(t, router, project.ID, "", owner.Token, http.StatusBadRequest)
+
+	assertProjectLogsCount(t, repository, project.ID, 3)
+}
+
+func Test_PurgeProjectLogs_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	repository := logs_core.GetLogCoreRepository()
+
+	project := projects_testing.CreateTestProject("Purge Member Test "+uuid.New().String()[:8], owner, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+	storePurgeTestLogs(t, repository, project.ID, 3)
+
+	purgeProjectLogs(t, router, project.ID, project.Name, member.Token, http.StatusForbidden)
+
+	assertProjectLogsCount(t, repository, project.ID, 3)
+}
+
+func Test_PurgeProjectLogs_WhenUserIsProjectAdmin_RemovesLogs(t *testing.T) {
+	router := createPurgeTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	repository := logs_core.GetLogCoreRepository()
+
+	project := projects_testing.CreateTestProject("Purge Admin Test "+uuid.New().String()[:8], owner, router)
+	projects_testing.AddMemberToProject(project, admin, users_enums.ProjectRoleAdmin, owner.Token, router)
+	storePurgeTestLogs(t, repository, project.ID, 3)
+
+	purgeProjectLogs(t, router, project.ID, project.Name, admin.Token, http.StatusOK)
+
+	logs_core_tests.WaitForLogsDeletion(
+		t, repository, project.ID, &logs_core.LogQueryRequestDTO{Limit: 10}, 60*time.Second,
+	)
+}
+
+func createPurgeTestRouter() *gin.Engine {
+	return api_keys.CreateApiKeyTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+		logs_cleanup.GetLogCleanupController(),
+	)
+}
+
+func storePurgeTestLogs(t *testing.T, repository *logs_core.LogCoreRepository, projectID uuid.UUID, count int) {
+	now := time.Now().UTC()
+
+	var allEntries map[uuid.UUID][]*logs_core.LogItem
+	for i := range count {
+		entries := logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+			projectID,
+			now.Add(-time.Duration(i)*time.Second),
+			fmt.Sprintf("Purge test log %d", i),
+			map[string]any{"log_index": i},
+		)
+		if allEntries == nil {
+			allEntries = entries
+		} else {
+			allEntries = logs_core_tests.MergeLogEntries(allEntries, entries)
+		}
+	}
+
+	logs_core_tests.StoreTestLogsAndFlush(t, repository, allEntries)
+}
+
+func purgeProjectLogs(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	confirmProjectName string,
+	token string,
+	expectedStatus int,
+) {
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/logs/purge/"+projectID.String(),
+		"Bearer "+token,
+		logs_cleanup.PurgeProjectLogsRequestDTO{ConfirmProjectName: confirmProjectName},
+		expectedStatus,
+	)
+}
+
+func hasProjectMember(members *projects_dto.GetMembersResponseDTO, userID uuid.UUID) bool {
+	for _, member := range members.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,263 @@
+package logs_core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Indices of a UTC day hold logs of every project, a single archiving run
+	// never touches more of them than this
+	maxArchivedIndicesPerRun = 1000
+
+	// Logs are archived in batches found up front, only the copied ones are
+	// deleted from the searchable index
+	archiveBatchSize = 1000
+
+	archiveTaskPollInterval = 500 * time.Millisecond
+)
+
+// archiveIndexSettings make the archived tier cheaper than the searchable one:
+// no replicas and a denser codec, at the cost of slower queries
+var archiveIndexSettings = map[string]any{
+	"index": map[string]any{
+		"number_of_replicas": 0,
+		"codec":              "best_compression",
+	},
+}
+
+// ArchiveOldLogs moves project logs older than the time from the searchable
+// indices to the archived tier, index by index. Returns how many logs were moved.
+// Between copying and deleting an index, queries including archived logs may
+// see its logs twice
+func (repository *LogCoreRepository) ArchiveOldLogs(projectID uuid.UUID, olderThan time.Time) (int64, error) {
+	searchBody := map[string]any{
+		"size":  0,
+		"query": olderLogsQuery(projectID, olderThan),
+		"aggs": map[string]any{
+			"indices": map[string]any{
+				"terms": map[string]any{"field": "_index", "size": maxArchivedIndicesPerRun},
+			},
+		},
+	}
+
+	var indicesResponse openSearchIndicesAggregationResponse
+	if err := repository.postJSON("/"+repository.indexPattern+"/_search", searchBody, &indicesResponse); err != nil {
+		return 0, fmt.Errorf("failed to find logs to archive: %w", err)
+	}
+
+	var archivedLogs int64
+	for _, bucket := range indicesResponse.Aggregations.Indices.Buckets {
+		indexArchivedLogs, err := repository.archiveIndexLogs(projectID, olderThan, bucket.Key)
+		archivedLogs += indexArchivedLogs
+		if err != nil {
+			return archivedLogs, fmt.Errorf("failed to archive logs of index %s: %w", bucket.Key, err)
+		}
+	}
+
+	return archivedLogs, nil
+}
+
+// archiveIndexLogs copies the project logs of the searchable index to its archived
+// counterpart batch by batch and deletes each batch by its ids once it is copied.
+// Logs with past timestamps may be ingested into the index meanwhile, deleting by
+// the time range would lose them without archiving. Returns how many logs were moved
+func (repository *LogCoreRepository) archiveIndexLogs(
+	projectID uuid.UUID,
+	olderThan time.Time,
+	indexName string,
+) (int64, error) {
+	archiveIndexName := repository.archiveIndexFor(indexName)
+	if err := repository.ensureArchiveIndex(indexName, archiveIndexName); err != nil {
+		return 0, err
+	}
+
+	var archivedLogs int64
+	var searchAfter []json.RawMessage
+	for {
+		logIDs, lastSort, err := repository.findLogIDsToArchive(projectID, olderThan, indexName, searchAfter)
+		if err != nil {
+			return archivedLogs, err
+		}
+		if len(logIDs) == 0 {
+			return archivedLogs, nil
+		}
+
+		batchQuery := map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
+					map[string]any{"ids": map[string]any{"values": logIDs}},
+				},
+			},
+		}
+
+		reindexBody := map[string]any{
+			"source": map[string]any{"index": indexName, "query": batchQuery},
+			"dest":   map[string]any{"index": archiveIndexName},
+		}
+		if err := repository.runTask("/_reindex?refresh=true&wait_for_completion=false", reindexBody); err != nil {
+			return archivedLogs, fmt.Errorf("failed to copy logs to the archive: %w", err)
+		}
+
+		deletePath := "/" + indexName + "/_delete_by_query?conflicts=proceed&refresh=true&wait_for_completion=false" +
+			"&routing=" + projectID.String()
+		if err := repository.runTask(deletePath, map[string]any{"query": batchQuery}); err != nil {
+			return archivedLogs, fmt.Errorf("failed to delete archived logs: %w", err)
+		}
+
+		archivedLogs += int64(len(logIDs))
+		searchAfter = lastSort
+	}
+}
+
+// findLogIDsToArchive returns the ids of the next batch of project logs older
+// than the time, oldest first, and the sort values to continue after it
+func (repository *LogCoreRepository) findLogIDsToArchive(
+	projectID uuid.UUID,
+	olderThan time.Time,
+	indexName string,
+	searchAfter []json.RawMessage,
+) ([]string, []json.RawMessage, error) {
+	searchBody := map[string]any{
+		"size":    archiveBatchSize,
+		"query":   olderLogsQuery(projectID, olderThan),
+		"_source": false,
+		"sort": []any{
+			map[string]any{"timestamp": map[string]any{"order": "asc"}},
+			map[string]any{"id.keyword": map[string]any{"order": "asc"}},
+		},
+	}
+	if len(searchAfter) > 0 {
+		searchBody["search_after"] = searchAfter
+	}
+
+	var searchResponse openSearchSearchResponse
+	searchPath := "/" + indexName + "/_search?routing=" + projectID.String()
+	if err := repository.postJSON(searchPath, searchBody, &searchResponse); err != nil {
+		return nil, nil, fmt.Errorf("failed to find logs to archive: %w", err)
+	}
+
+	hits := searchResponse.Hits.Hits
+	if len(hits) == 0 {
+		return nil, nil, nil
+	}
+
+	logIDs := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		logIDs = append(logIDs, hit.ID)
+	}
+
+	return logIDs, hits[len(hits)-1].Sort, nil
+}
+
+// ensureArchiveIndex creates the archived index with the mapping of the searchable
+// one, so fields keep their types (e.g. numbers and n-grams). An existing archived
+// index gets the fields the searchable index was mapped with since
+func (repository *LogCoreRepository) ensureArchiveIndex(indexName string, archiveIndexName string) error {
+	var mappingResponse map[string]struct {
+		Mappings map[string]any `json:"mappings"`
+	}
+	if err := repository.getJSON("/"+indexName+"/_mapping", &mappingResponse); err != nil {
+		return fmt.Errorf("failed to get mapping of index %s: %w", indexName, err)
+	}
+	mapping := mappingResponse[indexName].Mappings
+
+	statusCode, responseBody, err := repository.putJSON("/"+archiveIndexName, map[string]any{
+		"settings": archiveIndexSettings,
+		"mappings": mapping,
+	})
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusBadRequest && strings.Contains(string(responseBody), "resource_already_exists") {
+		if len(mapping) == 0 {
+			return nil
+		}
+
+		statusCode, responseBody, err = repository.putJSON("/"+archiveIndexName+"/_mapping", mapping)
+		if err != nil {
+			return err
+		}
+	}
+
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned status %d: %s", statusCode, string(responseBody))
+	}
+
+	return nil
+}
+
+// runTask starts the operation as an OpenSearch task and polls it until it completes,
+// so long copies and deletions are bounded by the repository timeout rather than
+// by the timeout of a single request
+func (repository *LogCoreRepository) runTask(path string, body any) error {
+	var startResponse openSearchTaskStartResponse
+	if err := repository.postJSON(path, body, &startResponse); err != nil {
+		return err
+	}
+	if startResponse.Task == "" {
+		return errors.New("OpenSearch did not return a task")
+	}
+
+	deadline := time.Now().Add(repository.timeout)
+	for {
+		var taskResponse openSearchTaskResponse
+		if err := repository.getJSON("/_tasks/"+startResponse.Task, &taskResponse); err != nil {
+			return fmt.Errorf("failed to get task %s: %w", startResponse.Task, err)
+		}
+
+		if taskResponse.Completed {
+			if len(taskResponse.Error) > 0 {
+				return fmt.Errorf("task %s failed: %s", startResponse.Task, string(taskResponse.Error))
+			}
+
+			if len(taskResponse.Response.Failures) > 0 {
+				failures, _ := json.Marshal(taskResponse.Response.Failures)
+				return fmt.Errorf("task %s reported failures: %s", startResponse.Task, string(failures))
+			}
+
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("task %s did not complete within %s", startResponse.Task, repository.timeout)
+		}
+
+		time.Sleep(archiveTaskPollInterval)
+	}
+}
+
+// olderLogsQuery matches project logs older than the time
+func olderLogsQuery(projectID uuid.UUID, olderThan time.Time) map[string]any {
+	return map[string]any{
+		"bool": map[string]any{
+			"filter": []any{
+				map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
+				map[string]any{
+					"range": map[string]any{
+						"timestamp": map[string]any{"lt": olderThan.UTC().UnixNano()},
+					},
+				},
+			},
+		},
+	}
+}
+
+// archiveIndexFor returns the archived index of the searchable index, both
+// cover the same UTC day
+func (repository *LogCoreRepository) archiveIndexFor(indexName string) string {
+	return repository.archiveIndexPrefix + strings.TrimPrefix(indexName, repository.indexPrefix)
+}
+
+// allTiersPattern matches the searchable and the archived indices
+func (repository *LogCoreRepository) allTiersPattern() string {
+	return repository.indexPattern + "," + repository.archiveIndexPattern
+}
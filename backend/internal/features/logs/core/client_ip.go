@@ -0,0 +1,25 @@
+package logs_core
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// NormalizeClientIP converts an IP address to the canonical form it is stored
+// in: IPv6 addresses lowercased and compressed (RFC 5952), IPv4-mapped IPv6
+// addresses as plain IPv4 and ports or brackets stripped. It is applied both
+// at ingestion and to client_ip conditions of queries, so "2001:DB8:0::1"
+// matches stored "2001:db8::1". Values which are not IP addresses are returned trimmed
+func NormalizeClientIP(value string) string {
+	value = strings.TrimSpace(value)
+
+	if addrPort, err := netip.ParseAddrPort(value); err == nil {
+		return addrPort.Addr().Unmap().String()
+	}
+
+	if addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")); err == nil {
+		return addr.Unmap().String()
+	}
+
+	return value
+}
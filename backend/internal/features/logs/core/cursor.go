@@ -0,0 +1,76 @@
+package logs_core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogCursor points at a position in the (timestamp, id) ordering of project logs.
+// Clients receive it as an opaque string and pass it back in the "after" field
+// to continue from that position without gaps or duplicates.
+type LogCursor struct {
+	TimestampNanos int64
+	ID             string
+}
+
+func EncodeLogCursor(cursor LogCursor) string {
+	raw := strconv.FormatInt(cursor.TimestampNanos, 10) + ":" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeLogCursor(value string) (*LogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("cursor is not valid base64")
+	}
+
+	timestampPart, idPart, found := strings.Cut(string(raw), ":")
+	if !found || idPart == "" {
+		return nil, errors.New("cursor has invalid format")
+	}
+
+	timestampNanos, err := strconv.ParseInt(timestampPart, 10, 64)
+	if err != nil {
+		return nil, errors.New("cursor has invalid timestamp")
+	}
+
+	return &LogCursor{TimestampNanos: timestampNanos, ID: idPart}, nil
+}
+
+// CursorAfterTimestamp points right after every log with the timestamp. Log ids
+// are UUIDs, so "~" sorts after all of them
+func CursorAfterTimestamp(timestamp time.Time) LogCursor {
+	return LogCursor{TimestampNanos: timestamp.UnixNano(), ID: "~"}
+}
+
+func (c LogCursor) isAfter(other LogCursor) bool {
+	if c.TimestampNanos != other.TimestampNanos {
+		return c.TimestampNanos > other.TimestampNanos
+	}
+
+	return c.ID > other.ID
+}
+
+// cursorFromSortValues reads the cursor from OpenSearch hit sort values. Raw
+// values are used so nanosecond timestamps are not rounded through float64
+func cursorFromSortValues(sortValues []json.RawMessage) (*LogCursor, bool) {
+	if len(sortValues) < 2 {
+		return nil, false
+	}
+
+	timestampNanos, err := strconv.ParseInt(string(sortValues[0]), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	var id string
+	if err := json.Unmarshal(sortValues[1], &id); err != nil || id == "" {
+		return nil, false
+	}
+
+	return &LogCursor{TimestampNanos: timestampNanos, ID: id}, true
+}
@@ -30,11 +30,22 @@ var logCoreRepository = &LogCoreRepository{
 	indexPrefix:  "logs-",
 	timeout:      5 * time.Minute,
 	logger:       logger.GetLogger(),
-	queryBuilder: &QueryBuilder{logger.GetLogger()},
+	queryBuilder: logQueryBuilder,
+
+	archiveIndexPattern: "archive-logs-*",
+	archiveIndexPrefix:  "archive-logs-",
+
+	ingestPipelineResolver:  &projectIngestPipelineResolver{projects_services.GetProjectService()},
+	ngramFieldsResolver:     ngramFieldsResolver,
+	messageOnlyModeResolver: &projectMessageOnlyModeResolver{projects_services.GetProjectService()},
 }
 
+var ngramFieldsResolver = &projectNgramFieldsResolver{projects_services.GetProjectService()}
+
 var logQueryBuilder = &QueryBuilder{
 	logger.GetLogger(),
+	DefaultTrackTotalHitsThreshold,
+	ngramFieldsResolver,
 }
 
 var logCoreService = &LogCoreService{
@@ -54,6 +65,23 @@ func GetUnavailableLogCoreRepository() *LogCoreRepository {
 	}
 }
 
+// GetLogCoreRepositoryForURL returns a repository talking to the given OpenSearch URL,
+// used by tests to point the repository to a stub server
+func GetLogCoreRepositoryForURL(baseURL string) *LogCoreRepository {
+	return &LogCoreRepository{
+		client:       &http.Client{Timeout: 10 * time.Second},
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		indexPattern: "logs-*",
+		indexPrefix:  "logs-",
+		timeout:      30 * time.Second,
+		logger:       logger.GetLogger(),
+		queryBuilder: &QueryBuilder{logger.GetLogger(), DefaultTrackTotalHitsThreshold, nil},
+
+		archiveIndexPattern: "archive-logs-*",
+		archiveIndexPrefix:  "archive-logs-",
+	}
+}
+
 func GetLogQueryBuilder() *QueryBuilder {
 	return logQueryBuilder
 }
@@ -1,16 +1,49 @@
 package logs_core
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // Repository DTOs for querying OpenSearch
 type LogQueryRequestDTO struct {
-	Query      *QueryNode    `json:"query,omitempty"`
-	TimeRange  *TimeRangeDTO `json:"timeRange,omitempty"`
-	Limit      int           `json:"limit,omitempty"`
-	Offset     int           `json:"offset,omitempty"`
-	SortBy     string        `json:"sortBy,omitempty"`    // always "timestamp" for now
-	SortOrder  string        `json:"sortOrder,omitempty"` // "asc" or "desc"
-	TrackTotal bool          `json:"trackTotal,omitempty"`
+	Query     *QueryNode    `json:"query,omitempty"`
+	TimeRange *TimeRangeDTO `json:"timeRange,omitempty"`
+	Limit     int           `json:"limit,omitempty"`
+	Offset    int           `json:"offset,omitempty"`
+	SortBy    string        `json:"sortBy,omitempty"`    // always "timestamp" for now
+	SortOrder string        `json:"sortOrder,omitempty"` // "asc" or "desc"
+	// TrackTotal counts every match exactly, otherwise counting stops at the
+	// track total hits threshold and the total becomes a lower bound
+	TrackTotal bool `json:"trackTotal,omitempty"`
+	// After continues from a cursor returned by a previous query. Results are
+	// always sorted ascending and timeRange.to becomes optional, which is used
+	// to tail new logs right after a historical page
+	After string `json:"after,omitempty"`
+	// IncludeAnnotations attaches review annotations to the returned logs
+	IncludeAnnotations bool `json:"includeAnnotations,omitempty"`
+	// AllHistory disables the default lookback when timeRange.from is not set,
+	// allowed for project owners and admins only
+	AllHistory bool `json:"allHistory,omitempty"`
+	// IncludeDiagnostics explains an empty result by counting the project logs
+	// with and without the time range, ignoring the query conditions
+	IncludeDiagnostics bool `json:"includeDiagnostics,omitempty"`
+	// Partitions restricts the search to the daily indices of these UTC dates
+	// (YYYY-MM-DD), other indices are not searched at all
+	Partitions []string `json:"partitions,omitempty"`
+	// IncludeArchived searches the archived tier of the project as well, which
+	// is slower. By default only the searchable (recent) logs are queried
+	IncludeArchived bool `json:"includeArchived,omitempty"`
+	// MinLevel matches logs at or above the severity (DEBUG < INFO < WARN <
+	// ERROR < FATAL) in addition to the query
+	MinLevel LogLevel `json:"minLevel,omitempty"`
+	// TimestampFormat serializes timestamp and createdAt of the returned logs
+	// as RFC3339 strings (default), epoch milliseconds or epoch seconds
+	TimestampFormat TimestampFormat `json:"timestampFormat,omitempty"`
+	// IncludeTiming adds a breakdown of where the query spent its time
+	IncludeTiming bool `json:"includeTiming,omitempty"`
 }
 
 type TimeRangeDTO struct {
@@ -19,11 +52,45 @@ type TimeRangeDTO struct {
 }
 
 type LogQueryResponseDTO struct {
-	Logs         []LogItemDTO `json:"logs"`
-	Total        int64        `json:"total"`
-	Limit        int          `json:"limit"`
-	Offset       int          `json:"offset"`
-	ExecutedInMs string       `json:"executedIn"`
+	Logs  []LogItemDTO `json:"logs"`
+	Total int64        `json:"total"`
+	// TotalRelation is "gte" when total is a lower bound (e.g. "10,000+")
+	TotalRelation TotalRelation `json:"totalRelation"`
+	Limit         int           `json:"limit"`
+	Offset        int           `json:"offset"`
+	ExecutedInMs  string        `json:"executedIn"`
+	// Cursor points at the newest log of the page (or echoes "after" when the
+	// page is empty) and can be passed as "after" to fetch what comes next
+	Cursor string `json:"cursor,omitempty"`
+	// AppliedTimeRange is set when the default lookback was applied to the query
+	AppliedTimeRange *TimeRangeDTO `json:"appliedTimeRange,omitempty"`
+	// Diagnostics is set when diagnostics were requested and nothing matched
+	Diagnostics *NoResultsDiagnosticsDTO `json:"diagnostics,omitempty"`
+	// Timing is set when timing was requested
+	Timing *QueryTimingDTO `json:"timing,omitempty"`
+}
+
+// QueryTimingDTO splits the query duration in milliseconds. OpenSearchTookMs is
+// what OpenSearch reports for the search itself, OpenSearchRequestMs adds the
+// network round trip and response parsing, TotalMs covers the whole request
+// including access checks, decryption and annotations
+type QueryTimingDTO struct {
+	ValidationMs        float64 `json:"validationMs"`
+	OpenSearchTookMs    int64   `json:"openSearchTookMs"`
+	OpenSearchRequestMs float64 `json:"openSearchRequestMs"`
+	TotalMs             float64 `json:"totalMs"`
+}
+
+// DurationMs converts a duration to fractional milliseconds, rounded to microseconds
+func DurationMs(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
+
+// NoResultsDiagnosticsDTO tells whether an empty result comes from the time
+// range (no logs in it) or from the query conditions (logs excluded by them)
+type NoResultsDiagnosticsDTO struct {
+	ProjectTotalLogs   int64 `json:"projectTotalLogs"`
+	TimeRangeTotalLogs int64 `json:"timeRangeTotalLogs"`
 }
 
 type LogItemDTO struct {
@@ -34,6 +101,43 @@ type LogItemDTO struct {
 	Fields    map[string]any `json:"fields,omitempty"`
 	ClientIP  string         `json:"clientIp,omitempty"`
 	CreatedAt time.Time      `json:"createdAt"`
+
+	Annotation *LogAnnotationDTO `json:"annotation,omitempty"`
+
+	timestampFormat TimestampFormat
+}
+
+// SetTimestampFormat changes how timestamp and createdAt are serialized to
+// JSON, the values themselves stay unchanged
+func (l *LogItemDTO) SetTimestampFormat(format TimestampFormat) {
+	l.timestampFormat = format
+}
+
+func (l LogItemDTO) MarshalJSON() ([]byte, error) {
+	type logItemFields LogItemDTO
+
+	switch l.timestampFormat {
+	case TimestampFormatEpochMillis:
+		return json.Marshal(struct {
+			logItemFields
+			Timestamp int64 `json:"timestamp"`
+			CreatedAt int64 `json:"createdAt"`
+		}{logItemFields(l), l.Timestamp.UnixMilli(), l.CreatedAt.UnixMilli()})
+	case TimestampFormatEpochSeconds:
+		return json.Marshal(struct {
+			logItemFields
+			Timestamp int64 `json:"timestamp"`
+			CreatedAt int64 `json:"createdAt"`
+		}{logItemFields(l), l.Timestamp.Unix(), l.CreatedAt.Unix()})
+	default:
+		return json.Marshal(logItemFields(l))
+	}
+}
+
+type LogAnnotationDTO struct {
+	Note       string     `json:"note"`
+	ReviewerID *uuid.UUID `json:"reviewerId,omitempty"`
+	ReviewedAt time.Time  `json:"reviewedAt"`
 }
 
 // QueryNode / Condition / Logic (same spirit as before)
@@ -52,6 +156,9 @@ type ConditionNode struct {
 	Field    string            `json:"field"`
 	Operator ConditionOperator `json:"operator"`
 	Value    any               `json:"value"`
+	// CaseInsensitive matches string values regardless of case, only for string
+	// operators which compare values
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
 }
 
 type QueryableField struct {
@@ -62,11 +169,112 @@ type QueryableField struct {
 }
 
 type GetQueryableFieldsRequestDTO struct {
-	Query string `form:"query" json:"query"`
+	Query  string `form:"query"  json:"query"`
+	Prefix string `form:"prefix" json:"prefix"`
+	Limit  int    `form:"limit"  json:"limit"`
+	Offset int    `form:"offset" json:"offset"`
+	// All returns the whole field list without pagination
+	All bool `form:"all" json:"all"`
 }
 
 type GetQueryableFieldsResponseDTO struct {
 	Fields []QueryableField `json:"fields"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+type DiscoverFieldsPageRequestDTO struct {
+	Prefix string
+	Search string
+	Limit  int
+	Offset int
+}
+
+type DiscoverFieldsPageDTO struct {
+	Fields []string
+	Total  int
+}
+
+// WindowComparisonCountsDTO holds the matches of each compared window and, when
+// grouped, the top values across all windows with their count in each window
+type WindowComparisonCountsDTO struct {
+	WindowTotals []int64
+	TopValues    []FieldValueWindowCountsDTO
+}
+
+type FieldValueWindowCountsDTO struct {
+	Value        string
+	WindowCounts []int64
+}
+
+// AggregateRequestDTO counts the logs matching the query per value of a field
+// for dashboard widgets, only the TopN most frequent values are returned
+type AggregateRequestDTO struct {
+	Query     *QueryNode    `json:"query,omitempty"`
+	TimeRange *TimeRangeDTO `json:"timeRange,omitempty"`
+	Field     string        `json:"field"`
+	TopN      int           `json:"topN,omitempty"`
+}
+
+type AggregateBucketDTO struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// GroupCountsDTO holds the matches of a query and its most frequent values of
+// the grouped field, sorted by count
+type GroupCountsDTO struct {
+	Total  int64
+	Groups []FieldValueCountDTO
+}
+
+type FieldValueCountDTO struct {
+	Value string
+	Count int64
+}
+
+// VolumePoint is the count of logs within the interval starting at Timestamp
+type VolumePoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int64     `json:"count"`
+}
+
+// TimeBucketLatestLogsDTO holds the latest log of each group within a bucket,
+// groups without logs in the bucket are left out
+type TimeBucketLatestLogsDTO struct {
+	TimeRange TimeRangeDTO
+	Groups    []GroupLatestLogDTO
+}
+
+type GroupLatestLogDTO struct {
+	Value string
+	// Count is the matches of the group within the bucket
+	Count int64
+	Log   LogItemDTO
+}
+
+// FieldSchemaDTO describes the custom fields of a project as discovered in its
+// stored logs, for documentation and for configuring downstream consumers
+type FieldSchemaDTO struct {
+	ProjectID   uuid.UUID `json:"projectId"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	TotalLogs   int64     `json:"totalLogs"`
+	// logs cardinality and occurrences are counted on, at most a sample per shard
+	SampledLogs int64                 `json:"sampledLogs"`
+	Fields      []FieldSchemaEntryDTO `json:"fields"`
+}
+
+type FieldSchemaEntryDTO struct {
+	Name string `json:"name"`
+	// string, number, boolean, object, array, null or mixed
+	Type string `json:"type"`
+	// types seen across logs when the type is mixed
+	Types []string `json:"types,omitempty"`
+	// approximate count of distinct values among sampled logs
+	Cardinality int64 `json:"cardinality"`
+	// sampled logs which have the field
+	Occurrences int64 `json:"occurrences"`
 }
 
 type ProjectLogStats struct {
@@ -74,6 +282,10 @@ type ProjectLogStats struct {
 	TotalSizeMB   float64   `json:"totalSizeMb"`
 	OldestLogTime time.Time `json:"oldestLogTime"`
 	NewestLogTime time.Time `json:"newestLogTime"`
+	// logs received before ingestion sampling, each stored log counts for the logs it stands for
+	EstimatedTotalLogs int64 `json:"estimatedTotalLogs"`
+	// estimated logs dropped by ingestion sampling, EstimatedTotalLogs minus TotalLogs
+	SampledOutLogs int64 `json:"sampledOutLogs"`
 }
 
 var PredefinedQueryableFields = []QueryableField{
@@ -83,6 +295,8 @@ var PredefinedQueryableFields = []QueryableField{
 		Operations: []ConditionOperator{
 			ConditionOperatorEquals, ConditionOperatorNotEquals,
 			ConditionOperatorContains, ConditionOperatorNotContains,
+			ConditionOperatorStartsWith, ConditionOperatorEndsWith,
+			ConditionOperatorRegex,
 		},
 	},
 	{
@@ -99,6 +313,8 @@ var PredefinedQueryableFields = []QueryableField{
 		Operations: []ConditionOperator{
 			ConditionOperatorEquals, ConditionOperatorNotEquals,
 			ConditionOperatorContains, ConditionOperatorNotContains,
+			ConditionOperatorStartsWith, ConditionOperatorEndsWith,
+			ConditionOperatorRegex,
 		},
 	},
 	{
@@ -108,6 +324,7 @@ var PredefinedQueryableFields = []QueryableField{
 			ConditionOperatorEquals, ConditionOperatorNotEquals,
 			ConditionOperatorGreaterThan, ConditionOperatorGreaterOrEqual,
 			ConditionOperatorLessThan, ConditionOperatorLessOrEqual,
+			ConditionOperatorBetween,
 		},
 	},
 	// Dynamic fields support
@@ -118,11 +335,34 @@ var PredefinedQueryableFields = []QueryableField{
 		Operations: []ConditionOperator{
 			ConditionOperatorEquals, ConditionOperatorNotEquals,
 			ConditionOperatorContains, ConditionOperatorNotContains,
+			ConditionOperatorStartsWith, ConditionOperatorEndsWith,
+			ConditionOperatorRegex,
+			ConditionOperatorGreaterThan, ConditionOperatorGreaterOrEqual,
+			ConditionOperatorLessThan, ConditionOperatorLessOrEqual,
 			ConditionOperatorExists, ConditionOperatorNotExists,
 		},
 	},
 }
 
+// ClusterHealthDTO summarizes OpenSearch cluster state for admin diagnostics
+type ClusterHealthDTO struct {
+	ClusterName       string           `json:"clusterName"`
+	Status            string           `json:"status"` // "green", "yellow" or "red"
+	NumberOfNodes     int              `json:"numberOfNodes"`
+	NumberOfDataNodes int              `json:"numberOfDataNodes"`
+	ActiveShards      int              `json:"activeShards"`
+	UnassignedShards  int              `json:"unassignedShards"`
+	TotalDocs         int64            `json:"totalDocs"`
+	Indices           []IndexHealthDTO `json:"indices"`
+}
+
+type IndexHealthDTO struct {
+	Name           string `json:"name"`
+	Health         string `json:"health"`
+	DocsCount      int64  `json:"docsCount"`
+	StoreSizeBytes int64  `json:"storeSizeBytes"`
+}
+
 // OpenSearch API DTOs (partial – only fields we need)
 
 type openSearchSearchResponse struct {
@@ -133,14 +373,82 @@ type openSearchSearchResponse struct {
 			Rel   string `json:"relation"`
 		} `json:"total"`
 		Hits []struct {
-			Index  string         `json:"_index"`
-			ID     string         `json:"_id"`
-			Source map[string]any `json:"_source"`
-			Sort   []any          `json:"sort,omitempty"`
+			Index  string            `json:"_index"`
+			ID     string            `json:"_id"`
+			Source map[string]any    `json:"_source"`
+			Sort   []json.RawMessage `json:"sort,omitempty"`
 		} `json:"hits"`
 	} `json:"hits"`
 }
 
+type openSearchWindowCountsAggregation struct {
+	Buckets []struct {
+		DocCount int64 `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+type openSearchWindowComparisonResponse struct {
+	Aggregations struct {
+		Windows   openSearchWindowCountsAggregation `json:"windows"`
+		TopValues struct {
+			Buckets []struct {
+				Key     string                            `json:"key"`
+				Windows openSearchWindowCountsAggregation `json:"windows"`
+			} `json:"buckets"`
+		} `json:"top_values"`
+	} `json:"aggregations"`
+}
+
+type openSearchGroupByResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+	} `json:"hits"`
+	Aggregations struct {
+		Groups struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount int64  `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"groups"`
+	} `json:"aggregations"`
+}
+
+type openSearchVolumeHistogramResponse struct {
+	Aggregations struct {
+		Volume struct {
+			Buckets []struct {
+				// start of the bucket in milliseconds
+				Key      int64 `json:"key"`
+				DocCount int64 `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"volume"`
+	} `json:"aggregations"`
+}
+
+type openSearchLatestPerGroupResponse struct {
+	Aggregations struct {
+		Buckets struct {
+			Buckets []struct {
+				Groups struct {
+					Buckets []struct {
+						Key      string `json:"key"`
+						DocCount int64  `json:"doc_count"`
+						Latest   struct {
+							Hits struct {
+								Hits []struct {
+									Source map[string]any `json:"_source"`
+								} `json:"hits"`
+							} `json:"hits"`
+						} `json:"latest"`
+					} `json:"buckets"`
+				} `json:"groups"`
+			} `json:"buckets"`
+		} `json:"buckets"`
+	} `json:"aggregations"`
+}
+
 type openSearchBulkResponse struct {
 	Errors bool `json:"errors"`
 	Items  []struct {
@@ -169,6 +477,9 @@ type openSearchStatsResponse struct {
 		TotalSizeBytes struct {
 			Value float64 `json:"value"`
 		} `json:"total_size_bytes"`
+		EstimatedTotalLogs struct {
+			Value float64 `json:"value"`
+		} `json:"estimated_total_logs"`
 		OldestLog struct {
 			Value         float64 `json:"value,omitempty"`
 			ValueAsString string  `json:"value_as_string,omitempty"`
@@ -179,3 +490,62 @@ type openSearchStatsResponse struct {
 		} `json:"newest_log"`
 	} `json:"aggregations"`
 }
+
+type openSearchClusterHealthResponse struct {
+	ClusterName       string `json:"cluster_name"`
+	Status            string `json:"status"`
+	NumberOfNodes     int    `json:"number_of_nodes"`
+	NumberOfDataNodes int    `json:"number_of_data_nodes"`
+	ActiveShards      int    `json:"active_shards"`
+	UnassignedShards  int    `json:"unassigned_shards"`
+}
+
+// _cat API returns numbers as strings
+type openSearchTaskStartResponse struct {
+	Task string `json:"task"`
+}
+
+type openSearchTaskResponse struct {
+	Completed bool `json:"completed"`
+	Response  struct {
+		Failures []json.RawMessage `json:"failures"`
+	} `json:"response"`
+	Error json.RawMessage `json:"error"`
+}
+
+type openSearchIndicesAggregationResponse struct {
+	Aggregations struct {
+		Indices struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount int64  `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"indices"`
+	} `json:"aggregations"`
+}
+
+type openSearchCatIndexResponse struct {
+	Index     string `json:"index"`
+	Health    string `json:"health"`
+	DocsCount string `json:"docs.count"`
+	StoreSize string `json:"store.size"`
+}
+
+type openSearchFieldSchemaAggregation struct {
+	DocCount int64 `json:"doc_count"`
+	Distinct struct {
+		Value int64 `json:"value"`
+	} `json:"distinct"`
+}
+
+// Sample holds doc_count and one aggregation per field, named by position
+type openSearchFieldSchemaResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+	} `json:"hits"`
+	Aggregations struct {
+		Sample map[string]json.RawMessage `json:"sample"`
+	} `json:"aggregations"`
+}
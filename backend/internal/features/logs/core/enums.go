@@ -1,5 +1,7 @@
 package logs_core
 
+import "strings"
+
 type LogLevel string
 
 const (
@@ -10,6 +12,42 @@ const (
 	LogLevelFatal LogLevel = "FATAL"
 )
 
+// TotalRelation tells whether a query total is exact or a lower bound
+type TotalRelation string
+
+const (
+	TotalRelationEq  TotalRelation = "eq"
+	TotalRelationGte TotalRelation = "gte"
+)
+
+// TimestampFormat selects how log timestamps are serialized in query
+// responses, storage always keeps them as dates
+type TimestampFormat string
+
+const (
+	TimestampFormatRFC3339      TimestampFormat = "rfc3339"
+	TimestampFormatEpochMillis  TimestampFormat = "epoch_millis"
+	TimestampFormatEpochSeconds TimestampFormat = "epoch_seconds"
+)
+
+func (f TimestampFormat) IsValid() bool {
+	switch f {
+	case TimestampFormatRFC3339, TimestampFormatEpochMillis, TimestampFormatEpochSeconds:
+		return true
+	default:
+		return false
+	}
+}
+
+// logLevelAliases maps level names clients commonly send to stored levels
+var logLevelAliases = map[string]LogLevel{
+	"WARNING":  LogLevelWarn,
+	"CRITICAL": LogLevelFatal,
+}
+
+// logLevelSeverityOrder lists the levels from the least to the most severe
+var logLevelSeverityOrder = []LogLevel{LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError, LogLevelFatal}
+
 func (l LogLevel) IsValid() bool {
 	switch l {
 	case LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError, LogLevelFatal:
@@ -19,6 +57,31 @@ func (l LogLevel) IsValid() bool {
 	}
 }
 
+// NormalizeLogLevel converts a level in any case (or an alias such as
+// "warning") to the stored form. It is applied both at ingestion and to
+// level conditions of queries, so "error" matches stored "ERROR". Unknown
+// values are only upper cased and stay invalid
+func NormalizeLogLevel(value string) LogLevel {
+	level := strings.ToUpper(strings.TrimSpace(value))
+	if alias, ok := logLevelAliases[level]; ok {
+		return alias
+	}
+
+	return LogLevel(level)
+}
+
+// LevelsAtOrAbove returns the levels at least as severe as the given one, e.g.
+// WARN, ERROR and FATAL for WARN. It returns nil for an unknown level
+func LevelsAtOrAbove(level LogLevel) []LogLevel {
+	for i, orderedLevel := range logLevelSeverityOrder {
+		if orderedLevel == level {
+			return logLevelSeverityOrder[i:]
+		}
+	}
+
+	return nil
+}
+
 type QueryNodeType string
 
 const (
@@ -32,6 +95,9 @@ const (
 	LogicalOperatorAnd LogicalOperator = "and"
 	LogicalOperatorOr  LogicalOperator = "or"
 	LogicalOperatorNot LogicalOperator = "not"
+	// NoneOf matches when none of its children match, a NOT over several
+	// children without nesting them in an OR
+	LogicalOperatorNoneOf LogicalOperator = "none_of"
 )
 
 type ConditionOperator string
@@ -42,6 +108,11 @@ const (
 	ConditionOperatorNotEquals   ConditionOperator = "not_equals"
 	ConditionOperatorContains    ConditionOperator = "contains"
 	ConditionOperatorNotContains ConditionOperator = "not_contains"
+	ConditionOperatorStartsWith  ConditionOperator = "starts_with"
+	ConditionOperatorEndsWith    ConditionOperator = "ends_with"
+
+	// Pattern anywhere in the value, RE2 syntax translated to Lucene
+	ConditionOperatorRegex ConditionOperator = "regex"
 
 	// Numeric operations
 	ConditionOperatorGreaterThan    ConditionOperator = "greater_than"
@@ -49,6 +120,9 @@ const (
 	ConditionOperatorLessThan       ConditionOperator = "less_than"
 	ConditionOperatorLessOrEqual    ConditionOperator = "less_or_equal"
 
+	// Inclusive range in one condition, the value is [from, to]
+	ConditionOperatorBetween ConditionOperator = "between"
+
 	// Array operations
 	ConditionOperatorIn    ConditionOperator = "in"
 	ConditionOperatorNotIn ConditionOperator = "not_in"
@@ -56,6 +130,10 @@ const (
 	// Existence operations
 	ConditionOperatorExists    ConditionOperator = "exists"
 	ConditionOperatorNotExists ConditionOperator = "not_exists"
+
+	// Absence across several fields, the value lists the fields and the field is empty
+	ConditionOperatorMissingAny ConditionOperator = "missing_any"
+	ConditionOperatorMissingAll ConditionOperator = "missing_all"
 )
 
 type QueryableFieldType string
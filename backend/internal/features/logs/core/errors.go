@@ -24,13 +24,29 @@ const (
 	ErrorBatchTooLarge        = "BATCH_TOO_LARGE"
 	ErrorMessageEmpty         = "MESSAGE_EMPTY"
 	ErrorFutureTimestamp      = "FUTURE_TIMESTAMP"
+	ErrorInvalidFieldName     = "INVALID_FIELD_NAME"
+	ErrorIngestTokenInvalid   = "INGEST_TOKEN_INVALID"
+	ErrorInvalidUsageRange    = "INVALID_USAGE_RANGE"
+	ErrorLevelNotAllowed      = "LEVEL_NOT_ALLOWED"
+	ErrorLogProcessingFailed  = "LOG_PROCESSING_FAILED"
 )
 
 // Error codes for log querying
 const (
 	ErrorTooManyConcurrentQueries = "TOO_MANY_CONCURRENT_QUERIES"
+	ErrorQueryCapacityExceeded    = "QUERY_CAPACITY_EXCEEDED"
 	ErrorInvalidQueryStructure    = "INVALID_QUERY_STRUCTURE"
 	ErrorQueryTimeout             = "QUERY_TIMEOUT"
 	ErrorQueryTooComplex          = "QUERY_TOO_COMPLEX"
 	ErrorMissingTimeRangeTo       = "MISSING_TIME_RANGE_TO"
+	ErrorTraceIdFieldNotSet       = "TRACE_ID_FIELD_NOT_SET"
+	ErrorInvalidCursor            = "INVALID_CURSOR"
+	ErrorSavedQueryInvalid        = "SAVED_QUERY_INVALID"
+	ErrorFieldMasked              = "FIELD_MASKED"
+	ErrorFieldEncrypted           = "FIELD_ENCRYPTED"
+	ErrorInvalidPartition         = "INVALID_PARTITION"
+	ErrorResultWindowExceeded     = "RESULT_WINDOW_EXCEEDED"
+	ErrorLogNotFound              = "LOG_NOT_FOUND"
+	ErrorQueryCancelled           = "QUERY_CANCELLED"
+	ErrorRunningQueryNotFound     = "RUNNING_QUERY_NOT_FOUND"
 )
@@ -0,0 +1,222 @@
+package logs_core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EncryptedValuePrefix marks field values stored as ciphertext
+const EncryptedValuePrefix = "enc:v1:"
+
+var ErrEncryptedFieldOperator = errors.New("operator is not supported on encrypted field")
+
+// FieldCipher encrypts custom field values with a project key. Encryption is
+// deterministic (the nonce is derived from the value), so equal values give
+// equal ciphertexts and equals/in conditions still work by encrypting the
+// condition value. Values are encrypted in their JSON form to keep the type
+type FieldCipher struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// NewFieldCipher derives the encryption and nonce keys from the base64 encoded
+// project key
+func NewFieldCipher(encodedKey string) (*FieldCipher, error) {
+	projectKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(projectKey) == 0 {
+		return nil, errors.New("invalid field encryption key")
+	}
+
+	block, err := aes.NewCipher(deriveKey(projectKey, "logbull field encryption"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create field cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create field cipher: %w", err)
+	}
+
+	return &FieldCipher{
+		aead:     aead,
+		nonceKey: deriveKey(projectKey, "logbull field nonce"),
+	}, nil
+}
+
+func (c *FieldCipher) EncryptValue(value any) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode field value: %w", err)
+	}
+
+	nonce := deriveKey(c.nonceKey, string(plaintext))[:c.aead.NonceSize()]
+	sealed := c.aead.Seal(nonce, nonce, plaintext, nil)
+
+	return EncryptedValuePrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func (c *FieldCipher) DecryptValue(value string) (any, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, EncryptedValuePrefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return nil, errors.New("malformed encrypted field value")
+	}
+
+	nonceSize := c.aead.NonceSize()
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt field value")
+	}
+
+	var decrypted any
+	if err := json.Unmarshal(plaintext, &decrypted); err != nil {
+		return nil, fmt.Errorf("failed to decode field value: %w", err)
+	}
+
+	return decrypted, nil
+}
+
+// EncryptFields replaces the values of the listed fields with ciphertext
+func (c *FieldCipher) EncryptFields(fields map[string]any, encryptedFields []string) error {
+	for _, fieldName := range encryptedFields {
+		value, exists := fields[fieldName]
+		if !exists || IsEncryptedValue(value) {
+			continue
+		}
+
+		encryptedValue, err := c.EncryptValue(value)
+		if err != nil {
+			return err
+		}
+
+		fields[fieldName] = encryptedValue
+	}
+
+	return nil
+}
+
+// DecryptLogs decrypts every encrypted value, including fields which were
+// removed from the encrypted list after the logs were stored. Values which
+// cannot be decrypted are returned as stored
+func (c *FieldCipher) DecryptLogs(logs []LogItemDTO) {
+	for _, log := range logs {
+		for fieldName, value := range log.Fields {
+			stringValue, ok := value.(string)
+			if !ok || !IsEncryptedValue(stringValue) {
+				continue
+			}
+
+			if decrypted, err := c.DecryptValue(stringValue); err == nil {
+				log.Fields[fieldName] = decrypted
+			}
+		}
+	}
+}
+
+func IsEncryptedValue(value any) bool {
+	stringValue, ok := value.(string)
+	return ok && strings.HasPrefix(stringValue, EncryptedValuePrefix)
+}
+
+// EncryptQueryValues returns a copy of the query where conditions on encrypted
+// fields compare ciphertexts. Only exact matching (equals, not_equals, in,
+// not_in) and existence checks are possible on ciphertext, other operators
+// return ErrEncryptedFieldOperator
+func EncryptQueryValues(node *QueryNode, encryptedFields []string, fieldCipher *FieldCipher) (*QueryNode, error) {
+	if node == nil || len(encryptedFields) == 0 {
+		return node, nil
+	}
+
+	encryptedNode := *node
+
+	if node.Condition != nil && slices.Contains(encryptedFields, strings.TrimSpace(node.Condition.Field)) {
+		condition, err := encryptConditionValue(node.Condition, fieldCipher)
+		if err != nil {
+			return nil, err
+		}
+
+		encryptedNode.Condition = condition
+	}
+
+	if node.Logic != nil {
+		logic := *node.Logic
+		logic.Children = make([]QueryNode, 0, len(node.Logic.Children))
+
+		for i := range node.Logic.Children {
+			child, err := EncryptQueryValues(&node.Logic.Children[i], encryptedFields, fieldCipher)
+			if err != nil {
+				return nil, err
+			}
+
+			logic.Children = append(logic.Children, *child)
+		}
+
+		encryptedNode.Logic = &logic
+	}
+
+	return &encryptedNode, nil
+}
+
+func encryptConditionValue(condition *ConditionNode, fieldCipher *FieldCipher) (*ConditionNode, error) {
+	encryptedCondition := *condition
+
+	switch condition.Operator {
+	case ConditionOperatorExists, ConditionOperatorNotExists:
+		return &encryptedCondition, nil
+
+	case ConditionOperatorEquals, ConditionOperatorNotEquals:
+		encryptedValue, err := fieldCipher.EncryptValue(condition.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		encryptedCondition.Value = encryptedValue
+		return &encryptedCondition, nil
+
+	case ConditionOperatorIn, ConditionOperatorNotIn:
+		var values []any
+		switch typedValue := condition.Value.(type) {
+		case []any:
+			values = typedValue
+		case []string:
+			for _, value := range typedValue {
+				values = append(values, value)
+			}
+		default:
+			return nil, fmt.Errorf("%s operator requires an array value", condition.Operator)
+		}
+
+		encryptedValues := make([]any, 0, len(values))
+		for _, value := range values {
+			encryptedValue, err := fieldCipher.EncryptValue(value)
+			if err != nil {
+				return nil, err
+			}
+
+			encryptedValues = append(encryptedValues, encryptedValue)
+		}
+
+		encryptedCondition.Value = encryptedValues
+		return &encryptedCondition, nil
+
+	default:
+		return nil, fmt.Errorf(
+			"%w: %s is encrypted and supports only equals, not_equals, in, not_in, exists and not_exists",
+			ErrEncryptedFieldOperator,
+			condition.Field,
+		)
+	}
+}
+
+func deriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,196 @@
+package logs_core
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Recent logs whose values decide the type of each field
+	fieldSchemaTypeSampleSize = 200
+
+	// Logs per shard cardinality and occurrences are counted on, so exporting the
+	// schema of a large project costs about the same as of a small one
+	fieldSchemaStatsSampleSize = 10000
+
+	FieldTypeString  = "string"
+	FieldTypeNumber  = "number"
+	FieldTypeBoolean = "boolean"
+	FieldTypeObject  = "object"
+	FieldTypeArray   = "array"
+	FieldTypeNull    = "null"
+	// the field holds values of different types across logs
+	FieldTypeMixed = "mixed"
+)
+
+// fieldValueScript returns the "field=value" token of the field, a log has at
+// most one per field. Indices without tokens at all have no such doc value
+const fieldValueScript = `if (!doc.containsKey('attrs_tokens.keyword')) { return null; }
+for (def token : doc['attrs_tokens.keyword']) { if (token.startsWith(params.prefix)) { return token; } }
+return null;`
+
+// GetFieldSchema returns the custom fields of the project with the type inferred
+// from recent values, the approximate count of distinct values and how many logs
+// have the field. Counts come from a sample of the project logs, which
+// SampledLogs reports
+func (repository *LogCoreRepository) GetFieldSchema(projectID uuid.UUID) (*FieldSchemaDTO, error) {
+	schema := &FieldSchemaDTO{
+		ProjectID:   projectID,
+		GeneratedAt: time.Now().UTC(),
+		Fields:      []FieldSchemaEntryDTO{},
+	}
+
+	fieldTypes, err := repository.inferFieldTypes(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldNames := make([]string, 0, len(fieldTypes))
+	for fieldName := range fieldTypes {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	slices.Sort(fieldNames)
+
+	sampleAggregation := map[string]any{
+		"sampler": map[string]any{"shard_size": fieldSchemaStatsSampleSize},
+	}
+
+	fieldAggregations := map[string]any{}
+	for i, fieldName := range fieldNames {
+		fieldAggregations[fieldSchemaAggregationName(i)] = map[string]any{
+			"filter": prefix("attrs_tokens.keyword", fieldName+"="),
+			"aggs": map[string]any{
+				"distinct": map[string]any{
+					"cardinality": map[string]any{
+						"script": map[string]any{
+							"source": fieldValueScript,
+							"params": map[string]any{"prefix": fieldName + "="},
+						},
+					},
+				},
+			},
+		}
+	}
+	if len(fieldAggregations) > 0 {
+		sampleAggregation["aggs"] = fieldAggregations
+	}
+
+	searchBody := map[string]any{
+		"size":             0,
+		"track_total_hits": true,
+		"query": map[string]any{"bool": map[string]any{
+			"filter": []any{
+				map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
+			},
+		}},
+		"aggs": map[string]any{"sample": sampleAggregation},
+	}
+
+	var schemaResponse openSearchFieldSchemaResponse
+	if err := repository.postJSON("/"+repository.indexPattern+"/_search", searchBody, &schemaResponse); err != nil {
+		return nil, fmt.Errorf("failed to count field values: %w", err)
+	}
+
+	schema.TotalLogs = schemaResponse.Hits.Total.Value
+
+	sample := schemaResponse.Aggregations.Sample
+	if docCount, exists := sample["doc_count"]; exists {
+		if err := json.Unmarshal(docCount, &schema.SampledLogs); err != nil {
+			return nil, fmt.Errorf("failed to parse sampled logs count: %w", err)
+		}
+	}
+
+	for i, fieldName := range fieldNames {
+		entry := FieldSchemaEntryDTO{Name: fieldName}
+
+		types := fieldTypes[fieldName]
+		if len(types) == 1 {
+			entry.Type = types[0]
+		} else {
+			entry.Type = FieldTypeMixed
+			entry.Types = types
+		}
+
+		if rawAggregation, exists := sample[fieldSchemaAggregationName(i)]; exists {
+			var fieldAggregation openSearchFieldSchemaAggregation
+			if err := json.Unmarshal(rawAggregation, &fieldAggregation); err != nil {
+				return nil, fmt.Errorf("failed to parse counts of field %s: %w", fieldName, err)
+			}
+
+			entry.Occurrences = fieldAggregation.DocCount
+			entry.Cardinality = fieldAggregation.Distinct.Value
+		}
+
+		schema.Fields = append(schema.Fields, entry)
+	}
+
+	return schema, nil
+}
+
+// inferFieldTypes returns the sorted types each custom field has in recent logs
+func (repository *LogCoreRepository) inferFieldTypes(projectID uuid.UUID) (map[string][]string, error) {
+	searchBody := map[string]any{
+		"size":    fieldSchemaTypeSampleSize,
+		"sort":    []any{map[string]any{"timestamp": map[string]any{"order": "desc"}}},
+		"_source": true,
+		"query": map[string]any{"bool": map[string]any{
+			"filter": []any{
+				map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
+			},
+		}},
+	}
+
+	var searchResponse openSearchSearchResponse
+	if err := repository.postJSON("/"+repository.indexPattern+"/_search", searchBody, &searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to sample field values: %w", err)
+	}
+
+	fieldTypes := map[string][]string{}
+	for _, hit := range searchResponse.Hits.Hits {
+		for fieldName, fieldValue := range hit.Source {
+			if systemFields[fieldName] || ignoredDiscoveryFields[fieldName] {
+				continue
+			}
+
+			fieldType := inferFieldType(fieldValue)
+			if !slices.Contains(fieldTypes[fieldName], fieldType) {
+				fieldTypes[fieldName] = append(fieldTypes[fieldName], fieldType)
+			}
+		}
+	}
+
+	for fieldName := range fieldTypes {
+		slices.Sort(fieldTypes[fieldName])
+	}
+
+	return fieldTypes, nil
+}
+
+// inferFieldType returns the JSON type of a value decoded from a stored log
+func inferFieldType(value any) string {
+	switch value.(type) {
+	case string:
+		return FieldTypeString
+	case float64, int, int64, json.Number:
+		return FieldTypeNumber
+	case bool:
+		return FieldTypeBoolean
+	case map[string]any:
+		return FieldTypeObject
+	case []any:
+		return FieldTypeArray
+	default:
+		return FieldTypeNull
+	}
+}
+
+// fieldSchemaAggregationName names aggregations by position, field names may
+// contain characters aggregation names cannot
+func fieldSchemaAggregationName(position int) string {
+	return "field_" + strconv.Itoa(position)
+}
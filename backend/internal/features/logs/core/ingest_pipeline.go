@@ -0,0 +1,22 @@
+package logs_core
+
+import (
+	projects_services "logbull/internal/features/projects/services"
+
+	"github.com/google/uuid"
+)
+
+// projectIngestPipelineResolver reads the pipeline from the project settings,
+// which are cached, so resolving it per bulk request is cheap
+type projectIngestPipelineResolver struct {
+	projectService *projects_services.ProjectService
+}
+
+func (r *projectIngestPipelineResolver) GetIngestPipeline(projectID uuid.UUID) (string, error) {
+	project, err := r.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return project.IngestPipeline, nil
+}
@@ -0,0 +1,36 @@
+package logs_core
+
+import (
+	projects_services "logbull/internal/features/projects/services"
+
+	"github.com/google/uuid"
+)
+
+// storedFieldsDocumentField holds the custom fields of message-only projects,
+// its mapping keeps them in the source without indexing them
+const storedFieldsDocumentField = "stored_fields"
+
+var storedFieldsMapping = map[string]any{
+	"properties": map[string]any{
+		storedFieldsDocumentField: map[string]any{"type": "object", "enabled": false},
+	},
+}
+
+// MessageOnlyModeResolver tells whether a project indexes its logs in
+// message-only mode, where custom fields are stored but not queryable
+type MessageOnlyModeResolver interface {
+	IsMessageOnlyMode(projectID uuid.UUID) (bool, error)
+}
+
+type projectMessageOnlyModeResolver struct {
+	projectService *projects_services.ProjectService
+}
+
+func (r *projectMessageOnlyModeResolver) IsMessageOnlyMode(projectID uuid.UUID) (bool, error) {
+	project, err := r.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return false, err
+	}
+
+	return project.IsMessageOnlyMode, nil
+}
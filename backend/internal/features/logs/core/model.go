@@ -14,4 +14,6 @@ type LogItem struct {
 	Message   string         `json:"message"`
 	Fields    map[string]any `json:"fields,omitempty"`
 	ClientIP  string         `json:"clientIp,omitempty"`
+	// logs this one stands for when ingestion sampling dropped the others, 0 when not sampled
+	SampleWeight float64 `json:"sampleWeight,omitempty"`
 }
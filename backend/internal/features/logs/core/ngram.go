@@ -0,0 +1,232 @@
+package logs_core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	projects_services "logbull/internal/features/projects/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// NgramSize is the length of the grams designated fields are indexed as,
+	// contains on shorter values falls back to the wildcard search
+	NgramSize = 3
+
+	// Values longer than this are not split into grams, the same bound the
+	// dynamic keyword mapping of attrs_tokens ignores values above
+	maxNgramValueLength = 256
+
+	ngramsDocumentField = "attrs_ngrams"
+)
+
+// ngramsMapping indexes each gram as a single token. Grams of a value are stored
+// as an array without a gap between elements, so they keep consecutive positions
+// and a span query over the grams of a searched value matches it as a substring
+var ngramsMapping = map[string]any{
+	"dynamic_templates": []any{
+		map[string]any{
+			ngramsDocumentField: map[string]any{
+				"path_match": ngramsDocumentField + ".*",
+				"mapping": map[string]any{
+					"type":                   "text",
+					"analyzer":               "keyword",
+					"position_increment_gap": 0,
+					"norms":                  false,
+				},
+			},
+		},
+	},
+}
+
+// NgramFieldsResolver returns the custom fields of a project which are indexed
+// as n-grams for partial match search
+type NgramFieldsResolver interface {
+	GetNgramFields(projectID uuid.UUID) ([]string, error)
+}
+
+type projectNgramFieldsResolver struct {
+	projectService *projects_services.ProjectService
+}
+
+func (r *projectNgramFieldsResolver) GetNgramFields(projectID uuid.UUID) ([]string, error) {
+	project, err := r.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.NgramFields, nil
+}
+
+// resolveNgramFields returns the n-gram fields of the project as a set. When they
+// cannot be resolved the project is treated as having none, so ingestion falls back
+// to the regular tokens and queries to wildcards
+func resolveNgramFields(resolver NgramFieldsResolver, projectID uuid.UUID) (map[string]bool, error) {
+	if resolver == nil {
+		return nil, nil
+	}
+
+	fields, err := resolver.GetNgramFields(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	fieldSet := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		fieldSet[field] = true
+	}
+
+	return fieldSet, nil
+}
+
+// buildNgrams splits the value into overlapping grams of NgramSize characters
+// in order. Values too short or too long to be indexed return nil
+func buildNgrams(value string) []string {
+	length := utf8.RuneCountInString(value)
+	if length < NgramSize || length > maxNgramValueLength {
+		return nil
+	}
+
+	runes := []rune(value)
+	grams := make([]string, 0, len(runes)-NgramSize+1)
+	for i := 0; i+NgramSize <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+NgramSize]))
+	}
+
+	return grams
+}
+
+// buildNgramContainsQuery matches values containing the given value through the
+// grams of the field. Values which cannot be expressed with grams (too short, or
+// with wildcard characters the wildcard path would interpret) return nil
+func buildNgramContainsQuery(fieldName string, value string) map[string]any {
+	if strings.ContainsAny(value, "*?") {
+		return nil
+	}
+
+	grams := buildNgrams(value)
+	if len(grams) == 0 {
+		return nil
+	}
+
+	ngramField := ngramsDocumentField + "." + fieldName
+	if len(grams) == 1 {
+		return term(ngramField, grams[0])
+	}
+
+	clauses := make([]any, 0, len(grams))
+	for _, gram := range grams {
+		clauses = append(clauses, map[string]any{"span_term": map[string]any{ngramField: gram}})
+	}
+
+	return map[string]any{
+		"span_near": map[string]any{
+			"clauses":  clauses,
+			"slop":     0,
+			"in_order": true,
+		},
+	}
+}
+
+// buildNgramsDocument returns grams of the designated fields of the log, nil when
+// none of them has a value which can be indexed
+func buildNgramsDocument(fields map[string]any, ngramFields map[string]bool) map[string]any {
+	var ngramsDocument map[string]any
+	for fieldName := range ngramFields {
+		fieldValue, exists := fields[fieldName]
+		if !exists || fieldValue == nil {
+			continue
+		}
+
+		grams := buildNgrams(fmt.Sprintf("%v", fieldValue))
+		if len(grams) == 0 {
+			continue
+		}
+
+		if ngramsDocument == nil {
+			ngramsDocument = map[string]any{}
+		}
+		ngramsDocument[fieldName] = grams
+	}
+
+	return ngramsDocument
+}
+
+// ensureNgramsMapping adds the grams mapping to the index before the first grams
+// are written to it, creating the index when it does not exist yet. Indices are
+// remembered once prepared, so it costs one request per daily index
+func (repository *LogCoreRepository) ensureNgramsMapping(indexName string) error {
+	return repository.ensureIndexMapping(&repository.ngramsMappedIndices, indexName, ngramsMapping)
+}
+
+// ensureIndexMapping puts the mapping on the index unless preparedIndices
+// already holds it, creating the index when it does not exist yet
+func (repository *LogCoreRepository) ensureIndexMapping(
+	preparedIndices *sync.Map,
+	indexName string,
+	mapping map[string]any,
+) error {
+	if _, isPrepared := preparedIndices.Load(indexName); isPrepared {
+		return nil
+	}
+
+	statusCode, responseBody, err := repository.putJSON("/"+indexName, map[string]any{"mappings": mapping})
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusBadRequest && strings.Contains(string(responseBody), "resource_already_exists") {
+		statusCode, responseBody, err = repository.putJSON("/"+indexName+"/_mapping", mapping)
+		if err != nil {
+			return err
+		}
+	}
+
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned status %d: %s", statusCode, string(responseBody))
+	}
+
+	preparedIndices.Store(indexName, true)
+	return nil
+}
+
+func (repository *LogCoreRepository) putJSON(path string, body any) (int, []byte, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	request, err := http.NewRequest("PUT", repository.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := repository.client.Do(request)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to connect to OpenSearch: %w", err)
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			repository.logger.Error("failed to close response body", "error", closeErr)
+		}
+	}()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return response.StatusCode, responseBody, nil
+}
@@ -0,0 +1,97 @@
+package logs_core
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const numbersDocumentField = "attrs_numbers"
+
+// numbersMapping indexes numeric values of custom fields as doubles, so range
+// operators compare them numerically whichever type the field got mapped as
+var numbersMapping = map[string]any{
+	"dynamic_templates": []any{
+		map[string]any{
+			numbersDocumentField: map[string]any{
+				"path_match": numbersDocumentField + ".*",
+				"mapping":    map[string]any{"type": "double"},
+			},
+		},
+	},
+}
+
+// buildNumbersDocument returns the numeric values of the custom fields of the
+// log, nil when none of them is numeric. Numbers sent as strings count too, so
+// clients which send a field both ways can still compare it
+func buildNumbersDocument(fields map[string]any) map[string]any {
+	var numbersDocument map[string]any
+	for fieldName, fieldValue := range fields {
+		if systemFields[fieldName] {
+			continue
+		}
+
+		number, isNumber := ToNumber(fieldValue)
+		if !isNumber {
+			continue
+		}
+
+		if numbersDocument == nil {
+			numbersDocument = make(map[string]any)
+		}
+		numbersDocument[fieldName] = number
+	}
+
+	return numbersDocument
+}
+
+// ToNumber converts numbers and numeric strings to a float, NaN and infinities
+// cannot be indexed so they are not numbers here
+func ToNumber(value any) (float64, bool) {
+	var number float64
+	switch typedValue := value.(type) {
+	case float64:
+		number = typedValue
+	case float32:
+		number = float64(typedValue)
+	case int:
+		number = float64(typedValue)
+	case int32:
+		number = float64(typedValue)
+	case int64:
+		number = float64(typedValue)
+	case uint:
+		number = float64(typedValue)
+	case uint32:
+		number = float64(typedValue)
+	case uint64:
+		number = float64(typedValue)
+	case json.Number:
+		parsed, err := typedValue.Float64()
+		if err != nil {
+			return 0, false
+		}
+		number = parsed
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(typedValue), 64)
+		if err != nil {
+			return 0, false
+		}
+		number = parsed
+	default:
+		return 0, false
+	}
+
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// ensureNumbersMapping maps the numeric values of the index as doubles before
+// the first log with one is indexed
+func (repository *LogCoreRepository) ensureNumbersMapping(indexName string) error {
+	return repository.ensureIndexMapping(&repository.numbersMappedIndices, indexName, numbersMapping)
+}
@@ -3,6 +3,7 @@ package logs_core
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"strconv"
 	"strings"
 	"time"
@@ -10,8 +11,30 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultTrackTotalHitsThreshold matches the OpenSearch default, totals above
+// it are reported as a lower bound unless the request asks for an exact count
+const DefaultTrackTotalHitsThreshold = 10_000
+
 type QueryBuilder struct {
-	logger *slog.Logger
+	logger                  *slog.Logger
+	trackTotalHitsThreshold int
+	ngramFieldsResolver     NgramFieldsResolver
+}
+
+func (builder *QueryBuilder) SetTrackTotalHitsThreshold(threshold int) {
+	builder.trackTotalHitsThreshold = threshold
+}
+
+func (builder *QueryBuilder) GetTrackTotalHitsThreshold() int {
+	return builder.trackTotalHitsThreshold
+}
+
+func (builder *QueryBuilder) SetNgramFieldsResolver(resolver NgramFieldsResolver) {
+	builder.ngramFieldsResolver = resolver
+}
+
+func (builder *QueryBuilder) GetNgramFieldsResolver() NgramFieldsResolver {
+	return builder.ngramFieldsResolver
 }
 
 // BuildSearchBody builds OpenSearch DSL body for the given project and structured request.
@@ -46,8 +69,16 @@ func (builder *QueryBuilder) BuildSearchBody(projectID uuid.UUID, request *LogQu
 		})
 	}
 
-	// User query
-	if queryNode := builder.buildQueryNode(request.Query); queryNode != nil {
+	// User query. The parts of a top level AND, the usual dashboard query, are
+	// filters of the same bool query as the project and the time range
+	ngramFields := builder.getNgramFields(projectID)
+	if isAndNode(request.Query) {
+		filterSlice, ok := boolQuery["filter"].([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid filter type in bool query")
+		}
+		boolQuery["filter"] = append(filterSlice, builder.buildAndParts(request.Query.Logic, ngramFields)...)
+	} else if queryNode := builder.buildQueryNode(request.Query, ngramFields); queryNode != nil {
 		// Attach to must
 		if _, exists := boolQuery["must"]; !exists {
 			boolQuery["must"] = []any{}
@@ -61,7 +92,7 @@ func (builder *QueryBuilder) BuildSearchBody(projectID uuid.UUID, request *LogQu
 
 	searchBody := map[string]any{
 		"query":            map[string]any{"bool": boolQuery},
-		"track_total_hits": true,
+		"track_total_hits": builder.buildTrackTotalHits(request),
 	}
 
 	// Sort
@@ -70,13 +101,26 @@ func (builder *QueryBuilder) BuildSearchBody(projectID uuid.UUID, request *LogQu
 		sortOrder = "asc"
 	}
 
-	// Use numeric timestamp for precise microsecond sorting
+	if request.After != "" {
+		cursor, err := DecodeLogCursor(request.After)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query cursor: %w", err)
+		}
+
+		// Continuing from a cursor only makes sense forward in time
+		sortOrder = "asc"
+		searchBody["search_after"] = []any{cursor.TimestampNanos, cursor.ID}
+	}
+
+	// Use numeric timestamp for precise microsecond sorting, id breaks ties so
+	// cursors point at an exact position
 	searchBody["sort"] = []any{
 		map[string]any{"timestamp": map[string]any{"order": sortOrder}},
+		map[string]any{"id.keyword": map[string]any{"order": sortOrder}},
 	}
 
-	// Pagination
-	if request.Offset > 0 {
+	// Pagination (search_after does not allow "from")
+	if request.Offset > 0 && request.After == "" {
 		searchBody["from"] = request.Offset
 	}
 	if request.Limit > 0 {
@@ -86,7 +130,197 @@ func (builder *QueryBuilder) BuildSearchBody(projectID uuid.UUID, request *LogQu
 	return searchBody, nil
 }
 
-func (builder *QueryBuilder) buildQueryNode(node *QueryNode) map[string]any {
+// BuildWindowComparisonBody counts the query matches in each window with one
+// search. When groupBy is set, the top values of the field across all windows
+// are counted per window as well
+func (builder *QueryBuilder) BuildWindowComparisonBody(
+	projectID uuid.UUID,
+	query *QueryNode,
+	windows []TimeRangeDTO,
+	groupBy string,
+	topValuesLimit int,
+) (map[string]any, error) {
+	searchBody, err := builder.BuildSearchBody(projectID, &LogQueryRequestDTO{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	windowFilters := buildWindowFilters(windows)
+	if err := filterToWindows(searchBody, windowFilters); err != nil {
+		return nil, err
+	}
+
+	windowsAggregation := map[string]any{"filters": map[string]any{"filters": windowFilters}}
+	aggregations := map[string]any{"windows": windowsAggregation}
+
+	if groupBy != "" {
+		aggregations["top_values"] = map[string]any{
+			"terms": builder.buildFieldTermsAggregation(groupBy, topValuesLimit),
+			"aggs":  map[string]any{"windows": windowsAggregation},
+		}
+	}
+
+	return map[string]any{
+		"size":             0,
+		"query":            searchBody["query"],
+		"track_total_hits": false,
+		"aggs":             aggregations,
+	}, nil
+}
+
+// BuildLatestPerGroupBody finds the latest log of each groupBy value within
+// every bucket. Buckets are exact half open windows, like the compared ones,
+// so the groups of a bucket are its top groupsLimit values ordered by value
+// and each carries one hit, the newest log of the group
+func (builder *QueryBuilder) BuildLatestPerGroupBody(
+	projectID uuid.UUID,
+	query *QueryNode,
+	buckets []TimeRangeDTO,
+	groupBy string,
+	groupsLimit int,
+) (map[string]any, error) {
+	searchBody, err := builder.BuildSearchBody(projectID, &LogQueryRequestDTO{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	bucketFilters := buildWindowFilters(buckets)
+	if err := filterToWindows(searchBody, bucketFilters); err != nil {
+		return nil, err
+	}
+
+	groups := builder.buildFieldTermsAggregation(groupBy, groupsLimit)
+	groups["order"] = map[string]any{"_key": "asc"}
+
+	latestLog := map[string]any{
+		"size": 1,
+		"sort": []any{
+			map[string]any{"timestamp": map[string]any{"order": "desc"}},
+			map[string]any{"id.keyword": map[string]any{"order": "desc"}},
+		},
+	}
+
+	return map[string]any{
+		"size":             0,
+		"query":            searchBody["query"],
+		"track_total_hits": false,
+		"aggs": map[string]any{
+			"buckets": map[string]any{
+				"filters": map[string]any{"filters": bucketFilters},
+				"aggs": map[string]any{
+					"groups": map[string]any{
+						"terms": groups,
+						"aggs":  map[string]any{"latest": map[string]any{"top_hits": latestLog}},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// BuildGroupByBody counts the query matches per value of the groupBy field.
+// Buckets are ordered by count and then by value, so pages of buckets taken
+// with a growing bucketsCount stay stable
+func (builder *QueryBuilder) BuildGroupByBody(
+	projectID uuid.UUID,
+	request *LogQueryRequestDTO,
+	groupBy string,
+	bucketsCount int,
+) (map[string]any, error) {
+	searchBody, err := builder.BuildSearchBody(projectID, &LogQueryRequestDTO{
+		Query:     request.Query,
+		TimeRange: request.TimeRange,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := builder.buildFieldTermsAggregation(groupBy, bucketsCount)
+	groups["order"] = []any{
+		map[string]any{"_count": "desc"},
+		map[string]any{"_key": "asc"},
+	}
+
+	return map[string]any{
+		"size":             0,
+		"query":            searchBody["query"],
+		"track_total_hits": true,
+		"aggs":             map[string]any{"groups": map[string]any{"terms": groups}},
+	}, nil
+}
+
+// buildFieldTermsAggregation aggregates custom fields over their "field=value"
+// tokens, the keys keep the "field=" prefix
+func (builder *QueryBuilder) buildFieldTermsAggregation(field string, size int) map[string]any {
+	terms := map[string]any{"size": size}
+	if builder.isSystemField(field) {
+		terms["field"] = builder.getSystemFieldName(field)
+	} else {
+		terms["field"] = "attrs_tokens.keyword"
+		terms["include"] = escapeLuceneRegexp(field+"=") + ".*"
+	}
+
+	return terms
+}
+
+// buildWindowFilters returns one timestamp range per window, from included
+// and to excluded
+func buildWindowFilters(windows []TimeRangeDTO) []any {
+	windowFilters := make([]any, 0, len(windows))
+	for _, window := range windows {
+		timeRange := map[string]any{}
+		if window.From != nil {
+			timeRange["gte"] = timestampToNanos(*window.From)
+		}
+		if window.To != nil {
+			timeRange["lt"] = timestampToNanos(*window.To)
+		}
+
+		windowFilters = append(windowFilters, map[string]any{"range": map[string]any{"timestamp": timeRange}})
+	}
+
+	return windowFilters
+}
+
+// filterToWindows limits the search body to logs within any of the windows
+func filterToWindows(searchBody map[string]any, windowFilters []any) error {
+	boolQuery, ok := searchBody["query"].(map[string]any)["bool"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid bool query in search body")
+	}
+	filterSlice, ok := boolQuery["filter"].([]any)
+	if !ok {
+		return fmt.Errorf("invalid filter type in bool query")
+	}
+	boolQuery["filter"] = append(filterSlice, map[string]any{
+		"bool": map[string]any{"should": windowFilters, "minimum_should_match": 1},
+	})
+
+	return nil
+}
+
+func (builder *QueryBuilder) buildTrackTotalHits(request *LogQueryRequestDTO) any {
+	if request.TrackTotal || builder.trackTotalHitsThreshold <= 0 {
+		return true
+	}
+
+	return builder.trackTotalHitsThreshold
+}
+
+// getNgramFields returns the n-gram fields of the project, when they cannot be
+// resolved contains falls back to wildcards, which match the same logs
+func (builder *QueryBuilder) getNgramFields(projectID uuid.UUID) map[string]bool {
+	ngramFields, err := resolveNgramFields(builder.ngramFieldsResolver, projectID)
+	if err != nil {
+		builder.logger.Warn("Failed to resolve n-gram fields, searching with wildcards",
+			"projectId", projectID.String(), "error", err)
+		return nil
+	}
+
+	return ngramFields
+}
+
+func (builder *QueryBuilder) buildQueryNode(node *QueryNode, ngramFields map[string]bool) map[string]any {
 	if node == nil {
 		return nil
 	}
@@ -96,21 +330,21 @@ func (builder *QueryBuilder) buildQueryNode(node *QueryNode) map[string]any {
 		if node.Condition == nil {
 			return nil
 		}
-		return builder.buildConditionNode(node.Condition)
+		return builder.buildConditionNode(node.Condition, ngramFields)
 	case QueryNodeTypeLogical:
 		if node.Logic == nil || len(node.Logic.Children) == 0 {
 			return nil
 		}
-		return builder.buildLogicalNode(node.Logic)
+		return builder.buildLogicalNode(node.Logic, ngramFields)
 	default:
 		return nil
 	}
 }
 
-func (builder *QueryBuilder) buildLogicalNode(logic *LogicalNode) map[string]any {
+func (builder *QueryBuilder) buildLogicalNode(logic *LogicalNode, ngramFields map[string]bool) map[string]any {
 	queryParts := make([]any, 0, len(logic.Children))
 	for _, child := range logic.Children {
-		if queryNode := builder.buildQueryNode(&child); queryNode != nil {
+		if queryNode := builder.buildQueryNode(&child, ngramFields); queryNode != nil {
 			queryParts = append(queryParts, queryNode)
 		}
 	}
@@ -123,15 +357,103 @@ func (builder *QueryBuilder) buildLogicalNode(logic *LogicalNode) map[string]any
 		return map[string]any{"bool": map[string]any{"must": queryParts}}
 	case LogicalOperatorOr:
 		return map[string]any{"bool": map[string]any{"should": queryParts, "minimum_should_match": 1}}
-	case LogicalOperatorNot:
-		// NOT over one or multiple => must_not
+	case LogicalOperatorNot, LogicalOperatorNoneOf:
+		// must_not excludes a document matching any of the parts
 		return map[string]any{"bool": map[string]any{"must_not": queryParts}}
 	default:
 		return nil
 	}
 }
 
-func (builder *QueryBuilder) buildConditionNode(condition *ConditionNode) map[string]any {
+// buildAndParts flattens nested ANDs into one list of parts and merges the
+// ranges on the same field, e.g. two timestamp bounds, into one range query
+func (builder *QueryBuilder) buildAndParts(logic *LogicalNode, ngramFields map[string]bool) []any {
+	queryParts := make([]any, 0, len(logic.Children))
+	for _, child := range logic.Children {
+		if isAndNode(&child) {
+			queryParts = append(queryParts, builder.buildAndParts(child.Logic, ngramFields)...)
+			continue
+		}
+
+		if queryNode := builder.buildQueryNode(&child, ngramFields); queryNode != nil {
+			queryParts = append(queryParts, queryNode)
+		}
+	}
+
+	return mergeRangeParts(queryParts)
+}
+
+func mergeRangeParts(queryParts []any) []any {
+	mergedParts := make([]any, 0, len(queryParts))
+	boundsByField := map[string]map[string]any{}
+
+	for _, queryPart := range queryParts {
+		field, bounds, isRange := asRangeQuery(queryPart)
+		if !isRange {
+			mergedParts = append(mergedParts, queryPart)
+			continue
+		}
+
+		if fieldBounds, exists := boundsByField[field]; exists && !hasAnyKey(fieldBounds, bounds) {
+			maps.Copy(fieldBounds, bounds)
+			continue
+		}
+
+		boundsByField[field] = bounds
+		mergedParts = append(mergedParts, queryPart)
+	}
+
+	return mergedParts
+}
+
+func asRangeQuery(queryPart any) (string, map[string]any, bool) {
+	queryMap, ok := queryPart.(map[string]any)
+	if !ok || len(queryMap) != 1 {
+		return "", nil, false
+	}
+
+	rangeMap, ok := queryMap["range"].(map[string]any)
+	if !ok || len(rangeMap) != 1 {
+		return "", nil, false
+	}
+
+	for field, bounds := range rangeMap {
+		boundsMap, ok := bounds.(map[string]any)
+		return field, boundsMap, ok
+	}
+
+	return "", nil, false
+}
+
+func hasAnyKey(target, source map[string]any) bool {
+	for key := range source {
+		if _, exists := target[key]; exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAndNode(node *QueryNode) bool {
+	return node != nil &&
+		node.Type == QueryNodeTypeLogical &&
+		node.Logic != nil &&
+		node.Logic.Operator == LogicalOperatorAnd
+}
+
+func (builder *QueryBuilder) buildConditionNode(condition *ConditionNode, ngramFields map[string]bool) map[string]any {
+	if condition.Operator == ConditionOperatorMissingAny || condition.Operator == ConditionOperatorMissingAll {
+		return builder.buildMissingFieldsNode(condition)
+	}
+
+	if condition.CaseInsensitive {
+		// Grams are stored as written, so n-gram fields fall back to wildcards
+		caseSensitiveCondition := *condition
+		caseSensitiveCondition.CaseInsensitive = false
+		return toCaseInsensitive(builder.buildConditionNode(&caseSensitiveCondition, nil))
+	}
+
 	fieldName := strings.TrimSpace(condition.Field)
 	if fieldName == "" {
 		return matchNone()
@@ -140,6 +462,13 @@ func (builder *QueryBuilder) buildConditionNode(condition *ConditionNode) map[st
 	// System fields mapped directly; unknown fields go via attrs strategy
 	isSystemField := builder.isSystemField(fieldName)
 
+	switch fieldName {
+	case "level":
+		condition = normalizeConditionValues(condition, normalizeLevelValue, strings.ToUpper)
+	case "client_ip":
+		condition = normalizeConditionValues(condition, NormalizeClientIP, strings.ToLower)
+	}
+
 	switch condition.Operator {
 	case ConditionOperatorEquals:
 		if isSystemField {
@@ -202,7 +531,7 @@ func (builder *QueryBuilder) buildConditionNode(condition *ConditionNode) map[st
 
 	case ConditionOperatorNotIn:
 		inCondition := (&ConditionNode{Field: fieldName, Operator: ConditionOperatorIn, Value: condition.Value})
-		return mustNot(builder.buildConditionNode(inCondition))
+		return mustNot(builder.buildConditionNode(inCondition, ngramFields))
 
 	case ConditionOperatorExists:
 		if isSystemField {
@@ -222,6 +551,11 @@ func (builder *QueryBuilder) buildConditionNode(condition *ConditionNode) map[st
 			// For strings: wildcard "*v*"; for message we also search in attrs_text
 			return wildcard(builder.getSystemFieldName(fieldName), fmt.Sprintf("*%v*", condition.Value))
 		}
+		if ngramFields[fieldName] {
+			if ngramQuery := buildNgramContainsQuery(fieldName, fmt.Sprintf("%v", condition.Value)); ngramQuery != nil {
+				return ngramQuery
+			}
+		}
 		// Search attrs_tokens.keyword using wildcard for fieldName=*value*
 		return wildcard("attrs_tokens.keyword", fmt.Sprintf("%s=*%v*", fieldName, condition.Value))
 
@@ -229,23 +563,212 @@ func (builder *QueryBuilder) buildConditionNode(condition *ConditionNode) map[st
 		if isSystemField {
 			return mustNot(wildcard(builder.getSystemFieldName(fieldName), fmt.Sprintf("*%v*", condition.Value)))
 		}
-		return mustNot(wildcard("attrs_tokens.keyword", fmt.Sprintf("%s=*%v*", fieldName, condition.Value)))
+		containsCondition := &ConditionNode{Field: fieldName, Operator: ConditionOperatorContains, Value: condition.Value}
+		return mustNot(builder.buildConditionNode(containsCondition, ngramFields))
+
+	case ConditionOperatorStartsWith:
+		if isSystemField {
+			return prefix(builder.getSystemFieldName(fieldName), fmt.Sprintf("%v", condition.Value))
+		}
+		return prefix("attrs_tokens.keyword", fmt.Sprintf("%s=%v", fieldName, condition.Value))
+
+	case ConditionOperatorEndsWith:
+		// The suffix is matched literally, unlike the pattern of contains
+		suffix := escapeWildcard(fmt.Sprintf("%v", condition.Value))
+		if isSystemField {
+			return wildcard(builder.getSystemFieldName(fieldName), "*"+suffix)
+		}
+		return wildcard("attrs_tokens.keyword", fmt.Sprintf("%s=*%s", fieldName, suffix))
+
+	case ConditionOperatorRegex:
+		pattern, err := ToLuceneRegexp(fmt.Sprintf("%v", condition.Value))
+		if err != nil {
+			return matchNone()
+		}
+		if isSystemField {
+			return regexpQuery(builder.getSystemFieldName(fieldName), pattern)
+		}
+		// The token is "field=value", the pattern applies to the value part
+		return regexpQuery("attrs_tokens.keyword", escapeLuceneRegexp(fieldName+"=")+pattern)
 
 	case ConditionOperatorGreaterThan, ConditionOperatorGreaterOrEqual,
 		ConditionOperatorLessThan, ConditionOperatorLessOrEqual:
 
-		// Only for system numeric/date fields; otherwise not supported
 		if !isSystemField {
-			// Range operators on custom fields are not supported and should match nothing
-			return matchNone()
+			// Custom fields compare their numeric values, logs where the field is not
+			// a number do not match, neither does a value which is not a number
+			number, isNumber := ToNumber(condition.Value)
+			if !isNumber {
+				return matchNone()
+			}
+			return rangeQuery(
+				numbersDocumentField+"."+fieldName,
+				condition.Operator,
+				strconv.FormatFloat(number, 'f', -1, 64),
+			)
 		}
 		return rangeQuery(fieldName, condition.Operator, fmt.Sprintf("%v", condition.Value))
 
+	case ConditionOperatorBetween:
+		values := asStringSlice(condition.Value)
+		if !isSystemField || len(values) != 2 {
+			return matchNone()
+		}
+		return betweenQuery(fieldName, values[0], values[1])
+
 	default:
 		return matchNone()
 	}
 }
 
+// buildMissingFieldsNode matches logs lacking any (missing_any) or all (missing_all)
+// of the fields listed in the value, as must_not over exists of each field
+func (builder *QueryBuilder) buildMissingFieldsNode(condition *ConditionNode) map[string]any {
+	existsParts := make([]any, 0)
+	for _, fieldName := range asStringSlice(condition.Value) {
+		fieldName = strings.TrimSpace(fieldName)
+		if fieldName == "" {
+			continue
+		}
+
+		existsCondition := &ConditionNode{Field: fieldName, Operator: ConditionOperatorExists}
+		existsParts = append(existsParts, builder.buildConditionNode(existsCondition, nil))
+	}
+	if len(existsParts) == 0 {
+		return matchNone()
+	}
+
+	if condition.Operator == ConditionOperatorMissingAny {
+		// not every field exists
+		return mustNot(map[string]any{"bool": map[string]any{"filter": existsParts}})
+	}
+
+	// none of the fields exists
+	return map[string]any{"bool": map[string]any{"must_not": existsParts}}
+}
+
+// normalizeConditionValues returns a copy of a system field condition with values
+// in the stored form, so values match regardless of how they are written. Whole
+// values go through normalizeValue, partial values of contains through normalizePattern
+func normalizeConditionValues(
+	condition *ConditionNode,
+	normalizeValue func(string) string,
+	normalizePattern func(string) string,
+) *ConditionNode {
+	normalized := *condition
+
+	switch value := condition.Value.(type) {
+	case string:
+		switch condition.Operator {
+		case ConditionOperatorContains, ConditionOperatorNotContains,
+			ConditionOperatorStartsWith, ConditionOperatorEndsWith:
+			normalized.Value = normalizePattern(value)
+		case ConditionOperatorRegex:
+			// Changing the case would change the meaning of escapes like \d
+		default:
+			normalized.Value = normalizeValue(value)
+		}
+	case []string, []any:
+		values := asStringSlice(value)
+		normalizedValues := make([]string, len(values))
+		for i, item := range values {
+			normalizedValues[i] = normalizeValue(item)
+		}
+		normalized.Value = normalizedValues
+	}
+
+	return &normalized
+}
+
+func normalizeLevelValue(value string) string {
+	return string(NormalizeLogLevel(value))
+}
+
+// toCaseInsensitive rewrites the term, prefix, wildcard and regexp queries of a condition
+// query to ignore case. Terms queries have no such option, they become a should
+// over case insensitive term queries
+func toCaseInsensitive(query map[string]any) map[string]any {
+	for queryType, body := range query {
+		switch queryType {
+		case "term", "prefix", "wildcard":
+			fields, isMap := body.(map[string]any)
+			if !isMap {
+				return query
+			}
+
+			converted := make(map[string]any, len(fields))
+			for field, value := range fields {
+				converted[field] = map[string]any{"value": value, "case_insensitive": true}
+			}
+			return map[string]any{queryType: converted}
+
+		case "regexp":
+			fields, isMap := body.(map[string]any)
+			if !isMap {
+				return query
+			}
+
+			converted := make(map[string]any, len(fields))
+			for field, params := range fields {
+				paramsMap, isParamsMap := params.(map[string]any)
+				if !isParamsMap {
+					return query
+				}
+				convertedParams := maps.Clone(paramsMap)
+				convertedParams["case_insensitive"] = true
+				converted[field] = convertedParams
+			}
+			return map[string]any{queryType: converted}
+
+		case "terms":
+			fields, isMap := body.(map[string]any)
+			if !isMap {
+				return query
+			}
+
+			should := make([]any, 0)
+			for field, values := range fields {
+				valueList, isList := values.([]any)
+				if !isList {
+					continue
+				}
+				for _, value := range valueList {
+					should = append(should, toCaseInsensitive(term(field, value)))
+				}
+			}
+			return map[string]any{"bool": map[string]any{"should": should, "minimum_should_match": 1}}
+
+		case "bool":
+			clauses, isMap := body.(map[string]any)
+			if !isMap {
+				return query
+			}
+
+			converted := make(map[string]any, len(clauses))
+			for occurrence, clause := range clauses {
+				clauseQueries, isList := clause.([]any)
+				if !isList {
+					converted[occurrence] = clause
+					continue
+				}
+
+				convertedQueries := make([]any, len(clauseQueries))
+				for i, clauseQuery := range clauseQueries {
+					if clauseMap, isClauseMap := clauseQuery.(map[string]any); isClauseMap {
+						convertedQueries[i] = toCaseInsensitive(clauseMap)
+					} else {
+						convertedQueries[i] = clauseQuery
+					}
+				}
+				converted[occurrence] = convertedQueries
+			}
+			return map[string]any{"bool": converted}
+		}
+	}
+
+	return query
+}
+
 func term(field string, value any) map[string]any {
 	return map[string]any{"term": map[string]any{field: value}}
 }
@@ -270,6 +793,12 @@ func wildcard(field, pattern string) map[string]any {
 	return map[string]any{"wildcard": map[string]any{field: pattern}}
 }
 
+// regexpQuery takes a pattern in the Lucene syntax, the optional operators of
+// Lucene are disabled so only the translated syntax has a meaning
+func regexpQuery(field, pattern string) map[string]any {
+	return map[string]any{"regexp": map[string]any{field: map[string]any{"value": pattern, "flags": "NONE"}}}
+}
+
 func mustNot(query map[string]any) map[string]any {
 	return map[string]any{"bool": map[string]any{"must_not": []any{query}}}
 }
@@ -291,15 +820,54 @@ func rangeQuery(field string, operator ConditionOperator, value string) map[stri
 		rangeKey = "lte"
 	}
 
-	// Convert timestamp strings to nanoseconds for consistency with storage
-	queryValue := value
+	return map[string]any{"range": map[string]any{field: map[string]any{rangeKey: rangeBound(field, value)}}}
+}
+
+// betweenQuery matches from and to inclusive with a single range query
+func betweenQuery(field, from, to string) map[string]any {
+	return map[string]any{"range": map[string]any{field: map[string]any{
+		"gte": rangeBound(field, from),
+		"lte": rangeBound(field, to),
+	}}}
+}
+
+// rangeBound converts timestamp strings to nanoseconds for consistency with storage
+func rangeBound(field, value string) string {
 	if field == "timestamp" {
 		if parsedTime, err := time.Parse(time.RFC3339Nano, value); err == nil {
-			queryValue = strconv.FormatInt(timestampToNanos(parsedTime), 10)
+			return strconv.FormatInt(timestampToNanos(parsedTime), 10)
+		}
+	}
+
+	return value
+}
+
+// escapeLuceneRegexp escapes the characters reserved by the Lucene regular
+// expressions which OpenSearch uses for terms include patterns
+func escapeLuceneRegexp(value string) string {
+	var escaped strings.Builder
+	for _, char := range value {
+		if strings.ContainsRune(`.?+*|{}[]()"\#@&<>~`, char) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(char)
+	}
+
+	return escaped.String()
+}
+
+// escapeWildcard escapes the characters with a meaning in wildcard queries, so
+// the value matches literally
+func escapeWildcard(value string) string {
+	var escaped strings.Builder
+	for _, char := range value {
+		if char == '*' || char == '?' || char == '\\' {
+			escaped.WriteRune('\\')
 		}
+		escaped.WriteRune(char)
 	}
 
-	return map[string]any{"range": map[string]any{field: map[string]any{rangeKey: queryValue}}}
+	return escaped.String()
 }
 
 // timestampToNanos converts a time to nanoseconds, ensuring consistent precision
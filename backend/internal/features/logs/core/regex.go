@@ -0,0 +1,226 @@
+package logs_core
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// ParseRegexPattern parses a pattern of the regex operator. Patterns use the
+// RE2 syntax (as in Go, JavaScript or PCRE without backtracking features)
+func ParseRegexPattern(pattern string) (*syntax.Regexp, error) {
+	if hasGroupModifier(pattern) {
+		return nil, errors.New("groups with flags or without capture are not supported")
+	}
+
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %w", err)
+	}
+
+	return parsed, nil
+}
+
+// hasGroupModifier reports whether a group opens with "(?", i.e. sets flags,
+// does not capture or is named. The parsed tree keeps no trace of these, so
+// the pattern is scanned, skipping escaped characters, \Q...\E quotes and
+// character classes where "(?" are literal characters
+func hasGroupModifier(pattern string) bool {
+	isInClass := false
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\':
+			if strings.HasPrefix(pattern[i+1:], "Q") {
+				quoteEnd := strings.Index(pattern[i+2:], `\E`)
+				if quoteEnd == -1 {
+					return false
+				}
+				i += 2 + quoteEnd + 1
+				continue
+			}
+			i++
+
+		case isInClass:
+			if strings.HasPrefix(pattern[i:], "[:") {
+				if classEnd := strings.Index(pattern[i+2:], ":]"); classEnd != -1 {
+					i += 2 + classEnd + 1
+				}
+				continue
+			}
+			isInClass = pattern[i] != ']'
+
+		case pattern[i] == '[':
+			isInClass = true
+			// A ']' right after the opening bracket is a literal one
+			if strings.HasPrefix(pattern[i+1:], "^") {
+				i++
+			}
+			if strings.HasPrefix(pattern[i+1:], "]") {
+				i++
+			}
+
+		case pattern[i] == '(' && strings.HasPrefix(pattern[i+1:], "?"):
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToLuceneRegexp translates a pattern of the regex operator to the Lucene
+// syntax of OpenSearch regexp queries. Lucene patterns always match the whole
+// value, so the result is wrapped to match anywhere in it like contains does
+func ToLuceneRegexp(pattern string) (string, error) {
+	parsed, err := ParseRegexPattern(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var lucene strings.Builder
+	lucene.WriteString(".*(")
+	if err := writeLuceneRegexp(&lucene, parsed); err != nil {
+		return "", err
+	}
+	lucene.WriteString(").*")
+
+	return lucene.String(), nil
+}
+
+func writeLuceneRegexp(lucene *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		lucene.WriteString("()")
+
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return errors.New("case folding flags are not supported, use caseInsensitive")
+		}
+		for _, char := range re.Rune {
+			writeLuceneRune(lucene, char)
+		}
+
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return errors.New("character class matches nothing")
+		}
+		writeLuceneCharClass(lucene, re.Rune)
+
+	// Lucene has no line terminators, the dot matches line breaks as well
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		lucene.WriteString(".")
+
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+		return errors.New("anchors are not supported, patterns match anywhere in the value")
+
+	case syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return errors.New("word boundaries are not supported")
+
+	case syntax.OpCapture:
+		lucene.WriteString("(")
+		if err := writeLuceneRegexp(lucene, re.Sub[0]); err != nil {
+			return err
+		}
+		lucene.WriteString(")")
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lucene.WriteString("(")
+		if err := writeLuceneRegexp(lucene, re.Sub[0]); err != nil {
+			return err
+		}
+		lucene.WriteString(")")
+
+		switch re.Op {
+		case syntax.OpStar:
+			lucene.WriteString("*")
+		case syntax.OpPlus:
+			lucene.WriteString("+")
+		case syntax.OpQuest:
+			lucene.WriteString("?")
+		default:
+			writeLuceneRepeat(lucene, re.Min, re.Max)
+		}
+
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := writeLuceneRegexp(lucene, sub); err != nil {
+				return err
+			}
+		}
+
+	case syntax.OpAlternate:
+		lucene.WriteString("(")
+		for i, sub := range re.Sub {
+			if i > 0 {
+				lucene.WriteString("|")
+			}
+			if err := writeLuceneRegexp(lucene, sub); err != nil {
+				return err
+			}
+		}
+		lucene.WriteString(")")
+
+	default:
+		return fmt.Errorf("unsupported regex construct: %s", re)
+	}
+
+	return nil
+}
+
+func writeLuceneRepeat(lucene *strings.Builder, minCount, maxCount int) {
+	switch {
+	case maxCount == -1:
+		fmt.Fprintf(lucene, "{%d,}", minCount)
+	case minCount == maxCount:
+		fmt.Fprintf(lucene, "{%d}", minCount)
+	default:
+		fmt.Fprintf(lucene, "{%d,%d}", minCount, maxCount)
+	}
+}
+
+// writeLuceneCharClass writes the ranges of the class, negated classes are
+// already complemented by the parser
+func writeLuceneCharClass(lucene *strings.Builder, ranges []rune) {
+	lucene.WriteString("[")
+	for i := 0; i+1 < len(ranges); i += 2 {
+		low, high := ranges[i], ranges[i+1]
+
+		// Surrogates are no characters of their own, a range across them is split
+		if low < 0xD800 && high > 0xDFFF {
+			writeLuceneRange(lucene, low, 0xD7FF)
+			writeLuceneRange(lucene, 0xE000, high)
+			continue
+		}
+		if low >= 0xD800 && high <= 0xDFFF {
+			continue
+		}
+		if low >= 0xD800 && low <= 0xDFFF {
+			low = 0xE000
+		}
+		if high >= 0xD800 && high <= 0xDFFF {
+			high = 0xD7FF
+		}
+
+		writeLuceneRange(lucene, low, high)
+	}
+	lucene.WriteString("]")
+}
+
+func writeLuceneRange(lucene *strings.Builder, low, high rune) {
+	writeLuceneRune(lucene, low)
+	if high != low {
+		lucene.WriteString("-")
+		writeLuceneRune(lucene, high)
+	}
+}
+
+// writeLuceneRune escapes everything but letters and digits, escaped letters
+// have a meaning in Lucene (\d, \w, \s)
+func writeLuceneRune(lucene *strings.Builder, char rune) {
+	if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+		lucene.WriteRune('\\')
+	}
+	lucene.WriteRune(char)
+}
@@ -2,14 +2,18 @@ package logs_core
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"maps"
+	"math"
 	"net/http"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,15 +21,38 @@ import (
 
 // Fields we treat as "system"
 var systemFields = map[string]bool{
-	"timestamp":    true,
-	"project_id":   true,
-	"id":           true,
-	"level":        true,
-	"client_ip":    true,
-	"created_at":   true,
-	"message":      true,
-	"attrs_text":   true,
-	"attrs_tokens": true,
+	"timestamp":     true,
+	"project_id":    true,
+	"id":            true,
+	"level":         true,
+	"client_ip":     true,
+	"created_at":    true,
+	"message":       true,
+	"attrs_text":    true,
+	"attrs_tokens":  true,
+	"attrs_ngrams":  true,
+	"attrs_numbers": true,
+	"sample_weight": true,
+	"stored_fields": true,
+}
+
+// ignoredDiscoveryFields are internal names of the previous storage which are
+// never offered as queryable fields
+var ignoredDiscoveryFields = map[string]bool{
+	"_msg":    true,
+	"_time":   true,
+	"_stream": true,
+	"project": true,
+}
+
+// PartitionDateLayout is the format of partition identifiers, logs are stored
+// in one index per UTC day
+const PartitionDateLayout = "2006-01-02"
+
+// IngestPipelineResolver returns the OpenSearch ingest pipeline the logs of a
+// project are indexed through, empty when logs are indexed as they are
+type IngestPipelineResolver interface {
+	GetIngestPipeline(projectID uuid.UUID) (string, error)
 }
 
 type LogCoreRepository struct {
@@ -36,7 +63,42 @@ type LogCoreRepository struct {
 	timeout      time.Duration
 	logger       *slog.Logger
 
-	queryBuilder *QueryBuilder
+	// Archived tier, daily indices of logs moved out of the searchable ones
+	archiveIndexPattern string
+	archiveIndexPrefix  string
+
+	queryBuilder            *QueryBuilder
+	ingestPipelineResolver  IngestPipelineResolver
+	ngramFieldsResolver     NgramFieldsResolver
+	messageOnlyModeResolver MessageOnlyModeResolver
+
+	ngramsMappedIndices       sync.Map
+	storedFieldsMappedIndices sync.Map
+	numbersMappedIndices      sync.Map
+}
+
+func (repository *LogCoreRepository) SetIngestPipelineResolver(resolver IngestPipelineResolver) {
+	repository.ingestPipelineResolver = resolver
+}
+
+func (repository *LogCoreRepository) GetIngestPipelineResolver() IngestPipelineResolver {
+	return repository.ingestPipelineResolver
+}
+
+func (repository *LogCoreRepository) SetNgramFieldsResolver(resolver NgramFieldsResolver) {
+	repository.ngramFieldsResolver = resolver
+}
+
+func (repository *LogCoreRepository) GetNgramFieldsResolver() NgramFieldsResolver {
+	return repository.ngramFieldsResolver
+}
+
+func (repository *LogCoreRepository) SetMessageOnlyModeResolver(resolver MessageOnlyModeResolver) {
+	repository.messageOnlyModeResolver = resolver
+}
+
+func (repository *LogCoreRepository) GetMessageOnlyModeResolver() MessageOnlyModeResolver {
+	return repository.messageOnlyModeResolver
 }
 
 func (repository *LogCoreRepository) StoreLogsBatch(entries map[uuid.UUID][]*LogItem) error {
@@ -47,17 +109,24 @@ func (repository *LogCoreRepository) StoreLogsBatch(entries map[uuid.UUID][]*Log
 	var bulkRequestBuilder strings.Builder
 
 	for projectID, logs := range entries {
+		ingestPipeline := repository.getIngestPipeline(projectID)
+		ngramFields := repository.getNgramFields(projectID)
+		isMessageOnlyMode := repository.isMessageOnlyMode(projectID)
+
 		for _, logItem := range logs {
 			indexName := repository.indexFor(logItem.Timestamp)
 
-			metadata := map[string]any{
-				"index": map[string]any{
-					"_index":  indexName,
-					"_id":     logItem.ID.String(),
-					"routing": projectID.String(),
-				},
+			indexAction := map[string]any{
+				"_index":  indexName,
+				"_id":     logItem.ID.String(),
+				"routing": projectID.String(),
+			}
+			if ingestPipeline != "" {
+				indexAction["pipeline"] = ingestPipeline
 			}
 
+			metadata := map[string]any{"index": indexAction}
+
 			metadataBytes, err := json.Marshal(metadata)
 			if err != nil {
 				return fmt.Errorf("failed to marshal metadata: %w", err)
@@ -75,6 +144,22 @@ func (repository *LogCoreRepository) StoreLogsBatch(entries map[uuid.UUID][]*Log
 				"message":    logItem.Message,
 			}
 
+			if logItem.SampleWeight > 0 {
+				document["sample_weight"] = logItem.SampleWeight
+			}
+
+			if isMessageOnlyMode {
+				// Fields are kept for display only, so no tokens are built for them
+				if len(logItem.Fields) > 0 {
+					repository.addStoredFields(document, indexName, logItem.Fields)
+				}
+
+				if err := appendBulkDocument(&bulkRequestBuilder, document); err != nil {
+					return err
+				}
+				continue
+			}
+
 			// Copy custom fields directly into document
 			maps.Copy(document, logItem.Fields)
 
@@ -98,13 +183,31 @@ func (repository *LogCoreRepository) StoreLogsBatch(entries map[uuid.UUID][]*Log
 				document["attrs_text"] = attrsText
 			}
 
-			documentBytes, err := json.Marshal(document)
-			if err != nil {
-				return fmt.Errorf("failed to marshal document: %w", err)
+			if ngramsDocument := buildNgramsDocument(logItem.Fields, ngramFields); ngramsDocument != nil {
+				// Without the mapping grams would be analyzed as regular text, the log
+				// is then indexed without them and stays searchable through wildcards
+				if err := repository.ensureNgramsMapping(indexName); err != nil {
+					repository.logger.Warn("Failed to prepare n-gram mapping, indexing log without n-grams",
+						"index", indexName, "error", err)
+				} else {
+					document[ngramsDocumentField] = ngramsDocument
+				}
 			}
 
-			bulkRequestBuilder.Write(documentBytes)
-			bulkRequestBuilder.WriteByte('\n')
+			if numbersDocument := buildNumbersDocument(logItem.Fields); numbersDocument != nil {
+				// Without the mapping a number first sent as a string would be mapped as
+				// text, the log is then indexed without numbers like with n-grams
+				if err := repository.ensureNumbersMapping(indexName); err != nil {
+					repository.logger.Warn("Failed to prepare numbers mapping, indexing log without numbers",
+						"index", indexName, "error", err)
+				} else {
+					document[numbersDocumentField] = numbersDocument
+				}
+			}
+
+			if err := appendBulkDocument(&bulkRequestBuilder, document); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -147,9 +250,83 @@ func (repository *LogCoreRepository) StoreLogsBatch(entries map[uuid.UUID][]*Log
 	return nil
 }
 
+// getIngestPipeline returns the pipeline of the project. When it cannot be
+// resolved logs are indexed without a pipeline rather than dropped
+func (repository *LogCoreRepository) getIngestPipeline(projectID uuid.UUID) string {
+	if repository.ingestPipelineResolver == nil {
+		return ""
+	}
+
+	pipeline, err := repository.ingestPipelineResolver.GetIngestPipeline(projectID)
+	if err != nil {
+		repository.logger.Warn("Failed to resolve ingest pipeline, indexing logs without it",
+			"projectId", projectID.String(), "error", err)
+		return ""
+	}
+
+	return pipeline
+}
+
+// isMessageOnlyMode tells whether the project stores custom fields without
+// indexing them. When it cannot be resolved the fields are indexed, so they
+// stay queryable
+func (repository *LogCoreRepository) isMessageOnlyMode(projectID uuid.UUID) bool {
+	if repository.messageOnlyModeResolver == nil {
+		return false
+	}
+
+	isMessageOnlyMode, err := repository.messageOnlyModeResolver.IsMessageOnlyMode(projectID)
+	if err != nil {
+		repository.logger.Warn("Failed to resolve message-only mode, indexing custom fields",
+			"projectId", projectID.String(), "error", err)
+		return false
+	}
+
+	return isMessageOnlyMode
+}
+
+// addStoredFields nests the custom fields under a field which is not indexed.
+// Without its mapping the fields would be indexed dynamically, the log is then
+// indexed with them and they are still not queryable as they have no tokens
+func (repository *LogCoreRepository) addStoredFields(document map[string]any, indexName string, fields map[string]any) {
+	if err := repository.ensureIndexMapping(
+		&repository.storedFieldsMappedIndices,
+		indexName,
+		storedFieldsMapping,
+	); err != nil {
+		repository.logger.Warn("Failed to prepare stored fields mapping, custom fields may be indexed",
+			"index", indexName, "error", err)
+	}
+
+	document[storedFieldsDocumentField] = fields
+}
+
+// getNgramFields returns the n-gram fields of the project, when they cannot be
+// resolved logs are indexed without grams rather than dropped
+func (repository *LogCoreRepository) getNgramFields(projectID uuid.UUID) map[string]bool {
+	ngramFields, err := resolveNgramFields(repository.ngramFieldsResolver, projectID)
+	if err != nil {
+		repository.logger.Warn("Failed to resolve n-gram fields, indexing logs without n-grams",
+			"projectId", projectID.String(), "error", err)
+		return nil
+	}
+
+	return ngramFields
+}
+
 func (repository *LogCoreRepository) ExecuteQueryForProject(
 	projectID uuid.UUID,
 	request *LogQueryRequestDTO,
+) (*LogQueryResponseDTO, error) {
+	return repository.ExecuteQueryForProjectWithContext(context.Background(), projectID, request)
+}
+
+// ExecuteQueryForProjectWithContext runs the query with the context of its
+// OpenSearch request, so cancelling the context stops a running query
+func (repository *LogCoreRepository) ExecuteQueryForProjectWithContext(
+	ctx context.Context,
+	projectID uuid.UUID,
+	request *LogQueryRequestDTO,
 ) (*LogQueryResponseDTO, error) {
 	startTime := time.Now()
 	searchBody, err := repository.queryBuilder.BuildSearchBody(projectID, request)
@@ -162,8 +339,8 @@ func (repository *LogCoreRepository) ExecuteQueryForProject(
 		return nil, fmt.Errorf("failed to marshal search body: %w", err)
 	}
 
-	searchEndpoint := repository.baseURL + "/" + repository.indexPattern + "/_search"
-	searchRequest, err := http.NewRequest("POST", searchEndpoint, bytes.NewReader(searchPayload))
+	searchEndpoint := repository.baseURL + "/" + repository.searchTarget(request.Partitions, request.IncludeArchived)
+	searchRequest, err := http.NewRequestWithContext(ctx, "POST", searchEndpoint, bytes.NewReader(searchPayload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create search request: %w", err)
 	}
@@ -197,64 +374,85 @@ func (repository *LogCoreRepository) ExecuteQueryForProject(
 		return nil, fmt.Errorf("failed to parse search response: %w", err)
 	}
 
+	var newestCursor *LogCursor
+
 	logItems := make([]LogItemDTO, 0, len(openSearchResponse.Hits.Hits))
 	for _, hit := range openSearchResponse.Hits.Hits {
-		source := hit.Source
-		logItemDTO := LogItemDTO{
-			ID:       asString(source["id"]),
-			Level:    asString(source["level"]),
-			Message:  asString(source["message"]),
-			ClientIP: asString(source["client_ip"]),
-		}
-		if timestampNanos, exists := source["timestamp"]; exists {
-			if nanos, ok := timestampNanos.(float64); ok {
-				logItemDTO.Timestamp = time.Unix(0, int64(nanos)).UTC()
+		if cursor, ok := cursorFromSortValues(hit.Sort); ok {
+			if newestCursor == nil || cursor.isAfter(*newestCursor) {
+				newestCursor = cursor
 			}
 		}
 
-		if createdAtStr, exists := source["created_at"].(string); exists {
-			if parsedTime, err := time.Parse(time.RFC3339Nano, createdAtStr); err == nil {
-				logItemDTO.CreatedAt = parsedTime.UTC()
-			}
-		}
+		logItems = append(logItems, logItemFromSource(hit.Source))
+	}
 
-		// Collect custom fields from source (excluding system fields) plus clientIp in sorted order
-		var fieldNames []string
-		for fieldName := range source {
-			if !systemFields[fieldName] || fieldName == "client_ip" {
-				fieldNames = append(fieldNames, fieldName)
-			}
+	totalRelation := TotalRelationEq
+	if openSearchResponse.Hits.Total.Rel == string(TotalRelationGte) {
+		totalRelation = TotalRelationGte
+	}
+
+	executionTime := time.Since(startTime).String()
+	response := &LogQueryResponseDTO{
+		Logs:          logItems,
+		Total:         openSearchResponse.Hits.Total.Value,
+		TotalRelation: totalRelation,
+		Limit:         request.Limit,
+		Offset:        request.Offset,
+		ExecutedInMs:  executionTime,
+		Cursor:        request.After,
+	}
+
+	if newestCursor != nil {
+		response.Cursor = EncodeLogCursor(*newestCursor)
+	}
+
+	if request.IncludeTiming {
+		response.Timing = &QueryTimingDTO{
+			OpenSearchTookMs:    openSearchResponse.Took,
+			OpenSearchRequestMs: DurationMs(time.Since(startTime)),
 		}
-		if len(fieldNames) > 0 {
-			// Sort field names alphabetically to ensure consistent ordering
-			slices.Sort(fieldNames)
-			fields := make(map[string]any)
+	}
 
-			for _, fieldName := range fieldNames {
+	return response, nil
+}
 
-				// Map client_ip to client_ip for consistency in Fields
-				if fieldName == "client_ip" {
-					fields["client_ip"] = source[fieldName]
-				} else {
-					fields[fieldName] = source[fieldName]
-				}
-			}
-			logItemDTO.Fields = fields
+// DiscoverFieldsPage returns discovered field names filtered by case-insensitive
+// prefix and substring and sliced by limit/offset. Total is counted before slicing
+func (repository *LogCoreRepository) DiscoverFieldsPage(
+	projectID uuid.UUID,
+	request *DiscoverFieldsPageRequestDTO,
+) (*DiscoverFieldsPageDTO, error) {
+	discoveredFields, err := repository.DiscoverFields(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.ToLower(request.Prefix)
+	search := strings.ToLower(request.Search)
+
+	matchedFields := make([]string, 0, len(discoveredFields))
+	for _, fieldName := range discoveredFields {
+		lowerFieldName := strings.ToLower(fieldName)
+
+		if !strings.HasPrefix(lowerFieldName, prefix) || !strings.Contains(lowerFieldName, search) {
+			continue
 		}
 
-		logItems = append(logItems, logItemDTO)
+		matchedFields = append(matchedFields, fieldName)
 	}
 
-	executionTime := time.Since(startTime).String()
-	response := &LogQueryResponseDTO{
-		Logs:         logItems,
-		Total:        openSearchResponse.Hits.Total.Value,
-		Limit:        request.Limit,
-		Offset:       request.Offset,
-		ExecutedInMs: executionTime,
+	total := len(matchedFields)
+	start := min(max(request.Offset, 0), total)
+	end := total
+	if request.Limit > 0 {
+		end = min(start+request.Limit, total)
 	}
 
-	return response, nil
+	return &DiscoverFieldsPageDTO{
+		Fields: matchedFields[start:end],
+		Total:  total,
+	}, nil
 }
 
 // DiscoverFields returns unique non-system keys present in recent documents of the project
@@ -313,7 +511,7 @@ func (repository *LogCoreRepository) DiscoverFields(projectID uuid.UUID) ([]stri
 	fieldSet := map[string]bool{}
 	for _, hit := range openSearchResponse.Hits.Hits {
 		for fieldName := range hit.Source {
-			if !systemFields[fieldName] {
+			if !systemFields[fieldName] && !ignoredDiscoveryFields[fieldName] {
 				fieldSet[fieldName] = true
 			}
 		}
@@ -332,7 +530,7 @@ func (repository *LogCoreRepository) DiscoverFields(projectID uuid.UUID) ([]stri
 
 // ForceFlush => OpenSearch _refresh to make recent docs searchable
 func (repository *LogCoreRepository) ForceFlush() error {
-	refreshEndpoint := repository.baseURL + "/" + repository.indexPattern + "/_refresh"
+	refreshEndpoint := repository.baseURL + "/" + repository.allTiersPattern() + "/_refresh"
 	refreshRequest, err := http.NewRequest("POST", refreshEndpoint, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create refresh request: %w", err)
@@ -375,34 +573,51 @@ func (repository *LogCoreRepository) DeleteLogsByProject(projectID uuid.UUID) er
 	return repository.deleteByQuery(deleteQuery, &projectID)
 }
 
-// Delete logs older than time for a given project
+// Delete logs older than time for a given project, archived logs included
 func (repository *LogCoreRepository) DeleteOldLogs(projectID uuid.UUID, olderThan time.Time) error {
 	deleteQuery := map[string]any{
-		"query": map[string]any{
-			"bool": map[string]any{
-				"filter": []any{
-					map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
-					map[string]any{
-						"range": map[string]any{
-							"timestamp": map[string]any{"lt": olderThan.UTC().UnixNano()},
-						},
-					},
-				},
-			},
-		},
+		"query": olderLogsQuery(projectID, olderThan),
 	}
 
 	return repository.deleteByQuery(deleteQuery, &projectID)
 }
 
+// GetProjectLogStats returns the stats of the searchable logs, quotas apply to
+// them only
 func (repository *LogCoreRepository) GetProjectLogStats(projectID uuid.UUID) (*ProjectLogStats, error) {
+	return repository.getLogStats(projectID, nil, repository.indexPattern)
+}
+
+// GetProjectLogStatsOlderThan returns the stats of the logs DeleteOldLogs would
+// delete with the same time
+func (repository *LogCoreRepository) GetProjectLogStatsOlderThan(
+	projectID uuid.UUID,
+	olderThan time.Time,
+) (*ProjectLogStats, error) {
+	return repository.getLogStats(projectID, &olderThan, repository.allTiersPattern())
+}
+
+func (repository *LogCoreRepository) getLogStats(
+	projectID uuid.UUID,
+	olderThan *time.Time,
+	indices string,
+) (*ProjectLogStats, error) {
+	filters := []any{
+		map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
+	}
+	if olderThan != nil {
+		filters = append(filters, map[string]any{
+			"range": map[string]any{
+				"timestamp": map[string]any{"lt": olderThan.UTC().UnixNano()},
+			},
+		})
+	}
+
 	statsQuery := map[string]any{
 		"size": 0, // Don't return hits, only aggregations
 		"query": map[string]any{
 			"bool": map[string]any{
-				"filter": []any{
-					map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
-				},
+				"filter": filters,
 			},
 		},
 		"aggs": map[string]any{
@@ -415,6 +630,17 @@ func (repository *LogCoreRepository) GetProjectLogStats(projectID uuid.UUID) (*P
 			"newest_log": map[string]any{
 				"max": map[string]any{"field": "timestamp"},
 			},
+			// Read from the source, dynamic mapping may have typed the weight as an integer
+			"estimated_total_logs": map[string]any{
+				"sum": map[string]any{
+					"script": map[string]any{
+						"source": `
+							def weight = params._source.sample_weight;
+							return weight == null ? 1 : weight;
+						`,
+					},
+				},
+			},
 			"total_size_bytes": map[string]any{
 				"sum": map[string]any{
 					"script": map[string]any{
@@ -444,7 +670,7 @@ func (repository *LogCoreRepository) GetProjectLogStats(projectID uuid.UUID) (*P
 		return nil, fmt.Errorf("failed to marshal stats query: %w", err)
 	}
 
-	statsEndpoint := repository.baseURL + "/" + repository.indexPattern + "/_search"
+	statsEndpoint := repository.baseURL + "/" + indices + "/_search"
 	statsRequest, err := http.NewRequest("POST", statsEndpoint, bytes.NewReader(statsPayload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stats request: %w", err)
@@ -484,6 +710,13 @@ func (repository *LogCoreRepository) GetProjectLogStats(projectID uuid.UUID) (*P
 		TotalSizeMB: statsSearchResponse.Aggregations.TotalSizeBytes.Value / (1024 * 1024), // Convert bytes to MB
 	}
 
+	// Every stored log counts at least once, so the estimate never falls below the stored count
+	stats.EstimatedTotalLogs = max(
+		stats.TotalLogs,
+		int64(math.Round(statsSearchResponse.Aggregations.EstimatedTotalLogs.Value)),
+	)
+	stats.SampledOutLogs = stats.EstimatedTotalLogs - stats.TotalLogs
+
 	// Parse oldest timestamp if available
 	if statsSearchResponse.Aggregations.OldestLog.ValueAsString != "" {
 		if oldestTime, err := time.Parse(time.RFC3339Nano, statsSearchResponse.Aggregations.OldestLog.ValueAsString); err == nil {
@@ -513,7 +746,8 @@ func (repository *LogCoreRepository) deleteByQuery(queryBody map[string]any, rou
 		return fmt.Errorf("failed to marshal delete query: %w", err)
 	}
 
-	deleteEndpoint := repository.baseURL + "/" + repository.indexPattern + "/_delete_by_query?conflicts=proceed&wait_for_completion=false"
+	deleteEndpoint := repository.baseURL + "/" + repository.allTiersPattern() +
+		"/_delete_by_query?conflicts=proceed&wait_for_completion=false"
 	if routing != nil {
 		deleteEndpoint += "&routing=" + routing.String()
 	}
@@ -554,6 +788,48 @@ func (repository *LogCoreRepository) deleteByQuery(queryBody map[string]any, rou
 	return nil
 }
 
+// GetClusterHealth returns OpenSearch cluster health together with doc counts
+// of the log indices, so operators can correlate LogBull behavior with cluster state
+func (repository *LogCoreRepository) GetClusterHealth() (*ClusterHealthDTO, error) {
+	var clusterHealth openSearchClusterHealthResponse
+	if err := repository.getJSON("/_cluster/health", &clusterHealth); err != nil {
+		return nil, fmt.Errorf("failed to get cluster health: %w", err)
+	}
+
+	var catIndices []openSearchCatIndexResponse
+	indicesPath := "/_cat/indices/" + repository.allTiersPattern() +
+		"?format=json&bytes=b&h=index,health,docs.count,store.size&s=index"
+	if err := repository.getJSON(indicesPath, &catIndices); err != nil {
+		return nil, fmt.Errorf("failed to get indices stats: %w", err)
+	}
+
+	health := &ClusterHealthDTO{
+		ClusterName:       clusterHealth.ClusterName,
+		Status:            clusterHealth.Status,
+		NumberOfNodes:     clusterHealth.NumberOfNodes,
+		NumberOfDataNodes: clusterHealth.NumberOfDataNodes,
+		ActiveShards:      clusterHealth.ActiveShards,
+		UnassignedShards:  clusterHealth.UnassignedShards,
+		Indices:           make([]IndexHealthDTO, 0, len(catIndices)),
+	}
+
+	for _, catIndex := range catIndices {
+		// Closed indices have no stats, counts stay zero
+		docsCount, _ := strconv.ParseInt(catIndex.DocsCount, 10, 64)
+		storeSizeBytes, _ := strconv.ParseInt(catIndex.StoreSize, 10, 64)
+
+		health.TotalDocs += docsCount
+		health.Indices = append(health.Indices, IndexHealthDTO{
+			Name:           catIndex.Index,
+			Health:         catIndex.Health,
+			DocsCount:      docsCount,
+			StoreSizeBytes: storeSizeBytes,
+		})
+	}
+
+	return health, nil
+}
+
 func (repository *LogCoreRepository) TestOpenSearchConnection() error {
 	healthEndpoint := repository.baseURL + "/_cluster/health"
 	healthRequest, err := http.NewRequest("GET", healthEndpoint, nil)
@@ -590,6 +866,352 @@ func (repository *LogCoreRepository) TestOpenSearchConnection() error {
 	return nil
 }
 
+func (repository *LogCoreRepository) getJSON(path string, target any) error {
+	request, err := http.NewRequest("GET", repository.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	response, err := repository.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to connect to OpenSearch: %w", err)
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			repository.logger.Error("failed to close response body", "error", closeErr)
+		}
+	}()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned status %d: %s", response.StatusCode, string(responseBody))
+	}
+
+	if err := json.Unmarshal(responseBody, target); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
+// CompareWindows counts the query matches in each window and, when groupBy is
+// set, the top values of the field with their count per window
+func (repository *LogCoreRepository) CompareWindows(
+	projectID uuid.UUID,
+	query *QueryNode,
+	windows []TimeRangeDTO,
+	groupBy string,
+	topValuesLimit int,
+) (*WindowComparisonCountsDTO, error) {
+	return repository.CompareWindowsWithContext(context.Background(), projectID, query, windows, groupBy, topValuesLimit)
+}
+
+// CompareWindowsWithContext compares the windows with the context of the
+// OpenSearch request, so cancelling the context stops the aggregation
+func (repository *LogCoreRepository) CompareWindowsWithContext(
+	ctx context.Context,
+	projectID uuid.UUID,
+	query *QueryNode,
+	windows []TimeRangeDTO,
+	groupBy string,
+	topValuesLimit int,
+) (*WindowComparisonCountsDTO, error) {
+	searchBody, err := repository.queryBuilder.BuildWindowComparisonBody(
+		projectID,
+		query,
+		windows,
+		groupBy,
+		topValuesLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build window comparison body: %w", err)
+	}
+
+	var comparisonResponse openSearchWindowComparisonResponse
+	searchPath := "/" + repository.indexPattern + "/_search"
+	if err := repository.postJSONWithContext(ctx, searchPath, searchBody, &comparisonResponse); err != nil {
+		return nil, fmt.Errorf("failed to compare windows: %w", err)
+	}
+
+	aggregations := comparisonResponse.Aggregations
+	counts := &WindowComparisonCountsDTO{
+		WindowTotals: windowBucketCounts(aggregations.Windows, len(windows)),
+		TopValues:    make([]FieldValueWindowCountsDTO, 0, len(aggregations.TopValues.Buckets)),
+	}
+
+	for _, bucket := range aggregations.TopValues.Buckets {
+		counts.TopValues = append(counts.TopValues, FieldValueWindowCountsDTO{
+			// Custom fields are aggregated over "field=value" tokens
+			Value:        strings.TrimPrefix(bucket.Key, groupBy+"="),
+			WindowCounts: windowBucketCounts(bucket.Windows, len(windows)),
+		})
+	}
+
+	return counts, nil
+}
+
+// GroupBy counts the query matches within the request time range and the
+// first bucketsCount values of the groupBy field with their matches
+func (repository *LogCoreRepository) GroupBy(
+	projectID uuid.UUID,
+	request *LogQueryRequestDTO,
+	groupBy string,
+	bucketsCount int,
+) (*GroupCountsDTO, error) {
+	return repository.GroupByWithContext(context.Background(), projectID, request, groupBy, bucketsCount)
+}
+
+// GroupByWithContext groups with the context of the OpenSearch request, so
+// cancelling the context stops the aggregation
+func (repository *LogCoreRepository) GroupByWithContext(
+	ctx context.Context,
+	projectID uuid.UUID,
+	request *LogQueryRequestDTO,
+	groupBy string,
+	bucketsCount int,
+) (*GroupCountsDTO, error) {
+	searchBody, err := repository.queryBuilder.BuildGroupByBody(projectID, request, groupBy, bucketsCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build group by body: %w", err)
+	}
+
+	var groupByResponse openSearchGroupByResponse
+	searchPath := "/" + repository.indexPattern + "/_search"
+	if err := repository.postJSONWithContext(ctx, searchPath, searchBody, &groupByResponse); err != nil {
+		return nil, fmt.Errorf("failed to group logs: %w", err)
+	}
+
+	buckets := groupByResponse.Aggregations.Groups.Buckets
+	counts := &GroupCountsDTO{
+		Total:  groupByResponse.Hits.Total.Value,
+		Groups: make([]FieldValueCountDTO, 0, len(buckets)),
+	}
+
+	for _, bucket := range buckets {
+		counts.Groups = append(counts.Groups, FieldValueCountDTO{
+			Value: strings.TrimPrefix(bucket.Key, groupBy+"="),
+			Count: bucket.DocCount,
+		})
+	}
+
+	return counts, nil
+}
+
+// AggregateByField counts the query matches per value of the request field,
+// the first TopN values sorted by count descending. It runs the terms
+// aggregation of GroupBy, so logs of other projects are never counted
+func (repository *LogCoreRepository) AggregateByField(
+	projectID uuid.UUID,
+	request *AggregateRequestDTO,
+) ([]AggregateBucketDTO, error) {
+	return repository.AggregateByFieldWithContext(context.Background(), projectID, request)
+}
+
+// AggregateByFieldWithContext aggregates with the context of the OpenSearch
+// request, so cancelling the context stops the aggregation
+func (repository *LogCoreRepository) AggregateByFieldWithContext(
+	ctx context.Context,
+	projectID uuid.UUID,
+	request *AggregateRequestDTO,
+) ([]AggregateBucketDTO, error) {
+	counts, err := repository.GroupByWithContext(ctx, projectID, &LogQueryRequestDTO{
+		Query:     request.Query,
+		TimeRange: request.TimeRange,
+	}, request.Field, request.TopN)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]AggregateBucketDTO, 0, len(counts.Groups))
+	for _, group := range counts.Groups {
+		buckets = append(buckets, AggregateBucketDTO{Value: group.Value, Count: group.Count})
+	}
+
+	return buckets, nil
+}
+
+// LatestPerGroup returns the latest log of the first groupsLimit values of the
+// groupBy field within each bucket, buckets keep the given order
+func (repository *LogCoreRepository) LatestPerGroup(
+	projectID uuid.UUID,
+	query *QueryNode,
+	buckets []TimeRangeDTO,
+	groupBy string,
+	groupsLimit int,
+) ([]TimeBucketLatestLogsDTO, error) {
+	return repository.LatestPerGroupWithContext(context.Background(), projectID, query, buckets, groupBy, groupsLimit)
+}
+
+// LatestPerGroupWithContext finds the latest logs with the context of the
+// OpenSearch request, so cancelling the context stops the aggregation
+func (repository *LogCoreRepository) LatestPerGroupWithContext(
+	ctx context.Context,
+	projectID uuid.UUID,
+	query *QueryNode,
+	buckets []TimeRangeDTO,
+	groupBy string,
+	groupsLimit int,
+) ([]TimeBucketLatestLogsDTO, error) {
+	searchBody, err := repository.queryBuilder.BuildLatestPerGroupBody(projectID, query, buckets, groupBy, groupsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build latest per group body: %w", err)
+	}
+
+	var latestResponse openSearchLatestPerGroupResponse
+	searchPath := "/" + repository.indexPattern + "/_search"
+	if err := repository.postJSONWithContext(ctx, searchPath, searchBody, &latestResponse); err != nil {
+		return nil, fmt.Errorf("failed to find latest logs per group: %w", err)
+	}
+
+	responseBuckets := latestResponse.Aggregations.Buckets.Buckets
+	latestLogs := make([]TimeBucketLatestLogsDTO, 0, len(buckets))
+	for i, bucket := range buckets {
+		bucketLogs := TimeBucketLatestLogsDTO{TimeRange: bucket, Groups: []GroupLatestLogDTO{}}
+
+		if i < len(responseBuckets) {
+			for _, group := range responseBuckets[i].Groups.Buckets {
+				if len(group.Latest.Hits.Hits) == 0 {
+					continue
+				}
+
+				bucketLogs.Groups = append(bucketLogs.Groups, GroupLatestLogDTO{
+					Value: strings.TrimPrefix(group.Key, groupBy+"="),
+					Count: group.DocCount,
+					Log:   logItemFromSource(group.Latest.Hits.Hits[0].Source),
+				})
+			}
+		}
+
+		latestLogs = append(latestLogs, bucketLogs)
+	}
+
+	return latestLogs, nil
+}
+
+// FindLogCursor returns the cursor of the project log with the id, nil when the
+// project has no such log
+func (repository *LogCoreRepository) FindLogCursor(projectID uuid.UUID, logID string) (*LogCursor, error) {
+	searchBody := map[string]any{
+		"size":    1,
+		"_source": false,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
+					map[string]any{"term": map[string]any{"id.keyword": logID}},
+				},
+			},
+		},
+		"sort": []any{
+			map[string]any{"timestamp": map[string]any{"order": "asc"}},
+			map[string]any{"id.keyword": map[string]any{"order": "asc"}},
+		},
+	}
+
+	var searchResponse openSearchSearchResponse
+	if err := repository.postJSON("/"+repository.indexPattern+"/_search", searchBody, &searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to find log: %w", err)
+	}
+
+	if len(searchResponse.Hits.Hits) == 0 {
+		return nil, nil
+	}
+
+	cursor, ok := cursorFromSortValues(searchResponse.Hits.Hits[0].Sort)
+	if !ok {
+		return nil, fmt.Errorf("log %s has no sort values", logID)
+	}
+
+	return cursor, nil
+}
+
+// searchTarget returns the search path of the given partitions or of every log
+// index, of the archived tier as well when it is included. Partitions without an
+// index (no logs that day) are skipped
+func (repository *LogCoreRepository) searchTarget(partitions []string, includeArchived bool) string {
+	if len(partitions) == 0 {
+		if includeArchived {
+			return repository.allTiersPattern() + "/_search"
+		}
+
+		return repository.indexPattern + "/_search"
+	}
+
+	indexNames := make([]string, 0, len(partitions))
+	for _, partition := range partitions {
+		partitionDate, err := time.Parse(PartitionDateLayout, partition)
+		if err != nil {
+			continue
+		}
+
+		indexNames = append(indexNames, repository.indexFor(partitionDate))
+		if includeArchived {
+			indexNames = append(indexNames, repository.archiveIndexFor(repository.indexFor(partitionDate)))
+		}
+	}
+
+	return strings.Join(indexNames, ",") + "/_search?ignore_unavailable=true&allow_no_indices=true"
+}
+
+func (repository *LogCoreRepository) postJSON(path string, body any, target any) error {
+	return repository.postJSONWithContext(context.Background(), path, body, target)
+}
+
+// postJSONWithContext ties the OpenSearch request to the context, so cancelling
+// the context stops a running aggregation
+func (repository *LogCoreRepository) postJSONWithContext(ctx context.Context, path string, body any, target any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", repository.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := repository.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to connect to OpenSearch: %w", err)
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			repository.logger.Error("failed to close response body", "error", closeErr)
+		}
+	}()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned status %d: %s", response.StatusCode, string(responseBody))
+	}
+
+	if err := json.Unmarshal(responseBody, target); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
+func windowBucketCounts(aggregation openSearchWindowCountsAggregation, windowsCount int) []int64 {
+	counts := make([]int64, windowsCount)
+	for i, bucket := range aggregation.Buckets {
+		if i < windowsCount {
+			counts[i] = bucket.DocCount
+		}
+	}
+
+	return counts
+}
+
 func (repository *LogCoreRepository) indexFor(timestamp time.Time) string {
 	utcTime := timestamp.UTC()
 	return fmt.Sprintf("%s%04d.%02d.%02d", repository.indexPrefix, utcTime.Year(), int(utcTime.Month()), utcTime.Day())
@@ -605,3 +1227,69 @@ func asString(value any) string {
 		return fmt.Sprintf("%v", value)
 	}
 }
+
+// logItemFromSource converts a stored document, custom fields and the client
+// IP end up in Fields
+func logItemFromSource(source map[string]any) LogItemDTO {
+	logItemDTO := LogItemDTO{
+		ID:       asString(source["id"]),
+		Level:    asString(source["level"]),
+		Message:  asString(source["message"]),
+		ClientIP: asString(source["client_ip"]),
+	}
+	if timestampNanos, exists := source["timestamp"]; exists {
+		if nanos, ok := timestampNanos.(float64); ok {
+			logItemDTO.Timestamp = time.Unix(0, int64(nanos)).UTC()
+		}
+	}
+
+	if createdAtStr, exists := source["created_at"].(string); exists {
+		if parsedTime, err := time.Parse(time.RFC3339Nano, createdAtStr); err == nil {
+			logItemDTO.CreatedAt = parsedTime.UTC()
+		}
+	}
+
+	// Custom fields of message-only projects are nested, they are read as any other
+	if storedFields, ok := source[storedFieldsDocumentField].(map[string]any); ok {
+		source = maps.Clone(source)
+		maps.Copy(source, storedFields)
+	}
+
+	// Collect custom fields from source (excluding system fields) plus clientIp in sorted order
+	var fieldNames []string
+	for fieldName := range source {
+		if !systemFields[fieldName] || fieldName == "client_ip" {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	if len(fieldNames) > 0 {
+		// Sort field names alphabetically to ensure consistent ordering
+		slices.Sort(fieldNames)
+		fields := make(map[string]any)
+
+		for _, fieldName := range fieldNames {
+
+			// Map client_ip to client_ip for consistency in Fields
+			if fieldName == "client_ip" {
+				fields["client_ip"] = source[fieldName]
+			} else {
+				fields[fieldName] = source[fieldName]
+			}
+		}
+		logItemDTO.Fields = fields
+	}
+
+	return logItemDTO
+}
+
+func appendBulkDocument(bulkRequestBuilder *strings.Builder, document map[string]any) error {
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	bulkRequestBuilder.Write(documentBytes)
+	bulkRequestBuilder.WriteByte('\n')
+
+	return nil
+}
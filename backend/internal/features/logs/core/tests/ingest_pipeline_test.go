@@ -0,0 +1,162 @@
+package logs_core_tests
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_controllers "logbull/internal/features/projects/controllers"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticIngestPipelineResolver struct {
+	pipelines map[uuid.UUID]string
+	err       error
+}
+
+func (r *staticIngestPipelineResolver) GetIngestPipeline(projectID uuid.UUID) (string, error) {
+	return r.pipelines[projectID], r.err
+}
+
+type bulkRequestRecorder struct {
+	mu      sync.Mutex
+	actions []map[string]any
+}
+
+func Test_StoreLogsBatch_WithProjectPipeline_AttachesPipelineToProjectLogs(t *testing.T) {
+	recorder := &bulkRequestRecorder{}
+	repository := createBulkRecordingRepository(t, recorder)
+
+	projectWithPipeline := uuid.New()
+	projectWithoutPipeline := uuid.New()
+	repository.SetIngestPipelineResolver(&staticIngestPipelineResolver{
+		pipelines: map[uuid.UUID]string{projectWithPipeline: "nginx-grok"},
+	})
+
+	now := time.Now().UTC()
+	entries := MergeLogEntries(
+		CreateTestLogEntriesWithUniqueFields(projectWithPipeline, now, "Piped log", nil),
+		CreateTestLogEntriesWithUniqueFields(projectWithoutPipeline, now, "Plain log", nil),
+	)
+
+	assert.NoError(t, repository.StoreLogsBatch(entries))
+
+	actions := recorder.getActions()
+	assert.Len(t, actions, 2)
+	for _, action := range actions {
+		if action["routing"] == projectWithPipeline.String() {
+			assert.Equal(t, "nginx-grok", action["pipeline"])
+		} else {
+			assert.NotContains(t, action, "pipeline")
+		}
+	}
+}
+
+func Test_StoreLogsBatch_WhenPipelineCannotBeResolved_IndexesLogsWithoutPipeline(t *testing.T) {
+	recorder := &bulkRequestRecorder{}
+	repository := createBulkRecordingRepository(t, recorder)
+
+	projectID := uuid.New()
+	repository.SetIngestPipelineResolver(&staticIngestPipelineResolver{err: errors.New("project not found")})
+
+	entries := CreateTestLogEntriesWithUniqueFields(projectID, time.Now().UTC(), "Plain log", nil)
+	assert.NoError(t, repository.StoreLogsBatch(entries))
+
+	actions := recorder.getActions()
+	if assert.Len(t, actions, 1) {
+		assert.NotContains(t, actions[0], "pipeline")
+	}
+}
+
+func Test_UpdateProject_WithIngestPipeline_StoresPipelineSetting(t *testing.T) {
+	router := projects_testing.CreateTestRouter(projects_controllers.GetProjectController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project := projects_testing.CreateTestProject("Ingest Pipeline Test "+uuid.New().String()[:8], owner, router)
+
+	project.IngestPipeline = "nginx-grok.v2"
+	updatedProject := projects_testing.UpdateProject(project, project, owner.Token, router)
+	assert.Equal(t, "nginx-grok.v2", updatedProject.IngestPipeline)
+
+	resolvedPipeline, err := logs_core.GetLogCoreRepository().GetIngestPipelineResolver().GetIngestPipeline(project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx-grok.v2", resolvedPipeline)
+}
+
+func Test_UpdateProject_WithInvalidIngestPipeline_ReturnsBadRequest(t *testing.T) {
+	router := projects_testing.CreateTestRouter(projects_controllers.GetProjectController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project := projects_testing.CreateTestProject("Ingest Pipeline Invalid "+uuid.New().String()[:8], owner, router)
+	project.IsConfirmQuotaDisable = true
+
+	for _, pipeline := range []string{"nginx grok", "pipe/line", strings.Repeat("p", 101)} {
+		project.IngestPipeline = pipeline
+		response := projects_testing.MakeAPIRequest(
+			router, "PUT", "/api/v1/projects/"+project.ID.String(), "Bearer "+owner.Token, project,
+		)
+		assert.Equal(t, http.StatusBadRequest, response.Code, "pipeline %q", pipeline)
+	}
+}
+
+func createBulkRecordingRepository(t *testing.T, recorder *bulkRequestRecorder) *logs_core.LogCoreRepository {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		recorder.record(string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"errors": false, "items": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return logs_core.GetLogCoreRepositoryForURL(server.URL)
+}
+
+// record keeps the index actions of a bulk body, documents follow each of
+// them on the next line
+func (r *bulkRequestRecorder) record(body string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	isActionLine := true
+	for scanner.Scan() {
+		if isActionLine {
+			var action map[string]map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &action); err == nil {
+				r.actions = append(r.actions, action["index"])
+			}
+		}
+
+		isActionLine = !isActionLine
+	}
+}
+
+func (r *bulkRequestRecorder) getActions() []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]map[string]any{}, r.actions...)
+}
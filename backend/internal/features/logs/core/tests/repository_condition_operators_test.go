@@ -1,6 +1,7 @@
 package logs_core_tests
 
 import (
+	"strconv"
 	"testing"
 	"time"
 
@@ -225,6 +226,78 @@ func Test_ExecuteQueryForProject_WithContainsOperator_UserAgentField_ReturnsMatc
 	assert.Contains(t, log.Message, "User request from browser", "Should return the correct log")
 }
 
+func Test_ExecuteQueryForProject_WithStartsWithAndEndsWithOperators_ReturnsMatchingLogs(t *testing.T) {
+	t.Parallel()
+	repository := logs_core.GetLogCoreRepository()
+	projectID := uuid.New()
+	uniqueTestSession := uuid.New().String()[:8]
+	currentTime := time.Now().UTC()
+
+	requests := []struct {
+		message     string
+		requestPath string
+		hostname    string
+	}{
+		{"Api request served", "/api/orders", "db.internal"},
+		{"Api request failed", "/api/users", "web.example.com"},
+		{"Health check served", "/health/api/", "cache.INTERNAL"},
+		{"Static file served", "/static/API/app.js", "internal.example.com"},
+	}
+
+	allEntries := map[uuid.UUID][]*logs_core.LogItem{}
+	for i, request := range requests {
+		allEntries = MergeLogEntries(allEntries, CreateTestLogEntriesWithUniqueFields(projectID,
+			currentTime.Add(time.Duration(i)*time.Second), request.message, map[string]any{
+				"test_session": uniqueTestSession,
+				"request_path": request.requestPath,
+				"hostname":     request.hostname,
+			}))
+	}
+	StoreTestLogsAndFlush(t, repository, allEntries)
+
+	// Matching is case sensitive like contains
+	testCases := []struct {
+		field            string
+		operator         logs_core.ConditionOperator
+		value            string
+		expectedMessages []string
+	}{
+		{"request_path", logs_core.ConditionOperatorStartsWith, "/api/",
+			[]string{"Api request served", "Api request failed"}},
+		{"hostname", logs_core.ConditionOperatorEndsWith, ".internal", []string{"Api request served"}},
+		{"message", logs_core.ConditionOperatorStartsWith, "Api request",
+			[]string{"Api request served", "Api request failed"}},
+		{"message", logs_core.ConditionOperatorEndsWith, "served",
+			[]string{"Api request served", "Health check served", "Static file served"}},
+		// Wildcard characters in the suffix are matched literally
+		{"request_path", logs_core.ConditionOperatorEndsWith, "*.js", []string{}},
+	}
+
+	for _, testCase := range testCases {
+		query := &logs_core.LogQueryRequestDTO{
+			Query: &logs_core.QueryNode{
+				Type: logs_core.QueryNodeTypeCondition,
+				Condition: &logs_core.ConditionNode{
+					Field:    testCase.field,
+					Operator: testCase.operator,
+					Value:    testCase.value,
+				},
+			},
+			Limit: 10,
+		}
+
+		result, err := repository.ExecuteQueryForProject(projectID, query)
+		assert.NoError(t, err)
+
+		messages := []string{}
+		for _, log := range result.Logs {
+			messages = append(messages, log.Message)
+		}
+		assert.ElementsMatch(t, testCase.expectedMessages, messages,
+			"%s %s %q", testCase.field, testCase.operator, testCase.value)
+	}
+}
+
 // Array Operations Tests
 
 func Test_ExecuteQueryForProject_WithInOperator_ReturnsMatchingLogs(t *testing.T) {
@@ -1031,66 +1104,71 @@ func Test_ExecuteQueryForProject_WithLessOrEqualOperator_SystemField_ReturnsMatc
 	assert.False(t, foundPositions["after"], "Should not include the after boundary log")
 }
 
-func Test_ExecuteQueryForProject_WithRangeOperators_CustomField_ReturnsNoLogs(t *testing.T) {
+func Test_ExecuteQueryForProject_WithRangeOperators_CustomField_ReturnsMatchingLogs(t *testing.T) {
 	t.Parallel()
 	repository := logs_core.GetLogCoreRepository()
 	projectID := uuid.New()
-	uniqueTestSession := uuid.New().String()[:8]
 	currentTime := time.Now().UTC()
 
-	// Create logs with custom numeric fields
-	testLogs := CreateTestLogEntriesWithUniqueFields(projectID, currentTime,
-		"Log with custom numeric field", map[string]any{
-			"test_session":  uniqueTestSession,
-			"custom_number": 100,
-			"response_time": 250,
-		})
-
-	StoreTestLogsAndFlush(t, repository, testLogs)
+	// The field is sent as numbers and as a numeric string, one log lacks it
+	fastLog := CreateTestLogEntriesWithUniqueFields(projectID, currentTime,
+		"Fast request", map[string]any{"range_test_duration": 250})
+	slowLog := CreateTestLogEntriesWithUniqueFields(projectID, currentTime.Add(1*time.Second),
+		"Slow request", map[string]any{"range_test_duration": 700})
+	slowStringLog := CreateTestLogEntriesWithUniqueFields(projectID, currentTime.Add(2*time.Second),
+		"Slow request sent as string", map[string]any{"range_test_duration": "900.5"})
+	otherLog := CreateTestLogEntriesWithUniqueFields(projectID, currentTime.Add(3*time.Second),
+		"Request without duration", map[string]any{"other_field": "value"})
+
+	allEntries := MergeLogEntries(fastLog, slowLog)
+	allEntries = MergeLogEntries(allEntries, slowStringLog)
+	allEntries = MergeLogEntries(allEntries, otherLog)
+	StoreTestLogsAndFlush(t, repository, allEntries)
 
-	// Test that range operators on custom fields return no results
-	rangeOperators := []logs_core.ConditionOperator{
-		logs_core.ConditionOperatorGreaterThan,
-		logs_core.ConditionOperatorGreaterOrEqual,
-		logs_core.ConditionOperatorLessThan,
-		logs_core.ConditionOperatorLessOrEqual,
+	testCases := []struct {
+		operator         logs_core.ConditionOperator
+		value            any
+		expectedMessages []string
+	}{
+		{
+			logs_core.ConditionOperatorGreaterThan, 500,
+			[]string{"Slow request", "Slow request sent as string"},
+		},
+		{
+			logs_core.ConditionOperatorGreaterOrEqual, "700",
+			[]string{"Slow request", "Slow request sent as string"},
+		},
+		{logs_core.ConditionOperatorLessThan, 700, []string{"Fast request"}},
+		{
+			logs_core.ConditionOperatorLessOrEqual, 900.5,
+			[]string{"Fast request", "Slow request", "Slow request sent as string"},
+		},
+		{logs_core.ConditionOperatorGreaterThan, "not a number", []string{}},
 	}
 
-	for _, operator := range rangeOperators {
+	for _, testCase := range testCases {
 		rangeQuery := &logs_core.LogQueryRequestDTO{
 			Query: &logs_core.QueryNode{
 				Type: logs_core.QueryNodeTypeCondition,
 				Condition: &logs_core.ConditionNode{
-					Field:    "custom_number",
-					Operator: operator,
-					Value:    50,
+					Field:    "range_test_duration",
+					Operator: testCase.operator,
+					Value:    testCase.value,
 				},
 			},
 			Limit: 10,
 		}
 
 		result, err := repository.ExecuteQueryForProject(projectID, rangeQuery)
-		assert.NoError(t, err)
-		assert.Equal(t, int64(0), result.Total, "Range operator %s on custom field should return 0 results", operator)
-		assert.Empty(t, result.Logs, "Range operator %s on custom field should return no logs", operator)
-	}
+		assert.NoError(t, err, "Range operator %s should not error", testCase.operator)
 
-	// Verify that the same logs can be found with a non-range operator
-	equalsQuery := &logs_core.LogQueryRequestDTO{
-		Query: &logs_core.QueryNode{
-			Type: logs_core.QueryNodeTypeCondition,
-			Condition: &logs_core.ConditionNode{
-				Field:    "test_session",
-				Operator: logs_core.ConditionOperatorEquals,
-				Value:    uniqueTestSession,
-			},
-		},
-		Limit: 10,
+		messages := make([]string, 0, len(result.Logs))
+		for _, log := range result.Logs {
+			messages = append(messages, log.Message)
+		}
+		assert.ElementsMatch(t, testCase.expectedMessages, messages,
+			"Range operator %s with %v on custom field", testCase.operator, testCase.value)
 	}
-
-	equalsResult, err := repository.ExecuteQueryForProject(projectID, equalsQuery)
-	assert.NoError(t, err)
-	assert.Equal(t, int64(1), equalsResult.Total, "Should find the logs with non-range operator")
 }
 
 // Edge Cases and Error Conditions
@@ -1375,3 +1453,193 @@ func Test_ExecuteQueryForProject_WithExactBoundaryTimestamp_ReturnsMatchingLogs(
 	// This test documents the behavior rather than asserting a specific result
 	t.Logf("Equals query on exact timestamp returned %d results", equalsResult.Total)
 }
+
+func Test_ExecuteQueryForProject_WithCaseInsensitiveStringOperators_ReturnsLogsRegardlessOfCase(t *testing.T) {
+	t.Parallel()
+	repository := logs_core.GetLogCoreRepository()
+	projectID := uuid.New()
+	currentTime := time.Now().UTC()
+
+	firefoxLog := CreateTestLogEntriesWithUniqueFields(projectID, currentTime,
+		"Request From Firefox", map[string]any{"user_agent": "Mozilla/5.0 Firefox/121.0"})
+	curlLog := CreateTestLogEntriesWithUniqueFields(projectID, currentTime.Add(1*time.Second),
+		"Request from curl", map[string]any{"user_agent": "curl/8.4.0"})
+	StoreTestLogsAndFlush(t, repository, MergeLogEntries(firefoxLog, curlLog))
+
+	testCases := []struct {
+		name             string
+		field            string
+		operator         logs_core.ConditionOperator
+		value            any
+		expectedMessages []string
+	}{
+		{
+			"equals on custom field", "user_agent", logs_core.ConditionOperatorEquals,
+			"MOZILLA/5.0 FIREFOX/121.0", []string{"Request From Firefox"},
+		},
+		{
+			"not_equals on custom field", "user_agent", logs_core.ConditionOperatorNotEquals,
+			"CURL/8.4.0", []string{"Request From Firefox"},
+		},
+		{
+			"contains on custom field", "user_agent", logs_core.ConditionOperatorContains,
+			"firefox", []string{"Request From Firefox"},
+		},
+		{
+			"not_contains on custom field", "user_agent", logs_core.ConditionOperatorNotContains,
+			"FIREFOX", []string{"Request from curl"},
+		},
+		{
+			"contains on message", "message", logs_core.ConditionOperatorContains,
+			"request from", []string{"Request From Firefox", "Request from curl"},
+		},
+		{
+			"in on custom field", "user_agent", logs_core.ConditionOperatorIn,
+			[]any{"CURL/8.4.0", "wget/1.21"}, []string{"Request from curl"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		query := &logs_core.LogQueryRequestDTO{
+			Query: &logs_core.QueryNode{
+				Type: logs_core.QueryNodeTypeCondition,
+				Condition: &logs_core.ConditionNode{
+					Field:    testCase.field,
+					Operator: testCase.operator,
+					Value:    testCase.value,
+				},
+			},
+			Limit: 10,
+		}
+
+		// Case sensitive by default, so only the exact case of the stored values would match
+		result, err := repository.ExecuteQueryForProject(projectID, query)
+		assert.NoError(t, err, testCase.name)
+		assert.NotEqual(t, int64(len(testCase.expectedMessages)), result.Total, testCase.name)
+
+		query.Query.Condition.CaseInsensitive = true
+		result, err = repository.ExecuteQueryForProject(projectID, query)
+		assert.NoError(t, err, testCase.name)
+
+		messages := make([]string, 0, len(result.Logs))
+		for _, log := range result.Logs {
+			messages = append(messages, log.Message)
+		}
+		assert.ElementsMatch(t, testCase.expectedMessages, messages, testCase.name)
+	}
+}
+
+func Test_ExecuteQueryForProject_WithBetweenOperator_IncludesBothBoundaries(t *testing.T) {
+	t.Parallel()
+	repository := logs_core.GetLogCoreRepository()
+	projectID := uuid.New()
+	fromTime := time.Now().UTC().Add(-1 * time.Hour).Truncate(time.Microsecond)
+	toTime := fromTime.Add(10 * time.Millisecond)
+
+	positions := []struct {
+		name      string
+		timestamp time.Time
+	}{
+		{"just_before", fromTime.Add(-1 * time.Millisecond)},
+		{"exact_from", fromTime},
+		{"inside", fromTime.Add(5 * time.Millisecond)},
+		{"exact_to", toTime},
+		{"just_after", toTime.Add(1 * time.Millisecond)},
+	}
+
+	allEntries := map[uuid.UUID][]*logs_core.LogItem{}
+	for _, position := range positions {
+		allEntries = MergeLogEntries(allEntries, CreateTestLogEntriesWithUniqueFields(projectID, position.timestamp,
+			"Between boundary "+position.name, map[string]any{"position": position.name}))
+	}
+	StoreTestLogsAndFlush(t, repository, allEntries)
+
+	betweenQuery := &logs_core.LogQueryRequestDTO{
+		Query: &logs_core.QueryNode{
+			Type: logs_core.QueryNodeTypeCondition,
+			Condition: &logs_core.ConditionNode{
+				Field:    "timestamp",
+				Operator: logs_core.ConditionOperatorBetween,
+				Value:    []any{fromTime.Format(time.RFC3339Nano), toTime.Format(time.RFC3339Nano)},
+			},
+		},
+		Limit: 10,
+	}
+
+	result, err := repository.ExecuteQueryForProject(projectID, betweenQuery)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total, "Between should include both boundaries and the logs in between")
+
+	foundPositions := make([]string, 0, len(result.Logs))
+	for _, log := range result.Logs {
+		foundPositions = append(foundPositions, log.Fields["position"].(string))
+	}
+	assert.ElementsMatch(t, []string{"exact_from", "inside", "exact_to"}, foundPositions)
+
+	// The same instant as both bounds matches only the log at that instant
+	betweenQuery.Query.Condition.Value = []any{fromTime.Format(time.RFC3339Nano), fromTime.Format(time.RFC3339Nano)}
+	result, err = repository.ExecuteQueryForProject(projectID, betweenQuery)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Logs, 1) {
+		assert.Equal(t, "exact_from", result.Logs[0].Fields["position"])
+	}
+}
+
+func Test_BuildSearchBody_WithBetweenOperator_BuildsSingleRangeQuery(t *testing.T) {
+	fromTime := time.Date(2025, 10, 16, 10, 0, 0, 0, time.UTC)
+	toTime := fromTime.Add(time.Hour)
+
+	searchBody, err := logs_core.GetLogQueryBuilder().BuildSearchBody(uuid.New(), &logs_core.LogQueryRequestDTO{
+		Query: &logs_core.QueryNode{
+			Type: logs_core.QueryNodeTypeCondition,
+			Condition: &logs_core.ConditionNode{
+				Field:    "timestamp",
+				Operator: logs_core.ConditionOperatorBetween,
+				Value:    []any{fromTime.Format(time.RFC3339), toTime.Format(time.RFC3339)},
+			},
+		},
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+
+	must := searchBody["query"].(map[string]any)["bool"].(map[string]any)["must"].([]any)
+	assert.Contains(t, must, map[string]any{"range": map[string]any{"timestamp": map[string]any{
+		"gte": strconv.FormatInt(fromTime.UnixNano(), 10),
+		"lte": strconv.FormatInt(toTime.UnixNano(), 10),
+	}}})
+}
+
+func Test_BuildSearchBody_WithRegexOperator_BuildsTranslatedRegexpQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		field         string
+		expectedField string
+		expectedValue string
+	}{
+		{"Message", "message", "message.keyword", `.*(ORD\-([0-9]){6}).*`},
+		{"Custom field", "order_id", "attrs_tokens.keyword", `order_id=.*(ORD\-([0-9]){6}).*`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchBody, err := logs_core.GetLogQueryBuilder().BuildSearchBody(uuid.New(), &logs_core.LogQueryRequestDTO{
+				Query: &logs_core.QueryNode{
+					Type: logs_core.QueryNodeTypeCondition,
+					Condition: &logs_core.ConditionNode{
+						Field:    tt.field,
+						Operator: logs_core.ConditionOperatorRegex,
+						Value:    `ORD-\d{6}`,
+					},
+				},
+				Limit: 10,
+			})
+			assert.NoError(t, err)
+
+			must := searchBody["query"].(map[string]any)["bool"].(map[string]any)["must"].([]any)
+			assert.Contains(t, must, map[string]any{"regexp": map[string]any{tt.expectedField: map[string]any{
+				"value": tt.expectedValue,
+				"flags": "NONE",
+			}}})
+		})
+	}
+}
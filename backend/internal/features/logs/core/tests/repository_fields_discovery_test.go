@@ -68,3 +68,83 @@ func Test_DiscoverFields_WithUnavailableLogsStorage_PropagatesError(t *testing.T
 	assert.Nil(t, discoveredFields)
 	assert.Contains(t, discoveryErr.Error(), "failed to execute field discovery search")
 }
+
+func Test_DiscoverFieldsPage_WithPrefix_ReturnsOnlyMatchingFields(t *testing.T) {
+	t.Parallel()
+	repository := logs_core.GetLogCoreRepository()
+	projectID := uuid.New()
+
+	testLogEntries := CreateTestLogEntriesWithMessageAndFields(
+		projectID,
+		time.Now().UTC(),
+		"Test log for paged field discovery",
+		map[string]any{
+			"http_method": "GET",
+			"http_status": 200,
+			"HTTP_path":   "/api",
+			"user_id":     "user-1",
+		},
+	)
+	StoreTestLogsAndFlush(t, repository, testLogEntries)
+
+	page, err := repository.DiscoverFieldsPage(projectID, &logs_core.DiscoverFieldsPageRequestDTO{
+		Prefix: "http_",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	assert.ElementsMatch(t, []string{"HTTP_path", "http_method", "http_status"}, page.Fields)
+}
+
+func Test_DiscoverFieldsPage_WithLimitAndOffset_ReturnsSliceOfSortedFields(t *testing.T) {
+	t.Parallel()
+	repository := logs_core.GetLogCoreRepository()
+	projectID := uuid.New()
+
+	testLogEntries := CreateTestLogEntriesWithMessageAndFields(
+		projectID,
+		time.Now().UTC(),
+		"Test log for paged field discovery",
+		map[string]any{
+			"field_a": "a",
+			"field_b": "b",
+			"field_c": "c",
+			"field_d": "d",
+			"field_e": "e",
+		},
+	)
+	StoreTestLogsAndFlush(t, repository, testLogEntries)
+
+	allFields, err := repository.DiscoverFields(projectID)
+	assert.NoError(t, err)
+
+	page, err := repository.DiscoverFieldsPage(projectID, &logs_core.DiscoverFieldsPageRequestDTO{
+		Prefix: "field_",
+		Limit:  2,
+		Offset: 1,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, page.Total)
+	assert.Equal(t, []string{"field_b", "field_c"}, page.Fields)
+	assert.Contains(t, allFields, "field_a")
+
+	lastPage, err := repository.DiscoverFieldsPage(projectID, &logs_core.DiscoverFieldsPageRequestDTO{
+		Prefix: "field_",
+		Limit:  2,
+		Offset: 4,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"field_e"}, lastPage.Fields)
+
+	outOfRangePage, err := repository.DiscoverFieldsPage(projectID, &logs_core.DiscoverFieldsPageRequestDTO{
+		Prefix: "field_",
+		Limit:  2,
+		Offset: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, outOfRangePage.Total)
+	assert.Empty(t, outOfRangePage.Fields)
+}
@@ -1,6 +1,7 @@
 package logs_core_tests
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -203,3 +204,54 @@ func extractSequenceNumber(log logs_core.LogItemDTO) float64 {
 	}
 	return 0
 }
+
+func Test_ExecuteQueryForProject_WithEqualTimestamps_OrdersStablyByIdInBothDirections(t *testing.T) {
+	t.Parallel()
+	repository := logs_core.GetLogCoreRepository()
+	projectID := uuid.New()
+	sharedTimestamp := time.Now().UTC().Add(-time.Minute)
+
+	logItems := make([]*logs_core.LogItem, 0, 8)
+	for i := range 8 {
+		timestamp := sharedTimestamp
+		// Two logs before the shared timestamp come first in ascending order
+		if i < 2 {
+			timestamp = sharedTimestamp.Add(-time.Duration(2-i) * time.Second)
+		}
+
+		logItems = append(logItems, &logs_core.LogItem{
+			ID:        uuid.New(),
+			ProjectID: projectID,
+			Timestamp: timestamp,
+			Level:     logs_core.LogLevelInfo,
+			Message:   "Replay log",
+		})
+	}
+	StoreTestLogsAndFlush(t, repository, map[uuid.UUID][]*logs_core.LogItem{projectID: logItems})
+
+	executeInOrder := func(sortOrder string) []string {
+		result, err := repository.ExecuteQueryForProject(projectID, &logs_core.LogQueryRequestDTO{
+			Limit:     100,
+			SortOrder: sortOrder,
+		})
+		assert.NoError(t, err)
+
+		logIDs := make([]string, 0, len(result.Logs))
+		for _, log := range result.Logs {
+			logIDs = append(logIDs, log.ID)
+		}
+		return logIDs
+	}
+
+	firstAscending := executeInOrder("asc")
+	assert.Len(t, firstAscending, 8)
+	assert.Equal(t, firstAscending, executeInOrder("asc"))
+
+	assert.Equal(t, logItems[0].ID.String(), firstAscending[0])
+	assert.Equal(t, logItems[1].ID.String(), firstAscending[1])
+	assert.True(t, slices.IsSorted(firstAscending[2:]), "equal timestamps should be ordered by id")
+
+	descending := executeInOrder("desc")
+	slices.Reverse(descending)
+	assert.Equal(t, firstAscending, descending)
+}
@@ -0,0 +1,111 @@
+package logs_core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VolumeHistogramIntervals are the supported bucket sizes of log volume
+// histograms, keyed by the fixed_interval OpenSearch accepts
+var VolumeHistogramIntervals = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// GetLogVolumeHistogram counts the query matches per interval from from up to
+// to (excluded). Buckets are aligned to the interval in UTC and intervals
+// without logs are returned with a zero count, so charts stay contiguous
+func (repository *LogCoreRepository) GetLogVolumeHistogram(
+	projectID uuid.UUID,
+	query *QueryNode,
+	interval string,
+	from, to time.Time,
+) ([]VolumePoint, error) {
+	return repository.GetLogVolumeHistogramWithContext(context.Background(), projectID, query, interval, from, to)
+}
+
+// GetLogVolumeHistogramWithContext counts with the context of the OpenSearch
+// request, so cancelling the context stops the aggregation
+func (repository *LogCoreRepository) GetLogVolumeHistogramWithContext(
+	ctx context.Context,
+	projectID uuid.UUID,
+	query *QueryNode,
+	interval string,
+	from, to time.Time,
+) ([]VolumePoint, error) {
+	intervalDuration, isSupported := VolumeHistogramIntervals[interval]
+	if !isSupported {
+		return nil, fmt.Errorf("unsupported histogram interval %q", interval)
+	}
+
+	searchBody, err := repository.queryBuilder.BuildVolumeHistogramBody(projectID, query, interval, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build volume histogram body: %w", err)
+	}
+
+	var histogramResponse openSearchVolumeHistogramResponse
+	searchPath := "/" + repository.indexPattern + "/_search"
+	if err := repository.postJSONWithContext(ctx, searchPath, searchBody, &histogramResponse); err != nil {
+		return nil, fmt.Errorf("failed to count log volume: %w", err)
+	}
+
+	bucketCounts := make(map[int64]int64, len(histogramResponse.Aggregations.Volume.Buckets))
+	for _, bucket := range histogramResponse.Aggregations.Volume.Buckets {
+		bucketCounts[bucket.Key] = bucket.DocCount
+	}
+
+	// OpenSearch only returns buckets between the first and the last match
+	points := make([]VolumePoint, 0)
+	for bucketStart := from.UTC().Truncate(intervalDuration); bucketStart.Before(to); {
+		points = append(points, VolumePoint{
+			Timestamp: bucketStart,
+			Count:     bucketCounts[bucketStart.UnixMilli()],
+		})
+		bucketStart = bucketStart.Add(intervalDuration)
+	}
+
+	return points, nil
+}
+
+// BuildVolumeHistogramBody counts the query matches per interval within the
+// half open window. Timestamps are stored in nanoseconds while date histograms
+// work in milliseconds, so the buckets are built over a script value
+func (builder *QueryBuilder) BuildVolumeHistogramBody(
+	projectID uuid.UUID,
+	query *QueryNode,
+	interval string,
+	from, to time.Time,
+) (map[string]any, error) {
+	searchBody, err := builder.BuildSearchBody(projectID, &LogQueryRequestDTO{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	windowFilters := buildWindowFilters([]TimeRangeDTO{{From: &from, To: &to}})
+	if err := filterToWindows(searchBody, windowFilters); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"size":             0,
+		"query":            searchBody["query"],
+		"track_total_hits": false,
+		"aggs": map[string]any{
+			"volume": map[string]any{
+				"date_histogram": map[string]any{
+					"script": map[string]any{
+						"source": "doc['timestamp'].value / 1000000L",
+						"lang":   "painless",
+					},
+					"fixed_interval": interval,
+					"min_doc_count":  1,
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,318 @@
+package logs_exports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"logbull/internal/config"
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+)
+
+const (
+	exportSchedulerInterval = 1 * time.Minute
+	exportPageSize          = 1_000
+	// Exports hold the whole window in memory before the upload
+	maxExportLogs = 100_000
+)
+
+var errExportStoreNotConfigured = errors.New("export storage is not configured")
+
+type ScheduledExportBackgroundService struct {
+	scheduledExportRepository *ScheduledExportRepository
+	savedQueryRepository      *logs_saved_queries.SavedQueryRepository
+	logCoreRepository         *logs_core.LogCoreRepository
+	queryValidator            *logs_querying.QueryValidator
+	logQueryService           *logs_querying.LogQueryService
+	// nil when no export storage is configured
+	exportStore ExportStore
+	logger      *slog.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (s *ScheduledExportBackgroundService) SetExportStore(exportStore ExportStore) {
+	s.exportStore = exportStore
+}
+
+func (s *ScheduledExportBackgroundService) GetExportStore() ExportStore {
+	return s.exportStore
+}
+
+func (s *ScheduledExportBackgroundService) StartWorkers() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.wg.Add(1)
+	go s.schedulerWorker()
+
+	s.logger.Info("Scheduled export worker started",
+		slog.Duration("interval", exportSchedulerInterval))
+}
+
+// ExecuteDueExportsForTestAt runs the exports due at the given time, so tests
+// can trigger a schedule without waiting for it
+func (s *ScheduledExportBackgroundService) ExecuteDueExportsForTestAt(now time.Time) error {
+	return s.runDueExports(now)
+}
+
+func (s *ScheduledExportBackgroundService) schedulerWorker() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(exportSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		if config.IsShouldShutdown() {
+			s.logger.Info("Scheduled export worker shutting down due to shutdown signal")
+			return
+		}
+
+		select {
+		case <-s.ctx.Done():
+			s.logger.Info("Scheduled export worker shutting down")
+			return
+
+		case <-ticker.C:
+			if err := s.runDueExports(time.Now().UTC()); err != nil {
+				s.logger.Error("Error during scheduled exports", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// runDueExports exports one window per due export. Missed windows (e.g. after
+// downtime) are caught up one per tick, failed ones are retried on the next tick
+func (s *ScheduledExportBackgroundService) runDueExports(now time.Time) error {
+	scheduledExports, err := s.scheduledExportRepository.GetDueScheduledExports(now)
+	if err != nil {
+		return fmt.Errorf("failed to get due scheduled exports: %w", err)
+	}
+
+	for _, scheduledExport := range scheduledExports {
+		if err := s.runExport(scheduledExport); err != nil {
+			s.logger.Error("Failed to run scheduled export",
+				slog.String("exportId", scheduledExport.ID.String()),
+				slog.String("projectId", scheduledExport.ProjectID.String()),
+				slog.String("error", err.Error()))
+
+			scheduledExport.LastError = err.Error()
+		} else {
+			scheduledExport.LastError = ""
+			scheduledExport.NextRunAt = scheduledExport.NextRunAt.Add(scheduledExport.Schedule.Period())
+		}
+
+		lastRunAt := now
+		scheduledExport.LastRunAt = &lastRunAt
+
+		if err := s.scheduledExportRepository.SaveScheduledExport(scheduledExport); err != nil {
+			s.logger.Error("Failed to save scheduled export state",
+				slog.String("exportId", scheduledExport.ID.String()),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+func (s *ScheduledExportBackgroundService) runExport(scheduledExport *ScheduledExport) error {
+	if s.exportStore == nil {
+		return errExportStoreNotConfigured
+	}
+
+	savedQuery, err := s.savedQueryRepository.GetSavedQuery(scheduledExport.ProjectID, scheduledExport.SavedQueryID)
+	if err != nil {
+		return fmt.Errorf("failed to get saved query: %w", err)
+	}
+
+	if err := s.queryValidator.ValidateQuery(savedQuery.Query); err != nil {
+		return fmt.Errorf("saved query is no longer valid: %w", err)
+	}
+
+	query, err := s.logQueryService.ResolveFieldAliasesForProject(scheduledExport.ProjectID, savedQuery.Query)
+	if err != nil {
+		return err
+	}
+
+	query, err = s.logQueryService.EncryptQueryForProject(scheduledExport.ProjectID, query)
+	if err != nil {
+		return fmt.Errorf("saved query cannot run on encrypted fields: %w", err)
+	}
+
+	windowEnd := scheduledExport.NextRunAt.UTC()
+	windowStart := windowEnd.Add(-scheduledExport.Schedule.Period())
+
+	logs, err := s.collectWindowLogs(scheduledExport, query, windowStart, windowEnd)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	switch scheduledExport.Format {
+	case ExportFormatCSV:
+		content, err = encodeLogsAsCSV(logs)
+	default:
+		content, err = encodeLogsAsNDJSON(logs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode export: %w", err)
+	}
+
+	key := BuildExportObjectKey(scheduledExport, windowStart)
+	if err := s.exportStore.PutObject(
+		scheduledExport.Bucket,
+		key,
+		scheduledExport.Format.ContentType(),
+		content,
+	); err != nil {
+		return err
+	}
+
+	s.logger.Info("Scheduled export written",
+		slog.String("exportId", scheduledExport.ID.String()),
+		slog.String("bucket", scheduledExport.Bucket),
+		slog.String("key", key),
+		slog.Int("logs", len(logs)))
+
+	return nil
+}
+
+// collectWindowLogs pages through the window in ascending order with cursors,
+// which unlike offsets are not limited by the result window of OpenSearch
+func (s *ScheduledExportBackgroundService) collectWindowLogs(
+	scheduledExport *ScheduledExport,
+	query *logs_core.QueryNode,
+	windowStart, windowEnd time.Time,
+) ([]logs_core.LogItemDTO, error) {
+	// Windows are half open, so a log at midnight belongs to one export only
+	to := windowEnd.Add(-time.Nanosecond)
+	request := &logs_core.LogQueryRequestDTO{
+		Query:     query,
+		TimeRange: &logs_core.TimeRangeDTO{From: &windowStart, To: &to},
+		Limit:     exportPageSize,
+		SortOrder: "asc",
+	}
+
+	var logs []logs_core.LogItemDTO
+	for {
+		response, err := s.logCoreRepository.ExecuteQueryForProject(scheduledExport.ProjectID, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query logs for export: %w", err)
+		}
+
+		if err := s.logQueryService.DecryptLogsForProject(scheduledExport.ProjectID, response.Logs); err != nil {
+			return nil, fmt.Errorf("failed to decrypt logs for export: %w", err)
+		}
+
+		logs = append(logs, response.Logs...)
+
+		if len(logs) >= maxExportLogs {
+			s.logger.Warn("Scheduled export reached the maximum logs count, the rest of the window is skipped",
+				slog.String("exportId", scheduledExport.ID.String()),
+				slog.Int("maxLogs", maxExportLogs))
+			return logs[:maxExportLogs], nil
+		}
+
+		if len(response.Logs) < exportPageSize || response.Cursor == "" {
+			return logs, nil
+		}
+
+		request.After = response.Cursor
+	}
+}
+
+// BuildExportObjectKey returns <keyPrefix>/<savedQueryId>/<window start date><extension>
+func BuildExportObjectKey(scheduledExport *ScheduledExport, windowStart time.Time) string {
+	fileName := windowStart.UTC().Format("2006-01-02") + scheduledExport.Format.FileExtension()
+	return path.Join(scheduledExport.KeyPrefix, scheduledExport.SavedQueryID.String(), fileName)
+}
+
+func encodeLogsAsNDJSON(logs []logs_core.LogItemDTO) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+
+	for _, log := range logs {
+		if err := encoder.Encode(log); err != nil {
+			return nil, err
+		}
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// encodeLogsAsCSV writes the standard columns followed by one column per custom
+// field found in the window, sorted by name. Non string values are JSON encoded
+func encodeLogsAsCSV(logs []logs_core.LogItemDTO) ([]byte, error) {
+	var fieldNames []string
+	seenFields := make(map[string]bool)
+	for _, log := range logs {
+		for fieldName := range log.Fields {
+			if fieldName != "client_ip" && !seenFields[fieldName] {
+				seenFields[fieldName] = true
+				fieldNames = append(fieldNames, fieldName)
+			}
+		}
+	}
+	slices.Sort(fieldNames)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	header := append([]string{"id", "timestamp", "level", "message", "client_ip"}, fieldNames...)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, log := range logs {
+		record := []string{
+			log.ID,
+			log.Timestamp.UTC().Format(time.RFC3339Nano),
+			log.Level,
+			log.Message,
+			log.ClientIP,
+		}
+
+		for _, fieldName := range fieldNames {
+			value, err := formatCSVValue(log.Fields[fieldName])
+			if err != nil {
+				return nil, err
+			}
+			record = append(record, value)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	return buffer.Bytes(), writer.Error()
+}
+
+func formatCSVValue(value any) (string, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typedValue, nil
+	default:
+		data, err := json.Marshal(typedValue)
+		if err != nil {
+			return "", err
+		}
+
+		return string(data), nil
+	}
+}
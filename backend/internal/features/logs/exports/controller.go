@@ -0,0 +1,257 @@
+package logs_exports
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduledExportController struct {
+	scheduledExportService *ScheduledExportService
+}
+
+func (c *ScheduledExportController) RegisterRoutes(router *gin.RouterGroup) {
+	exportRoutes := router.Group("/logs/saved-queries/:projectId/:queryId/export")
+
+	exportRoutes.PUT("", c.SaveScheduledExport)
+	exportRoutes.GET("", c.GetScheduledExport)
+	exportRoutes.DELETE("", c.DeleteScheduledExport)
+}
+
+// SaveScheduledExport
+// @Summary Save scheduled export
+// @Description Create or replace the scheduled export of a saved query. Every day (at midnight UTC) or week
+// @Description (on Monday) the results of the previous window are written to the bucket as NDJSON or CSV under
+// @Description <keyPrefix>/<queryId>/<window start date>. Only project owners and admins can manage exports
+// @Tags logs-exports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param queryId path string true "Saved query ID (UUID format)"
+// @Param request body SaveScheduledExportRequestDTO true "Export schedule, format and destination"
+// @Success 200 {object} ScheduledExport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/saved-queries/{projectId}/{queryId}/export [put]
+func (c *ScheduledExportController) SaveScheduledExport(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, savedQueryID, isOk := c.parseIDs(ctx)
+	if !isOk {
+		return
+	}
+
+	var request SaveScheduledExportRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	scheduledExport, err := c.scheduledExportService.SaveScheduledExport(projectID, savedQueryID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scheduledExport)
+}
+
+// GetScheduledExport
+// @Summary Get scheduled export
+// @Description Get the scheduled export of a saved query with its next run and the error of the last run
+// @Tags logs-exports
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param queryId path string true "Saved query ID (UUID format)"
+// @Success 200 {object} ScheduledExport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/saved-queries/{projectId}/{queryId}/export [get]
+func (c *ScheduledExportController) GetScheduledExport(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, savedQueryID, isOk := c.parseIDs(ctx)
+	if !isOk {
+		return
+	}
+
+	scheduledExport, err := c.scheduledExportService.GetScheduledExport(projectID, savedQueryID, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scheduledExport)
+}
+
+// DeleteScheduledExport
+// @Summary Delete scheduled export
+// @Description Stop exporting a saved query, already written files are kept
+// @Tags logs-exports
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param queryId path string true "Saved query ID (UUID format)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/saved-queries/{projectId}/{queryId}/export [delete]
+func (c *ScheduledExportController) DeleteScheduledExport(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, savedQueryID, isOk := c.parseIDs(ctx)
+	if !isOk {
+		return
+	}
+
+	if err := c.scheduledExportService.DeleteScheduledExport(projectID, savedQueryID, user); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Scheduled export deleted successfully"})
+}
+
+func (c *ScheduledExportController) parseIDs(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	savedQueryID, err := uuid.Parse(ctx.Param("queryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved query ID format"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, savedQueryID, true
+}
+
+func (c *ScheduledExportController) handleError(ctx *gin.Context, err error) {
+	handleExportError(ctx, err)
+}
+
+func handleExportError(ctx *gin.Context, err error) {
+	// Sink exports take a query slot like any other query
+	var validationErr *logs_querying.ValidationError
+	if errors.As(err, &validationErr) {
+		switch validationErr.Code {
+		case logs_core.ErrorTooManyConcurrentQueries:
+			ctx.JSON(http.StatusTooManyRequests, gin.H{"error": validationErr.Message, "code": validationErr.Code})
+			return
+		case logs_core.ErrorQueryCapacityExceeded:
+			ctx.Header("Retry-After", strconv.Itoa(int(logs_querying.QueryCapacityRetryAfter.Seconds())))
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": validationErr.Message, "code": validationErr.Code})
+			return
+		}
+	}
+
+	if strings.Contains(err.Error(), "not found") {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.Contains(err.Error(), "insufficient permissions") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.HasPrefix(err.Error(), "invalid") {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+type SinkExportController struct {
+	sinkExportService *SinkExportService
+}
+
+func (c *SinkExportController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/logs/exports/:projectId/sink", c.ExportToSink)
+}
+
+// ExportToSink
+// @Summary Export query results to an HTTP sink
+// @Description Post the logs matching the query within the time range to the sink URL in batches (NDJSON or CSV,
+// @Description oldest first), e.g. for one-off backfills. Failed batches are retried with exponential backoff on
+// @Description network errors, 429 and 5xx. When a batch keeps failing the export stops with 502 and the cursor of
+// @Description the last delivered log, which is passed as "after" to resume. An export stops after 100000 logs or
+// @Description 2 minutes and is resumed the same way. Sinks on loopback or private networks are refused unless
+// @Description SINK_EXPORT_ALLOW_PRIVATE_NETWORKS is set. Only project owners and admins can export
+// @Tags logs-exports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param request body ExportToSinkRequestDTO true "Query, time range and sink"
+// @Success 200 {object} ExportToSinkResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 502 {object} ExportToSinkResponseDTO
+// @Failure 503 {object} map[string]string
+// @Router /logs/exports/{projectId}/sink [post]
+func (c *SinkExportController) ExportToSink(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request ExportToSinkRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.sinkExportService.ExportToSink(projectID, &request, user)
+	if err != nil {
+		handleExportError(ctx, err)
+		return
+	}
+
+	if response.Error != "" {
+		ctx.JSON(http.StatusBadGateway, response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,99 @@
+package logs_exports
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"logbull/internal/config"
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+	projects_services "logbull/internal/features/projects/services"
+	system_webhooks "logbull/internal/features/system/webhooks"
+	"logbull/internal/util/logger"
+)
+
+var scheduledExportRepository = &ScheduledExportRepository{}
+
+var scheduledExportService = &ScheduledExportService{
+	scheduledExportRepository,
+	logs_saved_queries.GetSavedQueryRepository(),
+	projects_services.GetProjectService(),
+	audit_logs.GetAuditLogService(),
+}
+
+var scheduledExportBackgroundService = &ScheduledExportBackgroundService{
+	scheduledExportRepository,
+	logs_saved_queries.GetSavedQueryRepository(),
+	logs_core.GetLogCoreRepository(),
+	logs_querying.GetQueryValidator(),
+	logs_querying.GetLogQueryService(),
+	newExportStoreFromConfig(),
+	logger.GetLogger(),
+	nil,
+	nil,
+	sync.WaitGroup{},
+}
+
+var scheduledExportController = &ScheduledExportController{
+	scheduledExportService,
+}
+
+var sinkExportService = &SinkExportService{
+	logs_core.GetLogCoreRepository(),
+	logs_querying.GetQueryValidator(),
+	logs_querying.GetLogQueryService(),
+	logs_querying.GetConcurrentQueryLimiter(),
+	projects_services.GetProjectService(),
+	audit_logs.GetAuditLogService(),
+	system_webhooks.GetDestinationGuard(),
+	system_webhooks.GetDestinationGuard().NewHTTPClient(sinkRequestTimeout),
+	logger.GetLogger(),
+	defaultSinkRetryBackoff,
+	defaultMaxSinkExportDuration,
+}
+
+var sinkExportController = &SinkExportController{
+	sinkExportService,
+}
+
+func GetScheduledExportRepository() *ScheduledExportRepository {
+	return scheduledExportRepository
+}
+
+func GetScheduledExportService() *ScheduledExportService {
+	return scheduledExportService
+}
+
+func GetScheduledExportBackgroundService() *ScheduledExportBackgroundService {
+	return scheduledExportBackgroundService
+}
+
+func GetScheduledExportController() *ScheduledExportController {
+	return scheduledExportController
+}
+
+func GetSinkExportService() *SinkExportService {
+	return sinkExportService
+}
+
+func GetSinkExportController() *SinkExportController {
+	return sinkExportController
+}
+
+func newExportStoreFromConfig() ExportStore {
+	env := config.GetEnv()
+	if env.ExportsS3Endpoint == "" {
+		return nil
+	}
+
+	return NewS3ExportStore(
+		&http.Client{Timeout: 5 * time.Minute},
+		env.ExportsS3Endpoint,
+		env.ExportsS3Region,
+		env.ExportsS3AccessKeyID,
+		env.ExportsS3SecretAccessKey,
+	)
+}
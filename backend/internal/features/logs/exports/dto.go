@@ -0,0 +1,35 @@
+package logs_exports
+
+import (
+	logs_core "logbull/internal/features/logs/core"
+)
+
+type SaveScheduledExportRequestDTO struct {
+	Schedule  ExportSchedule `json:"schedule"  binding:"required"`
+	Format    ExportFormat   `json:"format"    binding:"required"`
+	Bucket    string         `json:"bucket"    binding:"required,min=1,max=255"`
+	KeyPrefix string         `json:"keyPrefix" binding:"max=512"`
+}
+
+// ExportToSinkRequestDTO posts the logs matching the query within the time
+// range to the sink, batchSize logs per request (1000 by default and at most)
+type ExportToSinkRequestDTO struct {
+	Query     *logs_core.QueryNode   `json:"query,omitempty"`
+	TimeRange logs_core.TimeRangeDTO `json:"timeRange"`
+	SinkURL   string                 `json:"sinkUrl"             binding:"required,max=2048"`
+	Format    ExportFormat           `json:"format,omitempty"`
+	BatchSize int                    `json:"batchSize,omitempty"`
+	// After resumes a stopped export from the cursor of its response
+	After string `json:"after,omitempty"`
+}
+
+// ExportToSinkResponseDTO counts the delivered batches. An export stops when a
+// batch keeps failing (Error is set) or after the maximum logs or duration of
+// an export, Cursor is passed as after to continue with the next batch
+type ExportToSinkResponseDTO struct {
+	BatchesSent int    `json:"batchesSent"`
+	LogsSent    int    `json:"logsSent"`
+	IsCompleted bool   `json:"isCompleted"`
+	Cursor      string `json:"cursor,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
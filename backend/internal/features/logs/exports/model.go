@@ -0,0 +1,92 @@
+package logs_exports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportSchedule string
+
+const (
+	ExportScheduleDaily  ExportSchedule = "DAILY"
+	ExportScheduleWeekly ExportSchedule = "WEEKLY"
+)
+
+func (s ExportSchedule) IsValid() bool {
+	return s == ExportScheduleDaily || s == ExportScheduleWeekly
+}
+
+// Period is the length of the time window covered by one export
+func (s ExportSchedule) Period() time.Duration {
+	if s == ExportScheduleWeekly {
+		return 7 * 24 * time.Hour
+	}
+
+	return 24 * time.Hour
+}
+
+// FirstRunAfter returns the first window end after the given time: the next
+// midnight (UTC) for daily exports and the next Monday midnight for weekly ones
+func (s ExportSchedule) FirstRunAfter(now time.Time) time.Time {
+	now = now.UTC()
+	nextRun := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+	if s == ExportScheduleWeekly {
+		for nextRun.Weekday() != time.Monday {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+	}
+
+	return nextRun
+}
+
+type ExportFormat string
+
+const (
+	ExportFormatNDJSON ExportFormat = "NDJSON"
+	ExportFormatCSV    ExportFormat = "CSV"
+)
+
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatNDJSON || f == ExportFormatCSV
+}
+
+func (f ExportFormat) FileExtension() string {
+	if f == ExportFormatCSV {
+		return ".csv"
+	}
+
+	return ".ndjson"
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatCSV {
+		return "text/csv"
+	}
+
+	return "application/x-ndjson"
+}
+
+// ScheduledExport writes the results of a saved query for every past window
+// (a day or a week) to object storage. NextRunAt is the end of the next window
+// to export, it moves forward by one period after each successful run
+type ScheduledExport struct {
+	ID           uuid.UUID      `json:"id"           gorm:"column:id"`
+	ProjectID    uuid.UUID      `json:"projectId"    gorm:"column:project_id"`
+	SavedQueryID uuid.UUID      `json:"savedQueryId" gorm:"column:saved_query_id"`
+	Schedule     ExportSchedule `json:"schedule"     gorm:"column:schedule"`
+	Format       ExportFormat   `json:"format"       gorm:"column:format"`
+	Bucket       string         `json:"bucket"       gorm:"column:bucket"`
+	KeyPrefix    string         `json:"keyPrefix"    gorm:"column:key_prefix"`
+	NextRunAt    time.Time      `json:"nextRunAt"    gorm:"column:next_run_at"`
+	LastRunAt    *time.Time     `json:"lastRunAt"    gorm:"column:last_run_at"`
+	LastError    string         `json:"lastError"    gorm:"column:last_error"`
+	CreatedBy    *uuid.UUID     `json:"createdBy"    gorm:"column:created_by"`
+	CreatedAt    time.Time      `json:"createdAt"    gorm:"column:created_at"`
+	UpdatedAt    time.Time      `json:"updatedAt"    gorm:"column:updated_at"`
+}
+
+func (ScheduledExport) TableName() string {
+	return "scheduled_exports"
+}
@@ -0,0 +1,57 @@
+package logs_exports
+
+import (
+	"time"
+
+	"logbull/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type ScheduledExportRepository struct{}
+
+func (r *ScheduledExportRepository) SaveScheduledExport(scheduledExport *ScheduledExport) error {
+	if scheduledExport.ID == uuid.Nil {
+		scheduledExport.ID = uuid.New()
+	}
+
+	now := time.Now().UTC()
+	if scheduledExport.CreatedAt.IsZero() {
+		scheduledExport.CreatedAt = now
+	}
+	scheduledExport.UpdatedAt = now
+
+	return storage.GetDb().Save(scheduledExport).Error
+}
+
+func (r *ScheduledExportRepository) GetScheduledExport(
+	projectID, savedQueryID uuid.UUID,
+) (*ScheduledExport, error) {
+	var scheduledExport ScheduledExport
+
+	err := storage.GetDb().
+		Where("project_id = ? AND saved_query_id = ?", projectID, savedQueryID).
+		First(&scheduledExport).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &scheduledExport, nil
+}
+
+func (r *ScheduledExportRepository) GetDueScheduledExports(now time.Time) ([]*ScheduledExport, error) {
+	var scheduledExports []*ScheduledExport
+
+	err := storage.GetDb().
+		Where("next_run_at <= ?", now).
+		Order("next_run_at ASC").
+		Find(&scheduledExports).Error
+
+	return scheduledExports, err
+}
+
+func (r *ScheduledExportRepository) DeleteScheduledExport(projectID, savedQueryID uuid.UUID) error {
+	return storage.GetDb().
+		Where("project_id = ? AND saved_query_id = ?", projectID, savedQueryID).
+		Delete(&ScheduledExport{}).Error
+}
@@ -0,0 +1,144 @@
+package logs_exports
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+	projects_services "logbull/internal/features/projects/services"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScheduledExportService struct {
+	scheduledExportRepository *ScheduledExportRepository
+	savedQueryRepository      *logs_saved_queries.SavedQueryRepository
+	projectService            *projects_services.ProjectService
+	auditLogService           *audit_logs.AuditLogService
+}
+
+// SaveScheduledExport creates or replaces the export schedule of a saved query.
+// The next run is kept when the schedule stays the same, so editing the
+// destination does not skip or repeat a window
+func (s *ScheduledExportService) SaveScheduledExport(
+	projectID uuid.UUID,
+	savedQueryID uuid.UUID,
+	request *SaveScheduledExportRequestDTO,
+	user *users_models.User,
+) (*ScheduledExport, error) {
+	if err := s.checkCanManage(projectID, user); err != nil {
+		return nil, err
+	}
+
+	savedQuery, err := s.savedQueryRepository.GetSavedQuery(projectID, savedQueryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("saved query not found")
+		}
+
+		return nil, fmt.Errorf("failed to get saved query: %w", err)
+	}
+
+	if !request.Schedule.IsValid() {
+		return nil, fmt.Errorf("invalid export schedule: %s", request.Schedule)
+	}
+	if !request.Format.IsValid() {
+		return nil, fmt.Errorf("invalid export format: %s", request.Format)
+	}
+
+	scheduledExport, err := s.scheduledExportRepository.GetScheduledExport(projectID, savedQueryID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get scheduled export: %w", err)
+		}
+
+		scheduledExport = &ScheduledExport{
+			ProjectID:    projectID,
+			SavedQueryID: savedQueryID,
+			CreatedBy:    &user.ID,
+		}
+	}
+
+	if scheduledExport.NextRunAt.IsZero() || scheduledExport.Schedule != request.Schedule {
+		scheduledExport.NextRunAt = request.Schedule.FirstRunAfter(time.Now().UTC())
+	}
+
+	scheduledExport.Schedule = request.Schedule
+	scheduledExport.Format = request.Format
+	scheduledExport.Bucket = strings.TrimSpace(request.Bucket)
+	scheduledExport.KeyPrefix = strings.Trim(strings.TrimSpace(request.KeyPrefix), "/")
+
+	if err := s.scheduledExportRepository.SaveScheduledExport(scheduledExport); err != nil {
+		return nil, fmt.Errorf("failed to save scheduled export: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Scheduled export saved for query: %s", savedQuery.Name),
+		&user.ID,
+		&projectID,
+	)
+
+	return scheduledExport, nil
+}
+
+func (s *ScheduledExportService) GetScheduledExport(
+	projectID uuid.UUID,
+	savedQueryID uuid.UUID,
+	user *users_models.User,
+) (*ScheduledExport, error) {
+	if err := s.checkCanManage(projectID, user); err != nil {
+		return nil, err
+	}
+
+	scheduledExport, err := s.scheduledExportRepository.GetScheduledExport(projectID, savedQueryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("scheduled export not found")
+		}
+
+		return nil, fmt.Errorf("failed to get scheduled export: %w", err)
+	}
+
+	return scheduledExport, nil
+}
+
+func (s *ScheduledExportService) DeleteScheduledExport(
+	projectID uuid.UUID,
+	savedQueryID uuid.UUID,
+	user *users_models.User,
+) error {
+	if _, err := s.GetScheduledExport(projectID, savedQueryID, user); err != nil {
+		return err
+	}
+
+	if err := s.scheduledExportRepository.DeleteScheduledExport(projectID, savedQueryID); err != nil {
+		return fmt.Errorf("failed to delete scheduled export: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		"Scheduled export deleted",
+		&user.ID,
+		&projectID,
+	)
+
+	return nil
+}
+
+// Exports hold a destination of the project data, so only owners and admins
+// can see and change them
+func (s *ScheduledExportService) checkCanManage(projectID uuid.UUID, user *users_models.User) error {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to manage scheduled exports")
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package logs_exports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	projects_services "logbull/internal/features/projects/services"
+	users_models "logbull/internal/features/users/models"
+	"logbull/internal/util/webhook"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// A failed batch is sent this many times in total before the export stops
+	maxSinkDeliveryAttempts = 4
+	// The wait before the first retry, doubled before every next one
+	defaultSinkRetryBackoff = time.Second
+	sinkRequestTimeout      = 30 * time.Second
+	// An export holds its HTTP request and a query slot, so it stops after
+	// this long and is resumed from the cursor of its response
+	defaultMaxSinkExportDuration = 2 * time.Minute
+
+	SinkBatchIndexHeader = "X-LogBull-Batch-Index"
+)
+
+// SinkExportService posts the logs matching a query in batches to an HTTP
+// sink, e.g. for one-off backfills into other systems. Exports run within the
+// request and are bounded in logs and duration, a stopped export is resumed
+// from the cursor of its response
+type SinkExportService struct {
+	logCoreRepository      *logs_core.LogCoreRepository
+	queryValidator         *logs_querying.QueryValidator
+	logQueryService        *logs_querying.LogQueryService
+	concurrentQueryLimiter *logs_querying.ConcurrentQueryLimiter
+	projectService         *projects_services.ProjectService
+	auditLogService        *audit_logs.AuditLogService
+	destinationGuard       *webhook.DestinationGuard
+	httpClient             *http.Client
+	logger                 *slog.Logger
+
+	retryBackoff time.Duration
+	maxDuration  time.Duration
+}
+
+func (s *SinkExportService) SetRetryBackoff(retryBackoff time.Duration) {
+	s.retryBackoff = retryBackoff
+}
+
+func (s *SinkExportService) GetRetryBackoff() time.Duration {
+	return s.retryBackoff
+}
+
+func (s *SinkExportService) SetMaxDuration(maxDuration time.Duration) {
+	s.maxDuration = maxDuration
+}
+
+func (s *SinkExportService) GetMaxDuration() time.Duration {
+	return s.maxDuration
+}
+
+func (s *SinkExportService) GetDestinationGuard() *webhook.DestinationGuard {
+	return s.destinationGuard
+}
+
+// ExportToSink pages through the time range oldest first and posts every page
+// as one batch. Delivery failures are reported in the response with the
+// cursor of the last delivered log rather than as an error
+func (s *SinkExportService) ExportToSink(
+	projectID uuid.UUID,
+	request *ExportToSinkRequestDTO,
+	user *users_models.User,
+) (*ExportToSinkResponseDTO, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to export logs to a sink")
+	}
+
+	if err := validateExportToSinkRequest(request); err != nil {
+		return nil, err
+	}
+
+	exportCtx, cancel := context.WithTimeout(context.Background(), s.maxDuration)
+	defer cancel()
+
+	sinkURL, _ := url.Parse(request.SinkURL)
+	if err := s.destinationGuard.CheckURL(exportCtx, sinkURL); err != nil {
+		return nil, fmt.Errorf("invalid sink url: %w", err)
+	}
+
+	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	query, err := s.logQueryService.ResolveFieldAliasesForProject(projectID, request.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err = s.logQueryService.EncryptQueryForProject(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	format := request.Format
+	if format == "" {
+		format = ExportFormatNDJSON
+	}
+
+	batchSize := request.BatchSize
+	if batchSize == 0 {
+		batchSize = exportPageSize
+	}
+
+	queryRequest := &logs_core.LogQueryRequestDTO{
+		Query:     query,
+		TimeRange: &request.TimeRange,
+		Limit:     batchSize,
+		SortOrder: "asc",
+		After:     request.After,
+	}
+
+	// The export queries like any other query of the user
+	queryID := uuid.New().String()
+	if err := s.concurrentQueryLimiter.AcquireQuerySlot(user.ID, queryID); err != nil {
+		return nil, err
+	}
+
+	defer s.concurrentQueryLimiter.ReleaseQuerySlot(user.ID, queryID)
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Logs exported to sink: %s", sinkHost(request.SinkURL)),
+		&user.ID,
+		&projectID,
+	)
+
+	response := &ExportToSinkResponseDTO{Cursor: request.After}
+	for {
+		// Both limits stop the export between batches, it is resumed from the cursor
+		if response.LogsSent >= maxExportLogs || exportCtx.Err() != nil {
+			return response, nil
+		}
+
+		page, err := s.logCoreRepository.ExecuteQueryForProjectWithContext(exportCtx, projectID, queryRequest)
+		if err != nil {
+			if exportCtx.Err() != nil {
+				return response, nil
+			}
+
+			return nil, fmt.Errorf("failed to query logs for export: %w", err)
+		}
+
+		if len(page.Logs) == 0 {
+			response.IsCompleted = true
+			return response, nil
+		}
+
+		if err := s.logQueryService.DecryptLogsForProject(projectID, page.Logs); err != nil {
+			return nil, fmt.Errorf("failed to decrypt logs for export: %w", err)
+		}
+
+		var content []byte
+		switch format {
+		case ExportFormatCSV:
+			content, err = encodeLogsAsCSV(page.Logs)
+		default:
+			content, err = encodeLogsAsNDJSON(page.Logs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode export: %w", err)
+		}
+
+		if err := s.deliverBatch(exportCtx, request.SinkURL, format, response.BatchesSent, content); err != nil {
+			// The time limit ran out during the delivery, the batch is sent again on resume
+			if exportCtx.Err() != nil {
+				return response, nil
+			}
+
+			s.logger.Warn("Sink export stopped",
+				slog.String("projectId", projectID.String()),
+				slog.Int("logsSent", response.LogsSent),
+				slog.String("error", err.Error()))
+
+			response.Error = err.Error()
+			return response, nil
+		}
+
+		response.BatchesSent++
+		response.LogsSent += len(page.Logs)
+		response.Cursor = page.Cursor
+
+		if len(page.Logs) < batchSize || page.Cursor == "" {
+			response.IsCompleted = true
+			return response, nil
+		}
+
+		queryRequest.After = page.Cursor
+	}
+}
+
+// deliverBatch posts the batch and retries network errors, 429 and 5xx
+// responses with exponential backoff. Other responses are final
+func (s *SinkExportService) deliverBatch(
+	ctx context.Context,
+	sinkURL string,
+	format ExportFormat,
+	batchIndex int,
+	content []byte,
+) error {
+	backoff := s.retryBackoff
+
+	var err error
+	for attempt := 1; attempt <= maxSinkDeliveryAttempts; attempt++ {
+		var isRetryable bool
+		if isRetryable, err = s.postBatch(ctx, sinkURL, format, batchIndex, content); err == nil || !isRetryable {
+			return err
+		}
+
+		if attempt < maxSinkDeliveryAttempts {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+		}
+	}
+
+	return fmt.Errorf("sink failed %d times: %w", maxSinkDeliveryAttempts, err)
+}
+
+func (s *SinkExportService) postBatch(
+	ctx context.Context,
+	sinkURL string,
+	format ExportFormat,
+	batchIndex int,
+	content []byte,
+) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, sinkURL, bytes.NewReader(content))
+	if err != nil {
+		return false, fmt.Errorf("failed to create sink request: %w", err)
+	}
+	request.Header.Set("Content-Type", format.ContentType())
+	request.Header.Set(SinkBatchIndexHeader, strconv.Itoa(batchIndex))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return true, fmt.Errorf("failed to call sink: %w", err)
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			s.logger.Error("failed to close sink response body", "error", closeErr)
+		}
+	}()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		isRetryable := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+
+		return isRetryable, fmt.Errorf("sink returned status %d: %s", response.StatusCode, string(responseBody))
+	}
+
+	return false, nil
+}
+
+func validateExportToSinkRequest(request *ExportToSinkRequestDTO) error {
+	sinkURL, err := url.Parse(request.SinkURL)
+	if err != nil || (sinkURL.Scheme != "http" && sinkURL.Scheme != "https") || sinkURL.Host == "" {
+		return errors.New("invalid sink url: must be an absolute http or https url")
+	}
+
+	if request.Format != "" && !request.Format.IsValid() {
+		return fmt.Errorf("invalid export format: %s", request.Format)
+	}
+
+	if request.BatchSize < 0 || request.BatchSize > exportPageSize {
+		return fmt.Errorf("invalid batch size: must be between 1 and %d", exportPageSize)
+	}
+
+	timeRange := request.TimeRange
+	if timeRange.From == nil || timeRange.To == nil || !timeRange.From.Before(*timeRange.To) {
+		return errors.New("invalid time range: from and to are required and from must be before to")
+	}
+
+	if request.After != "" {
+		if _, err := logs_core.DecodeLogCursor(request.After); err != nil {
+			return errors.New("invalid cursor: after must be the cursor of a previous export")
+		}
+	}
+
+	return nil
+}
+
+// sinkHost keeps credentials and paths of the sink out of audit logs
+func sinkHost(sinkURL string) string {
+	parsedURL, err := url.Parse(sinkURL)
+	if err != nil {
+		return ""
+	}
+
+	return parsedURL.Host
+}
@@ -0,0 +1,30 @@
+package logs_exports
+
+import (
+	"net/http"
+
+	s3_utils "logbull/internal/util/s3"
+)
+
+// ExportStore writes export files to object storage
+type ExportStore interface {
+	PutObject(bucket, key, contentType string, data []byte) error
+}
+
+// S3ExportStore writes objects to S3 compatible storage (AWS S3, MinIO, R2...)
+type S3ExportStore struct {
+	client *s3_utils.Client
+}
+
+func NewS3ExportStore(
+	client *http.Client,
+	endpoint, region, accessKeyID, secretAccessKey string,
+) *S3ExportStore {
+	return &S3ExportStore{
+		client: s3_utils.NewClient(client, endpoint, region, accessKeyID, secretAccessKey),
+	}
+}
+
+func (s *S3ExportStore) PutObject(bucket, key, contentType string, data []byte) error {
+	return s.client.PutObject(bucket, key, contentType, data)
+}
@@ -0,0 +1,383 @@
+package logs_exports_tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_core_tests "logbull/internal/features/logs/core/tests"
+	logs_exports "logbull/internal/features/logs/exports"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+	projects_controllers "logbull/internal/features/projects/controllers"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_dto "logbull/internal/features/users/dto"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScheduledExport_WhenScheduleTriggers_WindowResultsWrittenAsNDJSON(t *testing.T) {
+	testData := setupExportTest(t, "NDJSON Export Test")
+	store := useStubExportStore(t)
+
+	scheduledExport := saveScheduledExport(t, testData, &logs_exports.SaveScheduledExportRequestDTO{
+		Schedule:  logs_exports.ExportScheduleDaily,
+		Format:    logs_exports.ExportFormatNDJSON,
+		Bucket:    "compliance",
+		KeyPrefix: "/exports/",
+	})
+	windowEnd := moveNextRunToTodayMidnight(t, scheduledExport)
+	storeWindowLogs(t, testData, windowEnd)
+
+	err := logs_exports.GetScheduledExportBackgroundService().ExecuteDueExportsForTestAt(windowEnd.Add(time.Minute))
+	assert.NoError(t, err)
+
+	windowStart := windowEnd.Add(-24 * time.Hour)
+	expectedKey := fmt.Sprintf(
+		"exports/%s/%s.ndjson",
+		testData.SavedQuery.ID.String(),
+		windowStart.Format("2006-01-02"),
+	)
+
+	object, isWritten := store.getObject("compliance", expectedKey)
+	if !assert.True(t, isWritten, "Export was not written to %s", expectedKey) {
+		return
+	}
+	assert.Equal(t, "application/x-ndjson", object.contentType)
+
+	lines := strings.Split(strings.TrimSpace(string(object.data)), "\n")
+	if assert.Len(t, lines, 2) {
+		var firstLog, secondLog logs_core.LogItemDTO
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &firstLog))
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &secondLog))
+
+		assert.Equal(t, "Export morning log", firstLog.Message)
+		assert.Equal(t, "Export evening log", secondLog.Message)
+		assert.Equal(t, "api", firstLog.Fields["service"])
+	}
+
+	storedExport := getScheduledExport(t, testData, http.StatusOK)
+	assert.Equal(t, windowEnd.Add(24*time.Hour), storedExport.NextRunAt.UTC())
+	assert.NotNil(t, storedExport.LastRunAt)
+	assert.Empty(t, storedExport.LastError)
+}
+
+func Test_ScheduledExport_WithCSVFormat_FieldsWrittenAsColumns(t *testing.T) {
+	testData := setupExportTest(t, "CSV Export Test")
+	store := useStubExportStore(t)
+
+	scheduledExport := saveScheduledExport(t, testData, &logs_exports.SaveScheduledExportRequestDTO{
+		Schedule: logs_exports.ExportScheduleDaily,
+		Format:   logs_exports.ExportFormatCSV,
+		Bucket:   "compliance",
+	})
+	windowEnd := moveNextRunToTodayMidnight(t, scheduledExport)
+	storeWindowLogs(t, testData, windowEnd)
+
+	err := logs_exports.GetScheduledExportBackgroundService().ExecuteDueExportsForTestAt(windowEnd.Add(time.Minute))
+	assert.NoError(t, err)
+
+	expectedKey := fmt.Sprintf(
+		"%s/%s.csv",
+		testData.SavedQuery.ID.String(),
+		windowEnd.Add(-24*time.Hour).Format("2006-01-02"),
+	)
+
+	object, isWritten := store.getObject("compliance", expectedKey)
+	if !assert.True(t, isWritten, "Export was not written to %s", expectedKey) {
+		return
+	}
+	assert.Equal(t, "text/csv", object.contentType)
+
+	records, err := csv.NewReader(strings.NewReader(string(object.data))).ReadAll()
+	assert.NoError(t, err)
+	if assert.Len(t, records, 3) {
+		assert.Equal(t, []string{"id", "timestamp", "level", "message", "client_ip", "attempt", "service", "test_id"},
+			records[0])
+		assert.Equal(t, "Export morning log", records[1][3])
+		assert.Equal(t, "1", records[1][5])
+		assert.Equal(t, "api", records[1][6])
+		assert.Equal(t, "Export evening log", records[2][3])
+	}
+}
+
+func Test_ScheduledExport_BeforeScheduleTriggers_NothingWritten(t *testing.T) {
+	testData := setupExportTest(t, "Export Not Due Test")
+	store := useStubExportStore(t)
+
+	scheduledExport := saveScheduledExport(t, testData, &logs_exports.SaveScheduledExportRequestDTO{
+		Schedule: logs_exports.ExportScheduleWeekly,
+		Format:   logs_exports.ExportFormatNDJSON,
+		Bucket:   "compliance",
+	})
+	assert.Equal(t, time.Monday, scheduledExport.NextRunAt.UTC().Weekday())
+
+	err := logs_exports.GetScheduledExportBackgroundService().
+		ExecuteDueExportsForTestAt(scheduledExport.NextRunAt.Add(-time.Minute))
+	assert.NoError(t, err)
+
+	assert.False(t, store.hasObjectsWithPrefix(testData.SavedQuery.ID.String()))
+
+	storedExport := getScheduledExport(t, testData, http.StatusOK)
+	assert.Equal(t, scheduledExport.NextRunAt.UTC(), storedExport.NextRunAt.UTC())
+	assert.Nil(t, storedExport.LastRunAt)
+}
+
+func Test_SaveScheduledExport_AsProjectMember_ReturnsForbidden(t *testing.T) {
+	testData := setupExportTest(t, "Export Member Test")
+
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(
+		testData.Project,
+		member,
+		users_enums.ProjectRoleMember,
+		testData.Owner.Token,
+		testData.Router,
+	)
+
+	test_utils.MakePutRequest(
+		t,
+		testData.Router,
+		exportURL(testData),
+		"Bearer "+member.Token,
+		&logs_exports.SaveScheduledExportRequestDTO{
+			Schedule: logs_exports.ExportScheduleDaily,
+			Format:   logs_exports.ExportFormatNDJSON,
+			Bucket:   "compliance",
+		},
+		http.StatusForbidden,
+	)
+}
+
+func Test_SaveScheduledExport_WithInvalidFormat_ReturnsBadRequest(t *testing.T) {
+	testData := setupExportTest(t, "Export Invalid Format Test")
+
+	test_utils.MakePutRequest(
+		t,
+		testData.Router,
+		exportURL(testData),
+		"Bearer "+testData.Owner.Token,
+		&logs_exports.SaveScheduledExportRequestDTO{
+			Schedule: logs_exports.ExportScheduleDaily,
+			Format:   "XML",
+			Bucket:   "compliance",
+		},
+		http.StatusBadRequest,
+	)
+}
+
+type exportTestData struct {
+	Router     *gin.Engine
+	Owner      *users_dto.SignInResponseDTO
+	Project    *projects_models.Project
+	SavedQuery *logs_saved_queries.SavedQuery
+	UniqueID   string
+}
+
+type storedObject struct {
+	contentType string
+	data        []byte
+}
+
+// stubExportStore keeps written objects in memory instead of object storage
+type stubExportStore struct {
+	mu      sync.Mutex
+	objects map[string]storedObject
+}
+
+func (s *stubExportStore) PutObject(bucket, key, contentType string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[bucket+"/"+key] = storedObject{contentType: contentType, data: data}
+	return nil
+}
+
+func (s *stubExportStore) getObject(bucket, key string) (storedObject, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, exists := s.objects[bucket+"/"+key]
+	return object, exists
+}
+
+func (s *stubExportStore) hasObjectsWithPrefix(keyPrefix string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for objectPath := range s.objects {
+		if strings.Contains(objectPath, keyPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func useStubExportStore(t *testing.T) *stubExportStore {
+	backgroundService := logs_exports.GetScheduledExportBackgroundService()
+	previousStore := backgroundService.GetExportStore()
+
+	store := &stubExportStore{objects: make(map[string]storedObject)}
+	backgroundService.SetExportStore(store)
+	t.Cleanup(func() {
+		backgroundService.SetExportStore(previousStore)
+	})
+
+	return store
+}
+
+func setupExportTest(t *testing.T, testName string) *exportTestData {
+	router := projects_testing.CreateTestRouter(
+		projects_controllers.GetProjectController(),
+		projects_controllers.GetMembershipController(),
+		logs_saved_queries.GetSavedQueryController(),
+		logs_exports.GetScheduledExportController(),
+		logs_exports.GetSinkExportController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateTestProject(fmt.Sprintf("%s %s", testName, uniqueID[:8]), owner, router)
+
+	var savedQuery logs_saved_queries.SavedQuery
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/saved-queries/%s", project.ID.String()),
+		"Bearer "+owner.Token,
+		&logs_saved_queries.SaveQueryRequestDTO{
+			Name: "API logs",
+			Query: &logs_core.QueryNode{
+				Type: logs_core.QueryNodeTypeLogical,
+				Logic: &logs_core.LogicalNode{
+					Operator: logs_core.LogicalOperatorAnd,
+					Children: []logs_core.QueryNode{
+						buildEqualsCondition("test_id", uniqueID),
+						buildEqualsCondition("service", "api"),
+					},
+				},
+			},
+		},
+		http.StatusCreated,
+		&savedQuery,
+	)
+
+	return &exportTestData{
+		Router:     router,
+		Owner:      owner,
+		Project:    project,
+		SavedQuery: &savedQuery,
+		UniqueID:   uniqueID,
+	}
+}
+
+func saveScheduledExport(
+	t *testing.T,
+	testData *exportTestData,
+	request *logs_exports.SaveScheduledExportRequestDTO,
+) *logs_exports.ScheduledExport {
+	resp := test_utils.MakePutRequest(
+		t,
+		testData.Router,
+		exportURL(testData),
+		"Bearer "+testData.Owner.Token,
+		request,
+		http.StatusOK,
+	)
+
+	var scheduledExport logs_exports.ScheduledExport
+	if err := json.Unmarshal(resp.Body, &scheduledExport); err != nil {
+		t.Fatalf("Failed to unmarshal scheduled export: %v", err)
+	}
+
+	return &scheduledExport
+}
+
+func getScheduledExport(t *testing.T, testData *exportTestData, expectedStatus int) *logs_exports.ScheduledExport {
+	var scheduledExport logs_exports.ScheduledExport
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		testData.Router,
+		exportURL(testData),
+		"Bearer "+testData.Owner.Token,
+		expectedStatus,
+		&scheduledExport,
+	)
+
+	return &scheduledExport
+}
+
+// moveNextRunToTodayMidnight makes yesterday the pending window, so the test
+// logs are in the past and the schedule triggers right away
+func moveNextRunToTodayMidnight(t *testing.T, scheduledExport *logs_exports.ScheduledExport) time.Time {
+	now := time.Now().UTC()
+	todayMidnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	scheduledExport.NextRunAt = todayMidnight
+	assert.NoError(t, logs_exports.GetScheduledExportRepository().SaveScheduledExport(scheduledExport))
+
+	return todayMidnight
+}
+
+// storeWindowLogs stores two matching logs inside the window, plus a log of
+// another service and a matching log of the previous day which are not exported
+func storeWindowLogs(t *testing.T, testData *exportTestData, windowEnd time.Time) {
+	windowStart := windowEnd.Add(-24 * time.Hour)
+
+	entries := logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+		testData.Project.ID,
+		windowStart.Add(8*time.Hour),
+		"Export morning log",
+		map[string]any{"test_id": testData.UniqueID, "service": "api", "attempt": 1},
+	)
+	entries = logs_core_tests.MergeLogEntries(entries, logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+		testData.Project.ID,
+		windowStart.Add(20*time.Hour),
+		"Export evening log",
+		map[string]any{"test_id": testData.UniqueID, "service": "api", "attempt": 2},
+	))
+	entries = logs_core_tests.MergeLogEntries(entries, logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+		testData.Project.ID,
+		windowStart.Add(12*time.Hour),
+		"Export worker log",
+		map[string]any{"test_id": testData.UniqueID, "service": "worker", "attempt": 3},
+	))
+	entries = logs_core_tests.MergeLogEntries(entries, logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+		testData.Project.ID,
+		windowStart.Add(-time.Hour),
+		"Export previous day log",
+		map[string]any{"test_id": testData.UniqueID, "service": "api", "attempt": 4},
+	))
+
+	logs_core_tests.StoreTestLogsAndFlush(t, logs_core.GetLogCoreRepository(), entries)
+}
+
+func buildEqualsCondition(field, value string) logs_core.QueryNode {
+	return logs_core.QueryNode{
+		Type: logs_core.QueryNodeTypeCondition,
+		Condition: &logs_core.ConditionNode{
+			Field:    field,
+			Operator: logs_core.ConditionOperatorEquals,
+			Value:    value,
+		},
+	}
+}
+
+func exportURL(testData *exportTestData) string {
+	return fmt.Sprintf(
+		"/api/v1/logs/saved-queries/%s/%s/export",
+		testData.Project.ID.String(),
+		testData.SavedQuery.ID.String(),
+	)
+}
@@ -0,0 +1,403 @@
+package logs_exports_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_core_tests "logbull/internal/features/logs/core/tests"
+	logs_exports "logbull/internal/features/logs/exports"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExportToSink_WithBatchSize_LogsPostedInBatchesOldestFirst(t *testing.T) {
+	testData := setupExportTest(t, "Sink Export Batches Test")
+	sink := startMockSink(t, nil)
+	from, to := storeSinkExportLogs(t, testData, 5)
+
+	response := exportToSink(t, testData, &logs_exports.ExportToSinkRequestDTO{
+		Query:     sinkExportQuery(testData),
+		TimeRange: logs_core.TimeRangeDTO{From: &from, To: &to},
+		SinkURL:   sink.server.URL + "/ingest",
+		BatchSize: 2,
+	}, http.StatusOK)
+
+	assert.Equal(t, 3, response.BatchesSent)
+	assert.Equal(t, 5, response.LogsSent)
+	assert.True(t, response.IsCompleted)
+	assert.Empty(t, response.Error)
+
+	batches := sink.getDeliveredBatches()
+	if !assert.Len(t, batches, 3) {
+		return
+	}
+
+	var messages []string
+	for i, batch := range batches {
+		assert.Equal(t, "application/x-ndjson", batch.contentType)
+		assert.Equal(t, strconv.Itoa(i), batch.batchIndex)
+
+		lines := strings.Split(strings.TrimSpace(batch.body), "\n")
+		assert.Len(t, lines, []int{2, 2, 1}[i])
+		for _, line := range lines {
+			var log logs_core.LogItemDTO
+			assert.NoError(t, json.Unmarshal([]byte(line), &log))
+			messages = append(messages, log.Message)
+		}
+	}
+
+	assert.Equal(t, []string{"Sink log 1", "Sink log 2", "Sink log 3", "Sink log 4", "Sink log 5"}, messages)
+}
+
+func Test_ExportToSink_WhenSinkFailsTemporarily_BatchRetriedUntilDelivered(t *testing.T) {
+	testData := setupExportTest(t, "Sink Export Retry Test")
+	useShortSinkRetryBackoff(t)
+	sink := startMockSink(t, []int{http.StatusServiceUnavailable, http.StatusTooManyRequests})
+	from, to := storeSinkExportLogs(t, testData, 3)
+
+	response := exportToSink(t, testData, &logs_exports.ExportToSinkRequestDTO{
+		Query:     sinkExportQuery(testData),
+		TimeRange: logs_core.TimeRangeDTO{From: &from, To: &to},
+		SinkURL:   sink.server.URL,
+		Format:    logs_exports.ExportFormatCSV,
+	}, http.StatusOK)
+
+	assert.Equal(t, 1, response.BatchesSent)
+	assert.Equal(t, 3, response.LogsSent)
+	assert.True(t, response.IsCompleted)
+	assert.Equal(t, 3, sink.getAttemptsCount())
+
+	batches := sink.getDeliveredBatches()
+	if assert.Len(t, batches, 1) {
+		assert.Equal(t, "text/csv", batches[0].contentType)
+		// The header and one row per log
+		assert.Len(t, strings.Split(strings.TrimSpace(batches[0].body), "\n"), 4)
+	}
+}
+
+func Test_ExportToSink_WhenSinkKeepsFailing_StopsWithCursorToResume(t *testing.T) {
+	testData := setupExportTest(t, "Sink Export Resume Test")
+	useShortSinkRetryBackoff(t)
+	from, to := storeSinkExportLogs(t, testData, 3)
+
+	sink := startMockSink(t, nil)
+	sink.failFromBatch(1, http.StatusInternalServerError)
+
+	request := &logs_exports.ExportToSinkRequestDTO{
+		Query:     sinkExportQuery(testData),
+		TimeRange: logs_core.TimeRangeDTO{From: &from, To: &to},
+		SinkURL:   sink.server.URL,
+		BatchSize: 2,
+	}
+	stoppedExport := exportToSink(t, testData, request, http.StatusBadGateway)
+
+	assert.Equal(t, 1, stoppedExport.BatchesSent)
+	assert.Equal(t, 2, stoppedExport.LogsSent)
+	assert.False(t, stoppedExport.IsCompleted)
+	assert.Contains(t, stoppedExport.Error, "status 500")
+	assert.NotEmpty(t, stoppedExport.Cursor)
+	// The delivered batch and every attempt of the failing one
+	assert.Equal(t, 5, sink.getAttemptsCount())
+
+	sink.failFromBatch(-1, 0)
+	request.After = stoppedExport.Cursor
+	resumedExport := exportToSink(t, testData, request, http.StatusOK)
+
+	assert.Equal(t, 1, resumedExport.LogsSent)
+	assert.True(t, resumedExport.IsCompleted)
+
+	batches := sink.getDeliveredBatches()
+	if assert.Len(t, batches, 2) {
+		assert.Contains(t, batches[1].body, "Sink log 3")
+	}
+}
+
+func Test_ExportToSink_WithInvalidRequestOrRole_ReturnsError(t *testing.T) {
+	testData := setupExportTest(t, "Sink Export Invalid Test")
+	sink := startMockSink(t, nil)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+	validRequest := logs_exports.ExportToSinkRequestDTO{
+		TimeRange: logs_core.TimeRangeDTO{From: &from, To: &to},
+		SinkURL:   sink.server.URL,
+	}
+
+	invalidRequests := []logs_exports.ExportToSinkRequestDTO{validRequest, validRequest, validRequest, validRequest}
+	invalidRequests[0].SinkURL = "ftp://sink.example.com"
+	invalidRequests[1].BatchSize = 5000
+	invalidRequests[2].TimeRange = logs_core.TimeRangeDTO{To: &to}
+	invalidRequests[3].Format = "XML"
+
+	for _, request := range invalidRequests {
+		exportToSink(t, testData, &request, http.StatusBadRequest)
+	}
+
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(
+		testData.Project,
+		member,
+		users_enums.ProjectRoleMember,
+		testData.Owner.Token,
+		testData.Router,
+	)
+	test_utils.MakePostRequest(t, testData.Router, sinkExportURL(testData), "Bearer "+member.Token,
+		&validRequest, http.StatusForbidden)
+
+	assert.Zero(t, sink.getAttemptsCount())
+}
+
+func Test_ExportToSink_WithPrivateOrLoopbackSink_ReturnsBadRequest(t *testing.T) {
+	testData := setupExportTest(t, "Sink Export Private Network Test")
+	sink := startMockSink(t, nil)
+	setSinkPrivateNetworkAllowed(t, false)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+
+	sinkURLs := []string{
+		sink.server.URL,
+		"http://localhost:8080/ingest",
+		"http://10.0.0.1/ingest",
+		"http://192.168.1.10/ingest",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]:8080/ingest",
+	}
+
+	for _, sinkURL := range sinkURLs {
+		request := &logs_exports.ExportToSinkRequestDTO{
+			Query:     sinkExportQuery(testData),
+			TimeRange: logs_core.TimeRangeDTO{From: &from, To: &to},
+			SinkURL:   sinkURL,
+		}
+		exportToSink(t, testData, request, http.StatusBadRequest)
+	}
+
+	assert.Zero(t, sink.getAttemptsCount())
+}
+
+func Test_ExportToSink_WhenMaxDurationReached_StopsWithCursorToResume(t *testing.T) {
+	testData := setupExportTest(t, "Sink Export Duration Test")
+	from, to := storeSinkExportLogs(t, testData, 3)
+
+	sink := startMockSink(t, nil)
+	sink.setResponseDelay(500 * time.Millisecond)
+
+	sinkExportService := logs_exports.GetSinkExportService()
+	previousMaxDuration := sinkExportService.GetMaxDuration()
+	sinkExportService.SetMaxDuration(time.Second)
+	t.Cleanup(func() {
+		sinkExportService.SetMaxDuration(previousMaxDuration)
+	})
+
+	request := &logs_exports.ExportToSinkRequestDTO{
+		Query:     sinkExportQuery(testData),
+		TimeRange: logs_core.TimeRangeDTO{From: &from, To: &to},
+		SinkURL:   sink.server.URL,
+		BatchSize: 1,
+	}
+	stoppedExport := exportToSink(t, testData, request, http.StatusOK)
+
+	assert.Less(t, stoppedExport.LogsSent, 3)
+	assert.False(t, stoppedExport.IsCompleted)
+	assert.Empty(t, stoppedExport.Error)
+	assert.NotEmpty(t, stoppedExport.Cursor)
+
+	sink.setResponseDelay(0)
+	sinkExportService.SetMaxDuration(previousMaxDuration)
+	request.After = stoppedExport.Cursor
+	resumedExport := exportToSink(t, testData, request, http.StatusOK)
+
+	assert.Equal(t, 3-stoppedExport.LogsSent, resumedExport.LogsSent)
+	assert.True(t, resumedExport.IsCompleted)
+
+	batches := sink.getDeliveredBatches()
+	if assert.Len(t, batches, 3) {
+		assert.Contains(t, batches[2].body, "Sink log 3")
+	}
+}
+
+type deliveredBatch struct {
+	contentType string
+	batchIndex  string
+	body        string
+}
+
+// mockSink accepts batches after answering the first requests with the
+// configured failure statuses, or fails every batch from failingBatchIndex on.
+// It listens on loopback, so private sink networks are allowed while it runs
+type mockSink struct {
+	server *httptest.Server
+
+	mu                sync.Mutex
+	failureStatuses   []int
+	failingBatchIndex int
+	failingStatus     int
+	responseDelay     time.Duration
+	attemptsCount     int
+	batches           []deliveredBatch
+}
+
+func startMockSink(t *testing.T, failureStatuses []int) *mockSink {
+	setSinkPrivateNetworkAllowed(t, true)
+
+	sink := &mockSink{failureStatuses: failureStatuses, failingBatchIndex: -1}
+	sink.server = httptest.NewServer(http.HandlerFunc(sink.handle))
+	t.Cleanup(sink.server.Close)
+
+	return sink
+}
+
+func (s *mockSink) handle(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+
+	s.mu.Lock()
+	responseDelay := s.responseDelay
+	s.mu.Unlock()
+
+	// A batch cancelled by the exporter while waiting is not delivered
+	select {
+	case <-time.After(responseDelay):
+	case <-request.Context().Done():
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attemptsCount++
+
+	if len(s.failureStatuses) > 0 {
+		writer.WriteHeader(s.failureStatuses[0])
+		s.failureStatuses = s.failureStatuses[1:]
+		return
+	}
+
+	batchIndex := request.Header.Get(logs_exports.SinkBatchIndexHeader)
+	if index, err := strconv.Atoi(batchIndex); err == nil && s.failingBatchIndex >= 0 && index >= s.failingBatchIndex {
+		writer.WriteHeader(s.failingStatus)
+		return
+	}
+
+	s.batches = append(s.batches, deliveredBatch{
+		contentType: request.Header.Get("Content-Type"),
+		batchIndex:  batchIndex,
+		body:        string(body),
+	})
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (s *mockSink) failFromBatch(batchIndex, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failingBatchIndex = batchIndex
+	s.failingStatus = status
+}
+
+func (s *mockSink) setResponseDelay(responseDelay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responseDelay = responseDelay
+}
+
+func (s *mockSink) getDeliveredBatches() []deliveredBatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]deliveredBatch(nil), s.batches...)
+}
+
+func (s *mockSink) getAttemptsCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attemptsCount
+}
+
+func useShortSinkRetryBackoff(t *testing.T) {
+	sinkExportService := logs_exports.GetSinkExportService()
+	previousBackoff := sinkExportService.GetRetryBackoff()
+
+	sinkExportService.SetRetryBackoff(time.Millisecond)
+	t.Cleanup(func() {
+		sinkExportService.SetRetryBackoff(previousBackoff)
+	})
+}
+
+func setSinkPrivateNetworkAllowed(t *testing.T, isAllowed bool) {
+	destinationGuard := logs_exports.GetSinkExportService().GetDestinationGuard()
+	wasAllowed := destinationGuard.IsPrivateNetworkAllowed()
+
+	destinationGuard.SetPrivateNetworkAllowed(isAllowed)
+	t.Cleanup(func() {
+		destinationGuard.SetPrivateNetworkAllowed(wasAllowed)
+	})
+}
+
+// storeSinkExportLogs stores logsCount logs a minute apart, named "Sink log <n>",
+// and returns a time range covering them
+func storeSinkExportLogs(t *testing.T, testData *exportTestData, logsCount int) (time.Time, time.Time) {
+	from := time.Now().UTC().Add(-time.Hour)
+
+	entries := map[uuid.UUID][]*logs_core.LogItem{}
+	for i := 1; i <= logsCount; i++ {
+		entries = logs_core_tests.MergeLogEntries(entries, logs_core_tests.CreateTestLogEntriesWithUniqueFields(
+			testData.Project.ID,
+			from.Add(time.Duration(i)*time.Minute),
+			fmt.Sprintf("Sink log %d", i),
+			map[string]any{"test_id": testData.UniqueID},
+		))
+	}
+
+	logs_core_tests.StoreTestLogsAndFlush(t, logs_core.GetLogCoreRepository(), entries)
+
+	return from, from.Add(time.Duration(logsCount+1) * time.Minute)
+}
+
+func sinkExportQuery(testData *exportTestData) *logs_core.QueryNode {
+	query := buildEqualsCondition("test_id", testData.UniqueID)
+	return &query
+}
+
+func exportToSink(
+	t *testing.T,
+	testData *exportTestData,
+	request *logs_exports.ExportToSinkRequestDTO,
+	expectedStatus int,
+) *logs_exports.ExportToSinkResponseDTO {
+	resp := test_utils.MakePostRequest(
+		t,
+		testData.Router,
+		sinkExportURL(testData),
+		"Bearer "+testData.Owner.Token,
+		request,
+		expectedStatus,
+	)
+
+	var response logs_exports.ExportToSinkResponseDTO
+	if expectedStatus == http.StatusOK || expectedStatus == http.StatusBadGateway {
+		assert.NoError(t, json.Unmarshal(resp.Body, &response))
+	}
+
+	return &response
+}
+
+func sinkExportURL(testData *exportTestData) string {
+	return fmt.Sprintf("/api/v1/logs/exports/%s/sink", testData.Project.ID.String())
+}
@@ -12,8 +12,9 @@ import (
 )
 
 type ConcurrentQueryLimiter struct {
-	client valkey.Client
-	logger *slog.Logger
+	client          valkey.Client
+	logger          *slog.Logger
+	instanceLimiter *InstanceQueryLimiter
 }
 
 const (
@@ -22,7 +23,30 @@ const (
 	queryTimeout         = 30 * time.Minute // Auto-cleanup stale queries
 )
 
+// AcquireQuerySlot takes a slot of the user and one of the instance, the
+// instance slot is taken first so a queued query doesn't hold a user slot
 func (l *ConcurrentQueryLimiter) AcquireQuerySlot(userID uuid.UUID, queryID string) error {
+	if err := l.instanceLimiter.AcquireSlot(); err != nil {
+		l.logger.Warn("Query rejected, instance query capacity exceeded",
+			slog.String("userId", userID.String()),
+			slog.String("queryId", queryID),
+			slog.Int("maxQueries", l.instanceLimiter.GetMaxQueries()))
+		return err
+	}
+
+	if err := l.acquireUserQuerySlot(userID); err != nil {
+		l.instanceLimiter.ReleaseSlot()
+		return err
+	}
+
+	return nil
+}
+
+func (l *ConcurrentQueryLimiter) GetInstanceLimiter() *InstanceQueryLimiter {
+	return l.instanceLimiter
+}
+
+func (l *ConcurrentQueryLimiter) acquireUserQuerySlot(userID uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -53,6 +77,8 @@ func (l *ConcurrentQueryLimiter) AcquireQuerySlot(userID uuid.UUID, queryID stri
 }
 
 func (l *ConcurrentQueryLimiter) ReleaseQuerySlot(userID uuid.UUID, queryID string) {
+	defer l.instanceLimiter.ReleaseSlot()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -1,8 +1,11 @@
 package logs_querying
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	logs_core "logbull/internal/features/logs/core"
 	users_models "logbull/internal/features/users/models"
@@ -19,13 +22,33 @@ func (c *LogQueryController) RegisterRoutes(router *gin.RouterGroup) {
 	queryRoutes := router.Group("/logs/query")
 
 	queryRoutes.POST("/execute/:projectId", c.ExecuteQuery)
+	queryRoutes.POST("/download/:projectId", c.DownloadQueryPage)
 	queryRoutes.GET("/fields/:projectId", c.GetQueryableFields)
+	queryRoutes.GET("/schema/:projectId", c.DownloadFieldSchema)
 	queryRoutes.GET("/stats/:projectId", c.GetProjectStats)
+	queryRoutes.POST("/compare/:projectId", c.CompareWindows)
+	queryRoutes.POST("/group-by/:projectId", c.GroupBy)
+	queryRoutes.POST("/latest-per-group/:projectId", c.LatestPerGroup)
+	queryRoutes.GET("/tail/:projectId", c.TailLogs)
+
+	router.GET("/logs/:projectId/trace/:traceId", c.GetTraceLogs)
+	router.POST("/logs/:projectId/aggregate", c.AggregateByField)
+	router.GET("/projects/:id/overview", c.GetProjectOverview)
+	router.GET("/projects/:id/logs/histogram", c.GetLogVolumeHistogram)
+}
+
+// RegisterAdminRoutes registers the admin routes, which do not accept
+// personal access tokens. Cancelling writes no data, so runaway queries can be
+// cancelled in read-only mode as well
+func (c *LogQueryController) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.GET("/logs/queries/running", c.GetRunningQueries)
+	router.POST("/logs/queries/running/:queryId/cancel", c.CancelRunningQuery)
 }
 
 // ExecuteQuery
 // @Summary Execute log query
-// @Description Execute a structured query against project logs. timeRange.to is required for pagination consistency.
+// @Description Execute a structured query against project logs. timeRange.to is required for pagination consistency
+// @Description unless "after" is set to a cursor from a previous response, which returns newer logs in ascending order.
 // @Tags logs-query
 // @Accept json
 // @Produce json
@@ -36,8 +59,10 @@ func (c *LogQueryController) RegisterRoutes(router *gin.RouterGroup) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 408 {object} map[string]string
 // @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /logs/query/execute/{projectId} [post]
 func (c *LogQueryController) ExecuteQuery(ctx *gin.Context) {
 	user, isOk := ctx.MustGet("user").(*users_models.User)
@@ -68,6 +93,239 @@ func (c *LogQueryController) ExecuteQuery(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// DownloadQueryPage
+// @Summary Download query result page
+// @Description Execute a query like /logs/query/execute and return the page as a JSON file attachment, so the
+// @Description current results can be saved without a scheduled export
+// @Tags logs-query
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param request body logs_core.LogQueryRequestDTO true "Query request"
+// @Success 200 {object} logs_core.LogQueryResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 408 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /logs/query/download/{projectId} [post]
+func (c *LogQueryController) DownloadQueryPage(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request logs_core.LogQueryRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.logQueryService.ExecuteQuery(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, buildQueryPageFileName(projectID, time.Now())))
+	ctx.IndentedJSON(http.StatusOK, response)
+}
+
+// CompareWindows
+// @Summary Compare two time windows
+// @Description Run the same query over a "before" and an "after" window (e.g. around a deploy) and return the
+// @Description matches of each, the rate per minute and the change. With groupBy the top values of the field are
+// @Description counted in both windows. Windows are half open, from is included and to is not.
+// @Tags logs-query
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param request body CompareWindowsRequestDTO true "Query and windows to compare"
+// @Success 200 {object} CompareWindowsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /logs/query/compare/{projectId} [post]
+func (c *LogQueryController) CompareWindows(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request CompareWindowsRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.logQueryService.CompareWindows(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GroupBy
+// @Summary Count logs per field value
+// @Description Count the logs matching the query per value of the groupBy field, like GROUP BY in SQL. Groups are
+// @Description sorted by count (ties by value) and paginated with limit and offset over groups. timeRange.to is
+// @Description required. Logs without the field are counted in total but not grouped.
+// @Tags logs-query
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param request body GroupByRequestDTO true "Query and field to group by"
+// @Success 200 {object} GroupByResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /logs/query/group-by/{projectId} [post]
+func (c *LogQueryController) GroupBy(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request GroupByRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.logQueryService.GroupBy(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AggregateByField
+// @Summary Count logs per field value
+// @Description Count the logs matching the query per value of a field, e.g. level or a custom field, for dashboard
+// @Description widgets. Values are sorted by count descending, topN (default 10) is capped to 100.
+// @Tags logs-query
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param request body logs_core.AggregateRequestDTO true "Query, field and topN"
+// @Success 200 {array} logs_core.AggregateBucketDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 408 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /logs/{projectId}/aggregate [post]
+func (c *LogQueryController) AggregateByField(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectIDStr := ctx.Param("projectId")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request logs_core.AggregateRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.logQueryService.AggregateByField(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// LatestPerGroup
+// @Summary Get the latest log per group per interval
+// @Description Return the latest log of each groupBy value within every interval of the time range, e.g. the latest
+// @Description status per service per hour for status boards. Interval is a duration like "15m" or "1h", buckets are
+// @Description aligned to it and half open. timeRange.to is required, buckets times groupsLimit is bounded.
+// @Tags logs-query
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param request body LatestPerGroupRequestDTO true "Query, field to group by and interval"
+// @Success 200 {object} LatestPerGroupResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /logs/query/latest-per-group/{projectId} [post]
+func (c *LogQueryController) LatestPerGroup(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request LatestPerGroupRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.logQueryService.LatestPerGroup(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 // GetQueryableFields
 // @Summary Get available queryable fields
 // @Description Get list of fields that can be queried for a project, with optional search query
@@ -77,10 +335,15 @@ func (c *LogQueryController) ExecuteQuery(ctx *gin.Context) {
 // @Security BearerAuth
 // @Param projectId path string true "Project ID (UUID format)"
 // @Param query query string false "Search query to filter field names"
+// @Param prefix query string false "Prefix of field names (case-insensitive)"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Param all query bool false "Return the whole field list without pagination"
 // @Success 200 {object} logs_core.GetQueryableFieldsResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /logs/query/fields/{projectId} [get]
 func (c *LogQueryController) GetQueryableFields(ctx *gin.Context) {
 	user, isOk := ctx.MustGet("user").(*users_models.User)
@@ -104,7 +367,11 @@ func (c *LogQueryController) GetQueryableFields(ctx *gin.Context) {
 
 	response, err := c.logQueryService.GetQueryableFields(projectID, &request, user)
 	if err != nil {
-		if strings.Contains(err.Error(), "insufficient permissions") {
+		if validationErr, ok := err.(*ValidationError); ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "code": validationErr.Code})
+		} else if strings.Contains(err.Error(), "project not found") {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		} else if strings.Contains(err.Error(), "insufficient permissions") {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get queryable fields"})
@@ -115,6 +382,51 @@ func (c *LogQueryController) GetQueryableFields(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// DownloadFieldSchema
+// @Summary Download the field schema of a project
+// @Description Download the custom fields discovered in the project logs as a JSON document. Each field has the
+// @Description type inferred from recent logs ("mixed" with the seen types when they differ), the approximate count
+// @Description of distinct values and the count of logs having it. Counts come from a sample of the project logs.
+// @Tags logs-query
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Success 200 {object} logs_core.FieldSchemaDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/query/schema/{projectId} [get]
+func (c *LogQueryController) DownloadFieldSchema(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	response, err := c.logQueryService.GetFieldSchema(projectID, user)
+	if err != nil {
+		if strings.Contains(err.Error(), "project not found") {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		} else if strings.Contains(err.Error(), "insufficient permissions") {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get field schema"})
+		}
+		return
+	}
+
+	fileName := buildFieldSchemaFileName(projectID, response.GeneratedAt)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	ctx.IndentedJSON(http.StatusOK, response)
+}
+
 // GetProjectStats
 // @Summary Get project log statistics
 // @Description Get statistics about logs for a project including total count, size, and time range
@@ -127,6 +439,7 @@ func (c *LogQueryController) GetQueryableFields(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /logs/query/stats/{projectId} [get]
 func (c *LogQueryController) GetProjectStats(ctx *gin.Context) {
 	user, isOk := ctx.MustGet("user").(*users_models.User)
@@ -144,7 +457,9 @@ func (c *LogQueryController) GetProjectStats(ctx *gin.Context) {
 
 	response, err := c.logQueryService.GetProjectStats(projectID, user)
 	if err != nil {
-		if strings.Contains(err.Error(), "insufficient permissions") {
+		if strings.Contains(err.Error(), "project not found") {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		} else if strings.Contains(err.Error(), "insufficient permissions") {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project stats"})
@@ -155,9 +470,251 @@ func (c *LogQueryController) GetProjectStats(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// GetProjectOverview
+// @Summary Get project overview
+// @Description Get project settings, log statistics, the count of ERROR and FATAL logs over the last 24 hours,
+// @Description member count and last log time in one response
+// @Tags logs-query
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID format)"
+// @Success 200 {object} ProjectOverviewDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/overview [get]
+func (c *LogQueryController) GetProjectOverview(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	response, err := c.logQueryService.GetProjectOverview(projectID, user)
+	if err != nil {
+		if strings.Contains(err.Error(), "project not found") {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		} else if strings.Contains(err.Error(), "insufficient permissions") {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project overview"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetLogVolumeHistogram
+// @Summary Get log volume histogram
+// @Description Count the project logs per interval for volume charts. Buckets are aligned to the interval in UTC
+// @Description and intervals without logs are returned with a zero count.
+// @Tags logs-query
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID (UUID format)"
+// @Param interval query string false "Bucket size: 1m, 5m, 1h or 1d (default 1h)"
+// @Param from query string false "Start of the time window (RFC3339), defaults to 24 hours before 'to'"
+// @Param to query string false "End of the time window (RFC3339, excluded), defaults to now"
+// @Param query query string false "JSON encoded query node the counted logs must match"
+// @Success 200 {object} LogVolumeHistogramResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /projects/{id}/logs/histogram [get]
+func (c *LogQueryController) GetLogVolumeHistogram(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request GetLogVolumeHistogramRequestDTO
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := c.logQueryService.GetLogVolumeHistogram(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetTraceLogs
+// @Summary Get all logs of a trace
+// @Description Get logs where the project's configured trace id field equals the given value, sorted by time.
+// @Description Pass "field" to correlate by another field, e.g. a request id.
+// @Tags logs-query
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param traceId path string true "Trace ID"
+// @Param field query string false "Field to correlate by, defaults to the project's trace id field"
+// @Param from query string false "Start of the time window (RFC3339), defaults to 24 hours before 'to'"
+// @Param to query string false "End of the time window (RFC3339), defaults to now"
+// @Success 200 {object} logs_core.LogQueryResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/{projectId}/trace/{traceId} [get]
+func (c *LogQueryController) GetTraceLogs(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectIDStr := ctx.Param("projectId")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request GetTraceLogsRequestDTO
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := c.logQueryService.GetTraceLogs(projectID, ctx.Param("traceId"), &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// TailLogs
+// @Summary Poll new logs
+// @Description Get the logs after the last seen one in ascending order, for follow mode of CLI tools. Pass the cursor
+// @Description of the previous response as "after" to continue, without a position the newest logs are returned.
+// @Tags logs-query
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param after query string false "Cursor of the previous response"
+// @Param afterId query string false "ID of the last seen log"
+// @Param afterTimestamp query string false "Skip logs at or before this time (RFC3339)"
+// @Param limit query int false "Maximum logs to return (default 100, max 1000)"
+// @Param minLevel query string false "Minimum log level"
+// @Success 200 {object} TailLogsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /logs/query/tail/{projectId} [get]
+func (c *LogQueryController) TailLogs(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectIDStr := ctx.Param("projectId")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request TailLogsRequestDTO
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := c.logQueryService.TailLogs(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetRunningQueries
+// @Summary List running queries (ADMIN only)
+// @Description Lists the queries this instance runs, the longest running first
+// @Tags logs-query
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} RunningQueryDTO
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /logs/queries/running [get]
+func (c *LogQueryController) GetRunningQueries(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	runningQueries, err := c.logQueryService.GetRunningQueries(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runningQueries)
+}
+
+// CancelRunningQuery
+// @Summary Cancel a running query (ADMIN only)
+// @Description Cancels a query of this instance, its caller gets a QUERY_CANCELLED error
+// @Tags logs-query
+// @Produce json
+// @Security BearerAuth
+// @Param queryId path string true "Running query ID"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/queries/running/{queryId}/cancel [post]
+func (c *LogQueryController) CancelRunningQuery(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	if err := c.logQueryService.CancelRunningQuery(ctx.Param("queryId"), user); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Query cancelled successfully"})
+}
+
 func (c *LogQueryController) handleError(ctx *gin.Context, err error) {
 	if validationErr, ok := err.(*ValidationError); ok {
 		statusCode := c.getStatusCodeForQueryValidationError(validationErr.Code)
+		if validationErr.Code == logs_core.ErrorQueryCapacityExceeded {
+			ctx.Header("Retry-After", strconv.Itoa(int(QueryCapacityRetryAfter.Seconds())))
+		}
+
 		ctx.JSON(statusCode, gin.H{
 			"error": validationErr.Message,
 			"code":  validationErr.Code,
@@ -165,6 +722,11 @@ func (c *LogQueryController) handleError(ctx *gin.Context, err error) {
 		return
 	}
 
+	if strings.Contains(err.Error(), "project not found") {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		return
+	}
+
 	if strings.Contains(err.Error(), "insufficient permissions") {
 		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
@@ -187,11 +749,28 @@ func (c *LogQueryController) getStatusCodeForQueryValidationError(errorCode stri
 	switch errorCode {
 	case logs_core.ErrorTooManyConcurrentQueries:
 		return http.StatusTooManyRequests
-	case logs_core.ErrorInvalidQueryStructure, logs_core.ErrorQueryTooComplex, logs_core.ErrorMissingTimeRangeTo:
+	case logs_core.ErrorQueryCapacityExceeded:
+		return http.StatusServiceUnavailable
+	case logs_core.ErrorInvalidQueryStructure, logs_core.ErrorQueryTooComplex, logs_core.ErrorMissingTimeRangeTo,
+		logs_core.ErrorTraceIdFieldNotSet, logs_core.ErrorInvalidCursor:
 		return http.StatusBadRequest
 	case logs_core.ErrorQueryTimeout:
 		return http.StatusRequestTimeout
+	case logs_core.ErrorFieldMasked:
+		return http.StatusForbidden
+	case logs_core.ErrorLogNotFound, logs_core.ErrorRunningQueryNotFound:
+		return http.StatusNotFound
 	default:
 		return http.StatusBadRequest
 	}
 }
+
+// buildQueryPageFileName returns logs-<projectId>-<UTC time>.json
+func buildQueryPageFileName(projectID uuid.UUID, downloadedAt time.Time) string {
+	return fmt.Sprintf("logs-%s-%s.json", projectID.String(), downloadedAt.UTC().Format("20060102T150405Z"))
+}
+
+// buildFieldSchemaFileName returns field-schema-<projectId>-<UTC time>.json
+func buildFieldSchemaFileName(projectID uuid.UUID, generatedAt time.Time) string {
+	return fmt.Sprintf("field-schema-%s-%s.json", projectID.String(), generatedAt.UTC().Format("20060102T150405Z"))
+}
@@ -1,7 +1,11 @@
 package logs_querying
 
 import (
+	"time"
+
 	"logbull/internal/cache"
+	"logbull/internal/config"
+	logs_annotations "logbull/internal/features/logs/annotations"
 	logs_core "logbull/internal/features/logs/core"
 	projects_services "logbull/internal/features/projects/services"
 	"logbull/internal/util/logger"
@@ -10,18 +14,27 @@ import (
 var concurrentQueryLimiter = &ConcurrentQueryLimiter{
 	cache.GetCache(),
 	logger.GetLogger(),
+	NewInstanceQueryLimiter(getMaxInstanceQueriesFromConfig()),
 }
 
+var runningQueryRegistry = NewRunningQueryRegistry()
+
 var queryValidator = &QueryValidator{
 	logger.GetLogger(),
+	getQueryLimitsFromConfig(),
 }
 
 var logQueryService = &LogQueryService{
 	logs_core.GetLogCoreRepository(),
 	projects_services.GetProjectService(),
+	projects_services.GetMembershipService(),
 	concurrentQueryLimiter,
+	runningQueryRegistry,
 	queryValidator,
+	logs_annotations.GetLogAnnotationService(),
 	logger.GetLogger(),
+	getDefaultLookbackFromConfig(),
+	getMaxResultWindowFromConfig(),
 }
 
 var logQueryController = &LogQueryController{
@@ -32,6 +45,61 @@ func GetLogQueryService() *LogQueryService {
 	return logQueryService
 }
 
+func GetQueryValidator() *QueryValidator {
+	return queryValidator
+}
+
 func GetLogQueryController() *LogQueryController {
 	return logQueryController
 }
+
+func GetRunningQueryRegistry() *RunningQueryRegistry {
+	return runningQueryRegistry
+}
+
+func GetConcurrentQueryLimiter() *ConcurrentQueryLimiter {
+	return concurrentQueryLimiter
+}
+
+func GetInstanceQueryLimiter() *InstanceQueryLimiter {
+	return concurrentQueryLimiter.GetInstanceLimiter()
+}
+
+func getDefaultLookbackFromConfig() time.Duration {
+	lookbackHours := config.GetEnv().QueryDefaultLookbackHours
+	if lookbackHours <= 0 {
+		return DefaultQueryLookback
+	}
+
+	return time.Duration(lookbackHours) * time.Hour
+}
+
+func getMaxResultWindowFromConfig() int {
+	maxResultWindow := config.GetEnv().QueryMaxResultWindow
+	if maxResultWindow <= 0 {
+		return DefaultMaxResultWindow
+	}
+
+	return maxResultWindow
+}
+
+func getMaxInstanceQueriesFromConfig() int {
+	maxQueries := config.GetEnv().QueryMaxConcurrent
+	if maxQueries <= 0 {
+		return DefaultMaxInstanceQueries
+	}
+
+	return maxQueries
+}
+
+func getQueryLimitsFromConfig() QueryLimits {
+	env := config.GetEnv()
+
+	return QueryLimits{
+		MaxDepth:       env.QueryMaxDepth,
+		MaxNodes:       env.QueryMaxNodes,
+		MaxChildren:    env.QueryMaxChildren,
+		MaxArrayValues: env.QueryMaxArrayValues,
+		MaxValueLength: env.QueryMaxValueLength,
+	}.normalized()
+}
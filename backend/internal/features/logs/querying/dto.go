@@ -1,5 +1,38 @@
 package logs_querying
 
+import (
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_models "logbull/internal/features/projects/models"
+
+	"github.com/google/uuid"
+)
+
+// GetTraceLogsRequestDTO bounds the logs of a trace in time. Field correlates
+// by another field than the configured trace id field, e.g. a request id
+type GetTraceLogsRequestDTO struct {
+	From  *time.Time `form:"from"  time_format:"2006-01-02T15:04:05Z07:00"`
+	To    *time.Time `form:"to"    time_format:"2006-01-02T15:04:05Z07:00"`
+	Field string     `form:"field"`
+}
+
+// GetLogVolumeHistogramRequestDTO counts logs per interval for volume charts.
+// Query is an optional JSON encoded query node the counted logs must match
+type GetLogVolumeHistogramRequestDTO struct {
+	Interval string     `form:"interval"`
+	From     *time.Time `form:"from"     time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to"       time_format:"2006-01-02T15:04:05Z07:00"`
+	Query    string     `form:"query"`
+}
+
+type LogVolumeHistogramResponseDTO struct {
+	Interval  string                 `json:"interval"`
+	TimeRange logs_core.TimeRangeDTO `json:"timeRange"`
+	// Points are listed oldest first, intervals without logs included
+	Points []logs_core.VolumePoint `json:"points"`
+}
+
 type ValidationError struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -8,3 +41,142 @@ type ValidationError struct {
 func (e *ValidationError) Error() string {
 	return e.Message
 }
+
+// ProjectOverviewDTO combines what the project dashboard shows on load
+type ProjectOverviewDTO struct {
+	Project  *projects_models.Project   `json:"project"`
+	LogStats *logs_core.ProjectLogStats `json:"logStats"`
+	// ERROR and FATAL logs since RecentErrorsSince
+	RecentErrorsCount int64      `json:"recentErrorsCount"`
+	RecentErrorsSince time.Time  `json:"recentErrorsSince"`
+	MembersCount      int        `json:"membersCount"`
+	LastLogTime       *time.Time `json:"lastLogTime,omitempty"`
+}
+
+// CompareWindowsRequestDTO runs one query over two windows, e.g. before and
+// after a deploy. Windows are half open: from is included, to is not
+type CompareWindowsRequestDTO struct {
+	Query  *logs_core.QueryNode   `json:"query,omitempty"`
+	Before logs_core.TimeRangeDTO `json:"before"`
+	After  logs_core.TimeRangeDTO `json:"after"`
+	// GroupBy adds the top values of a field with their count in both windows
+	GroupBy        string `json:"groupBy,omitempty"`
+	TopValuesLimit int    `json:"topValuesLimit,omitempty"`
+}
+
+type WindowSummaryDTO struct {
+	TimeRange logs_core.TimeRangeDTO `json:"timeRange"`
+	Total     int64                  `json:"total"`
+	// RatePerMinute makes windows of different length comparable
+	RatePerMinute float64 `json:"ratePerMinute"`
+}
+
+type ValueComparisonDTO struct {
+	Value  string `json:"value"`
+	Before int64  `json:"before"`
+	After  int64  `json:"after"`
+	Delta  int64  `json:"delta"`
+}
+
+type CompareWindowsResponseDTO struct {
+	Before WindowSummaryDTO `json:"before"`
+	After  WindowSummaryDTO `json:"after"`
+	// Delta is after minus before, DeltaPercent is nil when before has no matches
+	Delta              int64    `json:"delta"`
+	DeltaPercent       *float64 `json:"deltaPercent"`
+	RatePerMinuteDelta float64  `json:"ratePerMinuteDelta"`
+	// TopValues are the most frequent groupBy values across both windows
+	TopValues []ValueComparisonDTO `json:"topValues,omitempty"`
+}
+
+// GroupByRequestDTO counts the logs matching the query per value of a field,
+// like GROUP BY in SQL. Groups are sorted by count and paginated by group
+type GroupByRequestDTO struct {
+	Query     *logs_core.QueryNode    `json:"query,omitempty"`
+	TimeRange *logs_core.TimeRangeDTO `json:"timeRange,omitempty"`
+	GroupBy   string                  `json:"groupBy"`
+	Limit     int                     `json:"limit,omitempty"`
+	Offset    int                     `json:"offset,omitempty"`
+}
+
+type GroupCountDTO struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type GroupByResponseDTO struct {
+	GroupBy string          `json:"groupBy"`
+	Groups  []GroupCountDTO `json:"groups"`
+	// Total counts every matching log, logs without the field are not grouped
+	Total   int64 `json:"total"`
+	Limit   int   `json:"limit"`
+	Offset  int   `json:"offset"`
+	HasMore bool  `json:"hasMore"`
+	// AppliedTimeRange is set when the default lookback bounded the query
+	AppliedTimeRange *logs_core.TimeRangeDTO `json:"appliedTimeRange,omitempty"`
+}
+
+// LatestPerGroupRequestDTO returns the latest log of each groupBy value per
+// interval, e.g. the latest status per service per hour for status boards.
+// Interval is a duration like "15m" or "1h", buckets are aligned to it and the
+// first and last one are cut to the time range
+type LatestPerGroupRequestDTO struct {
+	Query     *logs_core.QueryNode    `json:"query,omitempty"`
+	TimeRange *logs_core.TimeRangeDTO `json:"timeRange,omitempty"`
+	GroupBy   string                  `json:"groupBy"`
+	Interval  string                  `json:"interval"`
+	// GroupsLimit bounds the groups of each bucket, the first values in order
+	GroupsLimit int `json:"groupsLimit,omitempty"`
+}
+
+type GroupLatestLogDTO struct {
+	Value string `json:"value"`
+	// Count is the matches of the group within the bucket
+	Count int64                `json:"count"`
+	Log   logs_core.LogItemDTO `json:"log"`
+}
+
+// LatestPerGroupBucketDTO lists the groups with logs in the bucket by value.
+// Buckets are half open: from is included, to is not
+type LatestPerGroupBucketDTO struct {
+	TimeRange logs_core.TimeRangeDTO `json:"timeRange"`
+	Groups    []GroupLatestLogDTO    `json:"groups"`
+}
+
+type LatestPerGroupResponseDTO struct {
+	GroupBy  string `json:"groupBy"`
+	Interval string `json:"interval"`
+	// Buckets are listed oldest first, buckets without logs included
+	Buckets []LatestPerGroupBucketDTO `json:"buckets"`
+	// AppliedTimeRange is set when the default lookback bounded the query
+	AppliedTimeRange *logs_core.TimeRangeDTO `json:"appliedTimeRange,omitempty"`
+}
+
+// TailLogsRequestDTO polls the logs after the last seen one, given by the cursor
+// of the previous response, the log id or a timestamp. Without any of them the
+// newest logs are returned as the starting point
+type TailLogsRequestDTO struct {
+	After   string `form:"after"`
+	AfterID string `form:"afterId"`
+	// AfterTimestamp skips every log at or before the timestamp
+	AfterTimestamp *time.Time         `form:"afterTimestamp" time_format:"2006-01-02T15:04:05.999999999Z07:00"`
+	Limit          int                `form:"limit"`
+	MinLevel       logs_core.LogLevel `form:"minLevel"`
+}
+
+// TailLogsResponseDTO lists logs oldest first. Cursor is passed as "after" in
+// the next poll, HasMore tells the page was full and can be polled right away
+type TailLogsResponseDTO struct {
+	Logs    []logs_core.LogItemDTO `json:"logs"`
+	Cursor  string                 `json:"cursor"`
+	HasMore bool                   `json:"hasMore"`
+}
+
+// RunningQueryDTO is a query this instance runs, listed to admins so runaway
+// queries can be cancelled
+type RunningQueryDTO struct {
+	ID        string    `json:"id"`
+	ProjectID uuid.UUID `json:"projectId"`
+	UserID    uuid.UUID `json:"userId"`
+	StartedAt time.Time `json:"startedAt"`
+}
@@ -0,0 +1,99 @@
+package logs_querying
+
+import (
+	"fmt"
+	"strings"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/google/uuid"
+)
+
+// ResolveFieldAliasesForProject replaces the project field aliases used by the
+// query with the fields they map to. The query is copied, so saved queries keep
+// their aliases
+func (s *LogQueryService) ResolveFieldAliasesForProject(
+	projectID uuid.UUID,
+	query *logs_core.QueryNode,
+) (*logs_core.QueryNode, error) {
+	fieldAliases, err := s.getFieldAliases(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveFieldAliases(query, fieldAliases), nil
+}
+
+// resolveFieldAlias returns the field an alias maps to, other names are
+// returned unchanged
+func (s *LogQueryService) resolveFieldAlias(projectID uuid.UUID, field string) (string, error) {
+	if field == "" {
+		return field, nil
+	}
+
+	fieldAliases, err := s.getFieldAliases(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return resolveAlias(field, fieldAliases), nil
+}
+
+func (s *LogQueryService) getFieldAliases(projectID uuid.UUID) (map[string]string, error) {
+	project, err := s.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project.FieldAliases, nil
+}
+
+func resolveFieldAliases(node *logs_core.QueryNode, fieldAliases map[string]string) *logs_core.QueryNode {
+	if node == nil || len(fieldAliases) == 0 {
+		return node
+	}
+
+	resolvedNode := *node
+
+	if node.Condition != nil {
+		condition := *node.Condition
+
+		if condition.Operator == logs_core.ConditionOperatorMissingAny ||
+			condition.Operator == logs_core.ConditionOperatorMissingAll {
+			fields := getConditionFields(node.Condition)
+
+			resolvedFields := make([]any, 0, len(fields))
+			for _, field := range fields {
+				resolvedFields = append(resolvedFields, resolveAlias(field, fieldAliases))
+			}
+			condition.Value = resolvedFields
+		} else {
+			condition.Field = resolveAlias(condition.Field, fieldAliases)
+		}
+
+		resolvedNode.Condition = &condition
+	}
+
+	if node.Logic != nil {
+		logic := *node.Logic
+		logic.Children = make([]logs_core.QueryNode, 0, len(node.Logic.Children))
+
+		for i := range node.Logic.Children {
+			logic.Children = append(logic.Children, *resolveFieldAliases(&node.Logic.Children[i], fieldAliases))
+		}
+
+		resolvedNode.Logic = &logic
+	}
+
+	return &resolvedNode
+}
+
+// resolveAlias prefers the alias over a custom field of the same name, so an
+// alias keeps its meaning when such a field appears later
+func resolveAlias(field string, fieldAliases map[string]string) string {
+	if aliasedField, isAlias := fieldAliases[strings.TrimSpace(field)]; isAlias {
+		return aliasedField
+	}
+
+	return field
+}
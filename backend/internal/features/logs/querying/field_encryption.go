@@ -0,0 +1,140 @@
+package logs_querying
+
+import (
+	"errors"
+	"fmt"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/google/uuid"
+)
+
+// EncryptQueryForProject rewrites conditions on the project encrypted fields
+// to compare ciphertexts. Operators which cannot work on ciphertext return a
+// validation error
+func (s *LogQueryService) EncryptQueryForProject(
+	projectID uuid.UUID,
+	query *logs_core.QueryNode,
+) (*logs_core.QueryNode, error) {
+	if query == nil {
+		return nil, nil
+	}
+
+	project, err := s.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if len(project.EncryptedFields) == 0 {
+		return query, nil
+	}
+
+	fieldCipher, err := s.getFieldCipher(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedQuery, err := logs_core.EncryptQueryValues(query, project.EncryptedFields, fieldCipher)
+	if err != nil {
+		if errors.Is(err, logs_core.ErrEncryptedFieldOperator) {
+			return nil, &ValidationError{
+				Code:    logs_core.ErrorFieldEncrypted,
+				Message: err.Error(),
+			}
+		}
+
+		return nil, err
+	}
+
+	return encryptedQuery, nil
+}
+
+// DecryptLogsForProject decrypts encrypted field values in place. The project
+// key is only loaded when the logs contain ciphertext
+func (s *LogQueryService) DecryptLogsForProject(projectID uuid.UUID, logs []logs_core.LogItemDTO) error {
+	if !hasEncryptedValues(logs) {
+		return nil
+	}
+
+	fieldCipher, err := s.getFieldCipher(projectID)
+	if err != nil {
+		return err
+	}
+
+	fieldCipher.DecryptLogs(logs)
+
+	return nil
+}
+
+// decryptTopValues decrypts grouped values of an encrypted field, the values
+// keep the string form of aggregation keys
+func (s *LogQueryService) decryptTopValues(projectID uuid.UUID, topValues []ValueComparisonDTO) error {
+	values := make([]*string, 0, len(topValues))
+	for i := range topValues {
+		values = append(values, &topValues[i].Value)
+	}
+
+	return s.decryptAggregationKeys(projectID, values)
+}
+
+func (s *LogQueryService) decryptGroups(projectID uuid.UUID, groups []GroupCountDTO) error {
+	values := make([]*string, 0, len(groups))
+	for i := range groups {
+		values = append(values, &groups[i].Value)
+	}
+
+	return s.decryptAggregationKeys(projectID, values)
+}
+
+func (s *LogQueryService) decryptLatestGroups(projectID uuid.UUID, groups []GroupLatestLogDTO) error {
+	values := make([]*string, 0, len(groups))
+	for i := range groups {
+		values = append(values, &groups[i].Value)
+	}
+
+	return s.decryptAggregationKeys(projectID, values)
+}
+
+func (s *LogQueryService) decryptAggregationKeys(projectID uuid.UUID, keys []*string) error {
+	var fieldCipher *logs_core.FieldCipher
+
+	for _, key := range keys {
+		if !logs_core.IsEncryptedValue(*key) {
+			continue
+		}
+
+		if fieldCipher == nil {
+			var err error
+			if fieldCipher, err = s.getFieldCipher(projectID); err != nil {
+				return err
+			}
+		}
+
+		if decrypted, err := fieldCipher.DecryptValue(*key); err == nil {
+			*key = fmt.Sprintf("%v", decrypted)
+		}
+	}
+
+	return nil
+}
+
+func (s *LogQueryService) getFieldCipher(projectID uuid.UUID) (*logs_core.FieldCipher, error) {
+	encryptionKey, err := s.projectService.GetFieldEncryptionKey(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field encryption key: %w", err)
+	}
+
+	return logs_core.NewFieldCipher(encryptionKey)
+}
+
+func hasEncryptedValues(logs []logs_core.LogItemDTO) bool {
+	for _, log := range logs {
+		for _, value := range log.Fields {
+			if logs_core.IsEncryptedValue(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
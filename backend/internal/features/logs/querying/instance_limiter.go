@@ -0,0 +1,122 @@
+package logs_querying
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+)
+
+const (
+	DefaultMaxInstanceQueries = 50
+	// how long a query waits for a free slot before it is rejected
+	defaultInstanceQueueTimeout = 2 * time.Second
+	// returned as Retry-After when the instance is at capacity
+	QueryCapacityRetryAfter = 1 * time.Second
+)
+
+// InstanceQueryLimiter bounds the queries this instance runs against OpenSearch
+// at the same time. Unlike ConcurrentQueryLimiter it is in memory and counts
+// queries of all users, so a query storm cannot overload the cluster
+type InstanceQueryLimiter struct {
+	mu            sync.Mutex
+	maxQueries    int
+	activeQueries int
+	queueTimeout  time.Duration
+	// closed and replaced each time a slot is released to wake up waiters
+	slotReleased chan struct{}
+}
+
+func NewInstanceQueryLimiter(maxQueries int) *InstanceQueryLimiter {
+	return &InstanceQueryLimiter{
+		maxQueries:   maxQueries,
+		queueTimeout: defaultInstanceQueueTimeout,
+		slotReleased: make(chan struct{}),
+	}
+}
+
+func (l *InstanceQueryLimiter) SetMaxQueries(maxQueries int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.maxQueries = maxQueries
+	l.notifyWaiters()
+}
+
+func (l *InstanceQueryLimiter) GetMaxQueries() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.maxQueries
+}
+
+func (l *InstanceQueryLimiter) SetQueueTimeout(queueTimeout time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.queueTimeout = queueTimeout
+}
+
+func (l *InstanceQueryLimiter) GetQueueTimeout() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.queueTimeout
+}
+
+// AcquireSlot takes a free slot, waiting up to the queue timeout for one to be
+// released when all of them are in use
+func (l *InstanceQueryLimiter) AcquireSlot() error {
+	l.mu.Lock()
+	timer := time.NewTimer(l.queueTimeout)
+	l.mu.Unlock()
+	defer timer.Stop()
+
+	for {
+		l.mu.Lock()
+		if l.activeQueries < l.maxQueries {
+			l.activeQueries++
+			l.mu.Unlock()
+			return nil
+		}
+
+		slotReleased := l.slotReleased
+		maxQueries := l.maxQueries
+		l.mu.Unlock()
+
+		select {
+		case <-slotReleased:
+		case <-timer.C:
+			return &ValidationError{
+				Code: logs_core.ErrorQueryCapacityExceeded,
+				Message: fmt.Sprintf(
+					"the server is running the maximum number of queries (%d), try again later",
+					maxQueries,
+				),
+			}
+		}
+	}
+}
+
+func (l *InstanceQueryLimiter) ReleaseSlot() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.activeQueries > 0 {
+		l.activeQueries--
+	}
+	l.notifyWaiters()
+}
+
+func (l *InstanceQueryLimiter) GetActiveQueryCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.activeQueries
+}
+
+func (l *InstanceQueryLimiter) notifyWaiters() {
+	close(l.slotReleased)
+	l.slotReleased = make(chan struct{})
+}
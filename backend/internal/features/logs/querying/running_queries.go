@@ -0,0 +1,90 @@
+package logs_querying
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RunningQueryRegistry tracks the queries this instance runs, so admins can
+// list them and cancel runaway ones. Cancelling a query cancels the context
+// of its OpenSearch request, a query still waiting for a slot stops once it
+// gets one
+type RunningQueryRegistry struct {
+	mu      sync.Mutex
+	queries map[string]*runningQuery
+}
+
+type runningQuery struct {
+	info   RunningQueryDTO
+	cancel context.CancelFunc
+}
+
+func NewRunningQueryRegistry() *RunningQueryRegistry {
+	return &RunningQueryRegistry{queries: map[string]*runningQuery{}}
+}
+
+// Register adds the query and returns the context it runs with and a function
+// removing it again, which has to be called once the query finishes
+func (r *RunningQueryRegistry) Register(
+	queryID string,
+	projectID uuid.UUID,
+	userID uuid.UUID,
+) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r.mu.Lock()
+	r.queries[queryID] = &runningQuery{
+		info: RunningQueryDTO{
+			ID:        queryID,
+			ProjectID: projectID,
+			UserID:    userID,
+			StartedAt: time.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.queries, queryID)
+		r.mu.Unlock()
+
+		cancel()
+	}
+}
+
+// List returns the running queries, the longest running first
+func (r *RunningQueryRegistry) List() []RunningQueryDTO {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queries := make([]RunningQueryDTO, 0, len(r.queries))
+	for _, query := range r.queries {
+		queries = append(queries, query.info)
+	}
+
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].StartedAt.Before(queries[j].StartedAt)
+	})
+
+	return queries
+}
+
+// Cancel cancels the query and reports whether it was running. The query is
+// removed from the registry when it returns
+func (r *RunningQueryRegistry) Cancel(queryID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query, exists := r.queries[queryID]
+	if !exists {
+		return false
+	}
+
+	query.cancel()
+	return true
+}
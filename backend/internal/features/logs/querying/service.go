@@ -1,24 +1,100 @@
 package logs_querying
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
+	logs_annotations "logbull/internal/features/logs/annotations"
 	logs_core "logbull/internal/features/logs/core"
 	projects_services "logbull/internal/features/projects/services"
+	users_enums "logbull/internal/features/users/enums"
 	users_models "logbull/internal/features/users/models"
 
 	"github.com/google/uuid"
 )
 
+const (
+	defaultTraceTimeWindow = 24 * time.Hour
+	maxTraceLogs           = 1000
+
+	defaultFieldsPageSize = 50
+	maxFieldsPageSize     = 500
+
+	DefaultQueryLookback = 24 * time.Hour
+
+	// Deepest offset plus limit of a page, the default index.max_result_window
+	// of OpenSearch. Deeper pages are read with cursors
+	DefaultMaxResultWindow = 10000
+	// page size OpenSearch applies when the request has no limit
+	defaultQueryPageSize = 10
+
+	overviewRecentErrorsWindow = 24 * time.Hour
+
+	maskedFieldValue = "***"
+
+	maxQueryPartitions = 31
+
+	defaultComparisonTopValues = 10
+	maxComparisonTopValues     = 100
+
+	defaultGroupByLimit = 20
+	maxGroupByLimit     = 100
+	// offset plus limit, every page aggregates all buckets before it
+	maxGroupByBuckets = 1000
+
+	defaultAggregateTopN = 10
+	// larger requests are capped, not rejected
+	maxAggregateTopN = 100
+
+	defaultTailLimit = 100
+	maxTailLimit     = 1000
+
+	defaultLatestPerGroupLimit = 20
+	maxLatestPerGroupLimit     = 100
+	maxLatestPerGroupBuckets   = 100
+	minLatestPerGroupInterval  = time.Minute
+	// buckets times groupsLimit, every group of every bucket returns a log
+	maxLatestPerGroupLogs = 1000
+
+	defaultVolumeHistogramInterval = "1h"
+	defaultVolumeHistogramWindow   = 24 * time.Hour
+	maxVolumeHistogramPoints       = 1440
+)
+
 type LogQueryService struct {
 	logRepository          *logs_core.LogCoreRepository
 	projectService         *projects_services.ProjectService
+	membershipService      *projects_services.MembershipService
 	concurrentQueryLimiter *ConcurrentQueryLimiter
+	runningQueries         *RunningQueryRegistry
 	queryValidator         *QueryValidator
+	annotationService      *logs_annotations.LogAnnotationService
 	logger                 *slog.Logger
+	// applied as timeRange.from when a query has no lower time bound
+	defaultLookback time.Duration
+	maxResultWindow int
+}
+
+func (s *LogQueryService) SetDefaultLookback(defaultLookback time.Duration) {
+	s.defaultLookback = defaultLookback
+}
+
+func (s *LogQueryService) GetDefaultLookback() time.Duration {
+	return s.defaultLookback
+}
+
+func (s *LogQueryService) SetMaxResultWindow(maxResultWindow int) {
+	s.maxResultWindow = maxResultWindow
+}
+
+func (s *LogQueryService) GetMaxResultWindow() int {
+	return s.maxResultWindow
 }
 
 func (s *LogQueryService) ExecuteQuery(
@@ -26,8 +102,13 @@ func (s *LogQueryService) ExecuteQuery(
 	request *logs_core.LogQueryRequestDTO,
 	user *users_models.User,
 ) (*logs_core.LogQueryResponseDTO, error) {
+	startTime := time.Now()
 	queryID := uuid.New().String()
 
+	// Registered before the slots are taken, so queued queries are listed too
+	queryCtx, unregisterQuery := s.runningQueries.Register(queryID, projectID, user.ID)
+	defer unregisterQuery()
+
 	if err := s.concurrentQueryLimiter.AcquireQuerySlot(user.ID, queryID); err != nil {
 		return nil, err
 	}
@@ -35,7 +116,7 @@ func (s *LogQueryService) ExecuteQuery(
 	defer s.concurrentQueryLimiter.ReleaseQuerySlot(user.ID, queryID)
 
 	// Global admins can access any project, regular users only projects they're members
-	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	canAccess, projectRole, err := s.projectService.CanUserAccessProject(projectID, user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify project access: %w", err)
 	}
@@ -43,16 +124,145 @@ func (s *LogQueryService) ExecuteQuery(
 		return nil, errors.New("insufficient permissions to query project logs")
 	}
 
+	if request.AllHistory && !isProjectManagerRole(projectRole) {
+		return nil, errors.New("insufficient permissions to query full log history")
+	}
+
+	validationStartTime := time.Now()
 	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
 		return nil, fmt.Errorf("invalid query structure: %w", err)
 	}
 
-	if err := s.validateTimeRange(request.TimeRange); err != nil {
+	// Added after validation, so the level condition does not count towards
+	// the query depth and size limits, but before the masked fields check
+	if request.MinLevel != "" {
+		if request.Query, err = withMinLevel(request.Query, request.MinLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	// Resolved before the masked fields check, so aliases cannot reveal masked fields
+	if request.Query, err = s.ResolveFieldAliasesForProject(projectID, request.Query); err != nil {
+		return nil, err
+	}
+
+	maskedFields, err := s.getMaskedFields(projectID, projectRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if field, isMasked := findMaskedField(request.Query, maskedFields); isMasked {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be queried", field),
+		}
+	}
+
+	if request.After != "" {
+		if err := s.validateCursorQuery(request); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.validateTimeRange(request.TimeRange); err != nil {
+			return nil, err
+		}
+
+		if err := s.validateResultWindow(request); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validatePartitions(request.Partitions); err != nil {
+		return nil, err
+	}
+
+	if request.TimestampFormat != "" && !request.TimestampFormat.IsValid() {
+		return nil, &ValidationError{
+			Code: logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf(
+				"timestampFormat %q must be one of rfc3339, epoch_millis or epoch_seconds",
+				request.TimestampFormat,
+			),
+		}
+	}
+	validationTime := time.Since(validationStartTime)
+
+	request.Query, err = s.EncryptQueryForProject(projectID, request.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedTimeRange := s.applyDefaultLookback(request)
+
+	response, err := s.logRepository.ExecuteQueryForProjectWithContext(queryCtx, projectID, request)
+	if err != nil {
+		return nil, queryCancelledError(queryCtx, err)
+	}
+
+	if err := s.DecryptLogsForProject(projectID, response.Logs); err != nil {
+		return nil, err
+	}
+
+	response.AppliedTimeRange = appliedTimeRange
+	maskLogFields(response.Logs, maskedFields)
+
+	for i := range response.Logs {
+		response.Logs[i].SetTimestampFormat(request.TimestampFormat)
+	}
+
+	if request.IncludeDiagnostics && response.Total == 0 {
+		diagnostics, err := s.getNoResultsDiagnostics(projectID, request.TimeRange, request.IncludeArchived)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect query diagnostics: %w", err)
+		}
+
+		response.Diagnostics = diagnostics
+	}
+
+	if request.IncludeAnnotations {
+		if err := s.attachAnnotations(projectID, response.Logs); err != nil {
+			return nil, fmt.Errorf("failed to load log annotations: %w", err)
+		}
+	}
+
+	if request.IncludeTiming && response.Timing != nil {
+		response.Timing.ValidationMs = logs_core.DurationMs(validationTime)
+		response.Timing.TotalMs = logs_core.DurationMs(time.Since(startTime))
+	}
+
+	return response, nil
+}
+
+// getNoResultsDiagnostics counts the project logs overall and within the time
+// range, both without the query conditions and in the tiers the query searched
+func (s *LogQueryService) getNoResultsDiagnostics(
+	projectID uuid.UUID,
+	timeRange *logs_core.TimeRangeDTO,
+	includeArchived bool,
+) (*logs_core.NoResultsDiagnosticsDTO, error) {
+	projectLogs, err := s.logRepository.ExecuteQueryForProject(projectID, &logs_core.LogQueryRequestDTO{
+		Limit:           1,
+		TrackTotal:      true,
+		IncludeArchived: includeArchived,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeRangeLogs, err := s.logRepository.ExecuteQueryForProject(projectID, &logs_core.LogQueryRequestDTO{
+		TimeRange:       timeRange,
+		Limit:           1,
+		TrackTotal:      true,
+		IncludeArchived: includeArchived,
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	response, err := s.logRepository.ExecuteQueryForProject(projectID, request)
-	return response, err
+	return &logs_core.NoResultsDiagnosticsDTO{
+		ProjectTotalLogs:   projectLogs.Total,
+		TimeRangeTotalLogs: timeRangeLogs.Total,
+	}, nil
 }
 
 func (s *LogQueryService) GetQueryableFields(
@@ -68,27 +278,54 @@ func (s *LogQueryService) GetQueryableFields(
 		return nil, errors.New("insufficient permissions to view project fields")
 	}
 
-	discoveredFieldNames, err := s.logRepository.DiscoverFields(projectID)
+	if request.Limit < 0 || request.Offset < 0 {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "limit and offset must not be negative",
+		}
+	}
+
+	if request.All {
+		return s.getAllQueryableFields(projectID, request), nil
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultFieldsPageSize
+	}
+	limit = min(limit, maxFieldsPageSize)
+
+	// Predefined fields always go first, discovered custom fields follow them
+	predefinedFields := s.filterFields(logs_core.PredefinedQueryableFields, request.Query, request.Prefix)
+
+	pageFields := make([]logs_core.QueryableField, 0, limit)
+	if request.Offset < len(predefinedFields) {
+		end := min(request.Offset+limit, len(predefinedFields))
+		pageFields = append(pageFields, predefinedFields[request.Offset:end]...)
+	}
+
+	customFieldsPage, err := s.logRepository.DiscoverFieldsPage(projectID, &logs_core.DiscoverFieldsPageRequestDTO{
+		Prefix: request.Prefix,
+		Search: request.Query,
+		Limit:  limit - len(pageFields),
+		Offset: max(request.Offset-len(predefinedFields), 0),
+	})
 	if err != nil {
 		s.logger.Warn("Failed to discover fields from logs storage, using predefined fields only",
 			slog.String("error", err.Error()),
 			slog.String("projectId", projectID.String()))
-		discoveredFieldNames = []string{} // Continue with predefined fields only
+		customFieldsPage = &logs_core.DiscoverFieldsPageDTO{Fields: []string{}} // Continue with predefined fields only
 	}
 
-	allFields := s.combineFields(discoveredFieldNames)
-
-	// Filter fields based on query parameter (case-insensitive ILIKE behavior)
-	filteredFields := s.filterFields(allFields, request.Query)
-
-	// Limit to 50 fields
-	const maxFields = 50
-	if len(filteredFields) > maxFields {
-		filteredFields = filteredFields[:maxFields]
+	if len(pageFields) < limit {
+		pageFields = append(pageFields, s.toCustomFields(customFieldsPage.Fields)...)
 	}
 
 	return &logs_core.GetQueryableFieldsResponseDTO{
-		Fields: filteredFields,
+		Fields: pageFields,
+		Total:  len(predefinedFields) + customFieldsPage.Total,
+		Limit:  limit,
+		Offset: request.Offset,
 	}, nil
 }
 
@@ -112,79 +349,1413 @@ func (s *LogQueryService) GetProjectStats(
 	return stats, nil
 }
 
-func (s *LogQueryService) combineFields(discoveredFieldNames []string) []logs_core.QueryableField {
-	fieldMap := make(map[string]logs_core.QueryableField)
-	for _, field := range logs_core.PredefinedQueryableFields {
-		fieldMap[field.Name] = field
+// GetFieldSchema returns the discovered custom fields of the project with their
+// inferred types and cardinality
+func (s *LogQueryService) GetFieldSchema(
+	projectID uuid.UUID,
+	user *users_models.User,
+) (*logs_core.FieldSchemaDTO, error) {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view project fields")
 	}
 
-	for _, fieldName := range discoveredFieldNames {
-		if _, exists := fieldMap[fieldName]; exists {
-			continue
+	schema, err := s.logRepository.GetFieldSchema(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+// GetLogVolumeHistogram counts the logs matching the optional query per
+// interval, for volume sparklines. The window defaults to the last 24 hours
+func (s *LogQueryService) GetLogVolumeHistogram(
+	projectID uuid.UUID,
+	request *GetLogVolumeHistogramRequestDTO,
+	user *users_models.User,
+) (*LogVolumeHistogramResponseDTO, error) {
+	queryID := uuid.New().String()
+
+	queryCtx, unregisterQuery := s.runningQueries.Register(queryID, projectID, user.ID)
+	defer unregisterQuery()
+
+	if err := s.concurrentQueryLimiter.AcquireQuerySlot(user.ID, queryID); err != nil {
+		return nil, err
+	}
+
+	defer s.concurrentQueryLimiter.ReleaseQuerySlot(user.ID, queryID)
+
+	canAccess, projectRole, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
+	}
+
+	var query *logs_core.QueryNode
+	if strings.TrimSpace(request.Query) != "" {
+		if err := json.Unmarshal([]byte(request.Query), &query); err != nil {
+			return nil, &ValidationError{
+				Code:    logs_core.ErrorInvalidQueryStructure,
+				Message: "query must be a JSON encoded query node",
+			}
 		}
+	}
 
-		// Skip internal logs storage fields
-		if s.isInternalField(fieldName) {
-			continue
+	if err := s.queryValidator.ValidateQuery(query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	interval := request.Interval
+	if interval == "" {
+		interval = defaultVolumeHistogramInterval
+	}
+
+	to := time.Now().UTC()
+	if request.To != nil {
+		to = request.To.UTC()
+	}
+
+	from := to.Add(-defaultVolumeHistogramWindow)
+	if request.From != nil {
+		from = request.From.UTC()
+	}
+
+	if err := validateVolumeHistogramRequest(interval, from, to); err != nil {
+		return nil, err
+	}
+
+	query, err = s.ResolveFieldAliasesForProject(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	maskedFields, err := s.getMaskedFields(projectID, projectRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if field, isMasked := findMaskedField(query, maskedFields); isMasked {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be queried", field),
 		}
+	}
 
-		customField := logs_core.QueryableField{
-			Name:     fieldName,
-			Type:     logs_core.QueryableFieldTypeString, // Default to string for custom fields
-			IsCustom: true,
-			Operations: []logs_core.ConditionOperator{
-				logs_core.ConditionOperatorEquals, logs_core.ConditionOperatorNotEquals,
-				logs_core.ConditionOperatorContains, logs_core.ConditionOperatorNotContains,
-				logs_core.ConditionOperatorExists, logs_core.ConditionOperatorNotExists,
+	query, err = s.EncryptQueryForProject(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := s.logRepository.GetLogVolumeHistogramWithContext(queryCtx, projectID, query, interval, from, to)
+	if err != nil {
+		return nil, queryCancelledError(queryCtx, err)
+	}
+
+	return &LogVolumeHistogramResponseDTO{
+		Interval:  interval,
+		TimeRange: logs_core.TimeRangeDTO{From: &from, To: &to},
+		Points:    points,
+	}, nil
+}
+
+// GetProjectOverview returns project settings, log stats, recent errors and
+// members in one response for the project dashboard
+func (s *LogQueryService) GetProjectOverview(
+	projectID uuid.UUID,
+	user *users_models.User,
+) (*ProjectOverviewDTO, error) {
+	project, err := s.projectService.GetProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.logRepository.GetProjectLogStats(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project stats: %w", err)
+	}
+
+	members, err := s.membershipService.GetMembers(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	recentErrorsSince := now.Add(-overviewRecentErrorsWindow)
+	recentErrors, err := s.logRepository.ExecuteQueryForProject(projectID, &logs_core.LogQueryRequestDTO{
+		Query: &logs_core.QueryNode{
+			Type: logs_core.QueryNodeTypeCondition,
+			Condition: &logs_core.ConditionNode{
+				Field:    "level",
+				Operator: logs_core.ConditionOperatorIn,
+				Value:    []any{string(logs_core.LogLevelError), string(logs_core.LogLevelFatal)},
 			},
-		}
+		},
+		TimeRange:  &logs_core.TimeRangeDTO{From: &recentErrorsSince, To: &now},
+		Limit:      1,
+		TrackTotal: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent errors: %w", err)
+	}
 
-		fieldMap[customField.Name] = customField
+	overview := &ProjectOverviewDTO{
+		Project:           project,
+		LogStats:          stats,
+		RecentErrorsCount: recentErrors.Total,
+		RecentErrorsSince: recentErrorsSince,
+		MembersCount:      len(members.Members),
 	}
 
-	fields := make([]logs_core.QueryableField, 0, len(fieldMap))
-	for _, field := range fieldMap {
-		fields = append(fields, field)
+	if stats.TotalLogs > 0 {
+		lastLogTime := stats.NewestLogTime
+		overview.LastLogTime = &lastLogTime
 	}
 
-	return fields
+	return overview, nil
 }
 
-func (s *LogQueryService) filterFields(fields []logs_core.QueryableField, query string) []logs_core.QueryableField {
-	if query == "" {
-		return fields // Return all fields if no query specified
+func (s *LogQueryService) GetTraceLogs(
+	projectID uuid.UUID,
+	traceID string,
+	request *GetTraceLogsRequestDTO,
+	user *users_models.User,
+) (*logs_core.LogQueryResponseDTO, error) {
+	canAccess, projectRole, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
 	}
 
-	query = strings.ToLower(query)
-	filteredFields := make([]logs_core.QueryableField, 0)
+	project, err := s.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
 
-	for _, field := range fields {
-		// Check name (case-insensitive)
-		if strings.Contains(strings.ToLower(field.Name), query) {
-			filteredFields = append(filteredFields, field)
+	traceIdField := project.TraceIdField
+	if field := strings.TrimSpace(request.Field); field != "" {
+		traceIdField, err = s.resolveFieldAlias(projectID, field)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return filteredFields
-}
+	if traceIdField == "" {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorTraceIdFieldNotSet,
+			Message: "trace id field is not configured for this project, pass the field to correlate by",
+		}
+	}
+
+	maxValueLength := s.queryValidator.GetLimits().MaxValueLength
+	if traceID == "" || len(traceID) > maxValueLength {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("trace id must be between 1 and %d characters", maxValueLength),
+		}
+	}
 
-func (s *LogQueryService) isInternalField(fieldName string) bool {
-	internalFields := map[string]bool{
-		"_msg":    true,
-		"_time":   true,
-		"_stream": true,
-		"project": true, // Our internal project field
+	to := time.Now().UTC()
+	if request.To != nil {
+		to = request.To.UTC()
 	}
-	return internalFields[fieldName]
-}
 
-func (s *LogQueryService) GetUserActiveQueryCount(userID uuid.UUID) (int, error) {
-	return s.concurrentQueryLimiter.GetActiveQueryCount(userID)
+	from := to.Add(-defaultTraceTimeWindow)
+	if request.From != nil {
+		from = request.From.UTC()
+	}
+
+	timeRange := &logs_core.TimeRangeDTO{From: &from, To: &to}
+	if err := s.validateTimeRange(timeRange); err != nil {
+		return nil, err
+	}
+
+	traceQuery := &logs_core.QueryNode{
+		Type: logs_core.QueryNodeTypeCondition,
+		Condition: &logs_core.ConditionNode{
+			Field:    traceIdField,
+			Operator: logs_core.ConditionOperatorEquals,
+			Value:    traceID,
+		},
+	}
+
+	if err := s.queryValidator.ValidateQuery(traceQuery); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	maskedFields, err := s.getMaskedFields(projectID, projectRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(maskedFields, traceIdField) {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be queried", traceIdField),
+		}
+	}
+
+	traceQuery, err = s.EncryptQueryForProject(projectID, traceQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.logRepository.ExecuteQueryForProject(projectID, &logs_core.LogQueryRequestDTO{
+		Query:     traceQuery,
+		TimeRange: timeRange,
+		Limit:     maxTraceLogs,
+		SortOrder: "asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.DecryptLogsForProject(projectID, response.Logs); err != nil {
+		return nil, err
+	}
+
+	if !isProjectManagerRole(projectRole) {
+		maskLogFields(response.Logs, project.MaskedFields)
+	}
+
+	return response, nil
 }
 
-func (s *LogQueryService) CleanupPendingQueries() error {
-	if err := s.concurrentQueryLimiter.CleanupAllQuerySlots(); err != nil {
-		return fmt.Errorf("failed to cleanup query slots on startup: %w", err)
+// TailLogs returns the logs after the given position in ascending order, so CLI
+// tools can follow a project by polling with the returned cursor
+func (s *LogQueryService) TailLogs(
+	projectID uuid.UUID,
+	request *TailLogsRequestDTO,
+	user *users_models.User,
+) (*TailLogsResponseDTO, error) {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultTailLimit
+	}
+	if limit < 0 || limit > maxTailLimit {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("limit must be between 1 and %d", maxTailLimit),
+		}
+	}
+
+	positionsCount := 0
+	for _, isSet := range []bool{request.After != "", request.AfterID != "", request.AfterTimestamp != nil} {
+		if isSet {
+			positionsCount++
+		}
+	}
+	if positionsCount > 1 {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "only one of after, afterId and afterTimestamp can be set",
+		}
+	}
+
+	queryRequest := &logs_core.LogQueryRequestDTO{Limit: limit, MinLevel: request.MinLevel}
+	now := time.Now().UTC()
+
+	switch {
+	case request.After != "":
+		queryRequest.After = request.After
+	case request.AfterID != "":
+		cursor, err := s.logRepository.FindLogCursor(projectID, request.AfterID)
+		if err != nil {
+			return nil, err
+		}
+		if cursor == nil {
+			return nil, &ValidationError{
+				Code:    logs_core.ErrorLogNotFound,
+				Message: fmt.Sprintf("log %s not found in the project", request.AfterID),
+			}
+		}
+
+		queryRequest.After = logs_core.EncodeLogCursor(*cursor)
+	case request.AfterTimestamp != nil:
+		queryRequest.After = logs_core.EncodeLogCursor(logs_core.CursorAfterTimestamp(*request.AfterTimestamp))
+	default:
+		// The newest page is read descending and returned oldest first like
+		// the following polls
+		queryRequest.TimeRange = &logs_core.TimeRangeDTO{To: &now}
+		queryRequest.SortOrder = "desc"
+	}
+
+	response, err := s.ExecuteQuery(projectID, queryRequest, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if queryRequest.After == "" {
+		slices.Reverse(response.Logs)
+	}
+
+	cursor := response.Cursor
+	if cursor == "" {
+		cursor = logs_core.EncodeLogCursor(logs_core.CursorAfterTimestamp(now))
+	}
+
+	return &TailLogsResponseDTO{
+		Logs:    response.Logs,
+		Cursor:  cursor,
+		HasMore: len(response.Logs) == limit,
+	}, nil
+}
+
+// CompareWindows runs the query over the before and after windows and returns
+// the matches of each with the change between them
+func (s *LogQueryService) CompareWindows(
+	projectID uuid.UUID,
+	request *CompareWindowsRequestDTO,
+	user *users_models.User,
+) (*CompareWindowsResponseDTO, error) {
+	queryID := uuid.New().String()
+
+	queryCtx, unregisterQuery := s.runningQueries.Register(queryID, projectID, user.ID)
+	defer unregisterQuery()
+
+	if err := s.concurrentQueryLimiter.AcquireQuerySlot(user.ID, queryID); err != nil {
+		return nil, err
+	}
+
+	defer s.concurrentQueryLimiter.ReleaseQuerySlot(user.ID, queryID)
+
+	canAccess, projectRole, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
+	}
+
+	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	if err := validateComparisonRequest(request); err != nil {
+		return nil, err
+	}
+
+	groupBy, err := s.resolveFieldAlias(projectID, strings.TrimSpace(request.GroupBy))
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := s.ResolveFieldAliasesForProject(projectID, request.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	maskedFields, err := s.getMaskedFields(projectID, projectRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if field, isMasked := findMaskedField(query, maskedFields); isMasked {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be queried", field),
+		}
+	}
+
+	if slices.Contains(maskedFields, groupBy) {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be grouped by", groupBy),
+		}
+	}
+
+	query, err = s.EncryptQueryForProject(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	topValuesLimit := request.TopValuesLimit
+	if topValuesLimit == 0 {
+		topValuesLimit = defaultComparisonTopValues
+	}
+
+	counts, err := s.logRepository.CompareWindowsWithContext(
+		queryCtx,
+		projectID,
+		query,
+		[]logs_core.TimeRangeDTO{request.Before, request.After},
+		groupBy,
+		topValuesLimit,
+	)
+	if err != nil {
+		return nil, queryCancelledError(queryCtx, err)
+	}
+
+	before := newWindowSummary(request.Before, counts.WindowTotals[0])
+	after := newWindowSummary(request.After, counts.WindowTotals[1])
+
+	response := &CompareWindowsResponseDTO{
+		Before:             before,
+		After:              after,
+		Delta:              after.Total - before.Total,
+		RatePerMinuteDelta: after.RatePerMinute - before.RatePerMinute,
+	}
+
+	if before.Total > 0 {
+		deltaPercent := float64(response.Delta) / float64(before.Total) * 100
+		response.DeltaPercent = &deltaPercent
+	}
+
+	if groupBy != "" {
+		response.TopValues = make([]ValueComparisonDTO, 0, len(counts.TopValues))
+		for _, topValue := range counts.TopValues {
+			response.TopValues = append(response.TopValues, ValueComparisonDTO{
+				Value:  topValue.Value,
+				Before: topValue.WindowCounts[0],
+				After:  topValue.WindowCounts[1],
+				Delta:  topValue.WindowCounts[1] - topValue.WindowCounts[0],
+			})
+		}
+
+		if err := s.decryptTopValues(projectID, response.TopValues); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// GroupBy counts the logs matching the query per value of the groupBy field
+// and returns one page of groups, the most frequent values first
+func (s *LogQueryService) GroupBy(
+	projectID uuid.UUID,
+	request *GroupByRequestDTO,
+	user *users_models.User,
+) (*GroupByResponseDTO, error) {
+	queryID := uuid.New().String()
+
+	queryCtx, unregisterQuery := s.runningQueries.Register(queryID, projectID, user.ID)
+	defer unregisterQuery()
+
+	if err := s.concurrentQueryLimiter.AcquireQuerySlot(user.ID, queryID); err != nil {
+		return nil, err
+	}
+
+	defer s.concurrentQueryLimiter.ReleaseQuerySlot(user.ID, queryID)
+
+	canAccess, projectRole, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
+	}
+
+	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	if err := validateGroupByRequest(request); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTimeRange(request.TimeRange); err != nil {
+		return nil, err
+	}
+
+	groupBy, err := s.resolveFieldAlias(projectID, strings.TrimSpace(request.GroupBy))
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := s.ResolveFieldAliasesForProject(projectID, request.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	maskedFields, err := s.getMaskedFields(projectID, projectRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if field, isMasked := findMaskedField(query, maskedFields); isMasked {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be queried", field),
+		}
+	}
+
+	if slices.Contains(maskedFields, groupBy) {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be grouped by", groupBy),
+		}
+	}
+
+	query, err = s.EncryptQueryForProject(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultGroupByLimit
+	}
+
+	queryRequest := &logs_core.LogQueryRequestDTO{Query: query, TimeRange: request.TimeRange}
+	appliedTimeRange := s.applyDefaultLookback(queryRequest)
+
+	// One bucket more than the page tells whether another page exists
+	counts, err := s.logRepository.GroupByWithContext(queryCtx, projectID, queryRequest, groupBy, request.Offset+limit+1)
+	if err != nil {
+		return nil, queryCancelledError(queryCtx, err)
+	}
+
+	response := &GroupByResponseDTO{
+		GroupBy:          strings.TrimSpace(request.GroupBy),
+		Groups:           []GroupCountDTO{},
+		Total:            counts.Total,
+		Limit:            limit,
+		Offset:           request.Offset,
+		HasMore:          len(counts.Groups) > request.Offset+limit,
+		AppliedTimeRange: appliedTimeRange,
+	}
+
+	if request.Offset < len(counts.Groups) {
+		pageGroups := counts.Groups[request.Offset:min(request.Offset+limit, len(counts.Groups))]
+		for _, group := range pageGroups {
+			response.Groups = append(response.Groups, GroupCountDTO{Value: group.Value, Count: group.Count})
+		}
+	}
+
+	if err := s.decryptGroups(projectID, response.Groups); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// AggregateByField counts the logs matching the query per value of a field,
+// the topN most frequent values first
+func (s *LogQueryService) AggregateByField(
+	projectID uuid.UUID,
+	request *logs_core.AggregateRequestDTO,
+	user *users_models.User,
+) ([]logs_core.AggregateBucketDTO, error) {
+	queryID := uuid.New().String()
+
+	queryCtx, unregisterQuery := s.runningQueries.Register(queryID, projectID, user.ID)
+	defer unregisterQuery()
+
+	if err := s.concurrentQueryLimiter.AcquireQuerySlot(user.ID, queryID); err != nil {
+		return nil, err
+	}
+
+	defer s.concurrentQueryLimiter.ReleaseQuerySlot(user.ID, queryID)
+
+	canAccess, projectRole, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
+	}
+
+	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	if err := validateAggregateRequest(request); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTimeRange(request.TimeRange); err != nil {
+		return nil, err
+	}
+
+	field, err := s.resolveFieldAlias(projectID, strings.TrimSpace(request.Field))
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := s.ResolveFieldAliasesForProject(projectID, request.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	maskedFields, err := s.getMaskedFields(projectID, projectRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if maskedField, isMasked := findMaskedField(query, maskedFields); isMasked {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be queried", maskedField),
+		}
+	}
+
+	if slices.Contains(maskedFields, field) {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be aggregated", field),
+		}
+	}
+
+	query, err = s.EncryptQueryForProject(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	topN := request.TopN
+	if topN == 0 {
+		topN = defaultAggregateTopN
+	}
+
+	queryRequest := &logs_core.LogQueryRequestDTO{Query: query, TimeRange: request.TimeRange}
+	s.applyDefaultLookback(queryRequest)
+
+	buckets, err := s.logRepository.AggregateByFieldWithContext(queryCtx, projectID, &logs_core.AggregateRequestDTO{
+		Query:     queryRequest.Query,
+		TimeRange: queryRequest.TimeRange,
+		Field:     field,
+		TopN:      min(topN, maxAggregateTopN),
+	})
+	if err != nil {
+		return nil, queryCancelledError(queryCtx, err)
+	}
+
+	return buckets, nil
+}
+
+// LatestPerGroup returns the latest log of each groupBy value within every
+// interval of the time range, the groups of a bucket ordered by value
+func (s *LogQueryService) LatestPerGroup(
+	projectID uuid.UUID,
+	request *LatestPerGroupRequestDTO,
+	user *users_models.User,
+) (*LatestPerGroupResponseDTO, error) {
+	queryID := uuid.New().String()
+
+	queryCtx, unregisterQuery := s.runningQueries.Register(queryID, projectID, user.ID)
+	defer unregisterQuery()
+
+	if err := s.concurrentQueryLimiter.AcquireQuerySlot(user.ID, queryID); err != nil {
+		return nil, err
+	}
+
+	defer s.concurrentQueryLimiter.ReleaseQuerySlot(user.ID, queryID)
+
+	canAccess, projectRole, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify project access: %w", err)
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
+	}
+
+	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	interval, err := validateLatestPerGroupRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTimeRange(request.TimeRange); err != nil {
+		return nil, err
+	}
+
+	groupBy, err := s.resolveFieldAlias(projectID, strings.TrimSpace(request.GroupBy))
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := s.ResolveFieldAliasesForProject(projectID, request.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	maskedFields, err := s.getMaskedFields(projectID, projectRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if field, isMasked := findMaskedField(query, maskedFields); isMasked {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be queried", field),
+		}
+	}
+
+	if slices.Contains(maskedFields, groupBy) {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorFieldMasked,
+			Message: fmt.Sprintf("field %s is masked for your project role and cannot be grouped by", groupBy),
+		}
+	}
+
+	query, err = s.EncryptQueryForProject(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsLimit := request.GroupsLimit
+	if groupsLimit == 0 {
+		groupsLimit = defaultLatestPerGroupLimit
+	}
+
+	queryRequest := &logs_core.LogQueryRequestDTO{Query: query, TimeRange: request.TimeRange}
+	appliedTimeRange := s.applyDefaultLookback(queryRequest)
+
+	buckets, err := splitIntoBuckets(queryRequest.TimeRange, interval, groupsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	latestLogs, err := s.logRepository.LatestPerGroupWithContext(queryCtx, projectID, query, buckets, groupBy, groupsLimit)
+	if err != nil {
+		return nil, queryCancelledError(queryCtx, err)
+	}
+
+	response := &LatestPerGroupResponseDTO{
+		GroupBy:          strings.TrimSpace(request.GroupBy),
+		Interval:         request.Interval,
+		Buckets:          make([]LatestPerGroupBucketDTO, 0, len(latestLogs)),
+		AppliedTimeRange: appliedTimeRange,
+	}
+
+	for _, bucketLogs := range latestLogs {
+		bucket := LatestPerGroupBucketDTO{
+			TimeRange: bucketLogs.TimeRange,
+			Groups:    make([]GroupLatestLogDTO, 0, len(bucketLogs.Groups)),
+		}
+
+		logs := make([]logs_core.LogItemDTO, 0, len(bucketLogs.Groups))
+		for _, group := range bucketLogs.Groups {
+			logs = append(logs, group.Log)
+		}
+
+		if err := s.DecryptLogsForProject(projectID, logs); err != nil {
+			return nil, err
+		}
+		maskLogFields(logs, maskedFields)
+
+		for i, group := range bucketLogs.Groups {
+			bucket.Groups = append(bucket.Groups, GroupLatestLogDTO{Value: group.Value, Count: group.Count, Log: logs[i]})
+		}
+
+		if err := s.decryptLatestGroups(projectID, bucket.Groups); err != nil {
+			return nil, err
+		}
+
+		response.Buckets = append(response.Buckets, bucket)
+	}
+
+	return response, nil
+}
+
+func (s *LogQueryService) getAllQueryableFields(
+	projectID uuid.UUID,
+	request *logs_core.GetQueryableFieldsRequestDTO,
+) *logs_core.GetQueryableFieldsResponseDTO {
+	discoveredFieldNames, err := s.logRepository.DiscoverFields(projectID)
+	if err != nil {
+		s.logger.Warn("Failed to discover fields from logs storage, using predefined fields only",
+			slog.String("error", err.Error()),
+			slog.String("projectId", projectID.String()))
+		discoveredFieldNames = []string{} // Continue with predefined fields only
+	}
+
+	allFields := s.combineFields(discoveredFieldNames)
+	filteredFields := s.filterFields(allFields, request.Query, request.Prefix)
+
+	return &logs_core.GetQueryableFieldsResponseDTO{
+		Fields: filteredFields,
+		Total:  len(filteredFields),
+		Limit:  len(filteredFields),
+		Offset: 0,
+	}
+}
+
+func (s *LogQueryService) combineFields(discoveredFieldNames []string) []logs_core.QueryableField {
+	fields := make([]logs_core.QueryableField, 0, len(logs_core.PredefinedQueryableFields)+len(discoveredFieldNames))
+	fields = append(fields, logs_core.PredefinedQueryableFields...)
+
+	predefinedFieldNames := make(map[string]bool, len(logs_core.PredefinedQueryableFields))
+	for _, field := range logs_core.PredefinedQueryableFields {
+		predefinedFieldNames[field.Name] = true
+	}
+
+	customFieldNames := make([]string, 0, len(discoveredFieldNames))
+	for _, fieldName := range discoveredFieldNames {
+		if !predefinedFieldNames[fieldName] {
+			customFieldNames = append(customFieldNames, fieldName)
+		}
+	}
+
+	return append(fields, s.toCustomFields(customFieldNames)...)
+}
+
+func (s *LogQueryService) toCustomFields(fieldNames []string) []logs_core.QueryableField {
+	fields := make([]logs_core.QueryableField, 0, len(fieldNames))
+
+	for _, fieldName := range fieldNames {
+		fields = append(fields, logs_core.QueryableField{
+			Name:     fieldName,
+			Type:     logs_core.QueryableFieldTypeString, // Default to string for custom fields
+			IsCustom: true,
+			Operations: []logs_core.ConditionOperator{
+				logs_core.ConditionOperatorEquals, logs_core.ConditionOperatorNotEquals,
+				logs_core.ConditionOperatorContains, logs_core.ConditionOperatorNotContains,
+				logs_core.ConditionOperatorStartsWith, logs_core.ConditionOperatorEndsWith,
+				logs_core.ConditionOperatorRegex,
+				logs_core.ConditionOperatorGreaterThan, logs_core.ConditionOperatorGreaterOrEqual,
+				logs_core.ConditionOperatorLessThan, logs_core.ConditionOperatorLessOrEqual,
+				logs_core.ConditionOperatorExists, logs_core.ConditionOperatorNotExists,
+			},
+		})
+	}
+
+	return fields
+}
+
+func (s *LogQueryService) filterFields(
+	fields []logs_core.QueryableField,
+	query string,
+	prefix string,
+) []logs_core.QueryableField {
+	if query == "" && prefix == "" {
+		return fields // Return all fields if no filter specified
+	}
+
+	query = strings.ToLower(query)
+	prefix = strings.ToLower(prefix)
+	filteredFields := make([]logs_core.QueryableField, 0)
+
+	for _, field := range fields {
+		// Check name (case-insensitive)
+		fieldName := strings.ToLower(field.Name)
+		if strings.HasPrefix(fieldName, prefix) && strings.Contains(fieldName, query) {
+			filteredFields = append(filteredFields, field)
+		}
+	}
+
+	return filteredFields
+}
+
+func (s *LogQueryService) GetUserActiveQueryCount(userID uuid.UUID) (int, error) {
+	return s.concurrentQueryLimiter.GetActiveQueryCount(userID)
+}
+
+// GetRunningQueries lists the queries this instance runs, admins only
+func (s *LogQueryService) GetRunningQueries(user *users_models.User) ([]RunningQueryDTO, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("insufficient permissions to view running queries")
+	}
+
+	return s.runningQueries.List(), nil
+}
+
+// CancelRunningQuery stops a query of this instance, its caller gets a
+// QUERY_CANCELLED error. Admins only
+func (s *LogQueryService) CancelRunningQuery(queryID string, user *users_models.User) error {
+	if user.Role != users_enums.UserRoleAdmin {
+		return errors.New("insufficient permissions to cancel running queries")
+	}
+
+	if !s.runningQueries.Cancel(queryID) {
+		return &ValidationError{
+			Code:    logs_core.ErrorRunningQueryNotFound,
+			Message: fmt.Sprintf("query %s is not running", queryID),
+		}
+	}
+
+	s.logger.Info("Running query cancelled",
+		slog.String("queryId", queryID),
+		slog.String("cancelledBy", user.ID.String()))
+
+	return nil
+}
+
+func (s *LogQueryService) CleanupPendingQueries() error {
+	if err := s.concurrentQueryLimiter.CleanupAllQuerySlots(); err != nil {
+		return fmt.Errorf("failed to cleanup query slots on startup: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LogQueryService) attachAnnotations(projectID uuid.UUID, logs []logs_core.LogItemDTO) error {
+	logIDs := make([]uuid.UUID, 0, len(logs))
+	for _, log := range logs {
+		if logID, err := uuid.Parse(log.ID); err == nil {
+			logIDs = append(logIDs, logID)
+		}
+	}
+
+	annotationsByLogID, err := s.annotationService.GetAnnotationsByLogIDs(projectID, logIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range logs {
+		logID, err := uuid.Parse(logs[i].ID)
+		if err != nil {
+			continue
+		}
+
+		if annotation, exists := annotationsByLogID[logID]; exists {
+			logs[i].Annotation = &logs_core.LogAnnotationDTO{
+				Note:       annotation.Note,
+				ReviewerID: annotation.ReviewerID,
+				ReviewedAt: annotation.ReviewedAt,
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCursorQuery checks queries continuing from a cursor. The cursor
+// already pins the position, so timeRange.to may be omitted to tail new logs
+func (s *LogQueryService) validateCursorQuery(request *logs_core.LogQueryRequestDTO) error {
+	if _, err := logs_core.DecodeLogCursor(request.After); err != nil {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidCursor,
+			Message: fmt.Sprintf("invalid cursor: %s", err.Error()),
+		}
+	}
+
+	if request.Offset > 0 {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "offset cannot be combined with after cursor",
+		}
+	}
+
+	timeRange := request.TimeRange
+	if timeRange != nil && timeRange.From != nil && timeRange.To != nil && timeRange.From.After(*timeRange.To) {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "timeRange.from must be before timeRange.to",
+		}
+	}
+
+	return nil
+}
+
+// validateResultWindow rejects pages beyond the result window up front, OpenSearch
+// would fail them with an error which does not tell how to read further
+func (s *LogQueryService) validateResultWindow(request *logs_core.LogQueryRequestDTO) error {
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultQueryPageSize
+	}
+
+	if request.Offset+limit <= s.maxResultWindow {
+		return nil
+	}
+
+	return &ValidationError{
+		Code: logs_core.ErrorResultWindowExceeded,
+		Message: fmt.Sprintf(
+			"offset plus limit must not exceed %d logs, to read further switch to cursor pagination: "+
+				"pass the cursor of the last page as \"after\" instead of an offset",
+			s.maxResultWindow,
+		),
+	}
+}
+
+// applyDefaultLookback bounds queries without timeRange.from to the default
+// lookback before timeRange.to, so a forgotten bound does not scan the whole
+// history. Cursor queries are bounded by the cursor itself and left as is
+func (s *LogQueryService) applyDefaultLookback(request *logs_core.LogQueryRequestDTO) *logs_core.TimeRangeDTO {
+	// Partitions bound the searched days themselves
+	if request.AllHistory || request.After != "" || len(request.Partitions) > 0 || s.defaultLookback <= 0 {
+		return nil
+	}
+
+	if request.TimeRange == nil || request.TimeRange.From != nil || request.TimeRange.To == nil {
+		return nil
+	}
+
+	from := request.TimeRange.To.Add(-s.defaultLookback)
+	request.TimeRange = &logs_core.TimeRangeDTO{From: &from, To: request.TimeRange.To}
+
+	return request.TimeRange
+}
+
+// queryCancelledError reports a query cancelled through the running queries
+// registry as such, other errors are returned as they are
+func queryCancelledError(queryCtx context.Context, err error) error {
+	if queryCtx.Err() != nil {
+		return &ValidationError{
+			Code:    logs_core.ErrorQueryCancelled,
+			Message: "the query was cancelled by an administrator",
+		}
+	}
+
+	return err
+}
+
+// getMaskedFields returns the fields hidden from the caller, owners and admins
+// see every field
+func (s *LogQueryService) getMaskedFields(
+	projectID uuid.UUID,
+	projectRole *users_enums.ProjectRole,
+) ([]string, error) {
+	if isProjectManagerRole(projectRole) {
+		return nil, nil
+	}
+
+	project, err := s.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project.MaskedFields, nil
+}
+
+// findMaskedField reports a condition on a masked field, otherwise the values
+// could be guessed through filters
+func findMaskedField(node *logs_core.QueryNode, maskedFields []string) (string, bool) {
+	if node == nil || len(maskedFields) == 0 {
+		return "", false
+	}
+
+	if node.Condition != nil {
+		for _, field := range getConditionFields(node.Condition) {
+			if slices.Contains(maskedFields, strings.TrimSpace(field)) {
+				return field, true
+			}
+		}
+	}
+
+	if node.Logic != nil {
+		for i := range node.Logic.Children {
+			if field, isMasked := findMaskedField(&node.Logic.Children[i], maskedFields); isMasked {
+				return field, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// getConditionFields returns the fields a condition filters on, missing_any and
+// missing_all list them in the value
+func getConditionFields(condition *logs_core.ConditionNode) []string {
+	if condition.Operator != logs_core.ConditionOperatorMissingAny &&
+		condition.Operator != logs_core.ConditionOperatorMissingAll {
+		return []string{condition.Field}
+	}
+
+	var fields []string
+	switch values := condition.Value.(type) {
+	case []string:
+		fields = values
+	case []any:
+		for _, value := range values {
+			fields = append(fields, fmt.Sprintf("%v", value))
+		}
+	}
+
+	return fields
+}
+
+func maskLogFields(logs []logs_core.LogItemDTO, maskedFields []string) {
+	if len(maskedFields) == 0 {
+		return
+	}
+
+	for _, log := range logs {
+		for _, field := range maskedFields {
+			if _, exists := log.Fields[field]; exists {
+				log.Fields[field] = maskedFieldValue
+			}
+		}
+	}
+}
+
+func isProjectManagerRole(role *users_enums.ProjectRole) bool {
+	return role != nil && (*role == users_enums.ProjectRoleOwner || *role == users_enums.ProjectRoleAdmin)
+}
+
+func validateComparisonRequest(request *CompareWindowsRequestDTO) error {
+	windowNames := []string{"before", "after"}
+	for i, window := range []logs_core.TimeRangeDTO{request.Before, request.After} {
+		name := windowNames[i]
+
+		if window.From == nil || window.To == nil {
+			return &ValidationError{
+				Code:    logs_core.ErrorInvalidQueryStructure,
+				Message: fmt.Sprintf("%s.from and %s.to are required", name, name),
+			}
+		}
+
+		if !window.From.Before(*window.To) {
+			return &ValidationError{
+				Code:    logs_core.ErrorInvalidQueryStructure,
+				Message: fmt.Sprintf("%s.from must be before %s.to", name, name),
+			}
+		}
+	}
+
+	if request.TopValuesLimit < 0 || request.TopValuesLimit > maxComparisonTopValues {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("topValuesLimit must be between 1 and %d", maxComparisonTopValues),
+		}
+	}
+
+	return validateGroupByField(request.GroupBy)
+}
+
+func validateGroupByRequest(request *GroupByRequestDTO) error {
+	if strings.TrimSpace(request.GroupBy) == "" {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "groupBy is required",
+		}
+	}
+
+	if request.Limit < 0 || request.Limit > maxGroupByLimit {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("limit must be between 1 and %d", maxGroupByLimit),
+		}
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultGroupByLimit
+	}
+
+	if request.Offset < 0 || request.Offset+limit > maxGroupByBuckets {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("offset plus limit must not exceed %d groups", maxGroupByBuckets),
+		}
+	}
+
+	return validateGroupByField(request.GroupBy)
+}
+
+func validateAggregateRequest(request *logs_core.AggregateRequestDTO) error {
+	if strings.TrimSpace(request.Field) == "" {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "field is required",
+		}
+	}
+
+	if request.TopN < 0 {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "topN must not be negative",
+		}
+	}
+
+	return validateGroupByField(request.Field)
+}
+
+func validateLatestPerGroupRequest(request *LatestPerGroupRequestDTO) (time.Duration, error) {
+	if strings.TrimSpace(request.GroupBy) == "" {
+		return 0, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "groupBy is required",
+		}
+	}
+
+	interval, err := time.ParseDuration(request.Interval)
+	if err != nil || interval < minLatestPerGroupInterval {
+		return 0, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("interval must be a duration of at least %s, e.g. 15m or 1h", minLatestPerGroupInterval),
+		}
+	}
+
+	if request.GroupsLimit < 0 || request.GroupsLimit > maxLatestPerGroupLimit {
+		return 0, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("groupsLimit must be between 1 and %d", maxLatestPerGroupLimit),
+		}
+	}
+
+	return interval, validateGroupByField(request.GroupBy)
+}
+
+// splitIntoBuckets splits the time range into buckets aligned to the interval,
+// the first and last bucket are cut to the range. The buckets and the logs
+// they return are bounded, so large ranges need a larger interval
+func splitIntoBuckets(
+	timeRange *logs_core.TimeRangeDTO,
+	interval time.Duration,
+	groupsLimit int,
+) ([]logs_core.TimeRangeDTO, error) {
+	if timeRange == nil || timeRange.From == nil || timeRange.To == nil {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "timeRange.from and timeRange.to are required",
+		}
+	}
+
+	from, to := timeRange.From.UTC(), timeRange.To.UTC()
+	maxBuckets := min(maxLatestPerGroupBuckets, maxLatestPerGroupLogs/groupsLimit)
+
+	buckets := make([]logs_core.TimeRangeDTO, 0)
+	for bucketStart := from; bucketStart.Before(to); {
+		bucketEnd := bucketStart.Truncate(interval).Add(interval)
+		if bucketEnd.After(to) {
+			bucketEnd = to
+		}
+
+		if len(buckets) == maxBuckets {
+			return nil, &ValidationError{
+				Code: logs_core.ErrorInvalidQueryStructure,
+				Message: fmt.Sprintf(
+					"the time range splits into more than %d buckets of %s with groupsLimit %d, "+
+						"use a larger interval, a shorter range or fewer groups",
+					maxBuckets, interval, groupsLimit,
+				),
+			}
+		}
+
+		buckets = append(buckets, logs_core.TimeRangeDTO{From: &bucketStart, To: &bucketEnd})
+		bucketStart = bucketEnd
+	}
+
+	return buckets, nil
+}
+
+// validateVolumeHistogramRequest checks the interval against the supported
+// ones and bounds the points of the histogram
+func validateVolumeHistogramRequest(interval string, from, to time.Time) error {
+	intervalDuration, isSupported := logs_core.VolumeHistogramIntervals[interval]
+	if !isSupported {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "interval must be one of 1m, 5m, 1h or 1d",
+		}
+	}
+
+	if !from.Before(to) {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "from must be before to",
+		}
+	}
+
+	pointsCount := (to.Sub(from.Truncate(intervalDuration)) + intervalDuration - 1) / intervalDuration
+	if pointsCount > maxVolumeHistogramPoints {
+		return &ValidationError{
+			Code: logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf(
+				"the time range splits into more than %d intervals of %s, use a larger interval or a shorter range",
+				maxVolumeHistogramPoints, interval,
+			),
+		}
+	}
+
+	return nil
+}
+
+// validateGroupByField rejects fields whose values are (nearly) unique per log
+func validateGroupByField(groupBy string) error {
+	switch strings.TrimSpace(groupBy) {
+	case "timestamp", "created_at", "id", "project_id":
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("cannot group by %s", groupBy),
+		}
+	}
+
+	return nil
+}
+
+func newWindowSummary(timeRange logs_core.TimeRangeDTO, total int64) WindowSummaryDTO {
+	return WindowSummaryDTO{
+		TimeRange:     timeRange,
+		Total:         total,
+		RatePerMinute: float64(total) / timeRange.To.Sub(*timeRange.From).Minutes(),
+	}
+}
+
+// withMinLevel combines the query with an IN condition on the levels at or
+// above minLevel
+func withMinLevel(query *logs_core.QueryNode, minLevel logs_core.LogLevel) (*logs_core.QueryNode, error) {
+	levels := logs_core.LevelsAtOrAbove(logs_core.NormalizeLogLevel(string(minLevel)))
+	if levels == nil {
+		return nil, &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("minLevel %q must be one of DEBUG, INFO, WARN, ERROR or FATAL", minLevel),
+		}
+	}
+
+	levelValues := make([]any, 0, len(levels))
+	for _, level := range levels {
+		levelValues = append(levelValues, string(level))
+	}
+
+	levelCondition := logs_core.QueryNode{
+		Type: logs_core.QueryNodeTypeCondition,
+		Condition: &logs_core.ConditionNode{
+			Field:    "level",
+			Operator: logs_core.ConditionOperatorIn,
+			Value:    levelValues,
+		},
+	}
+
+	if query == nil {
+		return &levelCondition, nil
+	}
+
+	return &logs_core.QueryNode{
+		Type: logs_core.QueryNodeTypeLogical,
+		Logic: &logs_core.LogicalNode{
+			Operator: logs_core.LogicalOperatorAnd,
+			Children: []logs_core.QueryNode{*query, levelCondition},
+		},
+	}, nil
+}
+
+// validatePartitions accepts distinct UTC dates in the YYYY-MM-DD form
+func validatePartitions(partitions []string) error {
+	if len(partitions) > maxQueryPartitions {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidPartition,
+			Message: fmt.Sprintf("a query can be restricted to at most %d partitions", maxQueryPartitions),
+		}
+	}
+
+	seenPartitions := make(map[string]bool, len(partitions))
+	for _, partition := range partitions {
+		if _, err := time.Parse(logs_core.PartitionDateLayout, partition); err != nil {
+			return &ValidationError{
+				Code:    logs_core.ErrorInvalidPartition,
+				Message: fmt.Sprintf("partition %q must be a date in the YYYY-MM-DD format", partition),
+			}
+		}
+
+		if seenPartitions[partition] {
+			return &ValidationError{
+				Code:    logs_core.ErrorInvalidPartition,
+				Message: fmt.Sprintf("partition %s is listed more than once", partition),
+			}
+		}
+		seenPartitions[partition] = true
 	}
 
 	return nil
@@ -0,0 +1,131 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AggregateByField_WithTopN_ReturnsMostFrequentValuesSortedByCount(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Aggregate Top N Test")
+	storeServiceGroupLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+
+	buckets := aggregateTestLogs(t, router, project.ID, &logs_core.AggregateRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Field:     "service",
+		TopN:      2,
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, []logs_core.AggregateBucketDTO{
+		{Value: "checkout", Count: 3},
+		{Value: "auth", Count: 2},
+	}, buckets)
+
+	// topN above the maximum is capped rather than rejected
+	buckets = aggregateTestLogs(t, router, project.ID, &logs_core.AggregateRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Field:     "service",
+		TopN:      100000,
+	}, owner.Token, http.StatusOK)
+
+	assert.Len(t, buckets, 3)
+}
+
+func Test_AggregateByField_ByLevel_ReturnsCountsPerLevel(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Aggregate Level Test")
+	CreateTestLogsWithMessages(t, router, project.ID, uniqueID,
+		[]string{"Request handled", "Request handled", "Request handled"}, logs_core.LogLevelInfo, nil)
+	CreateTestLogsWithMessages(t, router, project.ID, uniqueID,
+		[]string{"Request failed"}, logs_core.LogLevelError, nil)
+
+	to := time.Now().UTC().Add(time.Minute)
+	from := to.Add(-time.Hour)
+
+	buckets := aggregateTestLogs(t, router, project.ID, &logs_core.AggregateRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Field:     "level",
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, []logs_core.AggregateBucketDTO{
+		{Value: string(logs_core.LogLevelInfo), Count: 3},
+		{Value: string(logs_core.LogLevelError), Count: 1},
+	}, buckets)
+}
+
+func Test_AggregateByField_WhenOtherProjectHasSameValues_CountsOnlyProjectLogs(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Aggregate Isolation Test")
+	_, otherOwner, otherProject, _ := SetupBasicQueryTest(t, "Aggregate Isolation Other Test")
+
+	storeServiceGroupLogs(t, router, project.ID, uniqueID, owner.Token)
+	storeServiceGroupLogs(t, router, otherProject.ID, uniqueID, otherOwner.Token)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+	request := &logs_core.AggregateRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Field:     "service",
+	}
+
+	buckets := aggregateTestLogs(t, router, project.ID, request, owner.Token, http.StatusOK)
+	assert.Equal(t, []logs_core.AggregateBucketDTO{
+		{Value: "checkout", Count: 3},
+		{Value: "auth", Count: 2},
+		{Value: "payments", Count: 2},
+	}, buckets)
+
+	aggregateTestLogs(t, router, project.ID, request, otherOwner.Token, http.StatusForbidden)
+}
+
+func Test_AggregateByField_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Aggregate Invalid Test")
+
+	now := time.Now().UTC()
+	timeRange := &logs_core.TimeRangeDTO{To: &now}
+
+	invalidRequests := []*logs_core.AggregateRequestDTO{
+		{TimeRange: timeRange},
+		{TimeRange: timeRange, Field: "service", TopN: -1},
+		{TimeRange: timeRange, Field: "timestamp"},
+		{Field: "service"},
+	}
+
+	for _, request := range invalidRequests {
+		aggregateTestLogs(t, router, project.ID, request, owner.Token, http.StatusBadRequest)
+	}
+}
+
+func aggregateTestLogs(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	request *logs_core.AggregateRequestDTO,
+	token string,
+	expectedStatus int,
+) []logs_core.AggregateBucketDTO {
+	url := fmt.Sprintf("/api/v1/logs/%s/aggregate", projectID.String())
+
+	if expectedStatus != http.StatusOK {
+		test_utils.MakePostRequest(t, router, url, "Bearer "+token, request, expectedStatus)
+		return nil
+	}
+
+	var buckets []logs_core.AggregateBucketDTO
+	test_utils.MakePostRequestAndUnmarshal(t, router, url, "Bearer "+token, request, expectedStatus, &buckets)
+
+	return buckets
+}
@@ -0,0 +1,177 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_annotations "logbull/internal/features/logs/annotations"
+	logs_core "logbull/internal/features/logs/core"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AnnotateLogs_WhenQueryingWithAnnotations_AnnotationIsReturned(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Annotations Surface")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, nil)
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	logs := queryLogsWithAnnotations(t, router, project.ID, uniqueID, owner.Token, true)
+	assert.Len(t, logs, 2)
+	reviewedLogID := uuid.MustParse(logs[0].ID)
+
+	var annotateResponse logs_annotations.AnnotationsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/annotations/%s", project.ID.String()),
+		"Bearer "+owner.Token,
+		logs_annotations.AnnotateLogsRequestDTO{LogIDs: []uuid.UUID{reviewedLogID}, Note: "Known issue"},
+		http.StatusOK,
+		&annotateResponse,
+	)
+	assert.Len(t, annotateResponse.Annotations, 1)
+
+	logs = queryLogsWithAnnotations(t, router, project.ID, uniqueID, owner.Token, true)
+	for _, log := range logs {
+		if log.ID == reviewedLogID.String() {
+			assert.NotNil(t, log.Annotation)
+			assert.Equal(t, "Known issue", log.Annotation.Note)
+			assert.Equal(t, owner.UserID, *log.Annotation.ReviewerID)
+			assert.False(t, log.Annotation.ReviewedAt.IsZero())
+		} else {
+			assert.Nil(t, log.Annotation)
+		}
+	}
+
+	logs = queryLogsWithAnnotations(t, router, project.ID, uniqueID, owner.Token, false)
+	for _, log := range logs {
+		assert.Nil(t, log.Annotation, "Annotations should only be attached when requested")
+	}
+}
+
+func Test_AnnotateLogs_WhenAnnotatedAgain_AnnotationIsReplaced(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Annotations Replace")
+	logID := uuid.New()
+
+	annotateLogs(t, router, project.ID, owner.Token, []uuid.UUID{logID}, "First note", http.StatusOK)
+	annotateLogs(t, router, project.ID, owner.Token, []uuid.UUID{logID, logID}, "Second note", http.StatusOK)
+
+	var response logs_annotations.AnnotationsResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/annotations/%s?logIds=%s", project.ID.String(), logID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Annotations, 1)
+	assert.Equal(t, "Second note", response.Annotations[0].Note)
+}
+
+func Test_AnnotateLogs_WhenAnnotatedInOtherProject_AnnotationIsNotVisible(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Annotations Isolation")
+	otherProject, _ := projects_testing.CreateTestProjectWithToken(
+		"Annotations Other "+uniqueID[:8], owner.Token, router,
+	)
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 1, nil)
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	logs := queryLogsWithAnnotations(t, router, project.ID, uniqueID, owner.Token, true)
+	assert.Len(t, logs, 1)
+	logID := uuid.MustParse(logs[0].ID)
+
+	// Same log ID annotated under another project must not leak into this one
+	annotateLogs(t, router, otherProject.ID, owner.Token, []uuid.UUID{logID}, "Other project", http.StatusOK)
+
+	logs = queryLogsWithAnnotations(t, router, project.ID, uniqueID, owner.Token, true)
+	assert.Len(t, logs, 1)
+	assert.Nil(t, logs[0].Annotation)
+}
+
+func Test_AnnotateLogs_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router, _, project, _ := SetupBasicQueryTest(t, "Annotations Forbidden")
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	annotateLogs(t, router, project.ID, outsider.Token, []uuid.UUID{uuid.New()}, "Not mine", http.StatusForbidden)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/annotations/%s?logIds=%s", project.ID.String(), uuid.New().String()),
+		"Bearer "+outsider.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_DeleteAnnotation_WhenAnnotationExists_AnnotationRemoved(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Annotations Delete")
+	logID := uuid.New()
+
+	annotateLogs(t, router, project.ID, owner.Token, []uuid.UUID{logID}, "Reviewed", http.StatusOK)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/annotations/%s/%s", project.ID.String(), logID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+
+	var response logs_annotations.AnnotationsResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/annotations/%s?logIds=%s", project.ID.String(), logID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Empty(t, response.Annotations)
+}
+
+func annotateLogs(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	logIDs []uuid.UUID,
+	note string,
+	expectedStatus int,
+) {
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/annotations/%s", projectID.String()),
+		"Bearer "+token,
+		logs_annotations.AnnotateLogsRequestDTO{LogIDs: logIDs, Note: note},
+		expectedStatus,
+	)
+}
+
+func queryLogsWithAnnotations(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	uniqueID string,
+	token string,
+	includeAnnotations bool,
+) []logs_core.LogItemDTO {
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.IncludeAnnotations = includeAnnotations
+
+	response := ExecuteTestQuery(t, router, projectID, query, token, http.StatusOK)
+
+	return response.Logs
+}
@@ -0,0 +1,72 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithCaseInsensitiveContains_FindsUserAgentInAnyCase(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Case Insensitive Contains Test")
+
+	userAgents := []string{
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
+	}
+	logItems := CreateLogItemsWithMessages(uniqueID, userAgents, logs_core.LogLevelInfo, nil)
+	for i, userAgent := range userAgents {
+		logItems[i].Fields["userAgent"] = userAgent
+	}
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+
+	// Sent as raw JSON, so the flag is checked under the name the frontend uses
+	buildQuery := func(caseInsensitive *bool) map[string]any {
+		condition := map[string]any{"field": "userAgent", "operator": "contains", "value": "macintosh"}
+		if caseInsensitive != nil {
+			condition["caseInsensitive"] = *caseInsensitive
+		}
+
+		return map[string]any{
+			"query": map[string]any{
+				"type": "logical",
+				"logic": map[string]any{
+					"operator": "and",
+					"children": []any{
+						map[string]any{
+							"type":      "condition",
+							"condition": map[string]any{"field": "test_id", "operator": "equals", "value": uniqueID},
+						},
+						map[string]any{"type": "condition", "condition": condition},
+					},
+				},
+			},
+			"limit": 10,
+		}
+	}
+
+	executeQuery := func(query map[string]any) []string {
+		var response logs_core.LogQueryResponseDTO
+		test_utils.MakePostRequestAndUnmarshal(
+			t,
+			router,
+			fmt.Sprintf("/api/v1/logs/query/execute/%s", project.ID.String()),
+			"Bearer "+owner.Token,
+			query,
+			http.StatusOK,
+			&response,
+		)
+
+		return getMessages(response.Logs)
+	}
+
+	isCaseInsensitive, isCaseSensitive := true, false
+
+	assert.Empty(t, executeQuery(buildQuery(nil)), "Matching must stay case sensitive by default")
+	assert.Empty(t, executeQuery(buildQuery(&isCaseSensitive)))
+	assert.Equal(t, []string{userAgents[0]}, executeQuery(buildQuery(&isCaseInsensitive)))
+}
@@ -0,0 +1,114 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithCursorFromHistoricalPage_ReturnsOnlyNewerLogs(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Cursor Tail")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 3, map[string]any{"batch": "historical"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	historicalQuery := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	historicalResponse := ExecuteTestQuery(t, router, project.ID, historicalQuery, owner.Token, http.StatusOK)
+
+	assert.Len(t, historicalResponse.Logs, 3)
+	assert.NotEmpty(t, historicalResponse.Cursor)
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"batch": "live"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	liveQuery := buildCursorQuery(uniqueID, historicalResponse.Cursor, 50)
+	liveResponse := ExecuteTestQuery(t, router, project.ID, liveQuery, owner.Token, http.StatusOK)
+
+	assert.Len(t, liveResponse.Logs, 2)
+	for _, log := range liveResponse.Logs {
+		assert.Equal(t, "live", log.Fields["batch"])
+	}
+	assertNoDuplicateLogs(t, append(historicalResponse.Logs, liveResponse.Logs...))
+	assert.NotEqual(t, historicalResponse.Cursor, liveResponse.Cursor)
+}
+
+func Test_ExecuteQuery_WhenPagingWithCursor_ReturnsAllLogsWithoutGapsOrDuplicates(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Cursor Paging")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 5, nil)
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	// Start from the very beginning of time so the cursor walks over every log
+	cursor := logs_core.EncodeLogCursor(logs_core.LogCursor{TimestampNanos: 0, ID: "0"})
+
+	var collectedLogs []logs_core.LogItemDTO
+	for range 5 {
+		response := ExecuteTestQuery(t, router, project.ID, buildCursorQuery(uniqueID, cursor, 2), owner.Token, 200)
+		if len(response.Logs) == 0 {
+			break
+		}
+
+		collectedLogs = append(collectedLogs, response.Logs...)
+		cursor = response.Cursor
+	}
+
+	assert.Len(t, collectedLogs, 5)
+	assertNoDuplicateLogs(t, collectedLogs)
+	for i := 1; i < len(collectedLogs); i++ {
+		assert.False(t, collectedLogs[i].Timestamp.Before(collectedLogs[i-1].Timestamp),
+			"Logs continued from a cursor should be in ascending order")
+	}
+}
+
+func Test_ExecuteQuery_WithCursorAndNoNewLogs_ReturnsSameCursor(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Cursor Empty Page")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, nil)
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	historicalQuery := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	historicalResponse := ExecuteTestQuery(t, router, project.ID, historicalQuery, owner.Token, http.StatusOK)
+
+	liveQuery := buildCursorQuery(uniqueID, historicalResponse.Cursor, 50)
+	liveResponse := ExecuteTestQuery(t, router, project.ID, liveQuery, owner.Token, http.StatusOK)
+
+	assert.Empty(t, liveResponse.Logs)
+	assert.Equal(t, historicalResponse.Cursor, liveResponse.Cursor)
+}
+
+func Test_ExecuteQuery_WithInvalidCursor_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Cursor Invalid")
+
+	query := buildCursorQuery(uniqueID, "not a cursor", 50)
+	ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+}
+
+func Test_ExecuteQuery_WithCursorAndOffset_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Cursor With Offset")
+
+	cursor := logs_core.EncodeLogCursor(logs_core.LogCursor{TimestampNanos: 0, ID: "0"})
+	query := buildCursorQuery(uniqueID, cursor, 50)
+	query.Offset = 10
+
+	ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+}
+
+func buildCursorQuery(uniqueID, cursor string, limit int) *logs_core.LogQueryRequestDTO {
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.TimeRange = nil
+	query.After = cursor
+	query.Limit = limit
+
+	return query
+}
+
+func assertNoDuplicateLogs(t *testing.T, logs []logs_core.LogItemDTO) {
+	seenIDs := make(map[string]bool, len(logs))
+	for _, log := range logs {
+		assert.False(t, seenIDs[log.ID], "Log %s returned more than once", log.ID)
+		seenIDs[log.ID] = true
+	}
+}
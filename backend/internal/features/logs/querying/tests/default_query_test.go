@@ -0,0 +1,148 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+	projects_dto "logbull/internal/features/projects/dto"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PatchProject_WithDefaultQuery_DefaultQueryReturnedWithProject(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Default Query Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Errors",
+		Query: BuildCondition("test_id", "equals", uniqueID),
+	})
+
+	timeRangeMinutes := 60
+	patchProjectDefaultQuery(t, router, project.ID, owner.Token, &projects_dto.PatchProjectRequestDTO{
+		DefaultSavedQueryID:     &savedQuery.ID,
+		DefaultTimeRangeMinutes: &timeRangeMinutes,
+	}, http.StatusOK)
+
+	memberProject := getProject(t, router, project.ID, member.Token)
+	assert.Equal(t, &savedQuery.ID, memberProject.DefaultSavedQueryID)
+	assert.Equal(t, 60, memberProject.DefaultTimeRangeMinutes)
+
+	patchProjectDefaultQuery(t, router, project.ID, owner.Token, &projects_dto.PatchProjectRequestDTO{
+		DefaultSavedQueryID: &uuid.Nil,
+	}, http.StatusOK)
+
+	clearedProject := getProject(t, router, project.ID, member.Token)
+	assert.Nil(t, clearedProject.DefaultSavedQueryID)
+	assert.Equal(t, 60, clearedProject.DefaultTimeRangeMinutes)
+}
+
+func Test_PatchProject_WithSavedQueryOfAnotherProject_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Default Query Foreign Test")
+	otherProject, _ := projects_testing.CreateTestProjectWithToken(
+		"Default Query Other "+uniqueID[:8], owner.Token, router,
+	)
+
+	foreignQuery := createSavedQuery(t, router, otherProject.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Other project query",
+		Query: BuildCondition("test_id", "equals", uniqueID),
+	})
+	unknownQueryID := uuid.New()
+
+	for _, savedQueryID := range []*uuid.UUID{&foreignQuery.ID, &unknownQueryID} {
+		patchProjectDefaultQuery(t, router, project.ID, owner.Token, &projects_dto.PatchProjectRequestDTO{
+			DefaultSavedQueryID: savedQueryID,
+		}, http.StatusBadRequest)
+	}
+
+	assert.Nil(t, getProject(t, router, project.ID, owner.Token).DefaultSavedQueryID)
+}
+
+func Test_PatchProject_WithDefaultTimeRangeOutOfBounds_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Default Query Range Test")
+
+	for _, minutes := range []int{-1, 365*24*60 + 1} {
+		patchProjectDefaultQuery(t, router, project.ID, owner.Token, &projects_dto.PatchProjectRequestDTO{
+			DefaultTimeRangeMinutes: &minutes,
+		}, http.StatusBadRequest)
+	}
+}
+
+func Test_PatchProject_WithDefaultQueryAsProjectMember_ReturnsForbidden(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Default Query Member Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Member default",
+		Query: BuildCondition("test_id", "equals", uniqueID),
+	})
+
+	patchProjectDefaultQuery(t, router, project.ID, member.Token, &projects_dto.PatchProjectRequestDTO{
+		DefaultSavedQueryID: &savedQuery.ID,
+	}, http.StatusForbidden)
+}
+
+func Test_DeleteSavedQuery_WhenItIsProjectDefault_DefaultQueryCleared(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Default Query Delete Test")
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Removed default",
+		Query: BuildCondition("test_id", "equals", uniqueID),
+	})
+	patchProjectDefaultQuery(t, router, project.ID, owner.Token, &projects_dto.PatchProjectRequestDTO{
+		DefaultSavedQueryID: &savedQuery.ID,
+	}, http.StatusOK)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/saved-queries/%s/%s", project.ID.String(), savedQuery.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+
+	assert.Nil(t, getProject(t, router, project.ID, owner.Token).DefaultSavedQueryID)
+}
+
+func patchProjectDefaultQuery(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	request *projects_dto.PatchProjectRequestDTO,
+	expectedStatus int,
+) {
+	test_utils.MakePatchRequest(
+		t,
+		router,
+		"/api/v1/projects/"+projectID.String(),
+		"Bearer "+token,
+		request,
+		expectedStatus,
+	)
+}
+
+func getProject(t *testing.T, router *gin.Engine, projectID uuid.UUID, token string) *projects_models.Project {
+	var project projects_models.Project
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/"+projectID.String(),
+		"Bearer "+token,
+		http.StatusOK,
+		&project,
+	)
+
+	return &project
+}
@@ -0,0 +1,131 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithoutTimeRangeFrom_DefaultLookbackApplied(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Default Lookback Test")
+	storeRecentAndOldLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	to := time.Now().UTC()
+	query := &logs_core.LogQueryRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{To: &to},
+		Limit:     100,
+	}
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 1)
+	assert.Equal(t, "Recent log message", response.Logs[0].Message)
+
+	defaultLookback := logs_querying.GetLogQueryService().GetDefaultLookback()
+	assert.NotNil(t, response.AppliedTimeRange)
+	assert.WithinDuration(t, to.Add(-defaultLookback), *response.AppliedTimeRange.From, time.Second)
+	assert.WithinDuration(t, to, *response.AppliedTimeRange.To, time.Second)
+}
+
+func Test_ExecuteQuery_WithAllHistoryAsOwner_AllLogsReturned(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "All History Test")
+	storeRecentAndOldLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	to := time.Now().UTC()
+	query := &logs_core.LogQueryRequestDTO{
+		Query:      BuildCondition("test_id", "equals", uniqueID),
+		TimeRange:  &logs_core.TimeRangeDTO{To: &to},
+		Limit:      100,
+		AllHistory: true,
+	}
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 2)
+	assert.Nil(t, response.AppliedTimeRange)
+}
+
+func Test_ExecuteQuery_WithTimeRangeFrom_DefaultLookbackNotApplied(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Explicit From Test")
+	storeRecentAndOldLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	to := time.Now().UTC()
+	from := to.Add(-7 * 24 * time.Hour)
+	query := &logs_core.LogQueryRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Limit:     100,
+	}
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 2)
+	assert.Nil(t, response.AppliedTimeRange)
+}
+
+func Test_ExecuteQuery_WithAllHistoryAsProjectMember_ReturnsForbidden(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "All History Forbidden Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	to := time.Now().UTC()
+	query := &logs_core.LogQueryRequestDTO{
+		Query:      BuildCondition("test_id", "equals", uniqueID),
+		TimeRange:  &logs_core.TimeRangeDTO{To: &to},
+		Limit:      100,
+		AllHistory: true,
+	}
+
+	ExecuteTestQuery(t, router, project.ID, query, member.Token, http.StatusForbidden)
+}
+
+func storeRecentAndOldLogs(t *testing.T, router *gin.Engine, projectID uuid.UUID, uniqueID, token string) {
+	repository := logs_core.GetLogCoreRepository()
+	now := time.Now().UTC()
+	defaultLookback := logs_querying.GetLogQueryService().GetDefaultLookback()
+
+	storeLogEntriesWithTimestamp(t, repository, projectID, now.Add(-time.Hour), "Recent log message", uniqueID, nil)
+	storeLogEntriesWithTimestamp(
+		t,
+		repository,
+		projectID,
+		now.Add(-2*defaultLookback),
+		"Old log message",
+		uniqueID,
+		nil,
+	)
+
+	query := &logs_core.LogQueryRequestDTO{
+		Query:      BuildCondition("test_id", "equals", uniqueID),
+		TimeRange:  &logs_core.TimeRangeDTO{To: &now},
+		Limit:      100,
+		AllHistory: true,
+	}
+
+	startTime := time.Now()
+	for time.Since(startTime) < 10*time.Second {
+		if err := repository.ForceFlush(); err != nil {
+			t.Fatalf("Failed to flush logs: %v", err)
+		}
+
+		response := ExecuteTestQuery(t, router, projectID, query, token, http.StatusOK)
+		if len(response.Logs) >= 2 {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("Timed out waiting for logs to be indexed")
+}
@@ -0,0 +1,54 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DownloadQueryPage_WithMatchingLogs_ReturnsAttachmentWithJSONBody(t *testing.T) {
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Download Query Page Test", 3)
+
+	resp := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/query/download/%s", project.ID.String()),
+		Headers:        map[string]string{"Authorization": "Bearer " + owner.Token},
+		Body:           BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+		ExpectedStatus: http.StatusOK,
+	})
+
+	expectedDisposition := regexp.MustCompile(
+		fmt.Sprintf(`^attachment; filename="logs-%s-\d{8}T\d{6}Z\.json"$`, project.ID.String()),
+	)
+	assert.Regexp(t, expectedDisposition, resp.Headers.Get("Content-Disposition"))
+	assert.Contains(t, resp.Headers.Get("Content-Type"), "application/json")
+
+	var response logs_core.LogQueryResponseDTO
+	assert.NoError(t, json.Unmarshal(resp.Body, &response))
+	AssertQueryResponseValid(t, &response, 3)
+	AssertLogContainsUniqueID(t, response.Logs, uniqueID, 3)
+}
+
+func Test_DownloadQueryPage_WhenUserIsNotProjectMember_ReturnsForbiddenWithoutAttachment(t *testing.T) {
+	router, _, project, uniqueID := SetupTestProjectWithLogs(t, "Download Query Page Access Test", 1)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	resp := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/query/download/%s", project.ID.String()),
+		Headers:        map[string]string{"Authorization": "Bearer " + outsider.Token},
+		Body:           BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+		ExpectedStatus: http.StatusForbidden,
+	})
+
+	assert.Empty(t, resp.Headers.Get("Content-Disposition"))
+}
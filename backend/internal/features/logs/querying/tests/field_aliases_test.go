@@ -0,0 +1,113 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithFieldAlias_MatchesLogsStoredUnderRealField(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Field Alias Query Test")
+	configureFieldAliases(t, router, project, owner.Token, map[string]string{"customer": "user_id"})
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"user_id": "user_42"})
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 1, map[string]any{"user_id": "user_7"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	aliasQuery := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("customer", "equals", "user_42"),
+	)
+	aliasResponse := ExecuteTestQuery(t, router, project.ID, aliasQuery, owner.Token, http.StatusOK)
+
+	assert.Len(t, aliasResponse.Logs, 2)
+	for _, log := range aliasResponse.Logs {
+		assert.Equal(t, "user_42", log.Fields["user_id"])
+		assert.NotContains(t, log.Fields, "customer")
+	}
+
+	// Field lists of missing_any and missing_all resolve aliases as well
+	missingQuery := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("", "missing_any", []any{"customer"}),
+	)
+	missingResponse := ExecuteTestQuery(t, router, project.ID, missingQuery, owner.Token, http.StatusOK)
+	assert.Empty(t, missingResponse.Logs)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+	groups := groupTestLogs(t, router, project.ID, &logs_querying.GroupByRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		GroupBy:   "customer",
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, "customer", groups.GroupBy)
+	assert.Equal(t, []logs_querying.GroupCountDTO{
+		{Value: "user_42", Count: 2},
+		{Value: "user_7", Count: 1},
+	}, groups.Groups)
+}
+
+func Test_ExecuteQuery_WhenMemberFiltersByAliasOfMaskedField_ReturnsForbidden(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Field Alias Masked Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	updateData := getProjectForUpdate(t, router, project, owner.Token)
+	updateData.MaskedFields = []string{"email"}
+	updateData.FieldAliases = map[string]string{"contact": "email"}
+	projects_testing.UpdateProject(project, updateData, owner.Token, router)
+
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("contact", "equals", "user@example.com"),
+	)
+
+	ExecuteTestQuery(t, router, project.ID, query, member.Token, http.StatusForbidden)
+}
+
+func Test_UpdateProject_WithInvalidFieldAliases_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Field Alias Invalid Test")
+
+	invalidAliases := []map[string]string{
+		{"user": "user"},
+		{"user": "user_id", "account": "user"},
+		{"message": "text"},
+		{"user name": "user_id"},
+		{"user": ""},
+	}
+
+	for _, fieldAliases := range invalidAliases {
+		updateData := getProjectForUpdate(t, router, project, owner.Token)
+		updateData.FieldAliases = fieldAliases
+
+		test_utils.MakePutRequest(t, router, "/api/v1/projects/"+project.ID.String(), "Bearer "+owner.Token,
+			updateData, http.StatusBadRequest)
+	}
+}
+
+func configureFieldAliases(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	fieldAliases map[string]string,
+) {
+	updateData := getProjectForUpdate(t, router, project, token)
+	updateData.FieldAliases = fieldAliases
+
+	updatedProject := projects_testing.UpdateProject(project, updateData, token, router)
+	assert.Equal(t, fieldAliases, updatedProject.FieldAliases)
+}
@@ -0,0 +1,94 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_dto "logbull/internal/features/users/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WhenFieldIsEncrypted_StoredAsCiphertextAndReturnedDecrypted(t *testing.T) {
+	router, owner, project, uniqueID := setupFieldEncryptionTest(t, "Field Encryption Storage Test")
+
+	storedLogs, err := logs_core.GetLogCoreRepository().ExecuteQueryForProject(
+		project.ID,
+		BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+	)
+	assert.NoError(t, err)
+	assert.Len(t, storedLogs.Logs, 2)
+	for _, log := range storedLogs.Logs {
+		assert.True(t, strings.HasPrefix(log.Fields["email"].(string), logs_core.EncryptedValuePrefix))
+		assert.NotContains(t, log.Fields["email"], "user@example.com")
+		assert.Equal(t, "production", log.Fields["env"])
+	}
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 2)
+	for _, log := range response.Logs {
+		assert.Equal(t, "user@example.com", log.Fields["email"])
+		assert.Equal(t, "production", log.Fields["env"])
+	}
+}
+
+func Test_ExecuteQuery_WhenEqualsOnEncryptedField_ReturnsMatchingLogs(t *testing.T) {
+	router, owner, project, uniqueID := setupFieldEncryptionTest(t, "Field Encryption Equals Test")
+
+	query := BuildLogicalQuery(
+		"and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("email", "equals", "user@example.com"),
+	)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	assert.Len(t, response.Logs, 2)
+
+	query = BuildLogicalQuery(
+		"and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("email", "in", []any{"other@example.com"}),
+	)
+	response = ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	assert.Empty(t, response.Logs)
+}
+
+func Test_ExecuteQuery_WhenContainsOnEncryptedField_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := setupFieldEncryptionTest(t, "Field Encryption Contains Test")
+
+	query := BuildLogicalQuery(
+		"and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("email", "contains", "example"),
+	)
+
+	ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+}
+
+func setupFieldEncryptionTest(t *testing.T, testName string) (
+	*gin.Engine,
+	*users_dto.SignInResponseDTO,
+	*projects_models.Project,
+	string,
+) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, testName)
+
+	updateData := getProjectForUpdate(t, router, project, owner.Token)
+	updateData.EncryptedFields = []string{"email"}
+	updatedProject := projects_testing.UpdateProject(project, updateData, owner.Token, router)
+	assert.Equal(t, []string{"email"}, updatedProject.EncryptedFields)
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{
+		"email": "user@example.com",
+		"env":   "production",
+	})
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	return router, owner, project, uniqueID
+}
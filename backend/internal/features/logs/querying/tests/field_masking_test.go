@@ -0,0 +1,84 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_dto "logbull/internal/features/users/dto"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WhenUserIsProjectMember_MaskedFieldsHidden(t *testing.T) {
+	router, owner, project, uniqueID := setupFieldMaskingTest(t, "Field Masking Member Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, member.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 2)
+	for _, log := range response.Logs {
+		assert.Equal(t, "***", log.Fields["email"])
+		assert.Equal(t, "***", log.Fields["token"])
+		assert.Equal(t, "production", log.Fields["env"])
+	}
+}
+
+func Test_ExecuteQuery_WhenUserIsProjectAdmin_MaskedFieldsVisible(t *testing.T) {
+	router, owner, project, uniqueID := setupFieldMaskingTest(t, "Field Masking Admin Test")
+	admin := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, admin, users_enums.ProjectRoleAdmin, owner.Token, router)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, admin.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 2)
+	for _, log := range response.Logs {
+		assert.Equal(t, "user@example.com", log.Fields["email"])
+		assert.Equal(t, "secret-token", log.Fields["token"])
+		assert.Equal(t, "production", log.Fields["env"])
+	}
+}
+
+func Test_ExecuteQuery_WhenMemberFiltersByMaskedField_ReturnsForbidden(t *testing.T) {
+	router, owner, project, uniqueID := setupFieldMaskingTest(t, "Field Masking Filter Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	query := BuildLogicalQuery(
+		"and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("email", "equals", "user@example.com"),
+	)
+
+	ExecuteTestQuery(t, router, project.ID, query, member.Token, http.StatusForbidden)
+}
+
+func setupFieldMaskingTest(t *testing.T, testName string) (
+	*gin.Engine,
+	*users_dto.SignInResponseDTO,
+	*projects_models.Project,
+	string,
+) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, testName)
+
+	updateData := getProjectForUpdate(t, router, project, owner.Token)
+	updateData.MaskedFields = []string{"email", "token"}
+	updatedProject := projects_testing.UpdateProject(project, updateData, owner.Token, router)
+	assert.Equal(t, []string{"email", "token"}, updatedProject.MaskedFields)
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{
+		"email": "user@example.com",
+		"token": "secret-token",
+		"env":   "production",
+	})
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	return router, owner, project, uniqueID
+}
@@ -0,0 +1,91 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WithLowercasedFieldNames_CaseVariantsMergedAndQueryable(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Field Name Case Test")
+	configureFieldNamesLowercased(t, router, project, owner.Token, true)
+
+	logItems := logs_receiving_tests.CreateValidLogItems(3, uniqueID)
+	// The lowercase variant wins a collision
+	logItems[0].Fields["Status"] = "from_capitalized"
+	logItems[0].Fields["status"] = "from_lowercase"
+	logItems[0].Fields["RequestPath"] = "/orders"
+	// Without a lowercase variant the first name in sort order wins
+	logItems[1].Fields["Status"] = "from_capitalized"
+	logItems[1].Fields["STATUS"] = "from_uppercase"
+	logItems[2].Fields["STATUS"] = "only_variant"
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	statusByIndex := map[float64]any{}
+	response := ExecuteTestQuery(t, router, project.ID, BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+		owner.Token, http.StatusOK)
+	for _, log := range response.Logs {
+		assert.NotContains(t, log.Fields, "Status")
+		assert.NotContains(t, log.Fields, "STATUS")
+		assert.NotContains(t, log.Fields, "RequestPath")
+
+		logIndex, _ := log.Fields["log_index"].(float64)
+		statusByIndex[logIndex] = log.Fields["status"]
+	}
+
+	assert.Equal(t, map[float64]any{
+		1: "from_lowercase",
+		2: "from_uppercase",
+		3: "only_variant",
+	}, statusByIndex)
+
+	statusQuery := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("status", "equals", "only_variant"),
+	)
+	assert.Len(t, ExecuteTestQuery(t, router, project.ID, statusQuery, owner.Token, http.StatusOK).Logs, 1)
+
+	pathQuery := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("requestpath", "equals", "/orders"),
+	)
+	assert.Len(t, ExecuteTestQuery(t, router, project.ID, pathQuery, owner.Token, http.StatusOK).Logs, 1)
+}
+
+func Test_SubmitLogs_WithoutLowercasedFieldNames_FieldNameCaseKept(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Field Name Case Kept Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 1, map[string]any{
+		"Status": "from_capitalized",
+		"status": "from_lowercase",
+	})
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	response := ExecuteTestQuery(t, router, project.ID, BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+		owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 1)
+	assert.Equal(t, "from_capitalized", response.Logs[0].Fields["Status"])
+	assert.Equal(t, "from_lowercase", response.Logs[0].Fields["status"])
+}
+
+func configureFieldNamesLowercased(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	isFieldNamesLowercased bool,
+) {
+	updateData := getProjectForUpdate(t, router, project, token)
+	updateData.IsFieldNamesLowercased = isFieldNamesLowercased
+
+	updatedProject := projects_testing.UpdateProject(project, updateData, token, router)
+	assert.Equal(t, isFieldNamesLowercased, updatedProject.IsFieldNamesLowercased)
+}
@@ -0,0 +1,135 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DownloadFieldSchema_WithStoredLogs_ReturnsFieldsWithTypesAndCardinality(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Field Schema Test")
+
+	logItems := logs_receiving_tests.CreateValidLogItems(3, uniqueID)
+	customFields := []map[string]any{
+		{"user_id": "user_1", "duration_ms": 12.5, "is_retry": false, "order_ref": "A-1"},
+		{"user_id": "user_2", "duration_ms": 30, "is_retry": true, "order_ref": 42},
+		{"user_id": "user_1", "duration_ms": 7, "is_retry": false},
+	}
+	for i := range logItems {
+		for fieldName, fieldValue := range customFields[i] {
+			logItems[i].Fields[fieldName] = fieldValue
+		}
+	}
+
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, len(logItems), uniqueID, "Bearer "+owner.Token)
+
+	resp := downloadFieldSchema(t, router, project.ID.String(), owner.Token, http.StatusOK)
+
+	expectedDisposition := regexp.MustCompile(
+		fmt.Sprintf(`^attachment; filename="field-schema-%s-\d{8}T\d{6}Z\.json"$`, project.ID.String()),
+	)
+	assert.Regexp(t, expectedDisposition, resp.Headers.Get("Content-Disposition"))
+
+	var schema logs_core.FieldSchemaDTO
+	assert.NoError(t, json.Unmarshal(resp.Body, &schema))
+	assert.Equal(t, project.ID, schema.ProjectID)
+	assert.Equal(t, int64(3), schema.TotalLogs)
+	assert.Equal(t, int64(3), schema.SampledLogs)
+
+	fields := make(map[string]logs_core.FieldSchemaEntryDTO, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+
+	expectedFields := []logs_core.FieldSchemaEntryDTO{
+		{Name: "user_id", Type: logs_core.FieldTypeString, Cardinality: 2, Occurrences: 3},
+		{Name: "duration_ms", Type: logs_core.FieldTypeNumber, Cardinality: 3, Occurrences: 3},
+		{Name: "is_retry", Type: logs_core.FieldTypeBoolean, Cardinality: 2, Occurrences: 3},
+		{
+			Name:        "order_ref",
+			Type:        logs_core.FieldTypeMixed,
+			Types:       []string{logs_core.FieldTypeNumber, logs_core.FieldTypeString},
+			Cardinality: 2,
+			Occurrences: 2,
+		},
+		{Name: "test_id", Type: logs_core.FieldTypeString, Cardinality: 1, Occurrences: 3},
+		{Name: "log_index", Type: logs_core.FieldTypeNumber, Cardinality: 3, Occurrences: 3},
+	}
+	for _, expectedField := range expectedFields {
+		assert.Equal(t, expectedField, fields[expectedField.Name], "field %s", expectedField.Name)
+	}
+
+	for _, systemField := range []string{"message", "level", "timestamp", "project_id", "attrs_tokens"} {
+		assert.NotContains(t, fields, systemField)
+	}
+}
+
+func Test_DownloadFieldSchema_WithFieldsOfOtherProject_ReturnsOnlyOwnFields(t *testing.T) {
+	router, owner1, project1, uniqueID1 := SetupBasicQueryTest(t, "Field Schema Isolation Project 1")
+	_, owner2, project2, uniqueID2 := SetupBasicQueryTest(t, "Field Schema Isolation Project 2")
+
+	logItems1 := logs_receiving_tests.CreateValidLogItems(1, uniqueID1)
+	logItems1[0].Fields["project_one_field"] = "one"
+	SubmitLogsAndProcess(t, router, project1.ID, logItems1)
+
+	logItems2 := logs_receiving_tests.CreateValidLogItems(2, uniqueID2)
+	for i := range logItems2 {
+		logItems2[i].Fields["project_two_field"] = "two"
+	}
+	SubmitLogsAndProcess(t, router, project2.ID, logItems2)
+
+	WaitForLogsToBeIndexed(t, router, project1.ID, 1, uniqueID1, "Bearer "+owner1.Token)
+	WaitForLogsToBeIndexed(t, router, project2.ID, 2, uniqueID2, "Bearer "+owner2.Token)
+
+	resp := downloadFieldSchema(t, router, project1.ID.String(), owner1.Token, http.StatusOK)
+
+	var schema logs_core.FieldSchemaDTO
+	assert.NoError(t, json.Unmarshal(resp.Body, &schema))
+	assert.Equal(t, int64(1), schema.TotalLogs)
+
+	fieldNames := make([]string, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fieldNames = append(fieldNames, field.Name)
+		assert.LessOrEqual(t, field.Occurrences, int64(1), "field %s", field.Name)
+	}
+	assert.Contains(t, fieldNames, "project_one_field")
+	assert.NotContains(t, fieldNames, "project_two_field")
+
+	resp = downloadFieldSchema(t, router, project1.ID.String(), owner2.Token, http.StatusForbidden)
+	assert.Empty(t, resp.Headers.Get("Content-Disposition"))
+}
+
+func Test_DownloadFieldSchema_WithoutLogs_ReturnsEmptySchema(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Field Schema Empty Test")
+
+	resp := downloadFieldSchema(t, router, project.ID.String(), owner.Token, http.StatusOK)
+
+	var schema logs_core.FieldSchemaDTO
+	assert.NoError(t, json.Unmarshal(resp.Body, &schema))
+	assert.Equal(t, int64(0), schema.TotalLogs)
+	assert.Empty(t, schema.Fields)
+}
+
+func downloadFieldSchema(
+	t *testing.T,
+	router *gin.Engine,
+	projectID, userToken string,
+	expectedStatus int,
+) *test_utils.TestResponse {
+	return test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "GET",
+		URL:            fmt.Sprintf("/api/v1/logs/query/schema/%s", projectID),
+		Headers:        map[string]string{"Authorization": "Bearer " + userToken},
+		ExpectedStatus: expectedStatus,
+	})
+}
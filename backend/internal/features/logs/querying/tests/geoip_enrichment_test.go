@@ -0,0 +1,216 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	logs_receiving "logbull/internal/features/logs/receiving"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubGeoIPResolver struct {
+	locations map[string]logs_receiving.GeoLocation
+}
+
+func (r *stubGeoIPResolver) Resolve(ip net.IP) (*logs_receiving.GeoLocation, bool) {
+	location, exists := r.locations[ip.String()]
+	if !exists {
+		return nil, false
+	}
+
+	return &location, true
+}
+
+func Test_SubmitLogs_WithGeoIPResolver_EnrichmentFieldsAreQueryable(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "GeoIP Enrichment")
+
+	receivingService := logs_receiving.GetLogReceivingService()
+	receivingService.SetGeoIPResolver(&stubGeoIPResolver{
+		locations: map[string]logs_receiving.GeoLocation{
+			"203.0.113.10": {Country: "NL", City: "Amsterdam"},
+		},
+	})
+	defer receivingService.SetGeoIPResolver(nil)
+
+	submitLogsWithClientIP(t, router, project.ID, uniqueID, "203.0.113.10")
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery(logs_receiving.GeoCountryField, "equals", "NL")
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, 200)
+
+	AssertQueryResponseValid(t, response, 1)
+	AssertLogContainsUniqueID(t, response.Logs, uniqueID, 1)
+	assert.Equal(t, "NL", response.Logs[0].Fields[logs_receiving.GeoCountryField])
+	assert.Equal(t, "Amsterdam", response.Logs[0].Fields[logs_receiving.GeoCityField])
+}
+
+func Test_SubmitLogs_WithClientProvidedGeoFields_FieldsAreNotOverwritten(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "GeoIP No Overwrite")
+
+	receivingService := logs_receiving.GetLogReceivingService()
+	receivingService.SetGeoIPResolver(&stubGeoIPResolver{
+		locations: map[string]logs_receiving.GeoLocation{
+			"203.0.113.11": {Country: "NL", City: "Amsterdam"},
+		},
+	})
+	defer receivingService.SetGeoIPResolver(nil)
+
+	logItems := logs_receiving_tests.CreateValidLogItems(1, uniqueID)
+	logItems[0].Fields[logs_receiving.GeoCountryField] = "DE"
+	submitLogItemsWithClientIP(t, router, project.ID, logItems, "203.0.113.11")
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, 200)
+
+	AssertQueryResponseValid(t, response, 1)
+	assert.Equal(t, "DE", response.Logs[0].Fields[logs_receiving.GeoCountryField])
+	assert.Equal(t, "Amsterdam", response.Logs[0].Fields[logs_receiving.GeoCityField])
+}
+
+func Test_SubmitLogs_WithoutGeoIPResolver_NoEnrichmentFieldsAdded(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "GeoIP Disabled")
+
+	logs_receiving.GetLogReceivingService().SetGeoIPResolver(nil)
+
+	submitLogsWithClientIP(t, router, project.ID, uniqueID, "203.0.113.12")
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, 200)
+
+	AssertQueryResponseValid(t, response, 1)
+	assert.NotContains(t, response.Logs[0].Fields, logs_receiving.GeoCountryField)
+	assert.NotContains(t, response.Logs[0].Fields, logs_receiving.GeoCityField)
+}
+
+func Test_ParseMaxMindGeoIPDatabase_WithCityDatabase_JoinsBlocksWithLocations(t *testing.T) {
+	locations := strings.Join([]string{
+		"geoname_id,locale_code,continent_code,continent_name,country_iso_code,country_name,city_name,time_zone",
+		"4887398,en,NA,\"North America\",US,\"United States\",Chicago,America/Chicago",
+		"6252001,en,NA,\"North America\",US,\"United States\",,America/Chicago",
+		"2950159,en,EU,Europe,DE,Germany,Berlin,Europe/Berlin",
+	}, "\n")
+	ipv4Blocks := strings.Join([]string{
+		"network,geoname_id,registered_country_geoname_id,represented_country_geoname_id,is_anonymous_proxy",
+		"198.51.100.128/25,4887398,6252001,,0",
+		"198.51.100.0/25,,6252001,,0",
+		"203.0.113.0/24,,,,1",
+	}, "\n")
+	ipv6Blocks := strings.Join([]string{
+		"network,geoname_id,registered_country_geoname_id,represented_country_geoname_id,is_anonymous_proxy",
+		"2001:db8::/32,2950159,2950159,,0",
+	}, "\n")
+
+	resolver, err := logs_receiving.ParseMaxMindGeoIPDatabase(
+		strings.NewReader(locations),
+		strings.NewReader(ipv4Blocks),
+		strings.NewReader(ipv6Blocks),
+	)
+	assert.NoError(t, err)
+
+	location, found := resolver.Resolve(net.ParseIP("198.51.100.255"))
+	assert.True(t, found)
+	assert.Equal(t, "US", location.Country)
+	assert.Equal(t, "Chicago", location.City)
+
+	location, found = resolver.Resolve(net.ParseIP("198.51.100.5"))
+	assert.True(t, found)
+	assert.Equal(t, "US", location.Country)
+	assert.Empty(t, location.City)
+
+	location, found = resolver.Resolve(net.ParseIP("2001:db8:ffff::1"))
+	assert.True(t, found)
+	assert.Equal(t, "DE", location.Country)
+	assert.Equal(t, "Berlin", location.City)
+
+	_, found = resolver.Resolve(net.ParseIP("203.0.113.1"))
+	assert.False(t, found)
+
+	_, found = resolver.Resolve(net.ParseIP("192.0.2.1"))
+	assert.False(t, found)
+
+	_, found = resolver.Resolve(net.ParseIP("2001:db9::1"))
+	assert.False(t, found)
+}
+
+func Test_ParseMaxMindGeoIPDatabase_WithCountryDatabase_ResolvesCountryOnly(t *testing.T) {
+	locations := strings.Join([]string{
+		"geoname_id,locale_code,continent_code,continent_name,country_iso_code,country_name,is_in_european_union",
+		"2921044,en,EU,Europe,DE,Germany,1",
+	}, "\n")
+	ipv4Blocks := strings.Join([]string{
+		"network,geoname_id,registered_country_geoname_id,represented_country_geoname_id,is_anonymous_proxy",
+		"192.0.2.0/24,2921044,2921044,,0",
+	}, "\n")
+
+	resolver, err := logs_receiving.ParseMaxMindGeoIPDatabase(
+		strings.NewReader(locations),
+		strings.NewReader(ipv4Blocks),
+	)
+	assert.NoError(t, err)
+
+	location, found := resolver.Resolve(net.ParseIP("192.0.2.10"))
+	assert.True(t, found)
+	assert.Equal(t, "DE", location.Country)
+	assert.Empty(t, location.City)
+}
+
+func Test_ParseMaxMindGeoIPDatabase_WithOverlappingNetworks_ReturnsError(t *testing.T) {
+	locations := "geoname_id,country_iso_code,city_name\n6252001,US,"
+	ipv4Blocks := strings.Join([]string{
+		"network,geoname_id,registered_country_geoname_id",
+		"198.51.100.0/24,6252001,6252001",
+		"198.51.100.128/25,6252001,6252001",
+	}, "\n")
+
+	_, err := logs_receiving.ParseMaxMindGeoIPDatabase(
+		strings.NewReader(locations),
+		strings.NewReader(ipv4Blocks),
+	)
+	assert.Error(t, err)
+}
+
+func submitLogsWithClientIP(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	uniqueID string,
+	clientIP string,
+) {
+	logItems := logs_receiving_tests.CreateValidLogItems(1, uniqueID)
+	submitLogItemsWithClientIP(t, router, projectID, logItems, clientIP)
+}
+
+func submitLogItemsWithClientIP(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	logItems []logs_receiving.LogItemRequestDTO,
+	clientIP string,
+) {
+	resp := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/receiving/%s", projectID.String()),
+		Body:           &logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		Headers:        map[string]string{"X-Real-IP": clientIP},
+		ExpectedStatus: 202,
+	})
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	if err := json.Unmarshal(resp.Body, &response); err != nil {
+		t.Fatalf("Failed to unmarshal submit response: %v", err)
+	}
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GroupBy_WhenLogsHaveFieldValues_ReturnsGroupCountsSortedByCount(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Group By Counts Test")
+	storeServiceGroupLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+
+	response := groupTestLogs(t, router, project.ID, &logs_querying.GroupByRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		GroupBy:   "service",
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, "service", response.GroupBy)
+	assert.Equal(t, int64(8), response.Total)
+	assert.False(t, response.HasMore)
+	assert.Equal(t, []logs_querying.GroupCountDTO{
+		{Value: "checkout", Count: 3},
+		{Value: "auth", Count: 2},
+		{Value: "payments", Count: 2},
+	}, response.Groups)
+}
+
+func Test_GroupBy_WhenGroupsExceedLimit_PaginatesByGroup(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Group By Pagination Test")
+	storeServiceGroupLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+	request := &logs_querying.GroupByRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		GroupBy:   "service",
+		Limit:     2,
+	}
+
+	firstPage := groupTestLogs(t, router, project.ID, request, owner.Token, http.StatusOK)
+	assert.True(t, firstPage.HasMore)
+	assert.Equal(t, []logs_querying.GroupCountDTO{
+		{Value: "checkout", Count: 3},
+		{Value: "auth", Count: 2},
+	}, firstPage.Groups)
+
+	request.Offset = 2
+	secondPage := groupTestLogs(t, router, project.ID, request, owner.Token, http.StatusOK)
+	assert.False(t, secondPage.HasMore)
+	assert.Equal(t, 2, secondPage.Offset)
+	assert.Equal(t, []logs_querying.GroupCountDTO{{Value: "payments", Count: 2}}, secondPage.Groups)
+
+	request.Offset = 4
+	emptyPage := groupTestLogs(t, router, project.ID, request, owner.Token, http.StatusOK)
+	assert.False(t, emptyPage.HasMore)
+	assert.Empty(t, emptyPage.Groups)
+}
+
+func Test_GroupBy_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Group By Invalid Test")
+
+	now := time.Now().UTC()
+	timeRange := &logs_core.TimeRangeDTO{To: &now}
+
+	invalidRequests := []*logs_querying.GroupByRequestDTO{
+		{TimeRange: timeRange},
+		{TimeRange: timeRange, GroupBy: "timestamp"},
+		{GroupBy: "service"},
+		{TimeRange: timeRange, GroupBy: "service", Limit: 101},
+		{TimeRange: timeRange, GroupBy: "service", Offset: -1},
+		{TimeRange: timeRange, GroupBy: "service", Limit: 100, Offset: 901},
+	}
+
+	for _, request := range invalidRequests {
+		groupTestLogs(t, router, project.ID, request, owner.Token, http.StatusBadRequest)
+	}
+}
+
+func Test_GroupBy_ByLevel_ReturnsCountsPerLevel(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Group By Level Test")
+	CreateTestLogsWithMessages(t, router, project.ID, uniqueID,
+		[]string{"Request handled", "Request handled", "Request handled"}, logs_core.LogLevelInfo, nil)
+	CreateTestLogsWithMessages(t, router, project.ID, uniqueID,
+		[]string{"Request failed", "Request failed"}, logs_core.LogLevelError, nil)
+
+	to := time.Now().UTC().Add(time.Minute)
+	from := to.Add(-time.Hour)
+
+	response := groupTestLogs(t, router, project.ID, &logs_querying.GroupByRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		GroupBy:   "level",
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(5), response.Total)
+	assert.Equal(t, []logs_querying.GroupCountDTO{
+		{Value: string(logs_core.LogLevelInfo), Count: 3},
+		{Value: string(logs_core.LogLevelError), Count: 2},
+	}, response.Groups)
+}
+
+func Test_GroupBy_WhenOtherProjectHasSameValues_CountsOnlyProjectLogs(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Group By Isolation Test")
+	_, otherOwner, otherProject, _ := SetupBasicQueryTest(t, "Group By Isolation Other Test")
+
+	// Both projects get logs with the same test_id and service values
+	storeServiceGroupLogs(t, router, project.ID, uniqueID, owner.Token)
+	storeServiceGroupLogs(t, router, otherProject.ID, uniqueID, otherOwner.Token)
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+	request := &logs_querying.GroupByRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		GroupBy:   "service",
+	}
+
+	response := groupTestLogs(t, router, project.ID, request, owner.Token, http.StatusOK)
+	assert.Equal(t, int64(8), response.Total)
+	assert.Equal(t, []logs_querying.GroupCountDTO{
+		{Value: "checkout", Count: 3},
+		{Value: "auth", Count: 2},
+		{Value: "payments", Count: 2},
+	}, response.Groups)
+
+	groupTestLogs(t, router, project.ID, request, otherOwner.Token, http.StatusForbidden)
+}
+
+// storeServiceGroupLogs stores 3 checkout, 2 auth, 2 payments logs and one log
+// without the service field
+func storeServiceGroupLogs(t *testing.T, router *gin.Engine, projectID uuid.UUID, uniqueID, token string) {
+	repository := logs_core.GetLogCoreRepository()
+	timestamp := time.Now().UTC().Add(-10 * time.Minute)
+
+	services := []string{"checkout", "payments", "checkout", "auth", "payments", "auth", "checkout"}
+	for i, service := range services {
+		storeLogEntriesWithTimestamp(t, repository, projectID, timestamp.Add(time.Duration(i)*time.Second),
+			"Request handled", uniqueID, map[string]any{"service": service})
+	}
+	storeLogEntriesWithTimestamp(t, repository, projectID, timestamp, "Without service", uniqueID, nil)
+
+	waitForTimestampLogsIndexing(t, router, projectID, uniqueID, token)
+}
+
+func groupTestLogs(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	request *logs_querying.GroupByRequestDTO,
+	token string,
+	expectedStatus int,
+) *logs_querying.GroupByResponseDTO {
+	url := fmt.Sprintf("/api/v1/logs/query/group-by/%s", projectID.String())
+
+	if expectedStatus != http.StatusOK {
+		test_utils.MakePostRequest(t, router, url, "Bearer "+token, request, expectedStatus)
+		return nil
+	}
+
+	var response logs_querying.GroupByResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(t, router, url, "Bearer "+token, request, expectedStatus, &response)
+
+	return &response
+}
@@ -0,0 +1,128 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WhenInstanceSlotsAreBusy_ReturnsServiceUnavailableWithRetryAfter(t *testing.T) {
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Instance Query Limit Test", 1)
+	limiter := setInstanceQueryLimitForTest(t, 2, 100*time.Millisecond)
+
+	holdInstanceQuerySlots(t, limiter, 2)
+
+	resp := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/query/execute/%s", project.ID.String()),
+		Headers:        map[string]string{"Authorization": "Bearer " + owner.Token},
+		Body:           BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+		ExpectedStatus: http.StatusServiceUnavailable,
+	})
+
+	var errorResponse map[string]string
+	assert.NoError(t, json.Unmarshal(resp.Body, &errorResponse))
+	assert.Equal(t, logs_core.ErrorQueryCapacityExceeded, errorResponse["code"])
+	assert.Equal(t, "1", resp.Headers.Get("Retry-After"))
+	assert.Equal(t, 2, limiter.GetActiveQueryCount())
+}
+
+func Test_ExecuteQuery_WhenInstanceSlotFreesUp_QueryProceeds(t *testing.T) {
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Instance Query Slot Freed Test", 1)
+	limiter := setInstanceQueryLimitForTest(t, 2, 100*time.Millisecond)
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+
+	holdInstanceQuerySlots(t, limiter, 2)
+	ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusServiceUnavailable)
+
+	limiter.ReleaseSlot()
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	AssertQueryResponseValid(t, response, 1)
+	// The query released its slot, the other one is still held
+	assert.Equal(t, 1, limiter.GetActiveQueryCount())
+}
+
+func Test_ExecuteQuery_WhenSlotFreesUpWhileQueued_QueryWaitsAndProceeds(t *testing.T) {
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Instance Query Queue Test", 1)
+	limiter := setInstanceQueryLimitForTest(t, 1, 5*time.Second)
+
+	holdInstanceQuerySlots(t, limiter, 1)
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		limiter.ReleaseSlot()
+	}()
+
+	startedAt := time.Now()
+	response := ExecuteTestQuery(
+		t, router, project.ID, BuildSimpleConditionQuery("test_id", "equals", uniqueID), owner.Token, http.StatusOK,
+	)
+
+	AssertQueryResponseValid(t, response, 1)
+	assert.GreaterOrEqual(t, time.Since(startedAt), 200*time.Millisecond)
+	assert.Equal(t, 0, limiter.GetActiveQueryCount())
+}
+
+func Test_AcquireSlot_WhenLimitReached_NextAcquireIsRejectedUntilRelease(t *testing.T) {
+	limiter := logs_querying.NewInstanceQueryLimiter(3)
+	limiter.SetQueueTimeout(50 * time.Millisecond)
+
+	for range 3 {
+		assert.NoError(t, limiter.AcquireSlot())
+	}
+
+	err := limiter.AcquireSlot()
+	assert.Error(t, err)
+	validationErr, ok := err.(*logs_querying.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, logs_core.ErrorQueryCapacityExceeded, validationErr.Code)
+	assert.Equal(t, 3, limiter.GetActiveQueryCount())
+
+	limiter.ReleaseSlot()
+
+	assert.NoError(t, limiter.AcquireSlot())
+	assert.Equal(t, 3, limiter.GetActiveQueryCount())
+}
+
+func setInstanceQueryLimitForTest(
+	t *testing.T,
+	maxQueries int,
+	queueTimeout time.Duration,
+) *logs_querying.InstanceQueryLimiter {
+	limiter := logs_querying.GetInstanceQueryLimiter()
+
+	originalMaxQueries := limiter.GetMaxQueries()
+	originalQueueTimeout := limiter.GetQueueTimeout()
+	t.Cleanup(func() {
+		limiter.SetMaxQueries(originalMaxQueries)
+		limiter.SetQueueTimeout(originalQueueTimeout)
+	})
+
+	limiter.SetMaxQueries(maxQueries)
+	limiter.SetQueueTimeout(queueTimeout)
+
+	return limiter
+}
+
+// holdInstanceQuerySlots takes slots as if queries were running, the slots
+// still held when the test ends are released
+func holdInstanceQuerySlots(t *testing.T, limiter *logs_querying.InstanceQueryLimiter, count int) {
+	for range count {
+		assert.NoError(t, limiter.AcquireSlot())
+	}
+
+	t.Cleanup(func() {
+		for limiter.GetActiveQueryCount() > 0 {
+			limiter.ReleaseSlot()
+		}
+	})
+}
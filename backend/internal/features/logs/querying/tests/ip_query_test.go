@@ -93,6 +93,69 @@ func Test_ExecuteQuery_FilterByClientIPContains_ReturnsMatchingLogs(t *testing.T
 	assert.Equal(t, 2, len(queryResponse.Logs), "Expected exactly 2 logs with client_ip containing %s", ipPattern)
 }
 
+func Test_ExecuteQuery_FilterByIPv6ClientIPEquals_MatchesAnyNotationOfAddress(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("IPv6 Equals Test %s", uniqueID[:8])
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	// Stored in the canonical notation regardless of how the client sent it
+	submitLogWithIP(t, router, project.ID, "2001:DB8:0:0:0:0:0:1", uniqueID, "Request from IPv6 client", "target_ip")
+	submitLogWithIP(t, router, project.ID, "2001:db8::2", uniqueID, "Request from other IPv6 client", "other_ip")
+	submitLogWithIP(t, router, project.ID, "192.168.1.100", uniqueID, "Request from IPv4 client", "other_ip")
+
+	workerService := logs_receiving.GetLogWorkerService()
+	if err := workerService.ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	for _, queriedIP := range []string{"2001:db8::1", "2001:0DB8::0001", "2001:db8:0:0::1"} {
+		query := BuildSimpleConditionQuery("client_ip", "equals", queriedIP)
+		queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+		assertAllLogsHaveClientIP(t, queryResponse.Logs, "2001:db8::1")
+		assert.Equal(t, 1, len(queryResponse.Logs), "Expected exactly 1 log with client_ip %s", queriedIP)
+	}
+
+	notEqualsQuery := BuildSimpleConditionQuery("client_ip", "not_equals", "2001:DB8::1")
+	notEqualsResponse := ExecuteTestQuery(t, router, project.ID, notEqualsQuery, owner.Token, http.StatusOK)
+
+	assert.Equal(t, 2, len(notEqualsResponse.Logs))
+	for _, log := range notEqualsResponse.Logs {
+		assert.NotEqual(t, "2001:db8::1", log.ClientIP)
+	}
+}
+
+func Test_ExecuteQuery_FilterByIPv6ClientIPContains_ReturnsLogsFromPrefix(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("IPv6 Contains Test %s", uniqueID[:8])
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	for i, clientIP := range []string{"2001:db8:abcd::10", "2001:db8:abcd::20", "2001:db8:ffff::10", "10.0.0.1"} {
+		submitLogWithIP(t, router, project.ID, clientIP, uniqueID, fmt.Sprintf("IPv6 request %d", i+1), "ipv6")
+	}
+
+	workerService := logs_receiving.GetLogWorkerService()
+	if err := workerService.ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	WaitForLogsToBeIndexed(t, router, project.ID, 4, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("client_ip", "contains", "2001:DB8:ABCD:")
+	queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assertAllLogsContainIPPattern(t, queryResponse.Logs, "2001:db8:abcd:")
+	assert.Equal(t, 2, len(queryResponse.Logs), "Expected exactly 2 logs from 2001:db8:abcd::/48")
+}
+
 func submitLogWithIP(
 	t *testing.T,
 	router *gin.Engine,
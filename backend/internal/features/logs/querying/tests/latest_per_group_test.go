@@ -0,0 +1,164 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LatestPerGroup_WhenGroupsLogInSeveralHours_ReturnsLatestLogPerGroupPerHour(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Latest Per Group Test")
+	repository := logs_core.GetLogCoreRepository()
+
+	to := time.Now().UTC().Truncate(time.Hour)
+	from := to.Add(-2 * time.Hour)
+	firstHour, secondHour := from, from.Add(time.Hour)
+
+	statusLogs := []struct {
+		timestamp time.Time
+		service   string
+		status    string
+	}{
+		{firstHour.Add(10 * time.Minute), "api", "ok"},
+		{firstHour.Add(40 * time.Minute), "api", "degraded"},
+		{firstHour.Add(20 * time.Minute), "db", "ok"},
+		{secondHour.Add(5 * time.Minute), "api", "down"},
+		{secondHour.Add(15 * time.Minute), "worker", "starting"},
+		{secondHour.Add(50 * time.Minute), "worker", "ok"},
+		// Outside of the time range
+		{to.Add(time.Minute), "api", "ok"},
+	}
+	for _, statusLog := range statusLogs {
+		storeLogEntriesWithTimestamp(t, repository, project.ID, statusLog.timestamp, "Status reported", uniqueID,
+			map[string]any{"service": statusLog.service, "status": statusLog.status})
+	}
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	response := latestPerGroupTestLogs(t, router, project.ID, &logs_querying.LatestPerGroupRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		GroupBy:   "service",
+		Interval:  "1h",
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, "service", response.GroupBy)
+	assert.Len(t, response.Buckets, 2)
+
+	assert.True(t, firstHour.Equal(*response.Buckets[0].TimeRange.From))
+	assert.True(t, secondHour.Equal(*response.Buckets[0].TimeRange.To))
+	assert.Equal(t, map[string]any{"api": "degraded", "db": "ok"}, latestStatuses(response.Buckets[0]))
+	assert.Equal(t, []int64{2, 1}, groupCounts(response.Buckets[0]))
+
+	assert.True(t, secondHour.Equal(*response.Buckets[1].TimeRange.From))
+	assert.True(t, to.Equal(*response.Buckets[1].TimeRange.To))
+	assert.Equal(t, map[string]any{"api": "down", "worker": "ok"}, latestStatuses(response.Buckets[1]))
+	assert.Equal(t, []int64{1, 2}, groupCounts(response.Buckets[1]))
+
+	for _, bucket := range response.Buckets {
+		for _, group := range bucket.Groups {
+			assert.False(t, group.Log.Timestamp.Before(*bucket.TimeRange.From))
+			assert.True(t, group.Log.Timestamp.Before(*bucket.TimeRange.To))
+		}
+	}
+}
+
+func Test_LatestPerGroup_WhenRangeIsNotAlignedOrHasGaps_BucketsCutAndEmptyOnesKept(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Latest Per Group Buckets Test")
+	repository := logs_core.GetLogCoreRepository()
+
+	hour := time.Now().UTC().Truncate(time.Hour).Add(-3 * time.Hour)
+	from := hour.Add(30 * time.Minute)
+	to := hour.Add(2*time.Hour + 30*time.Minute)
+
+	storeLogEntriesWithTimestamp(t, repository, project.ID, hour.Add(45*time.Minute), "Status reported", uniqueID,
+		map[string]any{"service": "api", "status": "ok"})
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	response := latestPerGroupTestLogs(t, router, project.ID, &logs_querying.LatestPerGroupRequestDTO{
+		Query:     BuildCondition("test_id", "equals", uniqueID),
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		GroupBy:   "service",
+		Interval:  "1h",
+	}, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Buckets, 3)
+	assert.True(t, from.Equal(*response.Buckets[0].TimeRange.From))
+	assert.True(t, hour.Add(time.Hour).Equal(*response.Buckets[0].TimeRange.To))
+	assert.Equal(t, map[string]any{"api": "ok"}, latestStatuses(response.Buckets[0]))
+
+	assert.Empty(t, response.Buckets[1].Groups)
+	assert.Empty(t, response.Buckets[2].Groups)
+	assert.True(t, to.Equal(*response.Buckets[2].TimeRange.To))
+}
+
+func Test_LatestPerGroup_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Latest Per Group Invalid Test")
+
+	to := time.Now().UTC()
+	from := to.Add(-5 * time.Hour)
+	timeRange := &logs_core.TimeRangeDTO{From: &from, To: &to}
+
+	invalidRequests := []*logs_querying.LatestPerGroupRequestDTO{
+		{TimeRange: timeRange, Interval: "1h"},
+		{TimeRange: timeRange, GroupBy: "id", Interval: "1h"},
+		{TimeRange: timeRange, GroupBy: "service"},
+		{TimeRange: timeRange, GroupBy: "service", Interval: "30s"},
+		{GroupBy: "service", Interval: "1h"},
+		{TimeRange: timeRange, GroupBy: "service", Interval: "1h", GroupsLimit: 101},
+		// 300 buckets exceed the bucket limit, 20 buckets of 100 groups the logs limit
+		{TimeRange: timeRange, GroupBy: "service", Interval: "1m"},
+		{TimeRange: timeRange, GroupBy: "service", Interval: "15m", GroupsLimit: 100},
+	}
+
+	for _, request := range invalidRequests {
+		latestPerGroupTestLogs(t, router, project.ID, request, owner.Token, http.StatusBadRequest)
+	}
+}
+
+func latestStatuses(bucket logs_querying.LatestPerGroupBucketDTO) map[string]any {
+	statuses := map[string]any{}
+	for _, group := range bucket.Groups {
+		statuses[group.Value] = group.Log.Fields["status"]
+	}
+
+	return statuses
+}
+
+func groupCounts(bucket logs_querying.LatestPerGroupBucketDTO) []int64 {
+	counts := make([]int64, 0, len(bucket.Groups))
+	for _, group := range bucket.Groups {
+		counts = append(counts, group.Count)
+	}
+
+	return counts
+}
+
+func latestPerGroupTestLogs(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	request *logs_querying.LatestPerGroupRequestDTO,
+	token string,
+	expectedStatus int,
+) *logs_querying.LatestPerGroupResponseDTO {
+	url := fmt.Sprintf("/api/v1/logs/query/latest-per-group/%s", projectID.String())
+
+	if expectedStatus != http.StatusOK {
+		test_utils.MakePostRequest(t, router, url, "Bearer "+token, request, expectedStatus)
+		return nil
+	}
+
+	var response logs_querying.LatestPerGroupResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(t, router, url, "Bearer "+token, request, expectedStatus, &response)
+
+	return &response
+}
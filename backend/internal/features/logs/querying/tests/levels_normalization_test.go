@@ -0,0 +1,168 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_FilterByLevelInMixedCase_MatchesCanonicalStoredLevel(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("Level Normalization Test %s", uniqueID[:8])
+
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	submitLogsWithEachLevel(t, router, project.ID, uniqueID)
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	queriedLevels := map[string][]string{
+		"DEBUG": {"debug", "Debug", "dEbUg"},
+		"INFO":  {"info", "Info", " INFO "},
+		"WARN":  {"warn", "Warn", "warning", "Warning", "WARNING"},
+		"ERROR": {"error", "Error", "eRRor"},
+		"FATAL": {"fatal", "Fatal", "fAtAl"},
+	}
+
+	for canonicalLevel, values := range queriedLevels {
+		for _, value := range values {
+			query := BuildSimpleConditionQuery("level", "equals", value)
+			queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+			assertAllLogsHaveLevel(t, queryResponse.Logs, canonicalLevel)
+			assert.Equal(t, 1, len(queryResponse.Logs),
+				"Expected level %q to match exactly one %s log", value, canonicalLevel)
+		}
+	}
+}
+
+func Test_ExecuteQuery_FilterByLevelInListWithMixedCase_MatchesCanonicalStoredLevels(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("Level In Normalization Test %s", uniqueID[:8])
+
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	submitLogsWithEachLevel(t, router, project.ID, uniqueID)
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	from := time.Now().UTC().Add(-2 * time.Hour)
+	to := time.Now().UTC()
+	query := &logs_core.LogQueryRequestDTO{
+		Query: &logs_core.QueryNode{
+			Type: logs_core.QueryNodeTypeCondition,
+			Condition: &logs_core.ConditionNode{
+				Field:    "level",
+				Operator: logs_core.ConditionOperatorIn,
+				Value:    []string{"warning", "Error"},
+			},
+		},
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Limit:     50,
+		SortBy:    "timestamp",
+		SortOrder: "desc",
+	}
+
+	queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	levels := make([]string, 0, len(queryResponse.Logs))
+	for _, log := range queryResponse.Logs {
+		levels = append(levels, log.Level)
+	}
+	assert.ElementsMatch(t, []string{"WARN", "ERROR"}, levels)
+
+	notEqualsQuery := BuildSimpleConditionQuery("level", "not_equals", "debug")
+	notEqualsResponse := ExecuteTestQuery(t, router, project.ID, notEqualsQuery, owner.Token, http.StatusOK)
+
+	assert.Equal(t, 4, len(notEqualsResponse.Logs))
+	for _, log := range notEqualsResponse.Logs {
+		assert.NotEqual(t, "DEBUG", log.Level)
+	}
+}
+
+func Test_SubmitLogs_WithLowercaseLevels_StoresCanonicalLevels(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("Level Ingest Normalization Test %s", uniqueID[:8])
+
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	submittedLevels := []logs_core.LogLevel{"error", "Warning", "info"}
+	logItems := make([]logs_receiving.LogItemRequestDTO, 0, len(submittedLevels))
+	for _, level := range submittedLevels {
+		logItems = append(logItems, logs_receiving.LogItemRequestDTO{
+			Level:   level,
+			Message: fmt.Sprintf("%s message %s", level, uniqueID),
+			Fields:  map[string]any{"test_id": uniqueID},
+		})
+	}
+
+	submitLevelTestLogs(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, len(submittedLevels), uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	levels := make([]string, 0, len(queryResponse.Logs))
+	for _, log := range queryResponse.Logs {
+		levels = append(levels, log.Level)
+	}
+	assert.ElementsMatch(t, []string{"ERROR", "WARN", "INFO"}, levels)
+}
+
+func submitLogsWithEachLevel(t *testing.T, router *gin.Engine, projectID uuid.UUID, uniqueID string) {
+	allLevels := []logs_core.LogLevel{
+		logs_core.LogLevelDebug,
+		logs_core.LogLevelInfo,
+		logs_core.LogLevelWarn,
+		logs_core.LogLevelError,
+		logs_core.LogLevelFatal,
+	}
+
+	logItems := make([]logs_receiving.LogItemRequestDTO, 0, len(allLevels))
+	for _, level := range allLevels {
+		logItems = append(logItems, logs_receiving.LogItemRequestDTO{
+			Level:   level,
+			Message: fmt.Sprintf("%s message %s", level, uniqueID),
+			Fields:  map[string]any{"test_id": uniqueID},
+		})
+	}
+
+	submitLevelTestLogs(t, router, projectID, logItems)
+}
+
+func submitLevelTestLogs(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	logItems []logs_receiving.LogItemRequestDTO,
+) {
+	test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/receiving/%s", projectID.String()),
+		Body:           &logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		ExpectedStatus: 202,
+	})
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+}
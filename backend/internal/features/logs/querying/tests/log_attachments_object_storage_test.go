@@ -0,0 +1,190 @@
+package logs_querying_tests
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	logs_attachments "logbull/internal/features/logs/attachments"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	s3_utils "logbull/internal/util/s3"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAttachmentsBucket = "attachments"
+
+func Test_SubmitLogs_WithObjectStorage_FieldOffloadedToBucketAndRetrievable(t *testing.T) {
+	bucket := useFakeAttachmentsBucket(t)
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Attachment Object Storage Test")
+	configureAttachmentThreshold(t, router, project, owner.Token, 1)
+
+	requestBody := strings.Repeat(`{"item":"value","quantity":1}`, 3000)
+	logItems := logs_receiving_tests.CreateValidLogItems(1, uniqueID)
+	logItems[0].Fields["request_body"] = requestBody
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	assert.Len(t, response.Logs, 1)
+
+	reference, _ := response.Logs[0].Fields["request_body"].(string)
+	assert.True(t, strings.HasPrefix(reference, logs_attachments.ReferencePrefix))
+
+	hash := strings.TrimPrefix(reference, logs_attachments.ReferencePrefix)
+	objectKey := project.ID.String() + "/" + hash
+	assert.Equal(t, requestBody, bucket.getObject(objectKey))
+	assert.True(t, bucket.isEverythingSigned())
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/attachments/%s/%s", project.ID.String(), hash),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+	assert.Equal(t, requestBody, string(resp.Body))
+}
+
+func Test_DeleteProjectAttachments_WithObjectStorage_RemovesOnlyProjectObjects(t *testing.T) {
+	bucket := useFakeAttachmentsBucket(t)
+	attachmentService := logs_attachments.GetAttachmentService()
+	projectID := uuid.New()
+	otherProjectID := uuid.New()
+
+	for i := range 3 {
+		fields := map[string]any{"payload": strings.Repeat(fmt.Sprintf("value %d ", i), 200)}
+		assert.NoError(t, attachmentService.OffloadLargeFields(projectID, 1024, fields))
+	}
+	otherFields := map[string]any{"payload": strings.Repeat("other ", 400)}
+	assert.NoError(t, attachmentService.OffloadLargeFields(otherProjectID, 1024, otherFields))
+
+	assert.NoError(t, attachmentService.DeleteProjectAttachments(projectID))
+
+	keys := bucket.getKeys()
+	assert.Len(t, keys, 1)
+	assert.True(t, strings.HasPrefix(keys[0], otherProjectID.String()+"/"))
+
+	otherReference, _ := otherFields["payload"].(string)
+	storage := attachmentService.GetAttachmentStorage()
+	_, err := storage.Get(projectID, strings.Repeat("0", 64))
+	assert.ErrorIs(t, err, logs_attachments.ErrAttachmentNotFound)
+	_, err = storage.Get(otherProjectID, strings.TrimPrefix(otherReference, logs_attachments.ReferencePrefix))
+	assert.NoError(t, err)
+}
+
+// fakeAttachmentsBucket is an in memory S3 bucket supporting the requests the
+// attachment storage makes
+type fakeAttachmentsBucket struct {
+	mu              sync.Mutex
+	objects         map[string]string
+	unsignedRequest bool
+}
+
+func useFakeAttachmentsBucket(t *testing.T) *fakeAttachmentsBucket {
+	bucket := &fakeAttachmentsBucket{objects: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(bucket.handle))
+
+	attachmentService := logs_attachments.GetAttachmentService()
+	previousStorage := attachmentService.GetAttachmentStorage()
+	attachmentService.SetAttachmentStorage(logs_attachments.NewS3AttachmentStorage(
+		s3_utils.NewClient(server.Client(), server.URL, "", "test-access-key", "test-secret-key"),
+		testAttachmentsBucket,
+	))
+
+	t.Cleanup(func() {
+		attachmentService.SetAttachmentStorage(previousStorage)
+		server.Close()
+	})
+
+	return bucket
+}
+
+func (b *fakeAttachmentsBucket) handle(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-access-key/") {
+		b.unsignedRequest = true
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	bucketPath := "/" + testAttachmentsBucket
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, bucketPath), "/")
+
+	switch {
+	case r.Method == http.MethodGet && key == "" && r.URL.Query().Get("list-type") == "2":
+		b.writeList(w, r.URL.Query().Get("prefix"))
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		b.objects[key] = string(body)
+	case r.Method == http.MethodGet:
+		object, exists := b.objects[key]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(object))
+	case r.Method == http.MethodDelete:
+		delete(b.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *fakeAttachmentsBucket) writeList(w http.ResponseWriter, prefix string) {
+	type listedObject struct {
+		Key string `xml:"Key"`
+	}
+	listResponse := struct {
+		XMLName  xml.Name       `xml:"ListBucketResult"`
+		Contents []listedObject `xml:"Contents"`
+	}{}
+
+	for key := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			listResponse.Contents = append(listResponse.Contents, listedObject{Key: key})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(listResponse)
+}
+
+func (b *fakeAttachmentsBucket) getObject(key string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.objects[key]
+}
+
+func (b *fakeAttachmentsBucket) getKeys() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	keys := make([]string, 0, len(b.objects))
+	for key := range b.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (b *fakeAttachmentsBucket) isEverythingSigned() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !b.unsignedRequest
+}
@@ -0,0 +1,111 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	logs_attachments "logbull/internal/features/logs/attachments"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WithFieldAboveAttachmentThreshold_FieldOffloadedAndRetrievable(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Attachment Offload Test")
+	configureAttachmentThreshold(t, router, project, owner.Token, 1)
+
+	// Larger than the 64 KB log size limit, accepted only because it is offloaded
+	stackTrace := strings.Repeat("at com.example.Service.method(Service.java:42)\n", 2000)
+	logItems := logs_receiving_tests.CreateValidLogItems(1, uniqueID)
+	logItems[0].Fields["stack"] = stackTrace
+	logItems[0].Fields["note"] = "short value"
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	assert.Len(t, response.Logs, 1)
+
+	reference, ok := response.Logs[0].Fields["stack"].(string)
+	assert.True(t, ok)
+	assert.True(t, strings.HasPrefix(reference, logs_attachments.ReferencePrefix))
+	assert.Equal(t, "short value", response.Logs[0].Fields["note"])
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/logs/attachments/%s/%s",
+			project.ID.String(),
+			strings.TrimPrefix(reference, logs_attachments.ReferencePrefix),
+		),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+	assert.Equal(t, stackTrace, string(resp.Body))
+}
+
+func Test_SubmitLogs_WithoutAttachmentThreshold_FieldsKeptInline(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Attachment Disabled Test")
+
+	requestBody := strings.Repeat("x", 4*1024)
+	logItems := logs_receiving_tests.CreateValidLogItems(1, uniqueID)
+	logItems[0].Fields["request_body"] = requestBody
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 1)
+	assert.Equal(t, requestBody, response.Logs[0].Fields["request_body"])
+}
+
+func Test_GetAttachment_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Attachment Forbidden Test")
+	configureAttachmentThreshold(t, router, project, owner.Token, 1)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	logItems := logs_receiving_tests.CreateValidLogItems(1, uniqueID)
+	logItems[0].Fields["payload"] = strings.Repeat("y", 2*1024)
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	reference, _ := response.Logs[0].Fields["payload"].(string)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/logs/attachments/%s/%s",
+			project.ID.String(),
+			strings.TrimPrefix(reference, logs_attachments.ReferencePrefix),
+		),
+		"Bearer "+outsider.Token,
+		http.StatusForbidden,
+	)
+}
+
+func configureAttachmentThreshold(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	thresholdKB int,
+) {
+	updateData := getProjectForUpdate(t, router, project, token)
+	updateData.AttachmentThresholdKB = thresholdKB
+
+	updatedProject := projects_testing.UpdateProject(project, updateData, token, router)
+	assert.Equal(t, thresholdKB, updatedProject.AttachmentThresholdKB)
+}
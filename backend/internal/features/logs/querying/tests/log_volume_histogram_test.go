@@ -0,0 +1,154 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetLogVolumeHistogram_WithIntervalsWithoutLogs_ReturnsContiguousZeroFilledPoints(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Volume Histogram Test")
+	repository := logs_core.GetLogCoreRepository()
+
+	from := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Hour)
+	to := from.Add(15 * time.Minute)
+
+	storeLogEntriesWithTimestamp(t, repository, project.ID, from, "First interval", uniqueID, nil)
+	storeLogEntriesWithTimestamp(t, repository, project.ID, from.Add(4*time.Minute), "First interval", uniqueID, nil)
+	storeLogEntriesWithTimestamp(t, repository, project.ID, from.Add(12*time.Minute), "Third interval", uniqueID, nil)
+	// "to" is excluded
+	storeLogEntriesWithTimestamp(t, repository, project.ID, to, "Outside of the range", uniqueID, nil)
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	query := BuildCondition("test_id", "equals", uniqueID)
+	response := getTestLogVolumeHistogram(t, router, project.ID, url.Values{
+		"interval": {"5m"},
+		"from":     {from.Format(time.RFC3339)},
+		"to":       {to.Format(time.RFC3339)},
+		"query":    {encodeTestQueryNode(t, query)},
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, "5m", response.Interval)
+	assert.Len(t, response.Points, 3)
+
+	expectedCounts := []int64{2, 0, 1}
+	for i, point := range response.Points {
+		assert.True(t, from.Add(time.Duration(i)*5*time.Minute).Equal(point.Timestamp))
+		assert.Equal(t, expectedCounts[i], point.Count)
+	}
+}
+
+func Test_GetLogVolumeHistogram_WithQuery_CountsOnlyMatchingLogs(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Volume Histogram Query Test")
+	repository := logs_core.GetLogCoreRepository()
+
+	from := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Hour)
+	to := from.Add(time.Hour)
+
+	for i, status := range []string{"500", "200", "500", "200", "200"} {
+		storeLogEntriesWithTimestamp(t, repository, project.ID, from.Add(time.Duration(i)*time.Minute),
+			"Request", uniqueID, map[string]any{"status": status})
+	}
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("status", "equals", "500"),
+	).Query
+	response := getTestLogVolumeHistogram(t, router, project.ID, url.Values{
+		"interval": {"1h"},
+		"from":     {from.Format(time.RFC3339)},
+		"to":       {to.Format(time.RFC3339)},
+		"query":    {encodeTestQueryNode(t, query)},
+	}, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Points, 1)
+	assert.Equal(t, int64(2), response.Points[0].Count)
+}
+
+func Test_GetLogVolumeHistogram_WithInvalidParameters_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Volume Histogram Validation Test")
+
+	to := time.Now().UTC().Truncate(time.Minute)
+	invalidParameters := map[string]url.Values{
+		"unsupported interval": {"interval": {"2m"}},
+		"go duration interval": {"interval": {"1h0m0s"}},
+		"week interval":        {"interval": {"1w"}},
+		"from after to": {
+			"from": {to.Format(time.RFC3339)},
+			"to":   {to.Add(-time.Hour).Format(time.RFC3339)},
+		},
+		"too many intervals": {
+			"interval": {"1m"},
+			"from":     {to.Add(-48 * time.Hour).Format(time.RFC3339)},
+			"to":       {to.Format(time.RFC3339)},
+		},
+		"malformed query": {"query": {"{not json"}},
+	}
+
+	for name, parameters := range invalidParameters {
+		t.Run(name, func(t *testing.T) {
+			test_utils.MakeGetRequest(
+				t,
+				router,
+				fmt.Sprintf("/api/v1/projects/%s/logs/histogram?%s", project.ID.String(), parameters.Encode()),
+				"Bearer "+owner.Token,
+				http.StatusBadRequest,
+			)
+		})
+	}
+}
+
+func Test_GetLogVolumeHistogram_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router, _, project, _ := SetupBasicQueryTest(t, "Volume Histogram Forbidden Test")
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/logs/histogram?interval=1h", project.ID.String()),
+		"Bearer "+nonMember.Token,
+		http.StatusForbidden,
+	)
+}
+
+func getTestLogVolumeHistogram(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	parameters url.Values,
+	token string,
+	expectedStatus int,
+) *logs_querying.LogVolumeHistogramResponseDTO {
+	var response logs_querying.LogVolumeHistogramResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/logs/histogram?%s", projectID.String(), parameters.Encode()),
+		"Bearer "+token,
+		expectedStatus,
+		&response,
+	)
+
+	return &response
+}
+
+func encodeTestQueryNode(t *testing.T, query *logs_core.QueryNode) string {
+	encodedQuery, err := json.Marshal(query)
+	assert.NoError(t, err)
+
+	return string(encodedQuery)
+}
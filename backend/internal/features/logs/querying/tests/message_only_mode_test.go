@@ -0,0 +1,73 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_InMessageOnlyMode_MessageSearchableAndCustomFieldsNot(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Message Only Mode Test")
+	configureMessageOnlyMode(t, router, project, owner.Token, true)
+
+	logItems := logs_receiving_tests.CreateValidLogItems(3, uniqueID)
+	for i := range logItems {
+		logItems[i].Fields["service"] = "checkout"
+	}
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	assert.NoError(t, logs_core.GetLogCoreRepository().ForceFlush())
+
+	messageQuery := BuildSimpleConditionQuery("message", "contains", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, messageQuery, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 3)
+	for _, log := range response.Logs {
+		// Custom fields are stored and returned with the log
+		assert.Equal(t, uniqueID, log.Fields["test_id"])
+		assert.Equal(t, "checkout", log.Fields["service"])
+	}
+
+	for _, query := range []*logs_core.LogQueryRequestDTO{
+		BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+		BuildSimpleConditionQuery("service", "contains", "check"),
+		BuildSimpleConditionQuery("service", "exists", ""),
+	} {
+		assert.Empty(t, ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK).Logs)
+	}
+}
+
+func Test_SubmitLogs_AfterMessageOnlyModeDisabled_NewLogsQueryableByCustomFields(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Message Only Mode Disabled Test")
+	configureMessageOnlyMode(t, router, project, owner.Token, true)
+
+	SubmitLogsAndProcess(t, router, project.ID, logs_receiving_tests.CreateValidLogItems(2, uniqueID))
+	configureMessageOnlyMode(t, router, project, owner.Token, false)
+	SubmitLogsAndProcess(t, router, project.ID, logs_receiving_tests.CreateValidLogItems(3, uniqueID))
+
+	// Only logs ingested with indexing on can be found by their fields
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	messageQuery := BuildSimpleConditionQuery("message", "contains", uniqueID)
+	assert.Len(t, ExecuteTestQuery(t, router, project.ID, messageQuery, owner.Token, http.StatusOK).Logs, 5)
+}
+
+func configureMessageOnlyMode(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	isMessageOnlyMode bool,
+) {
+	updateData := getProjectForUpdate(t, router, project, token)
+	updateData.IsMessageOnlyMode = isMessageOnlyMode
+
+	updatedProject := projects_testing.UpdateProject(project, updateData, token, router)
+	assert.Equal(t, isMessageOnlyMode, updatedProject.IsMessageOnlyMode)
+}
@@ -0,0 +1,75 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithMinLevelWarn_ReturnsWarnAndMoreSevereLevels(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Min Level Query Test")
+	submitLogsWithEachLevel(t, router, project.ID, uniqueID)
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.MinLevel = logs_core.LogLevelWarn
+	queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.ElementsMatch(t, []string{"WARN", "ERROR", "FATAL"}, getLogLevels(queryResponse.Logs))
+}
+
+func Test_ExecuteQuery_WithMinLevelInAnyCase_MatchesCanonicalLevels(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Min Level Case Test")
+	submitLogsWithEachLevel(t, router, project.ID, uniqueID)
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	expectedLevels := map[logs_core.LogLevel][]string{
+		"error":    {"ERROR", "FATAL"},
+		"critical": {"FATAL"},
+		"debug":    {"DEBUG", "INFO", "WARN", "ERROR", "FATAL"},
+	}
+
+	for minLevel, levels := range expectedLevels {
+		query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+		query.MinLevel = minLevel
+		queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+		assert.ElementsMatch(t, levels, getLogLevels(queryResponse.Logs), "minLevel %q", minLevel)
+	}
+}
+
+func Test_SubmitLogs_WithCriticalLevel_StoredAsFatal(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Critical Level Test")
+	submitLevelTestLogs(t, router, project.ID, []logs_receiving.LogItemRequestDTO{
+		{Level: "CRITICAL", Message: "Critical message " + uniqueID, Fields: map[string]any{"test_id": uniqueID}},
+	})
+	WaitForLogsToBeIndexed(t, router, project.ID, 1, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.MinLevel = logs_core.LogLevelError
+	queryResponse := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Equal(t, []string{"FATAL"}, getLogLevels(queryResponse.Logs))
+}
+
+func Test_ExecuteQuery_WithUnknownMinLevel_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Min Level Invalid Test")
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.MinLevel = "SEVERE"
+
+	ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+}
+
+func getLogLevels(logs []logs_core.LogItemDTO) []string {
+	levels := make([]string, 0, len(logs))
+	for _, log := range logs {
+		levels = append(levels, log.Level)
+	}
+
+	return levels
+}
@@ -0,0 +1,113 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_models "logbull/internal/features/projects/models"
+	users_dto "logbull/internal/features/users/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Logs of each variant have a different set of the user_id and session_id fields,
+// test logs always have request_id so it cannot be one of them
+var missingFieldsTestLogs = map[string]map[string]any{
+	"complete":   {"user_id": "user_1", "session_id": "session_1"},
+	"no_user":    {"session_id": "session_2"},
+	"no_session": {"user_id": "user_2"},
+	"bare":       {},
+}
+
+func Test_ExecuteQuery_WithMissingAny_ReturnsLogsLackingAtLeastOneField(t *testing.T) {
+	router, owner, project, uniqueID := setupMissingFieldsTest(t, "Missing Any Query Test")
+
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("", "missing_any", []any{"user_id", "session_id"}),
+	)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.ElementsMatch(t, []string{"no_user", "no_session", "bare"}, getVariants(response.Logs))
+}
+
+func Test_ExecuteQuery_WithMissingAll_ReturnsLogsLackingEveryField(t *testing.T) {
+	router, owner, project, uniqueID := setupMissingFieldsTest(t, "Missing All Query Test")
+
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("", "missing_all", []any{"user_id", "session_id"}),
+	)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.ElementsMatch(t, []string{"bare"}, getVariants(response.Logs))
+}
+
+func Test_ExecuteQuery_WithMissingFieldsOfOneField_MatchesNotExists(t *testing.T) {
+	router, owner, project, uniqueID := setupMissingFieldsTest(t, "Missing One Field Query Test")
+
+	notExistsQuery := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("user_id", "not_exists", nil),
+	)
+	notExistsResponse := ExecuteTestQuery(t, router, project.ID, notExistsQuery, owner.Token, http.StatusOK)
+	assert.ElementsMatch(t, []string{"no_user", "bare"}, getVariants(notExistsResponse.Logs))
+
+	for _, operator := range []string{"missing_any", "missing_all"} {
+		query := BuildLogicalQuery("and",
+			*BuildCondition("test_id", "equals", uniqueID),
+			*BuildCondition("", operator, []any{"user_id"}),
+		)
+		response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+		assert.ElementsMatch(t, getVariants(notExistsResponse.Logs), getVariants(response.Logs), operator)
+	}
+}
+
+func Test_ExecuteQuery_WithInvalidMissingFieldsCondition_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Missing Fields Invalid Test")
+
+	invalidConditions := []*logs_core.QueryNode{
+		BuildCondition("user_id", "missing_any", []any{"session_id"}),
+		BuildCondition("", "missing_all", []any{}),
+		BuildCondition("", "missing_any", "user_id"),
+	}
+
+	for _, condition := range invalidConditions {
+		query := &logs_core.LogQueryRequestDTO{Query: condition, Limit: 10}
+		ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+	}
+}
+
+func setupMissingFieldsTest(t *testing.T, testName string) (
+	*gin.Engine,
+	*users_dto.SignInResponseDTO,
+	*projects_models.Project,
+	string,
+) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, testName)
+
+	for variant, fields := range missingFieldsTestLogs {
+		logFields := map[string]any{"test_id": uniqueID, "variant": variant}
+		for fieldName, fieldValue := range fields {
+			logFields[fieldName] = fieldValue
+		}
+		CreateTestLogsWithFields(t, router, project.ID, logFields, 1)
+	}
+	WaitForLogsToBeIndexed(t, router, project.ID, len(missingFieldsTestLogs), uniqueID, "Bearer "+owner.Token)
+
+	return router, owner, project, uniqueID
+}
+
+func getVariants(logs []logs_core.LogItemDTO) []string {
+	variants := make([]string, 0, len(logs))
+	for _, log := range logs {
+		if variant, ok := log.Fields["variant"].(string); ok {
+			variants = append(variants, variant)
+		}
+	}
+
+	return variants
+}
@@ -0,0 +1,148 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_dto "logbull/internal/features/users/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var ngramTestPaths = []string{
+	"/api/orders/12345/items",
+	"/api/users/987/profile",
+	"/health",
+	"/api/orders/555/cancel",
+}
+
+func Test_ExecuteQuery_ContainsOnNgramField_MatchesSameLogsAsWildcard(t *testing.T) {
+	router, owner, project, uniqueID := setupNgramFieldsTest(t, "Ngram Contains Test")
+
+	// "/h" is shorter than a gram and "ord*" has a wildcard character, both fall back to wildcards
+	patterns := []string{"orders/", "/items", "987", "api", "rs/9", "12345/items", "xyz", "/h", "ord*"}
+
+	for _, operator := range []string{"contains", "not_contains"} {
+		for _, pattern := range patterns {
+			ngramQuery := buildNgramTestQuery(uniqueID, "request_path", operator, pattern)
+			ngramQuery.IncludeTiming = true
+			ngramResponse := ExecuteTestQuery(t, router, project.ID, ngramQuery, owner.Token, http.StatusOK)
+
+			wildcardQuery := buildNgramTestQuery(uniqueID, "request_path_copy", operator, pattern)
+			wildcardQuery.IncludeTiming = true
+			wildcardResponse := ExecuteTestQuery(t, router, project.ID, wildcardQuery, owner.Token, http.StatusOK)
+
+			assert.Equal(t, getRequestPaths(wildcardResponse.Logs), getRequestPaths(ngramResponse.Logs),
+				"%s %q", operator, pattern)
+
+			if ngramResponse.Timing != nil && wildcardResponse.Timing != nil {
+				t.Logf("%s %q: n-gram took %dms, wildcard took %dms", operator, pattern,
+					ngramResponse.Timing.OpenSearchTookMs, wildcardResponse.Timing.OpenSearchTookMs)
+			}
+		}
+	}
+
+	query := buildNgramTestQuery(uniqueID, "request_path", "contains", "orders/")
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	assert.Equal(t, []string{"/api/orders/12345/items", "/api/orders/555/cancel"}, getRequestPaths(response.Logs))
+}
+
+func Test_BuildSearchBody_ContainsOnNgramField_UsesNgramsInsteadOfWildcard(t *testing.T) {
+	_, _, project, uniqueID := setupNgramFieldsTest(t, "Ngram Search Body Test")
+	queryBuilder := logs_core.GetLogQueryBuilder()
+
+	ngramBody, err := queryBuilder.BuildSearchBody(
+		project.ID, buildNgramTestQuery(uniqueID, "request_path", "contains", "orders/"),
+	)
+	assert.NoError(t, err)
+	ngramJSON, _ := json.Marshal(ngramBody)
+	assert.Contains(t, string(ngramJSON), "span_near")
+	assert.Contains(t, string(ngramJSON), "attrs_ngrams.request_path")
+	assert.NotContains(t, string(ngramJSON), "wildcard")
+
+	wildcardBody, err := queryBuilder.BuildSearchBody(
+		project.ID, buildNgramTestQuery(uniqueID, "request_path_copy", "contains", "orders/"),
+	)
+	assert.NoError(t, err)
+	wildcardJSON, _ := json.Marshal(wildcardBody)
+	assert.Contains(t, string(wildcardJSON), "wildcard")
+	assert.NotContains(t, string(wildcardJSON), "attrs_ngrams")
+}
+
+func Test_UpdateProject_WithInvalidNgramFields_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Ngram Fields Invalid Test")
+
+	invalidSettings := []struct {
+		ngramFields     []string
+		encryptedFields []string
+	}{
+		{ngramFields: []string{""}},
+		{ngramFields: []string{"attrs_ngrams"}},
+		{ngramFields: []string{"request path"}},
+		{ngramFields: []string{"email"}, encryptedFields: []string{"email"}},
+	}
+
+	for _, settings := range invalidSettings {
+		updateData := getProjectForUpdate(t, router, project, owner.Token)
+		updateData.NgramFields = settings.ngramFields
+		updateData.EncryptedFields = settings.encryptedFields
+
+		response := projects_testing.MakeAPIRequest(
+			router, "PUT", "/api/v1/projects/"+project.ID.String(), "Bearer "+owner.Token, updateData,
+		)
+		assert.Equal(t, http.StatusBadRequest, response.Code, "n-gram fields %v", settings.ngramFields)
+	}
+}
+
+func setupNgramFieldsTest(t *testing.T, testName string) (
+	*gin.Engine,
+	*users_dto.SignInResponseDTO,
+	*projects_models.Project,
+	string,
+) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, testName)
+
+	updateData := getProjectForUpdate(t, router, project, owner.Token)
+	updateData.NgramFields = []string{"request_path"}
+	updatedProject := projects_testing.UpdateProject(project, updateData, owner.Token, router)
+	assert.Equal(t, []string{"request_path"}, updatedProject.NgramFields)
+
+	// request_path_copy holds the same values without n-grams, so it is searched with wildcards
+	logItems := logs_receiving_tests.CreateValidLogItems(len(ngramTestPaths), uniqueID)
+	for i := range logItems {
+		logItems[i].Fields["request_path"] = ngramTestPaths[i]
+		logItems[i].Fields["request_path_copy"] = ngramTestPaths[i]
+	}
+
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, len(ngramTestPaths), uniqueID, "Bearer "+owner.Token)
+
+	return router, owner, project, uniqueID
+}
+
+func buildNgramTestQuery(uniqueID, field, operator, value string) *logs_core.LogQueryRequestDTO {
+	return BuildLogicalQuery(
+		"and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition(field, operator, value),
+	)
+}
+
+func getRequestPaths(logs []logs_core.LogItemDTO) []string {
+	paths := make([]string, 0, len(logs))
+	for _, log := range logs {
+		if path, ok := log.Fields["request_path"].(string); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	slices.Sort(paths)
+	return paths
+}
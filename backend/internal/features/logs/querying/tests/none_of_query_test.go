@@ -0,0 +1,88 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithNoneOf_ExcludesLogsMatchingAnyChild(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "None Of Query Test")
+
+	services := []string{"checkout", "payments", "auth", "search"}
+	for _, service := range services {
+		CreateTestLogsWithFields(t, router, project.ID, map[string]any{"test_id": uniqueID, "service": service}, 1)
+	}
+	WaitForLogsToBeIndexed(t, router, project.ID, len(services), uniqueID, "Bearer "+owner.Token)
+
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildLogicalQuery("none_of",
+			*BuildCondition("service", "equals", "checkout"),
+			*BuildCondition("service", "equals", "auth"),
+		).Query,
+	)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	returnedServices := make([]string, 0, len(response.Logs))
+	for _, log := range response.Logs {
+		returnedServices = append(returnedServices, log.Fields["service"].(string))
+	}
+	assert.ElementsMatch(t, []string{"payments", "search"}, returnedServices)
+}
+
+func Test_ExecuteQuery_WithNoneOfInsideOr_AddsLogsMatchingNoChild(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "None Of Inside Or Test")
+
+	for _, service := range []string{"checkout", "payments", "auth"} {
+		CreateTestLogsWithFields(t, router, project.ID, map[string]any{"test_id": uniqueID, "service": service}, 1)
+	}
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	// checkout OR none_of(checkout, payments) => checkout and auth
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildLogicalQuery("or",
+			*BuildCondition("service", "equals", "checkout"),
+			*BuildLogicalQuery("none_of",
+				*BuildCondition("service", "equals", "checkout"),
+				*BuildCondition("service", "equals", "payments"),
+			).Query,
+		).Query,
+	)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	returnedServices := make([]string, 0, len(response.Logs))
+	for _, log := range response.Logs {
+		returnedServices = append(returnedServices, log.Fields["service"].(string))
+	}
+	assert.ElementsMatch(t, []string{"checkout", "auth"}, returnedServices)
+}
+
+func Test_ExecuteQuery_WithNoneOfOnly_DoesNotReturnLogsOfOtherProjects(t *testing.T) {
+	router, project1, _, owner1, _, uniqueID1, uniqueID2 := setupTwoProjectsWithLogs(t)
+
+	// Excluding the own logs leaves nothing, logs of the other project never match
+	ownLogsExcluded := BuildLogicalQuery("none_of", *BuildCondition("log_source", "equals", "project_1"))
+	response := ExecuteTestQuery(t, router, project1.ID, ownLogsExcluded, owner1.Token, http.StatusOK)
+	assert.Empty(t, response.Logs)
+
+	otherLogsExcluded := BuildLogicalQuery("none_of", *BuildCondition("test_id", "equals", uniqueID2))
+	response = ExecuteTestQuery(t, router, project1.ID, otherLogsExcluded, owner1.Token, http.StatusOK)
+	AssertLogContainsUniqueID(t, response.Logs, uniqueID1, 5)
+	for _, log := range response.Logs {
+		assertLogBelongsToProject(t, log, "project_1", uniqueID1)
+	}
+}
+
+func Test_ExecuteQuery_WithNotOfSeveralChildren_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Not Several Children Test")
+
+	query := BuildLogicalQuery("not",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("service", "equals", "checkout"),
+	)
+
+	ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+}
@@ -0,0 +1,85 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WhenRestrictedToOnePartition_LogsOfOtherPartitionsExcluded(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	repository := logs_core.GetLogCoreRepository()
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("Partition Query Test %s", uniqueID[:8])
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	now := time.Now().UTC()
+	todayLogTime := now.Add(-time.Minute)
+	earlierLogTime := now.AddDate(0, 0, -3)
+
+	storeLogEntriesWithTimestamp(t, repository, project.ID, todayLogTime, "Today log message", uniqueID, nil)
+	storeLogEntriesWithTimestamp(t, repository, project.ID, earlierLogTime, "Earlier log message", uniqueID, nil)
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	query := &logs_core.LogQueryRequestDTO{
+		Query:      BuildCondition("test_id", "equals", uniqueID),
+		TimeRange:  &logs_core.TimeRangeDTO{To: &now},
+		Partitions: []string{earlierLogTime.Format(logs_core.PartitionDateLayout)},
+		Limit:      100,
+	}
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 1)
+	assert.Equal(t, "Earlier log message", response.Logs[0].Message)
+}
+
+func Test_ExecuteQuery_WhenPartitionHasNoLogs_ReturnsEmptyResult(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Empty Partition Query Test")
+
+	now := time.Now().UTC()
+	query := &logs_core.LogQueryRequestDTO{
+		Query:      BuildCondition("test_id", "equals", uniqueID),
+		TimeRange:  &logs_core.TimeRangeDTO{To: &now},
+		Partitions: []string{"2001-01-01"},
+		Limit:      100,
+	}
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Empty(t, response.Logs)
+}
+
+func Test_ExecuteQuery_WhenPartitionIsInvalid_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Invalid Partition Query Test")
+
+	now := time.Now().UTC()
+	today := now.Format(logs_core.PartitionDateLayout)
+
+	invalidPartitions := [][]string{
+		{"logs-2025.10.16"},
+		{"2025-13-01"},
+		{today, today},
+	}
+
+	for _, partitions := range invalidPartitions {
+		query := &logs_core.LogQueryRequestDTO{
+			Query:      BuildCondition("test_id", "equals", uniqueID),
+			TimeRange:  &logs_core.TimeRangeDTO{To: &now},
+			Partitions: partitions,
+		}
+
+		ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,129 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	users_dto "logbull/internal/features/users/dto"
+	users_enums "logbull/internal/features/users/enums"
+	users_models "logbull/internal/features/users/models"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithPersonalAccessToken_ReturnsLogsOfAccessibleProject(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "PAT Query Test")
+	token := createPersonalAccessToken(t, router, owner)
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{
+		"pat_test": "query",
+	})
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, token.Token, http.StatusOK)
+
+	AssertQueryResponseValid(t, response, 1)
+	AssertLogContainsUniqueID(t, response.Logs, uniqueID, 2)
+}
+
+func Test_ExecuteQuery_WithPersonalAccessTokenOfNonMember_ReturnsForbidden(t *testing.T) {
+	router, _, project, uniqueID := SetupBasicQueryTest(t, "PAT Non-Member Test")
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	token := createPersonalAccessToken(t, router, nonMember)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	ExecuteTestQuery(t, router, project.ID, query, token.Token, http.StatusForbidden)
+}
+
+func Test_AccessNonQueryEndpoint_WithPersonalAccessToken_ReturnsUnauthorized(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "PAT Non-Query Test")
+	token := createPersonalAccessToken(t, router, owner)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s", project.ID.String()),
+		"Bearer "+token.Token,
+		http.StatusUnauthorized,
+	)
+
+	test_utils.MakeGetRequest(t, router, "/api/v1/users/tokens", "Bearer "+token.Token, http.StatusUnauthorized)
+}
+
+func Test_ExecuteQuery_WithRevokedPersonalAccessToken_ReturnsUnauthorized(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "PAT Revoked Test")
+	token := createPersonalAccessToken(t, router, owner)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/users/tokens/%s", token.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	ExecuteTestQuery(t, router, project.ID, query, token.Token, http.StatusUnauthorized)
+
+	var listResponse users_dto.ListPersonalAccessTokensResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/tokens",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&listResponse,
+	)
+
+	assert.Len(t, listResponse.Tokens, 1)
+	assert.Equal(t, users_enums.PersonalAccessTokenStatusRevoked, listResponse.Tokens[0].Status)
+	assert.Empty(t, listResponse.Tokens[0].Token)
+}
+
+func Test_CreatePersonalAccessToken_WhenCreated_AuditLogWritten(t *testing.T) {
+	router, owner, _, _ := SetupBasicQueryTest(t, "PAT Audit Test")
+	token := createPersonalAccessToken(t, router, owner)
+
+	auditLogs, err := audit_logs.GetAuditLogService().GetUserAuditLogs(
+		owner.UserID,
+		&users_models.User{ID: owner.UserID, Role: users_enums.UserRoleMember},
+		&audit_logs.GetAuditLogsRequest{Limit: 100},
+	)
+	assert.NoError(t, err)
+
+	found := false
+	for _, auditLog := range auditLogs.AuditLogs {
+		if auditLog.Message == "Personal access token created: "+token.Name {
+			found = true
+			break
+		}
+	}
+	assert.True(t, found, "Audit log for personal access token creation not found")
+}
+
+func createPersonalAccessToken(
+	t *testing.T,
+	router *gin.Engine,
+	user *users_dto.SignInResponseDTO,
+) *users_models.PersonalAccessToken {
+	var token users_models.PersonalAccessToken
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/tokens",
+		"Bearer "+user.Token,
+		users_dto.CreatePersonalAccessTokenRequestDTO{Name: "automation"},
+		http.StatusOK,
+		&token,
+	)
+
+	assert.NotEmpty(t, token.Token)
+
+	return &token
+}
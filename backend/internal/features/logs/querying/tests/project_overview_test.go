@@ -0,0 +1,90 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetProjectOverview_WithLogsAndMembers_AllSectionsPopulated(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Project Overview Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	logItems := logs_receiving_tests.CreateValidLogItems(4, uniqueID)
+	logItems[0].Level = logs_core.LogLevelError
+	logItems[1].Level = logs_core.LogLevelFatal
+	logItems[2].Level = logs_core.LogLevelInfo
+	logItems[3].Level = logs_core.LogLevelWarn
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, len(logItems), uniqueID, "Bearer "+owner.Token)
+
+	overview := getProjectOverview(t, router, project.ID, member.Token, http.StatusOK)
+
+	assert.Equal(t, project.ID, overview.Project.ID)
+	assert.Equal(t, project.Name, overview.Project.Name)
+	assert.Equal(t, int64(len(logItems)), overview.LogStats.TotalLogs)
+	assert.Equal(t, int64(2), overview.RecentErrorsCount)
+	assert.WithinDuration(t, time.Now().UTC().Add(-24*time.Hour), overview.RecentErrorsSince, time.Minute)
+	assert.Equal(t, 2, overview.MembersCount)
+	assert.NotNil(t, overview.LastLogTime)
+	assert.WithinDuration(t, time.Now().UTC(), *overview.LastLogTime, time.Minute)
+}
+
+func Test_GetProjectOverview_WithoutLogs_EmptyLogSectionsReturned(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Project Overview Empty Test")
+
+	overview := getProjectOverview(t, router, project.ID, owner.Token, http.StatusOK)
+
+	assert.Equal(t, project.ID, overview.Project.ID)
+	assert.Equal(t, int64(0), overview.LogStats.TotalLogs)
+	assert.Equal(t, int64(0), overview.RecentErrorsCount)
+	assert.Equal(t, 1, overview.MembersCount)
+	assert.Nil(t, overview.LastLogTime)
+}
+
+func Test_GetProjectOverview_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router, _, project, _ := SetupBasicQueryTest(t, "Project Overview Forbidden Test")
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/overview", project.ID.String()),
+		"Bearer "+outsider.Token,
+		http.StatusForbidden,
+	)
+}
+
+func getProjectOverview(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	expectedStatus int,
+) *logs_querying.ProjectOverviewDTO {
+	var overview logs_querying.ProjectOverviewDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/overview", projectID.String()),
+		"Bearer "+token,
+		expectedStatus,
+		&overview,
+	)
+
+	return &overview
+}
@@ -0,0 +1,73 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithOverNarrowFilterAndDiagnostics_ReportsLogsInTimeRange(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Diagnostics Filter Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 3, map[string]any{"service": "billing"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("service", "equals", "missing-"+uniqueID)
+	query.IncludeDiagnostics = true
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(0), response.Total)
+	assert.Empty(t, response.Logs)
+	if assert.NotNil(t, response.Diagnostics) {
+		assert.Equal(t, int64(3), response.Diagnostics.ProjectTotalLogs)
+		assert.Equal(t, int64(3), response.Diagnostics.TimeRangeTotalLogs)
+	}
+}
+
+func Test_ExecuteQuery_WithEmptyTimeRangeAndDiagnostics_ReportsNoLogsInTimeRange(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Diagnostics Time Range Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 3, map[string]any{"service": "billing"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	to := time.Now().UTC().Add(-24 * time.Hour)
+	from := to.Add(-time.Hour)
+	query := &logs_core.LogQueryRequestDTO{
+		TimeRange:          &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Limit:              50,
+		IncludeDiagnostics: true,
+	}
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(0), response.Total)
+	if assert.NotNil(t, response.Diagnostics) {
+		assert.Equal(t, int64(3), response.Diagnostics.ProjectTotalLogs)
+		assert.Equal(t, int64(0), response.Diagnostics.TimeRangeTotalLogs)
+	}
+}
+
+func Test_ExecuteQuery_WithMatchingLogsOrWithoutDiagnostics_DiagnosticsOmitted(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Diagnostics Omitted Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"service": "billing"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	matchingQuery := BuildSimpleConditionQuery("service", "equals", "billing")
+	matchingQuery.IncludeDiagnostics = true
+	matchingResponse := ExecuteTestQuery(t, router, project.ID, matchingQuery, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(2), matchingResponse.Total)
+	assert.Nil(t, matchingResponse.Diagnostics)
+
+	emptyQuery := BuildSimpleConditionQuery("service", "equals", "missing-"+uniqueID)
+	emptyResponse := ExecuteTestQuery(t, router, project.ID, emptyQuery, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(0), emptyResponse.Total)
+	assert.Nil(t, emptyResponse.Diagnostics)
+}
@@ -0,0 +1,76 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithIncludeTiming_ReturnsTimingBreakdown(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Query Timing Test")
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"timing_test": "yes"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.IncludeTiming = true
+
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	AssertLogContainsUniqueID(t, response.Logs, uniqueID, 2)
+	if assert.NotNil(t, response.Timing) {
+		assert.GreaterOrEqual(t, response.Timing.ValidationMs, 0.0)
+		assert.GreaterOrEqual(t, response.Timing.OpenSearchTookMs, int64(0))
+		assert.Greater(t, response.Timing.OpenSearchRequestMs, 0.0)
+		assert.GreaterOrEqual(t, response.Timing.TotalMs, response.Timing.OpenSearchRequestMs)
+		assert.GreaterOrEqual(t, response.Timing.TotalMs, response.Timing.ValidationMs)
+	}
+}
+
+func Test_ExecuteQuery_WithoutIncludeTiming_OmitsTiming(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Query No Timing Test")
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+
+	var rawResponse map[string]any
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/logs/query/execute/"+project.ID.String(),
+		"Bearer "+owner.Token,
+		query,
+		http.StatusOK,
+		&rawResponse,
+	)
+	assert.NotContains(t, rawResponse, "timing")
+}
+
+func Test_ExecuteSavedQuery_WithIncludeTiming_ReturnsTimingBreakdown(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Saved Query Timing Test")
+	storeServiceLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "API logs",
+		Query: buildServiceQuery(uniqueID, "api"),
+	})
+
+	var response logs_core.LogQueryResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/logs/saved-queries/"+project.ID.String()+"/"+savedQuery.ID.String()+"/execute",
+		"Bearer "+owner.Token,
+		&logs_saved_queries.ExecuteSavedQueryRequestDTO{Limit: 10, IncludeTiming: true},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Logs, 1)
+	if assert.NotNil(t, response.Timing) {
+		assert.Greater(t, response.Timing.TotalMs, 0.0)
+	}
+}
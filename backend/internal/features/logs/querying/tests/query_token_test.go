@@ -0,0 +1,229 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+	projects_dto "logbull/internal/features/projects/dto"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_dto "logbull/internal/features/users/dto"
+	users_enums "logbull/internal/features/users/enums"
+	users_services "logbull/internal/features/users/services"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithProjectQueryToken_ReturnsProjectLogs(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Query Token Test")
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"embed_test": "query"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	queryToken := createQueryToken(t, router, project.ID, owner.Token, &projects_dto.CreateQueryTokenRequestDTO{
+		ExpiresInMinutes: 30,
+	}, http.StatusOK)
+
+	assert.Equal(t, project.ID, queryToken.ProjectID)
+	assert.Nil(t, queryToken.SavedQueryID)
+	assert.WithinDuration(t, time.Now().UTC().Add(30*time.Minute), queryToken.ExpiresAt, time.Minute)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, queryToken.Token, http.StatusOK)
+
+	AssertQueryResponseValid(t, response, 1)
+	AssertLogContainsUniqueID(t, response.Logs, uniqueID, 2)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/fields/%s", project.ID.String()),
+		"Bearer "+queryToken.Token,
+		http.StatusOK,
+	)
+}
+
+func Test_ExecuteQuery_WithQueryTokenOfAnotherProject_ReturnsForbidden(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Query Token Scope Test")
+	otherProject, _ := projects_testing.CreateTestProjectWithToken(
+		"Query Token Other "+uniqueID[:8], owner.Token, router,
+	)
+
+	queryToken := createQueryToken(
+		t, router, project.ID, owner.Token, &projects_dto.CreateQueryTokenRequestDTO{}, http.StatusOK,
+	)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	ExecuteTestQuery(t, router, otherProject.ID, query, queryToken.Token, http.StatusForbidden)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/overview", otherProject.ID.String()),
+		"Bearer "+queryToken.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_AccessNonQueryEndpoint_WithQueryToken_ReturnsUnauthorized(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Query Token Non-Query Test")
+	queryToken := createQueryToken(
+		t, router, project.ID, owner.Token, &projects_dto.CreateQueryTokenRequestDTO{}, http.StatusOK,
+	)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s", project.ID.String()),
+		"Bearer "+queryToken.Token,
+		http.StatusUnauthorized,
+	)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/query-tokens", project.ID.String()),
+		"Bearer "+queryToken.Token,
+		&projects_dto.CreateQueryTokenRequestDTO{},
+		http.StatusUnauthorized,
+	)
+}
+
+func Test_UpdateProject_WithQueryTokenWithoutPrefix_ReturnsUnauthorized(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Query Token Without Prefix Test")
+	queryToken := createQueryToken(
+		t, router, project.ID, owner.Token, &projects_dto.CreateQueryTokenRequestDTO{}, http.StatusOK,
+	)
+
+	// Without its prefix the token must not be accepted as a session
+	sessionLikeToken := strings.TrimPrefix(queryToken.Token, users_services.QueryTokenPrefix)
+	_, err := users_services.GetUserService().GetUserFromToken(sessionLikeToken)
+	assert.Error(t, err)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s", project.ID.String()),
+		"Bearer "+sessionLikeToken,
+		&projects_models.Project{Name: "Renamed By Query Token"},
+		http.StatusUnauthorized,
+	)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/query-tokens", project.ID.String()),
+		"Bearer "+sessionLikeToken,
+		&projects_dto.CreateQueryTokenRequestDTO{},
+		http.StatusUnauthorized,
+	)
+
+	// Query endpoints do not take it as a session either
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/overview", project.ID.String()),
+		"Bearer "+sessionLikeToken,
+		http.StatusUnauthorized,
+	)
+}
+
+func Test_ExecuteSavedQuery_WithSavedQueryToken_OnlyThatSavedQueryAllowed(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Query Token Saved Query Test")
+	storeServiceLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	apiQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "API logs",
+		Query: buildServiceQuery(uniqueID, "api"),
+	})
+	workerQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Worker logs",
+		Query: buildServiceQuery(uniqueID, "worker"),
+	})
+
+	queryToken := createQueryToken(t, router, project.ID, owner.Token, &projects_dto.CreateQueryTokenRequestDTO{
+		SavedQueryID: &apiQuery.ID,
+	}, http.StatusOK)
+	assert.Equal(t, &apiQuery.ID, queryToken.SavedQueryID)
+
+	response := executeSavedQuery(t, router, project.ID, apiQuery.ID, queryToken.Token, http.StatusOK)
+	assert.Len(t, response.Logs, 1)
+	assert.Equal(t, "API log message", response.Logs[0].Message)
+
+	executeSavedQuery(t, router, project.ID, workerQuery.ID, queryToken.Token, http.StatusForbidden)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	ExecuteTestQuery(t, router, project.ID, query, queryToken.Token, http.StatusForbidden)
+}
+
+func Test_CreateQueryToken_WithSavedQueryOfAnotherProject_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Query Token Foreign Query Test")
+	otherProject, _ := projects_testing.CreateTestProjectWithToken(
+		"Query Token Foreign "+uniqueID[:8], owner.Token, router,
+	)
+
+	otherQuery := createSavedQuery(t, router, otherProject.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Other project logs",
+		Query: buildServiceQuery(uniqueID, "api"),
+	})
+
+	createQueryToken(t, router, project.ID, owner.Token, &projects_dto.CreateQueryTokenRequestDTO{
+		SavedQueryID: &otherQuery.ID,
+	}, http.StatusBadRequest)
+
+	createQueryToken(t, router, project.ID, owner.Token, &projects_dto.CreateQueryTokenRequestDTO{
+		ExpiresInMinutes: 24*60 + 1,
+	}, http.StatusBadRequest)
+}
+
+func Test_CreateQueryToken_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router, _, project, _ := SetupBasicQueryTest(t, "Query Token Non-Member Test")
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	createQueryToken(
+		t, router, project.ID, nonMember.Token, &projects_dto.CreateQueryTokenRequestDTO{}, http.StatusForbidden,
+	)
+}
+
+func Test_ExecuteQuery_WithExpiredQueryToken_ReturnsUnauthorized(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Query Token Expired Test")
+
+	user, err := users_services.GetUserService().GetUserFromToken(owner.Token)
+	assert.NoError(t, err)
+
+	expiredToken, err := users_services.GetQueryTokenService().GenerateQueryToken(user, &users_dto.QueryTokenScope{
+		ProjectID: project.ID,
+		ExpiresAt: time.Now().UTC().Add(-time.Minute),
+	})
+	assert.NoError(t, err)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	ExecuteTestQuery(t, router, project.ID, query, expiredToken, http.StatusUnauthorized)
+}
+
+func createQueryToken(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	request *projects_dto.CreateQueryTokenRequestDTO,
+	expectedStatus int,
+) *projects_dto.QueryTokenResponseDTO {
+	url := fmt.Sprintf("/api/v1/projects/%s/query-tokens", projectID.String())
+	if expectedStatus != http.StatusOK {
+		test_utils.MakePostRequest(t, router, url, "Bearer "+token, request, expectedStatus)
+		return nil
+	}
+
+	var response projects_dto.QueryTokenResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(t, router, url, "Bearer "+token, request, expectedStatus, &response)
+
+	return &response
+}
@@ -312,6 +312,85 @@ func Test_GetQueryableFields_WithDifferentProjects_ReturnsProjectSpecificFields(
 		len(response1.Fields), len(response2.Fields))
 }
 
+func Test_GetQueryableFields_WithPrefixLimitAndOffset_ReturnsPageOfFields(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("Paged Fields Test %s", uniqueID[:8])
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	logItems := createLogsWithCustomFields(uniqueID, map[string]any{
+		"paged_a": "a",
+		"paged_b": "b",
+		"paged_c": "c",
+		"other":   "value",
+	})
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, len(logItems), uniqueID, "Bearer "+owner.Token)
+
+	var firstPage GetQueryableFieldsResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/fields/%s?prefix=paged_&limit=2&offset=0", project.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&firstPage,
+	)
+
+	assert.Equal(t, 3, firstPage.Total)
+	assert.Equal(t, []string{"paged_a", "paged_b"}, getFieldNames(firstPage.Fields))
+
+	var secondPage GetQueryableFieldsResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/fields/%s?prefix=paged_&limit=2&offset=2", project.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&secondPage,
+	)
+
+	assert.Equal(t, 3, secondPage.Total)
+	assert.Equal(t, []string{"paged_c"}, getFieldNames(secondPage.Fields))
+}
+
+func Test_GetQueryableFields_WithAllFlag_ReturnsFullFieldList(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	projectName := fmt.Sprintf("All Flag Fields Test %s", uniqueID[:8])
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	customFields := map[string]any{}
+	for i := range 60 {
+		customFields[fmt.Sprintf("bulk_field_%02d", i)] = i
+	}
+
+	logItems := createLogsWithCustomFields(uniqueID, customFields)
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+	WaitForLogsToBeIndexed(t, router, project.ID, len(logItems), uniqueID, "Bearer "+owner.Token)
+
+	defaultPage := makeGetQueryableFieldsRequest(t, router, project.ID, "", owner.Token, http.StatusOK)
+	assert.Len(t, defaultPage.Fields, 50)
+	assert.Greater(t, defaultPage.Total, 60)
+
+	var fullList GetQueryableFieldsResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/fields/%s?all=true", project.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&fullList,
+	)
+
+	assert.Equal(t, defaultPage.Total, len(fullList.Fields))
+	assert.Equal(t, fullList.Total, len(fullList.Fields))
+}
+
 func Test_GetQueryableFields_WithInvalidProjectId_ReturnsBadRequest(t *testing.T) {
 	router := CreateLogQueryTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -356,6 +435,9 @@ func Test_GetQueryableFields_WithDifferentUserRoles_EnforcesPermissions(t *testi
 
 type GetQueryableFieldsResponse struct {
 	Fields []QueryableField `json:"fields"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
 }
 
 type QueryableField struct {
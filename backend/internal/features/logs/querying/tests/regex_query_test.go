@@ -0,0 +1,128 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithRegexOnMessage_ReturnsLogsMatchingPattern(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Regex Message Query Test")
+
+	CreateTestLogsWithMessages(t, router, project.ID, uniqueID, []string{
+		"Order ORD-123456 shipped",
+		"Refund for ORD-654321 issued",
+		"Order ORD-12345 shipped",
+		"Order ord-777777 cancelled",
+		"Order ORDX123456 shipped",
+	}, logs_core.LogLevelInfo, nil)
+
+	regexCondition := BuildCondition("message", "regex", `ORD-\d{6}`)
+	query := BuildLogicalQuery("and", *BuildCondition("test_id", "equals", uniqueID), *regexCondition)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.ElementsMatch(t, []string{
+		"Order ORD-123456 shipped",
+		"Refund for ORD-654321 issued",
+	}, getMessages(response.Logs))
+
+	regexCondition.Condition.CaseInsensitive = true
+	query = BuildLogicalQuery("and", *BuildCondition("test_id", "equals", uniqueID), *regexCondition)
+	response = ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.ElementsMatch(t, []string{
+		"Order ORD-123456 shipped",
+		"Refund for ORD-654321 issued",
+		"Order ord-777777 cancelled",
+	}, getMessages(response.Logs))
+}
+
+func Test_ExecuteQuery_WithRegexOnCustomField_ReturnsLogsMatchingPattern(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Regex Custom Field Query Test")
+
+	orderIDs := []string{"ORD-000001", "ORD-1", "INV-000002", "order ORD-999999 (retry)"}
+	logItems := CreateLogItemsWithMessages(uniqueID, orderIDs, logs_core.LogLevelInfo, nil)
+	for i, orderID := range orderIDs {
+		logItems[i].Fields["order_id"] = orderID
+	}
+	SubmitLogsAndProcess(t, router, project.ID, logItems)
+
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("order_id", "regex", `ORD-[0-9]{6}`),
+	)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.ElementsMatch(t, []string{"ORD-000001", "order ORD-999999 (retry)"}, getMessages(response.Logs))
+}
+
+func Test_ExecuteQuery_WithRegexOfOptionalLiteralParenthesis_ReturnsLogsMatchingPattern(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Regex Literal Parenthesis Query Test")
+
+	CreateTestLogsWithMessages(t, router, project.ID, uniqueID, []string{
+		"Call from (555) 123-4567",
+		"Call from 555) 123-4567",
+		"Call from 555 123-4567",
+		"Call from (55) 123-4567",
+		"Option [?] selected",
+	}, logs_core.LogLevelInfo, nil)
+
+	// "(?" of escaped parentheses and character classes opens no group
+	query := BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("message", "regex", `\(?\d{3}\)? \d{3}-\d{4}`),
+	)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.ElementsMatch(t, []string{
+		"Call from (555) 123-4567",
+		"Call from 555) 123-4567",
+		"Call from 555 123-4567",
+	}, getMessages(response.Logs))
+
+	query = BuildLogicalQuery("and",
+		*BuildCondition("test_id", "equals", uniqueID),
+		*BuildCondition("message", "regex", `\[[(?]\]`),
+	)
+	response = ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Equal(t, []string{"Option [?] selected"}, getMessages(response.Logs))
+}
+
+func Test_ExecuteQuery_WithInvalidRegex_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Regex Invalid Query Test")
+
+	invalidConditions := map[string]*logs_core.QueryNode{
+		"unclosed group":    BuildCondition("message", "regex", `ORD-(\d{6}`),
+		"nested repetition": BuildCondition("message", "regex", `(a+)+b`),
+		"anchor":            BuildCondition("message", "regex", `^ORD`),
+		"large repetition":  BuildCondition("message", "regex", `a{500}`),
+		"too long":          BuildCondition("message", "regex", strings.Repeat("a", 300)),
+		"not a string":      BuildCondition("message", "regex", 123),
+		"timestamp field":   BuildCondition("timestamp", "regex", `2025`),
+		"inline case flag":  BuildCondition("message", "regex", `(?i)ord`),
+		"non-capture group": BuildCondition("message", "regex", `\((?:ord)`),
+		"empty pattern":     BuildCondition("message", "regex", ""),
+		"unclosed class":    BuildCondition("order_id", "regex", `ORD-[0-9`),
+	}
+
+	for name, condition := range invalidConditions {
+		t.Run(name, func(t *testing.T) {
+			query := &logs_core.LogQueryRequestDTO{Query: condition, Limit: 10}
+			ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+		})
+	}
+}
+
+func getMessages(logs []logs_core.LogItemDTO) []string {
+	messages := make([]string, 0, len(logs))
+	for _, log := range logs {
+		messages = append(messages, log.Message)
+	}
+
+	return messages
+}
@@ -0,0 +1,101 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithOffsetBeyondDefaultResultWindow_ReturnsCursorSuggestion(t *testing.T) {
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Result Window Default Test", 0)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.Offset = logs_querying.DefaultMaxResultWindow - 5
+	query.Limit = 10
+
+	errorResponse := executeQueryExpectingError(t, router, project.ID, query, owner.Token)
+
+	assert.Equal(t, logs_core.ErrorResultWindowExceeded, errorResponse.Code)
+	assert.Contains(t, errorResponse.Error, fmt.Sprintf("%d", logs_querying.DefaultMaxResultWindow))
+	assert.Contains(t, errorResponse.Error, "cursor pagination")
+	assert.Contains(t, errorResponse.Error, `"after"`)
+}
+
+func Test_ExecuteQuery_WithConfiguredResultWindow_GuardsDeepPagesButNotCursors(t *testing.T) {
+	queryService := logs_querying.GetLogQueryService()
+	previousWindow := queryService.GetMaxResultWindow()
+	queryService.SetMaxResultWindow(20)
+	t.Cleanup(func() { queryService.SetMaxResultWindow(previousWindow) })
+
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Result Window Configured Test", 30)
+
+	// The last page within the window
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.Offset = 10
+	query.Limit = 10
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+	assert.Len(t, response.Logs, 10)
+
+	query.Offset = 15
+	errorResponse := executeQueryExpectingError(t, router, project.ID, query, owner.Token)
+	assert.Equal(t, logs_core.ErrorResultWindowExceeded, errorResponse.Code)
+	assert.Contains(t, errorResponse.Error, "must not exceed 20 logs")
+
+	// Without a limit the page has the OpenSearch default size of 10
+	query.Offset = 11
+	query.Limit = 0
+	errorResponse = executeQueryExpectingError(t, router, project.ID, query, owner.Token)
+	assert.Equal(t, logs_core.ErrorResultWindowExceeded, errorResponse.Code)
+
+	// Cursors read past the window
+	cursor := logs_core.EncodeLogCursor(logs_core.LogCursor{TimestampNanos: 0, ID: "0"})
+	var collectedLogs []logs_core.LogItemDTO
+	for range 4 {
+		page := ExecuteTestQuery(t, router, project.ID, buildCursorQuery(uniqueID, cursor, 10), owner.Token, 200)
+		if len(page.Logs) == 0 {
+			break
+		}
+
+		collectedLogs = append(collectedLogs, page.Logs...)
+		cursor = page.Cursor
+	}
+
+	assert.Len(t, collectedLogs, 30)
+	assertNoDuplicateLogs(t, collectedLogs)
+}
+
+type queryErrorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+func executeQueryExpectingError(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	query *logs_core.LogQueryRequestDTO,
+	token string,
+) *queryErrorResponse {
+	resp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/execute/%s", projectID.String()),
+		"Bearer "+token,
+		query,
+		http.StatusBadRequest,
+	)
+
+	var errorResponse queryErrorResponse
+	assert.NoError(t, json.Unmarshal(resp.Body, &errorResponse))
+
+	return &errorResponse
+}
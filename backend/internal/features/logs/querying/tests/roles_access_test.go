@@ -115,6 +115,22 @@ func Test_ExecuteQuery_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T)
 	ExecuteTestQuery(t, router, project.ID, query, nonMember.Token, http.StatusForbidden)
 }
 
+func Test_ExecuteQuery_WhenProjectDoesNotExist_ReturnsNotFound(t *testing.T) {
+	router := CreateLogQueryTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uuid.New().String())
+	ExecuteTestQuery(t, router, uuid.New(), query, user.Token, http.StatusNotFound)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/fields/%s", uuid.New().String()),
+		"Bearer "+user.Token,
+		http.StatusNotFound,
+	)
+}
+
 func Test_ExecuteQuery_WithoutAuthToken_ReturnsUnauthorized(t *testing.T) {
 	router, _, project, uniqueID := SetupBasicQueryTest(t, "No Auth Test")
 
@@ -0,0 +1,217 @@
+package logs_querying_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CancelRunningQuery_WhenQueryIsRunning_QueryStopsAndIsUnregistered(t *testing.T) {
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Cancel Running Query Test", 1)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	// The query waits for the held slot, so it is running until cancelled
+	limiter := setInstanceQueryLimitForTest(t, 1, 5*time.Second)
+	holdInstanceQuerySlots(t, limiter, 1)
+
+	queryResponse := make(chan *test_utils.TestResponse, 1)
+	go func() {
+		queryResponse <- test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+			Method:         "POST",
+			URL:            fmt.Sprintf("/api/v1/logs/query/execute/%s", project.ID.String()),
+			Headers:        map[string]string{"Authorization": "Bearer " + owner.Token},
+			Body:           BuildSimpleConditionQuery("test_id", "equals", uniqueID),
+			ExpectedStatus: http.StatusBadRequest,
+		})
+	}()
+
+	var runningQuery *logs_querying.RunningQueryDTO
+	assert.Eventually(t, func() bool {
+		runningQuery = findRunningQuery(t, router, admin.Token, project.ID)
+		return runningQuery != nil
+	}, 3*time.Second, 20*time.Millisecond)
+	if runningQuery == nil {
+		return
+	}
+
+	assert.Equal(t, owner.UserID, runningQuery.UserID)
+	assert.WithinDuration(t, time.Now().UTC(), runningQuery.StartedAt, 5*time.Second)
+
+	test_utils.MakePostRequest(t, router,
+		fmt.Sprintf("/api/v1/logs/queries/running/%s/cancel", runningQuery.ID),
+		"Bearer "+admin.Token, nil, http.StatusOK)
+	limiter.ReleaseSlot()
+
+	select {
+	case response := <-queryResponse:
+		var errorResponse map[string]string
+		assert.NoError(t, json.Unmarshal(response.Body, &errorResponse))
+		assert.Equal(t, logs_core.ErrorQueryCancelled, errorResponse["code"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled query did not stop")
+	}
+
+	assert.Nil(t, findRunningQuery(t, router, admin.Token, project.ID))
+}
+
+func Test_CancelRunningQuery_WhenAggregationIsRunning_QueryStopsAndIsUnregistered(t *testing.T) {
+	router, owner, project, uniqueID := SetupTestProjectWithLogs(t, "Cancel Running Aggregation Test", 1)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	limiter := setInstanceQueryLimitForTest(t, 1, 5*time.Second)
+
+	query := BuildCondition("test_id", "equals", uniqueID)
+	to := time.Now().UTC().Truncate(time.Minute).Add(time.Minute)
+	middle := to.Add(-30 * time.Minute)
+	from := to.Add(-time.Hour)
+
+	aggregations := map[string]test_utils.RequestOptions{
+		"compare": {
+			Method: "POST",
+			URL:    fmt.Sprintf("/api/v1/logs/query/compare/%s", project.ID.String()),
+			Body: &logs_querying.CompareWindowsRequestDTO{
+				Query:  query,
+				Before: logs_core.TimeRangeDTO{From: &from, To: &middle},
+				After:  logs_core.TimeRangeDTO{From: &middle, To: &to},
+			},
+		},
+		"group by": {
+			Method: "POST",
+			URL:    fmt.Sprintf("/api/v1/logs/query/group-by/%s", project.ID.String()),
+			Body: &logs_querying.GroupByRequestDTO{
+				Query:     query,
+				TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+				GroupBy:   "level",
+			},
+		},
+		"aggregate": {
+			Method: "POST",
+			URL:    fmt.Sprintf("/api/v1/logs/%s/aggregate", project.ID.String()),
+			Body: &logs_core.AggregateRequestDTO{
+				Query:     query,
+				TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+				Field:     "level",
+			},
+		},
+		"latest per group": {
+			Method: "POST",
+			URL:    fmt.Sprintf("/api/v1/logs/query/latest-per-group/%s", project.ID.String()),
+			Body: &logs_querying.LatestPerGroupRequestDTO{
+				Query:     query,
+				TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+				GroupBy:   "level",
+				Interval:  "30m",
+			},
+		},
+		"volume histogram": {
+			Method: "GET",
+			URL:    fmt.Sprintf("/api/v1/projects/%s/logs/histogram?interval=5m", project.ID.String()),
+		},
+	}
+
+	for name, options := range aggregations {
+		t.Run(name, func(t *testing.T) {
+			// The aggregation waits for the held slot, so it is running until cancelled
+			holdInstanceQuerySlots(t, limiter, 1)
+
+			options.Headers = map[string]string{"Authorization": "Bearer " + owner.Token}
+			options.ExpectedStatus = http.StatusBadRequest
+
+			queryResponse := make(chan *test_utils.TestResponse, 1)
+			go func() {
+				queryResponse <- test_utils.MakeRequest(t, router, options)
+			}()
+
+			var runningQuery *logs_querying.RunningQueryDTO
+			assert.Eventually(t, func() bool {
+				runningQuery = findRunningQuery(t, router, admin.Token, project.ID)
+				return runningQuery != nil
+			}, 3*time.Second, 20*time.Millisecond)
+			if runningQuery == nil {
+				return
+			}
+
+			test_utils.MakePostRequest(t, router,
+				fmt.Sprintf("/api/v1/logs/queries/running/%s/cancel", runningQuery.ID),
+				"Bearer "+admin.Token, nil, http.StatusOK)
+			limiter.ReleaseSlot()
+
+			select {
+			case response := <-queryResponse:
+				var errorResponse map[string]string
+				assert.NoError(t, json.Unmarshal(response.Body, &errorResponse))
+				assert.Equal(t, logs_core.ErrorQueryCancelled, errorResponse["code"])
+			case <-time.After(5 * time.Second):
+				t.Fatal("cancelled aggregation did not stop")
+			}
+
+			assert.Nil(t, findRunningQuery(t, router, admin.Token, project.ID))
+		})
+	}
+}
+
+func Test_RunningQueries_WhenUserIsNotAdminOrQueryUnknown_ReturnsError(t *testing.T) {
+	router, owner, _, _ := SetupBasicQueryTest(t, "Running Queries Access Test")
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	cancelURL := fmt.Sprintf("/api/v1/logs/queries/running/%s/cancel", uuid.New().String())
+
+	test_utils.MakeGetRequest(t, router, "/api/v1/logs/queries/running", "Bearer "+owner.Token, http.StatusForbidden)
+	test_utils.MakePostRequest(t, router, cancelURL, "Bearer "+owner.Token, nil, http.StatusForbidden)
+
+	test_utils.MakePostRequest(t, router, cancelURL, "Bearer "+admin.Token, nil, http.StatusNotFound)
+}
+
+func Test_RunningQueryRegistry_WhenQueryCancelledAndFinished_ContextDoneAndQueryRemoved(t *testing.T) {
+	registry := logs_querying.NewRunningQueryRegistry()
+	projectID, userID := uuid.New(), uuid.New()
+
+	firstCtx, unregisterFirst := registry.Register("first", projectID, userID)
+	_, unregisterSecond := registry.Register("second", projectID, userID)
+	defer unregisterSecond()
+
+	queries := registry.List()
+	assert.Len(t, queries, 2)
+	assert.Equal(t, "first", queries[0].ID)
+	assert.Equal(t, projectID, queries[0].ProjectID)
+	assert.Equal(t, userID, queries[0].UserID)
+
+	assert.True(t, registry.Cancel("first"))
+	assert.Error(t, firstCtx.Err())
+	// Listed until the query returns
+	assert.Len(t, registry.List(), 2)
+
+	unregisterFirst()
+	assert.Len(t, registry.List(), 1)
+	assert.Equal(t, "second", registry.List()[0].ID)
+	assert.False(t, registry.Cancel("first"))
+}
+
+func findRunningQuery(
+	t *testing.T,
+	router *gin.Engine,
+	adminToken string,
+	projectID uuid.UUID,
+) *logs_querying.RunningQueryDTO {
+	var runningQueries []logs_querying.RunningQueryDTO
+	test_utils.MakeGetRequestAndUnmarshal(t, router, "/api/v1/logs/queries/running", "Bearer "+adminToken,
+		http.StatusOK, &runningQueries)
+
+	for _, runningQuery := range runningQueries {
+		if runningQuery.ProjectID == projectID {
+			return &runningQuery
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetProjectStats_WithHalfSampling_ReportsStoredAndEstimatedTotal(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Sampling Stats Test")
+	configureSamplingPercent(t, router, project, owner.Token, 50)
+
+	logsCount := 200
+	response := submitLogsBatch(t, router, project.ID, logs_receiving_tests.CreateValidLogItems(logsCount, uniqueID))
+
+	storedCount := len(response.IDs)
+	assert.Equal(t, logsCount, response.Accepted)
+	assert.Equal(t, logsCount, storedCount+response.SampledOut)
+	assert.Greater(t, storedCount, 0)
+	assert.Less(t, storedCount, logsCount)
+
+	WaitForLogsToBeIndexed(t, router, project.ID, storedCount, uniqueID, "Bearer "+owner.Token)
+
+	stats := getProjectStats(t, router, project.ID, owner.Token)
+	assert.Equal(t, int64(storedCount), stats.TotalLogs)
+	assert.Equal(t, int64(2*storedCount), stats.EstimatedTotalLogs)
+	assert.Equal(t, int64(storedCount), stats.SampledOutLogs)
+}
+
+func Test_GetProjectStats_WithoutSampling_EstimatedTotalEqualsStored(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "No Sampling Stats Test")
+
+	response := submitLogsBatch(t, router, project.ID, logs_receiving_tests.CreateValidLogItems(5, uniqueID))
+	assert.Equal(t, 0, response.SampledOut)
+	assert.Len(t, response.IDs, 5)
+
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	stats := getProjectStats(t, router, project.ID, owner.Token)
+	assert.Equal(t, int64(5), stats.TotalLogs)
+	assert.Equal(t, int64(5), stats.EstimatedTotalLogs)
+	assert.Equal(t, int64(0), stats.SampledOutLogs)
+}
+
+func Test_UpdateProject_WithSamplingPercentOutOfRange_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Sampling Invalid Test")
+
+	for _, samplingPercent := range []int{-1, 101} {
+		updateData := getProjectForUpdate(t, router, project, owner.Token)
+		updateData.SamplingPercent = samplingPercent
+
+		test_utils.MakePutRequest(t, router, "/api/v1/projects/"+project.ID.String(), "Bearer "+owner.Token,
+			updateData, http.StatusBadRequest)
+	}
+}
+
+func configureSamplingPercent(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	samplingPercent int,
+) {
+	updateData := getProjectForUpdate(t, router, project, token)
+	updateData.SamplingPercent = samplingPercent
+
+	updatedProject := projects_testing.UpdateProject(project, updateData, token, router)
+	assert.Equal(t, samplingPercent, updatedProject.SamplingPercent)
+}
+
+func submitLogsBatch(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	logItems []logs_receiving.LogItemRequestDTO,
+) *logs_receiving.SubmitLogsResponseDTO {
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", projectID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	return &response
+}
+
+func getProjectStats(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+) *logs_core.ProjectLogStats {
+	var stats logs_core.ProjectLogStats
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/logs/query/stats/"+projectID.String(),
+		"Bearer "+token,
+		http.StatusOK,
+		&stats,
+	)
+
+	return &stats
+}
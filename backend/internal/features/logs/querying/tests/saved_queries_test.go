@@ -0,0 +1,240 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteSavedQuery_WithCallerTimeRange_MatchingLogsReturned(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Saved Query Execute Test")
+	storeServiceLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "API logs",
+		Query: buildServiceQuery(uniqueID, "api"),
+	})
+
+	response := executeSavedQuery(t, router, project.ID, savedQuery.ID, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 1)
+	assert.Equal(t, "API log message", response.Logs[0].Message)
+}
+
+func Test_ExecuteSavedQuery_AfterDefinitionUpdated_NewDefinitionUsed(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Saved Query Update Test")
+	storeServiceLogs(t, router, project.ID, uniqueID, owner.Token)
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Service logs",
+		Query: buildServiceQuery(uniqueID, "api"),
+	})
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/saved-queries/%s/%s", project.ID.String(), savedQuery.ID.String()),
+		"Bearer "+owner.Token,
+		&logs_saved_queries.SaveQueryRequestDTO{
+			Name:  "Service logs",
+			Query: buildServiceQuery(uniqueID, "worker"),
+		},
+		http.StatusOK,
+	)
+
+	response := executeSavedQuery(t, router, project.ID, savedQuery.ID, owner.Token, http.StatusOK)
+
+	assert.Len(t, response.Logs, 1)
+	assert.Equal(t, "Worker log message", response.Logs[0].Message)
+}
+
+func Test_ExecuteSavedQuery_WhenStoredQueryBecameInvalid_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Saved Query Invalid Test")
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Outdated query",
+		Query: BuildCondition("test_id", "equals", uniqueID),
+	})
+
+	// Simulate a definition stored before the current validation rules
+	repository := logs_saved_queries.GetSavedQueryRepository()
+	storedQuery, err := repository.GetSavedQuery(project.ID, savedQuery.ID)
+	assert.NoError(t, err)
+	storedQuery.Query = BuildCondition("", "equals", uniqueID)
+	assert.NoError(t, repository.UpdateSavedQuery(storedQuery))
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+	response := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method: "POST",
+		URL: fmt.Sprintf(
+			"/api/v1/logs/saved-queries/%s/%s/execute",
+			project.ID.String(),
+			savedQuery.ID.String(),
+		),
+		Body: &logs_saved_queries.ExecuteSavedQueryRequestDTO{
+			TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+			Limit:     10,
+		},
+		AuthToken:      "Bearer " + owner.Token,
+		ExpectedStatus: http.StatusBadRequest,
+	})
+
+	assert.Contains(t, string(response.Body), logs_core.ErrorSavedQueryInvalid)
+}
+
+func Test_CreateSavedQuery_WithInvalidQuery_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Saved Query Create Invalid Test")
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/saved-queries/%s", project.ID.String()),
+		"Bearer "+owner.Token,
+		&logs_saved_queries.SaveQueryRequestDTO{
+			Name:  "Invalid query",
+			Query: BuildCondition("test_id", "unknown_operator", "value"),
+		},
+		http.StatusBadRequest,
+	)
+}
+
+func Test_ExecuteSavedQuery_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Saved Query Forbidden Test")
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Private query",
+		Query: BuildCondition("test_id", "equals", uniqueID),
+	})
+
+	executeSavedQuery(t, router, project.ID, savedQuery.ID, outsider.Token, http.StatusForbidden)
+}
+
+func Test_SavedQuery_AsProjectMember_CanExecuteButNotModify(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Saved Query Member Test")
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	request := &logs_saved_queries.SaveQueryRequestDTO{
+		Name:  "Shared query",
+		Query: BuildCondition("test_id", "equals", uniqueID),
+	}
+	savedQuery := createSavedQuery(t, router, project.ID, owner.Token, request)
+
+	executeSavedQuery(t, router, project.ID, savedQuery.ID, member.Token, http.StatusOK)
+	test_utils.MakePutRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/saved-queries/%s/%s", project.ID.String(), savedQuery.ID.String()),
+		"Bearer "+member.Token,
+		request,
+		http.StatusForbidden,
+	)
+}
+
+func Test_ExecuteSavedQuery_WithUnknownId_ReturnsNotFound(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Saved Query Not Found Test")
+
+	executeSavedQuery(t, router, project.ID, uuid.New(), owner.Token, http.StatusNotFound)
+}
+
+func createSavedQuery(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	request *logs_saved_queries.SaveQueryRequestDTO,
+) *logs_saved_queries.SavedQuery {
+	var savedQuery logs_saved_queries.SavedQuery
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/saved-queries/%s", projectID.String()),
+		"Bearer "+token,
+		request,
+		http.StatusCreated,
+		&savedQuery,
+	)
+
+	return &savedQuery
+}
+
+func executeSavedQuery(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	savedQueryID uuid.UUID,
+	token string,
+	expectedStatus int,
+) *logs_core.LogQueryResponseDTO {
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+
+	var response logs_core.LogQueryResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/saved-queries/%s/%s/execute", projectID.String(), savedQueryID.String()),
+		"Bearer "+token,
+		&logs_saved_queries.ExecuteSavedQueryRequestDTO{
+			TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+			Limit:     10,
+		},
+		expectedStatus,
+		&response,
+	)
+
+	return &response
+}
+
+func buildServiceQuery(uniqueID, service string) *logs_core.QueryNode {
+	return &logs_core.QueryNode{
+		Type: logs_core.QueryNodeTypeLogical,
+		Logic: &logs_core.LogicalNode{
+			Operator: logs_core.LogicalOperatorAnd,
+			Children: []logs_core.QueryNode{
+				*BuildCondition("test_id", "equals", uniqueID),
+				*BuildCondition("service", "equals", service),
+			},
+		},
+	}
+}
+
+func storeServiceLogs(t *testing.T, router *gin.Engine, projectID uuid.UUID, uniqueID, token string) {
+	repository := logs_core.GetLogCoreRepository()
+	now := time.Now().UTC()
+
+	storeLogEntriesWithTimestamp(
+		t,
+		repository,
+		projectID,
+		now.Add(-10*time.Minute),
+		"API log message",
+		uniqueID,
+		map[string]any{"service": "api"},
+	)
+	storeLogEntriesWithTimestamp(
+		t,
+		repository,
+		projectID,
+		now.Add(-5*time.Minute),
+		"Worker log message",
+		uniqueID,
+		map[string]any{"service": "worker"},
+	)
+
+	waitForTimestampLogsIndexing(t, router, projectID, uniqueID, token)
+}
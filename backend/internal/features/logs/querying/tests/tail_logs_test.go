@@ -0,0 +1,153 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TailLogs_WithAdvancingCursors_ReturnsOnlyNewLogs(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Tail Logs Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 3, map[string]any{"batch": "first"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+
+	// Without a position the newest logs are the starting point
+	firstPage := tailLogs(t, router, project.ID, url.Values{}, owner.Token, http.StatusOK)
+	assert.Len(t, firstPage.Logs, 3)
+	assert.NotEmpty(t, firstPage.Cursor)
+	assert.False(t, firstPage.HasMore)
+	for i := 1; i < len(firstPage.Logs); i++ {
+		assert.False(t, firstPage.Logs[i].Timestamp.Before(firstPage.Logs[i-1].Timestamp),
+			"Tailed logs should be in ascending order")
+	}
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"batch": "second"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	secondPage := tailLogs(t, router, project.ID, url.Values{"after": {firstPage.Cursor}}, owner.Token, http.StatusOK)
+	assert.Len(t, secondPage.Logs, 2)
+	for _, log := range secondPage.Logs {
+		assert.Equal(t, "second", log.Fields["batch"])
+	}
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 1, map[string]any{"batch": "third"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 6, uniqueID, "Bearer "+owner.Token)
+
+	thirdPage := tailLogs(t, router, project.ID, url.Values{"after": {secondPage.Cursor}}, owner.Token, http.StatusOK)
+	assert.Len(t, thirdPage.Logs, 1)
+	assert.Equal(t, "third", thirdPage.Logs[0].Fields["batch"])
+
+	// Nothing new, the cursor is kept for the next poll
+	emptyPage := tailLogs(t, router, project.ID, url.Values{"after": {thirdPage.Cursor}}, owner.Token, http.StatusOK)
+	assert.Empty(t, emptyPage.Logs)
+	assert.Equal(t, thirdPage.Cursor, emptyPage.Cursor)
+
+	allLogs := append(append(firstPage.Logs, secondPage.Logs...), thirdPage.Logs...)
+	assertNoDuplicateLogs(t, allLogs)
+}
+
+func Test_TailLogs_WithAfterIdAndLimit_ReturnsFollowingLogsInPages(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Tail Logs After Id Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 5, nil)
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	allLogs := tailLogs(t, router, project.ID, url.Values{}, owner.Token, http.StatusOK).Logs
+	assert.Len(t, allLogs, 5)
+
+	page := tailLogs(t, router, project.ID, url.Values{
+		"afterId": {allLogs[1].ID},
+		"limit":   {"2"},
+	}, owner.Token, http.StatusOK)
+	assert.Equal(t, []string{allLogs[2].ID, allLogs[3].ID}, tailedLogIDs(page.Logs))
+	assert.True(t, page.HasMore)
+
+	page = tailLogs(t, router, project.ID, url.Values{
+		"after": {page.Cursor},
+		"limit": {"2"},
+	}, owner.Token, http.StatusOK)
+	assert.Equal(t, []string{allLogs[4].ID}, tailedLogIDs(page.Logs))
+	assert.False(t, page.HasMore)
+}
+
+func Test_TailLogs_WithInvalidPosition_ReturnsError(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Tail Logs Invalid Test")
+
+	tailLogs(t, router, project.ID, url.Values{"afterId": {uuid.New().String()}}, owner.Token, http.StatusNotFound)
+	tailLogs(t, router, project.ID, url.Values{"after": {"not-a-cursor"}}, owner.Token, http.StatusBadRequest)
+	tailLogs(t, router, project.ID, url.Values{"limit": {"5000"}}, owner.Token, http.StatusBadRequest)
+
+	cursor := logs_core.EncodeLogCursor(logs_core.LogCursor{TimestampNanos: 0, ID: "0"})
+	tailLogs(t, router, project.ID, url.Values{
+		"after":   {cursor},
+		"afterId": {uuid.New().String()},
+	}, owner.Token, http.StatusBadRequest)
+}
+
+func Test_TailLogs_AcrossProjects_RespectsIsolationAndPermissions(t *testing.T) {
+	router, project1, project2, owner1, owner2, uniqueID1, _ := setupTwoProjectsWithLogs(t)
+
+	project2Logs := tailLogs(t, router, project2.ID, url.Values{}, owner2.Token, http.StatusOK).Logs
+	assert.Len(t, project2Logs, 3)
+
+	// Ids of another project's logs are unknown to this project
+	tailLogs(t, router, project1.ID, url.Values{"afterId": {project2Logs[0].ID}}, owner1.Token, http.StatusNotFound)
+
+	// A cursor of another project only positions the tail, logs stay scoped
+	startCursor := logs_core.EncodeLogCursor(logs_core.LogCursor{TimestampNanos: 0, ID: "0"})
+	project1Logs := tailLogs(t, router, project1.ID, url.Values{"after": {startCursor}}, owner1.Token, http.StatusOK)
+	assert.Len(t, project1Logs.Logs, 5)
+	for _, log := range project1Logs.Logs {
+		assert.Equal(t, uniqueID1, log.Fields["test_id"])
+	}
+
+	tailLogs(t, router, project2.ID, url.Values{}, owner1.Token, http.StatusForbidden)
+
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	tailLogs(t, router, project1.ID, url.Values{"after": {startCursor}}, outsider.Token, http.StatusForbidden)
+}
+
+func tailLogs(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	parameters url.Values,
+	token string,
+	expectedStatus int,
+) *logs_querying.TailLogsResponseDTO {
+	requestURL := fmt.Sprintf("/api/v1/logs/query/tail/%s", projectID.String())
+	if len(parameters) > 0 {
+		requestURL += "?" + parameters.Encode()
+	}
+
+	if expectedStatus != http.StatusOK {
+		test_utils.MakeGetRequest(t, router, requestURL, "Bearer "+token, expectedStatus)
+		return nil
+	}
+
+	var response logs_querying.TailLogsResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(t, router, requestURL, "Bearer "+token, http.StatusOK, &response)
+
+	return &response
+}
+
+func tailedLogIDs(logs []logs_core.LogItemDTO) []string {
+	ids := make([]string, 0, len(logs))
+	for _, log := range logs {
+		ids = append(ids, log.ID)
+	}
+
+	return ids
+}
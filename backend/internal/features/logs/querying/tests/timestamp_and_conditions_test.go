@@ -0,0 +1,125 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_testing "logbull/internal/features/projects/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithTimestampBoundsAndLevelIn_ReturnsMatchingLogsOfProjectOnly(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Timestamp And Level Test")
+	otherProject, _ := projects_testing.CreateTestProjectWithToken(
+		fmt.Sprintf("Timestamp And Level Other Test %s", uniqueID[:8]), owner.Token, router,
+	)
+	repository := logs_core.GetLogCoreRepository()
+
+	now := time.Now().UTC()
+	rangeStart := now.Add(-3 * time.Hour)
+	rangeEnd := now.Add(-1 * time.Hour)
+
+	storedLogs := []struct {
+		projectID uuid.UUID
+		timestamp time.Time
+		level     logs_core.LogLevel
+		isMatched bool
+	}{
+		{project.ID, now.Add(-2 * time.Hour), logs_core.LogLevelError, true},
+		{project.ID, now.Add(-150 * time.Minute), logs_core.LogLevelWarn, true},
+		{project.ID, now.Add(-2 * time.Hour), logs_core.LogLevelInfo, false},
+		{project.ID, now.Add(-4 * time.Hour), logs_core.LogLevelError, false},
+		{project.ID, now.Add(-30 * time.Minute), logs_core.LogLevelError, false},
+		// Matches every condition but belongs to another project
+		{otherProject.ID, now.Add(-2 * time.Hour), logs_core.LogLevelError, false},
+	}
+
+	expectedIDs := []string{}
+	for _, storedLog := range storedLogs {
+		logItem := &logs_core.LogItem{
+			ID:        uuid.New(),
+			ProjectID: storedLog.projectID,
+			Timestamp: storedLog.timestamp,
+			Level:     storedLog.level,
+			Message:   "Dashboard log",
+			Fields:    map[string]any{"test_id": uniqueID},
+		}
+		assert.NoError(t, repository.StoreLogsBatch(map[uuid.UUID][]*logs_core.LogItem{
+			storedLog.projectID: {logItem},
+		}))
+
+		if storedLog.isMatched {
+			expectedIDs = append(expectedIDs, logItem.ID.String())
+		}
+	}
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	response := ExecuteTestQuery(t, router, project.ID, buildTimestampAndLevelQuery(uniqueID, rangeStart, rangeEnd),
+		owner.Token, http.StatusOK)
+
+	actualIDs := []string{}
+	for _, log := range response.Logs {
+		actualIDs = append(actualIDs, log.ID)
+	}
+	assert.ElementsMatch(t, expectedIDs, actualIDs)
+}
+
+func Test_BuildSearchBody_WithTimestampBoundsAndLevelIn_BuildsSingleBoolQuery(t *testing.T) {
+	projectID := uuid.New()
+	uniqueID := uuid.New().String()
+
+	now := time.Now().UTC()
+	rangeStart := now.Add(-3 * time.Hour)
+	rangeEnd := now.Add(-1 * time.Hour)
+
+	request := buildTimestampAndLevelQuery(uniqueID, rangeStart, rangeEnd)
+	searchBody, err := logs_core.GetLogQueryBuilder().BuildSearchBody(projectID, request)
+	assert.NoError(t, err)
+
+	boolQuery := searchBody["query"].(map[string]any)["bool"].(map[string]any)
+	assert.NotContains(t, boolQuery, "must")
+
+	// The conditions sit next to the project and time range filters, the two
+	// timestamp bounds are merged into one range
+	assert.Equal(t, []any{
+		map[string]any{"term": map[string]any{"project_id.keyword": projectID.String()}},
+		map[string]any{"range": map[string]any{"timestamp": map[string]any{
+			"gte": request.TimeRange.From.UnixNano(),
+			"lte": request.TimeRange.To.UnixNano(),
+		}}},
+		map[string]any{"term": map[string]any{"attrs_tokens.keyword": "test_id=" + uniqueID}},
+		map[string]any{"range": map[string]any{"timestamp": map[string]any{
+			"gte": fmt.Sprintf("%d", rangeStart.UnixNano()),
+			"lte": fmt.Sprintf("%d", rangeEnd.UnixNano()),
+		}}},
+		map[string]any{"terms": map[string]any{"level.keyword": []any{"ERROR", "WARN"}}},
+	}, boolQuery["filter"])
+}
+
+func buildTimestampAndLevelQuery(uniqueID string, rangeStart, rangeEnd time.Time) *logs_core.LogQueryRequestDTO {
+	now := time.Now().UTC()
+	from := rangeStart.Add(-time.Hour)
+
+	return &logs_core.LogQueryRequestDTO{
+		Query: &logs_core.QueryNode{
+			Type: logs_core.QueryNodeTypeLogical,
+			Logic: &logs_core.LogicalNode{
+				Operator: logs_core.LogicalOperatorAnd,
+				Children: []logs_core.QueryNode{
+					*BuildCondition("test_id", "equals", uniqueID),
+					*BuildCondition("timestamp", "greater_or_equal", rangeStart.Format(time.RFC3339Nano)),
+					*BuildCondition("timestamp", "less_or_equal", rangeEnd.Format(time.RFC3339Nano)),
+					*BuildCondition("level", "in", []string{"ERROR", "WARN"}),
+				},
+			},
+		},
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &now},
+		Limit:     50,
+		SortOrder: "desc",
+	}
+}
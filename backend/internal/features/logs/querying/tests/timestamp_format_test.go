@@ -0,0 +1,115 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type rawLogsResponse struct {
+	Logs []map[string]any `json:"logs"`
+}
+
+func Test_ExecuteQuery_WithEachTimestampFormat_SerializesTimestampInFormat(t *testing.T) {
+	router, token, projectID, uniqueID, timestamp := setupTimestampFormatTest(t, "Timestamp Format Test")
+
+	testCases := []struct {
+		format   logs_core.TimestampFormat
+		expected any
+	}{
+		{"", timestamp.Format(time.RFC3339Nano)},
+		{logs_core.TimestampFormatRFC3339, timestamp.Format(time.RFC3339Nano)},
+		{logs_core.TimestampFormatEpochMillis, float64(timestamp.UnixMilli())},
+		{logs_core.TimestampFormatEpochSeconds, float64(timestamp.Unix())},
+	}
+
+	for _, testCase := range testCases {
+		query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+		query.TimestampFormat = testCase.format
+
+		response := executeRawTestQuery(t, router, projectID, query, token)
+
+		assert.Len(t, response.Logs, 1, "format %q", testCase.format)
+		if len(response.Logs) == 1 {
+			assert.Equal(t, testCase.expected, response.Logs[0]["timestamp"], "format %q", testCase.format)
+			assert.IsType(t, testCase.expected, response.Logs[0]["createdAt"], "format %q", testCase.format)
+		}
+	}
+}
+
+func Test_ExecuteQuery_WithEpochFormat_KeepsTimeRangeFiltering(t *testing.T) {
+	router, token, projectID, uniqueID, timestamp := setupTimestampFormatTest(t, "Timestamp Format Range Test")
+
+	from := timestamp.Add(time.Second)
+	to := time.Now().UTC()
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.TimeRange = &logs_core.TimeRangeDTO{From: &from, To: &to}
+	query.TimestampFormat = logs_core.TimestampFormatEpochMillis
+
+	response := executeRawTestQuery(t, router, projectID, query, token)
+
+	assert.Empty(t, response.Logs)
+}
+
+func Test_ExecuteQuery_WithUnknownTimestampFormat_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Timestamp Format Invalid Test")
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.TimestampFormat = "unix_nanos"
+
+	ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusBadRequest)
+}
+
+func setupTimestampFormatTest(
+	t *testing.T,
+	testName string,
+) (*gin.Engine, string, uuid.UUID, string, time.Time) {
+	router := CreateLogQueryTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	uniqueID := uuid.New().String()
+	project, _ := projects_testing.CreateTestProjectWithToken(
+		fmt.Sprintf("%s %s", testName, uniqueID[:8]), owner.Token, router,
+	)
+
+	// Stored dates keep milliseconds only
+	timestamp := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+	storeLogEntriesWithTimestamp(
+		t, logs_core.GetLogCoreRepository(), project.ID, timestamp, "Timestamp format log", uniqueID, nil,
+	)
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	return router, owner.Token, project.ID, uniqueID, timestamp
+}
+
+func executeRawTestQuery(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	query *logs_core.LogQueryRequestDTO,
+	token string,
+) *rawLogsResponse {
+	var response rawLogsResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/execute/%s", projectID.String()),
+		"Bearer "+token,
+		query,
+		http.StatusOK,
+		&response,
+	)
+
+	return &response
+}
@@ -0,0 +1,66 @@
+package logs_querying_tests
+
+import (
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteQuery_WithMoreMatchesThanTrackingThreshold_TotalRelationIsGte(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Total Relation Gte Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 8, map[string]any{"service": "billing"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 8, uniqueID, "Bearer "+owner.Token)
+	setTrackTotalHitsThresholdForTest(t, 5)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Equal(t, logs_core.TotalRelationGte, response.TotalRelation)
+	assert.Equal(t, int64(5), response.Total)
+	assert.Len(t, response.Logs, 8)
+}
+
+func Test_ExecuteQuery_WithFewerMatchesThanTrackingThreshold_TotalRelationIsEq(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Total Relation Eq Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 3, map[string]any{"service": "billing"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 3, uniqueID, "Bearer "+owner.Token)
+	setTrackTotalHitsThresholdForTest(t, 5)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Equal(t, logs_core.TotalRelationEq, response.TotalRelation)
+	assert.Equal(t, int64(3), response.Total)
+}
+
+func Test_ExecuteQuery_WithTrackTotalAboveTrackingThreshold_ExactTotalReturned(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Total Relation Exact Test")
+
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 8, map[string]any{"service": "billing"})
+	WaitForLogsToBeIndexed(t, router, project.ID, 8, uniqueID, "Bearer "+owner.Token)
+	setTrackTotalHitsThresholdForTest(t, 5)
+
+	query := BuildSimpleConditionQuery("test_id", "equals", uniqueID)
+	query.TrackTotal = true
+	response := ExecuteTestQuery(t, router, project.ID, query, owner.Token, http.StatusOK)
+
+	assert.Equal(t, logs_core.TotalRelationEq, response.TotalRelation)
+	assert.Equal(t, int64(8), response.Total)
+}
+
+// setTrackTotalHitsThresholdForTest lowers the threshold so a small dataset
+// exceeds it, the default needs more than 10,000 logs
+func setTrackTotalHitsThresholdForTest(t *testing.T, threshold int) {
+	queryBuilder := logs_core.GetLogQueryBuilder()
+	previousThreshold := queryBuilder.GetTrackTotalHitsThreshold()
+
+	queryBuilder.SetTrackTotalHitsThreshold(threshold)
+	t.Cleanup(func() {
+		queryBuilder.SetTrackTotalHitsThreshold(previousThreshold)
+	})
+}
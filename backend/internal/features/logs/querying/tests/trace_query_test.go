@@ -0,0 +1,218 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTraceLogs_WhenTraceIdFieldConfigured_ReturnsFullTraceSortedByTime(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Trace Test")
+	configureTraceIdField(t, router, project, owner.Token, "trace_id")
+
+	traceID := uuid.New().String()
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 3, map[string]any{"trace_id": traceID})
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"trace_id": uuid.New().String()})
+	WaitForLogsToBeIndexed(t, router, project.ID, 5, uniqueID, "Bearer "+owner.Token)
+
+	var response logs_core.LogQueryResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/%s/trace/%s", project.ID.String(), traceID),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Logs, 3)
+	for i, log := range response.Logs {
+		assert.Equal(t, traceID, log.Fields["trace_id"])
+		if i > 0 {
+			assert.False(t, log.Timestamp.Before(response.Logs[i-1].Timestamp), "Trace logs must be sorted by time")
+		}
+	}
+}
+
+func Test_GetTraceLogs_WithSameTraceIdInOtherProject_ReturnsOnlyOwnProjectLogs(t *testing.T) {
+	router, owner, firstProject, uniqueID := SetupBasicQueryTest(t, "Trace Isolation Test 1")
+	secondProject, _ := projects_testing.CreateTestProjectWithToken(
+		"Trace Isolation Test 2 "+uniqueID[:8],
+		owner.Token,
+		router,
+	)
+	configureTraceIdField(t, router, firstProject, owner.Token, "trace_id")
+	configureTraceIdField(t, router, secondProject, owner.Token, "trace_id")
+
+	traceID := uuid.New().String()
+	SubmitLogsWithCustomFields(t, router, firstProject.ID, uniqueID, 2, map[string]any{"trace_id": traceID})
+	SubmitLogsWithCustomFields(t, router, secondProject.ID, uniqueID, 4, map[string]any{"trace_id": traceID})
+	WaitForLogsToBeIndexed(t, router, firstProject.ID, 2, uniqueID, "Bearer "+owner.Token)
+	WaitForLogsToBeIndexed(t, router, secondProject.ID, 4, uniqueID, "Bearer "+owner.Token)
+
+	var response logs_core.LogQueryResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/%s/trace/%s", firstProject.ID.String(), traceID),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Logs, 2)
+}
+
+func Test_GetTraceLogs_WithCorrelationField_ReturnsLogsSharingValueSortedByTime(t *testing.T) {
+	router, owner, firstProject, uniqueID := SetupBasicQueryTest(t, "Correlation Test 1")
+	secondProject, _ := projects_testing.CreateTestProjectWithToken(
+		"Correlation Test 2 "+uniqueID[:8],
+		owner.Token,
+		router,
+	)
+	// The configured field is not the one correlated by
+	configureTraceIdField(t, router, firstProject, owner.Token, "trace_id")
+	repository := logs_core.GetLogCoreRepository()
+
+	requestID := uuid.New().String()
+	requestStart := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	lifecycle := []string{"Request received", "Order validated", "Payment captured", "Response sent"}
+
+	// Stored newest first, so the order comes from the query
+	for i := len(lifecycle) - 1; i >= 0; i-- {
+		storeLogEntriesWithTimestamp(t, repository, firstProject.ID, requestStart.Add(time.Duration(i)*time.Second),
+			lifecycle[i], uniqueID, map[string]any{"request_id": requestID, "trace_id": uuid.New().String()})
+	}
+	storeLogEntriesWithTimestamp(t, repository, firstProject.ID, requestStart,
+		"Other request", uniqueID, map[string]any{"request_id": uuid.New().String()})
+	storeLogEntriesWithTimestamp(t, repository, secondProject.ID, requestStart,
+		"Same request id in other project", uniqueID, map[string]any{"request_id": requestID})
+	waitForTimestampLogsIndexing(t, router, firstProject.ID, uniqueID, owner.Token)
+
+	var response logs_core.LogQueryResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/%s/trace/%s?field=request_id", firstProject.ID.String(), requestID),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	// Neither the other request nor the log of the other project is included
+	assert.Equal(t, lifecycle, getMessages(response.Logs))
+	for _, log := range response.Logs {
+		assert.Equal(t, requestID, log.Fields["request_id"])
+	}
+}
+
+func Test_GetTraceLogs_WithCorrelationFieldAndNoConfiguredField_ReturnsLogs(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Correlation Without Trace Field Test")
+
+	correlationID := uuid.New().String()
+	SubmitLogsWithCustomFields(t, router, project.ID, uniqueID, 2, map[string]any{"correlation_id": correlationID})
+	WaitForLogsToBeIndexed(t, router, project.ID, 2, uniqueID, "Bearer "+owner.Token)
+
+	var response logs_core.LogQueryResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/%s/trace/%s?field=correlation_id", project.ID.String(), correlationID),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Logs, 2)
+}
+
+func Test_GetTraceLogs_WhenTraceIdFieldNotConfigured_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Trace Not Configured Test")
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/%s/trace/%s", project.ID.String(), uuid.New().String()),
+		"Bearer "+owner.Token,
+		http.StatusBadRequest,
+	)
+
+	assert.Contains(t, string(resp.Body), logs_core.ErrorTraceIdFieldNotSet)
+}
+
+func Test_GetTraceLogs_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Trace Forbidden Test")
+	configureTraceIdField(t, router, project, owner.Token, "trace_id")
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/%s/trace/%s", project.ID.String(), uuid.New().String()),
+		"Bearer "+nonMember.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_UpdateProject_WithInvalidTraceIdField_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Trace Invalid Field Test")
+
+	for _, fieldName := range []string{"message", "trace id", "trace$id"} {
+		updateData := getProjectForUpdate(t, router, project, owner.Token)
+		updateData.TraceIdField = fieldName
+
+		test_utils.MakePutRequest(
+			t,
+			router,
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			updateData,
+			http.StatusBadRequest,
+		)
+	}
+}
+
+func configureTraceIdField(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	fieldName string,
+) {
+	updateData := getProjectForUpdate(t, router, project, token)
+	updateData.TraceIdField = fieldName
+
+	updatedProject := projects_testing.UpdateProject(project, updateData, token, router)
+	assert.Equal(t, fieldName, updatedProject.TraceIdField)
+}
+
+func getProjectForUpdate(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+) *projects_models.Project {
+	var currentProject projects_models.Project
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+token,
+		http.StatusOK,
+		&currentProject,
+	)
+
+	return &currentProject
+}
@@ -6,13 +6,18 @@ import (
 	"testing"
 	"time"
 
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_annotations "logbull/internal/features/logs/annotations"
+	logs_attachments "logbull/internal/features/logs/attachments"
 	logs_core "logbull/internal/features/logs/core"
 	logs_querying "logbull/internal/features/logs/querying"
 	logs_receiving "logbull/internal/features/logs/receiving"
 	logs_receiving_tests "logbull/internal/features/logs/receiving/tests"
+	logs_saved_queries "logbull/internal/features/logs/saved_queries"
 	projects_controllers "logbull/internal/features/projects/controllers"
 	projects_models "logbull/internal/features/projects/models"
 	projects_testing "logbull/internal/features/projects/testing"
+	users_controllers "logbull/internal/features/users/controllers"
 	users_dto "logbull/internal/features/users/dto"
 	users_enums "logbull/internal/features/users/enums"
 	users_middleware "logbull/internal/features/users/middleware"
@@ -40,11 +45,30 @@ func CreateLogQueryTestRouter() *gin.Engine {
 
 	// Register controllers that need authentication
 	if routerGroup, ok := protected.(*gin.RouterGroup); ok {
-		logs_querying.GetLogQueryController().RegisterRoutes(routerGroup)
 		projects_controllers.GetProjectController().RegisterRoutes(routerGroup)
 		projects_controllers.GetMembershipController().RegisterRoutes(routerGroup)
+		users_controllers.GetPersonalAccessTokenController().RegisterRoutes(routerGroup)
+		logs_annotations.GetLogAnnotationController().RegisterRoutes(routerGroup)
+		logs_saved_queries.GetSavedQueryController().RegisterRoutes(routerGroup)
+		logs_querying.GetLogQueryController().RegisterAdminRoutes(routerGroup)
+	}
+
+	// Query routes also accept personal access tokens
+	queryable := v1.Group("").Use(users_middleware.QueryAuthMiddleware(
+		users_services.GetUserService(),
+		users_services.GetPersonalAccessTokenService(),
+		users_services.GetQueryTokenService(),
+	))
+
+	if routerGroup, ok := queryable.(*gin.RouterGroup); ok {
+		logs_querying.GetLogQueryController().RegisterRoutes(routerGroup)
+		logs_saved_queries.GetSavedQueryController().RegisterQueryRoutes(routerGroup)
+		logs_attachments.GetAttachmentController().RegisterRoutes(routerGroup)
 	}
 
+	audit_logs.SetupDependencies()
+	logs_saved_queries.SetupDependencies()
+
 	return router
 }
 
@@ -0,0 +1,126 @@
+package logs_querying_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompareWindows_WhenLogsInBothWindows_ReturnsPerWindowCountsAndDelta(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Compare Windows Test")
+	repository := logs_core.GetLogCoreRepository()
+
+	deployTime := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	beforeFrom := deployTime.Add(-time.Hour)
+	afterTo := deployTime.Add(time.Hour)
+
+	beforeStatuses := []string{"500", "200"}
+	afterStatuses := []string{"500", "500", "500", "200"}
+
+	for i, status := range beforeStatuses {
+		storeLogEntriesWithTimestamp(t, repository, project.ID, beforeFrom.Add(time.Duration(i+1)*time.Minute),
+			"Before deploy", uniqueID, map[string]any{"status": status})
+	}
+	for i, status := range afterStatuses {
+		storeLogEntriesWithTimestamp(t, repository, project.ID, deployTime.Add(time.Duration(i)*time.Minute),
+			"After deploy", uniqueID, map[string]any{"status": status})
+	}
+	// Outside of both windows
+	storeLogEntriesWithTimestamp(t, repository, project.ID, afterTo.Add(time.Minute),
+		"Later log", uniqueID, map[string]any{"status": "500"})
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	response := compareTestWindows(t, router, project.ID, &logs_querying.CompareWindowsRequestDTO{
+		Query:   BuildCondition("test_id", "equals", uniqueID),
+		Before:  logs_core.TimeRangeDTO{From: &beforeFrom, To: &deployTime},
+		After:   logs_core.TimeRangeDTO{From: &deployTime, To: &afterTo},
+		GroupBy: "status",
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(2), response.Before.Total)
+	assert.Equal(t, int64(4), response.After.Total)
+	assert.Equal(t, int64(2), response.Delta)
+	assert.NotNil(t, response.DeltaPercent)
+	assert.InDelta(t, 100.0, *response.DeltaPercent, 0.001)
+	assert.InDelta(t, 2.0/60, response.RatePerMinuteDelta, 0.001)
+
+	assert.Equal(t, []logs_querying.ValueComparisonDTO{
+		{Value: "500", Before: 1, After: 3, Delta: 2},
+		{Value: "200", Before: 1, After: 1, Delta: 0},
+	}, response.TopValues)
+}
+
+func Test_CompareWindows_WhenBeforeWindowIsEmpty_DeltaPercentIsNull(t *testing.T) {
+	router, owner, project, uniqueID := SetupBasicQueryTest(t, "Compare Empty Window Test")
+	repository := logs_core.GetLogCoreRepository()
+
+	deployTime := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	beforeFrom := deployTime.Add(-time.Hour)
+	afterTo := deployTime.Add(time.Hour)
+
+	storeLogEntriesWithTimestamp(t, repository, project.ID, deployTime.Add(time.Minute), "After deploy", uniqueID, nil)
+	waitForTimestampLogsIndexing(t, router, project.ID, uniqueID, owner.Token)
+
+	response := compareTestWindows(t, router, project.ID, &logs_querying.CompareWindowsRequestDTO{
+		Query:  BuildCondition("test_id", "equals", uniqueID),
+		Before: logs_core.TimeRangeDTO{From: &beforeFrom, To: &deployTime},
+		After:  logs_core.TimeRangeDTO{From: &deployTime, To: &afterTo},
+	}, owner.Token, http.StatusOK)
+
+	assert.Equal(t, int64(0), response.Before.Total)
+	assert.Equal(t, int64(1), response.After.Total)
+	assert.Equal(t, int64(1), response.Delta)
+	assert.Nil(t, response.DeltaPercent)
+	assert.Empty(t, response.TopValues)
+}
+
+func Test_CompareWindows_WhenWindowIsInvalid_ReturnsBadRequest(t *testing.T) {
+	router, owner, project, _ := SetupBasicQueryTest(t, "Compare Invalid Windows Test")
+
+	now := time.Now().UTC()
+	hourAgo := now.Add(-time.Hour)
+
+	invalidRequests := []*logs_querying.CompareWindowsRequestDTO{
+		{Before: logs_core.TimeRangeDTO{From: &hourAgo}, After: logs_core.TimeRangeDTO{From: &hourAgo, To: &now}},
+		{Before: logs_core.TimeRangeDTO{From: &now, To: &hourAgo}, After: logs_core.TimeRangeDTO{From: &hourAgo, To: &now}},
+		{
+			Before:  logs_core.TimeRangeDTO{From: &hourAgo, To: &now},
+			After:   logs_core.TimeRangeDTO{From: &hourAgo, To: &now},
+			GroupBy: "timestamp",
+		},
+	}
+
+	for _, request := range invalidRequests {
+		compareTestWindows(t, router, project.ID, request, owner.Token, http.StatusBadRequest)
+	}
+}
+
+func compareTestWindows(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	request *logs_querying.CompareWindowsRequestDTO,
+	token string,
+	expectedStatus int,
+) *logs_querying.CompareWindowsResponseDTO {
+	url := fmt.Sprintf("/api/v1/logs/query/compare/%s", projectID.String())
+
+	if expectedStatus != http.StatusOK {
+		test_utils.MakePostRequest(t, router, url, "Bearer "+token, request, expectedStatus)
+		return nil
+	}
+
+	var response logs_querying.CompareWindowsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(t, router, url, "Bearer "+token, request, expectedStatus, &response)
+
+	return &response
+}
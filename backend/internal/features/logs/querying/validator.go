@@ -3,23 +3,87 @@ package logs_querying
 import (
 	"fmt"
 	"log/slog"
+	"maps"
+	"regexp/syntax"
 	"strings"
+	"time"
 
 	logs_core "logbull/internal/features/logs/core"
 )
 
 type QueryValidator struct {
 	logger *slog.Logger
+	limits QueryLimits
+}
+
+// QueryLimits bound the complexity of accepted queries. Zero values mean the
+// defaults, larger values than the ceilings are lowered to them
+type QueryLimits struct {
+	MaxDepth       int // Maximum nesting depth
+	MaxNodes       int // Maximum total nodes in query tree
+	MaxChildren    int // Maximum children per logical node
+	MaxArrayValues int // Maximum values of IN / NOT IN conditions
+	MaxValueLength int // Maximum value length
 }
 
 const (
-	// Query complexity limits
-	maxQueryDepth    = 10   // Maximum nesting depth
-	maxQueryNodes    = 50   // Maximum total nodes in query tree
-	maxValueLength   = 1000 // Maximum value length
-	maxChildrenCount = 20   // Maximum children per logical node
+	// Default query complexity limits
+	defaultMaxQueryDepth  = 10
+	defaultMaxQueryNodes  = 50
+	defaultMaxChildren    = 20
+	defaultMaxArrayValues = 100
+	defaultMaxValueLength = 1000
+
+	// Bounds of regex patterns, OpenSearch rejects patterns whose automaton gets
+	// too large only after building part of it
+	maxRegexPatternLength = 256
+	maxRegexRepeatCount   = 100
+
+	// Ceilings of configured limits, higher ones would let single queries
+	// overload OpenSearch
+	MaxQueryDepthCeiling  = 32
+	MaxQueryNodesCeiling  = 500
+	MaxChildrenCeiling    = 200
+	MaxArrayValuesCeiling = 1000
+	MaxValueLengthCeiling = 10000
 )
 
+func DefaultQueryLimits() QueryLimits {
+	return QueryLimits{
+		MaxDepth:       defaultMaxQueryDepth,
+		MaxNodes:       defaultMaxQueryNodes,
+		MaxChildren:    defaultMaxChildren,
+		MaxArrayValues: defaultMaxArrayValues,
+		MaxValueLength: defaultMaxValueLength,
+	}
+}
+
+func (l QueryLimits) normalized() QueryLimits {
+	return QueryLimits{
+		MaxDepth:       normalizeQueryLimit(l.MaxDepth, defaultMaxQueryDepth, MaxQueryDepthCeiling),
+		MaxNodes:       normalizeQueryLimit(l.MaxNodes, defaultMaxQueryNodes, MaxQueryNodesCeiling),
+		MaxChildren:    normalizeQueryLimit(l.MaxChildren, defaultMaxChildren, MaxChildrenCeiling),
+		MaxArrayValues: normalizeQueryLimit(l.MaxArrayValues, defaultMaxArrayValues, MaxArrayValuesCeiling),
+		MaxValueLength: normalizeQueryLimit(l.MaxValueLength, defaultMaxValueLength, MaxValueLengthCeiling),
+	}
+}
+
+func normalizeQueryLimit(limit, defaultLimit, ceiling int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+
+	return min(limit, ceiling)
+}
+
+func (v *QueryValidator) SetLimits(limits QueryLimits) {
+	v.limits = limits.normalized()
+}
+
+func (v *QueryValidator) GetLimits() QueryLimits {
+	return v.limits.normalized()
+}
+
 func (v *QueryValidator) ValidateQuery(query *logs_core.QueryNode) error {
 	// Allow nil queries - they represent "return all logs within time period"
 	if query == nil {
@@ -38,19 +102,21 @@ func (v *QueryValidator) ValidateQuery(query *logs_core.QueryNode) error {
 }
 
 func (v *QueryValidator) validateComplexity(query *logs_core.QueryNode) error {
+	limits := v.GetLimits()
+
 	depth := v.calculateQueryDepth(query, 0)
-	if depth > maxQueryDepth {
+	if depth > limits.MaxDepth {
 		return &ValidationError{
 			Code:    logs_core.ErrorQueryTooComplex,
-			Message: fmt.Sprintf("query depth %d exceeds maximum %d", depth, maxQueryDepth),
+			Message: fmt.Sprintf("query depth %d exceeds maximum %d", depth, limits.MaxDepth),
 		}
 	}
 
 	nodeCount := v.countQueryNodes(query)
-	if nodeCount > maxQueryNodes {
+	if nodeCount > limits.MaxNodes {
 		return &ValidationError{
 			Code:    logs_core.ErrorQueryTooComplex,
-			Message: fmt.Sprintf("query has %d nodes, maximum allowed is %d", nodeCount, maxQueryNodes),
+			Message: fmt.Sprintf("query has %d nodes, maximum allowed is %d", nodeCount, limits.MaxNodes),
 		}
 	}
 
@@ -94,7 +160,8 @@ func (v *QueryValidator) validateLogicalNode(node *logs_core.QueryNode, depth in
 
 	if logic.Operator != logs_core.LogicalOperatorAnd &&
 		logic.Operator != logs_core.LogicalOperatorOr &&
-		logic.Operator != logs_core.LogicalOperatorNot {
+		logic.Operator != logs_core.LogicalOperatorNot &&
+		logic.Operator != logs_core.LogicalOperatorNoneOf {
 		return &ValidationError{
 			Code:    logs_core.ErrorInvalidQueryStructure,
 			Message: fmt.Sprintf("invalid logical operator: %s", logic.Operator),
@@ -108,22 +175,23 @@ func (v *QueryValidator) validateLogicalNode(node *logs_core.QueryNode, depth in
 		}
 	}
 
-	if len(logic.Children) > maxChildrenCount {
+	maxChildren := v.GetLimits().MaxChildren
+	if len(logic.Children) > maxChildren {
 		return &ValidationError{
 			Code: logs_core.ErrorQueryTooComplex,
 			Message: fmt.Sprintf(
 				"logical node has %d children, maximum allowed is %d",
 				len(logic.Children),
-				maxChildrenCount,
+				maxChildren,
 			),
 		}
 	}
 
-	// Special case for NOT: should have exactly one child
+	// Special case for NOT: should have exactly one child, none_of takes several
 	if logic.Operator == logs_core.LogicalOperatorNot && len(logic.Children) > 1 {
 		return &ValidationError{
 			Code:    logs_core.ErrorInvalidQueryStructure,
-			Message: "NOT operator should have exactly one child",
+			Message: "NOT operator should have exactly one child, use none_of to exclude several conditions",
 		}
 	}
 
@@ -146,6 +214,15 @@ func (v *QueryValidator) validateConditionNode(node *logs_core.QueryNode) error
 
 	condition := node.Condition
 
+	if err := v.validateCaseInsensitive(condition); err != nil {
+		return err
+	}
+
+	if condition.Operator == logs_core.ConditionOperatorMissingAny ||
+		condition.Operator == logs_core.ConditionOperatorMissingAll {
+		return v.validateMissingFieldsCondition(condition)
+	}
+
 	if err := v.validateField(condition.Field); err != nil {
 		return err
 	}
@@ -162,6 +239,70 @@ func (v *QueryValidator) validateConditionNode(node *logs_core.QueryNode) error
 		return err
 	}
 
+	if err := v.validateCustomFieldRangeValue(condition); err != nil {
+		return err
+	}
+
+	if condition.Operator == logs_core.ConditionOperatorBetween {
+		return v.validateBetweenValue(condition.Value)
+	}
+
+	if condition.Operator == logs_core.ConditionOperatorRegex {
+		return v.validateRegexValue(condition.Value)
+	}
+
+	return nil
+}
+
+// validateMissingFieldsCondition checks missing_any and missing_all, which take
+// the fields in the value instead of the field
+func (v *QueryValidator) validateMissingFieldsCondition(condition *logs_core.ConditionNode) error {
+	if strings.TrimSpace(condition.Field) != "" {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("%s takes the fields as the value, field must be empty", condition.Operator),
+		}
+	}
+
+	var values []any
+	switch typedValue := condition.Value.(type) {
+	case []any:
+		values = typedValue
+	case []string:
+		for _, value := range typedValue {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("%s requires a non-empty array of field names", condition.Operator),
+		}
+	}
+
+	maxArrayValues := v.GetLimits().MaxArrayValues
+	if len(values) > maxArrayValues {
+		return &ValidationError{
+			Code:    logs_core.ErrorQueryTooComplex,
+			Message: fmt.Sprintf("array value has too many elements (max %d)", maxArrayValues),
+		}
+	}
+
+	for _, value := range values {
+		fieldName, isString := value.(string)
+		if !isString {
+			return &ValidationError{
+				Code:    logs_core.ErrorInvalidQueryStructure,
+				Message: fmt.Sprintf("%s requires field names as strings", condition.Operator),
+			}
+		}
+
+		if err := v.validateField(fieldName); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -186,14 +327,20 @@ func (v *QueryValidator) validateOperator(operator logs_core.ConditionOperator)
 		logs_core.ConditionOperatorNotEquals:      true,
 		logs_core.ConditionOperatorContains:       true,
 		logs_core.ConditionOperatorNotContains:    true,
+		logs_core.ConditionOperatorStartsWith:     true,
+		logs_core.ConditionOperatorEndsWith:       true,
+		logs_core.ConditionOperatorRegex:          true,
 		logs_core.ConditionOperatorGreaterThan:    true,
 		logs_core.ConditionOperatorGreaterOrEqual: true,
 		logs_core.ConditionOperatorLessThan:       true,
 		logs_core.ConditionOperatorLessOrEqual:    true,
+		logs_core.ConditionOperatorBetween:        true,
 		logs_core.ConditionOperatorIn:             true,
 		logs_core.ConditionOperatorNotIn:          true,
 		logs_core.ConditionOperatorExists:         true,
 		logs_core.ConditionOperatorNotExists:      true,
+		logs_core.ConditionOperatorMissingAny:     true,
+		logs_core.ConditionOperatorMissingAll:     true,
 	}
 
 	if !validOperators[operator] {
@@ -215,6 +362,7 @@ func (v *QueryValidator) validateValue(value any, operator logs_core.ConditionOp
 		}
 	}
 
+	maxValueLength := v.GetLimits().MaxValueLength
 	strValue := fmt.Sprintf("%v", value)
 	if len(strValue) > maxValueLength {
 		return &ValidationError{
@@ -231,37 +379,217 @@ func (v *QueryValidator) validateValue(value any, operator logs_core.ConditionOp
 }
 
 func (v *QueryValidator) validateArrayValue(value interface{}) error {
-	switch v := value.(type) {
+	var valuesCount int
+	switch values := value.(type) {
 	case []interface{}:
-		if len(v) == 0 {
-			return &ValidationError{
-				Code:    logs_core.ErrorInvalidQueryStructure,
-				Message: "array value cannot be empty for IN/NOT IN operators",
-			}
+		valuesCount = len(values)
+	case []string:
+		valuesCount = len(values)
+	default:
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "IN/NOT IN operators require array values",
+		}
+	}
+
+	if valuesCount == 0 {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "array value cannot be empty for IN/NOT IN operators",
+		}
+	}
+
+	maxArrayValues := v.GetLimits().MaxArrayValues
+	if valuesCount > maxArrayValues {
+		return &ValidationError{
+			Code:    logs_core.ErrorQueryTooComplex,
+			Message: fmt.Sprintf("array value has too many elements (max %d)", maxArrayValues),
+		}
+	}
+
+	return nil
+}
+
+// validateCaseInsensitive allows case insensitive matching only for string
+// operators which compare values, and not on timestamp fields
+func (v *QueryValidator) validateCaseInsensitive(condition *logs_core.ConditionNode) error {
+	if !condition.CaseInsensitive {
+		return nil
+	}
+
+	caseInsensitiveOperators := map[logs_core.ConditionOperator]bool{
+		logs_core.ConditionOperatorEquals:      true,
+		logs_core.ConditionOperatorNotEquals:   true,
+		logs_core.ConditionOperatorContains:    true,
+		logs_core.ConditionOperatorNotContains: true,
+		logs_core.ConditionOperatorStartsWith:  true,
+		logs_core.ConditionOperatorEndsWith:    true,
+		logs_core.ConditionOperatorRegex:       true,
+		logs_core.ConditionOperatorIn:          true,
+		logs_core.ConditionOperatorNotIn:       true,
+	}
+
+	if !caseInsensitiveOperators[condition.Operator] {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("case insensitive matching is not supported for operator %s", condition.Operator),
 		}
-		if len(v) > 100 { // Reasonable limit for IN clauses
+	}
+
+	switch strings.TrimSpace(condition.Field) {
+	case "timestamp", "created_at":
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: fmt.Sprintf("case insensitive matching is not supported for field %s", condition.Field),
+		}
+	}
+
+	return nil
+}
+
+// validateBetweenValue requires [from, to] as RFC3339 timestamps with from not
+// after to
+func (v *QueryValidator) validateBetweenValue(value any) error {
+	var values []any
+	switch typedValue := value.(type) {
+	case []any:
+		values = typedValue
+	case []string:
+		for _, item := range typedValue {
+			values = append(values, item)
+		}
+	}
+
+	if len(values) != 2 {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "between requires an array of two timestamps [from, to]",
+		}
+	}
+
+	bounds := make([]time.Time, 0, 2)
+	for _, item := range values {
+		stringValue, isString := item.(string)
+		if !isString {
 			return &ValidationError{
-				Code:    logs_core.ErrorQueryTooComplex,
-				Message: "array value has too many elements (max 100)",
+				Code:    logs_core.ErrorInvalidQueryStructure,
+				Message: "between bounds must be RFC3339 timestamps",
 			}
 		}
-	case []string:
-		if len(v) == 0 {
+
+		bound, err := time.Parse(time.RFC3339Nano, stringValue)
+		if err != nil {
 			return &ValidationError{
 				Code:    logs_core.ErrorInvalidQueryStructure,
-				Message: "array value cannot be empty for IN/NOT IN operators",
+				Message: fmt.Sprintf("between bound %q is not an RFC3339 timestamp", stringValue),
 			}
 		}
-		if len(v) > 100 {
+		bounds = append(bounds, bound)
+	}
+
+	if bounds[0].After(bounds[1]) {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "between requires from to be before or equal to to",
+		}
+	}
+
+	return nil
+}
+
+// validateRegexValue accepts patterns OpenSearch can run without overloading
+// itself. Regexp queries compile to automata, so patterns are bounded by length,
+// repetition counts and nesting of repetitions, which multiply automaton states
+func (v *QueryValidator) validateRegexValue(value any) error {
+	pattern, isString := value.(string)
+	if !isString || pattern == "" {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: "regex requires a non-empty pattern string",
+		}
+	}
+
+	if len(pattern) > maxRegexPatternLength {
+		return &ValidationError{
+			Code:    logs_core.ErrorQueryTooComplex,
+			Message: fmt.Sprintf("regex pattern length %d exceeds maximum %d", len(pattern), maxRegexPatternLength),
+		}
+	}
+
+	parsed, err := logs_core.ParseRegexPattern(pattern)
+	if err != nil {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: err.Error(),
+		}
+	}
+
+	if err := validateRegexComplexity(parsed, false); err != nil {
+		return err
+	}
+
+	if _, err := logs_core.ToLuceneRegexp(pattern); err != nil {
+		return &ValidationError{
+			Code:    logs_core.ErrorInvalidQueryStructure,
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// validateRegexComplexity rejects repetitions inside repetitions, like (a+)+,
+// and repetition counts above maxRegexRepeatCount
+func validateRegexComplexity(re *syntax.Regexp, isInsideRepetition bool) error {
+	isRepetition := false
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus:
+		isRepetition = true
+	case syntax.OpRepeat:
+		if re.Min > maxRegexRepeatCount || re.Max > maxRegexRepeatCount {
 			return &ValidationError{
 				Code:    logs_core.ErrorQueryTooComplex,
-				Message: "array value has too many elements (max 100)",
+				Message: fmt.Sprintf("regex repetition count exceeds maximum %d", maxRegexRepeatCount),
 			}
 		}
+		isRepetition = re.Max == -1 || re.Max > 1
+	}
+
+	if isRepetition && isInsideRepetition {
+		return &ValidationError{
+			Code:    logs_core.ErrorQueryTooComplex,
+			Message: "nested regex repetitions like (a+)+ are not allowed",
+		}
+	}
+
+	for _, sub := range re.Sub {
+		if err := validateRegexComplexity(sub, isInsideRepetition || isRepetition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateCustomFieldRangeValue requires a number for range operators on custom
+// fields, as only the numeric values of the field are compared
+func (v *QueryValidator) validateCustomFieldRangeValue(condition *logs_core.ConditionNode) error {
+	switch condition.Operator {
+	case logs_core.ConditionOperatorGreaterThan, logs_core.ConditionOperatorGreaterOrEqual,
+		logs_core.ConditionOperatorLessThan, logs_core.ConditionOperatorLessOrEqual:
 	default:
+		return nil
+	}
+
+	switch condition.Field {
+	case "message", "level", "client_ip", "timestamp", "created_at":
+		return nil
+	}
+
+	if _, isNumber := logs_core.ToNumber(condition.Value); !isNumber {
 		return &ValidationError{
 			Code:    logs_core.ErrorInvalidQueryStructure,
-			Message: "IN/NOT IN operators require array values",
+			Message: fmt.Sprintf("operator %s on custom field %s requires a numeric value", condition.Operator, condition.Field),
 		}
 	}
 
@@ -274,6 +602,9 @@ func (v *QueryValidator) validateFieldOperatorCompatibility(field string, operat
 		logs_core.ConditionOperatorNotEquals:   true,
 		logs_core.ConditionOperatorContains:    true,
 		logs_core.ConditionOperatorNotContains: true,
+		logs_core.ConditionOperatorStartsWith:  true,
+		logs_core.ConditionOperatorEndsWith:    true,
+		logs_core.ConditionOperatorRegex:       true,
 		logs_core.ConditionOperatorIn:          true,
 		logs_core.ConditionOperatorNotIn:       true,
 		logs_core.ConditionOperatorExists:      true,
@@ -291,7 +622,12 @@ func (v *QueryValidator) validateFieldOperatorCompatibility(field string, operat
 		logs_core.ConditionOperatorNotExists:      true,
 	}
 
-	timestampOperators := numericOperators
+	timestampOperators := maps.Clone(numericOperators)
+	timestampOperators[logs_core.ConditionOperatorBetween] = true
+
+	// Custom fields hold strings or numbers, range operators compare the numbers
+	customFieldOperators := maps.Clone(stringOperators)
+	maps.Copy(customFieldOperators, numericOperators)
 
 	switch field {
 	case "message", "level", "client_ip":
@@ -309,8 +645,7 @@ func (v *QueryValidator) validateFieldOperatorCompatibility(field string, operat
 			}
 		}
 	default:
-		// Custom fields - allow string operations by default
-		if !stringOperators[operator] {
+		if !customFieldOperators[operator] {
 			return &ValidationError{
 				Code:    logs_core.ErrorInvalidQueryStructure,
 				Message: fmt.Sprintf("operator %s is not compatible with custom field %s", operator, field),
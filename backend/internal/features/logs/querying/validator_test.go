@@ -162,7 +162,7 @@ func Test_ValidateLogicalNode_WithInvalidStructure_ReturnsErrors(t *testing.T) {
 				*createValidSimpleConditionQuery(),
 			}),
 			logs_core.ErrorInvalidQueryStructure,
-			"NOT operator should have exactly one child",
+			"NOT operator should have exactly one child, use none_of to exclude several conditions",
 		},
 	}
 
@@ -184,6 +184,7 @@ func Test_ValidateLogicalNode_WithValidOperators_ReturnsNoError(t *testing.T) {
 		logs_core.LogicalOperatorAnd,
 		logs_core.LogicalOperatorOr,
 		logs_core.LogicalOperatorNot,
+		logs_core.LogicalOperatorNoneOf,
 	}
 
 	validator := createValidator()
@@ -197,6 +198,19 @@ func Test_ValidateLogicalNode_WithValidOperators_ReturnsNoError(t *testing.T) {
 	}
 }
 
+func Test_ValidateLogicalNode_WithNoneOfMultipleChildren_ReturnsNoError(t *testing.T) {
+	validator := createValidator()
+	node := createLogicalNode(logs_core.LogicalOperatorNoneOf, []logs_core.QueryNode{
+		*createValidSimpleConditionQuery(),
+		*createValidSimpleConditionQuery(),
+		*createValidSimpleConditionQuery(),
+	})
+
+	err := validator.validateLogicalNode(node, 0)
+
+	assert.NoError(t, err)
+}
+
 func Test_ValidateLogicalNode_WithTooManyChildren_ReturnsQueryTooComplexError(t *testing.T) {
 	validator := createValidator()
 	children := make([]logs_core.QueryNode, 21) // Exceeds maxChildrenCount = 20
@@ -275,6 +289,62 @@ func Test_ValidateConditionNode_WithValidCondition_ReturnsNoError(t *testing.T)
 	assert.NoError(t, err)
 }
 
+func Test_ValidateConditionNode_WithMissingFieldsOperators_ValidatesFieldList(t *testing.T) {
+	tests := []struct {
+		name      string
+		node      *logs_core.QueryNode
+		errorCode string
+	}{
+		{
+			"Field list",
+			createConditionNode("", logs_core.ConditionOperatorMissingAny, []any{"user_id", "request_id"}),
+			"",
+		},
+		{
+			"Field list of strings",
+			createConditionNode("", logs_core.ConditionOperatorMissingAll, []string{"user_id"}),
+			"",
+		},
+		{
+			"Field set",
+			createConditionNode("user_id", logs_core.ConditionOperatorMissingAny, []any{"request_id"}),
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Empty field list",
+			createConditionNode("", logs_core.ConditionOperatorMissingAll, []any{}),
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Not a list",
+			createConditionNode("", logs_core.ConditionOperatorMissingAny, "user_id"),
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Empty field name",
+			createConditionNode("", logs_core.ConditionOperatorMissingAny, []any{"user_id", " "}),
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Non-string field name",
+			createConditionNode("", logs_core.ConditionOperatorMissingAll, []any{"user_id", 42}),
+			logs_core.ErrorInvalidQueryStructure,
+		},
+	}
+
+	validator := createValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateConditionNode(tt.node)
+			if tt.errorCode == "" {
+				assert.NoError(t, err)
+			} else {
+				assertValidationError(t, err, tt.errorCode)
+			}
+		})
+	}
+}
+
 // Field validation tests
 func Test_ValidateField_WithValidFields_ReturnsNoError(t *testing.T) {
 	validFields := []string{
@@ -319,6 +389,8 @@ func Test_ValidateOperator_WithValidOperators_ReturnsNoError(t *testing.T) {
 	validOperators := []logs_core.ConditionOperator{
 		logs_core.ConditionOperatorEquals,
 		logs_core.ConditionOperatorContains,
+		logs_core.ConditionOperatorStartsWith,
+		logs_core.ConditionOperatorEndsWith,
 		logs_core.ConditionOperatorGreaterThan,
 		logs_core.ConditionOperatorIn,
 		logs_core.ConditionOperatorExists,
@@ -450,6 +522,48 @@ func Test_ValidateFieldOperatorCompatibility_ReturnsCorrectResults(t *testing.T)
 		},
 		{"Custom field with Equals", "user_id", logs_core.ConditionOperatorEquals, false, ""},
 		{"Level with In", "level", logs_core.ConditionOperatorIn, false, ""},
+		{"Message with StartsWith", "message", logs_core.ConditionOperatorStartsWith, false, ""},
+		{"Custom field with EndsWith", "hostname", logs_core.ConditionOperatorEndsWith, false, ""},
+		{"Custom field with GreaterThan", "response_time", logs_core.ConditionOperatorGreaterThan, false, ""},
+		{"Custom field with LessOrEqual", "status_code", logs_core.ConditionOperatorLessOrEqual, false, ""},
+		{"Timestamp with Between", "timestamp", logs_core.ConditionOperatorBetween, false, ""},
+		{"Message with Regex", "message", logs_core.ConditionOperatorRegex, false, ""},
+		{"Custom field with Regex", "order_id", logs_core.ConditionOperatorRegex, false, ""},
+		{
+			"Timestamp with Regex",
+			"timestamp",
+			logs_core.ConditionOperatorRegex,
+			true,
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Message with Between",
+			"message",
+			logs_core.ConditionOperatorBetween,
+			true,
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Custom field with Between",
+			"response_time",
+			logs_core.ConditionOperatorBetween,
+			true,
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Timestamp with StartsWith",
+			"timestamp",
+			logs_core.ConditionOperatorStartsWith,
+			true,
+			logs_core.ErrorInvalidQueryStructure,
+		},
+		{
+			"Timestamp with EndsWith",
+			"timestamp",
+			logs_core.ConditionOperatorEndsWith,
+			true,
+			logs_core.ErrorInvalidQueryStructure,
+		},
 	}
 
 	validator := createValidator()
@@ -609,6 +723,216 @@ func Test_ValidateQuery_WithChainedNOTOperators_ReturnsNoError(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_ValidateQuery_WithRegexOperator_AcceptsOnlyBoundedPatterns(t *testing.T) {
+	tests := []struct {
+		name              string
+		pattern           any
+		expectedErrorCode string
+	}{
+		{"Digits class", `ORD-\d{6}`, ""},
+		{"Alternation and groups", `(GET|POST) /api/(users|orders)/[0-9]+`, ""},
+		{"Repetition of alternation", `(ab|cd)*e`, ""},
+		{"Special characters of Lucene", `"quoted" <tag> @user #1 a&b ~x`, ""},
+		{"Not a string", 42, logs_core.ErrorInvalidQueryStructure},
+		{"Empty pattern", "", logs_core.ErrorInvalidQueryStructure},
+		{"Unclosed group", `ORD-(\d{6}`, logs_core.ErrorInvalidQueryStructure},
+		{"Unclosed class", `[a-z`, logs_core.ErrorInvalidQueryStructure},
+		{"Anchor", `ORD-\d+$`, logs_core.ErrorInvalidQueryStructure},
+		{"Word boundary", `\bORD`, logs_core.ErrorInvalidQueryStructure},
+		{"Inline flags", `(?i)ord`, logs_core.ErrorInvalidQueryStructure},
+		{"Non-capturing group", `(?:ab)+`, logs_core.ErrorInvalidQueryStructure},
+		{"Nested plus", `(a+)+b`, logs_core.ErrorQueryTooComplex},
+		{"Nested star", `(.*a)*`, logs_core.ErrorQueryTooComplex},
+		{"Nested counted repetition", `(a{2,5}){3,}`, logs_core.ErrorQueryTooComplex},
+		{"Large repetition", `a{101}`, logs_core.ErrorQueryTooComplex},
+		{"Too long", strings.Repeat("a", maxRegexPatternLength+1), logs_core.ErrorQueryTooComplex},
+	}
+
+	validator := createValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateQuery(createConditionNode("message", logs_core.ConditionOperatorRegex, tt.pattern))
+			if tt.expectedErrorCode != "" {
+				assertValidationError(t, err, tt.expectedErrorCode)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateQuery_WithBetweenOperator_RequiresTwoRFC3339Timestamps(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       any
+		expectError bool
+	}{
+		{"Two timestamps", []any{"2025-10-16T10:00:00Z", "2025-10-16T11:00:00.5+02:00"}, false},
+		{"Same timestamp twice", []any{"2025-10-16T10:00:00Z", "2025-10-16T10:00:00Z"}, false},
+		{"Single timestamp", "2025-10-16T10:00:00Z", true},
+		{"One element", []any{"2025-10-16T10:00:00Z"}, true},
+		{"Three elements", []any{"2025-10-16T10:00:00Z", "2025-10-16T11:00:00Z", "2025-10-16T12:00:00Z"}, true},
+		{"Not RFC3339", []any{"2025-10-16 10:00:00", "2025-10-16T11:00:00Z"}, true},
+		{"Numbers", []any{1760608800, 1760612400}, true},
+		{"From after to", []any{"2025-10-16T11:00:00Z", "2025-10-16T10:00:00Z"}, true},
+	}
+
+	validator := createValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateQuery(createConditionNode("timestamp", logs_core.ConditionOperatorBetween, tt.value))
+			if tt.expectError {
+				assertValidationError(t, err, logs_core.ErrorInvalidQueryStructure)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateQuery_WithRangeOperatorOnCustomField_RequiresNumericValue(t *testing.T) {
+	validator := createValidator()
+
+	assert.NoError(t, validator.ValidateQuery(
+		createConditionNode("response_time", logs_core.ConditionOperatorGreaterThan, 500),
+	))
+	assert.NoError(t, validator.ValidateQuery(
+		createConditionNode("response_time", logs_core.ConditionOperatorLessThan, "1.5"),
+	))
+
+	err := validator.ValidateQuery(
+		createConditionNode("response_time", logs_core.ConditionOperatorGreaterThan, "slow"),
+	)
+	assertValidationError(t, err, logs_core.ErrorInvalidQueryStructure)
+}
+
+func Test_ValidateQuery_WithCaseInsensitive_AllowedOnlyForStringOperators(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       string
+		operator    logs_core.ConditionOperator
+		value       any
+		expectError bool
+	}{
+		{"Custom field with Equals", "user_agent", logs_core.ConditionOperatorEquals, "Mozilla", false},
+		{"Custom field with NotEquals", "user_agent", logs_core.ConditionOperatorNotEquals, "Mozilla", false},
+		{"Message with Contains", "message", logs_core.ConditionOperatorContains, "error", false},
+		{"Message with NotContains", "message", logs_core.ConditionOperatorNotContains, "error", false},
+		{"Level with In", "level", logs_core.ConditionOperatorIn, []any{"info", "Error"}, false},
+		{"Custom field with Exists", "user_agent", logs_core.ConditionOperatorExists, nil, true},
+		{"Custom field with NotExists", "user_agent", logs_core.ConditionOperatorNotExists, nil, true},
+		{"Timestamp with GreaterThan", "timestamp", logs_core.ConditionOperatorGreaterThan, "2024-01-01T00:00:00Z", true},
+		{"Timestamp with Equals", "timestamp", logs_core.ConditionOperatorEquals, "2024-01-01T00:00:00Z", true},
+	}
+
+	validator := createValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := createConditionNode(tt.field, tt.operator, tt.value)
+			query.Condition.CaseInsensitive = true
+
+			err := validator.ValidateQuery(query)
+			if tt.expectError {
+				assertValidationError(t, err, logs_core.ErrorInvalidQueryStructure)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Configured limits tests
+func Test_ValidateQuery_WithLoweredLimits_EnforcesConfiguredLimits(t *testing.T) {
+	validator := createValidator()
+	validator.SetLimits(QueryLimits{
+		MaxDepth:       3,
+		MaxNodes:       5,
+		MaxChildren:    4,
+		MaxArrayValues: 2,
+		MaxValueLength: 10,
+	})
+
+	assert.NoError(t, validator.ValidateQuery(createDeepNestedQuery(3)))
+	assertValidationErrorWithMessage(
+		t, validator.ValidateQuery(createDeepNestedQuery(4)), logs_core.ErrorQueryTooComplex, "maximum 3",
+	)
+
+	fiveChildren := make([]logs_core.QueryNode, 5)
+	for i := range fiveChildren {
+		fiveChildren[i] = *createValidSimpleConditionQuery()
+	}
+	assertValidationErrorWithMessage(
+		t,
+		validator.ValidateQuery(createLogicalNode(logs_core.LogicalOperatorAnd, fiveChildren)),
+		logs_core.ErrorQueryTooComplex,
+		"maximum allowed is 5",
+	)
+
+	validator.SetLimits(QueryLimits{MaxNodes: 10, MaxChildren: 4})
+	assertValidationErrorWithMessage(
+		t,
+		validator.ValidateQuery(createLogicalNode(logs_core.LogicalOperatorAnd, fiveChildren)),
+		logs_core.ErrorQueryTooComplex,
+		"maximum allowed is 4",
+	)
+
+	validator.SetLimits(QueryLimits{MaxArrayValues: 2, MaxValueLength: 10})
+	assert.NoError(t, validator.ValidateQuery(
+		createConditionNode("service", logs_core.ConditionOperatorIn, []interface{}{"a", "b"}),
+	))
+	assertValidationErrorWithMessage(
+		t,
+		validator.ValidateQuery(createConditionNode("service", logs_core.ConditionOperatorIn, []string{"a", "b", "c"})),
+		logs_core.ErrorQueryTooComplex,
+		"max 2",
+	)
+	assertValidationErrorWithMessage(
+		t,
+		validator.ValidateQuery(createConditionNode("message", logs_core.ConditionOperatorEquals, "longer value")),
+		logs_core.ErrorInvalidQueryStructure,
+		"exceeds maximum 10",
+	)
+}
+
+func Test_ValidateQuery_WithRaisedLimits_AcceptsLargerQueries(t *testing.T) {
+	validator := createValidator()
+
+	values := make([]interface{}, 150)
+	for i := range values {
+		values[i] = i
+	}
+	largeInQuery := createConditionNode("service", logs_core.ConditionOperatorIn, values)
+
+	assertValidationError(t, validator.ValidateQuery(createDeepNestedQuery(12)), logs_core.ErrorQueryTooComplex)
+	assertValidationError(t, validator.ValidateQuery(largeInQuery), logs_core.ErrorQueryTooComplex)
+
+	validator.SetLimits(QueryLimits{MaxDepth: 15, MaxArrayValues: 200})
+
+	assert.NoError(t, validator.ValidateQuery(createDeepNestedQuery(12)))
+	assert.NoError(t, validator.ValidateQuery(largeInQuery))
+}
+
+func Test_SetLimits_WithZeroOrExcessiveValues_UsesDefaultsAndCeilings(t *testing.T) {
+	validator := createValidator()
+	assert.Equal(t, DefaultQueryLimits(), validator.GetLimits())
+
+	validator.SetLimits(QueryLimits{
+		MaxDepth:       1000,
+		MaxNodes:       100000,
+		MaxChildren:    -1,
+		MaxArrayValues: 1000000,
+		MaxValueLength: 0,
+	})
+
+	assert.Equal(t, QueryLimits{
+		MaxDepth:       MaxQueryDepthCeiling,
+		MaxNodes:       MaxQueryNodesCeiling,
+		MaxChildren:    DefaultQueryLimits().MaxChildren,
+		MaxArrayValues: MaxArrayValuesCeiling,
+		MaxValueLength: DefaultQueryLimits().MaxValueLength,
+	}, validator.GetLimits())
+}
+
 // Private helper functions - moved to bottom per coding standards
 
 func createValidator() *QueryValidator {
@@ -0,0 +1,178 @@
+package logs_receiving
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+)
+
+const (
+	// usage of API keys is kept this long, longer ranges cannot be requested
+	DefaultApiKeyUsageRetention = 30 * 24 * time.Hour
+
+	ApiKeyUsageIntervalHour = "hour"
+	ApiKeyUsageIntervalDay  = "day"
+
+	apiKeyUsageKeyPrefix    = "api_key_usage:"
+	apiKeyUsageDayLayout    = "2006-01-02"
+	apiKeyUsageCacheTimeout = 2 * time.Second
+)
+
+// ApiKeyUsageTracker counts ingested logs per API key and hour in the cache,
+// so the volume of each service can be attributed whichever instance received
+// it. Counts of a project day are one hash with "<apiKeyId>:<hour>" fields,
+// which expires after the retention
+type ApiKeyUsageTracker struct {
+	client    valkey.Client
+	logger    *slog.Logger
+	retention time.Duration
+}
+
+func NewApiKeyUsageTracker(client valkey.Client, logger *slog.Logger) *ApiKeyUsageTracker {
+	return &ApiKeyUsageTracker{
+		client:    client,
+		logger:    logger,
+		retention: DefaultApiKeyUsageRetention,
+	}
+}
+
+func (t *ApiKeyUsageTracker) SetRetention(retention time.Duration) {
+	t.retention = retention
+}
+
+func (t *ApiKeyUsageTracker) GetRetention() time.Duration {
+	return t.retention
+}
+
+// Record adds logsCount logs to the current hour of the key. Failures are
+// logged only, usage analytics never fail ingestion
+func (t *ApiKeyUsageTracker) Record(projectID, apiKeyID uuid.UUID, logsCount int) {
+	now := time.Now().UTC()
+	dayKey := apiKeyUsageDayKey(projectID, now)
+
+	// The day hash lives until the retention passed for its last hour
+	expiresAt := now.Truncate(24 * time.Hour).Add(24*time.Hour + t.retention)
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiKeyUsageCacheTimeout)
+	defer cancel()
+
+	results := t.client.DoMulti(ctx,
+		t.client.B().Hincrby().Key(dayKey).Field(apiKeyUsageField(apiKeyID, now.Hour())).
+			Increment(int64(logsCount)).Build(),
+		t.client.B().Pexpireat().Key(dayKey).MillisecondsTimestamp(expiresAt.UnixMilli()).Build(),
+	)
+
+	for _, result := range results {
+		if err := result.Error(); err != nil {
+			t.logger.Error("Failed to record API key usage", "projectId", projectID.String(), "error", err)
+			return
+		}
+	}
+}
+
+// Get returns the logs per API key in the hours starting within [from, to),
+// summed into buckets of the interval
+func (t *ApiKeyUsageTracker) Get(
+	projectID uuid.UUID,
+	from, to time.Time,
+	interval string,
+) (map[uuid.UUID]map[time.Time]int64, error) {
+	from, to = from.UTC(), to.UTC()
+
+	days := make([]time.Time, 0)
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		days = append(days, day)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiKeyUsageCacheTimeout)
+	defer cancel()
+
+	commands := make(valkey.Commands, 0, len(days))
+	for _, day := range days {
+		commands = append(commands, t.client.B().Hgetall().Key(apiKeyUsageDayKey(projectID, day)).Build())
+	}
+
+	usage := map[uuid.UUID]map[time.Time]int64{}
+	for i, result := range t.client.DoMulti(ctx, commands...) {
+		countsByField, err := result.AsIntMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API key usage: %w", err)
+		}
+
+		for field, logsCount := range countsByField {
+			apiKeyID, hour, ok := parseApiKeyUsageField(field)
+			if !ok {
+				continue
+			}
+
+			hourStart := days[i].Add(time.Duration(hour) * time.Hour)
+			if hourStart.Before(from) || !hourStart.Before(to) {
+				continue
+			}
+
+			bucketStart := hourStart
+			if interval == ApiKeyUsageIntervalDay {
+				bucketStart = days[i]
+			}
+
+			if usage[apiKeyID] == nil {
+				usage[apiKeyID] = map[time.Time]int64{}
+			}
+			usage[apiKeyID][bucketStart] += logsCount
+		}
+	}
+
+	return usage, nil
+}
+
+// OnBeforeProjectDeletion removes the usage of every day within the retention
+func (t *ApiKeyUsageTracker) OnBeforeProjectDeletion(projectID uuid.UUID) error {
+	now := time.Now().UTC()
+
+	keys := []string{}
+	for day := now.Add(-t.retention).Truncate(24 * time.Hour); !day.After(now); day = day.Add(24 * time.Hour) {
+		keys = append(keys, apiKeyUsageDayKey(projectID, day))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiKeyUsageCacheTimeout)
+	defer cancel()
+
+	if err := t.client.Do(ctx, t.client.B().Del().Key(keys...).Build()).Error(); err != nil {
+		return fmt.Errorf("failed to delete API key usage: %w", err)
+	}
+
+	return nil
+}
+
+func apiKeyUsageDayKey(projectID uuid.UUID, day time.Time) string {
+	return apiKeyUsageKeyPrefix + projectID.String() + ":" + day.Format(apiKeyUsageDayLayout)
+}
+
+func apiKeyUsageField(apiKeyID uuid.UUID, hour int) string {
+	return apiKeyID.String() + ":" + strconv.Itoa(hour)
+}
+
+func parseApiKeyUsageField(field string) (uuid.UUID, int, bool) {
+	apiKeyPart, hourPart, found := strings.Cut(field, ":")
+	if !found {
+		return uuid.Nil, 0, false
+	}
+
+	apiKeyID, err := uuid.Parse(apiKeyPart)
+	if err != nil {
+		return uuid.Nil, 0, false
+	}
+
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil || hour < 0 || hour > 23 {
+		return uuid.Nil, 0, false
+	}
+
+	return apiKeyID, hour, true
+}
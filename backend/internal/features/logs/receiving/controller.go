@@ -2,6 +2,7 @@ package logs_receiving
 
 import (
 	logs_core "logbull/internal/features/logs/core"
+	users_middleware "logbull/internal/features/users/middleware"
 	"net/http"
 	"strings"
 
@@ -19,6 +20,15 @@ func (c *ReceivingController) RegisterRoutes(router *gin.RouterGroup) {
 	logRoutes := router.Group("/logs/receiving")
 
 	logRoutes.POST("/:projectId", c.SubmitLogs)
+	logRoutes.POST("/:projectId/single", c.SubmitLog)
+	logRoutes.POST("/:projectId/validate", c.ValidateIngestion)
+}
+
+func (c *ReceivingController) RegisterProtectedRoutes(router *gin.RouterGroup) {
+	router.POST("/projects/:id/test-ingest", c.TestIngest)
+	router.GET("/projects/:id/ingestion-rejections", c.GetIngestionRejections)
+	router.GET("/projects/:id/api-key-usage", c.GetApiKeyUsage)
+	router.GET("/system/receiving/worker-status", c.GetWorkerStatus)
 }
 
 // SubmitLogs
@@ -31,19 +41,20 @@ func (c *ReceivingController) RegisterRoutes(router *gin.RouterGroup) {
 // @Description - Domain Filtering: Origin header required if project has `isFilterByDomain=true` with matching allowed domain
 // @Description - IP Filtering: Client IP must match allowed IPs/CIDRs if project has `isFilterByIP=true`
 // @Description - Rate Limiting: Requests limited by project's `logsPerSecondLimit` with burst capability (5x multiplier)
-// @Description - Batch Limits: Maximum 1000 logs per batch, maximum 10MB total batch size
+// @Description - Batch Limits: Maximum 1000 logs per batch by default (LOG_MAX_BATCH_SIZE), maximum 10MB total batch size
 // @Description - Log Requirements: Valid log level (DEBUG/INFO/WARN/ERROR), non-empty message, log size within project's `maxLogSizeKB` limit (timestamp automatically set by server)
 // @Tags logs
 // @Accept json
 // @Produce json
 // @Param projectId path string true "Project ID (UUID format)"
 // @Param X-API-Key header string false "API Key (required if project has isApiKeyRequired=true)"
+// @Param Authorization header string false "Bearer ingest token, accepted instead of the API key"
 // @Param Origin header string false "Origin header (required if project has domain filtering enabled)"
 // @Param X-Forwarded-For header string false "Client IP for IP filtering (auto-detected from various headers)"
-// @Param request body SubmitLogsRequestDTO true "Log items to submit (1-1000 logs, max 10MB total, timestamp automatically set by server)"
-// @Success 202 {object} SubmitLogsResponseDTO "Logs accepted (may include partial rejection for invalid logs)"
+// @Param request body SubmitLogsRequestDTO true "Log items to submit (1-1000 logs by default, max 10MB total, timestamp automatically set by server)"
+// @Success 202 {object} SubmitLogsResponseDTO "Logs accepted with the IDs of accepted logs in request order (may include partial rejection for invalid logs)"
 // @Failure 400 {object} map[string]string "Invalid request format, project ID, or batch limits exceeded"
-// @Failure 401 {object} map[string]string "API key required or invalid"
+// @Failure 401 {object} map[string]string "API key required or invalid, or ingest token invalid or expired"
 // @Failure 403 {object} map[string]string "Domain not allowed or IP not allowed"
 // @Failure 404 {object} map[string]string "Project not found"
 // @Failure 413 {object} map[string]string "Project quota exceeded"
@@ -64,7 +75,7 @@ func (c *ReceivingController) SubmitLogs(ctx *gin.Context) {
 	}
 
 	// Extract headers and client information
-	apiKey := ctx.GetHeader("X-API-Key")
+	apiKey := c.extractCredential(ctx)
 	origin := c.extractOrigin(ctx)
 	clientIP := c.extractClientIP(ctx)
 
@@ -78,6 +89,306 @@ func (c *ReceivingController) SubmitLogs(ctx *gin.Context) {
 	ctx.JSON(http.StatusAccepted, response)
 }
 
+// SubmitLog
+// @Summary Submit a single log to project
+// @Description Submit one log item without wrapping it into a batch. The log passes the same checks as batch
+// @Description submission (API key, domain/IP filtering, rate limits, quotas and log size). Unlike batches,
+// @Description a log rejected by validation returns an error with the rejection code.
+// @Tags logs
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param X-API-Key header string false "API Key (required if project has isApiKeyRequired=true)"
+// @Param Authorization header string false "Bearer ingest token, accepted instead of the API key"
+// @Param Origin header string false "Origin header (required if project has domain filtering enabled)"
+// @Param X-Forwarded-For header string false "Client IP for IP filtering (auto-detected from various headers)"
+// @Param request body LogItemRequestDTO true "Log item to submit"
+// @Success 202 {object} SubmitLogResponseDTO "Log accepted"
+// @Failure 400 {object} map[string]string "Invalid request format, project ID or log rejected by validation"
+// @Failure 401 {object} map[string]string "API key required or invalid, or ingest token invalid or expired"
+// @Failure 403 {object} map[string]string "Domain not allowed or IP not allowed"
+// @Failure 404 {object} map[string]string "Project not found"
+// @Failure 413 {object} map[string]string "Project quota exceeded"
+// @Failure 429 {object} map[string]string "Rate limit exceeded"
+// @Router /logs/receiving/{projectId}/single [post]
+func (c *ReceivingController) SubmitLog(ctx *gin.Context) {
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request LogItemRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	apiKey := c.extractCredential(ctx)
+	origin := c.extractOrigin(ctx)
+	clientIP := c.extractClientIP(ctx)
+
+	response, err := c.logReceivingService.SubmitLog(projectID, &request, clientIP, apiKey, origin)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response)
+}
+
+// ValidateIngestion
+// @Summary Check whether logs would be accepted
+// @Description Run the project, API key, domain/IP filter and rate limit checks of log submission without
+// @Description ingesting anything, so setup scripts can verify their configuration. The rate limit is read
+// @Description without counting against the project. Failed checks are reported with the code submission
+// @Description would return.
+// @Tags logs
+// @Produce json
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param X-API-Key header string false "API Key (required if project has isApiKeyRequired=true)"
+// @Param Authorization header string false "Bearer ingest token, accepted instead of the API key"
+// @Param Origin header string false "Origin header (required if project has domain filtering enabled)"
+// @Param X-Forwarded-For header string false "Client IP for IP filtering (auto-detected from various headers)"
+// @Success 200 {object} ValidateIngestionResponseDTO
+// @Failure 400 {object} map[string]string "Invalid project ID"
+// @Failure 500 {object} map[string]string
+// @Router /logs/receiving/{projectId}/validate [post]
+func (c *ReceivingController) ValidateIngestion(ctx *gin.Context) {
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	apiKey := c.extractCredential(ctx)
+	origin := c.extractOrigin(ctx)
+	clientIP := c.extractClientIP(ctx)
+
+	response, err := c.logReceivingService.ValidateIngestion(projectID, clientIP, apiKey, origin)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate ingestion"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// TestIngest
+// @Summary Send a test log to verify ingestion setup
+// @Description Send a synthetic log through the real ingestion pipeline (API key, domain/IP filters,
+// @Description rate and size limits) and report whether it was accepted and why not. API key, origin and
+// @Description client IP can be given in the body, otherwise they are taken from the request.
+// @Description With deleteAfter=true the log is validated but not stored. The response includes the
+// @Description remaining rate limit and quota headroom of the project.
+// @Tags logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body TestIngestRequestDTO false "Client to send the test log as"
+// @Success 200 {object} TestIngestResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/test-ingest [post]
+func (c *ReceivingController) TestIngest(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request TestIngestRequestDTO
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	if request.ApiKey == "" {
+		request.ApiKey = ctx.GetHeader("X-API-Key")
+	}
+	if request.Origin == "" {
+		request.Origin = c.extractOrigin(ctx)
+	}
+	if request.ClientIP == "" {
+		request.ClientIP = c.extractClientIP(ctx)
+	}
+
+	response, err := c.logReceivingService.TestIngest(projectID, &request, user)
+	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to test project ingestion" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to test ingestion"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetIngestionRejections
+// @Summary Get recent ingestion rejections
+// @Description Get recent events of logs dropped by the receiver (rate limit, domain/IP filters, API key,
+// @Description size and validation) with reasons, a sample message and counts per rejection code, newest
+// @Description first. Rejections of all instances are kept in the cache for 24 hours after the last one.
+// @Tags logs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} IngestionRejectionsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/ingestion-rejections [get]
+func (c *ReceivingController) GetIngestionRejections(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	response, err := c.logReceivingService.GetIngestionRejections(projectID, user)
+	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to view ingestion rejections" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get ingestion rejections"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetApiKeyUsage
+// @Summary Get ingested logs per API key
+// @Description Get the logs accepted through each API key of the project in hourly or daily buckets, keys with the
+// @Description most logs first. Usage of all instances is kept in the cache for 30 days.
+// @Tags logs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param from query string false "Start of the range (RFC3339), defaults to 24 hours before 'to'"
+// @Param to query string false "End of the range (RFC3339), defaults to now"
+// @Param interval query string false "Bucket size, hour (default) or day"
+// @Success 200 {object} ApiKeyUsageResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/api-key-usage [get]
+func (c *ReceivingController) GetApiKeyUsage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request GetApiKeyUsageRequestDTO
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := c.logReceivingService.GetApiKeyUsage(projectID, &request, user)
+	if err != nil {
+		if validationErr, ok := err.(*logs_core.ValidationError); ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "code": validationErr.Code})
+			return
+		}
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to view API key usage" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get API key usage"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetWorkerStatus
+// @Summary Get receiving worker status (ADMIN only)
+// @Description Returns whether the receiving workers run on this instance, logs waiting in memory and in the
+// @Description queue, last flush and store times and error counters since the instance started
+// @Tags logs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} WorkerStatusDTO
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /system/receiving/worker-status [get]
+func (c *ReceivingController) GetWorkerStatus(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.logReceivingService.GetWorkerStatus(user)
+	if err != nil {
+		if err.Error() == "only administrators can view receiving worker status" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get receiving worker status"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// extractCredential returns the API key, or the bearer ingest token when no API
+// key is sent
+func (c *ReceivingController) extractCredential(ctx *gin.Context) string {
+	if apiKey := ctx.GetHeader("X-API-Key"); apiKey != "" {
+		return apiKey
+	}
+
+	if token, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
 func (c *ReceivingController) extractOrigin(ctx *gin.Context) string {
 	// Try Origin header first (CORS requests)
 	origin := ctx.GetHeader("Origin")
@@ -172,7 +483,7 @@ func (c *ReceivingController) getStatusCodeForValidationError(errorCode string)
 	switch errorCode {
 	case logs_core.ErrorProjectNotFound:
 		return http.StatusNotFound
-	case logs_core.ErrorAPIKeyRequired, logs_core.ErrorAPIKeyInvalid:
+	case logs_core.ErrorAPIKeyRequired, logs_core.ErrorAPIKeyInvalid, logs_core.ErrorIngestTokenInvalid:
 		return http.StatusUnauthorized
 	case logs_core.ErrorDomainNotAllowed, logs_core.ErrorIPNotAllowed:
 		return http.StatusForbidden
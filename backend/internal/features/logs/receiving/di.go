@@ -1,9 +1,13 @@
 package logs_receiving
 
 import (
+	"logbull/internal/cache"
+	"logbull/internal/config"
 	api_keys "logbull/internal/features/api_keys"
+	logs_attachments "logbull/internal/features/logs/attachments"
 	logs_core "logbull/internal/features/logs/core"
 	projects_services "logbull/internal/features/projects/services"
+	system_webhooks "logbull/internal/features/system/webhooks"
 	"logbull/internal/util/logger"
 	rate_limit "logbull/internal/util/rate_limit"
 )
@@ -13,15 +17,32 @@ var rateLimiter = rate_limit.NewRateLimiter()
 var logWorkerService = NewLogWorkerService(
 	logs_core.GetLogCoreRepository(),
 	logger.GetLogger(),
+	config.GetEnv().LogStorageWorkersCount,
+	config.GetEnv().LogFlushWorkersCount,
+	config.GetEnv().LogLowLatencyFlushMaxLogs,
 )
 
+var rejectionDiagnostics = NewRejectionDiagnostics(cache.GetCache(), logger.GetLogger(), RejectionEventsPerProject)
+
+var apiKeyUsageTracker = NewApiKeyUsageTracker(cache.GetCache(), logger.GetLogger())
+
+var firstLogNotifier = NewFirstLogNotifier(system_webhooks.GetWebhookSender(), logger.GetLogger())
+
 var logReceivingService = &LogReceivingService{
 	logs_core.GetLogCoreRepository(),
 	rateLimiter,
 	projects_services.GetProjectService(),
 	api_keys.GetApiKeyService(),
+	api_keys.GetIngestTokenService(),
 	logWorkerService,
 	logger.GetLogger(),
+	newGeoIPResolverFromConfig(),
+	rejectionDiagnostics,
+	apiKeyUsageTracker,
+	logs_attachments.GetAttachmentService(),
+	getMaxBatchSizeFromConfig(),
+	firstLogNotifier,
+	getFieldNameRulesFromConfig(),
 }
 
 var receivingController = &ReceivingController{
@@ -36,6 +57,61 @@ func GetLogWorkerService() *LogWorkerService {
 	return logWorkerService
 }
 
+func GetRejectionDiagnostics() *RejectionDiagnostics {
+	return rejectionDiagnostics
+}
+
+func GetApiKeyUsageTracker() *ApiKeyUsageTracker {
+	return apiKeyUsageTracker
+}
+
+func GetFirstLogNotifier() *FirstLogNotifier {
+	return firstLogNotifier
+}
+
 func GetReceivingController() *ReceivingController {
 	return receivingController
 }
+
+func SetupDependencies() {
+	projects_services.GetProjectService().AddProjectDeletionListener(rejectionDiagnostics)
+	projects_services.GetProjectService().AddProjectDeletionListener(apiKeyUsageTracker)
+}
+
+func newGeoIPResolverFromConfig() GeoIPResolver {
+	databasePath := config.GetEnv().GeoIPDatabasePath
+	if databasePath == "" {
+		return nil
+	}
+
+	resolver, err := NewMaxMindGeoIPResolver(databasePath)
+	if err != nil {
+		logger.GetLogger().Error("Failed to load GeoIP database, enrichment is disabled",
+			"path", databasePath,
+			"error", err)
+		return nil
+	}
+
+	return resolver
+}
+
+func getMaxBatchSizeFromConfig() int {
+	maxBatchSize := config.GetEnv().LogMaxBatchSize
+	if maxBatchSize <= 0 {
+		return MaxBatchSize
+	}
+
+	return maxBatchSize
+}
+
+func getFieldNameRulesFromConfig() FieldNameRules {
+	maxLength := config.GetEnv().LogMaxFieldNameLength
+	if maxLength <= 0 {
+		maxLength = DefaultMaxFieldNameLength
+	}
+
+	return FieldNameRules{
+		MaxLength:  maxLength,
+		IsRejected: config.GetEnv().IsInvalidFieldNameRejected,
+	}
+}
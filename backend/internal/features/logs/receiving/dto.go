@@ -1,7 +1,11 @@
 package logs_receiving
 
 import (
+	"time"
+
 	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/google/uuid"
 )
 
 type SubmitLogsRequestDTO struct {
@@ -9,19 +13,159 @@ type SubmitLogsRequestDTO struct {
 }
 
 type LogItemRequestDTO struct {
-	Level     logs_core.LogLevel `json:"level"               binding:"required"`
+	Level     logs_core.LogLevel `json:"level"`
 	Message   string             `json:"message"             binding:"required,max=10000"`
 	Timestamp any                `json:"timestamp,omitempty"`
 	Fields    map[string]any     `json:"fields,omitempty"`
 }
 
 type SubmitLogsResponseDTO struct {
-	Accepted int                  `json:"accepted"`
-	Rejected int                  `json:"rejected"`
-	Errors   []LogSubmissionError `json:"errors,omitempty"`
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+	// accepted logs dropped by the project sampling, they are not stored
+	SampledOut int `json:"sampledOut"`
+	// IDs of the stored logs in request order, rejected logs are listed in
+	// errors by their index instead
+	IDs    []uuid.UUID          `json:"ids"`
+	Errors []LogSubmissionError `json:"errors,omitempty"`
+}
+
+type SubmitLogResponseDTO struct {
+	ID uuid.UUID `json:"id"`
+	// the log was accepted but dropped by the project sampling, it is not stored
+	IsSampledOut bool `json:"sampledOut,omitempty"`
 }
 
 type LogSubmissionError struct {
-	Index   int    `json:"index"`
+	Index int `json:"index"`
+	// Code is one of the logs_core.Error* codes
+	Code    string `json:"code"`
 	Message string `json:"message"`
 }
+
+// TestIngestRequestDTO describes the client the test log is sent as. Empty
+// values are taken from the current request headers
+type TestIngestRequestDTO struct {
+	ApiKey   string `json:"apiKey,omitempty"`
+	Origin   string `json:"origin,omitempty"`
+	ClientIP string `json:"clientIp,omitempty"`
+	// DeleteAfter validates the test log without storing it
+	DeleteAfter bool `json:"deleteAfter,omitempty"`
+}
+
+type TestIngestResponseDTO struct {
+	Accepted bool       `json:"accepted"`
+	Code     string     `json:"code,omitempty"`
+	Reason   string     `json:"reason,omitempty"`
+	LogID    *uuid.UUID `json:"logId,omitempty"`
+	IsStored bool       `json:"isStored"`
+	// Limits is the state of the project limits after the test log, nil when it
+	// cannot be read
+	Limits *IngestionLimitsDTO `json:"limits,omitempty"`
+}
+
+// ValidateIngestionResponseDTO tells whether logs sent with the same API key,
+// origin and client IP would currently be accepted, Code and Reason say why not
+type ValidateIngestionResponseDTO struct {
+	Accepted bool   `json:"accepted"`
+	Code     string `json:"code,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// IngestionLimitsDTO shows how close a project is to its rate limit and quotas
+type IngestionLimitsDTO struct {
+	RateLimit RateLimitStatusDTO `json:"rateLimit"`
+	// Quotas are nil when unlimited
+	LogsAmount *QuotaUsageDTO `json:"logsAmount,omitempty"`
+	LogsSizeMB *QuotaUsageDTO `json:"logsSizeMb,omitempty"`
+	// Zero means unlimited
+	MaxLogsLifeDays int `json:"maxLogsLifeDays"`
+	MaxLogSizeKB    int `json:"maxLogSizeKb"`
+}
+
+type RateLimitStatusDTO struct {
+	IsUnlimited        bool `json:"isUnlimited"`
+	LogsPerSecondLimit int  `json:"logsPerSecondLimit"`
+	BurstLimit         int  `json:"burstLimit"`
+	// Remaining logs which can be sent right now, refilled at LogsPerSecondLimit
+	Remaining int        `json:"remaining"`
+	ResetTime *time.Time `json:"resetTime,omitempty"`
+}
+
+// QuotaUsageDTO compares stored logs with a quota. Used may exceed Limit until
+// the next cleanup run, Remaining does not go below zero
+type QuotaUsageDTO struct {
+	Limit       float64 `json:"limit"`
+	Used        float64 `json:"used"`
+	Remaining   float64 `json:"remaining"`
+	UsedPercent float64 `json:"usedPercent"`
+}
+
+type IngestionRejectionEventDTO struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+	// Sample is the (shortened) message of the first rejected log
+	Sample     string    `json:"sample,omitempty"`
+	LogsCount  int       `json:"logsCount"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type IngestionRejectionsResponseDTO struct {
+	// Rejected logs per code until the rejections expire
+	TotalsByCode map[string]int64 `json:"totalsByCode"`
+	// Recent events, newest first
+	Events []IngestionRejectionEventDTO `json:"events"`
+}
+
+type GetApiKeyUsageRequestDTO struct {
+	// Defaults to 24 hours before to
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Defaults to now
+	To *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// "hour" (default) or "day"
+	Interval string `form:"interval"`
+}
+
+// ApiKeyUsageResponseDTO attributes the ingested logs to API keys, keys with
+// the most logs first. Logs sent without an API key are not counted
+type ApiKeyUsageResponseDTO struct {
+	From     time.Time        `json:"from"`
+	To       time.Time        `json:"to"`
+	Interval string           `json:"interval"`
+	ApiKeys  []ApiKeyUsageDTO `json:"apiKeys"`
+}
+
+type ApiKeyUsageDTO struct {
+	ApiKeyID uuid.UUID `json:"apiKeyId"`
+	// Name is empty when the key was deleted since
+	Name      string `json:"name"`
+	TotalLogs int64  `json:"totalLogs"`
+	// Every bucket of the range, oldest first, including empty ones
+	Buckets []ApiKeyUsageBucketDTO `json:"buckets"`
+}
+
+type ApiKeyUsageBucketDTO struct {
+	Start     time.Time `json:"start"`
+	LogsCount int64     `json:"logsCount"`
+}
+
+type WorkerStatusDTO struct {
+	// Workers are started on the instance running background tasks only
+	IsRunning           bool `json:"isRunning"`
+	StorageWorkersCount int  `json:"storageWorkersCount"`
+	FlushWorkersCount   int  `json:"flushWorkersCount"`
+	// Received logs in memory which are not flushed to the queue yet
+	AccumulatedLogs int `json:"accumulatedLogs"`
+	// Logs in the shared queue which are not stored yet
+	QueuedLogs int64 `json:"queuedLogs"`
+	// Last flush of accumulated logs to the queue and last store of queued logs
+	LastFlushAt *time.Time `json:"lastFlushAt"`
+	LastStoreAt *time.Time `json:"lastStoreAt"`
+	// Counters since the instance started
+	StoredLogsCount  int64 `json:"storedLogsCount"`
+	FlushErrorsCount int64 `json:"flushErrorsCount"`
+	// Failed dequeues and failed batch stores
+	StoreErrorsCount   int64 `json:"storeErrorsCount"`
+	DecodeErrorsCount  int64 `json:"decodeErrorsCount"`
+	DiscardedLogsCount int64 `json:"discardedLogsCount"`
+}
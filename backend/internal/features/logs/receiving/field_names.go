@@ -0,0 +1,141 @@
+package logs_receiving
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	logs_core "logbull/internal/features/logs/core"
+)
+
+const DefaultMaxFieldNameLength = 128
+
+// Custom fields become OpenSearch fields and "field=value" tokens, so control
+// characters and "=" are replaced. Other characters (spaces, parentheses, "#"
+// and so on) are valid field names and are kept
+var (
+	unsafeFieldNameCharacter = regexp.MustCompile(`[\p{C}=]`)
+	repeatedFieldNameDots    = regexp.MustCompile(`\.{2,}`)
+)
+
+// FieldNameRules protect the index mapping from field names which are too long
+// or contain unsafe characters. Such names are either sanitized or the whole
+// log is rejected
+type FieldNameRules struct {
+	MaxLength  int
+	IsRejected bool
+}
+
+// apply returns the fields with every name passing the rules. A sanitized name
+// which collides with another field or ends up empty is dropped with its value
+func (rules FieldNameRules) apply(fields map[string]any) (map[string]any, error) {
+	var invalidNames []string
+	for fieldName := range fields {
+		if !rules.isValid(fieldName) {
+			invalidNames = append(invalidNames, fieldName)
+		}
+	}
+
+	if len(invalidNames) == 0 {
+		return fields, nil
+	}
+
+	slices.Sort(invalidNames)
+
+	if rules.IsRejected {
+		return nil, &logs_core.ValidationError{
+			Code: logs_core.ErrorInvalidFieldName,
+			Message: fmt.Sprintf(
+				"field name %q must be at most %d characters without control characters, \"=\" and empty dot segments",
+				invalidNames[0],
+				rules.MaxLength,
+			),
+			Field: invalidNames[0],
+		}
+	}
+
+	sanitizedFields := make(map[string]any, len(fields))
+	for fieldName, value := range fields {
+		if rules.isValid(fieldName) {
+			sanitizedFields[fieldName] = value
+		}
+	}
+
+	// Sorted, so the same field wins a collision in every log
+	for _, fieldName := range invalidNames {
+		sanitizedName := rules.sanitize(fieldName)
+		if sanitizedName == "" {
+			continue
+		}
+
+		if _, exists := sanitizedFields[sanitizedName]; !exists {
+			sanitizedFields[sanitizedName] = fields[fieldName]
+		}
+	}
+
+	return sanitizedFields, nil
+}
+
+// isValid also rejects blank names and leading, trailing and repeated dots,
+// OpenSearch treats dots as object paths and fails on empty path segments
+func (rules FieldNameRules) isValid(fieldName string) bool {
+	return utf8.RuneCountInString(fieldName) <= rules.MaxLength &&
+		strings.TrimSpace(fieldName) != "" &&
+		!unsafeFieldNameCharacter.MatchString(fieldName) &&
+		!strings.HasPrefix(fieldName, ".") &&
+		!strings.HasSuffix(fieldName, ".") &&
+		!strings.Contains(fieldName, "..")
+}
+
+// sanitize replaces unsafe characters with "_" and cuts the name to the
+// maximum length
+func (rules FieldNameRules) sanitize(fieldName string) string {
+	sanitizedName := unsafeFieldNameCharacter.ReplaceAllString(fieldName, "_")
+	sanitizedName = repeatedFieldNameDots.ReplaceAllString(sanitizedName, ".")
+	sanitizedName = strings.Trim(sanitizedName, ".")
+	if strings.TrimSpace(sanitizedName) == "" {
+		return ""
+	}
+
+	if runes := []rune(sanitizedName); len(runes) > rules.MaxLength {
+		sanitizedName = strings.TrimRight(string(runes[:rules.MaxLength]), ".")
+	}
+
+	return sanitizedName
+}
+
+// lowercaseFieldNames stores case variants such as "Status" and "status" as one
+// field. Of colliding names the lowercase one wins, otherwise the first in sort
+// order, so every log keeps the value of the same variant
+func lowercaseFieldNames(fields map[string]any) map[string]any {
+	hasUppercase := false
+	for fieldName := range fields {
+		if strings.ToLower(fieldName) != fieldName {
+			hasUppercase = true
+			break
+		}
+	}
+
+	if !hasUppercase {
+		return fields
+	}
+
+	lowercasedFields := make(map[string]any, len(fields))
+	for fieldName, value := range fields {
+		if strings.ToLower(fieldName) == fieldName {
+			lowercasedFields[fieldName] = value
+		}
+	}
+
+	for _, fieldName := range slices.Sorted(maps.Keys(fields)) {
+		lowercasedName := strings.ToLower(fieldName)
+		if _, exists := lowercasedFields[lowercasedName]; !exists {
+			lowercasedFields[lowercasedName] = fields[fieldName]
+		}
+	}
+
+	return lowercasedFields
+}
@@ -0,0 +1,58 @@
+package logs_receiving
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"logbull/internal/util/webhook"
+
+	"github.com/google/uuid"
+)
+
+const FirstLogReceivedEvent = "FIRST_LOG_RECEIVED"
+
+// FirstLogEventDTO is sent to the project first log webhook
+type FirstLogEventDTO struct {
+	Event       string    `json:"event"`
+	ProjectID   uuid.UUID `json:"projectId"`
+	ProjectName string    `json:"projectName"`
+	LogID       uuid.UUID `json:"logId"`
+	ReceivedAt  time.Time `json:"receivedAt"`
+}
+
+// FirstLogNotifier calls first log webhooks in the background, so a slow
+// webhook never delays ingestion. A call failing after the retries of the
+// sender is logged
+type FirstLogNotifier struct {
+	webhookSender *webhook.Sender
+	logger        *slog.Logger
+
+	wg sync.WaitGroup
+}
+
+func NewFirstLogNotifier(webhookSender *webhook.Sender, logger *slog.Logger) *FirstLogNotifier {
+	return &FirstLogNotifier{
+		webhookSender: webhookSender,
+		logger:        logger,
+	}
+}
+
+func (n *FirstLogNotifier) Notify(webhookURL string, event *FirstLogEventDTO) {
+	n.wg.Add(1)
+
+	go func() {
+		defer n.wg.Done()
+
+		if err := n.webhookSender.Send("first log", webhookURL, event); err != nil {
+			n.logger.Error("Failed to send first log event",
+				slog.String("projectId", event.ProjectID.String()),
+				slog.String("error", err.Error()))
+		}
+	}()
+}
+
+// WaitForNotificationsForTest blocks until sent events are delivered
+func (n *FirstLogNotifier) WaitForNotificationsForTest() {
+	n.wg.Wait()
+}
@@ -0,0 +1,299 @@
+package logs_receiving
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	GeoCountryField = "geo.country"
+	GeoCityField    = "geo.city"
+)
+
+type GeoLocation struct {
+	Country string
+	City    string
+}
+
+type GeoIPResolver interface {
+	Resolve(ip net.IP) (*GeoLocation, bool)
+}
+
+// MaxMindGeoIPResolver resolves IPs from a MaxMind GeoLite2 or GeoIP2 City or
+// Country database in the CSV format: the blocks files map networks to geoname
+// ids, which are joined with the English locations file. Networks are kept as
+// sorted address ranges, so a lookup is a binary search
+type MaxMindGeoIPResolver struct {
+	ipv4Ranges []ipv4GeoRange
+	ipv6Ranges []ipv6GeoRange
+	locations  []GeoLocation
+}
+
+// Ranges keep an index into locations, databases have millions of networks
+// but only a few hundred thousand locations
+type ipv4GeoRange struct {
+	start, end    uint32
+	locationIndex int32
+}
+
+type ipv6GeoRange struct {
+	startHigh, startLow uint64
+	endHigh, endLow     uint64
+	locationIndex       int32
+}
+
+// NewMaxMindGeoIPResolver loads the database from the directory of its
+// extracted CSV archive, e.g. GeoLite2-City-CSV_20250101 with
+// GeoLite2-City-Blocks-IPv4.csv, GeoLite2-City-Blocks-IPv6.csv and
+// GeoLite2-City-Locations-en.csv
+func NewMaxMindGeoIPResolver(directory string) (*MaxMindGeoIPResolver, error) {
+	locationsPaths, _ := filepath.Glob(filepath.Join(directory, "*-Locations-en.csv"))
+	if len(locationsPaths) != 1 {
+		return nil, fmt.Errorf("expected one *-Locations-en.csv file in GeoIP database directory %s", directory)
+	}
+
+	var blocksPaths []string
+	for _, pattern := range []string{"*-Blocks-IPv4.csv", "*-Blocks-IPv6.csv"} {
+		paths, _ := filepath.Glob(filepath.Join(directory, pattern))
+		blocksPaths = append(blocksPaths, paths...)
+	}
+	if len(blocksPaths) == 0 {
+		return nil, fmt.Errorf("no *-Blocks-IPv4.csv or *-Blocks-IPv6.csv file in GeoIP database directory %s",
+			directory)
+	}
+
+	locationsFile, err := os.Open(locationsPaths[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP locations: %w", err)
+	}
+	defer func() { _ = locationsFile.Close() }()
+
+	blocksReaders := make([]io.Reader, 0, len(blocksPaths))
+	for _, blocksPath := range blocksPaths {
+		blocksFile, err := os.Open(blocksPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open GeoIP blocks: %w", err)
+		}
+		defer func() { _ = blocksFile.Close() }()
+
+		blocksReaders = append(blocksReaders, blocksFile)
+	}
+
+	return ParseMaxMindGeoIPDatabase(locationsFile, blocksReaders...)
+}
+
+// ParseMaxMindGeoIPDatabase reads the locations file and the blocks files of a
+// MaxMind CSV database. Blocks without a geoname id fall back to the registered
+// country, blocks of unknown locations are skipped
+func ParseMaxMindGeoIPDatabase(locations io.Reader, blocks ...io.Reader) (*MaxMindGeoIPResolver, error) {
+	resolver := &MaxMindGeoIPResolver{}
+
+	locationIndexes, err := resolver.readLocations(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blocksReader := range blocks {
+		if err := resolver.readBlocks(blocksReader, locationIndexes); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(resolver.ipv4Ranges, func(i, j int) bool {
+		return resolver.ipv4Ranges[i].start < resolver.ipv4Ranges[j].start
+	})
+	sort.Slice(resolver.ipv6Ranges, func(i, j int) bool {
+		return compareUint128(
+			resolver.ipv6Ranges[i].startHigh, resolver.ipv6Ranges[i].startLow,
+			resolver.ipv6Ranges[j].startHigh, resolver.ipv6Ranges[j].startLow,
+		) < 0
+	})
+
+	// MaxMind networks never overlap, a binary search could miss nested ones
+	for i := 1; i < len(resolver.ipv4Ranges); i++ {
+		if resolver.ipv4Ranges[i].start <= resolver.ipv4Ranges[i-1].end {
+			return nil, errors.New("GeoIP database has overlapping IPv4 networks")
+		}
+	}
+	for i := 1; i < len(resolver.ipv6Ranges); i++ {
+		current, previous := resolver.ipv6Ranges[i], resolver.ipv6Ranges[i-1]
+		if compareUint128(current.startHigh, current.startLow, previous.endHigh, previous.endLow) <= 0 {
+			return nil, errors.New("GeoIP database has overlapping IPv6 networks")
+		}
+	}
+
+	return resolver, nil
+}
+
+func (r *MaxMindGeoIPResolver) Resolve(ip net.IP) (*GeoLocation, bool) {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		address := binary.BigEndian.Uint32(ipv4)
+
+		// The last range starting at or before the address
+		i := sort.Search(len(r.ipv4Ranges), func(i int) bool { return r.ipv4Ranges[i].start > address }) - 1
+		if i < 0 || r.ipv4Ranges[i].end < address {
+			return nil, false
+		}
+
+		location := r.locations[r.ipv4Ranges[i].locationIndex]
+		return &location, true
+	}
+
+	ipv6 := ip.To16()
+	if ipv6 == nil {
+		return nil, false
+	}
+	high, low := binary.BigEndian.Uint64(ipv6[:8]), binary.BigEndian.Uint64(ipv6[8:])
+
+	i := sort.Search(len(r.ipv6Ranges), func(i int) bool {
+		return compareUint128(r.ipv6Ranges[i].startHigh, r.ipv6Ranges[i].startLow, high, low) > 0
+	}) - 1
+	if i < 0 || compareUint128(r.ipv6Ranges[i].endHigh, r.ipv6Ranges[i].endLow, high, low) < 0 {
+		return nil, false
+	}
+
+	location := r.locations[r.ipv6Ranges[i].locationIndex]
+	return &location, true
+}
+
+// readLocations stores the locations and returns their indexes by geoname id
+func (r *MaxMindGeoIPResolver) readLocations(reader io.Reader) (map[string]int32, error) {
+	csvReader := csv.NewReader(reader)
+	columns, err := readGeoIPHeader(csvReader, "geoname_id", "country_iso_code")
+	if err != nil {
+		return nil, fmt.Errorf("invalid GeoIP locations: %w", err)
+	}
+	// Country databases have no cities
+	cityColumn, hasCity := columns["city_name"]
+
+	locationIndexes := map[string]int32{}
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return locationIndexes, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GeoIP locations: %w", err)
+		}
+
+		location := GeoLocation{Country: record[columns["country_iso_code"]]}
+		if hasCity {
+			location.City = record[cityColumn]
+		}
+
+		locationIndexes[record[columns["geoname_id"]]] = int32(len(r.locations))
+		r.locations = append(r.locations, location)
+	}
+}
+
+func (r *MaxMindGeoIPResolver) readBlocks(reader io.Reader, locationIndexes map[string]int32) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.ReuseRecord = true
+	columns, err := readGeoIPHeader(csvReader, "network", "geoname_id", "registered_country_geoname_id")
+	if err != nil {
+		return fmt.Errorf("invalid GeoIP blocks: %w", err)
+	}
+
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read GeoIP blocks: %w", err)
+		}
+
+		geonameID := record[columns["geoname_id"]]
+		if geonameID == "" {
+			geonameID = record[columns["registered_country_geoname_id"]]
+		}
+
+		locationIndex, exists := locationIndexes[geonameID]
+		if !exists {
+			continue
+		}
+
+		network, err := netip.ParsePrefix(record[columns["network"]])
+		if err != nil {
+			return fmt.Errorf("invalid network in GeoIP blocks: %s", record[columns["network"]])
+		}
+		r.addNetwork(network.Masked(), locationIndex)
+	}
+}
+
+func (r *MaxMindGeoIPResolver) addNetwork(network netip.Prefix, locationIndex int32) {
+	if network.Addr().Is4() {
+		start := binary.BigEndian.Uint32(network.Addr().AsSlice())
+		r.ipv4Ranges = append(r.ipv4Ranges, ipv4GeoRange{
+			start:         start,
+			end:           start | ^uint32(0)>>network.Bits(),
+			locationIndex: locationIndex,
+		})
+		return
+	}
+
+	address := network.Addr().As16()
+	startHigh, startLow := binary.BigEndian.Uint64(address[:8]), binary.BigEndian.Uint64(address[8:])
+
+	// Shifts of 64 bits and more give 0 in Go
+	highHostMask := ^uint64(0) >> network.Bits()
+	lowHostMask := ^uint64(0)
+	if network.Bits() > 64 {
+		lowHostMask = ^uint64(0) >> (network.Bits() - 64)
+	}
+
+	r.ipv6Ranges = append(r.ipv6Ranges, ipv6GeoRange{
+		startHigh:     startHigh,
+		startLow:      startLow,
+		endHigh:       startHigh | highHostMask,
+		endLow:        startLow | lowHostMask,
+		locationIndex: locationIndex,
+	})
+}
+
+// readGeoIPHeader returns the column indexes by name, MaxMind adds columns over
+// time so they are not read by position
+func readGeoIPHeader(csvReader *csv.Reader, requiredColumns ...string) (map[string]int, error) {
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.TrimSpace(column)] = i
+	}
+
+	for _, column := range requiredColumns {
+		if _, exists := columns[column]; !exists {
+			return nil, fmt.Errorf("missing column %s", column)
+		}
+	}
+
+	return columns, nil
+}
+
+func compareUint128(aHigh, aLow, bHigh, bLow uint64) int {
+	switch {
+	case aHigh != bHigh:
+		if aHigh < bHigh {
+			return -1
+		}
+		return 1
+	case aLow < bLow:
+		return -1
+	case aLow > bLow:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,87 @@
+package logs_receiving
+
+import (
+	"fmt"
+	"math"
+
+	projects_models "logbull/internal/features/projects/models"
+
+	"github.com/google/uuid"
+)
+
+// getIngestionLimits returns the rate limit and quota headroom of the project.
+// The rate limit is read without taking a token, so checking it does not count
+// against the project
+func (s *LogReceivingService) getIngestionLimits(projectID uuid.UUID) (*IngestionLimitsDTO, error) {
+	project, err := s.projectService.GetProjectWithCache(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	rateLimit, err := s.getRateLimitStatus(project)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := &IngestionLimitsDTO{
+		RateLimit:       *rateLimit,
+		MaxLogsLifeDays: project.MaxLogsLifeDays,
+		MaxLogSizeKB:    project.MaxLogSizeKB,
+	}
+
+	if project.MaxLogsAmount <= 0 && project.MaxLogsSizeMB <= 0 {
+		return limits, nil
+	}
+
+	stats, err := s.logRepository.GetProjectLogStats(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project log stats: %w", err)
+	}
+
+	if project.MaxLogsAmount > 0 {
+		limits.LogsAmount = newQuotaUsage(float64(project.MaxLogsAmount), float64(stats.TotalLogs))
+	}
+	if project.MaxLogsSizeMB > 0 {
+		limits.LogsSizeMB = newQuotaUsage(float64(project.MaxLogsSizeMB), stats.TotalSizeMB)
+	}
+
+	return limits, nil
+}
+
+func (s *LogReceivingService) getRateLimitStatus(project *projects_models.Project) (*RateLimitStatusDTO, error) {
+	if project.LogsPerSecondLimit == 0 {
+		return &RateLimitStatusDTO{IsUnlimited: true}, nil
+	}
+
+	burstLimit := project.LogsPerSecondLimit * LogsBurstMultiplier
+
+	result, err := s.rateLimiter.GetRateLimitInfo(project.ID, project.LogsPerSecondLimit, burstLimit)
+	if err != nil {
+		return nil, fmt.Errorf("rate limit check failed: %w", err)
+	}
+
+	// GetRateLimitInfo reports what remains after taking a token, without taking one
+	status := &RateLimitStatusDTO{
+		LogsPerSecondLimit: project.LogsPerSecondLimit,
+		BurstLimit:         burstLimit,
+		Remaining:          0,
+	}
+	if result.Allowed {
+		status.Remaining = result.Remaining + 1
+	}
+	if !result.ResetTime.IsZero() {
+		resetTime := result.ResetTime.UTC()
+		status.ResetTime = &resetTime
+	}
+
+	return status, nil
+}
+
+func newQuotaUsage(limit, used float64) *QuotaUsageDTO {
+	return &QuotaUsageDTO{
+		Limit:       limit,
+		Used:        used,
+		Remaining:   math.Max(0, limit-used),
+		UsedPercent: math.Round(used/limit*10000) / 100,
+	}
+}
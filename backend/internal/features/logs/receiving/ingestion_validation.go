@@ -0,0 +1,59 @@
+package logs_receiving
+
+import (
+	"errors"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/google/uuid"
+)
+
+// ValidateIngestion runs the project, API key, domain, IP and rate limit
+// checks of SubmitLogs without ingesting anything. The rate limit is read
+// without taking a token, so setup scripts can call it repeatedly. Failed
+// checks are reported in the response instead of being returned as errors
+func (s *LogReceivingService) ValidateIngestion(
+	projectID uuid.UUID,
+	clientIP, apiKey, origin string,
+) (*ValidateIngestionResponseDTO, error) {
+	err := s.validateIngestion(projectID, logs_core.NormalizeClientIP(clientIP), apiKey, origin)
+	if err == nil {
+		return &ValidateIngestionResponseDTO{Accepted: true}, nil
+	}
+
+	var validationErr *logs_core.ValidationError
+	if errors.As(err, &validationErr) {
+		return &ValidateIngestionResponseDTO{
+			Accepted: false,
+			Code:     validationErr.Code,
+			Reason:   validationErr.Message,
+		}, nil
+	}
+
+	return nil, err
+}
+
+func (s *LogReceivingService) validateIngestion(projectID uuid.UUID, clientIP, apiKey, origin string) error {
+	project, err := s.validateBasicProjectConstraints(projectID, origin, clientIP)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.validateApiKey(project, apiKey); err != nil {
+		return err
+	}
+
+	rateLimit, err := s.getRateLimitStatus(project)
+	if err != nil {
+		return err
+	}
+
+	if !rateLimit.IsUnlimited && rateLimit.Remaining == 0 {
+		return &logs_core.ValidationError{
+			Code:    logs_core.ErrorRateLimitExceeded,
+			Message: "logs per second limit exceeded",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package logs_receiving
+
+import (
+	"regexp"
+	"sync"
+)
+
+// multilinePatterns caches compiled project patterns, keyed by the pattern
+var multilinePatterns sync.Map
+
+// joinMultilineLogs appends the message of every log matching the continuation
+// pattern to the previous log of the batch, so a stack trace split into lines by
+// a file tailing agent is stored as one log. Continuation lines keep only their
+// message, level, timestamp and fields of the first line are used. A matching
+// first log of the batch has nothing to join to and is kept as it is.
+// The request indexes of the returned logs are returned too, so submission
+// errors point at the lines the client sent
+func joinMultilineLogs(
+	logRequests []LogItemRequestDTO,
+	continuationPattern *regexp.Regexp,
+) ([]LogItemRequestDTO, []int) {
+	joinedLogs := make([]LogItemRequestDTO, 0, len(logRequests))
+	requestIndexes := make([]int, 0, len(logRequests))
+
+	for i, logRequest := range logRequests {
+		if continuationPattern != nil && len(joinedLogs) > 0 && continuationPattern.MatchString(logRequest.Message) {
+			previousLog := &joinedLogs[len(joinedLogs)-1]
+			previousLog.Message += "\n" + logRequest.Message
+			continue
+		}
+
+		joinedLogs = append(joinedLogs, logRequest)
+		requestIndexes = append(requestIndexes, i)
+	}
+
+	return joinedLogs, requestIndexes
+}
+
+// getMultilinePattern returns nil when joining is disabled. Patterns are
+// validated when saved, one which does not compile disables joining
+func (s *LogReceivingService) getMultilinePattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+
+	if compiledPattern, ok := multilinePatterns.Load(pattern); ok {
+		return compiledPattern.(*regexp.Regexp)
+	}
+
+	compiledPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		s.logger.Error("Invalid multiline pattern, logs are not joined", "pattern", pattern, "error", err)
+		return nil
+	}
+
+	multilinePatterns.Store(pattern, compiledPattern)
+
+	return compiledPattern
+}
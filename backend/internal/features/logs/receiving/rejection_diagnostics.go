@@ -0,0 +1,155 @@
+package logs_receiving
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+)
+
+const (
+	// Number of recent rejection events kept per project
+	RejectionEventsPerProject = 100
+	// rejections of a project expire this long after its last rejection
+	DefaultRejectionsRetention = 24 * time.Hour
+	// rejected log messages are cut to this many characters in samples
+	maxRejectionSampleLength = 200
+
+	rejectionEventsKeyPrefix = "ingestion_rejections:events:"
+	rejectionTotalsKeyPrefix = "ingestion_rejections:totals:"
+	rejectionsCacheTimeout   = 2 * time.Second
+)
+
+// RejectionDiagnostics keeps recent ingestion rejections per project in the
+// cache, so dropped logs can be explained to project owners whichever instance
+// received them. Events are a capped list, totals a hash of logs per code, both
+// expire after the retention without new rejections
+type RejectionDiagnostics struct {
+	client    valkey.Client
+	logger    *slog.Logger
+	capacity  int
+	retention time.Duration
+}
+
+func NewRejectionDiagnostics(client valkey.Client, logger *slog.Logger, capacity int) *RejectionDiagnostics {
+	return &RejectionDiagnostics{
+		client:    client,
+		logger:    logger,
+		capacity:  capacity,
+		retention: DefaultRejectionsRetention,
+	}
+}
+
+func (d *RejectionDiagnostics) SetRetention(retention time.Duration) {
+	d.retention = retention
+}
+
+func (d *RejectionDiagnostics) GetRetention() time.Duration {
+	return d.retention
+}
+
+// Record stores a rejection event. sample is the message of a rejected log, it
+// may be empty. Failures are logged only, diagnostics never fail ingestion
+func (d *RejectionDiagnostics) Record(projectID uuid.UUID, code, reason, sample string, logsCount int) {
+	event := IngestionRejectionEventDTO{
+		Code:       code,
+		Reason:     reason,
+		Sample:     truncateRejectionSample(sample),
+		LogsCount:  logsCount,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		d.logger.Error("Failed to encode ingestion rejection", "projectId", projectID.String(), "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rejectionsCacheTimeout)
+	defer cancel()
+
+	eventsKey := rejectionEventsKeyPrefix + projectID.String()
+	totalsKey := rejectionTotalsKeyPrefix + projectID.String()
+	retentionMillis := d.retention.Milliseconds()
+
+	results := d.client.DoMulti(ctx,
+		d.client.B().Lpush().Key(eventsKey).Element(string(eventJSON)).Build(),
+		d.client.B().Ltrim().Key(eventsKey).Start(0).Stop(int64(d.capacity-1)).Build(),
+		d.client.B().Pexpire().Key(eventsKey).Milliseconds(retentionMillis).Build(),
+		d.client.B().Hincrby().Key(totalsKey).Field(code).Increment(int64(logsCount)).Build(),
+		d.client.B().Pexpire().Key(totalsKey).Milliseconds(retentionMillis).Build(),
+	)
+
+	for _, result := range results {
+		if err := result.Error(); err != nil {
+			d.logger.Error("Failed to record ingestion rejection", "projectId", projectID.String(), "error", err)
+			return
+		}
+	}
+}
+
+func (d *RejectionDiagnostics) Get(projectID uuid.UUID) (*IngestionRejectionsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rejectionsCacheTimeout)
+	defer cancel()
+
+	results := d.client.DoMulti(ctx,
+		d.client.B().Lrange().Key(rejectionEventsKeyPrefix+projectID.String()).Start(0).Stop(-1).Build(),
+		d.client.B().Hgetall().Key(rejectionTotalsKeyPrefix+projectID.String()).Build(),
+	)
+
+	encodedEvents, err := results[0].AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingestion rejection events: %w", err)
+	}
+
+	totalsByCode, err := results[1].AsIntMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingestion rejection totals: %w", err)
+	}
+
+	response := &IngestionRejectionsResponseDTO{
+		TotalsByCode: totalsByCode,
+		Events:       make([]IngestionRejectionEventDTO, 0, len(encodedEvents)),
+	}
+
+	// Events are pushed to the head of the list, so they are newest first
+	for _, encodedEvent := range encodedEvents {
+		var event IngestionRejectionEventDTO
+		if err := json.Unmarshal([]byte(encodedEvent), &event); err != nil {
+			d.logger.Warn("Skipping malformed ingestion rejection", "projectId", projectID.String(), "error", err)
+			continue
+		}
+
+		response.Events = append(response.Events, event)
+	}
+
+	return response, nil
+}
+
+func (d *RejectionDiagnostics) OnBeforeProjectDeletion(projectID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rejectionsCacheTimeout)
+	defer cancel()
+
+	result := d.client.Do(ctx, d.client.B().Del().Key(
+		rejectionEventsKeyPrefix+projectID.String(),
+		rejectionTotalsKeyPrefix+projectID.String(),
+	).Build())
+	if err := result.Error(); err != nil {
+		return fmt.Errorf("failed to delete ingestion rejections: %w", err)
+	}
+
+	return nil
+}
+
+func truncateRejectionSample(sample string) string {
+	runes := []rune(sample)
+	if len(runes) <= maxRejectionSampleLength {
+		return sample
+	}
+
+	return string(runes[:maxRejectionSampleLength]) + "..."
+}
@@ -0,0 +1,39 @@
+package logs_receiving
+
+import (
+	"math/rand/v2"
+
+	logs_core "logbull/internal/features/logs/core"
+)
+
+// sampleLogs keeps about samplingPercent of the logs and returns them with the
+// count of dropped ones. Kept logs carry the weight of the logs they stand for,
+// so stats can estimate the volume before sampling. Test ingest logs are always
+// kept, so the test shows whether ingestion works
+func sampleLogs(logs []*logs_core.LogItem, samplingPercent int) ([]*logs_core.LogItem, int) {
+	if samplingPercent <= 0 || samplingPercent >= 100 {
+		return logs, 0
+	}
+
+	sampleWeight := 100 / float64(samplingPercent)
+
+	keptLogs := make([]*logs_core.LogItem, 0, len(logs))
+	for _, logItem := range logs {
+		if isTestIngestLog(logItem) {
+			keptLogs = append(keptLogs, logItem)
+			continue
+		}
+
+		if rand.IntN(100) < samplingPercent {
+			logItem.SampleWeight = sampleWeight
+			keptLogs = append(keptLogs, logItem)
+		}
+	}
+
+	return keptLogs, len(logs) - len(keptLogs)
+}
+
+func isTestIngestLog(logItem *logs_core.LogItem) bool {
+	isTest, _ := logItem.Fields[TestIngestField].(bool)
+	return isTest
+}
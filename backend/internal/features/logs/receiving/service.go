@@ -1,17 +1,24 @@
 package logs_receiving
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net"
+	"slices"
 	"strings"
 	"time"
 
 	api_keys "logbull/internal/features/api_keys"
+	logs_attachments "logbull/internal/features/logs/attachments"
 	logs_core "logbull/internal/features/logs/core"
 	projects_models "logbull/internal/features/projects/models"
 	projects_services "logbull/internal/features/projects/services"
+	users_enums "logbull/internal/features/users/enums"
+	users_models "logbull/internal/features/users/models"
 	rate_limit "logbull/internal/util/rate_limit"
 	time_parser "logbull/internal/util/time"
 
@@ -23,20 +30,52 @@ const (
 	LogsBurstMultiplier = 5 // 5x base limit for burst handling
 
 	// Batch limits
-	MaxBatchSize      = 1000             // Maximum number of logs per batch
+	MaxBatchSize      = 1000             // Default maximum number of logs per batch
 	MaxBatchSizeBytes = 10 * 1024 * 1024 // 10MB maximum batch size
 
 	// Individual log limits
 	MaxLogSizeFactor = 1024 // Convert KB to bytes
+
+	// Marks logs sent by the test ingest endpoint
+	TestIngestField = "logbull_test_ingest"
 )
 
 type LogReceivingService struct {
-	logRepository    *logs_core.LogCoreRepository
-	rateLimiter      *rate_limit.RateLimiter
-	projectService   *projects_services.ProjectService
-	apiKeyService    *api_keys.ApiKeyService
-	logWorkerService *LogWorkerService
-	logger           *slog.Logger
+	logRepository      *logs_core.LogCoreRepository
+	rateLimiter        *rate_limit.RateLimiter
+	projectService     *projects_services.ProjectService
+	apiKeyService      *api_keys.ApiKeyService
+	ingestTokenService *api_keys.IngestTokenService
+	logWorkerService   *LogWorkerService
+	logger             *slog.Logger
+	// nil when GeoIP enrichment is disabled
+	geoIPResolver        GeoIPResolver
+	rejectionDiagnostics *RejectionDiagnostics
+	apiKeyUsageTracker   *ApiKeyUsageTracker
+	attachmentService    *logs_attachments.AttachmentService
+	maxBatchSize         int
+	firstLogNotifier     *FirstLogNotifier
+	fieldNameRules       FieldNameRules
+}
+
+func (s *LogReceivingService) SetGeoIPResolver(resolver GeoIPResolver) {
+	s.geoIPResolver = resolver
+}
+
+func (s *LogReceivingService) SetMaxBatchSize(maxBatchSize int) {
+	s.maxBatchSize = maxBatchSize
+}
+
+func (s *LogReceivingService) GetMaxBatchSize() int {
+	return s.maxBatchSize
+}
+
+func (s *LogReceivingService) SetFieldNameRules(fieldNameRules FieldNameRules) {
+	s.fieldNameRules = fieldNameRules
+}
+
+func (s *LogReceivingService) GetFieldNameRules() FieldNameRules {
+	return s.fieldNameRules
 }
 
 func (s *LogReceivingService) SubmitLogs(
@@ -44,88 +83,465 @@ func (s *LogReceivingService) SubmitLogs(
 	request *SubmitLogsRequestDTO,
 	clientIP, apiKey, origin string,
 ) (*SubmitLogsResponseDTO, error) {
-	if err := s.validateBasicBatchLimits(request); err != nil {
+	response, _, err := s.ingestLogs(projectID, request, clientIP, apiKey, origin, true)
+	s.recordRejections(projectID, request.Logs, response, err)
+
+	return response, err
+}
+
+// SubmitLog ingests a single log as a one element batch, so it passes the same
+// checks as SubmitLogs. A rejected log is returned as a validation error
+func (s *LogReceivingService) SubmitLog(
+	projectID uuid.UUID,
+	request *LogItemRequestDTO,
+	clientIP, apiKey, origin string,
+) (*SubmitLogResponseDTO, error) {
+	batchRequest := &SubmitLogsRequestDTO{Logs: []LogItemRequestDTO{*request}}
+
+	response, validLogs, err := s.ingestLogs(projectID, batchRequest, clientIP, apiKey, origin, true)
+	s.recordRejections(projectID, batchRequest.Logs, response, err)
+	if err != nil {
 		return nil, err
 	}
 
-	project, err := s.validateBasicProjectConstraints(projectID, origin, clientIP)
+	if len(validLogs) == 0 {
+		code := logs_core.ErrorInvalidLogLevel
+		if len(response.Errors) > 0 {
+			code = response.Errors[0].Code
+		}
+
+		return nil, &logs_core.ValidationError{
+			Code:    code,
+			Message: "log rejected by validation: " + code,
+		}
+	}
+
+	return &SubmitLogResponseDTO{ID: validLogs[0].ID, IsSampledOut: response.SampledOut > 0}, nil
+}
+
+// GetIngestionRejections returns recent reasons of dropped logs for the project
+func (s *LogReceivingService) GetIngestionRejections(
+	projectID uuid.UUID,
+	user *users_models.User,
+) (*IngestionRejectionsResponseDTO, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
 	if err != nil {
 		return nil, err
 	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to view ingestion rejections")
+	}
+
+	return s.rejectionDiagnostics.Get(projectID)
+}
 
-	if err := s.validateApiKey(project, apiKey); err != nil {
+// GetApiKeyUsage returns the logs ingested through each API key of the project
+// in buckets of the interval, so volume can be attributed to services
+func (s *LogReceivingService) GetApiKeyUsage(
+	projectID uuid.UUID,
+	request *GetApiKeyUsageRequestDTO,
+	user *users_models.User,
+) (*ApiKeyUsageResponseDTO, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
 		return nil, err
 	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to view API key usage")
+	}
 
-	_, err = s.validateRateLimit(project)
+	interval := request.Interval
+	if interval == "" {
+		interval = ApiKeyUsageIntervalHour
+	}
+
+	bucketSize := time.Hour
+	switch interval {
+	case ApiKeyUsageIntervalHour:
+	case ApiKeyUsageIntervalDay:
+		bucketSize = 24 * time.Hour
+	default:
+		return nil, &logs_core.ValidationError{
+			Code:    logs_core.ErrorInvalidUsageRange,
+			Message: fmt.Sprintf("interval must be %s or %s", ApiKeyUsageIntervalHour, ApiKeyUsageIntervalDay),
+		}
+	}
+
+	to := time.Now().UTC()
+	if request.To != nil {
+		to = request.To.UTC()
+	}
+
+	from := to.Add(-24 * time.Hour)
+	if request.From != nil {
+		from = request.From.UTC()
+	}
+
+	retention := s.apiKeyUsageTracker.GetRetention()
+	if !from.Before(to) || to.Sub(from) > retention {
+		return nil, &logs_core.ValidationError{
+			Code: logs_core.ErrorInvalidUsageRange,
+			Message: fmt.Sprintf(
+				"from must be before to and the range must not exceed %d days",
+				int(retention.Hours()/24),
+			),
+		}
+	}
+
+	usage, err := s.apiKeyUsageTracker.Get(projectID, from, to, interval)
 	if err != nil {
 		return nil, err
 	}
 
-	validLogs, errors, totalBatchSize := s.processLogItems(request.Logs, project, projectID, clientIP)
+	apiKeys, err := s.apiKeyService.GetProjectApiKeys(projectID, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	apiKeyNames := make(map[uuid.UUID]string, len(apiKeys.ApiKeys))
+	for _, apiKey := range apiKeys.ApiKeys {
+		apiKeyNames[apiKey.ID] = apiKey.Name
+	}
 
-	if err := s.validateTotalBatchSize(totalBatchSize); err != nil {
+	response := &ApiKeyUsageResponseDTO{
+		From:     from,
+		To:       to,
+		Interval: interval,
+		ApiKeys:  make([]ApiKeyUsageDTO, 0, len(usage)),
+	}
+
+	for apiKeyID, countsByBucket := range usage {
+		apiKeyUsage := ApiKeyUsageDTO{
+			ApiKeyID: apiKeyID,
+			Name:     apiKeyNames[apiKeyID],
+			Buckets:  []ApiKeyUsageBucketDTO{},
+		}
+
+		for bucketStart := from.Truncate(bucketSize); bucketStart.Before(to); bucketStart = bucketStart.Add(bucketSize) {
+			logsCount := countsByBucket[bucketStart]
+			apiKeyUsage.TotalLogs += logsCount
+			apiKeyUsage.Buckets = append(apiKeyUsage.Buckets, ApiKeyUsageBucketDTO{
+				Start:     bucketStart,
+				LogsCount: logsCount,
+			})
+		}
+
+		response.ApiKeys = append(response.ApiKeys, apiKeyUsage)
+	}
+
+	slices.SortFunc(response.ApiKeys, func(a, b ApiKeyUsageDTO) int {
+		if a.TotalLogs != b.TotalLogs {
+			return cmp.Compare(b.TotalLogs, a.TotalLogs)
+		}
+
+		return strings.Compare(a.ApiKeyID.String(), b.ApiKeyID.String())
+	})
+
+	return response, nil
+}
+
+// GetWorkerStatus returns the state of the receiving workers of this instance
+func (s *LogReceivingService) GetWorkerStatus(user *users_models.User) (*WorkerStatusDTO, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only administrators can view receiving worker status")
+	}
+
+	return s.logWorkerService.GetStatus()
+}
+
+// TestIngest sends a synthetic log through the same pipeline as SubmitLogs, so
+// project owners can check API key, filters and limits. Validation failures are
+// reported in the response instead of being returned as errors. The response
+// also shows the rate limit and quota headroom left after the test log
+func (s *LogReceivingService) TestIngest(
+	projectID uuid.UUID,
+	request *TestIngestRequestDTO,
+	user *users_models.User,
+) (*TestIngestResponseDTO, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
 		return nil, err
 	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to test project ingestion")
+	}
 
-	s.queueValidLogs(validLogs, projectID)
+	response, err := s.sendTestLog(projectID, request)
+	if err != nil {
+		return nil, err
+	}
 
-	return &SubmitLogsResponseDTO{
-		Accepted: len(validLogs),
-		Rejected: len(errors),
-		Errors:   errors,
+	limits, err := s.getIngestionLimits(projectID)
+	if err != nil {
+		s.logger.Warn("Failed to get ingestion limits for test ingest",
+			"projectId", projectID.String(), "error", err)
+	} else {
+		response.Limits = limits
+	}
+
+	return response, nil
+}
+
+func (s *LogReceivingService) sendTestLog(
+	projectID uuid.UUID,
+	request *TestIngestRequestDTO,
+) (*TestIngestResponseDTO, error) {
+	testLogRequest := &SubmitLogsRequestDTO{
+		Logs: []LogItemRequestDTO{
+			{
+				Level:   logs_core.LogLevelInfo,
+				Message: "LogBull test ingest: if you see this log, ingestion is configured correctly",
+				Fields: map[string]any{
+					TestIngestField: true,
+				},
+			},
+		},
+	}
+
+	isStoreLog := !request.DeleteAfter
+	submitResponse, validLogs, err := s.ingestLogs(
+		projectID,
+		testLogRequest,
+		request.ClientIP,
+		request.ApiKey,
+		request.Origin,
+		isStoreLog,
+	)
+	if err != nil {
+		var validationErr *logs_core.ValidationError
+		if errors.As(err, &validationErr) {
+			return &TestIngestResponseDTO{
+				Accepted: false,
+				Code:     validationErr.Code,
+				Reason:   validationErr.Message,
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	if submitResponse.Rejected > 0 || len(validLogs) == 0 {
+		response := &TestIngestResponseDTO{Accepted: false}
+		if len(submitResponse.Errors) > 0 {
+			response.Code = submitResponse.Errors[0].Code
+			response.Reason = "log rejected by validation: " + submitResponse.Errors[0].Message
+		}
+
+		return response, nil
+	}
+
+	return &TestIngestResponseDTO{
+		Accepted: true,
+		LogID:    &validLogs[0].ID,
+		IsStored: isStoreLog,
 	}, nil
 }
 
+// ingestLogs runs the whole ingestion pipeline. Logs are only queued for
+// storage when isQueueLogs is set, otherwise they are validated only
+func (s *LogReceivingService) ingestLogs(
+	projectID uuid.UUID,
+	request *SubmitLogsRequestDTO,
+	clientIP, apiKey, origin string,
+	isQueueLogs bool,
+) (*SubmitLogsResponseDTO, []*logs_core.LogItem, error) {
+	clientIP = logs_core.NormalizeClientIP(clientIP)
+
+	if err := s.validateBasicBatchLimits(request); err != nil {
+		return nil, nil, err
+	}
+
+	project, err := s.validateBasicProjectConstraints(projectID, origin, clientIP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiKeyID, err := s.validateApiKey(project, apiKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = s.validateRateLimit(project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fieldCipher, err := s.getFieldCipher(project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logRequests, requestIndexes := joinMultilineLogs(request.Logs, s.getMultilinePattern(project.MultilinePattern))
+
+	validLogs, errors, totalBatchSize := s.processLogItems(
+		logRequests,
+		requestIndexes,
+		project,
+		projectID,
+		clientIP,
+		fieldCipher,
+	)
+
+	if err := s.validateTotalBatchSize(totalBatchSize); err != nil {
+		return nil, nil, err
+	}
+
+	storedLogs, sampledOut := validLogs, 0
+	if isQueueLogs {
+		storedLogs, sampledOut = sampleLogs(validLogs, project.SamplingPercent)
+		s.queueValidLogs(storedLogs, projectID)
+
+		if len(storedLogs) > 0 && project.FirstLogReceivedAt == nil {
+			s.recordFirstLog(project, storedLogs[0])
+		}
+
+		// Accepted logs are counted before sampling, it is the volume the key sends
+		if apiKeyID != uuid.Nil && len(validLogs) > 0 {
+			s.apiKeyUsageTracker.Record(projectID, apiKeyID, len(validLogs))
+		}
+	}
+
+	logIDs := make([]uuid.UUID, 0, len(storedLogs))
+	for _, logItem := range storedLogs {
+		logIDs = append(logIDs, logItem.ID)
+	}
+
+	return &SubmitLogsResponseDTO{
+		Accepted:   len(validLogs),
+		Rejected:   len(errors),
+		SampledOut: sampledOut,
+		IDs:        logIDs,
+		Errors:     errors,
+	}, validLogs, nil
+}
+
+// recordFirstLog marks the project and calls its first log webhook. Only the
+// call which sets the mark sends the event
+func (s *LogReceivingService) recordFirstLog(project *projects_models.Project, firstLog *logs_core.LogItem) {
+	receivedAt := time.Now().UTC()
+
+	isMarked, err := s.projectService.MarkFirstLogReceived(project.ID, receivedAt)
+	if err != nil {
+		s.logger.Error("Failed to record first project log", "projectId", project.ID.String(), "error", err)
+		return
+	}
+
+	if !isMarked || project.FirstLogWebhookURL == "" {
+		return
+	}
+
+	s.firstLogNotifier.Notify(project.FirstLogWebhookURL, &FirstLogEventDTO{
+		Event:       FirstLogReceivedEvent,
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		LogID:       firstLog.ID,
+		ReceivedAt:  receivedAt,
+	})
+}
+
+// processLogItems validates and converts the logs, requestIndexes hold the
+// index in the request of each log and are used in submission errors
 func (s *LogReceivingService) processLogItems(
 	logRequests []LogItemRequestDTO,
+	requestIndexes []int,
 	project *projects_models.Project,
 	projectID uuid.UUID,
 	clientIP string,
+	fieldCipher *logs_core.FieldCipher,
 ) ([]*logs_core.LogItem, []LogSubmissionError, int) {
 	var validLogs []*logs_core.LogItem
 	var errors []LogSubmissionError
 	var totalBatchSize int
 
 	for i, logRequest := range logRequests {
-		logSize, err := s.calculateLogSize(&logRequest)
+		// Level is resolved before field filtering, so the level field may be a dropped one
+		if logRequest.Level == "" {
+			logRequest.Level = s.levelFromMappedField(&logRequest, project)
+		} else {
+			logRequest.Level = logs_core.NormalizeLogLevel(string(logRequest.Level))
+		}
+		// Without a project default level, logs lacking a level are rejected
+		if logRequest.Level == "" {
+			logRequest.Level = logs_core.LogLevel(project.DefaultLevel)
+		}
+		// Levels the project does not allow are mapped when configured. Without a mapping
+		// valid ones are rejected, invalid ones fail the level validation below
+		if len(project.AllowedLevels) > 0 && !slices.Contains(project.AllowedLevels, string(logRequest.Level)) {
+			if project.DisallowedLevelMapping != "" {
+				logRequest.Level = logs_core.LogLevel(project.DisallowedLevelMapping)
+			} else if logRequest.Level.IsValid() {
+				errors = append(errors, LogSubmissionError{
+					Index:   requestIndexes[i],
+					Code:    logs_core.ErrorLevelNotAllowed,
+					Message: logs_core.ErrorLevelNotAllowed,
+				})
+
+				continue
+			}
+		}
 
+		// Sanitized before filtering, so field filters list the stored names
+		fields, err := s.fieldNameRules.apply(logRequest.Fields)
 		if err != nil {
-			message := fmt.Sprintf("failed to calculate log size: %v", err)
-			if validationErr, ok := err.(*logs_core.ValidationError); ok {
-				message = validationErr.Code
+			errors = append(errors, newLogSubmissionError(requestIndexes[i], err))
+
+			continue
+		}
+
+		// Lowercased before filtering, so settings list the lowercase names
+		if project.IsFieldNamesLowercased {
+			fields = lowercaseFieldNames(fields)
+		}
+
+		logRequest.Fields = filterLogFields(fields, project)
+
+		// Encrypted before offloading, so attachments never hold plaintext either
+		if fieldCipher != nil {
+			if err := fieldCipher.EncryptFields(logRequest.Fields, project.EncryptedFields); err != nil {
+				errors = append(errors, newLogSubmissionError(
+					requestIndexes[i],
+					fmt.Errorf("failed to encrypt log fields: %w", err),
+				))
+
+				continue
 			}
+		}
 
-			errors = append(errors, LogSubmissionError{
-				Index:   i,
-				Message: message,
-			})
+		// Offloaded before the size check, so large payloads fit into the log size limit
+		thresholdBytes := project.AttachmentThresholdKB * MaxLogSizeFactor
+		if err := s.attachmentService.OffloadLargeFields(projectID, thresholdBytes, logRequest.Fields); err != nil {
+			s.logger.Error("Failed to offload log fields", "projectId", projectID.String(), "error", err)
+
+			errors = append(errors, newLogSubmissionError(
+				requestIndexes[i],
+				fmt.Errorf("failed to offload log fields: %w", err),
+			))
 
 			continue
 		}
 
-		totalBatchSize += logSize
+		logSize, err := s.calculateLogSize(&logRequest)
+
+		if err != nil {
+			if _, ok := err.(*logs_core.ValidationError); !ok {
+				err = fmt.Errorf("failed to calculate log size: %w", err)
+			}
+
+			errors = append(errors, newLogSubmissionError(requestIndexes[i], err))
 
-		if logRequest.Level == "warning" {
-			logRequest.Level = logs_core.LogLevelWarn
+			continue
 		}
 
-		if err := s.validateLogItemWithSize(&logRequest, project, logSize); err != nil {
-			message := err.Error()
-			if validationErr, ok := err.(*logs_core.ValidationError); ok {
-				message = validationErr.Code
-			}
+		totalBatchSize += logSize
 
-			errors = append(errors, LogSubmissionError{
-				Index:   i,
-				Message: message,
-			})
+		if err := s.validateLogItemWithSize(&logRequest, project, logSize); err != nil {
+			errors = append(errors, newLogSubmissionError(requestIndexes[i], err))
 
 			continue
 		}
 
 		logItem := &logs_core.LogItem{
-			ID:        uuid.New(),
+			ID:        uuid.Must(uuid.NewV7()), // time ordered, breaks ties of equal timestamps in arrival order
 			ProjectID: projectID,
 			Timestamp: time_parser.ParseTimestamp(logRequest.Timestamp),
 			Level:     logRequest.Level,
@@ -134,12 +550,137 @@ func (s *LogReceivingService) processLogItems(
 			ClientIP:  clientIP,
 		}
 
+		s.enrichWithGeoIP(logItem)
+
 		validLogs = append(validLogs, logItem)
 	}
 
 	return validLogs, errors, totalBatchSize
 }
 
+// newLogSubmissionError keeps the code of validation errors as the message,
+// other failures are reported as ErrorLogProcessingFailed with their text
+func newLogSubmissionError(index int, err error) LogSubmissionError {
+	var validationErr *logs_core.ValidationError
+	if errors.As(err, &validationErr) {
+		return LogSubmissionError{Index: index, Code: validationErr.Code, Message: validationErr.Code}
+	}
+
+	return LogSubmissionError{Index: index, Code: logs_core.ErrorLogProcessingFailed, Message: err.Error()}
+}
+
+// getFieldCipher returns nil when the project has no encrypted fields
+func (s *LogReceivingService) getFieldCipher(project *projects_models.Project) (*logs_core.FieldCipher, error) {
+	if len(project.EncryptedFields) == 0 {
+		return nil, nil
+	}
+
+	fieldEncryptionKey, err := s.projectService.GetFieldEncryptionKey(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field encryption key: %w", err)
+	}
+
+	return logs_core.NewFieldCipher(fieldEncryptionKey)
+}
+
+// recordRejections stores why logs of a submission were dropped. Whole batch
+// rejections are recorded once, rejected items are grouped by their code. The
+// message of the first rejected log is kept as a sample
+func (s *LogReceivingService) recordRejections(
+	projectID uuid.UUID,
+	logRequests []LogItemRequestDTO,
+	response *SubmitLogsResponseDTO,
+	err error,
+) {
+	if err != nil {
+		var validationErr *logs_core.ValidationError
+		if !errors.As(err, &validationErr) {
+			return
+		}
+
+		// Unknown projects are not tracked, otherwise any random ID would allocate cache keys
+		if validationErr.Code == logs_core.ErrorProjectNotFound {
+			return
+		}
+
+		var sample string
+		if len(logRequests) > 0 {
+			sample = logRequests[0].Message
+		}
+
+		s.rejectionDiagnostics.Record(projectID, validationErr.Code, validationErr.Message, sample, len(logRequests))
+		return
+	}
+
+	if response == nil || len(response.Errors) == 0 {
+		return
+	}
+
+	countsByCode := make(map[string]int)
+	samplesByCode := make(map[string]string)
+	var codes []string
+	for _, submissionError := range response.Errors {
+		code := submissionError.Code
+		if countsByCode[code] == 0 {
+			codes = append(codes, code)
+			if submissionError.Index < len(logRequests) {
+				samplesByCode[code] = logRequests[submissionError.Index].Message
+			}
+		}
+		countsByCode[code]++
+	}
+
+	for _, code := range codes {
+		s.rejectionDiagnostics.Record(
+			projectID,
+			code,
+			"logs rejected by validation: "+code,
+			samplesByCode[code],
+			countsByCode[code],
+		)
+	}
+}
+
+// levelFromMappedField reads the level from the project's configured level field,
+// so clients sending "severity" or "loglevel" instead of "level" are accepted
+func (s *LogReceivingService) levelFromMappedField(
+	logRequest *LogItemRequestDTO,
+	project *projects_models.Project,
+) logs_core.LogLevel {
+	if project.LevelField == "" {
+		return ""
+	}
+
+	value, ok := logRequest.Fields[project.LevelField].(string)
+	if !ok {
+		return ""
+	}
+
+	return logs_core.NormalizeLogLevel(value)
+}
+
+// filterLogFields drops custom fields not passing the project field filter. A new
+// map is returned, so fields of the request are left as they are
+func filterLogFields(fields map[string]any, project *projects_models.Project) map[string]any {
+	if project.FieldFilterMode == projects_models.FieldFilterModeNone || fields == nil {
+		return fields
+	}
+
+	filteredFields := make(map[string]any, len(fields))
+	for fieldName, value := range fields {
+		isStored := slices.Contains(project.FilteredFields, fieldName)
+		if project.FieldFilterMode == projects_models.FieldFilterModeBlock {
+			isStored = !isStored
+		}
+
+		if isStored {
+			filteredFields[fieldName] = value
+		}
+	}
+
+	return filteredFields
+}
+
 func (s *LogReceivingService) queueValidLogs(validLogs []*logs_core.LogItem, projectID uuid.UUID) {
 	if len(validLogs) == 0 {
 		return
@@ -159,6 +700,35 @@ func (s *LogReceivingService) queueValidLogs(validLogs []*logs_core.LogItem, pro
 	}
 }
 
+func (s *LogReceivingService) enrichWithGeoIP(logItem *logs_core.LogItem) {
+	if s.geoIPResolver == nil || logItem.ClientIP == "" {
+		return
+	}
+
+	ip := net.ParseIP(logItem.ClientIP)
+	if ip == nil {
+		return
+	}
+
+	location, found := s.geoIPResolver.Resolve(ip)
+	if !found {
+		return
+	}
+
+	fields := make(map[string]any, len(logItem.Fields)+2)
+	maps.Copy(fields, logItem.Fields)
+
+	// Values sent by the client take precedence over enrichment
+	if _, exists := fields[GeoCountryField]; !exists && location.Country != "" {
+		fields[GeoCountryField] = location.Country
+	}
+	if _, exists := fields[GeoCityField]; !exists && location.City != "" {
+		fields[GeoCityField] = location.City
+	}
+
+	logItem.Fields = fields
+}
+
 func (s *LogReceivingService) validateBasicBatchLimits(request *SubmitLogsRequestDTO) error {
 	if len(request.Logs) == 0 {
 		return &logs_core.ValidationError{
@@ -167,10 +737,14 @@ func (s *LogReceivingService) validateBasicBatchLimits(request *SubmitLogsReques
 		}
 	}
 
-	if len(request.Logs) > MaxBatchSize {
+	if len(request.Logs) > s.maxBatchSize {
 		return &logs_core.ValidationError{
-			Code:    logs_core.ErrorBatchTooLarge,
-			Message: fmt.Sprintf("batch size cannot exceed %d logs", MaxBatchSize),
+			Code: logs_core.ErrorBatchTooLarge,
+			Message: fmt.Sprintf(
+				"batch of %d logs exceeds the maximum of %d logs per request, split it into smaller batches",
+				len(request.Logs),
+				s.maxBatchSize,
+			),
 		}
 	}
 
@@ -200,13 +774,22 @@ func (s *LogReceivingService) validateBasicProjectConstraints(
 	return project, nil
 }
 
-func (s *LogReceivingService) validateApiKey(project *projects_models.Project, apiKey string) error {
-	if !project.IsApiKeyRequired {
-		return nil
+// validateApiKey accepts an API key or a bearer ingest token and returns the
+// ID of a valid API key, uuid.Nil otherwise. Unlike API keys, ingest tokens are
+// checked even when the project does not require a key, so a client with an
+// expired token learns about it. Keys sent to projects which do not require
+// one are still looked up, so their usage is attributed
+func (s *LogReceivingService) validateApiKey(project *projects_models.Project, apiKey string) (uuid.UUID, error) {
+	if s.ingestTokenService.IsIngestToken(apiKey) {
+		return uuid.Nil, s.validateIngestToken(project, apiKey)
 	}
 
 	if apiKey == "" {
-		return &logs_core.ValidationError{
+		if !project.IsApiKeyRequired {
+			return uuid.Nil, nil
+		}
+
+		return uuid.Nil, &logs_core.ValidationError{
 			Code:    logs_core.ErrorAPIKeyRequired,
 			Message: "API key required for this project",
 		}
@@ -214,16 +797,41 @@ func (s *LogReceivingService) validateApiKey(project *projects_models.Project, a
 
 	result, err := s.apiKeyService.ValidateApiKey(apiKey, project.ID)
 	if err != nil {
-		return fmt.Errorf("failed to validate API key: %w", err)
+		if !project.IsApiKeyRequired {
+			s.logger.Warn("Failed to validate optional API key", "projectId", project.ID.String(), "error", err)
+			return uuid.Nil, nil
+		}
+
+		return uuid.Nil, fmt.Errorf("failed to validate API key: %w", err)
 	}
 
 	if !result.IsValid {
-		return &logs_core.ValidationError{
+		if !project.IsApiKeyRequired {
+			return uuid.Nil, nil
+		}
+
+		return uuid.Nil, &logs_core.ValidationError{
 			Code:    logs_core.ErrorAPIKeyInvalid,
 			Message: "invalid API key",
 		}
 	}
 
+	return result.ApiKeyID, nil
+}
+
+func (s *LogReceivingService) validateIngestToken(project *projects_models.Project, token string) error {
+	isValid, err := s.ingestTokenService.ValidateIngestToken(token, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to validate ingest token: %w", err)
+	}
+
+	if !isValid {
+		return &logs_core.ValidationError{
+			Code:    logs_core.ErrorIngestTokenInvalid,
+			Message: "invalid or expired ingest token",
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,140 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WithAllowedLevels_DisallowedLevelRejected(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Allowed Levels Test "+uniqueID[:8], owner, router)
+
+	project.AllowedLevels = []string{"info", "ERROR", "Info"}
+	updatedProject := projects_testing.UpdateProject(project, project, owner.Token, router)
+	assert.Equal(t, []string{"INFO", "ERROR"}, updatedProject.AllowedLevels)
+
+	response := submitLevelTestLogs(t, router, project, uniqueID, []logs_core.LogLevel{
+		logs_core.LogLevelInfo,
+		logs_core.LogLevelError,
+		logs_core.LogLevelDebug,
+	})
+
+	assert.Equal(t, 2, response.Accepted)
+	assert.Equal(t, 1, response.Rejected)
+	assert.Equal(t, 2, response.Errors[0].Index)
+	assert.Equal(t, logs_core.ErrorLevelNotAllowed, response.Errors[0].Code)
+	assert.Equal(t, logs_core.ErrorLevelNotAllowed, response.Errors[0].Message)
+}
+
+func Test_SubmitLogs_WithDisallowedLevelMapping_DisallowedLevelMapped(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Allowed Levels Mapping Test "+uniqueID[:8], owner, router)
+
+	project.AllowedLevels = []string{"INFO", "WARN"}
+	project.DisallowedLevelMapping = "warn"
+	updatedProject := projects_testing.UpdateProject(project, project, owner.Token, router)
+	assert.Equal(t, string(logs_core.LogLevelWarn), updatedProject.DisallowedLevelMapping)
+
+	levels := []logs_core.LogLevel{logs_core.LogLevelInfo, logs_core.LogLevelDebug, logs_core.LogLevelFatal}
+	response := submitLevelTestLogs(t, router, project, uniqueID, levels)
+	assert.Equal(t, len(levels), response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	levelsByMessage := make(map[string]string)
+	for _, log := range waitForStoredLogsInAscendingOrder(t, project.ID, len(levels)) {
+		levelsByMessage[log.Message] = log.Level
+	}
+
+	assert.Equal(t, map[string]string{
+		levelTestMessage(uniqueID, 0): string(logs_core.LogLevelInfo),
+		levelTestMessage(uniqueID, 1): string(logs_core.LogLevelWarn),
+		levelTestMessage(uniqueID, 2): string(logs_core.LogLevelWarn),
+	}, levelsByMessage)
+}
+
+func Test_UpdateProject_WithInvalidAllowedLevels_ReturnsBadRequest(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Allowed Levels Invalid "+uuid.NewString()[:8], owner, router)
+
+	invalidSettings := []struct {
+		allowedLevels          []string
+		disallowedLevelMapping string
+		defaultLevel           string
+	}{
+		{allowedLevels: []string{"TRACE"}},
+		{allowedLevels: []string{"INFO", ""}},
+		{disallowedLevelMapping: "INFO"},
+		{allowedLevels: []string{"INFO"}, disallowedLevelMapping: "ERROR"},
+		{allowedLevels: []string{"INFO"}, defaultLevel: "DEBUG"},
+	}
+
+	for _, settings := range invalidSettings {
+		project.AllowedLevels = settings.allowedLevels
+		project.DisallowedLevelMapping = settings.disallowedLevelMapping
+		project.DefaultLevel = settings.defaultLevel
+
+		test_utils.MakePutRequest(
+			t,
+			router,
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			project,
+			http.StatusBadRequest,
+		)
+	}
+}
+
+func submitLevelTestLogs(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	uniqueID string,
+	levels []logs_core.LogLevel,
+) *logs_receiving.SubmitLogsResponseDTO {
+	logItems := make([]logs_receiving.LogItemRequestDTO, 0, len(levels))
+	for i, level := range levels {
+		logItems = append(logItems, logs_receiving.LogItemRequestDTO{
+			Level:   level,
+			Message: levelTestMessage(uniqueID, i),
+		})
+	}
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+
+	return &response
+}
+
+func levelTestMessage(uniqueID string, index int) string {
+	return fmt.Sprintf("Level test log %s - %d", uniqueID, index)
+}
@@ -0,0 +1,144 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	api_keys "logbull/internal/features/api_keys"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetApiKeyUsage_WhenLogsSentThroughDifferentKeys_CountsAttributedPerKey(t *testing.T) {
+	testData := setupApiKeyTest("Api Key Usage Test", false)
+	checkoutKey := api_keys.CreateTestApiKey("checkout", testData.Project.ID, testData.User.Token, testData.Router)
+	billingKey := api_keys.CreateTestApiKey("billing", testData.Project.ID, testData.User.Token, testData.Router)
+
+	for range 3 {
+		submitTestLogs(t, testData.Router, testData.Project.ID, checkoutKey.Token, testData.UniqueID)
+	}
+	submitTestLogs(t, testData.Router, testData.Project.ID, billingKey.Token, testData.UniqueID)
+	// Logs without a key or with an invalid one are accepted but not attributed
+	submitTestLogs(t, testData.Router, testData.Project.ID, "", testData.UniqueID)
+	submitTestLogs(t, testData.Router, testData.Project.ID, generateInvalidApiKeyToken(), testData.UniqueID)
+
+	usage := getApiKeyUsage(t, testData.Router, testData.Project.ID, testData.User.Token, url.Values{})
+
+	assert.Equal(t, logs_receiving.ApiKeyUsageIntervalHour, usage.Interval)
+	assert.Len(t, usage.ApiKeys, 2)
+
+	assert.Equal(t, checkoutKey.ID, usage.ApiKeys[0].ApiKeyID)
+	assert.Equal(t, "checkout", usage.ApiKeys[0].Name)
+	assert.Equal(t, int64(3), usage.ApiKeys[0].TotalLogs)
+
+	assert.Equal(t, billingKey.ID, usage.ApiKeys[1].ApiKeyID)
+	assert.Equal(t, "billing", usage.ApiKeys[1].Name)
+	assert.Equal(t, int64(1), usage.ApiKeys[1].TotalLogs)
+
+	// Every hour of the default 24 hours range is listed, the logs are in the current one
+	checkoutBuckets := usage.ApiKeys[0].Buckets
+	assert.GreaterOrEqual(t, len(checkoutBuckets), 24)
+	lastBucket := checkoutBuckets[len(checkoutBuckets)-1]
+	assert.Equal(t, time.Now().UTC().Truncate(time.Hour), lastBucket.Start)
+	assert.Equal(t, int64(3), lastBucket.LogsCount)
+}
+
+func Test_GetApiKeyUsage_WithDailyInterval_CountsSummedPerDay(t *testing.T) {
+	testData := setupApiKeyTest("Api Key Usage Daily Test", true)
+	apiKey := api_keys.CreateTestApiKey("worker", testData.Project.ID, testData.User.Token, testData.Router)
+
+	for range 2 {
+		submitTestLogs(t, testData.Router, testData.Project.ID, apiKey.Token, testData.UniqueID)
+	}
+
+	to := time.Now().UTC().Add(time.Minute)
+	from := to.Add(-7 * 24 * time.Hour)
+	usage := getApiKeyUsage(t, testData.Router, testData.Project.ID, testData.User.Token, url.Values{
+		"from":     {from.Format(time.RFC3339)},
+		"to":       {to.Format(time.RFC3339)},
+		"interval": {logs_receiving.ApiKeyUsageIntervalDay},
+	})
+
+	assert.Len(t, usage.ApiKeys, 1)
+	assert.Equal(t, int64(2), usage.ApiKeys[0].TotalLogs)
+
+	buckets := usage.ApiKeys[0].Buckets
+	assert.Len(t, buckets, 8)
+	assert.Equal(t, time.Now().UTC().Truncate(24*time.Hour), buckets[len(buckets)-1].Start)
+	assert.Equal(t, int64(2), buckets[len(buckets)-1].LogsCount)
+}
+
+func Test_GetApiKeyUsage_WhenOtherProjectKeyUsed_UsageNotShared(t *testing.T) {
+	firstProject := setupApiKeyTest("Api Key Usage Isolation First", false)
+	secondProject := setupApiKeyTest("Api Key Usage Isolation Second", false)
+	apiKey := api_keys.CreateTestApiKey(
+		"first only",
+		firstProject.Project.ID,
+		firstProject.User.Token,
+		firstProject.Router,
+	)
+
+	submitTestLogs(t, firstProject.Router, firstProject.Project.ID, apiKey.Token, firstProject.UniqueID)
+	// The key belongs to the first project, so the second one does not attribute it
+	submitTestLogs(t, secondProject.Router, secondProject.Project.ID, apiKey.Token, secondProject.UniqueID)
+
+	firstUsage := getApiKeyUsage(t, firstProject.Router, firstProject.Project.ID, firstProject.User.Token, nil)
+	assert.Len(t, firstUsage.ApiKeys, 1)
+	assert.Equal(t, int64(1), firstUsage.ApiKeys[0].TotalLogs)
+
+	secondUsage := getApiKeyUsage(t, secondProject.Router, secondProject.Project.ID, secondProject.User.Token, nil)
+	assert.Empty(t, secondUsage.ApiKeys)
+}
+
+func Test_GetApiKeyUsage_WithInvalidRequest_ReturnsError(t *testing.T) {
+	testData := setupApiKeyTest("Api Key Usage Invalid Test", false)
+	usageURL := fmt.Sprintf("/api/v1/projects/%s/api-key-usage", testData.Project.ID.String())
+	ownerAuth := "Bearer " + testData.User.Token
+
+	test_utils.MakeGetRequest(t, testData.Router, usageURL+"?interval=week", ownerAuth, http.StatusBadRequest)
+
+	now := time.Now().UTC()
+	tooLongRange := url.Values{
+		"from": {now.Add(-60 * 24 * time.Hour).Format(time.RFC3339)},
+		"to":   {now.Format(time.RFC3339)},
+	}
+	test_utils.MakeGetRequest(t, testData.Router, usageURL+"?"+tooLongRange.Encode(), ownerAuth, http.StatusBadRequest)
+
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(
+		testData.Project,
+		member,
+		users_enums.ProjectRoleMember,
+		testData.User.Token,
+		testData.Router,
+	)
+	test_utils.MakeGetRequest(t, testData.Router, usageURL, "Bearer "+member.Token, http.StatusForbidden)
+}
+
+func getApiKeyUsage(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	parameters url.Values,
+) *logs_receiving.ApiKeyUsageResponseDTO {
+	usageURL := fmt.Sprintf("/api/v1/projects/%s/api-key-usage", projectID.String())
+	if len(parameters) > 0 {
+		usageURL += "?" + parameters.Encode()
+	}
+
+	var usage logs_receiving.ApiKeyUsageResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(t, router, usageURL, "Bearer "+token, http.StatusOK, &usage)
+
+	return &usage
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	logs_receiving "logbull/internal/features/logs/receiving"
 	projects_testing "logbull/internal/features/projects/testing"
@@ -137,3 +138,87 @@ func Test_SubmitLogs_WithNonExistentProject_ReturnsNotFound(t *testing.T) {
 
 	assert.Contains(t, string(resp.Body), "project not found")
 }
+
+func Test_SubmitLogs_WithMultipleLogs_ReturnedIDsMatchStoredLogs(t *testing.T) {
+	router := CreateLogsTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateTestProject("Returned IDs Test "+uniqueID[:8], user, router)
+
+	const logsCount = 5
+	timestamp := time.Now().UTC().Add(-time.Minute).Format(time.RFC3339Nano)
+
+	logItems := CreateValidLogItems(logsCount, uniqueID)
+	for i := range logItems {
+		logItems[i].Timestamp = timestamp
+	}
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+	assert.Len(t, response.IDs, logsCount)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	storedLogs := waitForStoredLogsInAscendingOrder(t, project.ID, logsCount)
+
+	storedMessagesByID := make(map[string]string, len(storedLogs))
+	for _, storedLog := range storedLogs {
+		storedMessagesByID[storedLog.ID] = storedLog.Message
+	}
+
+	for i, logID := range response.IDs {
+		assert.Equal(t, logItems[i].Message, storedMessagesByID[logID.String()],
+			"Returned ID at position %d does not belong to the submitted log", i)
+	}
+}
+
+func Test_SubmitLogs_WithRejectedLogs_IDsReturnedForAcceptedLogsOnly(t *testing.T) {
+	router := CreateLogsTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateTestProject("Returned IDs Rejected Test "+uniqueID[:8], user, router)
+
+	logItems := CreateValidLogItems(3, uniqueID)
+	logItems[1].Level = "INVALID_LEVEL"
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+
+	assert.Equal(t, 2, response.Accepted)
+	assert.Len(t, response.IDs, 2)
+	if assert.Len(t, response.Errors, 1) {
+		assert.Equal(t, 1, response.Errors[0].Index)
+	}
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	storedLogs := waitForStoredLogsInAscendingOrder(t, project.ID, 2)
+
+	storedIDs := make([]string, 0, len(storedLogs))
+	for _, storedLog := range storedLogs {
+		storedIDs = append(storedIDs, storedLog.ID)
+	}
+	for _, logID := range response.IDs {
+		assert.Contains(t, storedIDs, logID.String())
+	}
+}
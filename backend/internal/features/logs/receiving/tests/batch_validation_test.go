@@ -51,6 +51,39 @@ func Test_SubmitLogs_ExceedingMaxBatchCount_ReturnsBadRequest(t *testing.T) {
 	assert.Contains(t, string(resp.Body), "BATCH_TOO_LARGE")
 }
 
+func Test_SubmitLogs_WithConfiguredMaxBatchSize_BatchAtLimitAccepted(t *testing.T) {
+	testData := setupBatchTest("Configured Batch Limit Test")
+	setMaxBatchSizeForTest(t, 5)
+
+	logItems := CreateValidLogItems(5, testData.UniqueID)
+
+	response := submitLogsForBatch(t, testData.Router, testData.Project.ID, logItems)
+
+	assert.Equal(t, 5, response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+	assert.Empty(t, response.Errors)
+}
+
+func Test_SubmitLogs_ExceedingConfiguredMaxBatchSize_RejectedWithSplitGuidance(t *testing.T) {
+	testData := setupBatchTest("Configured Batch Limit Exceeded Test")
+	setMaxBatchSizeForTest(t, 5)
+
+	logItems := CreateValidLogItems(6, testData.UniqueID)
+
+	resp := submitLogsForBatchExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		logItems,
+		http.StatusBadRequest,
+	)
+
+	body := string(resp.Body)
+	assert.Contains(t, body, "BATCH_TOO_LARGE")
+	assert.Contains(t, body, "batch of 6 logs exceeds the maximum of 5 logs per request")
+	assert.Contains(t, body, "split it into smaller batches")
+}
+
 func Test_SubmitLogs_ExceedingMaxBatchSizeBytes_ReturnsBadRequest(t *testing.T) {
 	testData := setupBatchTest("Exceeding Max Batch Size Bytes Test")
 
@@ -189,3 +222,13 @@ func submitLogsForBatchExpectingError(
 		ExpectedStatus: expectedStatus,
 	})
 }
+
+func setMaxBatchSizeForTest(t *testing.T, maxBatchSize int) {
+	service := logs_receiving.GetLogReceivingService()
+	previousMaxBatchSize := service.GetMaxBatchSize()
+
+	service.SetMaxBatchSize(maxBatchSize)
+	t.Cleanup(func() {
+		service.SetMaxBatchSize(previousMaxBatchSize)
+	})
+}
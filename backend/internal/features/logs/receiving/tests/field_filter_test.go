@@ -0,0 +1,156 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WithBlockListedFields_BlockListedFieldsOmitted(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Field Block List "+uniqueID[:8], owner, router)
+	configureFieldFilter(router, project, owner.Token, projects_models.FieldFilterModeBlock, "password", "card_number")
+
+	storedLog := submitLogAndGetStored(t, router, project, uniqueID, map[string]any{
+		"user_id":     "user-1",
+		"password":    "secret",
+		"card_number": "4111111111111111",
+	})
+
+	assert.Equal(t, "user-1", storedLog.Fields["user_id"])
+	assert.NotContains(t, storedLog.Fields, "password")
+	assert.NotContains(t, storedLog.Fields, "card_number")
+}
+
+func Test_SubmitLogs_WithAllowListedFields_OnlyAllowedAndSystemFieldsStored(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Field Allow List "+uniqueID[:8], owner, router)
+	configureFieldFilter(router, project, owner.Token, projects_models.FieldFilterModeAllow, "user_id", "order_id")
+
+	storedLog := submitLogAndGetStored(t, router, project, uniqueID, map[string]any{
+		"user_id":  "user-1",
+		"order_id": "order-1",
+		"session":  "session-1",
+		"debug":    "verbose payload",
+	})
+
+	assert.Equal(t, "user-1", storedLog.Fields["user_id"])
+	assert.Equal(t, "order-1", storedLog.Fields["order_id"])
+	assert.NotContains(t, storedLog.Fields, "session")
+	assert.NotContains(t, storedLog.Fields, "debug")
+
+	assert.Equal(t, string(logs_core.LogLevelInfo), storedLog.Level)
+	assert.Equal(t, "Field filter log "+uniqueID, storedLog.Message)
+	assert.False(t, storedLog.Timestamp.IsZero())
+}
+
+func Test_SubmitLogs_WithMappedLevelFieldNotAllowed_LevelStillMapped(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Field Filter Level "+uniqueID[:8], owner, router)
+
+	project.LevelField = "severity"
+	configureFieldFilter(router, project, owner.Token, projects_models.FieldFilterModeAllow, "user_id")
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: []logs_receiving.LogItemRequestDTO{
+			{
+				Message: "Field filter log " + uniqueID,
+				Fields:  map[string]any{"severity": "error", "user_id": "user-1"},
+			},
+		}},
+		http.StatusAccepted,
+		&response,
+	)
+	assert.Equal(t, 1, response.Accepted)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	storedLogs := waitForStoredLogsInAscendingOrder(t, project.ID, 1)
+	assert.Equal(t, string(logs_core.LogLevelError), storedLogs[0].Level)
+	assert.NotContains(t, storedLogs[0].Fields, "severity")
+}
+
+func Test_UpdateProject_WithInvalidFieldFilter_ReturnsBadRequest(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Field Filter Invalid "+uuid.NewString()[:8], owner, router)
+
+	project.FieldFilterMode = "DROP"
+	test_utils.MakePutRequest(t, router, "/api/v1/projects/"+project.ID.String(), "Bearer "+owner.Token,
+		project, http.StatusBadRequest)
+
+	project.FieldFilterMode = projects_models.FieldFilterModeBlock
+	project.FilteredFields = []string{"message"}
+	test_utils.MakePutRequest(t, router, "/api/v1/projects/"+project.ID.String(), "Bearer "+owner.Token,
+		project, http.StatusBadRequest)
+}
+
+func configureFieldFilter(
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	mode projects_models.FieldFilterMode,
+	fields ...string,
+) {
+	project.FieldFilterMode = mode
+	project.FilteredFields = fields
+
+	projects_testing.UpdateProject(project, project, token, router)
+}
+
+func submitLogAndGetStored(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	uniqueID string,
+	fields map[string]any,
+) logs_core.LogItemDTO {
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: []logs_receiving.LogItemRequestDTO{
+			{
+				Level:   logs_core.LogLevelInfo,
+				Message: "Field filter log " + uniqueID,
+				Fields:  fields,
+			},
+		}},
+		http.StatusAccepted,
+		&response,
+	)
+	assert.Equal(t, 1, response.Accepted)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	return waitForStoredLogsInAscendingOrder(t, project.ID, 1)[0]
+}
@@ -0,0 +1,101 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WithUnsafeFieldNames_NamesSanitized(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Field Names Sanitized "+uniqueID[:8], owner, router)
+	setFieldNameRulesForTest(t, logs_receiving.FieldNameRules{MaxLength: 16})
+
+	storedLog := submitLogAndGetStored(t, router, project, uniqueID, map[string]any{
+		"user\tid":                "user-1",
+		"order=id":                "order-1",
+		"method(param)":           "place",
+		"request_path_template_x": "/orders/{id}",
+		"tenant":                  "acme",
+	})
+
+	assert.Equal(t, "user-1", storedLog.Fields["user_id"])
+	assert.Equal(t, "order-1", storedLog.Fields["order_id"])
+	assert.Equal(t, "/orders/{id}", storedLog.Fields["request_path_tem"])
+	assert.Equal(t, "acme", storedLog.Fields["tenant"])
+	assert.Equal(t, "place", storedLog.Fields["method(param)"])
+	assert.NotContains(t, storedLog.Fields, "user\tid")
+	assert.NotContains(t, storedLog.Fields, "order=id")
+	assert.NotContains(t, storedLog.Fields, "request_path_template_x")
+}
+
+func Test_SubmitLogs_WhenSanitizedNameCollides_ExistingFieldKept(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Field Names Collision "+uniqueID[:8], owner, router)
+	setFieldNameRulesForTest(t, logs_receiving.FieldNameRules{MaxLength: logs_receiving.DefaultMaxFieldNameLength})
+
+	storedLog := submitLogAndGetStored(t, router, project, uniqueID, map[string]any{
+		"user_id": "valid",
+		"user=id": "sanitized",
+	})
+
+	assert.Equal(t, "valid", storedLog.Fields["user_id"])
+}
+
+func Test_SubmitLogs_WhenInvalidFieldNamesRejected_LogWithInvalidNameRejected(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Field Names Rejected "+uniqueID[:8], owner, router)
+	setFieldNameRulesForTest(t, logs_receiving.FieldNameRules{MaxLength: 16, IsRejected: true})
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: []logs_receiving.LogItemRequestDTO{
+			{Level: logs_core.LogLevelInfo, Message: "Valid " + uniqueID, Fields: map[string]any{"user_id": "1"}},
+			{Level: logs_core.LogLevelInfo, Message: "Too long " + uniqueID, Fields: map[string]any{
+				strings.Repeat("a", 17): "1",
+			}},
+			{Level: logs_core.LogLevelInfo, Message: "Unsafe " + uniqueID, Fields: map[string]any{"user=id": "1"}},
+			{Level: logs_core.LogLevelInfo, Message: "Empty segment " + uniqueID, Fields: map[string]any{"a..b": "1"}},
+		}},
+		http.StatusAccepted,
+		&response,
+	)
+
+	assert.Equal(t, 1, response.Accepted)
+	assert.Equal(t, 3, response.Rejected)
+	for i, submissionError := range response.Errors {
+		assert.Equal(t, i+1, submissionError.Index)
+		assert.Equal(t, logs_core.ErrorInvalidFieldName, submissionError.Message)
+	}
+}
+
+func setFieldNameRulesForTest(t *testing.T, fieldNameRules logs_receiving.FieldNameRules) {
+	service := logs_receiving.GetLogReceivingService()
+	previousFieldNameRules := service.GetFieldNameRules()
+
+	service.SetFieldNameRules(fieldNameRules)
+	t.Cleanup(func() {
+		service.SetFieldNameRules(previousFieldNameRules)
+	})
+}
@@ -0,0 +1,180 @@
+package logs_receiving_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_services "logbull/internal/features/projects/services"
+	projects_testing "logbull/internal/features/projects/testing"
+	system_webhooks "logbull/internal/features/system/webhooks"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WhenProjectReceivesFirstLog_WebhookCalledOnce(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("First Log Webhook "+uniqueID[:8], owner, router)
+
+	webhook := newFirstLogWebhookRecorder(t)
+	project.FirstLogWebhookURL = webhook.server.URL
+	projects_testing.UpdateProject(project, project, owner.Token, router)
+
+	firstResponse := submitFirstLogTestBatch(t, router, project.ID, uniqueID, 2)
+	submitFirstLogTestBatch(t, router, project.ID, uniqueID, 3)
+	logs_receiving.GetFirstLogNotifier().WaitForNotificationsForTest()
+
+	events := webhook.getEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, logs_receiving.FirstLogReceivedEvent, events[0].Event)
+	assert.Equal(t, project.ID, events[0].ProjectID)
+	assert.Equal(t, project.Name, events[0].ProjectName)
+	assert.Equal(t, firstResponse.IDs[0], events[0].LogID)
+
+	storedProject, err := projects_services.GetProjectService().GetProjectWithCache(project.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, storedProject.FirstLogReceivedAt)
+}
+
+func Test_SubmitLogs_WhenFirstLogReceivedWithoutWebhook_LaterWebhookNotCalled(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("First Log No Webhook "+uniqueID[:8], owner, router)
+
+	submitFirstLogTestBatch(t, router, project.ID, uniqueID, 1)
+
+	webhook := newFirstLogWebhookRecorder(t)
+	project.FirstLogWebhookURL = webhook.server.URL
+	updatedProject := projects_testing.UpdateProject(project, project, owner.Token, router)
+	assert.NotNil(t, updatedProject.FirstLogReceivedAt)
+
+	submitFirstLogTestBatch(t, router, project.ID, uniqueID, 1)
+	logs_receiving.GetFirstLogNotifier().WaitForNotificationsForTest()
+
+	assert.Empty(t, webhook.getEvents())
+}
+
+func Test_UpdateProject_WithInvalidFirstLogWebhookURL_ReturnsBadRequest(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("First Log Webhook Validation "+uuid.New().String()[:8], owner, router)
+
+	project.FirstLogWebhookURL = "ftp://example.com/hook"
+	test_utils.MakePutRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s", project.ID.String()),
+		"Bearer "+owner.Token,
+		project,
+		http.StatusBadRequest,
+	)
+}
+
+func Test_UpdateProject_WithPrivateOrLoopbackFirstLogWebhookURL_ReturnsBadRequest(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("First Log Webhook SSRF "+uuid.New().String()[:8], owner, router)
+	setWebhookPrivateNetworkAllowed(t, false)
+
+	webhookURLs := []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://10.0.0.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+	}
+
+	for _, webhookURL := range webhookURLs {
+		project.FirstLogWebhookURL = webhookURL
+		resp := test_utils.MakePutRequest(
+			t,
+			router,
+			fmt.Sprintf("/api/v1/projects/%s", project.ID.String()),
+			"Bearer "+owner.Token,
+			project,
+			http.StatusBadRequest,
+		)
+		assert.Contains(t, string(resp.Body), "first log webhook url is not allowed")
+	}
+}
+
+type firstLogWebhookRecorder struct {
+	server *httptest.Server
+
+	mu     sync.Mutex
+	events []logs_receiving.FirstLogEventDTO
+}
+
+func newFirstLogWebhookRecorder(t *testing.T) *firstLogWebhookRecorder {
+	setWebhookPrivateNetworkAllowed(t, true)
+
+	recorder := &firstLogWebhookRecorder{}
+	recorder.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event logs_receiving.FirstLogEventDTO
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recorder.mu.Lock()
+		recorder.events = append(recorder.events, event)
+		recorder.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(recorder.server.Close)
+
+	return recorder
+}
+
+func (r *firstLogWebhookRecorder) getEvents() []logs_receiving.FirstLogEventDTO {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]logs_receiving.FirstLogEventDTO(nil), r.events...)
+}
+
+// setWebhookPrivateNetworkAllowed lets webhooks reach the test receivers on
+// loopback, the setting is restored after the test
+func setWebhookPrivateNetworkAllowed(t *testing.T, isAllowed bool) {
+	destinationGuard := system_webhooks.GetDestinationGuard()
+	wasAllowed := destinationGuard.IsPrivateNetworkAllowed()
+
+	destinationGuard.SetPrivateNetworkAllowed(isAllowed)
+	t.Cleanup(func() {
+		destinationGuard.SetPrivateNetworkAllowed(wasAllowed)
+	})
+}
+
+func submitFirstLogTestBatch(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	uniqueID string,
+	count int,
+) *logs_receiving.SubmitLogsResponseDTO {
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", projectID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: CreateValidLogItems(count, uniqueID)},
+		http.StatusAccepted,
+		&response,
+	)
+
+	return &response
+}
@@ -0,0 +1,149 @@
+package logs_receiving_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	api_keys "logbull/internal/features/api_keys"
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_repositories "logbull/internal/features/users/repositories"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WithValidIngestToken_LogsAccepted(t *testing.T) {
+	testData := setupApiKeyTest("Ingest Token Valid Test", true)
+	ingestToken := createIngestToken(t, testData.Router, testData.Project.ID, testData.User.Token, 30, http.StatusOK)
+
+	assert.Equal(t, testData.Project.ID, ingestToken.ProjectID)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), ingestToken.ExpiresAt, time.Minute)
+
+	resp := submitLogsWithBearerToken(
+		t, testData.Router, testData.Project.ID, ingestToken.Token, testData.UniqueID, http.StatusAccepted,
+	)
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	assert.NoError(t, json.Unmarshal(resp.Body, &response))
+	assert.Equal(t, 1, response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+}
+
+func Test_SubmitLogs_WithInvalidIngestToken_ReturnsUnauthorized(t *testing.T) {
+	testData := setupApiKeyTest("Ingest Token Invalid Test", true)
+
+	resp := submitLogsWithBearerToken(
+		t, testData.Router, testData.Project.ID, api_keys.IngestTokenPrefix+"not.a.token", testData.UniqueID,
+		http.StatusUnauthorized,
+	)
+
+	assert.Contains(t, string(resp.Body), logs_core.ErrorIngestTokenInvalid)
+}
+
+func Test_SubmitLogs_WithExpiredIngestToken_ReturnsUnauthorized(t *testing.T) {
+	testData := setupApiKeyTest("Ingest Token Expired Test", false)
+	expiredToken := signTestIngestToken(t, testData.Project.ID, time.Now().Add(-time.Minute))
+
+	resp := submitLogsWithBearerToken(
+		t, testData.Router, testData.Project.ID, expiredToken, testData.UniqueID, http.StatusUnauthorized,
+	)
+
+	assert.Contains(t, string(resp.Body), "invalid or expired ingest token")
+}
+
+func Test_SubmitLogs_WithIngestTokenOfOtherProject_ReturnsUnauthorized(t *testing.T) {
+	testData1 := setupApiKeyTest("Ingest Token Project 1", true)
+	testData2 := setupApiKeyTest("Ingest Token Project 2", true)
+	ingestToken := createIngestToken(t, testData1.Router, testData1.Project.ID, testData1.User.Token, 0, http.StatusOK)
+
+	submitLogsWithBearerToken(
+		t, testData2.Router, testData2.Project.ID, ingestToken.Token, testData2.UniqueID, http.StatusUnauthorized,
+	)
+}
+
+func Test_CreateIngestToken_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
+	testData := setupApiKeyTest("Ingest Token Member Test", true)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projects_testing.AddMemberToProject(
+		testData.Project, member, users_enums.ProjectRoleMember, testData.User.Token, testData.Router,
+	)
+
+	createIngestToken(t, testData.Router, testData.Project.ID, member.Token, 0, http.StatusForbidden)
+}
+
+func Test_CreateIngestToken_WithLifetimeAboveMaximum_ReturnsBadRequest(t *testing.T) {
+	testData := setupApiKeyTest("Ingest Token Lifetime Test", true)
+	maxLifetimeMinutes := int(api_keys.GetIngestTokenService().GetMaxLifetime().Minutes())
+
+	createIngestToken(
+		t, testData.Router, testData.Project.ID, testData.User.Token, maxLifetimeMinutes+1, http.StatusBadRequest,
+	)
+}
+
+func createIngestToken(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	userToken string,
+	expiresInMinutes int,
+	expectedStatus int,
+) *api_keys.IngestTokenResponseDTO {
+	resp := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/projects/api-keys/%s/ingest-tokens", projectID.String()),
+		Body:           api_keys.CreateIngestTokenRequestDTO{ExpiresInMinutes: expiresInMinutes},
+		AuthToken:      "Bearer " + userToken,
+		ExpectedStatus: expectedStatus,
+	})
+
+	var response api_keys.IngestTokenResponseDTO
+	if expectedStatus == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(resp.Body, &response))
+	}
+
+	return &response
+}
+
+func submitLogsWithBearerToken(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	bearerToken, uniqueID string,
+	expectedStatus int,
+) *test_utils.TestResponse {
+	return test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/receiving/%s", projectID.String()),
+		Body:           &logs_receiving.SubmitLogsRequestDTO{Logs: CreateValidLogItems(1, uniqueID)},
+		Headers:        map[string]string{"Authorization": "Bearer " + bearerToken},
+		ExpectedStatus: expectedStatus,
+	})
+}
+
+// signTestIngestToken signs a token the way the service does, so tokens which
+// the API can't create (e.g. already expired ones) can be tested
+func signTestIngestToken(t *testing.T, projectID uuid.UUID, expiresAt time.Time) string {
+	secretKey, err := (&users_repositories.SecretKeyRepository{}).GetSecretKey()
+	assert.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"typ": "ingest",
+		"pid": projectID.String(),
+		"jti": uuid.New().String(),
+		"exp": expiresAt.Unix(),
+		"iat": expiresAt.Add(-time.Hour).Unix(),
+	}).SignedString([]byte(secretKey))
+	assert.NoError(t, err)
+
+	return api_keys.IngestTokenPrefix + token
+}
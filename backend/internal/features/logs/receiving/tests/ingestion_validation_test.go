@@ -0,0 +1,183 @@
+package logs_receiving_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	api_keys "logbull/internal/features/api_keys"
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateIngestion_WhenAllChecksPass_ReturnsAccepted(t *testing.T) {
+	router := CreateLogsTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProjectWithConfiguration(
+		"Validate Ingestion Accepted "+uuid.NewString()[:8],
+		user,
+		router,
+		&projects_testing.ProjectConfigurationDTO{
+			IsApiKeyRequired:   true,
+			IsFilterByDomain:   true,
+			AllowedDomains:     []string{"example.com"},
+			IsFilterByIP:       true,
+			AllowedIPs:         []string{"10.0.0.0/8"},
+			LogsPerSecondLimit: 1000,
+			MaxLogSizeKB:       64,
+		},
+	)
+	apiKey := api_keys.CreateTestApiKey("Validate Ingestion Key", project.ID, user.Token, router)
+
+	response := validateIngestion(t, router, project.ID, map[string]string{
+		"X-API-Key":       apiKey.Token,
+		"Origin":          "https://example.com",
+		"X-Forwarded-For": "10.1.2.3",
+	})
+
+	assert.True(t, response.Accepted)
+	assert.Empty(t, response.Code)
+	assert.Empty(t, response.Reason)
+}
+
+func Test_ValidateIngestion_WhenChecksFail_ReturnsReason(t *testing.T) {
+	router := CreateLogsTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProjectWithConfiguration(
+		"Validate Ingestion Rejected "+uuid.NewString()[:8],
+		user,
+		router,
+		&projects_testing.ProjectConfigurationDTO{
+			IsApiKeyRequired:   true,
+			IsFilterByDomain:   true,
+			AllowedDomains:     []string{"example.com"},
+			IsFilterByIP:       true,
+			AllowedIPs:         []string{"10.0.0.0/8"},
+			LogsPerSecondLimit: 1000,
+			MaxLogSizeKB:       64,
+		},
+	)
+	apiKey := api_keys.CreateTestApiKey("Validate Ingestion Key", project.ID, user.Token, router)
+
+	testCases := []struct {
+		name         string
+		projectID    uuid.UUID
+		headers      map[string]string
+		expectedCode string
+	}{
+		{
+			name:         "unknown project",
+			projectID:    uuid.New(),
+			headers:      map[string]string{"X-API-Key": apiKey.Token},
+			expectedCode: logs_core.ErrorProjectNotFound,
+		},
+		{
+			name:      "missing API key",
+			projectID: project.ID,
+			headers: map[string]string{
+				"Origin":          "https://example.com",
+				"X-Forwarded-For": "10.1.2.3",
+			},
+			expectedCode: logs_core.ErrorAPIKeyRequired,
+		},
+		{
+			name:      "invalid API key",
+			projectID: project.ID,
+			headers: map[string]string{
+				"X-API-Key":       generateInvalidApiKeyToken(),
+				"Origin":          "https://example.com",
+				"X-Forwarded-For": "10.1.2.3",
+			},
+			expectedCode: logs_core.ErrorAPIKeyInvalid,
+		},
+		{
+			name:      "blocked domain",
+			projectID: project.ID,
+			headers: map[string]string{
+				"X-API-Key":       apiKey.Token,
+				"Origin":          "https://blocked.com",
+				"X-Forwarded-For": "10.1.2.3",
+			},
+			expectedCode: logs_core.ErrorDomainNotAllowed,
+		},
+		{
+			name:      "blocked IP",
+			projectID: project.ID,
+			headers: map[string]string{
+				"X-API-Key":       apiKey.Token,
+				"Origin":          "https://example.com",
+				"X-Forwarded-For": "192.168.1.1",
+			},
+			expectedCode: logs_core.ErrorIPNotAllowed,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			response := validateIngestion(t, router, testCase.projectID, testCase.headers)
+
+			assert.False(t, response.Accepted)
+			assert.Equal(t, testCase.expectedCode, response.Code)
+			assert.NotEmpty(t, response.Reason)
+		})
+	}
+}
+
+func Test_ValidateIngestion_WhenRateLimitExhausted_ReturnsRateLimitExceeded(t *testing.T) {
+	testData := setupRateLimitTest("Validate Ingestion Rate Limit Test", 1)
+
+	// Validating does not take rate limit tokens, so it can run more often than the burst
+	for i := 0; i < 2*logs_receiving.LogsBurstMultiplier; i++ {
+		response := validateIngestion(t, testData.Router, testData.Project.ID, nil)
+		assert.True(t, response.Accepted)
+	}
+
+	isRateLimitHit := false
+	for i := 0; i < 4*logs_receiving.LogsBurstMultiplier; i++ {
+		resp := submitTestLogsForRateLimitRaw(
+			t,
+			testData.Router,
+			testData.Project.ID,
+			fmt.Sprintf("%s_%d", testData.UniqueID, i),
+		)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			isRateLimitHit = true
+			break
+		}
+	}
+	assert.True(t, isRateLimitHit)
+
+	response := validateIngestion(t, testData.Router, testData.Project.ID, nil)
+	assert.False(t, response.Accepted)
+	assert.Equal(t, logs_core.ErrorRateLimitExceeded, response.Code)
+}
+
+func validateIngestion(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	headers map[string]string,
+) *logs_receiving.ValidateIngestionResponseDTO {
+	resp := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/receiving/%s/validate", projectID.String()),
+		Headers:        headers,
+		ExpectedStatus: http.StatusOK,
+	})
+
+	var response logs_receiving.ValidateIngestionResponseDTO
+	if err := json.Unmarshal(resp.Body, &response); err != nil {
+		t.Fatalf("Failed to unmarshal validate ingestion response: %v", err)
+	}
+
+	return &response
+}
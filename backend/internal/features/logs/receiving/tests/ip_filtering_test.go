@@ -249,6 +249,92 @@ func Test_SubmitLogs_WhenIPFilterEnabled_WithMixedIPsAndCIDR_LogsAccepted(t *tes
 	assert.Contains(t, string(resp.Body), "IP address not allowed")
 }
 
+func Test_SubmitLogs_WhenIPFilterEnabled_WithAllowedIPv6_LogsAccepted(t *testing.T) {
+	testData := setupIPTest("Allowed IPv6 Test", []string{"2001:db8::1"})
+
+	// Same address written differently than in the allowed list
+	for i, clientIP := range []string{"2001:db8::1", "2001:DB8:0:0:0:0:0:1", "[2001:db8::1]:8080"} {
+		response := submitTestLogsWithIP(
+			t,
+			testData.Router,
+			testData.Project.ID,
+			"",
+			fmt.Sprintf("%s_%d", testData.UniqueID, i),
+			clientIP,
+		)
+
+		assert.Equal(t, 1, response.Accepted, "IPv6 address %s should be allowed", clientIP)
+	}
+}
+
+func Test_SubmitLogs_WhenIPFilterEnabled_WithDisallowedIPv6_ReturnsForbidden(t *testing.T) {
+	testData := setupIPTest("Disallowed IPv6 Test", []string{"2001:db8::1", "192.168.1.100"})
+
+	resp := submitTestLogsWithIPExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID,
+		"2001:db8::2",
+		http.StatusForbidden,
+	)
+
+	assert.Contains(t, string(resp.Body), "IP address not allowed")
+}
+
+func Test_SubmitLogs_WhenIPFilterEnabled_WithIPv6CIDRRange_OnlyAddressesInRangeAccepted(t *testing.T) {
+	testData := setupIPTest("IPv6 CIDR Test", []string{"2001:db8:abcd::/48"})
+
+	response := submitTestLogsWithIP(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID+"_1",
+		"2001:db8:abcd:12::5",
+	)
+	assert.Equal(t, 1, response.Accepted)
+
+	resp := submitTestLogsWithIPExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID+"_2",
+		"2001:db8:abce::5",
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "IP address not allowed")
+
+	// IPv4 clients do not match IPv6 ranges
+	resp = submitTestLogsWithIPExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID+"_3",
+		"192.168.1.100",
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "IP address not allowed")
+}
+
+func Test_SubmitLogs_WhenIPFilterEnabled_WithIPv4MappedIPv6Address_MatchesIPv4Rules(t *testing.T) {
+	testData := setupIPTest("IPv4 Mapped Test", []string{"192.168.1.0/24"})
+
+	response := submitTestLogsWithIP(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID,
+		"::ffff:192.168.1.50",
+	)
+
+	assert.Equal(t, 1, response.Accepted)
+}
+
 type IPTestData struct {
 	Router   *gin.Engine
 	User     *users_dto.SignInResponseDTO
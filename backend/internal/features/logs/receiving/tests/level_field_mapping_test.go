@@ -0,0 +1,201 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLogs_WithSeverityFieldConfigured_LogStoredWithMappedLevel(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Level Field Test "+uniqueID[:8], owner, router)
+	configureLevelField(t, router, project, owner.Token, "severity")
+
+	logItems := []logs_receiving.LogItemRequestDTO{
+		{
+			Message: fmt.Sprintf("Test log message %s - %d", uniqueID, 1),
+			Fields:  map[string]any{"severity": "ERROR"},
+		},
+		{
+			Message: fmt.Sprintf("Test log message %s - %d", uniqueID, 2),
+			Fields:  map[string]any{"severity": "warning"},
+		},
+		{
+			Level:   logs_core.LogLevelDebug,
+			Message: fmt.Sprintf("Test log message %s - %d", uniqueID, 3),
+			Fields:  map[string]any{"severity": "ERROR"},
+		},
+	}
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+	assert.Equal(t, len(logItems), response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	storedLogs := waitForStoredLogsInAscendingOrder(t, project.ID, len(logItems))
+	levelsByMessage := make(map[string]string)
+	for _, log := range storedLogs {
+		levelsByMessage[log.Message] = log.Level
+	}
+
+	assert.Equal(t, string(logs_core.LogLevelError), levelsByMessage[logItems[0].Message])
+	assert.Equal(t, string(logs_core.LogLevelWarn), levelsByMessage[logItems[1].Message])
+	// Explicit level takes precedence over the mapped field
+	assert.Equal(t, string(logs_core.LogLevelDebug), levelsByMessage[logItems[2].Message])
+}
+
+func Test_SubmitLogs_WithoutLevelAndWithoutLevelField_LogRejected(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Level Field Missing "+uniqueID[:8], owner, router)
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: []logs_receiving.LogItemRequestDTO{
+			{
+				Message: "Test log without level " + uniqueID,
+				Fields:  map[string]any{"severity": "ERROR"},
+			},
+		}},
+		http.StatusAccepted,
+		&response,
+	)
+
+	assert.Equal(t, 0, response.Accepted)
+	assert.Equal(t, 1, response.Rejected)
+	assert.Equal(t, logs_core.ErrorInvalidLogLevel, response.Errors[0].Message)
+}
+
+func Test_SubmitLogs_WithDefaultLevelConfigured_LevelLessLogsGetDefault(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Default Level Test "+uniqueID[:8], owner, router)
+	configureLevelField(t, router, project, owner.Token, "severity")
+
+	project.DefaultLevel = "warn"
+	updatedProject := projects_testing.UpdateProject(project, project, owner.Token, router)
+	assert.Equal(t, string(logs_core.LogLevelWarn), updatedProject.DefaultLevel)
+
+	logItems := []logs_receiving.LogItemRequestDTO{
+		{Message: fmt.Sprintf("Test log message %s - %d", uniqueID, 1)},
+		{
+			Level:   logs_core.LogLevelDebug,
+			Message: fmt.Sprintf("Test log message %s - %d", uniqueID, 2),
+		},
+		{
+			Message: fmt.Sprintf("Test log message %s - %d", uniqueID, 3),
+			Fields:  map[string]any{"severity": "ERROR"},
+		},
+	}
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+	assert.Equal(t, len(logItems), response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	storedLogs := waitForStoredLogsInAscendingOrder(t, project.ID, len(logItems))
+	levelsByMessage := make(map[string]string)
+	for _, log := range storedLogs {
+		levelsByMessage[log.Message] = log.Level
+	}
+
+	assert.Equal(t, string(logs_core.LogLevelWarn), levelsByMessage[logItems[0].Message])
+	// Provided and mapped levels take precedence over the default
+	assert.Equal(t, string(logs_core.LogLevelDebug), levelsByMessage[logItems[1].Message])
+	assert.Equal(t, string(logs_core.LogLevelError), levelsByMessage[logItems[2].Message])
+}
+
+func Test_UpdateProject_WithInvalidDefaultLevel_ReturnsBadRequest(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Default Level Invalid "+uuid.NewString()[:8], owner, router)
+
+	for _, level := range []string{"TRACE", "warning", "INFO,ERROR"} {
+		project.DefaultLevel = level
+
+		test_utils.MakePutRequest(
+			t,
+			router,
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			project,
+			http.StatusBadRequest,
+		)
+	}
+}
+
+func Test_UpdateProject_WithInvalidLevelField_ReturnsBadRequest(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Level Field Invalid "+uuid.NewString()[:8], owner, router)
+
+	for _, fieldName := range []string{"level", "log level", "level$"} {
+		project.LevelField = fieldName
+
+		test_utils.MakePutRequest(
+			t,
+			router,
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			project,
+			http.StatusBadRequest,
+		)
+	}
+}
+
+func configureLevelField(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	fieldName string,
+) {
+	project.LevelField = fieldName
+
+	updatedProject := projects_testing.UpdateProject(project, project, token, router)
+	assert.Equal(t, fieldName, updatedProject.LevelField)
+}
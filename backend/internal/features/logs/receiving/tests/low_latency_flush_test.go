@@ -0,0 +1,58 @@
+package logs_receiving_tests
+
+import (
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	"logbull/internal/util/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewLogWorkerService_WithoutLowLatencyFlushMaxLogs_UsesDefault(t *testing.T) {
+	workerService := logs_receiving.NewLogWorkerService(logs_core.GetLogCoreRepository(), logger.GetLogger(), 1, 1, 0)
+
+	assert.Equal(t, 100, workerService.GetLowLatencyFlushMaxLogs())
+}
+
+func Test_NewLogWorkerService_WithNegativeLowLatencyFlushMaxLogs_LowLatencyFlushDisabled(t *testing.T) {
+	workerService := logs_receiving.NewLogWorkerService(logs_core.GetLogCoreRepository(), logger.GetLogger(), 1, 1, -1)
+
+	assert.Equal(t, -1, workerService.GetLowLatencyFlushMaxLogs())
+}
+
+func Test_QueueLog_WithSingleLogInQuietProject_LogSearchableBeforeBatchInterval(t *testing.T) {
+	router := CreateLogsTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Low Latency Test "+uniqueID[:8], user, router)
+
+	workerService := logs_receiving.NewLogWorkerService(logs_core.GetLogCoreRepository(), logger.GetLogger(), 1, 1, 0)
+	workerService.StartWorkers()
+	t.Cleanup(workerService.StopWorkers)
+
+	startTime := time.Now()
+	err := workerService.QueueLog(&logs_core.LogItem{
+		ID:        uuid.Must(uuid.NewV7()),
+		ProjectID: project.ID,
+		Timestamp: time.Now().UTC(),
+		Level:     logs_core.LogLevelInfo,
+		Message:   "Low latency log " + uniqueID,
+		Fields:    map[string]any{"test_id": uniqueID},
+	})
+	assert.NoError(t, err)
+
+	waitForStoredLogsInAscendingOrder(t, project.ID, 1)
+	searchableAfter := time.Since(startTime)
+
+	// Without low latency flushing the log waits for the accumulation and the storage
+	// intervals, up to 2 seconds
+	assert.Less(t, searchableAfter, 500*time.Millisecond,
+		"Single log of a quiet project became searchable after %s", searchableAfter)
+}
@@ -0,0 +1,188 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_models "logbull/internal/features/projects/models"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const whitespaceContinuationPattern = `^\s`
+
+func Test_SubmitLogs_WithJavaStackTraceLines_StoredAsOneLogWithFullTrace(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Multiline Java "+uniqueID[:8], owner, router)
+	configureMultilinePattern(t, router, project, owner.Token, whitespaceContinuationPattern)
+
+	traceLines := []string{
+		"Exception in thread \"main\" java.lang.IllegalStateException: failed " + uniqueID,
+		"\tat com.example.OrderService.place(OrderService.java:42)",
+		"\tat com.example.OrderController.create(OrderController.java:17)",
+		"\t... 3 more",
+	}
+
+	response := submitLines(t, router, project, traceLines, logs_core.LogLevelError)
+	assert.Equal(t, 1, response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+	assert.Len(t, response.IDs, 1)
+
+	storedLogs := flushAndWaitForStoredLogs(t, project, 1)
+	assert.Len(t, storedLogs, 1)
+	assert.Equal(t, strings.Join(traceLines, "\n"), storedLogs[0].Message)
+	assert.Equal(t, string(logs_core.LogLevelError), storedLogs[0].Level)
+}
+
+func Test_SubmitLogs_WithPythonTracebackFollowedByNextLog_TracebackJoinedAndNextLogKept(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Multiline Python "+uniqueID[:8], owner, router)
+	// Python puts the exception line after the frames, so it is matched explicitly
+	configureMultilinePattern(t, router, project, owner.Token, `^(\s|[A-Za-z_.]+(Error|Exception):)`)
+
+	traceLines := []string{
+		"Traceback (most recent call last): " + uniqueID,
+		"  File \"/app/worker.py\", line 12, in <module>",
+		"    run()",
+		"  File \"/app/worker.py\", line 8, in run",
+		"    raise ValueError(\"bad input\")",
+		"ValueError: bad input",
+	}
+	nextLine := "Worker restarted " + uniqueID
+
+	response := submitLines(t, router, project, append(traceLines, nextLine), logs_core.LogLevelError)
+	assert.Equal(t, 2, response.Accepted)
+	assert.Equal(t, 0, response.Rejected)
+
+	storedLogs := flushAndWaitForStoredLogs(t, project, 2)
+	messages := make([]string, 0, len(storedLogs))
+	for _, log := range storedLogs {
+		messages = append(messages, log.Message)
+	}
+
+	assert.ElementsMatch(t, []string{strings.Join(traceLines, "\n"), nextLine}, messages)
+}
+
+func Test_SubmitLogs_WithoutMultilinePattern_EachLineStoredAsLog(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Multiline Disabled "+uniqueID[:8], owner, router)
+
+	response := submitLines(t, router, project, []string{
+		"java.lang.IllegalStateException: failed " + uniqueID,
+		"\tat com.example.OrderService.place(OrderService.java:42)",
+	}, logs_core.LogLevelError)
+
+	assert.Equal(t, 2, response.Accepted)
+}
+
+func Test_SubmitLogs_WhenJoinedLogIsRejected_ErrorPointsAtFirstLineIndex(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Multiline Errors "+uniqueID[:8], owner, router)
+	configureMultilinePattern(t, router, project, owner.Token, whitespaceContinuationPattern)
+
+	logItems := []logs_receiving.LogItemRequestDTO{
+		{Level: logs_core.LogLevelInfo, Message: "First log " + uniqueID},
+		{Message: "  continuation of the first log"},
+		{Level: "NOT_A_LEVEL", Message: "Rejected log " + uniqueID},
+		{Message: "  continuation of the rejected log"},
+	}
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+
+	assert.Equal(t, 1, response.Accepted)
+	assert.Equal(t, 1, response.Rejected)
+	assert.Equal(t, 2, response.Errors[0].Index)
+}
+
+func Test_UpdateProject_WithInvalidMultilinePattern_ReturnsBadRequest(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Multiline Invalid "+uuid.NewString()[:8], owner, router)
+
+	for _, pattern := range []string{`^(\s`, strings.Repeat("a", 201)} {
+		project.MultilinePattern = pattern
+
+		test_utils.MakePutRequest(
+			t,
+			router,
+			"/api/v1/projects/"+project.ID.String(),
+			"Bearer "+owner.Token,
+			project,
+			http.StatusBadRequest,
+		)
+	}
+}
+
+func configureMultilinePattern(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	token string,
+	pattern string,
+) {
+	project.MultilinePattern = pattern
+
+	updatedProject := projects_testing.UpdateProject(project, project, token, router)
+	assert.Equal(t, pattern, updatedProject.MultilinePattern)
+}
+
+func submitLines(
+	t *testing.T,
+	router *gin.Engine,
+	project *projects_models.Project,
+	lines []string,
+	level logs_core.LogLevel,
+) *logs_receiving.SubmitLogsResponseDTO {
+	logItems := make([]logs_receiving.LogItemRequestDTO, 0, len(lines))
+	for _, line := range lines {
+		logItems = append(logItems, logs_receiving.LogItemRequestDTO{Level: level, Message: line})
+	}
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+
+	return &response
+}
+
+func flushAndWaitForStoredLogs(t *testing.T, project *projects_models.Project, expectedCount int) []logs_core.LogItemDTO {
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	return waitForStoredLogsInAscendingOrder(t, project.ID, expectedCount)
+}
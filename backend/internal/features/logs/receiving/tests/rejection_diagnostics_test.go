@@ -0,0 +1,257 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"logbull/internal/cache"
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	"logbull/internal/util/logger"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetIngestionRejections_WhenIPNotAllowed_RejectionRecorded(t *testing.T) {
+	testData := setupIPTest("Rejections IP Test", []string{"192.168.1.100"})
+
+	submitTestLogsWithIPExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID,
+		"10.0.0.5",
+		http.StatusForbidden,
+	)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorIPNotAllowed, 1)
+}
+
+func Test_GetIngestionRejections_WhenDomainNotAllowed_RejectionRecorded(t *testing.T) {
+	testData := setupDomainTest("Rejections Domain Test", []string{"example.com"})
+
+	submitTestLogsWithOriginExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID,
+		"https://unknown.com",
+		http.StatusForbidden,
+	)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorDomainNotAllowed, 1)
+}
+
+func Test_GetIngestionRejections_WhenApiKeyMissing_RejectionRecorded(t *testing.T) {
+	testData := setupApiKeyTest("Rejections Api Key Test", true)
+
+	submitTestLogsExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID,
+		http.StatusUnauthorized,
+	)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorAPIKeyRequired, 1)
+}
+
+func Test_GetIngestionRejections_WhenRateLimitExceeded_RejectionRecorded(t *testing.T) {
+	testData := setupRateLimitTest("Rejections Rate Limit Test", 1)
+
+	rateLimitHit := false
+	for i := range 20 {
+		resp := submitTestLogsForRateLimitRaw(
+			t,
+			testData.Router,
+			testData.Project.ID,
+			fmt.Sprintf("%s_%d", testData.UniqueID, i),
+		)
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			rateLimitHit = true
+			break
+		}
+	}
+	assert.True(t, rateLimitHit, "Should have hit rate limit")
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorRateLimitExceeded, 1)
+}
+
+func Test_GetIngestionRejections_WhenLogsFailValidation_RejectionsGroupedByCode(t *testing.T) {
+	testData := setupValidationTest("Rejections Validation Test")
+
+	logItems := CreateValidLogItems(4, testData.UniqueID)
+	logItems[0].Level = "INVALID"
+	logItems[1].Level = "UNKNOWN"
+	logItems[2].Fields["payload"] = strings.Repeat("x", 65*1024)
+
+	response := submitLogsForValidation(t, testData.Router, testData.Project.ID, logItems)
+	assert.Equal(t, 1, response.Accepted)
+	assert.Equal(t, 3, response.Rejected)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorInvalidLogLevel, 2)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorLogTooLarge, 1)
+	assert.Len(t, rejections.Events, 2)
+}
+
+func Test_GetIngestionRejections_WhenNothingRejected_ReturnsEmptyDiagnostics(t *testing.T) {
+	testData := setupValidationTest("Rejections Empty Test")
+
+	submitLogsForValidation(t, testData.Router, testData.Project.ID, CreateValidLogItems(2, testData.UniqueID))
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assert.Empty(t, rejections.Events)
+	assert.Empty(t, rejections.TotalsByCode)
+}
+
+func Test_GetIngestionRejections_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Rejections Forbidden "+uuid.NewString()[:8], owner, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/ingestion-rejections", project.ID.String()),
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_GetIngestionRejections_WhenApiKeyMissing_SampleOfRejectedLogRecorded(t *testing.T) {
+	testData := setupApiKeyTest("Rejections Sample Test", true)
+
+	submitTestLogsExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID,
+		http.StatusUnauthorized,
+	)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assert.Len(t, rejections.Events, 1)
+	assert.Equal(t, fmt.Sprintf("Test log message %s - 1", testData.UniqueID), rejections.Events[0].Sample)
+}
+
+func Test_GetIngestionRejections_WhenLogTooLarge_SampleIsShortenedRejectedMessage(t *testing.T) {
+	testData := setupValidationTest("Rejections Size Sample Test")
+
+	logItems := CreateValidLogItems(2, testData.UniqueID)
+	logItems[1].Message = testData.UniqueID + strings.Repeat("x", 65*1024)
+
+	response := submitLogsForValidation(t, testData.Router, testData.Project.ID, logItems)
+	assert.Equal(t, 1, response.Rejected)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorLogTooLarge, 1)
+	assert.True(t, strings.HasPrefix(rejections.Events[0].Sample, testData.UniqueID))
+	assert.Less(t, len(rejections.Events[0].Sample), 300)
+}
+
+func Test_GetIngestionRejections_WhenRecordedByAnotherInstance_RejectionsShared(t *testing.T) {
+	testData := setupValidationTest("Rejections Shared Test")
+
+	otherInstanceDiagnostics := logs_receiving.NewRejectionDiagnostics(
+		cache.GetCache(),
+		logger.GetLogger(),
+		logs_receiving.RejectionEventsPerProject,
+	)
+	otherInstanceDiagnostics.Record(testData.Project.ID, logs_core.ErrorIPNotAllowed, "IP not allowed", "log", 3)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorIPNotAllowed, 3)
+}
+
+func Test_GetIngestionRejections_WhenRetentionPassed_RejectionsExpired(t *testing.T) {
+	testData := setupApiKeyTest("Rejections Expiry Test", true)
+	setRejectionsRetentionForTest(t, 500*time.Millisecond)
+
+	submitTestLogsExpectingError(
+		t,
+		testData.Router,
+		testData.Project.ID,
+		"",
+		testData.UniqueID,
+		http.StatusUnauthorized,
+	)
+
+	rejections := getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assertRejectionRecorded(t, rejections, logs_core.ErrorAPIKeyRequired, 1)
+
+	time.Sleep(time.Second)
+
+	rejections = getIngestionRejections(t, testData.Router, testData.Project.ID, testData.User.Token)
+	assert.Empty(t, rejections.Events)
+	assert.Empty(t, rejections.TotalsByCode)
+}
+
+func setRejectionsRetentionForTest(t *testing.T, retention time.Duration) {
+	diagnostics := logs_receiving.GetRejectionDiagnostics()
+	previousRetention := diagnostics.GetRetention()
+
+	diagnostics.SetRetention(retention)
+	t.Cleanup(func() {
+		diagnostics.SetRetention(previousRetention)
+	})
+}
+
+func getIngestionRejections(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+) *logs_receiving.IngestionRejectionsResponseDTO {
+	var response logs_receiving.IngestionRejectionsResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/ingestion-rejections", projectID.String()),
+		"Bearer "+token,
+		http.StatusOK,
+		&response,
+	)
+
+	return &response
+}
+
+func assertRejectionRecorded(
+	t *testing.T,
+	rejections *logs_receiving.IngestionRejectionsResponseDTO,
+	code string,
+	expectedLogsCount int,
+) {
+	assert.Equal(t, int64(expectedLogsCount), rejections.TotalsByCode[code])
+
+	for _, event := range rejections.Events {
+		if event.Code == code {
+			assert.Equal(t, expectedLogsCount, event.LogsCount)
+			assert.NotEmpty(t, event.Reason)
+			assert.False(t, event.OccurredAt.IsZero())
+			return
+		}
+	}
+
+	t.Errorf("Expected rejection event with code %s", code)
+}
@@ -0,0 +1,101 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubmitLog_WithValidLog_LogStoredAndQueryable(t *testing.T) {
+	testData := setupValidationTest("Single Log Test")
+	logItem := CreateValidLogItems(1, testData.UniqueID)[0]
+
+	var response logs_receiving.SubmitLogResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		testData.Router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s/single", testData.Project.ID.String()),
+		"",
+		&logItem,
+		http.StatusAccepted,
+		&response,
+	)
+	assert.NotEqual(t, uuid.Nil, response.ID)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	storedLogs := waitForStoredLogsInAscendingOrder(t, testData.Project.ID, 1)
+	assert.Equal(t, response.ID.String(), storedLogs[0].ID)
+	assert.Equal(t, logItem.Message, storedLogs[0].Message)
+	assert.Equal(t, testData.UniqueID, storedLogs[0].Fields["test_id"])
+}
+
+func Test_SubmitLog_WhenLogExceedsSizeLimit_ReturnsBadRequest(t *testing.T) {
+	testData := setupValidationTest("Single Log Too Large Test")
+	logItem := CreateValidLogItems(1, testData.UniqueID)[0]
+	logItem.Fields["payload"] = strings.Repeat("x", 65*1024)
+
+	resp := submitSingleLog(t, testData.Router, testData.Project.ID, &logItem, http.StatusBadRequest)
+	assert.Contains(t, string(resp.Body), logs_core.ErrorLogTooLarge)
+}
+
+func Test_SubmitLog_WithInvalidLevel_ReturnsBadRequest(t *testing.T) {
+	testData := setupValidationTest("Single Log Invalid Level Test")
+	logItem := CreateValidLogItems(1, testData.UniqueID)[0]
+	logItem.Level = "INVALID"
+
+	resp := submitSingleLog(t, testData.Router, testData.Project.ID, &logItem, http.StatusBadRequest)
+	assert.Contains(t, string(resp.Body), logs_core.ErrorInvalidLogLevel)
+}
+
+func Test_SubmitLog_WhenApiKeyRequiredButMissing_ReturnsUnauthorized(t *testing.T) {
+	testData := setupApiKeyTest("Single Log Api Key Test", true)
+	logItem := CreateValidLogItems(1, testData.UniqueID)[0]
+
+	resp := submitSingleLog(t, testData.Router, testData.Project.ID, &logItem, http.StatusUnauthorized)
+	assert.Contains(t, string(resp.Body), logs_core.ErrorAPIKeyRequired)
+}
+
+func Test_SubmitLog_WhenRateLimitExceeded_ReturnsTooManyRequests(t *testing.T) {
+	testData := setupRateLimitTest("Single Log Rate Limit Test", 1)
+
+	rateLimitHit := false
+	for i := range 20 {
+		logItem := CreateValidLogItems(1, fmt.Sprintf("%s_%d", testData.UniqueID, i))[0]
+
+		resp := submitSingleLog(t, testData.Router, testData.Project.ID, &logItem, 0)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			rateLimitHit = true
+			break
+		}
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	}
+
+	assert.True(t, rateLimitHit, "Should have hit rate limit")
+}
+
+func submitSingleLog(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	logItem *logs_receiving.LogItemRequestDTO,
+	expectedStatus int,
+) *test_utils.TestResponse {
+	return test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         "POST",
+		URL:            fmt.Sprintf("/api/v1/logs/receiving/%s/single", projectID.String()),
+		Body:           logItem,
+		ExpectedStatus: expectedStatus,
+	})
+}
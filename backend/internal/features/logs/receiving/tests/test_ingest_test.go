@@ -0,0 +1,268 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TestIngest_WhenProjectAcceptsLogs_ReturnsAccepted(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Test Ingest Accepted "+uuid.NewString()[:8], owner, router)
+
+	var response logs_receiving.TestIngestResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/test-ingest", project.ID.String()),
+		"Bearer "+owner.Token,
+		logs_receiving.TestIngestRequestDTO{},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.True(t, response.Accepted)
+	assert.Empty(t, response.Code)
+	assert.NotNil(t, response.LogID)
+	assert.True(t, response.IsStored)
+}
+
+func Test_TestIngest_WhenProjectHasLimits_ReturnsRateLimitAndQuotaStatus(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Test Ingest Limits "+uuid.NewString()[:8], owner, router)
+
+	project.LogsPerSecondLimit = 10
+	project.MaxLogsAmount = 1000
+	project.MaxLogsSizeMB = 100
+	project.MaxLogsLifeDays = 30
+	project = projects_testing.UpdateProject(project, project, owner.Token, router)
+
+	response := sendTestIngest(t, router, project.ID, owner.Token, logs_receiving.TestIngestRequestDTO{})
+
+	assert.True(t, response.Accepted)
+	if assert.NotNil(t, response.Limits) {
+		rateLimit := response.Limits.RateLimit
+		assert.False(t, rateLimit.IsUnlimited)
+		assert.Equal(t, 10, rateLimit.LogsPerSecondLimit)
+		assert.Equal(t, 10*logs_receiving.LogsBurstMultiplier, rateLimit.BurstLimit)
+		// The test log took a token
+		assert.Less(t, rateLimit.Remaining, rateLimit.BurstLimit)
+		assert.Greater(t, rateLimit.Remaining, 0)
+
+		if assert.NotNil(t, response.Limits.LogsAmount) {
+			assert.Equal(t, 1000.0, response.Limits.LogsAmount.Limit)
+			assert.Equal(t, 1000.0, response.Limits.LogsAmount.Used+response.Limits.LogsAmount.Remaining)
+		}
+		if assert.NotNil(t, response.Limits.LogsSizeMB) {
+			assert.Equal(t, 100.0, response.Limits.LogsSizeMB.Limit)
+		}
+		assert.Equal(t, 30, response.Limits.MaxLogsLifeDays)
+		assert.Equal(t, 64, response.Limits.MaxLogSizeKB)
+	}
+}
+
+func Test_TestIngest_WhenProjectIsUnlimited_ReturnsUnlimitedStatus(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProjectWithConfiguration(
+		"Test Ingest Unlimited "+uuid.NewString()[:8],
+		owner,
+		router,
+		&projects_testing.ProjectConfigurationDTO{MaxLogSizeKB: 64},
+	)
+
+	response := sendTestIngest(t, router, project.ID, owner.Token, logs_receiving.TestIngestRequestDTO{})
+
+	assert.True(t, response.Accepted)
+	if assert.NotNil(t, response.Limits) {
+		assert.True(t, response.Limits.RateLimit.IsUnlimited)
+		assert.Nil(t, response.Limits.LogsAmount)
+		assert.Nil(t, response.Limits.LogsSizeMB)
+	}
+}
+
+func Test_TestIngest_WhenLogIsRejected_StillReturnsLimits(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProjectWithConfiguration(
+		"Test Ingest Rejected Limits "+uuid.NewString()[:8],
+		owner,
+		router,
+		&projects_testing.ProjectConfigurationDTO{
+			IsApiKeyRequired:   true,
+			LogsPerSecondLimit: 1000,
+			MaxLogSizeKB:       64,
+		},
+	)
+
+	response := sendTestIngest(t, router, project.ID, owner.Token, logs_receiving.TestIngestRequestDTO{})
+
+	assert.False(t, response.Accepted)
+	if assert.NotNil(t, response.Limits) {
+		assert.Equal(t, 1000, response.Limits.RateLimit.LogsPerSecondLimit)
+	}
+}
+
+func Test_TestIngest_WhenClientIPIsNotAllowed_ReturnsRejectionReason(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProjectWithConfiguration(
+		"Test Ingest IP Rejected "+uuid.NewString()[:8],
+		owner,
+		router,
+		&projects_testing.ProjectConfigurationDTO{
+			IsFilterByIP:       true,
+			AllowedIPs:         []string{"10.0.0.0/8"},
+			LogsPerSecondLimit: 1000,
+			MaxLogSizeKB:       64,
+		},
+	)
+
+	var response logs_receiving.TestIngestResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/test-ingest", project.ID.String()),
+		"Bearer "+owner.Token,
+		logs_receiving.TestIngestRequestDTO{ClientIP: "192.168.1.10"},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.False(t, response.Accepted)
+	assert.Equal(t, logs_core.ErrorIPNotAllowed, response.Code)
+	assert.Equal(t, "IP address not allowed", response.Reason)
+	assert.Nil(t, response.LogID)
+	assert.False(t, response.IsStored)
+}
+
+func Test_TestIngest_WhenApiKeyIsMissing_ReturnsRejectionReason(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProjectWithConfiguration(
+		"Test Ingest Key Missing "+uuid.NewString()[:8],
+		owner,
+		router,
+		&projects_testing.ProjectConfigurationDTO{
+			IsApiKeyRequired:   true,
+			LogsPerSecondLimit: 1000,
+			MaxLogSizeKB:       64,
+		},
+	)
+
+	var response logs_receiving.TestIngestResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/test-ingest", project.ID.String()),
+		"Bearer "+owner.Token,
+		logs_receiving.TestIngestRequestDTO{},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.False(t, response.Accepted)
+	assert.Equal(t, logs_core.ErrorAPIKeyRequired, response.Code)
+	assert.NotEmpty(t, response.Reason)
+}
+
+func Test_TestIngest_WhenLevelIsNotAllowed_ReturnsErrorCode(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Test Ingest Level "+uuid.NewString()[:8], owner, router)
+
+	project.AllowedLevels = []string{"ERROR"}
+	project = projects_testing.UpdateProject(project, project, owner.Token, router)
+
+	response := sendTestIngest(t, router, project.ID, owner.Token, logs_receiving.TestIngestRequestDTO{})
+
+	assert.False(t, response.Accepted)
+	assert.Equal(t, logs_core.ErrorLevelNotAllowed, response.Code)
+	assert.Contains(t, response.Reason, "log rejected by validation")
+	assert.Nil(t, response.LogID)
+}
+
+func Test_TestIngest_WithDeleteAfter_LogAcceptedButNotStored(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Test Ingest Delete "+uuid.NewString()[:8], owner, router)
+
+	var response logs_receiving.TestIngestResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/test-ingest", project.ID.String()),
+		"Bearer "+owner.Token,
+		logs_receiving.TestIngestRequestDTO{DeleteAfter: true},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.True(t, response.Accepted)
+	assert.False(t, response.IsStored)
+}
+
+func Test_TestIngest_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateBasicTestProject("Test Ingest Forbidden "+uuid.NewString()[:8], owner, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/test-ingest", project.ID.String()),
+		"Bearer "+member.Token,
+		logs_receiving.TestIngestRequestDTO{},
+		http.StatusForbidden,
+	)
+}
+
+func Test_TestIngest_WhenProjectDoesNotExist_ReturnsNotFound(t *testing.T) {
+	router := CreateLogsTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/test-ingest", uuid.New().String()),
+		"Bearer "+owner.Token,
+		logs_receiving.TestIngestRequestDTO{},
+		http.StatusNotFound,
+	)
+}
+
+func sendTestIngest(
+	t *testing.T,
+	router *gin.Engine,
+	projectID uuid.UUID,
+	token string,
+	request logs_receiving.TestIngestRequestDTO,
+) *logs_receiving.TestIngestResponseDTO {
+	var response logs_receiving.TestIngestResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/test-ingest", projectID.String()),
+		"Bearer "+token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+
+	return &response
+}
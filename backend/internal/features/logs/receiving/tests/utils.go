@@ -30,6 +30,7 @@ func CreateLogsTestRouter() *gin.Engine {
 		projects_controllers.GetProjectController().RegisterRoutes(routerGroup)
 		projects_controllers.GetMembershipController().RegisterRoutes(routerGroup)
 		api_keys.GetApiKeyController().RegisterRoutes(routerGroup)
+		logs_receiving.GetReceivingController().RegisterProtectedRoutes(routerGroup)
 	}
 
 	return router
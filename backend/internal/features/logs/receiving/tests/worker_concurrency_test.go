@@ -0,0 +1,109 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	"logbull/internal/util/logger"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewLogWorkerService_WithConfiguredConcurrency_UsesConfiguredWorkerCounts(t *testing.T) {
+	workerService := logs_receiving.NewLogWorkerService(logs_core.GetLogCoreRepository(), logger.GetLogger(), 3, 2, 0)
+
+	assert.Equal(t, 3, workerService.GetStorageWorkersCount())
+	assert.Equal(t, 2, workerService.GetFlushWorkersCount())
+}
+
+func Test_NewLogWorkerService_WithoutConfiguredConcurrency_UsesCPUBasedDefaults(t *testing.T) {
+	workerService := logs_receiving.NewLogWorkerService(logs_core.GetLogCoreRepository(), logger.GetLogger(), 0, -1, 0)
+
+	assert.GreaterOrEqual(t, workerService.GetStorageWorkersCount(), 1)
+	assert.GreaterOrEqual(t, workerService.GetFlushWorkersCount(), 1)
+}
+
+func Test_NewLogWorkerService_WithMoreStorageWorkersThanQueueShards_StorageWorkersCapped(t *testing.T) {
+	workerService := logs_receiving.NewLogWorkerService(logs_core.GetLogCoreRepository(), logger.GetLogger(), 100, 4, 0)
+
+	// Each of the 16 queue shards is owned by one worker, more workers would stay idle
+	assert.Equal(t, 16, workerService.GetStorageWorkersCount())
+	assert.Equal(t, 4, workerService.GetFlushWorkersCount())
+}
+
+func Test_SubmitLogs_WithEqualTimestampsInBatch_ArrivalOrderPreserved(t *testing.T) {
+	router := CreateLogsTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Ordering Test "+uniqueID[:8], user, router)
+
+	const logsCount = 20
+	timestamp := time.Now().UTC().Add(-time.Minute).Format(time.RFC3339Nano)
+
+	logItems := CreateValidLogItems(logsCount, uniqueID)
+	for i := range logItems {
+		logItems[i].Timestamp = timestamp
+	}
+
+	var response logs_receiving.SubmitLogsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: logItems},
+		http.StatusAccepted,
+		&response,
+	)
+	assert.Equal(t, logsCount, response.Accepted)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	storedLogs := waitForStoredLogsInAscendingOrder(t, project.ID, logsCount)
+
+	assert.Len(t, storedLogs, logsCount)
+	for i, log := range storedLogs {
+		expectedMessage := fmt.Sprintf("Test log message %s - %d", uniqueID, i+1)
+		assert.Equal(t, expectedMessage, log.Message, "Log at position %d is out of arrival order", i)
+	}
+}
+
+func waitForStoredLogsInAscendingOrder(t *testing.T, projectID uuid.UUID, expectedCount int) []logs_core.LogItemDTO {
+	repository := logs_core.GetLogCoreRepository()
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+
+	query := &logs_core.LogQueryRequestDTO{
+		TimeRange: &logs_core.TimeRangeDTO{From: &from, To: &to},
+		Limit:     expectedCount,
+		SortOrder: "asc",
+	}
+
+	startTime := time.Now()
+	for time.Since(startTime) < 10*time.Second {
+		if err := repository.ForceFlush(); err != nil {
+			t.Fatalf("Failed to flush logs: %v", err)
+		}
+
+		result, err := repository.ExecuteQueryForProject(projectID, query)
+		if err == nil && len(result.Logs) >= expectedCount {
+			return result.Logs
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("Timed out waiting for %d logs to be stored", expectedCount)
+	return nil
+}
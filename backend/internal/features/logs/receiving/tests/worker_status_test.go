@@ -0,0 +1,86 @@
+package logs_receiving_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetWorkerStatus_WhenLogsSubmittedAndFlushed_StatusReflectsPendingThenStoredLogs(t *testing.T) {
+	router := CreateLogsTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	uniqueID := uuid.New().String()
+	project := projects_testing.CreateBasicTestProject("Worker Status "+uniqueID[:8], admin, router)
+
+	initialStatus := getWorkerStatus(t, router, admin.Token)
+	submittedAt := time.Now().UTC()
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: CreateValidLogItems(3, uniqueID)},
+		http.StatusAccepted,
+	)
+
+	pendingStatus := getWorkerStatus(t, router, admin.Token)
+	assert.GreaterOrEqual(t, pendingStatus.AccumulatedLogs, initialStatus.AccumulatedLogs+3)
+	assert.Equal(t, initialStatus.StoredLogsCount, pendingStatus.StoredLogsCount)
+	assert.Equal(t, initialStatus.LastFlushAt, pendingStatus.LastFlushAt)
+
+	if err := logs_receiving.GetLogWorkerService().ExecuteBackgroundTasksForTest(); err != nil {
+		t.Fatalf("Failed to execute background tasks: %v", err)
+	}
+
+	flushedStatus := getWorkerStatus(t, router, admin.Token)
+	assert.Equal(t, 0, flushedStatus.AccumulatedLogs)
+	assert.GreaterOrEqual(t, flushedStatus.StoredLogsCount, initialStatus.StoredLogsCount+3)
+	if assert.NotNil(t, flushedStatus.LastFlushAt) {
+		assert.False(t, flushedStatus.LastFlushAt.Before(submittedAt))
+	}
+	if assert.NotNil(t, flushedStatus.LastStoreAt) {
+		assert.False(t, flushedStatus.LastStoreAt.Before(*flushedStatus.LastFlushAt))
+	}
+	assert.Equal(t, logs_receiving.GetLogWorkerService().GetStorageWorkersCount(), flushedStatus.StorageWorkersCount)
+	assert.Equal(t, logs_receiving.GetLogWorkerService().GetFlushWorkersCount(), flushedStatus.FlushWorkersCount)
+}
+
+func Test_GetWorkerStatus_WhenUserIsNotAdmin_ReturnsForbidden(t *testing.T) {
+	router := CreateLogsTestRouter()
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/receiving/worker-status",
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "only administrators can view receiving worker status")
+}
+
+func getWorkerStatus(t *testing.T, router *gin.Engine, token string) *logs_receiving.WorkerStatusDTO {
+	var status logs_receiving.WorkerStatusDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/receiving/worker-status",
+		"Bearer "+token,
+		http.StatusOK,
+		&status,
+	)
+
+	return &status
+}
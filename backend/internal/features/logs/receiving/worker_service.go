@@ -3,9 +3,11 @@ package logs_receiving
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"logbull/internal/config"
@@ -26,12 +28,28 @@ import (
 // - Direct processing from Valkey to log storage without worker buffers
 //
 // ARCHITECTURE:
-// - Multi-worker direct processing with CPU-based worker pool for log storage (25% of CPU cores, min 1)
+// - Multi-worker direct processing with worker pool for log storage (LOG_STORAGE_WORKERS_COUNT or 25% of CPU cores)
 // - Sharded accumulation buffers for incoming logs (write TO Valkey)
-// - CPU-based dedicated flush workers processing shards to Valkey in parallel (25% of CPU cores, min 1)
+// - Dedicated flush workers processing shards to Valkey in parallel (LOG_FLUSH_WORKERS_COUNT or 25% of CPU cores)
 // - Direct processing from Valkey to log storage (no worker buffers)
 // - Separate background workers for maintenance (quotas, retention, stats)
 //
+// ORDERING:
+// Both the accumulation shards and the Valkey queue shards are keyed by project ID, and every queue
+// shard is owned by exactly one storage worker. So logs of one project always go through a single
+// buffer, a single queue and a single worker, and are stored in the order they were received
+// regardless of the configured concurrency. The queue shards count is fixed so API and worker
+// instances with different worker counts still agree on the queue keys. Log IDs are time ordered
+// (UUIDv7), so queries break ties between equal timestamps in arrival order.
+//
+// LOW LATENCY:
+// Quiet shards would hold a log for up to two intervals (accumulation and storage) before it is
+// searchable. When a log arrives at an empty shard whose previous flush had at most
+// LOG_LOW_LATENCY_FLUSH_MAX_LOGS logs, the shard is flushed right away and the storage worker owning
+// the queue shard is woken up, so small projects see their logs in near real time. Busy shards keep
+// flushing on the interval only, so high-volume throughput is not affected. Waking up storage
+// workers works when API and worker run in the same instance, otherwise the worker interval applies.
+//
 // LOAD HANDLING:
 // - Queue capacity: unlimited (Valkey-based distributed queue)
 // - Batch-only operations: All logs processed in batches for maximum efficiency
@@ -50,6 +68,10 @@ type LogWorkerService struct {
 	queueService  *cache_utils.ValkeyQueueService
 	logger        *slog.Logger
 
+	storageWorkersCount    int
+	flushWorkersCount      int
+	lowLatencyFlushMaxLogs int
+
 	// Worker control
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -60,6 +82,22 @@ type LogWorkerService struct {
 	accumulatedLogShards [][]*logs_core.LogItem
 	accumulationMutexes  []sync.RWMutex
 	flushTickers         []*time.Ticker
+
+	// Low latency flushing: logs count of the previous flush of each shard (guarded by the
+	// shard mutex) and wake up signals of flush and storage workers
+	lastFlushedCounts []int
+	flushSignals      []chan struct{}
+	storageSignals    []chan struct{}
+
+	// Status counters of this instance, see GetStatus
+	isRunning          atomic.Bool
+	lastFlushAt        atomic.Pointer[time.Time]
+	lastStoreAt        atomic.Pointer[time.Time]
+	flushErrorsCount   atomic.Int64
+	storeErrorsCount   atomic.Int64
+	decodeErrorsCount  atomic.Int64
+	storedLogsCount    atomic.Int64
+	discardedLogsCount atomic.Int64
 }
 
 const (
@@ -68,26 +106,53 @@ const (
 	// Fixed settings optimized for 10k RPS capacity
 	cacheToLogsStorageWritingBatchSize = 1_000 // Fixed batch size for dequeuing from Valkey
 
-	logQueueKey = "logbull:logs:queue" // Valkey queue key for log items
+	logQueueKey         = "logbull:logs:queue" // Valkey queue key for log items
+	logQueueShardsCount = 16                   // Fixed, must be the same on every instance
 
 	// Internal accumulation settings - sharded for high RPS
 	ramToValkeyQueueAccumulationFlushInterval = 1 * time.Second
+
+	// Shards which flushed at most this many logs last time flush new logs right away
+	defaultLowLatencyFlushMaxLogs = 100
+	// Short wait before a low latency flush, so logs sent together are flushed together
+	lowLatencyFlushLinger = 20 * time.Millisecond
 )
 
 var (
-	queueToLogsStorageWriterWorkersCount = max(runtime.NumCPU()/4, 1) // 25% of CPUs
-	accumulationFlushWorkersCount        = max(runtime.NumCPU()/4, 1) // 25% of CPUs
+	defaultStorageWorkersCount = max(runtime.NumCPU()/4, 1) // 25% of CPUs
+	defaultFlushWorkersCount   = max(runtime.NumCPU()/4, 1) // 25% of CPUs
 )
 
+// NewLogWorkerService creates the worker service. Non-positive worker counts fall back
+// to CPU based defaults. Storage workers are capped by the queue shards count, since
+// every queue shard is owned by a single worker. Zero lowLatencyFlushMaxLogs falls back
+// to the default and a negative value disables low latency flushing
 func NewLogWorkerService(
 	logRepository *logs_core.LogCoreRepository,
 	logger *slog.Logger,
+	storageWorkersCount int,
+	flushWorkersCount int,
+	lowLatencyFlushMaxLogs int,
 ) *LogWorkerService {
+	if storageWorkersCount <= 0 {
+		storageWorkersCount = defaultStorageWorkersCount
+	}
+	if flushWorkersCount <= 0 {
+		flushWorkersCount = defaultFlushWorkersCount
+	}
+	if lowLatencyFlushMaxLogs == 0 {
+		lowLatencyFlushMaxLogs = defaultLowLatencyFlushMaxLogs
+	}
+
 	service := &LogWorkerService{
 		logRepository: logRepository,
 		queueService:  cache_utils.NewValkeyQueueService(),
 		logger:        logger,
 
+		storageWorkersCount:    min(storageWorkersCount, logQueueShardsCount),
+		flushWorkersCount:      flushWorkersCount,
+		lowLatencyFlushMaxLogs: lowLatencyFlushMaxLogs,
+
 		// Worker control - will be initialized when StartWorkers() is called
 		ctx:    nil,
 		cancel: nil,
@@ -95,62 +160,141 @@ func NewLogWorkerService(
 	}
 
 	// Initialize sharded accumulation buffers
-	service.accumulatedLogShards = make([][]*logs_core.LogItem, accumulationFlushWorkersCount)
-	service.accumulationMutexes = make([]sync.RWMutex, accumulationFlushWorkersCount)
-	service.flushTickers = make([]*time.Ticker, accumulationFlushWorkersCount)
+	service.accumulatedLogShards = make([][]*logs_core.LogItem, service.flushWorkersCount)
+	service.accumulationMutexes = make([]sync.RWMutex, service.flushWorkersCount)
+	service.flushTickers = make([]*time.Ticker, service.flushWorkersCount)
+	service.lastFlushedCounts = make([]int, service.flushWorkersCount)
+	service.flushSignals = make([]chan struct{}, service.flushWorkersCount)
 
-	for i := range accumulationFlushWorkersCount {
+	for i := range service.flushWorkersCount {
 		service.accumulatedLogShards[i] = make(
 			[]*logs_core.LogItem,
 			0,
-			cacheToLogsStorageWritingBatchSize/accumulationFlushWorkersCount,
+			cacheToLogsStorageWritingBatchSize/service.flushWorkersCount,
 		)
+		service.flushSignals[i] = make(chan struct{}, 1)
+	}
+
+	service.storageSignals = make([]chan struct{}, service.storageWorkersCount)
+	for i := range service.storageWorkersCount {
+		service.storageSignals[i] = make(chan struct{}, 1)
 	}
 
 	return service
 }
 
+func (s *LogWorkerService) GetStorageWorkersCount() int {
+	return s.storageWorkersCount
+}
+
+func (s *LogWorkerService) GetFlushWorkersCount() int {
+	return s.flushWorkersCount
+}
+
+// GetLowLatencyFlushMaxLogs returns the low latency flushing threshold, negative when disabled
+func (s *LogWorkerService) GetLowLatencyFlushMaxLogs() int {
+	return s.lowLatencyFlushMaxLogs
+}
+
 func (s *LogWorkerService) StartWorkers() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 
-	s.logger.Info("Starting log worker services with fixed configuration",
+	s.logger.Info("Starting log worker services",
 		slog.Duration("batchInterval", batchProcessingInterval),
 		slog.Int("batchSize", cacheToLogsStorageWritingBatchSize),
-		slog.Int("workerCount", queueToLogsStorageWriterWorkersCount),
-		slog.Int("flushWorkersCount", accumulationFlushWorkersCount),
-		slog.Duration("accumulationFlushInterval", ramToValkeyQueueAccumulationFlushInterval))
+		slog.Int("workerCount", s.storageWorkersCount),
+		slog.Int("flushWorkersCount", s.flushWorkersCount),
+		slog.Int("queueShardsCount", logQueueShardsCount),
+		slog.Duration("accumulationFlushInterval", ramToValkeyQueueAccumulationFlushInterval),
+		slog.Int("lowLatencyFlushMaxLogs", s.lowLatencyFlushMaxLogs))
 
 	// Start multiple sharded accumulation flush workers
-	for i := range accumulationFlushWorkersCount {
+	for i := range s.flushWorkersCount {
 		s.wg.Add(1)
 		go s.accumulationFlushWorker(i)
 	}
 
 	// Start multiple batch processing workers
-	for i := range queueToLogsStorageWriterWorkersCount {
+	for i := range s.storageWorkersCount {
 		s.wg.Add(1)
 		go s.runCacheToLogStorageWorker(i)
 	}
 
+	s.isRunning.Store(true)
+
 	s.logger.Info("All log workers started successfully")
 }
 
+// StopWorkers stops the workers started by StartWorkers and waits until accumulated
+// logs are flushed
+func (s *LogWorkerService) StopWorkers() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	s.wg.Wait()
+
+	s.isRunning.Store(false)
+}
+
+// GetStatus returns the state of the workers. Counters, times and accumulated logs
+// belong to this instance, while queued logs are counted in the shared Valkey queue
+func (s *LogWorkerService) GetStatus() (*WorkerStatusDTO, error) {
+	accumulatedLogs := 0
+	for shard := range s.flushWorkersCount {
+		s.accumulationMutexes[shard].RLock()
+		accumulatedLogs += len(s.accumulatedLogShards[shard])
+		s.accumulationMutexes[shard].RUnlock()
+	}
+
+	var queuedLogs int64
+	for queueShard := range logQueueShardsCount {
+		queueLength, err := s.queueService.QueueLength(s.queueKeyForShard(queueShard))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get queue length: %w", err)
+		}
+
+		queuedLogs += queueLength
+	}
+
+	return &WorkerStatusDTO{
+		IsRunning:           s.isRunning.Load(),
+		StorageWorkersCount: s.storageWorkersCount,
+		FlushWorkersCount:   s.flushWorkersCount,
+		AccumulatedLogs:     accumulatedLogs,
+		QueuedLogs:          queuedLogs,
+		LastFlushAt:         s.lastFlushAt.Load(),
+		LastStoreAt:         s.lastStoreAt.Load(),
+		StoredLogsCount:     s.storedLogsCount.Load(),
+		FlushErrorsCount:    s.flushErrorsCount.Load(),
+		StoreErrorsCount:    s.storeErrorsCount.Load(),
+		DecodeErrorsCount:   s.decodeErrorsCount.Load(),
+		DiscardedLogsCount:  s.discardedLogsCount.Load(),
+	}, nil
+}
+
 // QueueLog adds a single log item to a sharded accumulation buffer.
 // Logs are distributed across shards using project ID hash to balance load.
-// Each shard is flushed to Valkey automatically every second by dedicated workers.
+// Each shard is flushed to Valkey automatically every second by dedicated workers,
+// or right away when the shard is quiet (see LOW LATENCY above).
 func (s *LogWorkerService) QueueLog(log *logs_core.LogItem) error {
 	if log == nil {
 		return nil
 	}
 
-	// Hash project ID to determine shard (distribute load evenly)
-	shard := s.hashProjectIDToShard(log.ProjectID)
+	// Hash project ID to determine shard (distribute load evenly, keep project order)
+	shard := int(s.hashProjectID(log.ProjectID) % uint32(s.flushWorkersCount))
 
 	s.accumulationMutexes[shard].Lock()
 	defer s.accumulationMutexes[shard].Unlock()
 
 	s.accumulatedLogShards[shard] = append(s.accumulatedLogShards[shard], log)
 
+	if len(s.accumulatedLogShards[shard]) == 1 && s.isLowLatencyFlushCount(s.lastFlushedCounts[shard]) {
+		signal(s.flushSignals[shard])
+	}
+
 	return nil
 }
 
@@ -161,12 +305,14 @@ func (s *LogWorkerService) QueueLog(log *logs_core.LogItem) error {
 // - Process any remaining logs in Valkey queue
 func (s *LogWorkerService) ExecuteBackgroundTasksForTest() error {
 	// Flush accumulated logs from all producer shards
-	for shard := range accumulationFlushWorkersCount {
+	for shard := range s.flushWorkersCount {
 		s.flushAccumulatedLogsShard(shard)
 	}
 
-	// Process any remaining logs in Valkey queue (single worker execution)
-	s.processLogsFromValkeyQueueToLogsRepository(0)
+	// Process any remaining logs in all Valkey queue shards (single worker execution)
+	for queueShard := range logQueueShardsCount {
+		s.processLogsFromValkeyQueueToLogsRepository(0, queueShard)
+	}
 
 	return nil
 }
@@ -197,19 +343,35 @@ func (s *LogWorkerService) runCacheToLogStorageWorker(workerID int) {
 			return
 
 		case <-ticker.C:
-			// Dequeue and process logs from Valkey directly to log storage
-			s.processLogsFromValkeyQueueToLogsRepository(workerID)
+			s.processOwnedQueueShards(workerID)
+
+		case <-s.storageSignals[workerID]:
+			s.processOwnedQueueShards(workerID)
 		}
 	}
 }
 
-func (s *LogWorkerService) processLogsFromValkeyQueueToLogsRepository(workerID int) {
+// processOwnedQueueShards dequeues and processes logs from Valkey directly to log storage.
+// Each queue shard is owned by exactly one worker to keep per-project ordering
+func (s *LogWorkerService) processOwnedQueueShards(workerID int) {
+	for queueShard := workerID; queueShard < logQueueShardsCount; queueShard += s.storageWorkersCount {
+		s.processLogsFromValkeyQueueToLogsRepository(workerID, queueShard)
+	}
+}
+
+func (s *LogWorkerService) processLogsFromValkeyQueueToLogsRepository(workerID, queueShard int) {
 	// Dequeue batch of logs from Valkey using pipeline for high performance
 	// Use non-blocking dequeue to prevent worker from hanging
-	serializedLogs, err := s.queueService.DequeueBatch(logQueueKey, cacheToLogsStorageWritingBatchSize, 0)
+	serializedLogs, err := s.queueService.DequeueBatch(
+		s.queueKeyForShard(queueShard),
+		cacheToLogsStorageWritingBatchSize,
+		0,
+	)
 	if err != nil {
+		s.storeErrorsCount.Add(1)
 		s.logger.Error("Failed to dequeue logs from Valkey",
 			slog.Int("workerID", workerID),
+			slog.Int("queueShard", queueShard),
 			slog.String("error", err.Error()))
 		return
 	}
@@ -225,6 +387,7 @@ func (s *LogWorkerService) processLogsFromValkeyQueueToLogsRepository(workerID i
 		var log logs_core.LogItem
 
 		if err := json.Unmarshal(data, &log); err != nil {
+			s.decodeErrorsCount.Add(1)
 			s.logger.Error("Failed to unmarshal log item from Valkey",
 				slog.Int("workerID", workerID),
 				slog.String("error", err.Error()))
@@ -250,13 +413,20 @@ func (s *LogWorkerService) processLogsFromValkeyQueueToLogsRepository(workerID i
 	duration := time.Since(startTime)
 
 	if err != nil {
+		s.storeErrorsCount.Add(1)
+		s.discardedLogsCount.Add(int64(len(logs)))
 		s.logger.Error("Failed to store log batch",
 			slog.Int("workerID", workerID),
 			slog.Int("totalLogs", len(logs)),
 			slog.Int("projects", len(batch)),
 			slog.Duration("duration", duration),
 			slog.String("error", err.Error()))
+		return
 	}
+
+	storedAt := time.Now().UTC()
+	s.lastStoreAt.Store(&storedAt)
+	s.storedLogsCount.Add(int64(len(logs)))
 }
 
 func (s *LogWorkerService) accumulationFlushWorker(shardID int) {
@@ -286,6 +456,10 @@ func (s *LogWorkerService) accumulationFlushWorker(shardID int) {
 
 		case <-s.flushTickers[shardID].C:
 			s.flushAccumulatedLogsShard(shardID)
+
+		case <-s.flushSignals[shardID]:
+			time.Sleep(lowLatencyFlushLinger)
+			s.flushAccumulatedLogsShard(shardID)
 		}
 	}
 }
@@ -296,51 +470,85 @@ func (s *LogWorkerService) flushAccumulatedLogsShard(shardID int) {
 	s.accumulatedLogShards[shardID] = make(
 		[]*logs_core.LogItem,
 		0,
-		cacheToLogsStorageWritingBatchSize/accumulationFlushWorkersCount,
+		cacheToLogsStorageWritingBatchSize/s.flushWorkersCount,
 	)
+	s.lastFlushedCounts[shardID] = len(logsToFlush)
 	s.accumulationMutexes[shardID].Unlock()
 
 	if len(logsToFlush) == 0 {
 		return
 	}
 
-	// Serialize logs to JSON for Valkey storage
-	serializedLogs := make([][]byte, 0, len(logsToFlush))
+	// Serialize logs to JSON for Valkey storage, grouped by queue shard
+	serializedLogsByQueueShard := make(map[int][][]byte)
 
 	for _, log := range logsToFlush {
 		data, err := json.Marshal(log)
 		if err != nil {
+			s.decodeErrorsCount.Add(1)
 			s.logger.Error("Failed to marshal log item during flush",
 				slog.Int("shardID", shardID),
 				slog.String("logId", log.ID.String()),
 				slog.String("error", err.Error()))
 			continue
 		}
-		serializedLogs = append(serializedLogs, data)
+		queueShard := int(s.hashProjectID(log.ProjectID) % logQueueShardsCount)
+		serializedLogsByQueueShard[queueShard] = append(serializedLogsByQueueShard[queueShard], data)
 	}
 
-	if len(serializedLogs) == 0 {
-		return
+	// Use batch enqueue with pipeline for maximum performance
+	for queueShard, serializedLogs := range serializedLogsByQueueShard {
+		err := s.queueService.EnqueueBatch(s.queueKeyForShard(queueShard), serializedLogs)
+		if err != nil {
+			s.flushErrorsCount.Add(1)
+			s.discardedLogsCount.Add(int64(len(serializedLogs)))
+			s.logger.Error("Failed to flush accumulated logs to Valkey",
+				slog.Int("shardID", shardID),
+				slog.Int("queueShard", queueShard),
+				slog.Int("logsCount", len(serializedLogs)),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		if s.isLowLatencyFlushCount(len(logsToFlush)) {
+			signal(s.storageSignals[queueShard%s.storageWorkersCount])
+		}
 	}
 
-	// Use batch enqueue with pipeline for maximum performance
-	err := s.queueService.EnqueueBatch(logQueueKey, serializedLogs)
-	if err != nil {
-		s.logger.Error("Failed to flush accumulated logs to Valkey",
-			slog.Int("shardID", shardID),
-			slog.Int("logsCount", len(serializedLogs)),
-			slog.String("error", err.Error()))
-		return
+	flushedAt := time.Now().UTC()
+	s.lastFlushAt.Store(&flushedAt)
+}
+
+func (s *LogWorkerService) isLowLatencyFlushCount(logsCount int) bool {
+	return s.lowLatencyFlushMaxLogs > 0 && logsCount <= s.lowLatencyFlushMaxLogs
+}
+
+// signal wakes up a worker without blocking, pending signals are merged
+func signal(signals chan struct{}) {
+	select {
+	case signals <- struct{}{}:
+	default:
 	}
 }
 
-// hashProjectIDToShard distributes logs across shards using project ID hash.
-// This ensures even load distribution and prevents hot-spotting on single shards.
-func (s *LogWorkerService) hashProjectIDToShard(projectID uuid.UUID) int {
+// queueKeyForShard returns the Valkey key of the queue shard. The first shard keeps
+// the original key, so logs queued before sharding are still processed
+func (s *LogWorkerService) queueKeyForShard(queueShard int) string {
+	if queueShard == 0 {
+		return logQueueKey
+	}
+
+	return fmt.Sprintf("%s:%d", logQueueKey, queueShard)
+}
+
+// hashProjectID distributes logs across shards using project ID hash.
+// This ensures even load distribution and prevents hot-spotting on single shards,
+// while logs of the same project always land in the same shard.
+func (s *LogWorkerService) hashProjectID(projectID uuid.UUID) uint32 {
 	// Use a simple hash of the project ID bytes to determine shard
 	hash := uint32(0)
 	for _, b := range projectID[:] {
 		hash = hash*31 + uint32(b)
 	}
-	return int(hash % uint32(accumulationFlushWorkersCount))
+	return hash
 }
@@ -0,0 +1,301 @@
+package logs_saved_queries
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SavedQueryController struct {
+	savedQueryService *SavedQueryService
+}
+
+func (c *SavedQueryController) RegisterRoutes(router *gin.RouterGroup) {
+	savedQueryRoutes := router.Group("/logs/saved-queries/:projectId")
+
+	savedQueryRoutes.POST("", c.CreateSavedQuery)
+	savedQueryRoutes.GET("", c.GetSavedQueries)
+	savedQueryRoutes.PUT("/:queryId", c.UpdateSavedQuery)
+	savedQueryRoutes.DELETE("/:queryId", c.DeleteSavedQuery)
+}
+
+// RegisterQueryRoutes registers routes that execute queries, so they accept the
+// same credentials as the query API
+func (c *SavedQueryController) RegisterQueryRoutes(router *gin.RouterGroup) {
+	router.POST("/logs/saved-queries/:projectId/:queryId/execute", c.ExecuteSavedQuery)
+}
+
+// CreateSavedQuery
+// @Summary Create saved query
+// @Description Save a query definition for the project. Only project owners and admins can manage saved queries
+// @Tags logs-saved-queries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param request body SaveQueryRequestDTO true "Saved query"
+// @Success 201 {object} SavedQuery
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/saved-queries/{projectId} [post]
+func (c *SavedQueryController) CreateSavedQuery(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	var request SaveQueryRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	savedQuery, err := c.savedQueryService.CreateSavedQuery(projectID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, savedQuery)
+}
+
+// GetSavedQueries
+// @Summary List saved queries
+// @Description Get all saved queries of the project ordered by name
+// @Tags logs-saved-queries
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Success 200 {object} ListSavedQueriesResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/saved-queries/{projectId} [get]
+func (c *SavedQueryController) GetSavedQueries(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	response, err := c.savedQueryService.GetSavedQueries(projectID, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateSavedQuery
+// @Summary Update saved query
+// @Description Replace the definition of a saved query. Executions by id use the new definition right away
+// @Tags logs-saved-queries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param queryId path string true "Saved query ID (UUID format)"
+// @Param request body SaveQueryRequestDTO true "Saved query"
+// @Success 200 {object} SavedQuery
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/saved-queries/{projectId}/{queryId} [put]
+func (c *SavedQueryController) UpdateSavedQuery(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	savedQueryID, err := uuid.Parse(ctx.Param("queryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved query ID format"})
+		return
+	}
+
+	var request SaveQueryRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	savedQuery, err := c.savedQueryService.UpdateSavedQuery(projectID, savedQueryID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, savedQuery)
+}
+
+// DeleteSavedQuery
+// @Summary Delete saved query
+// @Description Delete a saved query of the project
+// @Tags logs-saved-queries
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param queryId path string true "Saved query ID (UUID format)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /logs/saved-queries/{projectId}/{queryId} [delete]
+func (c *SavedQueryController) DeleteSavedQuery(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	savedQueryID, err := uuid.Parse(ctx.Param("queryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved query ID format"})
+		return
+	}
+
+	if err := c.savedQueryService.DeleteSavedQuery(projectID, savedQueryID, user); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Saved query deleted successfully"})
+}
+
+// ExecuteSavedQuery
+// @Summary Execute saved query
+// @Description Run the current definition of a saved query with the given time range and pagination.
+// @Description The definition is validated again before running, invalid ones return SAVED_QUERY_INVALID.
+// @Tags logs-saved-queries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param projectId path string true "Project ID (UUID format)"
+// @Param queryId path string true "Saved query ID (UUID format)"
+// @Param request body ExecuteSavedQueryRequestDTO true "Execution parameters"
+// @Success 200 {object} logs_core.LogQueryResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 408 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /logs/saved-queries/{projectId}/{queryId}/execute [post]
+func (c *SavedQueryController) ExecuteSavedQuery(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		return
+	}
+
+	savedQueryID, err := uuid.Parse(ctx.Param("queryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved query ID format"})
+		return
+	}
+
+	var request ExecuteSavedQueryRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.savedQueryService.ExecuteSavedQuery(projectID, savedQueryID, &request, user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *SavedQueryController) handleError(ctx *gin.Context, err error) {
+	if validationErr, ok := err.(*logs_querying.ValidationError); ok {
+		statusCode := http.StatusBadRequest
+		switch validationErr.Code {
+		case logs_core.ErrorTooManyConcurrentQueries:
+			statusCode = http.StatusTooManyRequests
+		case logs_core.ErrorQueryCapacityExceeded:
+			statusCode = http.StatusServiceUnavailable
+			ctx.Header("Retry-After", strconv.Itoa(int(logs_querying.QueryCapacityRetryAfter.Seconds())))
+		case logs_core.ErrorQueryTimeout:
+			statusCode = http.StatusRequestTimeout
+		case logs_core.ErrorFieldMasked:
+			statusCode = http.StatusForbidden
+		}
+
+		ctx.JSON(statusCode, gin.H{
+			"error": validationErr.Message,
+			"code":  validationErr.Code,
+		})
+		return
+	}
+
+	if strings.Contains(err.Error(), "not found") {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.Contains(err.Error(), "insufficient permissions") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.Contains(err.Error(), "invalid query") {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "context deadline") {
+		ctx.JSON(http.StatusRequestTimeout, gin.H{"error": "Query execution timed out"})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
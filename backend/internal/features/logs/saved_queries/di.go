@@ -0,0 +1,37 @@
+package logs_saved_queries
+
+import (
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_querying "logbull/internal/features/logs/querying"
+	projects_services "logbull/internal/features/projects/services"
+)
+
+var savedQueryRepository = &SavedQueryRepository{}
+
+var savedQueryService = &SavedQueryService{
+	savedQueryRepository,
+	projects_services.GetProjectService(),
+	logs_querying.GetLogQueryService(),
+	logs_querying.GetQueryValidator(),
+	audit_logs.GetAuditLogService(),
+}
+
+var savedQueryController = &SavedQueryController{
+	savedQueryService,
+}
+
+func GetSavedQueryRepository() *SavedQueryRepository {
+	return savedQueryRepository
+}
+
+func GetSavedQueryService() *SavedQueryService {
+	return savedQueryService
+}
+
+func GetSavedQueryController() *SavedQueryController {
+	return savedQueryController
+}
+
+func SetupDependencies() {
+	projects_services.GetProjectService().SetSavedQueryChecker(savedQueryService)
+}
@@ -0,0 +1,33 @@
+package logs_saved_queries
+
+import (
+	logs_core "logbull/internal/features/logs/core"
+)
+
+type SaveQueryRequestDTO struct {
+	Name        string               `json:"name"        binding:"required,min=1,max=255"`
+	Description string               `json:"description" binding:"max=1000"`
+	Query       *logs_core.QueryNode `json:"query"       binding:"required"`
+}
+
+type ListSavedQueriesResponseDTO struct {
+	SavedQueries []*SavedQuery `json:"savedQueries"`
+}
+
+// ExecuteSavedQueryRequestDTO holds what is given per execution, the query
+// itself always comes from the saved definition
+type ExecuteSavedQueryRequestDTO struct {
+	TimeRange          *logs_core.TimeRangeDTO   `json:"timeRange,omitempty"`
+	Limit              int                       `json:"limit,omitempty"`
+	Offset             int                       `json:"offset,omitempty"`
+	SortOrder          string                    `json:"sortOrder,omitempty"`
+	TrackTotal         bool                      `json:"trackTotal,omitempty"`
+	After              string                    `json:"after,omitempty"`
+	IncludeAnnotations bool                      `json:"includeAnnotations,omitempty"`
+	AllHistory         bool                      `json:"allHistory,omitempty"`
+	IncludeDiagnostics bool                      `json:"includeDiagnostics,omitempty"`
+	MinLevel           logs_core.LogLevel        `json:"minLevel,omitempty"`
+	TimestampFormat    logs_core.TimestampFormat `json:"timestampFormat,omitempty"`
+	IncludeTiming      bool                      `json:"includeTiming,omitempty"`
+	IncludeArchived    bool                      `json:"includeArchived,omitempty"`
+}
@@ -0,0 +1,56 @@
+package logs_saved_queries
+
+import (
+	"encoding/json"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedQuery is a named query definition shared by project members. Time range
+// and pagination are not stored, they are given on each execution
+type SavedQuery struct {
+	ID          uuid.UUID            `json:"id"          gorm:"column:id"`
+	ProjectID   uuid.UUID            `json:"projectId"   gorm:"column:project_id"`
+	Name        string               `json:"name"        gorm:"column:name"`
+	Description string               `json:"description" gorm:"column:description"`
+	QueryRaw    string               `json:"-"           gorm:"column:query"`
+	Query       *logs_core.QueryNode `json:"query"       gorm:"-"`
+	CreatedBy   *uuid.UUID           `json:"createdBy"   gorm:"column:created_by"`
+	CreatedAt   time.Time            `json:"createdAt"   gorm:"column:created_at"`
+	UpdatedAt   time.Time            `json:"updatedAt"   gorm:"column:updated_at"`
+}
+
+func (SavedQuery) TableName() string {
+	return "saved_queries"
+}
+
+func (q *SavedQuery) BeforeSave(tx *gorm.DB) error {
+	data, err := json.Marshal(q.Query)
+	if err != nil {
+		return err
+	}
+
+	q.QueryRaw = string(data)
+
+	return nil
+}
+
+func (q *SavedQuery) AfterFind(tx *gorm.DB) error {
+	if q.QueryRaw == "" || q.QueryRaw == "null" {
+		q.Query = nil
+		return nil
+	}
+
+	var query logs_core.QueryNode
+	if err := json.Unmarshal([]byte(q.QueryRaw), &query); err != nil {
+		return err
+	}
+
+	q.Query = &query
+
+	return nil
+}
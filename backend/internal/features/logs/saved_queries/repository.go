@@ -0,0 +1,61 @@
+package logs_saved_queries
+
+import (
+	"time"
+
+	"logbull/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type SavedQueryRepository struct{}
+
+func (r *SavedQueryRepository) CreateSavedQuery(savedQuery *SavedQuery) error {
+	if savedQuery.ID == uuid.Nil {
+		savedQuery.ID = uuid.New()
+	}
+
+	now := time.Now().UTC()
+	if savedQuery.CreatedAt.IsZero() {
+		savedQuery.CreatedAt = now
+	}
+	savedQuery.UpdatedAt = now
+
+	return storage.GetDb().Create(savedQuery).Error
+}
+
+func (r *SavedQueryRepository) UpdateSavedQuery(savedQuery *SavedQuery) error {
+	savedQuery.UpdatedAt = time.Now().UTC()
+
+	return storage.GetDb().Save(savedQuery).Error
+}
+
+func (r *SavedQueryRepository) GetSavedQuery(projectID, savedQueryID uuid.UUID) (*SavedQuery, error) {
+	var savedQuery SavedQuery
+
+	err := storage.GetDb().
+		Where("project_id = ? AND id = ?", projectID, savedQueryID).
+		First(&savedQuery).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &savedQuery, nil
+}
+
+func (r *SavedQueryRepository) GetSavedQueriesByProjectID(projectID uuid.UUID) ([]*SavedQuery, error) {
+	var savedQueries []*SavedQuery
+
+	err := storage.GetDb().
+		Where("project_id = ?", projectID).
+		Order("name ASC").
+		Find(&savedQueries).Error
+
+	return savedQueries, err
+}
+
+func (r *SavedQueryRepository) DeleteSavedQuery(projectID, savedQueryID uuid.UUID) error {
+	return storage.GetDb().
+		Where("project_id = ? AND id = ?", projectID, savedQueryID).
+		Delete(&SavedQuery{}).Error
+}
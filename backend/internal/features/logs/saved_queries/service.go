@@ -0,0 +1,230 @@
+package logs_saved_queries
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	projects_services "logbull/internal/features/projects/services"
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavedQueryService struct {
+	savedQueryRepository *SavedQueryRepository
+	projectService       *projects_services.ProjectService
+	logQueryService      *logs_querying.LogQueryService
+	queryValidator       *logs_querying.QueryValidator
+	auditLogService      *audit_logs.AuditLogService
+}
+
+// CreateSavedQuery stores a query definition for the project. Saved queries
+// are shared by all members, so only owners and admins can change them
+func (s *SavedQueryService) CreateSavedQuery(
+	projectID uuid.UUID,
+	request *SaveQueryRequestDTO,
+	user *users_models.User,
+) (*SavedQuery, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to manage saved queries")
+	}
+
+	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	savedQuery := &SavedQuery{
+		ProjectID:   projectID,
+		Name:        strings.TrimSpace(request.Name),
+		Description: strings.TrimSpace(request.Description),
+		Query:       request.Query,
+		CreatedBy:   &user.ID,
+	}
+
+	if err := s.savedQueryRepository.CreateSavedQuery(savedQuery); err != nil {
+		return nil, fmt.Errorf("failed to create saved query: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Saved query created: %s", savedQuery.Name),
+		&user.ID,
+		&projectID,
+	)
+
+	return savedQuery, nil
+}
+
+func (s *SavedQueryService) UpdateSavedQuery(
+	projectID uuid.UUID,
+	savedQueryID uuid.UUID,
+	request *SaveQueryRequestDTO,
+	user *users_models.User,
+) (*SavedQuery, error) {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to manage saved queries")
+	}
+
+	savedQuery, err := s.getSavedQuery(projectID, savedQueryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queryValidator.ValidateQuery(request.Query); err != nil {
+		return nil, fmt.Errorf("invalid query structure: %w", err)
+	}
+
+	savedQuery.Name = strings.TrimSpace(request.Name)
+	savedQuery.Description = strings.TrimSpace(request.Description)
+	savedQuery.Query = request.Query
+
+	if err := s.savedQueryRepository.UpdateSavedQuery(savedQuery); err != nil {
+		return nil, fmt.Errorf("failed to update saved query: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Saved query updated: %s", savedQuery.Name),
+		&user.ID,
+		&projectID,
+	)
+
+	return savedQuery, nil
+}
+
+func (s *SavedQueryService) GetSavedQueries(
+	projectID uuid.UUID,
+	user *users_models.User,
+) (*ListSavedQueriesResponseDTO, error) {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view saved queries")
+	}
+
+	savedQueries, err := s.savedQueryRepository.GetSavedQueriesByProjectID(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved queries: %w", err)
+	}
+
+	return &ListSavedQueriesResponseDTO{SavedQueries: savedQueries}, nil
+}
+
+func (s *SavedQueryService) DeleteSavedQuery(
+	projectID uuid.UUID,
+	savedQueryID uuid.UUID,
+	user *users_models.User,
+) error {
+	canManage, err := s.projectService.CanUserManageProject(projectID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to manage saved queries")
+	}
+
+	savedQuery, err := s.getSavedQuery(projectID, savedQueryID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.savedQueryRepository.DeleteSavedQuery(projectID, savedQueryID); err != nil {
+		return fmt.Errorf("failed to delete saved query: %w", err)
+	}
+
+	// The database clears the project default query referencing it
+	s.projectService.InvalidateProjectCache(projectID)
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Saved query deleted: %s", savedQuery.Name),
+		&user.ID,
+		&projectID,
+	)
+
+	return nil
+}
+
+// ExecuteSavedQuery runs the current definition of a saved query with the
+// caller's time range and pagination. The definition is validated again, since
+// it may have been stored under older query limits
+func (s *SavedQueryService) ExecuteSavedQuery(
+	projectID uuid.UUID,
+	savedQueryID uuid.UUID,
+	request *ExecuteSavedQueryRequestDTO,
+	user *users_models.User,
+) (*logs_core.LogQueryResponseDTO, error) {
+	canAccess, _, err := s.projectService.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to query project logs")
+	}
+
+	savedQuery, err := s.getSavedQuery(projectID, savedQueryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queryValidator.ValidateQuery(savedQuery.Query); err != nil {
+		return nil, &logs_querying.ValidationError{
+			Code:    logs_core.ErrorSavedQueryInvalid,
+			Message: fmt.Sprintf("saved query is no longer valid: %s", err.Error()),
+		}
+	}
+
+	return s.logQueryService.ExecuteQuery(projectID, &logs_core.LogQueryRequestDTO{
+		Query:              savedQuery.Query,
+		TimeRange:          request.TimeRange,
+		Limit:              request.Limit,
+		Offset:             request.Offset,
+		SortOrder:          request.SortOrder,
+		TrackTotal:         request.TrackTotal,
+		After:              request.After,
+		IncludeAnnotations: request.IncludeAnnotations,
+		AllHistory:         request.AllHistory,
+		IncludeDiagnostics: request.IncludeDiagnostics,
+		MinLevel:           request.MinLevel,
+		TimestampFormat:    request.TimestampFormat,
+		IncludeTiming:      request.IncludeTiming,
+		IncludeArchived:    request.IncludeArchived,
+	}, user)
+}
+
+func (s *SavedQueryService) IsSavedQueryOfProject(projectID, savedQueryID uuid.UUID) (bool, error) {
+	if _, err := s.savedQueryRepository.GetSavedQuery(projectID, savedQueryID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *SavedQueryService) getSavedQuery(projectID, savedQueryID uuid.UUID) (*SavedQuery, error) {
+	savedQuery, err := s.savedQueryRepository.GetSavedQuery(projectID, savedQueryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("saved query not found")
+		}
+
+		return nil, fmt.Errorf("failed to get saved query: %w", err)
+	}
+
+	return savedQuery, nil
+}
@@ -36,6 +36,7 @@ func (c *MembershipController) RegisterRoutes(router *gin.RouterGroup) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/memberships/{id}/members [get]
 func (c *MembershipController) ListMembers(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -53,6 +54,10 @@ func (c *MembershipController) ListMembers(ctx *gin.Context) {
 
 	response, err := c.membershipService.GetMembers(projectID, user)
 	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to view project members" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -77,6 +82,7 @@ func (c *MembershipController) ListMembers(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/memberships/{id}/members [post]
 func (c *MembershipController) AddMember(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -105,6 +111,10 @@ func (c *MembershipController) AddMember(ctx *gin.Context) {
 
 	response, err := c.membershipService.AddMember(projectID, &request, user)
 	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to manage members" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -130,6 +140,7 @@ func (c *MembershipController) AddMember(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/memberships/{id}/members/{userId}/role [put]
 func (c *MembershipController) ChangeMemberRole(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -159,6 +170,10 @@ func (c *MembershipController) ChangeMemberRole(ctx *gin.Context) {
 	}
 
 	if err := c.membershipService.ChangeMemberRole(projectID, userID, &request, user); err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to manage members" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -181,6 +196,7 @@ func (c *MembershipController) ChangeMemberRole(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/memberships/{id}/members/{userId} [delete]
 func (c *MembershipController) RemoveMember(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -204,6 +220,10 @@ func (c *MembershipController) RemoveMember(ctx *gin.Context) {
 	}
 
 	if err := c.membershipService.RemoveMember(projectID, userID, user); err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to remove members" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -228,6 +248,7 @@ func (c *MembershipController) RemoveMember(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/memberships/{id}/transfer-ownership [post]
 func (c *MembershipController) TransferOwnership(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -250,6 +271,10 @@ func (c *MembershipController) TransferOwnership(ctx *gin.Context) {
 	}
 
 	if err := c.membershipService.TransferOwnership(projectID, &request, user); err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "only project owner or admin can transfer ownership" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -106,6 +106,40 @@ func Test_GetProjectMembers_WhenUserIsProjectAdmin_ReturnsMembers(t *testing.T)
 	assert.GreaterOrEqual(t, len(response.Members), 2) // Owner + ProjectAdmin
 }
 
+func Test_GetProjectMembers_WhenProjectDoesNotExist_ReturnsNotFound(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/projects/memberships/"+uuid.New().String()+"/members",
+		"Bearer "+user.Token,
+		http.StatusNotFound,
+	)
+	assert.Contains(t, string(resp.Body), "project not found")
+}
+
+func Test_AddMemberToProject_WhenProjectDoesNotExist_ReturnsNotFound(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	request := projects_dto.AddMemberRequestDTO{
+		Email: member.Email,
+		Role:  users_enums.ProjectRoleMember,
+	}
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/projects/memberships/"+uuid.New().String()+"/members",
+		"Bearer "+user.Token,
+		request,
+		http.StatusNotFound,
+	)
+}
+
 func Test_GetProjectMembers_WithInvalidProjectID_ReturnsBadRequest(t *testing.T) {
 	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
 	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -22,10 +22,19 @@ func (c *ProjectController) RegisterRoutes(router *gin.RouterGroup) {
 
 	projectRoutes.POST("", c.CreateProject)
 	projectRoutes.GET("", c.GetProjects)
+	projectRoutes.GET("/preferences", c.GetProjectPreferences)
+	projectRoutes.PUT("/preferences/default-project", c.SetDefaultProject)
+	projectRoutes.PUT("/preferences/auto-execute", c.SetAutoExecuteOnOpen)
 	projectRoutes.GET("/:id", c.GetProject)
 	projectRoutes.PUT("/:id", c.UpdateProject)
+	projectRoutes.PATCH("/:id", c.PatchProject)
 	projectRoutes.DELETE("/:id", c.DeleteProject)
+	projectRoutes.POST("/bulk-delete", c.BulkDeleteProjects)
 	projectRoutes.GET("/:id/audit-logs", c.GetProjectAuditLogs)
+	projectRoutes.PUT("/:id/favorite", c.AddFavoriteProject)
+	projectRoutes.DELETE("/:id/favorite", c.RemoveFavoriteProject)
+	projectRoutes.PUT("/:id/last-used", c.MarkProjectOpened)
+	projectRoutes.POST("/:id/query-tokens", c.CreateQueryToken)
 }
 
 // CreateProject
@@ -70,11 +79,13 @@ func (c *ProjectController) CreateProject(ctx *gin.Context) {
 
 // GetProjects
 // @Summary List user's projects
-// @Description Get list of projects the user is a member of
+// @Description Get list of projects the user is a member of, each flagged whether it is a favorite of the user
 // @Tags projects
 // @Produce json
 // @Security BearerAuth
+// @Param favoritesFirst query bool false "List favorite projects first"
 // @Success 200 {object} projects_dto.ListProjectsResponseDTO
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Router /projects [get]
 func (c *ProjectController) GetProjects(ctx *gin.Context) {
@@ -84,7 +95,13 @@ func (c *ProjectController) GetProjects(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.projectService.GetUserProjects(user)
+	request := &projects_dto.GetProjectsRequestDTO{}
+	if err := ctx.ShouldBindQuery(request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := c.projectService.GetUserProjects(user, request)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve projects"})
 		return
@@ -104,6 +121,7 @@ func (c *ProjectController) GetProjects(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/{id} [get]
 func (c *ProjectController) GetProject(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -121,6 +139,10 @@ func (c *ProjectController) GetProject(ctx *gin.Context) {
 
 	project, err := c.projectService.GetProject(projectID, user)
 	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to view project" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -134,7 +156,8 @@ func (c *ProjectController) GetProject(ctx *gin.Context) {
 
 // UpdateProject
 // @Summary Update project settings
-// @Description Update project configuration and settings
+// @Description Update project configuration and settings. The body replaces all settings, so setting an enabled
+// @Description quota to zero (unlimited) requires confirmQuotaDisable=true, use PATCH to change single settings
 // @Tags projects
 // @Accept json
 // @Produce json
@@ -145,6 +168,7 @@ func (c *ProjectController) GetProject(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/{id} [put]
 func (c *ProjectController) UpdateProject(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -168,6 +192,62 @@ func (c *ProjectController) UpdateProject(ctx *gin.Context) {
 
 	updatedProject, err := c.projectService.UpdateProject(projectID, &project, user)
 	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to update project" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updatedProject)
+}
+
+// PatchProject
+// @Summary Partially update project settings
+// @Description Update only the provided project settings, omitted fields keep their current values
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body projects_dto.PatchProjectRequestDTO true "Project settings to change"
+// @Success 200 {object} projects_models.Project
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id} [patch]
+func (c *ProjectController) PatchProject(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectIDStr := ctx.Param("id")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request projects_dto.PatchProjectRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	updatedProject, err := c.projectService.PatchProject(projectID, &request, user)
+	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to update project" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -189,6 +269,7 @@ func (c *ProjectController) UpdateProject(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/{id} [delete]
 func (c *ProjectController) DeleteProject(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -205,6 +286,10 @@ func (c *ProjectController) DeleteProject(ctx *gin.Context) {
 	}
 
 	if err := c.projectService.DeleteProject(projectID, user); err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "only project owner or admin can delete project" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -216,6 +301,45 @@ func (c *ProjectController) DeleteProject(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
 }
 
+// BulkDeleteProjects
+// @Summary Delete several projects
+// @Description Delete several projects with their logs at once (admin only). Returns status per project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body projects_dto.BulkDeleteProjectsRequestDTO true "Project IDs to delete"
+// @Success 200 {object} projects_dto.BulkDeleteProjectsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /projects/bulk-delete [post]
+func (c *ProjectController) BulkDeleteProjects(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request projects_dto.BulkDeleteProjectsRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.projectService.BulkDeleteProjects(request.ProjectIDs, user)
+	if err != nil {
+		if err.Error() == "insufficient permissions to bulk delete projects" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 // GetProjectAuditLogs
 // @Summary Get project audit logs
 // @Description Retrieve audit logs for a specific project (member access required)
@@ -231,6 +355,7 @@ func (c *ProjectController) DeleteProject(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /projects/{id}/audit-logs [get]
 func (c *ProjectController) GetProjectAuditLogs(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -254,6 +379,10 @@ func (c *ProjectController) GetProjectAuditLogs(ctx *gin.Context) {
 
 	response, err := c.projectService.GetProjectAuditLogs(projectID, user, request)
 	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "insufficient permissions to view project audit logs" {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -264,3 +393,273 @@ func (c *ProjectController) GetProjectAuditLogs(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+// AddFavoriteProject
+// @Summary Add project to favorites
+// @Description Mark the project as favorite for the current user
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/favorite [put]
+func (c *ProjectController) AddFavoriteProject(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if err := c.projectService.AddFavoriteProject(projectID, user); err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to access project" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project added to favorites"})
+}
+
+// RemoveFavoriteProject
+// @Summary Remove project from favorites
+// @Description Unmark the project as favorite for the current user
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/favorite [delete]
+func (c *ProjectController) RemoveFavoriteProject(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if err := c.projectService.RemoveFavoriteProject(projectID, user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project removed from favorites"})
+}
+
+// GetProjectPreferences
+// @Summary Get project preferences
+// @Description Get the default and last used project of the current user and the project the UI should open:
+// @Description the default project, else the last used one, skipping projects the user cannot access.
+// @Description autoExecuteOnOpen tells whether the UI runs the query when a project is opened, true by default
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} projects_dto.ProjectPreferencesResponseDTO
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /projects/preferences [get]
+func (c *ProjectController) GetProjectPreferences(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.projectService.GetProjectPreferences(user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// SetDefaultProject
+// @Summary Set default project
+// @Description Set the project opened for the current user, null projectId clears it
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body projects_dto.SetDefaultProjectRequestDTO true "Default project"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/preferences/default-project [put]
+func (c *ProjectController) SetDefaultProject(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request projects_dto.SetDefaultProjectRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := c.projectService.SetDefaultProject(request.ProjectID, user); err != nil {
+		c.handleProjectAccessError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Default project updated"})
+}
+
+// SetAutoExecuteOnOpen
+// @Summary Set auto execute on open
+// @Description Set whether the UI runs the query as soon as the current user opens or switches to a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body projects_dto.SetAutoExecuteOnOpenRequestDTO true "Auto execute preference"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /projects/preferences/auto-execute [put]
+func (c *ProjectController) SetAutoExecuteOnOpen(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request projects_dto.SetAutoExecuteOnOpenRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := c.projectService.SetAutoExecuteOnOpen(*request.IsAutoExecuteOnOpen, user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Auto execute preference updated"})
+}
+
+// MarkProjectOpened
+// @Summary Remember last used project
+// @Description Remember the project as the last one opened by the current user
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/last-used [put]
+func (c *ProjectController) MarkProjectOpened(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if err := c.projectService.MarkProjectOpened(projectID, user); err != nil {
+		c.handleProjectAccessError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Last used project updated"})
+}
+
+// CreateQueryToken
+// @Summary Create a query token
+// @Description Create a short-lived read-only token for embedding dashboards. The token is accepted only by
+// @Description query endpoints of the project, or only for one saved query when savedQueryId is set
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body projects_dto.CreateQueryTokenRequestDTO true "Token scope and lifetime"
+// @Success 200 {object} projects_dto.QueryTokenResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/query-tokens [post]
+func (c *ProjectController) CreateQueryToken(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request projects_dto.CreateQueryTokenRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	response, err := c.projectService.CreateQueryToken(projectID, &request, user)
+	if err != nil {
+		if err.Error() == "project not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "insufficient permissions to access project" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *ProjectController) handleProjectAccessError(ctx *gin.Context, err error) {
+	if err.Error() == "project not found" {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err.Error() == "insufficient permissions to access project" {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
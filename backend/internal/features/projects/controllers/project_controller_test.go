@@ -18,6 +18,7 @@ import (
 	users_testing "logbull/internal/features/users/testing"
 	test_utils "logbull/internal/util/testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -164,6 +165,217 @@ func Test_GetUserProjects_WithoutAuthToken_ReturnsUnauthorized(t *testing.T) {
 	test_utils.MakeGetRequest(t, router, "/api/v1/projects", "", http.StatusUnauthorized)
 }
 
+func Test_GetUserProjects_WithFavoritesFirst_FavoriteProjectsListedFirst(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	projectA, _ := projects_testing.CreateTestProjectWithToken("A Favorites Project", user.Token, router)
+	projectB, _ := projects_testing.CreateTestProjectWithToken("B Favorites Project", user.Token, router)
+	projectC, _ := projects_testing.CreateTestProjectWithToken("C Favorites Project", user.Token, router)
+
+	addFavoriteProject(t, router, projectC.ID, user.Token, http.StatusOK)
+
+	projects := getUserProjects(t, router, user.Token, "?favoritesFirst=true")
+	assert.Equal(t, []uuid.UUID{projectC.ID, projectA.ID, projectB.ID}, getProjectIDs(projects))
+	assert.True(t, projects[0].IsFavorite)
+	assert.False(t, projects[1].IsFavorite)
+	assert.False(t, projects[2].IsFavorite)
+
+	projects = getUserProjects(t, router, user.Token, "")
+	assert.Equal(t, []uuid.UUID{projectA.ID, projectB.ID, projectC.ID}, getProjectIDs(projects))
+	assert.True(t, projects[2].IsFavorite)
+}
+
+func Test_RemoveFavoriteProject_WhenProjectIsFavorite_FavoriteFlagCleared(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Unfavorite Project", user.Token, router)
+	addFavoriteProject(t, router, project.ID, user.Token, http.StatusOK)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/favorite", project.ID.String()),
+		"Bearer "+user.Token,
+		http.StatusOK,
+	)
+
+	projects := getUserProjects(t, router, user.Token, "")
+	assert.Len(t, projects, 1)
+	assert.False(t, projects[0].IsFavorite)
+}
+
+func Test_AddFavoriteProject_WhenAnotherMemberFavorites_FavoriteScopedToThatUser(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Shared Favorite Project", owner.Token, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	addFavoriteProject(t, router, project.ID, member.Token, http.StatusOK)
+
+	memberProjects := getUserProjects(t, router, member.Token, "")
+	assert.Len(t, memberProjects, 1)
+	assert.True(t, memberProjects[0].IsFavorite)
+
+	ownerProjects := getUserProjects(t, router, owner.Token, "")
+	assert.Len(t, ownerProjects, 1)
+	assert.False(t, ownerProjects[0].IsFavorite)
+}
+
+func Test_AddFavoriteProject_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Private Favorite Project", owner.Token, router)
+
+	addFavoriteProject(t, router, project.ID, outsider.Token, http.StatusForbidden)
+}
+
+func Test_GetProjectPreferences_WhenUserHasNoPreferences_ReturnsNoProjectToOpen(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	projects_testing.CreateTestProjectWithToken("No Preferences Project", user.Token, router)
+
+	preferences := getProjectPreferences(t, router, user.Token)
+	assert.Nil(t, preferences.DefaultProjectID)
+	assert.Nil(t, preferences.LastProjectID)
+	assert.Nil(t, preferences.ProjectToOpenID)
+}
+
+func Test_MarkProjectOpened_WhenProjectsSwitched_LastUsedProjectOpened(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	projectA, _ := projects_testing.CreateTestProjectWithToken("Last Used Project A", user.Token, router)
+	projectB, _ := projects_testing.CreateTestProjectWithToken("Last Used Project B", user.Token, router)
+
+	markProjectOpened(t, router, projectA.ID, user.Token, http.StatusOK)
+	markProjectOpened(t, router, projectB.ID, user.Token, http.StatusOK)
+
+	preferences := getProjectPreferences(t, router, user.Token)
+	assert.Equal(t, &projectB.ID, preferences.LastProjectID)
+	assert.Equal(t, &projectB.ID, preferences.ProjectToOpenID)
+	assert.Nil(t, preferences.DefaultProjectID)
+}
+
+func Test_SetDefaultProject_WhenLastUsedProjectDiffers_DefaultProjectOpened(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	defaultProject, _ := projects_testing.CreateTestProjectWithToken("Default Project", user.Token, router)
+	lastProject, _ := projects_testing.CreateTestProjectWithToken("Last Project", user.Token, router)
+
+	setDefaultProject(t, router, &defaultProject.ID, user.Token, http.StatusOK)
+	markProjectOpened(t, router, lastProject.ID, user.Token, http.StatusOK)
+
+	preferences := getProjectPreferences(t, router, user.Token)
+	assert.Equal(t, &defaultProject.ID, preferences.DefaultProjectID)
+	assert.Equal(t, &lastProject.ID, preferences.LastProjectID)
+	assert.Equal(t, &defaultProject.ID, preferences.ProjectToOpenID)
+
+	setDefaultProject(t, router, nil, user.Token, http.StatusOK)
+
+	preferences = getProjectPreferences(t, router, user.Token)
+	assert.Nil(t, preferences.DefaultProjectID)
+	assert.Equal(t, &lastProject.ID, preferences.ProjectToOpenID)
+}
+
+func Test_GetProjectPreferences_WhenAnotherUserSwitchesProject_PreferencesScopedToUser(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	ownerProject, _ := projects_testing.CreateTestProjectWithToken("Owner Project", owner.Token, router)
+	sharedProject, _ := projects_testing.CreateTestProjectWithToken("Shared Project", owner.Token, router)
+	projects_testing.AddMemberToProject(sharedProject, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	markProjectOpened(t, router, ownerProject.ID, owner.Token, http.StatusOK)
+	markProjectOpened(t, router, sharedProject.ID, member.Token, http.StatusOK)
+
+	assert.Equal(t, &ownerProject.ID, getProjectPreferences(t, router, owner.Token).ProjectToOpenID)
+	assert.Equal(t, &sharedProject.ID, getProjectPreferences(t, router, member.Token).ProjectToOpenID)
+}
+
+func Test_GetProjectPreferences_WhenUserLostAccessToDefaultProject_FallsBackToLastUsed(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	sharedProject, _ := projects_testing.CreateTestProjectWithToken("Revoked Default Project", owner.Token, router)
+	projects_testing.AddMemberToProject(sharedProject, member, users_enums.ProjectRoleMember, owner.Token, router)
+	memberProject, _ := projects_testing.CreateTestProjectWithToken("Member Own Project", member.Token, router)
+
+	markProjectOpened(t, router, memberProject.ID, member.Token, http.StatusOK)
+	setDefaultProject(t, router, &sharedProject.ID, member.Token, http.StatusOK)
+	projects_testing.RemoveMemberFromProject(sharedProject, member.UserID, owner.Token, router)
+
+	preferences := getProjectPreferences(t, router, member.Token)
+	assert.Equal(t, &sharedProject.ID, preferences.DefaultProjectID)
+	assert.Equal(t, &memberProject.ID, preferences.ProjectToOpenID)
+}
+
+func Test_SetDefaultProject_WhenUserIsNotProjectMember_ReturnsForbidden(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Private Default Project", owner.Token, router)
+
+	setDefaultProject(t, router, &project.ID, outsider.Token, http.StatusForbidden)
+	markProjectOpened(t, router, project.ID, outsider.Token, http.StatusForbidden)
+	assert.Nil(t, getProjectPreferences(t, router, outsider.Token).ProjectToOpenID)
+}
+
+func Test_GetProjectPreferences_WhenAutoExecuteNotSet_DefaultsToTrue(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	assert.True(t, getProjectPreferences(t, router, user.Token).IsAutoExecuteOnOpen)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Auto Execute Default Project", user.Token, router)
+	markProjectOpened(t, router, project.ID, user.Token, http.StatusOK)
+
+	assert.True(t, getProjectPreferences(t, router, user.Token).IsAutoExecuteOnOpen)
+}
+
+func Test_SetAutoExecuteOnOpen_WhenChanged_FlagRoundTrips(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectWithToken("Auto Execute Project", user.Token, router)
+
+	setAutoExecuteOnOpen(t, router, false, user.Token)
+	assert.False(t, getProjectPreferences(t, router, user.Token).IsAutoExecuteOnOpen)
+
+	// Other preferences leave the flag unchanged
+	markProjectOpened(t, router, project.ID, user.Token, http.StatusOK)
+	setDefaultProject(t, router, &project.ID, user.Token, http.StatusOK)
+	preferences := getProjectPreferences(t, router, user.Token)
+	assert.False(t, preferences.IsAutoExecuteOnOpen)
+	assert.Equal(t, &project.ID, preferences.ProjectToOpenID)
+
+	setAutoExecuteOnOpen(t, router, true, user.Token)
+	assert.True(t, getProjectPreferences(t, router, user.Token).IsAutoExecuteOnOpen)
+}
+
+func Test_SetAutoExecuteOnOpen_WithoutValue_ReturnsBadRequest(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/projects/preferences/auto-execute",
+		"Bearer "+user.Token,
+		map[string]any{},
+		http.StatusBadRequest,
+	)
+}
+
 func Test_GetSingleProject_WhenUserIsProjectMember_ReturnsProject(t *testing.T) {
 	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
 	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -235,6 +447,34 @@ func Test_GetSingleProject_WithInvalidProjectID_ReturnsBadRequest(t *testing.T)
 	assert.Contains(t, string(resp.Body), "Invalid project ID")
 }
 
+func Test_GetSingleProject_WhenProjectDoesNotExist_ReturnsNotFound(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	globalAdmin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	missingProjectURL := "/api/v1/projects/" + uuid.New().String()
+
+	resp := test_utils.MakeGetRequest(t, router, missingProjectURL, "Bearer "+user.Token, http.StatusNotFound)
+	assert.Contains(t, string(resp.Body), "project not found")
+
+	test_utils.MakeGetRequest(t, router, missingProjectURL, "Bearer "+globalAdmin.Token, http.StatusNotFound)
+	test_utils.MakeDeleteRequest(t, router, missingProjectURL, "Bearer "+user.Token, http.StatusNotFound)
+	test_utils.MakeGetRequest(t, router, missingProjectURL+"/audit-logs", "Bearer "+user.Token, http.StatusNotFound)
+}
+
+func Test_GetSingleProject_WhenProjectExistsButUserIsNotMember_ReturnsForbiddenNotNotFound(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Test Project", owner.Token, router)
+	projectURL := "/api/v1/projects/" + project.ID.String()
+
+	test_utils.MakeGetRequest(t, router, projectURL, "Bearer "+nonMember.Token, http.StatusForbidden)
+	test_utils.MakeDeleteRequest(t, router, projectURL, "Bearer "+nonMember.Token, http.StatusForbidden)
+	test_utils.MakeGetRequest(t, router, projectURL+"/audit-logs", "Bearer "+nonMember.Token, http.StatusForbidden)
+}
+
 func Test_UpdateProject_WhenUserIsProjectOwner_ProjectUpdated(t *testing.T) {
 	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
 	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -274,6 +514,63 @@ func Test_UpdateProject_WhenUserIsProjectOwner_ProjectUpdated(t *testing.T) {
 	assert.Contains(t, response.AllowedDomains, "test.com")
 }
 
+func Test_UpdateProject_WhenSettingsChanged_AuditLogListsChangedSettingsWithValues(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projectName := "Settings Diff " + uuid.NewString()[:8]
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	updateData := *project
+	updateData.MaxLogsLifeDays = 7
+	updateData.IsApiKeyRequired = !project.IsApiKeyRequired
+	updateData.AllowedDomains = []string{"example.com"}
+	projects_testing.UpdateProject(project, &updateData, owner.Token, router)
+
+	message := getLatestProjectAuditLogMessage(t, project.ID)
+
+	assert.Equal(t, fmt.Sprintf(
+		"Project updated: %s (allowedDomains: null -> [\"example.com\"]; isApiKeyRequired: %t -> %t; maxLogsLifeDays: %d -> 7)",
+		project.Name,
+		project.IsApiKeyRequired,
+		updateData.IsApiKeyRequired,
+		project.MaxLogsLifeDays,
+	), message)
+}
+
+func Test_UpdateProject_WhenWebhookUrlChanged_AuditLogDoesNotContainUrl(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projectName := "Settings Diff Webhook " + uuid.NewString()[:8]
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	updateData := *project
+	updateData.FirstLogWebhookURL = "https://203.0.113.10/first-log?token=secret"
+	projects_testing.UpdateProject(project, &updateData, owner.Token, router)
+
+	message := getLatestProjectAuditLogMessage(t, project.ID)
+
+	assert.Equal(t, fmt.Sprintf("Project updated: %s (firstLogWebhookUrl: changed)", project.Name), message)
+	assert.NotContains(t, message, "secret")
+}
+
+func Test_UpdateProject_WhenSettingsDiffAuditDisabled_AuditLogHasNoDiff(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	projectName := "Settings Diff Disabled " + uuid.NewString()[:8]
+	project, _ := projects_testing.CreateTestProjectWithToken(projectName, owner.Token, router)
+
+	projectService := projects_services.GetProjectService()
+	originalIsSettingsDiffAudited := projectService.IsSettingsDiffAudited()
+	t.Cleanup(func() { projectService.SetSettingsDiffAudited(originalIsSettingsDiffAudited) })
+	projectService.SetSettingsDiffAudited(false)
+
+	updateData := *project
+	updateData.MaxLogsLifeDays = 7
+	projects_testing.UpdateProject(project, &updateData, owner.Token, router)
+
+	assert.Equal(t, "Project updated: "+project.Name, getLatestProjectAuditLogMessage(t, project.ID))
+}
+
 func Test_UpdateProject_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
 	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -297,6 +594,165 @@ func Test_UpdateProject_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
 	assert.Contains(t, string(resp.Body), "insufficient permissions to update project")
 }
 
+func Test_UpdateProject_WithPartialBody_QuotaZeroingRejected(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Quota Guard Project", owner.Token, router)
+
+	updateRequest := projects_models.Project{
+		Name:               project.Name,
+		LogsPerSecondLimit: 2000,
+	}
+
+	resp := test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+owner.Token,
+		updateRequest,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(resp.Body), "maxLogsAmount")
+	assert.Contains(t, string(resp.Body), "maxLogsSizeMb")
+	assert.Contains(t, string(resp.Body), "maxLogsLifeDays")
+
+	var storedProject projects_models.Project
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&storedProject,
+	)
+	assert.NotZero(t, storedProject.MaxLogsAmount)
+	assert.NotZero(t, storedProject.MaxLogsSizeMB)
+	assert.NotZero(t, storedProject.MaxLogsLifeDays)
+	assert.NotEqual(t, 2000, storedProject.LogsPerSecondLimit)
+}
+
+func Test_UpdateProject_WithConfirmQuotaDisable_QuotasDisabled(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Quota Disable Project", owner.Token, router)
+
+	updateRequest := projects_models.Project{
+		Name:                  project.Name,
+		LogsPerSecondLimit:    2000,
+		MaxLogSizeKB:          64,
+		IsConfirmQuotaDisable: true,
+	}
+
+	var response projects_models.Project
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+owner.Token,
+		updateRequest,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, 2000, response.LogsPerSecondLimit)
+	assert.Zero(t, response.MaxLogsAmount)
+	assert.Zero(t, response.MaxLogsSizeMB)
+	assert.Zero(t, response.MaxLogsLifeDays)
+	assert.False(t, response.IsConfirmQuotaDisable)
+}
+
+func Test_PatchProject_WithOnlyLogsPerSecondLimit_OtherSettingsPreserved(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	ownerResponse := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProjectWithConfiguration(
+		"Patch Test Project",
+		ownerResponse,
+		router,
+		&projects_testing.ProjectConfigurationDTO{
+			IsApiKeyRequired:   true,
+			IsFilterByDomain:   true,
+			AllowedDomains:     []string{"example.com"},
+			IsFilterByIP:       true,
+			AllowedIPs:         []string{"10.0.0.0/8"},
+			LogsPerSecondLimit: 1000,
+			MaxLogSizeKB:       64,
+		},
+	)
+
+	// Populate the cache so the test also covers its invalidation
+	_, err := projects_services.GetProjectService().GetProjectWithCache(project.ID)
+	assert.NoError(t, err)
+
+	logsPerSecondLimit := 2500
+	var response projects_models.Project
+	test_utils.MakePatchRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+ownerResponse.Token,
+		projects_dto.PatchProjectRequestDTO{LogsPerSecondLimit: &logsPerSecondLimit},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, 2500, response.LogsPerSecondLimit)
+	assert.Equal(t, project.Name, response.Name)
+	assert.Equal(t, project.CreatedAt.Unix(), response.CreatedAt.Unix())
+	assert.True(t, response.IsApiKeyRequired)
+	assert.True(t, response.IsFilterByDomain)
+	assert.True(t, response.IsFilterByIP)
+	assert.Equal(t, []string{"example.com"}, response.AllowedDomains)
+	assert.Equal(t, []string{"10.0.0.0/8"}, response.AllowedIPs)
+	assert.Equal(t, project.MaxLogsAmount, response.MaxLogsAmount)
+	assert.Equal(t, project.MaxLogsSizeMB, response.MaxLogsSizeMB)
+	assert.Equal(t, project.MaxLogsLifeDays, response.MaxLogsLifeDays)
+	assert.Equal(t, 64, response.MaxLogSizeKB)
+
+	cachedProject, err := projects_services.GetProjectService().GetProjectWithCache(project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2500, cachedProject.LogsPerSecondLimit)
+	assert.True(t, cachedProject.IsApiKeyRequired)
+	assert.Equal(t, []string{"example.com"}, cachedProject.AllowedDomains)
+}
+
+func Test_PatchProject_WithInvalidTraceIdField_ReturnsBadRequest(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project, _ := projects_testing.CreateTestProjectWithToken("Patch Invalid Project", owner.Token, router)
+
+	traceIdField := "message"
+	test_utils.MakePatchRequest(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+owner.Token,
+		projects_dto.PatchProjectRequestDTO{TraceIdField: &traceIdField},
+		http.StatusBadRequest,
+	)
+}
+
+func Test_PatchProject_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Patch Forbidden Project", owner.Token, router)
+	projects_testing.AddMemberToProject(project, member, users_enums.ProjectRoleMember, owner.Token, router)
+
+	name := "Updated Name"
+	resp := test_utils.MakePatchRequest(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+member.Token,
+		projects_dto.PatchProjectRequestDTO{Name: &name},
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "insufficient permissions to update project")
+}
+
 func Test_DeleteProject_WhenUserIsProjectOwner_ProjectDeleted(t *testing.T) {
 	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
 	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -348,6 +804,91 @@ func Test_DeleteProject_WhenUserIsProjectMember_ReturnsForbidden(t *testing.T) {
 	assert.Contains(t, string(resp.Body), "only project owner or admin can delete project")
 }
 
+func Test_BulkDeleteProjects_WithMixedValidAndInvalidIDs_ReturnsPerProjectStatus(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	globalAdmin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	firstProject, _ := projects_testing.CreateTestProjectWithToken("Bulk Project 1", owner.Token, router)
+	secondProject, _ := projects_testing.CreateTestProjectWithToken("Bulk Project 2", owner.Token, router)
+	missingProjectID := uuid.New()
+
+	request := projects_dto.BulkDeleteProjectsRequestDTO{
+		ProjectIDs: []uuid.UUID{firstProject.ID, missingProjectID, secondProject.ID},
+	}
+
+	var response projects_dto.BulkDeleteProjectsResponseDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/bulk-delete",
+		"Bearer "+globalAdmin.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Results, 3)
+	assert.Equal(t, firstProject.ID, response.Results[0].ProjectID)
+	assert.Equal(t, projects_dto.BulkDeleteStatusDeleted, response.Results[0].Status)
+	assert.Equal(t, missingProjectID, response.Results[1].ProjectID)
+	assert.Equal(t, projects_dto.BulkDeleteStatusNotFound, response.Results[1].Status)
+	assert.Equal(t, secondProject.ID, response.Results[2].ProjectID)
+	assert.Equal(t, projects_dto.BulkDeleteStatusDeleted, response.Results[2].Status)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/projects/"+firstProject.ID.String(),
+		"Bearer "+globalAdmin.Token,
+		http.StatusNotFound,
+	)
+
+	auditLogs, err := audit_logs.GetAuditLogService().GetProjectAuditLogs(
+		secondProject.ID,
+		&audit_logs.GetAuditLogsRequest{Limit: 100},
+	)
+	assert.NoError(t, err)
+
+	found := false
+	for _, auditLog := range auditLogs.AuditLogs {
+		if auditLog.Message == "Project deleted: Bulk Project 2" {
+			found = true
+			break
+		}
+	}
+	assert.True(t, found, "Audit log for bulk deleted project not found")
+}
+
+func Test_BulkDeleteProjects_WhenUserIsNotAdmin_ReturnsForbidden(t *testing.T) {
+	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	project, _ := projects_testing.CreateTestProjectWithToken("Bulk Project", owner.Token, router)
+
+	request := projects_dto.BulkDeleteProjectsRequestDTO{
+		ProjectIDs: []uuid.UUID{project.ID},
+	}
+
+	resp := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/projects/bulk-delete",
+		"Bearer "+owner.Token,
+		request,
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "insufficient permissions to bulk delete projects")
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/projects/"+project.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+}
+
 func Test_UpdateProject_WhenUserIsProjectAdmin_ProjectUpdated(t *testing.T) {
 	router := projects_testing.CreateTestRouter(GetProjectController(), GetMembershipController())
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -360,6 +901,8 @@ func Test_UpdateProject_WhenUserIsProjectAdmin_ProjectUpdated(t *testing.T) {
 		Name:               project.Name,
 		IsApiKeyRequired:   true,
 		LogsPerSecondLimit: 3000,
+		// Quotas are omitted from the body, so they are disabled on purpose
+		IsConfirmQuotaDisable: true,
 	}
 
 	var response projects_models.Project
@@ -437,6 +980,8 @@ func Test_GetProjectAuditLogs_WithMultipleProjects_ReturnsOnlyProjectSpecificLog
 		Name:               project1.Name,
 		IsApiKeyRequired:   true,
 		LogsPerSecondLimit: 1500,
+		// Quotas are omitted from the body, so they are disabled on purpose
+		IsConfirmQuotaDisable: true,
 	}
 	test_utils.MakePutRequest(
 		t,
@@ -451,6 +996,8 @@ func Test_GetProjectAuditLogs_WithMultipleProjects_ReturnsOnlyProjectSpecificLog
 		Name:               project2.Name,
 		IsFilterByDomain:   true,
 		LogsPerSecondLimit: 2000,
+		// Quotas are omitted from the body, so they are disabled on purpose
+		IsConfirmQuotaDisable: true,
 	}
 	test_utils.MakePutRequest(
 		t,
@@ -719,6 +1266,98 @@ func Test_GetProjectWithCache_MultipleNonExistentProjects_CachesEachSeparately(t
 	assert.Error(t, err4)
 }
 
+func addFavoriteProject(t *testing.T, router *gin.Engine, projectID uuid.UUID, token string, expectedStatus int) {
+	test_utils.MakePutRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/favorite", projectID.String()),
+		"Bearer "+token,
+		nil,
+		expectedStatus,
+	)
+}
+
+func markProjectOpened(t *testing.T, router *gin.Engine, projectID uuid.UUID, token string, expectedStatus int) {
+	test_utils.MakePutRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/projects/%s/last-used", projectID.String()),
+		"Bearer "+token,
+		nil,
+		expectedStatus,
+	)
+}
+
+func setDefaultProject(t *testing.T, router *gin.Engine, projectID *uuid.UUID, token string, expectedStatus int) {
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/projects/preferences/default-project",
+		"Bearer "+token,
+		projects_dto.SetDefaultProjectRequestDTO{ProjectID: projectID},
+		expectedStatus,
+	)
+}
+
+func setAutoExecuteOnOpen(t *testing.T, router *gin.Engine, isAutoExecuteOnOpen bool, token string) {
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/projects/preferences/auto-execute",
+		"Bearer "+token,
+		projects_dto.SetAutoExecuteOnOpenRequestDTO{IsAutoExecuteOnOpen: &isAutoExecuteOnOpen},
+		http.StatusOK,
+	)
+}
+
+func getProjectPreferences(t *testing.T, router *gin.Engine, token string) *projects_dto.ProjectPreferencesResponseDTO {
+	var response projects_dto.ProjectPreferencesResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects/preferences",
+		"Bearer "+token,
+		http.StatusOK,
+		&response,
+	)
+
+	return &response
+}
+
+func getUserProjects(t *testing.T, router *gin.Engine, token, query string) []projects_dto.ProjectResponseDTO {
+	var response projects_dto.ListProjectsResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/projects"+query,
+		"Bearer "+token,
+		http.StatusOK,
+		&response,
+	)
+
+	return response.Projects
+}
+
+func getProjectIDs(projects []projects_dto.ProjectResponseDTO) []uuid.UUID {
+	projectIDs := make([]uuid.UUID, len(projects))
+	for i, project := range projects {
+		projectIDs[i] = project.ID
+	}
+
+	return projectIDs
+}
+
+func getLatestProjectAuditLogMessage(t *testing.T, projectID uuid.UUID) string {
+	auditLogs, err := audit_logs.GetAuditLogService().GetProjectAuditLogs(
+		projectID,
+		&audit_logs.GetAuditLogsRequest{Limit: 1},
+	)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, auditLogs.AuditLogs)
+
+	return auditLogs.AuditLogs[0].Message
+}
+
 func extractAuditLogMessages(logs []*audit_logs.AuditLogDTO) []string {
 	messages := make([]string, len(logs))
 	for i, log := range logs {
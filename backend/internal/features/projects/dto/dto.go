@@ -3,6 +3,7 @@ package projects_dto
 import (
 	"time"
 
+	projects_models "logbull/internal/features/projects/models"
 	users_enums "logbull/internal/features/users/enums"
 
 	"github.com/google/uuid"
@@ -27,12 +28,129 @@ type ProjectResponseDTO struct {
 
 	// User's role in this project (populated when fetching for specific user)
 	UserRole *users_enums.ProjectRole `json:"userRole,omitempty"`
+	// Whether the current user marked the project as favorite
+	IsFavorite bool `json:"favorite" gorm:"-"`
+}
+
+// ProjectPreferencesResponseDTO tells the UI which project to open.
+// ProjectToOpenID is the default project, or the last used one when there is
+// no default, provided the user can still access it. Nil means no project
+type ProjectPreferencesResponseDTO struct {
+	DefaultProjectID *uuid.UUID `json:"defaultProjectId"`
+	LastProjectID    *uuid.UUID `json:"lastProjectId"`
+	ProjectToOpenID  *uuid.UUID `json:"projectToOpenId"`
+	// Whether the UI runs the project query as soon as a project is opened or
+	// switched to, true until the user turns it off
+	IsAutoExecuteOnOpen bool `json:"autoExecuteOnOpen"`
+}
+
+type SetDefaultProjectRequestDTO struct {
+	// Null clears the default project
+	ProjectID *uuid.UUID `json:"projectId"`
+}
+
+type SetAutoExecuteOnOpenRequestDTO struct {
+	IsAutoExecuteOnOpen *bool `json:"autoExecuteOnOpen" binding:"required"`
+}
+
+type CreateQueryTokenRequestDTO struct {
+	// Limits the token to executing this saved query of the project
+	SavedQueryID *uuid.UUID `json:"savedQueryId"`
+	// Lifetime of the token, 0 means an hour and at most a day is allowed
+	ExpiresInMinutes int `json:"expiresInMinutes" binding:"omitempty,min=1,max=1440"`
+}
+
+type QueryTokenResponseDTO struct {
+	Token        string     `json:"token"`
+	ProjectID    uuid.UUID  `json:"projectId"`
+	SavedQueryID *uuid.UUID `json:"savedQueryId"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+}
+
+type GetProjectsRequestDTO struct {
+	// FavoritesFirst lists favorite projects before the others, both sorted by name
+	FavoritesFirst bool `form:"favoritesFirst"`
 }
 
 type ListProjectsResponseDTO struct {
 	Projects []ProjectResponseDTO `json:"projects"`
 }
 
+// PatchProjectRequestDTO holds project settings for a partial update,
+// nil fields are left unchanged
+type PatchProjectRequestDTO struct {
+	Name *string `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+
+	IsApiKeyRequired *bool     `json:"isApiKeyRequired,omitempty"`
+	IsFilterByDomain *bool     `json:"isFilterByDomain,omitempty"`
+	IsFilterByIP     *bool     `json:"isFilterByIp,omitempty"`
+	AllowedDomains   *[]string `json:"allowedDomains,omitempty"`
+	AllowedIPs       *[]string `json:"allowedIps,omitempty"`
+
+	LogsPerSecondLimit *int   `json:"logsPerSecondLimit,omitempty"`
+	MaxLogsAmount      *int64 `json:"maxLogsAmount,omitempty"`
+	MaxLogsSizeMB      *int   `json:"maxLogsSizeMb,omitempty"`
+	MaxLogsLifeDays    *int   `json:"maxLogsLifeDays,omitempty"`
+	MaxLogSizeKB       *int   `json:"maxLogSizeKb,omitempty"`
+	ArchiveAfterDays   *int   `json:"archiveAfterDays,omitempty"`
+
+	AttachmentThresholdKB *int `json:"attachmentThresholdKb,omitempty"`
+	SamplingPercent       *int `json:"samplingPercent,omitempty"`
+
+	CleanupWebhookURL    *string `json:"cleanupWebhookUrl,omitempty"`
+	CleanupNoticeMinutes *int    `json:"cleanupNoticeMinutes,omitempty"`
+	FirstLogWebhookURL   *string `json:"firstLogWebhookUrl,omitempty"`
+
+	TraceIdField *string `json:"traceIdField,omitempty"`
+	LevelField   *string `json:"levelField,omitempty"`
+	DefaultLevel *string `json:"defaultLevel,omitempty"`
+
+	AllowedLevels          *[]string `json:"allowedLevels,omitempty"`
+	DisallowedLevelMapping *string   `json:"disallowedLevelMapping,omitempty"`
+
+	MultilinePattern       *string `json:"multilinePattern,omitempty"`
+	IsFieldNamesLowercased *bool   `json:"isFieldNamesLowercased,omitempty"`
+	IsMessageOnlyMode      *bool   `json:"isMessageOnlyMode,omitempty"`
+	IngestPipeline         *string `json:"ingestPipeline,omitempty"`
+
+	// The nil UUID clears the default saved query
+	DefaultSavedQueryID     *uuid.UUID `json:"defaultSavedQueryId,omitempty"`
+	DefaultTimeRangeMinutes *int       `json:"defaultTimeRangeMinutes,omitempty"`
+
+	MaskedFields *[]string `json:"maskedFields,omitempty"`
+
+	FieldFilterMode *projects_models.FieldFilterMode `json:"fieldFilterMode,omitempty"`
+	FilteredFields  *[]string                        `json:"filteredFields,omitempty"`
+
+	EncryptedFields *[]string `json:"encryptedFields,omitempty"`
+
+	NgramFields *[]string `json:"ngramFields,omitempty"`
+
+	FieldAliases *map[string]string `json:"fieldAliases,omitempty"`
+}
+
+type BulkDeleteProjectsRequestDTO struct {
+	ProjectIDs []uuid.UUID `json:"projectIds" binding:"required,min=1,max=100"`
+}
+
+type BulkDeleteProjectStatus string
+
+const (
+	BulkDeleteStatusDeleted  BulkDeleteProjectStatus = "DELETED"
+	BulkDeleteStatusNotFound BulkDeleteProjectStatus = "NOT_FOUND"
+	BulkDeleteStatusFailed   BulkDeleteProjectStatus = "FAILED"
+)
+
+type BulkDeleteProjectResultDTO struct {
+	ProjectID uuid.UUID               `json:"projectId"`
+	Status    BulkDeleteProjectStatus `json:"status"`
+	Error     string                  `json:"error,omitempty"`
+}
+
+type BulkDeleteProjectsResponseDTO struct {
+	Results []BulkDeleteProjectResultDTO `json:"results"`
+}
+
 // Membership DTOs
 type AddMemberRequestDTO struct {
 	Email string                  `json:"email" binding:"required,email"`
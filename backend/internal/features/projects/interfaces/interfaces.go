@@ -5,3 +5,9 @@ import "github.com/google/uuid"
 type ProjectDeletionListener interface {
 	OnBeforeProjectDeletion(projectID uuid.UUID) error
 }
+
+// SavedQueryChecker tells whether a saved query belongs to the project, saved
+// queries are owned by the logs features which depend on projects
+type SavedQueryChecker interface {
+	IsSavedQueryOfProject(projectID, savedQueryID uuid.UUID) (bool, error)
+}
@@ -1,6 +1,7 @@
 package projects_models
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -8,6 +9,24 @@ import (
 	"gorm.io/gorm"
 )
 
+// FieldFilterMode tells how FilteredFields select custom fields stored at ingestion
+type FieldFilterMode string
+
+const (
+	FieldFilterModeNone  FieldFilterMode = ""
+	FieldFilterModeAllow FieldFilterMode = "ALLOW"
+	FieldFilterModeBlock FieldFilterMode = "BLOCK"
+)
+
+func (m FieldFilterMode) IsValid() bool {
+	switch m {
+	case FieldFilterModeNone, FieldFilterModeAllow, FieldFilterModeBlock:
+		return true
+	default:
+		return false
+	}
+}
+
 type Project struct {
 	ID        uuid.UUID `json:"id"        gorm:"column:id"`
 	Name      string    `json:"name"      gorm:"column:name"`
@@ -29,6 +48,96 @@ type Project struct {
 	MaxLogsLifeDays    int   `json:"maxLogsLifeDays"    gorm:"column:max_logs_life_days"`
 	MaxLogSizeKB       int   `json:"maxLogSizeKb"       gorm:"column:max_log_size_kb"`
 
+	// Archived tier: logs older than this many days are moved from the searchable indices
+	// to archive indices with no replicas, kept until retention deletes them and searched
+	// only by queries including archived logs. 0 keeps all logs searchable
+	ArchiveAfterDays int `json:"archiveAfterDays" gorm:"column:archive_after_days"`
+
+	// String fields above this size are offloaded to attachment storage (0 disables offloading)
+	AttachmentThresholdKB int `json:"attachmentThresholdKb" gorm:"column:attachment_threshold_kb"`
+
+	// Ingestion sampling: percent of accepted logs which are stored, the others are dropped at
+	// random and accounted for in estimated stats. 0 and 100 store every log
+	SamplingPercent int `json:"samplingPercent" gorm:"column:sampling_percent"`
+
+	// Cleanup notice: quota and retention deletions are announced to the webhook and
+	// wait this many minutes before running (0 deletes right away without a notice)
+	CleanupWebhookURL    string `json:"cleanupWebhookUrl"    gorm:"column:cleanup_webhook_url"`
+	CleanupNoticeMinutes int    `json:"cleanupNoticeMinutes" gorm:"column:cleanup_notice_minutes"`
+
+	// Onboarding: the webhook is called once, when the project receives its first log.
+	// FirstLogReceivedAt is set by ingestion only
+	FirstLogWebhookURL string     `json:"firstLogWebhookUrl" gorm:"column:first_log_webhook_url"`
+	FirstLogReceivedAt *time.Time `json:"firstLogReceivedAt" gorm:"column:first_log_received_at"`
+
+	// Correlation
+	TraceIdField string `json:"traceIdField" gorm:"column:trace_id_field"`
+
+	// Ingestion mapping: custom field read as level when a log has no level
+	LevelField string `json:"levelField" gorm:"column:level_field"`
+	// Ingestion default: level of logs with neither a level nor the level field, one of
+	// DEBUG, INFO, WARN, ERROR or FATAL. Empty rejects such logs
+	DefaultLevel string `json:"defaultLevel" gorm:"column:default_level"`
+	// Ingestion levels: the levels the project accepts, empty accepts all. Logs with another
+	// level are stored with DisallowedLevelMapping, one of the allowed levels, or rejected
+	// when it is empty
+	AllowedLevelsRaw       string   `json:"-"                      gorm:"column:allowed_levels_raw"`
+	AllowedLevels          []string `json:"allowedLevels"          gorm:"-"`
+	DisallowedLevelMapping string   `json:"disallowedLevelMapping" gorm:"column:disallowed_level_mapping"`
+
+	// Ingestion multi-line join: logs whose message matches the pattern are continuation
+	// lines (e.g. of a stack trace) and are appended to the previous log of the batch.
+	// Empty disables joining
+	MultilinePattern string `json:"multilinePattern" gorm:"column:multiline_pattern"`
+
+	// Ingestion field names: custom field names are lowercased, so case variants of a name
+	// (e.g. "Status" and "status") are stored and queried as one field
+	IsFieldNamesLowercased bool `json:"isFieldNamesLowercased" gorm:"column:is_field_names_lowercased"`
+
+	// Ingestion indexing: in message-only mode custom fields are stored with the log and
+	// returned by queries, but not indexed, so only the message and system fields can be
+	// searched. Saves storage for projects searching message text only. Applies to logs
+	// ingested after the change
+	IsMessageOnlyMode bool `json:"isMessageOnlyMode" gorm:"column:is_message_only_mode"`
+
+	// Ingestion processing: OpenSearch ingest pipeline (e.g. with grok, date or set processors)
+	// the project logs are indexed through. The pipeline is managed in OpenSearch, empty disables it
+	IngestPipeline string `json:"ingestPipeline" gorm:"column:ingest_pipeline"`
+
+	// Default query: saved query and time range (minutes back from now) the UI loads when a
+	// project member opens the project. Empty values leave the choice to the UI
+	DefaultSavedQueryID     *uuid.UUID `json:"defaultSavedQueryId"     gorm:"column:default_saved_query_id"`
+	DefaultTimeRangeMinutes int        `json:"defaultTimeRangeMinutes" gorm:"column:default_time_range_minutes"`
+
+	// Field visibility: custom fields masked in query results for project members
+	MaskedFieldsRaw string   `json:"-"            gorm:"column:masked_fields_raw"`
+	MaskedFields    []string `json:"maskedFields" gorm:"-"`
+
+	// Field filtering: custom fields dropped at ingestion. In ALLOW mode only the listed
+	// fields are stored, in BLOCK mode the listed fields are dropped
+	FieldFilterMode   FieldFilterMode `json:"fieldFilterMode" gorm:"column:field_filter_mode"`
+	FilteredFieldsRaw string          `json:"-"               gorm:"column:filtered_fields_raw"`
+	FilteredFields    []string        `json:"filteredFields"  gorm:"-"`
+
+	// Field encryption: custom fields stored as ciphertext and decrypted for project readers.
+	// The key is generated when the first field is listed and is never returned by the API
+	EncryptedFieldsRaw string   `json:"-"               gorm:"column:encrypted_fields_raw"`
+	EncryptedFields    []string `json:"encryptedFields" gorm:"-"`
+	FieldEncryptionKey string   `json:"-"               gorm:"column:field_encryption_key"`
+
+	// Partial match: custom fields indexed as n-grams at ingestion, so contains on them
+	// avoids slow wildcards. Applies to logs received after a field is listed
+	NgramFieldsRaw string   `json:"-"           gorm:"column:ngram_fields_raw"`
+	NgramFields    []string `json:"ngramFields" gorm:"-"`
+
+	// Field aliases: names usable in queries in place of the custom field they map to, so
+	// queries and the UI keep stable names when applications rename fields
+	FieldAliasesRaw string            `json:"-"            gorm:"column:field_aliases_raw"`
+	FieldAliases    map[string]string `json:"fieldAliases" gorm:"-"`
+
+	// Update options: confirms that an update sets previously enabled quotas to zero (unlimited)
+	IsConfirmQuotaDisable bool `json:"confirmQuotaDisable,omitempty" gorm:"-"`
+
 	// Cache-related fields for logs insertion
 	IsNotExists bool `json:"isNotExists,omitempty" gorm:"-"` // Used for caching non-existent projects
 }
@@ -50,6 +159,48 @@ func (p *Project) BeforeSave(tx *gorm.DB) error {
 		p.AllowedIPsRaw = ""
 	}
 
+	if len(p.AllowedLevels) > 0 {
+		p.AllowedLevelsRaw = strings.Join(p.AllowedLevels, ",")
+	} else {
+		p.AllowedLevelsRaw = ""
+	}
+
+	if len(p.MaskedFields) > 0 {
+		p.MaskedFieldsRaw = strings.Join(p.MaskedFields, ",")
+	} else {
+		p.MaskedFieldsRaw = ""
+	}
+
+	if len(p.FilteredFields) > 0 {
+		p.FilteredFieldsRaw = strings.Join(p.FilteredFields, ",")
+	} else {
+		p.FilteredFieldsRaw = ""
+	}
+
+	if len(p.EncryptedFields) > 0 {
+		p.EncryptedFieldsRaw = strings.Join(p.EncryptedFields, ",")
+	} else {
+		p.EncryptedFieldsRaw = ""
+	}
+
+	if len(p.NgramFields) > 0 {
+		p.NgramFieldsRaw = strings.Join(p.NgramFields, ",")
+	} else {
+		p.NgramFieldsRaw = ""
+	}
+
+	// Stored as "alias=field" pairs sorted by alias
+	if len(p.FieldAliases) > 0 {
+		fieldAliases := make([]string, 0, len(p.FieldAliases))
+		for alias, field := range p.FieldAliases {
+			fieldAliases = append(fieldAliases, alias+"="+field)
+		}
+		slices.Sort(fieldAliases)
+		p.FieldAliasesRaw = strings.Join(fieldAliases, ",")
+	} else {
+		p.FieldAliasesRaw = ""
+	}
+
 	return nil
 }
 
@@ -72,5 +223,60 @@ func (p *Project) AfterFind(tx *gorm.DB) error {
 		p.AllowedIPs = []string{}
 	}
 
+	if p.AllowedLevelsRaw != "" {
+		p.AllowedLevels = strings.Split(p.AllowedLevelsRaw, ",")
+		for i, level := range p.AllowedLevels {
+			p.AllowedLevels[i] = strings.TrimSpace(level)
+		}
+	} else {
+		p.AllowedLevels = []string{}
+	}
+
+	if p.MaskedFieldsRaw != "" {
+		p.MaskedFields = strings.Split(p.MaskedFieldsRaw, ",")
+		for i, field := range p.MaskedFields {
+			p.MaskedFields[i] = strings.TrimSpace(field)
+		}
+	} else {
+		p.MaskedFields = []string{}
+	}
+
+	if p.FilteredFieldsRaw != "" {
+		p.FilteredFields = strings.Split(p.FilteredFieldsRaw, ",")
+		for i, field := range p.FilteredFields {
+			p.FilteredFields[i] = strings.TrimSpace(field)
+		}
+	} else {
+		p.FilteredFields = []string{}
+	}
+
+	if p.EncryptedFieldsRaw != "" {
+		p.EncryptedFields = strings.Split(p.EncryptedFieldsRaw, ",")
+		for i, field := range p.EncryptedFields {
+			p.EncryptedFields[i] = strings.TrimSpace(field)
+		}
+	} else {
+		p.EncryptedFields = []string{}
+	}
+
+	if p.NgramFieldsRaw != "" {
+		p.NgramFields = strings.Split(p.NgramFieldsRaw, ",")
+		for i, field := range p.NgramFields {
+			p.NgramFields[i] = strings.TrimSpace(field)
+		}
+	} else {
+		p.NgramFields = []string{}
+	}
+
+	p.FieldAliases = map[string]string{}
+	if p.FieldAliasesRaw != "" {
+		for _, fieldAlias := range strings.Split(p.FieldAliasesRaw, ",") {
+			alias, field, isPair := strings.Cut(fieldAlias, "=")
+			if isPair {
+				p.FieldAliases[strings.TrimSpace(alias)] = strings.TrimSpace(field)
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,17 @@
+package projects_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectFavorite struct {
+	UserID    uuid.UUID `json:"userId"    gorm:"column:user_id;primaryKey"`
+	ProjectID uuid.UUID `json:"projectId" gorm:"column:project_id;primaryKey"`
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (ProjectFavorite) TableName() string {
+	return "project_favorites"
+}
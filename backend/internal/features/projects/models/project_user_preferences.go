@@ -0,0 +1,25 @@
+package projects_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultAutoExecuteOnOpen is used until the user changes the preference
+const DefaultAutoExecuteOnOpen = true
+
+// ProjectUserPreferences keeps which project the UI opens for a user and
+// whether the query runs right away. The project references are cleared when
+// the project is deleted
+type ProjectUserPreferences struct {
+	UserID              uuid.UUID  `json:"userId"            gorm:"column:user_id;primaryKey"`
+	DefaultProjectID    *uuid.UUID `json:"defaultProjectId"  gorm:"column:default_project_id"`
+	LastProjectID       *uuid.UUID `json:"lastProjectId"     gorm:"column:last_project_id"`
+	IsAutoExecuteOnOpen bool       `json:"autoExecuteOnOpen" gorm:"column:is_auto_execute_on_open"`
+	UpdatedAt           time.Time  `json:"updatedAt"         gorm:"column:updated_at"`
+}
+
+func (ProjectUserPreferences) TableName() string {
+	return "project_user_preferences"
+}
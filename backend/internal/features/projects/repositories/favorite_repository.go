@@ -0,0 +1,42 @@
+package projects_repositories
+
+import (
+	"time"
+
+	projects_models "logbull/internal/features/projects/models"
+	"logbull/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type FavoriteRepository struct{}
+
+// AddFavorite marks the project as favorite for the user, adding an existing
+// favorite again is a no-op
+func (r *FavoriteRepository) AddFavorite(userID, projectID uuid.UUID) error {
+	favorite := &projects_models.ProjectFavorite{
+		UserID:    userID,
+		ProjectID: projectID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	return storage.GetDb().Clauses(clause.OnConflict{DoNothing: true}).Create(favorite).Error
+}
+
+func (r *FavoriteRepository) RemoveFavorite(userID, projectID uuid.UUID) error {
+	return storage.GetDb().
+		Where("user_id = ? AND project_id = ?", userID, projectID).
+		Delete(&projects_models.ProjectFavorite{}).Error
+}
+
+func (r *FavoriteRepository) GetFavoriteProjectIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	projectIDs := make([]uuid.UUID, 0)
+
+	err := storage.GetDb().
+		Model(&projects_models.ProjectFavorite{}).
+		Where("user_id = ?", userID).
+		Pluck("project_id", &projectIDs).Error
+
+	return projectIDs, err
+}
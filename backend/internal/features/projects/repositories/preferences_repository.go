@@ -0,0 +1,69 @@
+package projects_repositories
+
+import (
+	"errors"
+	"time"
+
+	projects_models "logbull/internal/features/projects/models"
+	"logbull/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PreferencesRepository struct{}
+
+// GetPreferences returns empty preferences for users who never set any
+func (r *PreferencesRepository) GetPreferences(userID uuid.UUID) (*projects_models.ProjectUserPreferences, error) {
+	var preferences projects_models.ProjectUserPreferences
+
+	err := storage.GetDb().Where("user_id = ?", userID).First(&preferences).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &projects_models.ProjectUserPreferences{
+			UserID:              userID,
+			IsAutoExecuteOnOpen: projects_models.DefaultAutoExecuteOnOpen,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+// SetDefaultProject sets or, with nil, clears the default project of the user
+func (r *PreferencesRepository) SetDefaultProject(userID uuid.UUID, projectID *uuid.UUID) error {
+	return r.upsertColumn("default_project_id", &projects_models.ProjectUserPreferences{
+		UserID:              userID,
+		DefaultProjectID:    projectID,
+		IsAutoExecuteOnOpen: projects_models.DefaultAutoExecuteOnOpen,
+		UpdatedAt:           time.Now().UTC(),
+	})
+}
+
+func (r *PreferencesRepository) SetLastProject(userID, projectID uuid.UUID) error {
+	return r.upsertColumn("last_project_id", &projects_models.ProjectUserPreferences{
+		UserID:              userID,
+		LastProjectID:       &projectID,
+		IsAutoExecuteOnOpen: projects_models.DefaultAutoExecuteOnOpen,
+		UpdatedAt:           time.Now().UTC(),
+	})
+}
+
+func (r *PreferencesRepository) SetAutoExecuteOnOpen(userID uuid.UUID, isAutoExecuteOnOpen bool) error {
+	return r.upsertColumn("is_auto_execute_on_open", &projects_models.ProjectUserPreferences{
+		UserID:              userID,
+		IsAutoExecuteOnOpen: isAutoExecuteOnOpen,
+		UpdatedAt:           time.Now().UTC(),
+	})
+}
+
+// upsertColumn creates the preferences row or updates only the given column,
+// leaving the other preferences of the user unchanged
+func (r *PreferencesRepository) upsertColumn(column string, preferences *projects_models.ProjectUserPreferences) error {
+	return storage.GetDb().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{column, "updated_at"}),
+	}).Create(preferences).Error
+}
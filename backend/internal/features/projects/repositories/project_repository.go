@@ -32,8 +32,21 @@ func (r *ProjectRepository) GetProjectByID(projectID uuid.UUID) (*projects_model
 	return &project, nil
 }
 
+// UpdateProject saves the settings, the first log mark is only written by
+// MarkFirstLogReceived so an update never resets it
 func (r *ProjectRepository) UpdateProject(project *projects_models.Project) error {
-	return storage.GetDb().Save(project).Error
+	return storage.GetDb().Omit("first_log_received_at").Save(project).Error
+}
+
+// MarkFirstLogReceived sets the first log time unless it is already set and
+// reports whether this call set it
+func (r *ProjectRepository) MarkFirstLogReceived(projectID uuid.UUID, receivedAt time.Time) (bool, error) {
+	result := storage.GetDb().
+		Model(&projects_models.Project{}).
+		Where("id = ? AND first_log_received_at IS NULL", projectID).
+		Update("first_log_received_at", receivedAt)
+
+	return result.RowsAffected == 1, result.Error
 }
 
 func (r *ProjectRepository) DeleteProject(projectID uuid.UUID) error {
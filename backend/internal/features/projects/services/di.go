@@ -1,11 +1,15 @@
 package projects_services
 
 import (
+	"sync"
+
 	"logbull/internal/cache"
+	"logbull/internal/config"
 	"logbull/internal/features/audit_logs"
 	projects_interfaces "logbull/internal/features/projects/interfaces"
 	projects_models "logbull/internal/features/projects/models"
 	projects_repositories "logbull/internal/features/projects/repositories"
+	system_webhooks "logbull/internal/features/system/webhooks"
 	users_services "logbull/internal/features/users/services"
 	cache_utils "logbull/internal/util/cache"
 
@@ -14,16 +18,25 @@ import (
 
 var projectRepository = &projects_repositories.ProjectRepository{}
 var membershipRepository = &projects_repositories.MembershipRepository{}
+var favoriteRepository = &projects_repositories.FavoriteRepository{}
+var preferencesRepository = &projects_repositories.PreferencesRepository{}
 
 var projectService = &ProjectService{
 	projectRepository,
 	membershipRepository,
+	favoriteRepository,
+	preferencesRepository,
 	users_services.GetUserService(),
 	audit_logs.GetAuditLogService(),
 	users_services.GetSettingsService(),
+	users_services.GetQueryTokenService(),
 	[]projects_interfaces.ProjectDeletionListener{},
+	nil,
+	system_webhooks.GetDestinationGuard(),
 	cache_utils.NewCacheUtil[projects_models.Project](cache.GetCache(), "lb_project:"),
 	singleflight.Group{},
+	sync.Map{},
+	!config.GetEnv().IsAuditSettingsDiffDisabled,
 }
 
 var membershipService = &MembershipService{
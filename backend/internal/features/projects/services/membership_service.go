@@ -228,6 +228,10 @@ func (s *MembershipService) TransferOwnership(
 	request *projects_dto.TransferOwnershipRequestDTO,
 	user *users_models.User,
 ) error {
+	if _, err := s.projectService.GetProjectWithCache(projectID); err != nil {
+		return err
+	}
+
 	currentRole, err := s.membershipRepository.GetUserProjectRole(projectID, user.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get current user role: %w", err)
@@ -1,8 +1,16 @@
 package projects_services
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	audit_logs "logbull/internal/features/audit_logs"
@@ -10,25 +18,101 @@ import (
 	projects_interfaces "logbull/internal/features/projects/interfaces"
 	projects_models "logbull/internal/features/projects/models"
 	projects_repositories "logbull/internal/features/projects/repositories"
+	users_dto "logbull/internal/features/users/dto"
 	users_enums "logbull/internal/features/users/enums"
 	users_models "logbull/internal/features/users/models"
 	users_services "logbull/internal/features/users/services"
 	cache_utils "logbull/internal/util/cache"
+	"logbull/internal/util/webhook"
 
 	"github.com/google/uuid"
 	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
 )
 
+const (
+	maxFieldSettingLength = 100
+	// A week, longer notices would let quotas be exceeded for too long
+	maxCleanupNoticeMinutes = 7 * 24 * 60
+
+	maxMultilinePatternLength = 200
+	maxIngestPipelineLength   = 100
+
+	// Every n-gram field adds a token per character of its values to the index
+	maxNgramFields = 20
+
+	maxFieldAliases = 50
+
+	// A year, the same bound as the widest time range of regular queries
+	maxDefaultTimeRangeMinutes = 365 * 24 * 60
+
+	defaultQueryTokenLifetime = time.Hour
+	maxQueryTokenLifetime     = 24 * time.Hour
+)
+
+var fieldSettingPattern = regexp.MustCompile(`^[a-zA-Z0-9_.\-]+$`)
+
+// logLevelValues mirrors the stored log levels, which this package cannot
+// import from the logs core
+var logLevelValues = []string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// reservedLogFields mirrors system fields of stored logs, custom fields
+// with these names are never indexed as attributes
+var reservedLogFields = map[string]bool{
+	"id":            true,
+	"project_id":    true,
+	"timestamp":     true,
+	"level":         true,
+	"message":       true,
+	"client_ip":     true,
+	"created_at":    true,
+	"attrs_text":    true,
+	"attrs_tokens":  true,
+	"attrs_ngrams":  true,
+	"attrs_numbers": true,
+	"sample_weight": true,
+}
+
 type ProjectService struct {
 	projectRepository        *projects_repositories.ProjectRepository
 	membershipRepository     *projects_repositories.MembershipRepository
+	favoriteRepository       *projects_repositories.FavoriteRepository
+	preferencesRepository    *projects_repositories.PreferencesRepository
 	userService              *users_services.UserService
 	auditLogService          *audit_logs.AuditLogService
 	settingsService          *users_services.SettingsService
+	queryTokenService        *users_services.QueryTokenService
 	projectDeletionListeners []projects_interfaces.ProjectDeletionListener
+	savedQueryChecker        projects_interfaces.SavedQueryChecker
+	webhookDestinationGuard  *webhook.DestinationGuard
 
 	projectCacheUtil *cache_utils.CacheUtil[projects_models.Project]
 	singleflight     singleflight.Group // Prevents thundering herd on DB calls
+
+	// Field encryption keys by project ID. Keys never change once generated and
+	// are not part of the cached project, which is serialized without them
+	fieldEncryptionKeys sync.Map
+
+	// when set, update audit logs list the changed settings with their values
+	isSettingsDiffAudited bool
+}
+
+func (s *ProjectService) SetSettingsDiffAudited(isSettingsDiffAudited bool) {
+	s.isSettingsDiffAudited = isSettingsDiffAudited
+}
+
+func (s *ProjectService) IsSettingsDiffAudited() bool {
+	return s.isSettingsDiffAudited
+}
+
+func (s *ProjectService) SetSavedQueryChecker(checker projects_interfaces.SavedQueryChecker) {
+	s.savedQueryChecker = checker
+}
+
+// InvalidateProjectCache drops the cached project after its row was changed
+// outside of the service, e.g. by a foreign key action
+func (s *ProjectService) InvalidateProjectCache(projectID uuid.UUID) {
+	s.projectCacheUtil.Invalidate(projectID.String())
 }
 
 func (s *ProjectService) AddProjectDeletionListener(listener projects_interfaces.ProjectDeletionListener) {
@@ -111,17 +195,211 @@ func (s *ProjectService) GetProject(projectID uuid.UUID, user *users_models.User
 	return s.projectRepository.GetProjectByID(projectID)
 }
 
-func (s *ProjectService) GetUserProjects(user *users_models.User) (*projects_dto.ListProjectsResponseDTO, error) {
+func (s *ProjectService) GetUserProjects(
+	user *users_models.User,
+	request *projects_dto.GetProjectsRequestDTO,
+) (*projects_dto.ListProjectsResponseDTO, error) {
 	projects, err := s.membershipRepository.GetProjectsWithRolesByUserID(user.Role, user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user projects: %w", err)
 	}
 
+	favoriteProjectIDs, err := s.favoriteRepository.GetFavoriteProjectIDs(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorite projects: %w", err)
+	}
+
+	for i := range projects {
+		projects[i].IsFavorite = slices.Contains(favoriteProjectIDs, projects[i].ID)
+	}
+
+	if request.FavoritesFirst {
+		// Stable sort keeps the name order within favorites and the rest
+		slices.SortStableFunc(projects, func(a, b projects_dto.ProjectResponseDTO) int {
+			if a.IsFavorite == b.IsFavorite {
+				return 0
+			}
+			if a.IsFavorite {
+				return -1
+			}
+			return 1
+		})
+	}
+
 	return &projects_dto.ListProjectsResponseDTO{
 		Projects: projects,
 	}, nil
 }
 
+func (s *ProjectService) AddFavoriteProject(projectID uuid.UUID, user *users_models.User) error {
+	canAccess, _, err := s.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return errors.New("insufficient permissions to access project")
+	}
+
+	if err := s.favoriteRepository.AddFavorite(user.ID, projectID); err != nil {
+		return fmt.Errorf("failed to add favorite project: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveFavoriteProject does not check project access, so users can clean up
+// favorites of projects they were removed from
+func (s *ProjectService) RemoveFavoriteProject(projectID uuid.UUID, user *users_models.User) error {
+	if err := s.favoriteRepository.RemoveFavorite(user.ID, projectID); err != nil {
+		return fmt.Errorf("failed to remove favorite project: %w", err)
+	}
+
+	return nil
+}
+
+// GetProjectPreferences resolves the project to open for the user. Preferences
+// pointing to projects the user lost access to are returned but never opened
+func (s *ProjectService) GetProjectPreferences(
+	user *users_models.User,
+) (*projects_dto.ProjectPreferencesResponseDTO, error) {
+	preferences, err := s.preferencesRepository.GetPreferences(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project preferences: %w", err)
+	}
+
+	response := &projects_dto.ProjectPreferencesResponseDTO{
+		DefaultProjectID:    preferences.DefaultProjectID,
+		LastProjectID:       preferences.LastProjectID,
+		IsAutoExecuteOnOpen: preferences.IsAutoExecuteOnOpen,
+	}
+
+	for _, projectID := range []*uuid.UUID{preferences.DefaultProjectID, preferences.LastProjectID} {
+		if projectID == nil {
+			continue
+		}
+
+		// An error means the project no longer exists, it is skipped as well
+		canAccess, _, err := s.CanUserAccessProject(*projectID, user)
+		if err == nil && canAccess {
+			response.ProjectToOpenID = projectID
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// SetDefaultProject sets the project opened for the user regardless of the
+// last used one, nil clears it
+func (s *ProjectService) SetDefaultProject(projectID *uuid.UUID, user *users_models.User) error {
+	if projectID != nil {
+		canAccess, _, err := s.CanUserAccessProject(*projectID, user)
+		if err != nil {
+			return err
+		}
+		if !canAccess {
+			return errors.New("insufficient permissions to access project")
+		}
+	}
+
+	if err := s.preferencesRepository.SetDefaultProject(user.ID, projectID); err != nil {
+		return fmt.Errorf("failed to set default project: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProjectService) SetAutoExecuteOnOpen(isAutoExecuteOnOpen bool, user *users_models.User) error {
+	if err := s.preferencesRepository.SetAutoExecuteOnOpen(user.ID, isAutoExecuteOnOpen); err != nil {
+		return fmt.Errorf("failed to set auto execute preference: %w", err)
+	}
+
+	return nil
+}
+
+// MarkProjectOpened remembers the project as the last one the user opened
+func (s *ProjectService) MarkProjectOpened(projectID uuid.UUID, user *users_models.User) error {
+	canAccess, _, err := s.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return errors.New("insufficient permissions to access project")
+	}
+
+	if err := s.preferencesRepository.SetLastProject(user.ID, projectID); err != nil {
+		return fmt.Errorf("failed to set last used project: %w", err)
+	}
+
+	return nil
+}
+
+// CreateQueryToken mints a read-only token for embedding project logs. It reads
+// with the permissions of the user, so members can mint it as well
+func (s *ProjectService) CreateQueryToken(
+	projectID uuid.UUID,
+	request *projects_dto.CreateQueryTokenRequestDTO,
+	user *users_models.User,
+) (*projects_dto.QueryTokenResponseDTO, error) {
+	canAccess, _, err := s.CanUserAccessProject(projectID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to access project")
+	}
+
+	lifetime := defaultQueryTokenLifetime
+	if request.ExpiresInMinutes != 0 {
+		lifetime = time.Duration(request.ExpiresInMinutes) * time.Minute
+	}
+	if lifetime <= 0 || lifetime > maxQueryTokenLifetime {
+		return nil, fmt.Errorf("token lifetime must be between 1 and %d minutes", int(maxQueryTokenLifetime.Minutes()))
+	}
+
+	if request.SavedQueryID != nil {
+		if s.savedQueryChecker == nil {
+			return nil, errors.New("saved queries are not available")
+		}
+
+		isSavedQueryOfProject, err := s.savedQueryChecker.IsSavedQueryOfProject(projectID, *request.SavedQueryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check saved query: %w", err)
+		}
+		if !isSavedQueryOfProject {
+			return nil, errors.New("saved query not found in project")
+		}
+	}
+
+	scope := &users_dto.QueryTokenScope{
+		ProjectID:    projectID,
+		SavedQueryID: request.SavedQueryID,
+		ExpiresAt:    time.Now().UTC().Add(lifetime).Truncate(time.Second),
+	}
+
+	token, err := s.queryTokenService.GenerateQueryToken(user, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	auditMessage := fmt.Sprintf("Query token created, expires at %s", scope.ExpiresAt.Format(time.RFC3339))
+	if scope.SavedQueryID != nil {
+		auditMessage = fmt.Sprintf(
+			"Query token created for saved query %s, expires at %s",
+			scope.SavedQueryID.String(),
+			scope.ExpiresAt.Format(time.RFC3339),
+		)
+	}
+	s.auditLogService.WriteAuditLog(auditMessage, &user.ID, &projectID)
+
+	return &projects_dto.QueryTokenResponseDTO{
+		Token:        token,
+		ProjectID:    projectID,
+		SavedQueryID: scope.SavedQueryID,
+		ExpiresAt:    scope.ExpiresAt,
+	}, nil
+}
+
 func (s *ProjectService) UpdateProject(
 	projectID uuid.UUID,
 	project *projects_models.Project,
@@ -141,8 +419,119 @@ func (s *ProjectService) UpdateProject(
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
+	if !project.IsConfirmQuotaDisable {
+		if err := s.validateQuotasNotDisabled(existingProject, project); err != nil {
+			return nil, err
+		}
+	}
+	project.IsConfirmQuotaDisable = false
+
+	if err := s.validateFieldSetting("trace id field", project.TraceIdField); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateFieldSetting("level field", project.LevelField); err != nil {
+		return nil, err
+	}
+
+	project.DefaultLevel = strings.ToUpper(strings.TrimSpace(project.DefaultLevel))
+	if project.DefaultLevel != "" && !slices.Contains(logLevelValues, project.DefaultLevel) {
+		return nil, fmt.Errorf("default level must be one of %s", strings.Join(logLevelValues, ", "))
+	}
+
+	if err := s.validateAllowedLevels(project); err != nil {
+		return nil, err
+	}
+
+	if project.AttachmentThresholdKB < 0 {
+		return nil, errors.New("attachment threshold must not be negative")
+	}
+
+	if project.SamplingPercent < 0 || project.SamplingPercent > 100 {
+		return nil, errors.New("sampling percent must be between 0 and 100")
+	}
+
+	if err := validateMultilinePattern(project.MultilinePattern); err != nil {
+		return nil, err
+	}
+
+	if err := validateIngestPipeline(project.IngestPipeline); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateDefaultQuery(projectID, project); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateFirstLogWebhook(project); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCleanupNotice(project); err != nil {
+		return nil, err
+	}
+
+	if err := validateArchiveAfterDays(project); err != nil {
+		return nil, err
+	}
+
+	if !project.FieldFilterMode.IsValid() {
+		return nil, errors.New("field filter mode must be ALLOW, BLOCK or empty")
+	}
+
+	for _, filteredField := range project.FilteredFields {
+		if filteredField == "" {
+			return nil, errors.New("filtered field must not be empty")
+		}
+
+		if err := s.validateFieldSetting("filtered field", filteredField); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, maskedField := range project.MaskedFields {
+		if maskedField == "" {
+			return nil, errors.New("masked field must not be empty")
+		}
+
+		if err := s.validateFieldSetting("masked field", maskedField); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, encryptedField := range project.EncryptedFields {
+		if encryptedField == "" {
+			return nil, errors.New("encrypted field must not be empty")
+		}
+
+		if err := s.validateFieldSetting("encrypted field", encryptedField); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.validateNgramFields(project); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateFieldAliases(project); err != nil {
+		return nil, err
+	}
+
+	// The key is not part of the request, it is kept once generated so
+	// already encrypted values stay readable
+	project.FieldEncryptionKey = existingProject.FieldEncryptionKey
+	if len(project.EncryptedFields) > 0 && project.FieldEncryptionKey == "" {
+		fieldEncryptionKey, err := generateFieldEncryptionKey()
+		if err != nil {
+			return nil, err
+		}
+
+		project.FieldEncryptionKey = fieldEncryptionKey
+	}
+
 	project.ID = projectID
 	project.CreatedAt = existingProject.CreatedAt
+	project.FirstLogReceivedAt = existingProject.FirstLogReceivedAt
 
 	if err := s.projectRepository.UpdateProject(project); err != nil {
 		return nil, fmt.Errorf("failed to update project: %w", err)
@@ -151,7 +540,7 @@ func (s *ProjectService) UpdateProject(
 	s.projectCacheUtil.Invalidate(projectID.String())
 
 	s.auditLogService.WriteAuditLog(
-		fmt.Sprintf("Project updated: %s", project.Name),
+		s.buildUpdateAuditMessage(existingProject, project),
 		&user.ID,
 		&projectID,
 	)
@@ -159,7 +548,55 @@ func (s *ProjectService) UpdateProject(
 	return project, nil
 }
 
+// buildUpdateAuditMessage lists the changed settings with their old and new
+// values, so the audit log tells who changed e.g. the retention and from what
+func (s *ProjectService) buildUpdateAuditMessage(existingProject, project *projects_models.Project) string {
+	if !s.isSettingsDiffAudited {
+		return buildProjectUpdatedMessage(project.Name, nil)
+	}
+
+	changes, err := diffProjectSettings(existingProject, project)
+	if err != nil {
+		return buildProjectUpdatedMessage(project.Name, nil)
+	}
+
+	return buildProjectUpdatedMessage(project.Name, changes)
+}
+
+// PatchProject updates only the settings present in the request, the rest
+// of the project is taken from the stored version
+func (s *ProjectService) PatchProject(
+	projectID uuid.UUID,
+	request *projects_dto.PatchProjectRequestDTO,
+	user *users_models.User,
+) (*projects_models.Project, error) {
+	canManage, err := s.CanUserManageProject(projectID, user)
+
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to update project")
+	}
+
+	project, err := s.projectRepository.GetProjectByID(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	applyProjectPatch(project, request)
+
+	// Omitted settings keep their values, so a zero quota can only be sent on purpose
+	project.IsConfirmQuotaDisable = true
+
+	return s.UpdateProject(projectID, project, user)
+}
+
 func (s *ProjectService) DeleteProject(projectID uuid.UUID, user *users_models.User) error {
+	if _, err := s.GetProjectWithCache(projectID); err != nil {
+		return err
+	}
+
 	if user.Role != users_enums.UserRoleAdmin {
 		userProjectRole, err := s.GetUserProjectRole(projectID, user.ID)
 		if err != nil {
@@ -197,14 +634,64 @@ func (s *ProjectService) DeleteProject(projectID uuid.UUID, user *users_models.U
 	return nil
 }
 
+func (s *ProjectService) BulkDeleteProjects(
+	projectIDs []uuid.UUID,
+	user *users_models.User,
+) (*projects_dto.BulkDeleteProjectsResponseDTO, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("insufficient permissions to bulk delete projects")
+	}
+
+	results := make([]projects_dto.BulkDeleteProjectResultDTO, 0, len(projectIDs))
+	processed := make(map[uuid.UUID]bool, len(projectIDs))
+
+	for _, projectID := range projectIDs {
+		if processed[projectID] {
+			continue
+		}
+		processed[projectID] = true
+
+		result := projects_dto.BulkDeleteProjectResultDTO{ProjectID: projectID}
+
+		if _, err := s.projectRepository.GetProjectByID(projectID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				result.Status = projects_dto.BulkDeleteStatusNotFound
+			} else {
+				result.Status = projects_dto.BulkDeleteStatusFailed
+				result.Error = fmt.Sprintf("failed to get project: %v", err)
+			}
+
+			results = append(results, result)
+			continue
+		}
+
+		if err := s.DeleteProject(projectID, user); err != nil {
+			result.Status = projects_dto.BulkDeleteStatusFailed
+			result.Error = err.Error()
+		} else {
+			result.Status = projects_dto.BulkDeleteStatusDeleted
+		}
+
+		results = append(results, result)
+	}
+
+	return &projects_dto.BulkDeleteProjectsResponseDTO{Results: results}, nil
+}
+
 func (s *ProjectService) GetUserProjectRole(projectID uuid.UUID, userID uuid.UUID) (*users_enums.ProjectRole, error) {
 	return s.membershipRepository.GetUserProjectRole(projectID, userID)
 }
 
+// CanUserAccessProject returns "project not found" error for missing projects,
+// so callers can answer 404 for them and 403 only for existing projects
 func (s *ProjectService) CanUserAccessProject(
 	projectID uuid.UUID,
 	user *users_models.User,
 ) (bool, *users_enums.ProjectRole, error) {
+	if _, err := s.GetProjectWithCache(projectID); err != nil {
+		return false, nil, err
+	}
+
 	if user.Role == users_enums.UserRoleAdmin {
 		adminRole := users_enums.ProjectRoleOwner
 		return true, &adminRole, nil
@@ -219,6 +706,10 @@ func (s *ProjectService) CanUserAccessProject(
 }
 
 func (s *ProjectService) CanUserManageProject(projectID uuid.UUID, user *users_models.User) (bool, error) {
+	if _, err := s.GetProjectWithCache(projectID); err != nil {
+		return false, err
+	}
+
 	if user.Role == users_enums.UserRoleAdmin {
 		return true, nil
 	}
@@ -289,6 +780,433 @@ func (s *ProjectService) GetProjectWithCache(projectID uuid.UUID) (*projects_mod
 	return project, nil
 }
 
+// GetFieldEncryptionKey returns the base64 encoded key of the project encrypted
+// fields, or an empty string when the project never had encrypted fields
+func (s *ProjectService) GetFieldEncryptionKey(projectID uuid.UUID) (string, error) {
+	if key, ok := s.fieldEncryptionKeys.Load(projectID); ok {
+		return key.(string), nil
+	}
+
+	project, err := s.projectRepository.GetProjectByID(projectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if project.FieldEncryptionKey != "" {
+		s.fieldEncryptionKeys.Store(projectID, project.FieldEncryptionKey)
+	}
+
+	return project.FieldEncryptionKey, nil
+}
+
+// MarkFirstLogReceived records the first log of the project and reports
+// whether this call recorded it, so the first log is announced only once even
+// when several instances ingest at the same time
+func (s *ProjectService) MarkFirstLogReceived(projectID uuid.UUID, receivedAt time.Time) (bool, error) {
+	isMarked, err := s.projectRepository.MarkFirstLogReceived(projectID, receivedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark first log: %w", err)
+	}
+
+	// Cached projects would keep asking for the mark otherwise
+	s.projectCacheUtil.Invalidate(projectID.String())
+
+	return isMarked, nil
+}
+
 func (s *ProjectService) GetAllProjects() ([]*projects_models.Project, error) {
 	return s.projectRepository.GetAllProjects()
 }
+
+// validateQuotasNotDisabled rejects updates which set an enabled quota to zero
+// (unlimited) without confirmation. Zero usually comes from a partial body
+// sent to the full update, which would silently turn the quota off
+func (s *ProjectService) validateQuotasNotDisabled(
+	existingProject *projects_models.Project,
+	project *projects_models.Project,
+) error {
+	var disabledQuotas []string
+
+	if existingProject.LogsPerSecondLimit != 0 && project.LogsPerSecondLimit == 0 {
+		disabledQuotas = append(disabledQuotas, "logsPerSecondLimit")
+	}
+	if existingProject.MaxLogsAmount != 0 && project.MaxLogsAmount == 0 {
+		disabledQuotas = append(disabledQuotas, "maxLogsAmount")
+	}
+	if existingProject.MaxLogsSizeMB != 0 && project.MaxLogsSizeMB == 0 {
+		disabledQuotas = append(disabledQuotas, "maxLogsSizeMb")
+	}
+	if existingProject.MaxLogsLifeDays != 0 && project.MaxLogsLifeDays == 0 {
+		disabledQuotas = append(disabledQuotas, "maxLogsLifeDays")
+	}
+
+	if len(disabledQuotas) > 0 {
+		return fmt.Errorf(
+			"update would disable quotas: %s, set confirmQuotaDisable to disable them",
+			strings.Join(disabledQuotas, ", "),
+		)
+	}
+
+	return nil
+}
+
+// validateFieldSetting checks project settings which name a custom log field
+func (s *ProjectService) validateFieldSetting(settingName, fieldName string) error {
+	if fieldName == "" {
+		return nil
+	}
+
+	if len(fieldName) > maxFieldSettingLength {
+		return fmt.Errorf("%s must not be longer than %d characters", settingName, maxFieldSettingLength)
+	}
+
+	if !fieldSettingPattern.MatchString(fieldName) {
+		return fmt.Errorf("%s may contain only letters, digits, '_', '-' and '.'", settingName)
+	}
+
+	if reservedLogFields[fieldName] {
+		return fmt.Errorf("%s cannot be a system field: %s", settingName, fieldName)
+	}
+
+	return nil
+}
+
+// validateFieldAliases allows aliases of custom fields only, an alias cannot
+// point to another alias, so resolving it never chains
+func (s *ProjectService) validateFieldAliases(project *projects_models.Project) error {
+	if len(project.FieldAliases) > maxFieldAliases {
+		return fmt.Errorf("no more than %d field aliases are allowed", maxFieldAliases)
+	}
+
+	for alias, field := range project.FieldAliases {
+		if alias == "" || field == "" {
+			return errors.New("field alias and its field must not be empty")
+		}
+
+		if err := s.validateFieldSetting("field alias", alias); err != nil {
+			return err
+		}
+
+		if err := s.validateFieldSetting("aliased field", field); err != nil {
+			return err
+		}
+
+		if alias == field {
+			return fmt.Errorf("field alias %s must differ from its field", alias)
+		}
+
+		if _, isAlias := project.FieldAliases[field]; isAlias {
+			return fmt.Errorf("field alias %s must not point to another alias: %s", alias, field)
+		}
+	}
+
+	return nil
+}
+
+// validateAllowedLevels upper-cases the allowed levels and their mapping. The
+// mapping and the default level have to be allowed, otherwise logs given them
+// would be rejected anyway
+func (s *ProjectService) validateAllowedLevels(project *projects_models.Project) error {
+	allowedLevels := make([]string, 0, len(project.AllowedLevels))
+	for _, level := range project.AllowedLevels {
+		level = strings.ToUpper(strings.TrimSpace(level))
+		if !slices.Contains(logLevelValues, level) {
+			return fmt.Errorf("allowed levels must be one of %s", strings.Join(logLevelValues, ", "))
+		}
+
+		if !slices.Contains(allowedLevels, level) {
+			allowedLevels = append(allowedLevels, level)
+		}
+	}
+	project.AllowedLevels = allowedLevels
+
+	if len(allowedLevels) > 0 && project.DefaultLevel != "" && !slices.Contains(allowedLevels, project.DefaultLevel) {
+		return errors.New("default level must be one of the allowed levels")
+	}
+
+	project.DisallowedLevelMapping = strings.ToUpper(strings.TrimSpace(project.DisallowedLevelMapping))
+	if project.DisallowedLevelMapping == "" {
+		return nil
+	}
+
+	if len(allowedLevels) == 0 {
+		return errors.New("disallowed level mapping requires allowed levels")
+	}
+
+	if !slices.Contains(allowedLevels, project.DisallowedLevelMapping) {
+		return errors.New("disallowed level mapping must be one of the allowed levels")
+	}
+
+	return nil
+}
+
+// validateNgramFields rejects encrypted fields as well, n-grams of ciphertext
+// would never match a searched value
+func (s *ProjectService) validateNgramFields(project *projects_models.Project) error {
+	if len(project.NgramFields) > maxNgramFields {
+		return fmt.Errorf("no more than %d n-gram fields are allowed", maxNgramFields)
+	}
+
+	for _, ngramField := range project.NgramFields {
+		if ngramField == "" {
+			return errors.New("n-gram field must not be empty")
+		}
+
+		if err := s.validateFieldSetting("n-gram field", ngramField); err != nil {
+			return err
+		}
+
+		if slices.Contains(project.EncryptedFields, ngramField) {
+			return fmt.Errorf("n-gram field cannot be an encrypted field: %s", ngramField)
+		}
+	}
+
+	return nil
+}
+
+func (s *ProjectService) validateCleanupNotice(project *projects_models.Project) error {
+	if project.CleanupNoticeMinutes < 0 || project.CleanupNoticeMinutes > maxCleanupNoticeMinutes {
+		return fmt.Errorf("cleanup notice must be between 0 and %d minutes", maxCleanupNoticeMinutes)
+	}
+
+	if project.CleanupWebhookURL == "" {
+		if project.CleanupNoticeMinutes > 0 {
+			return errors.New("cleanup notice requires a cleanup webhook url")
+		}
+
+		return nil
+	}
+
+	webhookURL, err := url.Parse(project.CleanupWebhookURL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return errors.New("cleanup webhook url must be an absolute http or https url")
+	}
+
+	if err := s.webhookDestinationGuard.CheckURL(context.Background(), webhookURL); err != nil {
+		return fmt.Errorf("cleanup webhook url is not allowed: %w", err)
+	}
+
+	return nil
+}
+
+// validateArchiveAfterDays requires logs to be archived before the retention
+// period deletes them, archiving them later would never happen
+func validateArchiveAfterDays(project *projects_models.Project) error {
+	if project.ArchiveAfterDays < 0 {
+		return errors.New("archive after days must not be negative")
+	}
+
+	if project.ArchiveAfterDays > 0 && project.MaxLogsLifeDays > 0 &&
+		project.ArchiveAfterDays >= project.MaxLogsLifeDays {
+		return errors.New("archive after days must be less than max logs life days")
+	}
+
+	return nil
+}
+
+// validateFirstLogWebhook refuses urls the backend must not call, e.g. its own
+// network or cloud metadata
+func (s *ProjectService) validateFirstLogWebhook(project *projects_models.Project) error {
+	if project.FirstLogWebhookURL == "" {
+		return nil
+	}
+
+	webhookURL, err := url.Parse(project.FirstLogWebhookURL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return errors.New("first log webhook url must be an absolute http or https url")
+	}
+
+	if err := s.webhookDestinationGuard.CheckURL(context.Background(), webhookURL); err != nil {
+		return fmt.Errorf("first log webhook url is not allowed: %w", err)
+	}
+
+	return nil
+}
+
+func validateMultilinePattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+
+	if len(pattern) > maxMultilinePatternLength {
+		return fmt.Errorf("multiline pattern must not exceed %d characters", maxMultilinePatternLength)
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid multiline pattern: %w", err)
+	}
+
+	return nil
+}
+
+// validateIngestPipeline checks the pipeline name only, a pipeline missing in
+// OpenSearch fails indexing of the project logs
+func validateIngestPipeline(pipeline string) error {
+	if pipeline == "" {
+		return nil
+	}
+
+	if len(pipeline) > maxIngestPipelineLength {
+		return fmt.Errorf("ingest pipeline must not exceed %d characters", maxIngestPipelineLength)
+	}
+
+	if !fieldSettingPattern.MatchString(pipeline) {
+		return errors.New("ingest pipeline may contain only letters, digits, '_', '.' and '-'")
+	}
+
+	return nil
+}
+
+func (s *ProjectService) validateDefaultQuery(projectID uuid.UUID, project *projects_models.Project) error {
+	if project.DefaultTimeRangeMinutes < 0 || project.DefaultTimeRangeMinutes > maxDefaultTimeRangeMinutes {
+		return fmt.Errorf("default time range must be between 0 and %d minutes", maxDefaultTimeRangeMinutes)
+	}
+
+	if project.DefaultSavedQueryID == nil {
+		return nil
+	}
+
+	if s.savedQueryChecker == nil {
+		return errors.New("default saved query cannot be set, saved queries are not available")
+	}
+
+	isSavedQueryOfProject, err := s.savedQueryChecker.IsSavedQueryOfProject(projectID, *project.DefaultSavedQueryID)
+	if err != nil {
+		return fmt.Errorf("failed to check default saved query: %w", err)
+	}
+	if !isSavedQueryOfProject {
+		return errors.New("default saved query must be a saved query of the project")
+	}
+
+	return nil
+}
+
+func applyProjectPatch(project *projects_models.Project, request *projects_dto.PatchProjectRequestDTO) {
+	if request.Name != nil {
+		project.Name = *request.Name
+	}
+
+	if request.IsApiKeyRequired != nil {
+		project.IsApiKeyRequired = *request.IsApiKeyRequired
+	}
+	if request.IsFilterByDomain != nil {
+		project.IsFilterByDomain = *request.IsFilterByDomain
+	}
+	if request.IsFilterByIP != nil {
+		project.IsFilterByIP = *request.IsFilterByIP
+	}
+	if request.AllowedDomains != nil {
+		project.AllowedDomains = *request.AllowedDomains
+	}
+	if request.AllowedIPs != nil {
+		project.AllowedIPs = *request.AllowedIPs
+	}
+
+	if request.LogsPerSecondLimit != nil {
+		project.LogsPerSecondLimit = *request.LogsPerSecondLimit
+	}
+	if request.MaxLogsAmount != nil {
+		project.MaxLogsAmount = *request.MaxLogsAmount
+	}
+	if request.MaxLogsSizeMB != nil {
+		project.MaxLogsSizeMB = *request.MaxLogsSizeMB
+	}
+	if request.MaxLogsLifeDays != nil {
+		project.MaxLogsLifeDays = *request.MaxLogsLifeDays
+	}
+	if request.MaxLogSizeKB != nil {
+		project.MaxLogSizeKB = *request.MaxLogSizeKB
+	}
+	if request.ArchiveAfterDays != nil {
+		project.ArchiveAfterDays = *request.ArchiveAfterDays
+	}
+
+	if request.AttachmentThresholdKB != nil {
+		project.AttachmentThresholdKB = *request.AttachmentThresholdKB
+	}
+	if request.SamplingPercent != nil {
+		project.SamplingPercent = *request.SamplingPercent
+	}
+
+	if request.CleanupWebhookURL != nil {
+		project.CleanupWebhookURL = *request.CleanupWebhookURL
+	}
+	if request.CleanupNoticeMinutes != nil {
+		project.CleanupNoticeMinutes = *request.CleanupNoticeMinutes
+	}
+	if request.FirstLogWebhookURL != nil {
+		project.FirstLogWebhookURL = *request.FirstLogWebhookURL
+	}
+
+	if request.TraceIdField != nil {
+		project.TraceIdField = *request.TraceIdField
+	}
+	if request.LevelField != nil {
+		project.LevelField = *request.LevelField
+	}
+	if request.DefaultLevel != nil {
+		project.DefaultLevel = *request.DefaultLevel
+	}
+	if request.AllowedLevels != nil {
+		project.AllowedLevels = *request.AllowedLevels
+	}
+	if request.DisallowedLevelMapping != nil {
+		project.DisallowedLevelMapping = *request.DisallowedLevelMapping
+	}
+
+	if request.MultilinePattern != nil {
+		project.MultilinePattern = *request.MultilinePattern
+	}
+	if request.IsFieldNamesLowercased != nil {
+		project.IsFieldNamesLowercased = *request.IsFieldNamesLowercased
+	}
+	if request.IsMessageOnlyMode != nil {
+		project.IsMessageOnlyMode = *request.IsMessageOnlyMode
+	}
+	if request.IngestPipeline != nil {
+		project.IngestPipeline = *request.IngestPipeline
+	}
+
+	if request.DefaultSavedQueryID != nil {
+		if *request.DefaultSavedQueryID == uuid.Nil {
+			project.DefaultSavedQueryID = nil
+		} else {
+			project.DefaultSavedQueryID = request.DefaultSavedQueryID
+		}
+	}
+	if request.DefaultTimeRangeMinutes != nil {
+		project.DefaultTimeRangeMinutes = *request.DefaultTimeRangeMinutes
+	}
+
+	if request.MaskedFields != nil {
+		project.MaskedFields = *request.MaskedFields
+	}
+
+	if request.FieldFilterMode != nil {
+		project.FieldFilterMode = *request.FieldFilterMode
+	}
+	if request.FilteredFields != nil {
+		project.FilteredFields = *request.FilteredFields
+	}
+
+	if request.EncryptedFields != nil {
+		project.EncryptedFields = *request.EncryptedFields
+	}
+
+	if request.NgramFields != nil {
+		project.NgramFields = *request.NgramFields
+	}
+
+	if request.FieldAliases != nil {
+		project.FieldAliases = *request.FieldAliases
+	}
+}
+
+func generateFieldEncryptionKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate field encryption key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
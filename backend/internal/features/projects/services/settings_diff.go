@@ -0,0 +1,104 @@
+package projects_services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	projects_models "logbull/internal/features/projects/models"
+)
+
+// Settings which are not changed through UpdateProject
+var settingsDiffIgnoredFields = map[string]bool{
+	"id":                 true,
+	"createdAt":          true,
+	"firstLogReceivedAt": true,
+}
+
+// Webhook URLs often carry tokens, so only the fact of the change is recorded
+var settingsDiffRedactedFields = map[string]bool{
+	"cleanupWebhookUrl":  true,
+	"firstLogWebhookUrl": true,
+}
+
+// diffProjectSettings lists the settings which differ, sorted by name, as
+// "<setting>: <before> -> <after>" with JSON encoded values. Settings are
+// compared in their API form, so fields hidden from the API (e.g. the field
+// encryption key) are never part of the diff
+func diffProjectSettings(before, after *projects_models.Project) ([]string, error) {
+	beforeSettings, err := encodeProjectSettings(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterSettings, err := encodeProjectSettings(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var settingNames []string
+	for settingName := range beforeSettings {
+		settingNames = append(settingNames, settingName)
+	}
+	for settingName := range afterSettings {
+		if _, exists := beforeSettings[settingName]; !exists {
+			settingNames = append(settingNames, settingName)
+		}
+	}
+	slices.Sort(settingNames)
+
+	var changes []string
+	for _, settingName := range settingNames {
+		if settingsDiffIgnoredFields[settingName] {
+			continue
+		}
+
+		beforeValue := formatSettingValue(beforeSettings[settingName])
+		afterValue := formatSettingValue(afterSettings[settingName])
+		if beforeValue == afterValue {
+			continue
+		}
+
+		if settingsDiffRedactedFields[settingName] {
+			changes = append(changes, settingName+": changed")
+			continue
+		}
+
+		changes = append(changes, fmt.Sprintf("%s: %s -> %s", settingName, beforeValue, afterValue))
+	}
+
+	return changes, nil
+}
+
+func encodeProjectSettings(project *projects_models.Project) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode project settings: %w", err)
+	}
+
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode project settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// formatSettingValue treats missing and empty list settings as the same value
+func formatSettingValue(value json.RawMessage) string {
+	if len(value) == 0 || bytes.Equal(value, []byte("[]")) {
+		return "null"
+	}
+
+	return strings.TrimSpace(string(value))
+}
+
+func buildProjectUpdatedMessage(projectName string, changes []string) string {
+	if len(changes) == 0 {
+		return fmt.Sprintf("Project updated: %s", projectName)
+	}
+
+	return fmt.Sprintf("Project updated: %s (%s)", projectName, strings.Join(changes, "; "))
+}
@@ -309,6 +309,8 @@ func GetProjectMembers(
 	return &response
 }
 
+// UpdateProject sends updateData as the full project settings. Updates which
+// disable enabled quotas need updateData.IsConfirmQuotaDisable
 func UpdateProject(
 	project *projects_models.Project,
 	updateData *projects_models.Project,
@@ -395,6 +397,8 @@ func CreateTestProjectWithConfiguration(
 		AllowedIPs:         config.AllowedIPs,
 		LogsPerSecondLimit: config.LogsPerSecondLimit,
 		MaxLogSizeKB:       config.MaxLogSizeKB,
+		// Configured test projects keep no storage quotas
+		IsConfirmQuotaDisable: true,
 	}
 
 	return UpdateProject(project, updateData, owner.Token, router)
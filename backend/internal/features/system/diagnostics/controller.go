@@ -0,0 +1,48 @@
+package system_diagnostics
+
+import (
+	"net/http"
+
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DiagnosticsController struct {
+	diagnosticsService *DiagnosticsService
+}
+
+func (c *DiagnosticsController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/system/diagnostics/opensearch", c.GetOpenSearchHealth)
+}
+
+// GetOpenSearchHealth
+// @Summary Get OpenSearch cluster health (ADMIN only)
+// @Description Returns OpenSearch cluster status, node count and doc counts of log indices
+// @Tags system/diagnostics
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} logs_core.ClusterHealthDTO
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /system/diagnostics/opensearch [get]
+func (c *DiagnosticsController) GetOpenSearchHealth(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	health, err := c.diagnosticsService.GetOpenSearchHealth(user)
+	if err != nil {
+		if err.Error() == "only administrators can view system diagnostics" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, health)
+}
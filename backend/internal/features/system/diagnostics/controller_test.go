@@ -0,0 +1,127 @@
+package system_diagnostics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logs_core "logbull/internal/features/logs/core"
+	users_enums "logbull/internal/features/users/enums"
+	users_middleware "logbull/internal/features/users/middleware"
+	users_services "logbull/internal/features/users/services"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpenSearchHealth_WhenUserIsAdmin_ReturnsHealthSummary(t *testing.T) {
+	openSearch := createOpenSearchStub()
+	defer openSearch.Close()
+
+	router := createRouter(openSearch.URL)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	var response logs_core.ClusterHealthDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/diagnostics/opensearch",
+		"Bearer "+admin.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, "logbull-cluster", response.ClusterName)
+	assert.Equal(t, "yellow", response.Status)
+	assert.Equal(t, 3, response.NumberOfNodes)
+	assert.Equal(t, 2, response.NumberOfDataNodes)
+	assert.Equal(t, 10, response.ActiveShards)
+	assert.Equal(t, 1, response.UnassignedShards)
+	assert.Equal(t, int64(1500), response.TotalDocs)
+
+	assert.Len(t, response.Indices, 3)
+	assert.Equal(t, "logs-2025.10.15", response.Indices[0].Name)
+	assert.Equal(t, "green", response.Indices[0].Health)
+	assert.Equal(t, int64(1000), response.Indices[0].DocsCount)
+	assert.Equal(t, int64(204800), response.Indices[0].StoreSizeBytes)
+	assert.Equal(t, int64(0), response.Indices[2].DocsCount, "Closed index should have zero docs")
+}
+
+func Test_GetOpenSearchHealth_WhenUserIsMember_ReturnsForbidden(t *testing.T) {
+	openSearch := createOpenSearchStub()
+	defer openSearch.Close()
+
+	router := createRouter(openSearch.URL)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/diagnostics/opensearch",
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "only administrators can view system diagnostics")
+}
+
+func Test_GetOpenSearchHealth_WhenOpenSearchUnavailable_ReturnsServiceUnavailable(t *testing.T) {
+	openSearch := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer openSearch.Close()
+
+	router := createRouter(openSearch.URL)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/diagnostics/opensearch",
+		"Bearer "+admin.Token,
+		http.StatusServiceUnavailable,
+	)
+}
+
+func createOpenSearchStub() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/_cluster/health":
+			_, _ = w.Write([]byte(`{
+				"cluster_name": "logbull-cluster",
+				"status": "yellow",
+				"number_of_nodes": 3,
+				"number_of_data_nodes": 2,
+				"active_shards": 10,
+				"unassigned_shards": 1
+			}`))
+		case strings.HasPrefix(r.URL.Path, "/_cat/indices/logs-"):
+			_, _ = w.Write([]byte(`[
+				{"index": "logs-2025.10.15", "health": "green", "docs.count": "1000", "store.size": "204800"},
+				{"index": "logs-2025.10.16", "health": "yellow", "docs.count": "500", "store.size": "102400"},
+				{"index": "logs-2025.10.17", "health": "red", "docs.count": null, "store.size": null}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func createRouter(openSearchURL string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	controller := &DiagnosticsController{
+		&DiagnosticsService{logs_core.GetLogCoreRepositoryForURL(openSearchURL)},
+	}
+
+	v1 := router.Group("/api/v1")
+	protected := v1.Group("").Use(users_middleware.AuthMiddleware(users_services.GetUserService()))
+	controller.RegisterRoutes(protected.(*gin.RouterGroup))
+
+	return router
+}
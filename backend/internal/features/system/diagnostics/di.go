@@ -0,0 +1,17 @@
+package system_diagnostics
+
+import (
+	logs_core "logbull/internal/features/logs/core"
+)
+
+var diagnosticsService = &DiagnosticsService{
+	logs_core.GetLogCoreRepository(),
+}
+
+var diagnosticsController = &DiagnosticsController{
+	diagnosticsService,
+}
+
+func GetDiagnosticsController() *DiagnosticsController {
+	return diagnosticsController
+}
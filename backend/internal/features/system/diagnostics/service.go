@@ -0,0 +1,27 @@
+package system_diagnostics
+
+import (
+	"errors"
+	"fmt"
+
+	logs_core "logbull/internal/features/logs/core"
+	users_enums "logbull/internal/features/users/enums"
+	users_models "logbull/internal/features/users/models"
+)
+
+type DiagnosticsService struct {
+	logRepository *logs_core.LogCoreRepository
+}
+
+func (s *DiagnosticsService) GetOpenSearchHealth(user *users_models.User) (*logs_core.ClusterHealthDTO, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only administrators can view system diagnostics")
+	}
+
+	health, err := s.logRepository.GetClusterHealth()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenSearch health: %w", err)
+	}
+
+	return health, nil
+}
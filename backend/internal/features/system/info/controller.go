@@ -0,0 +1,28 @@
+package system_info
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SystemInfoController struct {
+	systemInfoService *SystemInfoService
+}
+
+func (c *SystemInfoController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/system/info", c.GetSystemInfo)
+}
+
+// GetSystemInfo
+// @Summary Get system info
+// @Description Returns the running version and commit, the uptime and which optional features are enabled
+// @Tags system/info
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} SystemInfoDTO
+// @Failure 401 {object} map[string]string
+// @Router /system/info [get]
+func (c *SystemInfoController) GetSystemInfo(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.systemInfoService.GetSystemInfo())
+}
@@ -0,0 +1,104 @@
+package system_info
+
+import (
+	"net/http"
+	"testing"
+
+	"logbull/internal/config"
+	users_enums "logbull/internal/features/users/enums"
+	users_middleware "logbull/internal/features/users/middleware"
+	users_services "logbull/internal/features/users/services"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetSystemInfo_WithOptionalFeaturesConfigured_ReturnsEnabledFeatureFlags(t *testing.T) {
+	router := createRouter()
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	env := GetSystemInfoService().GetEnv()
+	env.AppVersion = "1.4.2"
+	env.AppCommit = "0123abcd"
+	env.GeoIPDatabasePath = "/data/GeoLite2-City-CSV"
+	env.ExportsS3Endpoint = ""
+	env.IsReadOnlyMode = true
+	env.IsAuditSettingsDiffDisabled = false
+	setEnvForTest(t, env)
+
+	var response SystemInfoDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/info",
+		"Bearer "+member.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, "1.4.2", response.Version)
+	assert.Equal(t, "0123abcd", response.Commit)
+	assert.False(t, response.StartedAt.IsZero())
+	assert.GreaterOrEqual(t, response.UptimeSeconds, int64(0))
+	assert.Equal(t, map[string]bool{
+		FeatureGeoIPEnrichment:   true,
+		FeatureScheduledExports:  false,
+		FeatureReadOnlyMode:      true,
+		FeatureAuditSettingsDiff: true,
+	}, response.Features)
+}
+
+func Test_GetSystemInfo_WithoutOptionalFeatures_ReturnsDisabledFlagsAndDevVersion(t *testing.T) {
+	router := createRouter()
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	env := GetSystemInfoService().GetEnv()
+	env.AppVersion = ""
+	env.GeoIPDatabasePath = ""
+	env.ExportsS3Endpoint = ""
+	env.IsReadOnlyMode = false
+	env.IsAuditSettingsDiffDisabled = true
+	setEnvForTest(t, env)
+
+	var response SystemInfoDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/info",
+		"Bearer "+member.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, "dev", response.Version)
+	assert.NotEmpty(t, response.Commit)
+	for feature, isEnabled := range response.Features {
+		assert.False(t, isEnabled, "feature %s should be disabled", feature)
+	}
+}
+
+func Test_GetSystemInfo_WithoutAuthToken_ReturnsUnauthorized(t *testing.T) {
+	router := createRouter()
+
+	test_utils.MakeGetRequest(t, router, "/api/v1/system/info", "", http.StatusUnauthorized)
+}
+
+func createRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	protected := router.Group("/api/v1")
+	protected.Use(users_middleware.AuthMiddleware(users_services.GetUserService()))
+	GetSystemInfoController().RegisterRoutes(protected)
+
+	return router
+}
+
+func setEnvForTest(t *testing.T, env config.EnvVariables) {
+	originalEnv := GetSystemInfoService().GetEnv()
+	t.Cleanup(func() { GetSystemInfoService().SetEnv(originalEnv) })
+
+	GetSystemInfoService().SetEnv(env)
+}
@@ -0,0 +1,24 @@
+package system_info
+
+import (
+	"time"
+
+	"logbull/internal/config"
+)
+
+var systemInfoService = &SystemInfoService{
+	config.GetEnv(),
+	time.Now().UTC(),
+}
+
+var systemInfoController = &SystemInfoController{
+	systemInfoService,
+}
+
+func GetSystemInfoService() *SystemInfoService {
+	return systemInfoService
+}
+
+func GetSystemInfoController() *SystemInfoController {
+	return systemInfoController
+}
@@ -0,0 +1,12 @@
+package system_info
+
+import "time"
+
+type SystemInfoDTO struct {
+	Version       string    `json:"version"`
+	Commit        string    `json:"commit"`
+	StartedAt     time.Time `json:"startedAt"`
+	UptimeSeconds int64     `json:"uptimeSeconds"`
+	// optional features by name, true when enabled in this instance's config
+	Features map[string]bool `json:"features"`
+}
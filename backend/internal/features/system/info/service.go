@@ -0,0 +1,78 @@
+package system_info
+
+import (
+	"runtime/debug"
+	"time"
+
+	"logbull/internal/config"
+)
+
+const (
+	FeatureGeoIPEnrichment   = "geoipEnrichment"
+	FeatureScheduledExports  = "scheduledExports"
+	FeatureReadOnlyMode      = "readOnlyMode"
+	FeatureAuditSettingsDiff = "auditSettingsDiff"
+
+	unknownVersion = "dev"
+	unknownCommit  = "unknown"
+)
+
+type SystemInfoService struct {
+	env       config.EnvVariables
+	startedAt time.Time
+}
+
+// SetEnv replaces the config the info is read from, so tests can check the
+// features of other configs
+func (s *SystemInfoService) SetEnv(env config.EnvVariables) {
+	s.env = env
+}
+
+func (s *SystemInfoService) GetEnv() config.EnvVariables {
+	return s.env
+}
+
+func (s *SystemInfoService) GetSystemInfo() *SystemInfoDTO {
+	version := s.env.AppVersion
+	if version == "" {
+		version = unknownVersion
+	}
+
+	return &SystemInfoDTO{
+		Version:       version,
+		Commit:        resolveCommit(s.env.AppCommit),
+		StartedAt:     s.startedAt,
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+		Features:      BuildFeatureFlags(s.env),
+	}
+}
+
+func BuildFeatureFlags(env config.EnvVariables) map[string]bool {
+	return map[string]bool{
+		FeatureGeoIPEnrichment:   env.GeoIPDatabasePath != "",
+		FeatureScheduledExports:  env.ExportsS3Endpoint != "",
+		FeatureReadOnlyMode:      env.IsReadOnlyMode,
+		FeatureAuditSettingsDiff: !env.IsAuditSettingsDiffDisabled,
+	}
+}
+
+// resolveCommit falls back to the revision recorded by go build when the
+// binary was built from a git checkout
+func resolveCommit(configuredCommit string) string {
+	if configuredCommit != "" {
+		return configuredCommit
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownCommit
+	}
+
+	for _, setting := range buildInfo.Settings {
+		if setting.Key == "vcs.revision" && setting.Value != "" {
+			return setting.Value
+		}
+	}
+
+	return unknownCommit
+}
@@ -0,0 +1,78 @@
+package system_maintenance
+
+import (
+	"net/http"
+
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceController struct {
+	maintenanceService *MaintenanceService
+}
+
+func (c *MaintenanceController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/system/maintenance/read-only", c.GetReadOnlyMode)
+	router.PUT("/system/maintenance/read-only", c.SetReadOnlyMode)
+}
+
+// GetReadOnlyMode
+// @Summary Get read-only mode
+// @Description Tells whether writes (ingestion, project and user changes) are disabled for maintenance
+// @Tags system/maintenance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ReadOnlyModeDTO
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /system/maintenance/read-only [get]
+func (c *MaintenanceController) GetReadOnlyMode(ctx *gin.Context) {
+	readOnlyMode, err := c.maintenanceService.GetReadOnlyMode()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readOnlyMode)
+}
+
+// SetReadOnlyMode
+// @Summary Set read-only mode (ADMIN only)
+// @Description Enable or disable read-only mode. While enabled writes return 503 and queries keep working.
+// @Description The mode applies to all instances within a few seconds
+// @Tags system/maintenance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SetReadOnlyModeRequestDTO true "Read-only mode"
+// @Success 200 {object} ReadOnlyModeDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/maintenance/read-only [put]
+func (c *MaintenanceController) SetReadOnlyMode(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	var request SetReadOnlyModeRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	readOnlyMode, err := c.maintenanceService.SetReadOnlyMode(*request.IsReadOnly, user)
+	if err != nil {
+		if err.Error() == "insufficient permissions to change read-only mode" {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readOnlyMode)
+}
@@ -0,0 +1,163 @@
+package system_maintenance
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	logs_core "logbull/internal/features/logs/core"
+	logs_querying "logbull/internal/features/logs/querying"
+	logs_receiving "logbull/internal/features/logs/receiving"
+	projects_controllers "logbull/internal/features/projects/controllers"
+	projects_dto "logbull/internal/features/projects/dto"
+	projects_testing "logbull/internal/features/projects/testing"
+	users_enums "logbull/internal/features/users/enums"
+	users_middleware "logbull/internal/features/users/middleware"
+	users_services "logbull/internal/features/users/services"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadOnlyMode_WhenEnabledByConfig_WritesRejectedAndReadsSucceed(t *testing.T) {
+	router := createRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProject("Read Only Mode "+uuid.New().String()[:8], owner, router)
+
+	setReadOnlyByConfigForTest(t, true)
+
+	resp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String()),
+		"",
+		&logs_receiving.SubmitLogsRequestDTO{Logs: []logs_receiving.LogItemRequestDTO{
+			{Level: logs_core.LogLevelInfo, Message: "Read only mode log"},
+		}},
+		http.StatusServiceUnavailable,
+	)
+	assert.Contains(t, string(resp.Body), ErrorReadOnlyMode)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/projects",
+		"Bearer "+owner.Token,
+		&projects_dto.CreateProjectRequestDTO{Name: "Blocked Project"},
+		http.StatusServiceUnavailable,
+	)
+
+	test_utils.MakeGetRequest(t, router, "/api/v1/projects", "Bearer "+owner.Token, http.StatusOK)
+
+	now := time.Now().UTC()
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/logs/query/execute/%s", project.ID.String()),
+		"Bearer "+owner.Token,
+		&logs_core.LogQueryRequestDTO{TimeRange: &logs_core.TimeRangeDTO{To: &now}, Limit: 10},
+		http.StatusOK,
+	)
+}
+
+func Test_SetReadOnlyMode_WhenAdminTogglesMode_WritesBlockedUntilDisabled(t *testing.T) {
+	router := createRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	project := projects_testing.CreateTestProject("Read Only Toggle "+uuid.New().String()[:8], owner, router)
+
+	t.Cleanup(func() {
+		test_utils.MakePutRequest(
+			t,
+			router,
+			"/api/v1/system/maintenance/read-only",
+			"Bearer "+admin.Token,
+			map[string]any{"isReadOnly": false},
+			http.StatusOK,
+		)
+	})
+
+	var readOnlyMode ReadOnlyModeDTO
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/maintenance/read-only",
+		"Bearer "+admin.Token,
+		map[string]any{"isReadOnly": true},
+		http.StatusOK,
+		&readOnlyMode,
+	)
+	assert.True(t, readOnlyMode.IsReadOnly)
+	assert.False(t, readOnlyMode.IsForcedByConfig)
+
+	submitRequest := &logs_receiving.SubmitLogsRequestDTO{Logs: []logs_receiving.LogItemRequestDTO{
+		{Level: logs_core.LogLevelInfo, Message: "Read only toggle log"},
+	}}
+	submitURL := fmt.Sprintf("/api/v1/logs/receiving/%s", project.ID.String())
+
+	test_utils.MakePostRequest(t, router, submitURL, "", submitRequest, http.StatusServiceUnavailable)
+
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/maintenance/read-only",
+		"Bearer "+admin.Token,
+		map[string]any{"isReadOnly": false},
+		http.StatusOK,
+		&readOnlyMode,
+	)
+	assert.False(t, readOnlyMode.IsReadOnly)
+
+	test_utils.MakePostRequest(t, router, submitURL, "", submitRequest, http.StatusAccepted)
+}
+
+func Test_SetReadOnlyMode_WhenUserIsMember_ReturnsForbidden(t *testing.T) {
+	router := createRouter()
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/system/maintenance/read-only",
+		"Bearer "+member.Token,
+		map[string]any{"isReadOnly": true},
+		http.StatusForbidden,
+	)
+}
+
+func createRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+
+	readOnlyMiddleware := ReadOnlyMiddleware(GetMaintenanceService())
+	authMiddleware := users_middleware.AuthMiddleware(users_services.GetUserService())
+
+	ingestion := v1.Group("")
+	ingestion.Use(readOnlyMiddleware)
+	logs_receiving.GetReceivingController().RegisterRoutes(ingestion)
+
+	maintenance := v1.Group("")
+	maintenance.Use(authMiddleware)
+	GetMaintenanceController().RegisterRoutes(maintenance)
+
+	protected := v1.Group("")
+	protected.Use(authMiddleware, readOnlyMiddleware)
+	projects_controllers.GetProjectController().RegisterRoutes(protected)
+
+	queryable := v1.Group("")
+	queryable.Use(authMiddleware)
+	logs_querying.GetLogQueryController().RegisterRoutes(queryable)
+
+	return router
+}
+
+func setReadOnlyByConfigForTest(t *testing.T, isReadOnly bool) {
+	previous := GetMaintenanceService().IsReadOnlyByConfig()
+	GetMaintenanceService().SetReadOnlyByConfig(isReadOnly)
+	t.Cleanup(func() { GetMaintenanceService().SetReadOnlyByConfig(previous) })
+}
@@ -0,0 +1,34 @@
+package system_maintenance
+
+import (
+	"sync"
+	"time"
+
+	"logbull/internal/config"
+	audit_logs "logbull/internal/features/audit_logs"
+	"logbull/internal/util/logger"
+)
+
+var maintenanceSettingsRepository = &MaintenanceSettingsRepository{}
+
+var maintenanceService = &MaintenanceService{
+	maintenanceSettingsRepository,
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+	config.GetEnv().IsReadOnlyMode,
+	sync.Mutex{},
+	false,
+	time.Time{},
+}
+
+var maintenanceController = &MaintenanceController{
+	maintenanceService,
+}
+
+func GetMaintenanceService() *MaintenanceService {
+	return maintenanceService
+}
+
+func GetMaintenanceController() *MaintenanceController {
+	return maintenanceController
+}
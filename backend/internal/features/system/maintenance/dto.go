@@ -0,0 +1,11 @@
+package system_maintenance
+
+type ReadOnlyModeDTO struct {
+	IsReadOnly bool `json:"isReadOnly"`
+	// set when READ_ONLY_MODE is enabled, the mode cannot be turned off by the API then
+	IsForcedByConfig bool `json:"isForcedByConfig"`
+}
+
+type SetReadOnlyModeRequestDTO struct {
+	IsReadOnly *bool `json:"isReadOnly" binding:"required"`
+}
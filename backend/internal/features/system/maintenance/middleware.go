@@ -0,0 +1,33 @@
+package system_maintenance
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ErrorReadOnlyMode = "READ_ONLY_MODE"
+
+// ReadOnlyMiddleware rejects writes with 503 while read-only mode is on. It is
+// attached to route groups which change data, query routes (which are POST
+// requests too) are registered without it
+func ReadOnlyMiddleware(maintenanceService *MaintenanceService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+
+		if maintenanceService.IsReadOnly() {
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "LogBull is in read-only mode for maintenance, writes are temporarily disabled",
+				"code":  ErrorReadOnlyMode,
+			})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,18 @@
+package system_maintenance
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MaintenanceSettings struct {
+	ID uuid.UUID `json:"-"          gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	// means that writes (ingestion, project and user changes) are rejected while queries keep working
+	IsReadOnly bool      `json:"isReadOnly" gorm:"column:is_read_only"`
+	UpdatedAt  time.Time `json:"updatedAt"  gorm:"column:updated_at"`
+}
+
+func (MaintenanceSettings) TableName() string {
+	return "maintenance_settings"
+}
@@ -0,0 +1,46 @@
+package system_maintenance
+
+import (
+	"time"
+
+	"logbull/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MaintenanceSettingsRepository struct{}
+
+func (r *MaintenanceSettingsRepository) GetSettings() (*MaintenanceSettings, error) {
+	var settings MaintenanceSettings
+
+	if err := storage.GetDb().First(&settings).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			defaultSettings := &MaintenanceSettings{
+				ID:        uuid.New(),
+				UpdatedAt: time.Now().UTC(),
+			}
+
+			if createErr := storage.GetDb().Create(defaultSettings).Error; createErr != nil {
+				return nil, createErr
+			}
+
+			return defaultSettings, nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *MaintenanceSettingsRepository) UpdateSettings(settings *MaintenanceSettings) error {
+	existingSettings, err := r.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	settings.ID = existingSettings.ID
+	settings.UpdatedAt = time.Now().UTC()
+
+	return storage.GetDb().Save(settings).Error
+}
@@ -0,0 +1,107 @@
+package system_maintenance
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	audit_logs "logbull/internal/features/audit_logs"
+	users_models "logbull/internal/features/users/models"
+)
+
+// Instances pick up a mode changed on another instance within this interval
+const readOnlyStateTTL = 5 * time.Second
+
+type MaintenanceService struct {
+	maintenanceSettingsRepository *MaintenanceSettingsRepository
+	auditLogService               *audit_logs.AuditLogService
+	logger                        *slog.Logger
+	isReadOnlyByConfig            bool
+
+	mu               sync.Mutex
+	cachedIsReadOnly bool
+	cachedAt         time.Time
+}
+
+func (s *MaintenanceService) SetReadOnlyByConfig(isReadOnly bool) {
+	s.isReadOnlyByConfig = isReadOnly
+}
+
+func (s *MaintenanceService) IsReadOnlyByConfig() bool {
+	return s.isReadOnlyByConfig
+}
+
+// IsReadOnly is checked on every write request, so the stored mode is cached
+// for a few seconds. When the mode cannot be read writes stay allowed
+func (s *MaintenanceService) IsReadOnly() bool {
+	if s.isReadOnlyByConfig {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.cachedAt) < readOnlyStateTTL {
+		return s.cachedIsReadOnly
+	}
+
+	settings, err := s.maintenanceSettingsRepository.GetSettings()
+	if err != nil {
+		s.logger.Error("Failed to get maintenance settings, writes stay allowed", slog.String("error", err.Error()))
+		return s.cachedIsReadOnly
+	}
+
+	s.cachedIsReadOnly = settings.IsReadOnly
+	s.cachedAt = time.Now()
+
+	return s.cachedIsReadOnly
+}
+
+func (s *MaintenanceService) GetReadOnlyMode() (*ReadOnlyModeDTO, error) {
+	settings, err := s.maintenanceSettingsRepository.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance settings: %w", err)
+	}
+
+	return &ReadOnlyModeDTO{
+		IsReadOnly:       s.isReadOnlyByConfig || settings.IsReadOnly,
+		IsForcedByConfig: s.isReadOnlyByConfig,
+	}, nil
+}
+
+func (s *MaintenanceService) SetReadOnlyMode(isReadOnly bool, user *users_models.User) (*ReadOnlyModeDTO, error) {
+	if !user.CanUpdateSettings() {
+		return nil, errors.New("insufficient permissions to change read-only mode")
+	}
+
+	settings, err := s.maintenanceSettingsRepository.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance settings: %w", err)
+	}
+
+	if settings.IsReadOnly != isReadOnly {
+		settings.IsReadOnly = isReadOnly
+		if err := s.maintenanceSettingsRepository.UpdateSettings(settings); err != nil {
+			return nil, fmt.Errorf("failed to update maintenance settings: %w", err)
+		}
+
+		auditLogMessage := "Read-only mode disabled"
+		if isReadOnly {
+			auditLogMessage = "Read-only mode enabled"
+		}
+
+		s.auditLogService.WriteAuditLog(auditLogMessage, &user.ID, nil)
+	}
+
+	s.mu.Lock()
+	s.cachedIsReadOnly = isReadOnly
+	s.cachedAt = time.Now()
+	s.mu.Unlock()
+
+	return &ReadOnlyModeDTO{
+		IsReadOnly:       s.isReadOnlyByConfig || isReadOnly,
+		IsForcedByConfig: s.isReadOnlyByConfig,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package system_webhooks
+
+import (
+	"net/http"
+
+	users_models "logbull/internal/features/users/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookService *WebhookService
+}
+
+func (c *WebhookController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/system/webhooks/dead-letters", c.GetDeadLetters)
+}
+
+// GetDeadLetters
+// @Summary Get failed webhook deliveries (ADMIN only)
+// @Description Returns first log and cleanup webhook calls which failed after all attempts, the oldest first.
+// @Description They are kept in memory of the instance, the oldest are dropped after 100
+// @Tags system/webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} webhook.DeadLetter
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/webhooks/dead-letters [get]
+func (c *WebhookController) GetDeadLetters(ctx *gin.Context) {
+	user, isOk := ctx.MustGet("user").(*users_models.User)
+	if !isOk {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		return
+	}
+
+	deadLetters, err := c.webhookService.GetDeadLetters(user)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deadLetters)
+}
@@ -0,0 +1,77 @@
+package system_webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	users_enums "logbull/internal/features/users/enums"
+	users_middleware "logbull/internal/features/users/middleware"
+	users_services "logbull/internal/features/users/services"
+	users_testing "logbull/internal/features/users/testing"
+	"logbull/internal/util/logger"
+	test_utils "logbull/internal/util/testing"
+	"logbull/internal/util/webhook"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetDeadLetters_WhenWebhookFailed_AdminSeesFailedDelivery(t *testing.T) {
+	failingWebhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failingWebhook.Close()
+
+	sender := webhook.NewSender(webhook.Config{}, webhook.NewDestinationGuard(true), logger.GetLogger())
+	err := sender.Send("cleanup", failingWebhook.URL, map[string]string{"projectId": "test"})
+	assert.Error(t, err)
+
+	router := createRouter(sender)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	var deadLetters []webhook.DeadLetter
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/webhooks/dead-letters",
+		"Bearer "+admin.Token,
+		http.StatusOK,
+		&deadLetters,
+	)
+
+	if assert.Len(t, deadLetters, 1) {
+		assert.Equal(t, "cleanup", deadLetters[0].Name)
+		assert.Equal(t, failingWebhook.URL, deadLetters[0].URL)
+		assert.Equal(t, 1, deadLetters[0].Attempts)
+		assert.Contains(t, deadLetters[0].Error, "status 400")
+		assert.JSONEq(t, `{"projectId": "test"}`, string(deadLetters[0].Payload))
+	}
+}
+
+func Test_GetDeadLetters_WhenUserIsMember_ReturnsForbidden(t *testing.T) {
+	router := createRouter(webhook.NewSender(webhook.Config{}, webhook.NewDestinationGuard(true), logger.GetLogger()))
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/webhooks/dead-letters",
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+	assert.Contains(t, string(resp.Body), "only administrators can view webhook dead letters")
+}
+
+func createRouter(sender *webhook.Sender) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	controller := &WebhookController{&WebhookService{sender}}
+
+	v1 := router.Group("/api/v1")
+	protected := v1.Group("").Use(users_middleware.AuthMiddleware(users_services.GetUserService()))
+	controller.RegisterRoutes(protected.(*gin.RouterGroup))
+
+	return router
+}
@@ -0,0 +1,49 @@
+package system_webhooks
+
+import (
+	"time"
+
+	"logbull/internal/config"
+	"logbull/internal/util/logger"
+	"logbull/internal/util/webhook"
+)
+
+var destinationGuard = webhook.NewDestinationGuard(config.GetEnv().IsSinkExportPrivateNetworkAllowed)
+
+var webhookSender = webhook.NewSender(getWebhookConfigFromEnv(), destinationGuard, logger.GetLogger())
+
+var webhookService = &WebhookService{
+	webhookSender,
+}
+
+var webhookController = &WebhookController{
+	webhookService,
+}
+
+// GetWebhookSender returns the sender shared by all project webhooks, so their
+// dead letters are listed together
+func GetWebhookSender() *webhook.Sender {
+	return webhookSender
+}
+
+// GetDestinationGuard returns the guard of all requests to user-set urls, so
+// one setting allows private networks for webhooks and export sinks
+func GetDestinationGuard() *webhook.DestinationGuard {
+	return destinationGuard
+}
+
+func GetWebhookController() *WebhookController {
+	return webhookController
+}
+
+// getWebhookConfigFromEnv leaves unset values zero, the sender uses its
+// defaults for them
+func getWebhookConfigFromEnv() webhook.Config {
+	env := config.GetEnv()
+
+	return webhook.Config{
+		Timeout:        time.Duration(max(env.WebhookTimeoutMs, 0)) * time.Millisecond,
+		MaxAttempts:    max(env.WebhookMaxAttempts, 0),
+		InitialBackoff: time.Duration(max(env.WebhookInitialBackoffMs, 0)) * time.Millisecond,
+	}
+}
@@ -0,0 +1,28 @@
+package system_webhooks
+
+import (
+	"errors"
+
+	users_enums "logbull/internal/features/users/enums"
+	users_models "logbull/internal/features/users/models"
+	"logbull/internal/util/webhook"
+)
+
+type WebhookService struct {
+	webhookSender *webhook.Sender
+}
+
+// GetDeadLetters returns the webhook deliveries of this instance which failed
+// after all attempts, the oldest first
+func (s *WebhookService) GetDeadLetters(user *users_models.User) ([]webhook.DeadLetter, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only administrators can view webhook dead letters")
+	}
+
+	deadLetters := s.webhookSender.GetDeadLetters()
+	if deadLetters == nil {
+		deadLetters = []webhook.DeadLetter{}
+	}
+
+	return deadLetters, nil
+}
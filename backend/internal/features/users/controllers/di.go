@@ -19,6 +19,10 @@ var managementController = &ManagementController{
 	managementService: users_services.GetManagementService(),
 }
 
+var personalAccessTokenController = &PersonalAccessTokenController{
+	tokenService: users_services.GetPersonalAccessTokenService(),
+}
+
 func GetUserController() *UserController {
 	return userController
 }
@@ -30,3 +34,7 @@ func GetSettingsController() *SettingsController {
 func GetManagementController() *ManagementController {
 	return managementController
 }
+
+func GetPersonalAccessTokenController() *PersonalAccessTokenController {
+	return personalAccessTokenController
+}
@@ -0,0 +1,119 @@
+package users_controllers
+
+import (
+	"net/http"
+
+	user_dto "logbull/internal/features/users/dto"
+	user_middleware "logbull/internal/features/users/middleware"
+	users_services "logbull/internal/features/users/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenController struct {
+	tokenService *users_services.PersonalAccessTokenService
+}
+
+func (c *PersonalAccessTokenController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/users/tokens", c.CreateToken)
+	router.GET("/users/tokens", c.GetTokens)
+	router.DELETE("/users/tokens/:id", c.RevokeToken)
+}
+
+// CreateToken
+// @Summary Create a personal access token
+// @Description Create a read-only personal access token for automation. The token is returned only once.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body users_dto.CreatePersonalAccessTokenRequestDTO true "Token data"
+// @Success 200 {object} users_models.PersonalAccessToken
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /users/tokens [post]
+func (c *PersonalAccessTokenController) CreateToken(ctx *gin.Context) {
+	user, ok := user_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request user_dto.CreatePersonalAccessTokenRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	token, err := c.tokenService.CreateToken(&request, user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, token)
+}
+
+// GetTokens
+// @Summary List personal access tokens
+// @Description List personal access tokens of the current user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} users_dto.ListPersonalAccessTokensResponseDTO
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/tokens [get]
+func (c *PersonalAccessTokenController) GetTokens(ctx *gin.Context) {
+	user, ok := user_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	tokens, err := c.tokenService.GetUserTokens(user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get personal access tokens"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user_dto.ListPersonalAccessTokensResponseDTO{Tokens: tokens})
+}
+
+// RevokeToken
+// @Summary Revoke a personal access token
+// @Description Revoke a personal access token of the current user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Token ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/tokens/{id} [delete]
+func (c *PersonalAccessTokenController) RevokeToken(ctx *gin.Context) {
+	user, ok := user_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	tokenID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	if err := c.tokenService.RevokeToken(tokenID, user); err != nil {
+		if err.Error() == "personal access token not found" {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Personal access token revoked successfully"})
+}
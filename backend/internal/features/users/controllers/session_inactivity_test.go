@@ -0,0 +1,70 @@
+package users_controllers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	users_enums "logbull/internal/features/users/enums"
+	users_services "logbull/internal/features/users/services"
+	users_testing "logbull/internal/features/users/testing"
+	test_utils "logbull/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthMiddleware_WithInactivityTimeout_ActivityRefreshesSessionWindow(t *testing.T) {
+	router := createUserTestRouter()
+	setSessionInactivityTimeout(t, 2*time.Second)
+
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	test_utils.MakeGetRequest(t, router, "/api/v1/users/me", "Bearer "+user.Token, http.StatusOK)
+
+	// Each request starts a new window, so the session outlives the timeout while used
+	for range 3 {
+		time.Sleep(1500 * time.Millisecond)
+		test_utils.MakeGetRequest(t, router, "/api/v1/users/me", "Bearer "+user.Token, http.StatusOK)
+	}
+}
+
+func Test_AuthMiddleware_WithInactivityTimeout_IdleSessionExpires(t *testing.T) {
+	router := createUserTestRouter()
+	setSessionInactivityTimeout(t, 2*time.Second)
+
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	test_utils.MakeGetRequest(t, router, "/api/v1/users/me", "Bearer "+user.Token, http.StatusOK)
+
+	time.Sleep(3 * time.Second)
+
+	response := test_utils.MakeGetRequest(t, router, "/api/v1/users/me", "Bearer "+user.Token, http.StatusUnauthorized)
+	assert.Contains(t, string(response.Body), "inactivity")
+
+	// The session stays expired, only a new sign in gets a working token
+	test_utils.MakeGetRequest(t, router, "/api/v1/users/me", "Bearer "+user.Token, http.StatusUnauthorized)
+
+	userModel, err := users_services.GetUserService().GetUserByID(user.UserID)
+	assert.NoError(t, err)
+	newToken, err := users_services.GetUserService().GenerateAccessToken(userModel)
+	assert.NoError(t, err)
+	test_utils.MakeGetRequest(t, router, "/api/v1/users/me", "Bearer "+newToken.Token, http.StatusOK)
+}
+
+func Test_AuthMiddleware_WithoutInactivityTimeout_IdleSessionStaysValid(t *testing.T) {
+	router := createUserTestRouter()
+	setSessionInactivityTimeout(t, 0)
+
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	time.Sleep(1500 * time.Millisecond)
+
+	test_utils.MakeGetRequest(t, router, "/api/v1/users/me", "Bearer "+user.Token, http.StatusOK)
+}
+
+func setSessionInactivityTimeout(t *testing.T, inactivityTimeout time.Duration) {
+	sessionActivityService := users_services.GetSessionActivityService()
+	previousTimeout := sessionActivityService.GetInactivityTimeout()
+
+	sessionActivityService.SetInactivityTimeout(inactivityTimeout)
+	t.Cleanup(func() {
+		sessionActivityService.SetInactivityTimeout(previousTimeout)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	users_enums "logbull/internal/features/users/enums"
+	users_models "logbull/internal/features/users/models"
 
 	"github.com/google/uuid"
 )
@@ -72,3 +73,19 @@ type ListUsersRequestDTO struct {
 	Offset     int        `form:"offset"     json:"offset"`
 	BeforeDate *time.Time `form:"beforeDate" json:"beforeDate"`
 }
+
+type CreatePersonalAccessTokenRequestDTO struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ListPersonalAccessTokensResponseDTO struct {
+	Tokens []*users_models.PersonalAccessToken `json:"tokens"`
+}
+
+// QueryTokenScope is what a query token may read: logs of one project and,
+// when SavedQueryID is set, only that saved query
+type QueryTokenScope struct {
+	ProjectID    uuid.UUID
+	SavedQueryID *uuid.UUID
+	ExpiresAt    time.Time
+}
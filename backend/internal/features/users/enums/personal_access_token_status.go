@@ -0,0 +1,8 @@
+package users_enums
+
+type PersonalAccessTokenStatus string
+
+const (
+	PersonalAccessTokenStatusActive  PersonalAccessTokenStatus = "ACTIVE"
+	PersonalAccessTokenStatusRevoked PersonalAccessTokenStatus = "REVOKED"
+)
@@ -31,11 +31,86 @@ func AuthMiddleware(userService *users_services.UserService) gin.HandlerFunc {
 			return
 		}
 
+		// Idle sessions expire before their token does
+		if err := userService.RecordSessionActivity(token); err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Session expired due to inactivity"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set("user", user)
+		ctx.Next()
+	}
+}
+
+// QueryAuthMiddleware works like AuthMiddleware but also accepts personal
+// access tokens and query tokens. Use it only for read-only routes, because
+// these tokens must not be able to change anything on behalf of the user
+func QueryAuthMiddleware(
+	userService *users_services.UserService,
+	tokenService *users_services.PersonalAccessTokenService,
+	queryTokenService *users_services.QueryTokenService,
+) gin.HandlerFunc {
+	sessionAuth := AuthMiddleware(userService)
+
+	return func(ctx *gin.Context) {
+		token := ctx.GetHeader("Authorization")
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		if queryTokenService.IsQueryToken(token) {
+			queryTokenAuth(ctx, queryTokenService, token)
+			return
+		}
+
+		if !tokenService.IsPersonalAccessToken(token) {
+			sessionAuth(ctx)
+			return
+		}
+
+		user, err := tokenService.GetUserFromToken(token)
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			ctx.Abort()
+			return
+		}
+
 		ctx.Set("user", user)
 		ctx.Next()
 	}
 }
 
+// queryTokenAuth accepts a query token only for routes of its project and,
+// when the token is limited to a saved query, only for that saved query
+func queryTokenAuth(ctx *gin.Context, queryTokenService *users_services.QueryTokenService, token string) {
+	user, scope, err := queryTokenService.GetUserFromToken(token)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		ctx.Abort()
+		return
+	}
+
+	projectID := ctx.Param("projectId")
+	if projectID == "" {
+		projectID = ctx.Param("id")
+	}
+
+	isAllowed := projectID == scope.ProjectID.String()
+	if scope.SavedQueryID != nil && ctx.Param("queryId") != scope.SavedQueryID.String() {
+		isAllowed = false
+	}
+
+	if !isAllowed {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Token is not allowed to access this resource"})
+		ctx.Abort()
+		return
+	}
+
+	ctx.Set("user", user)
+	ctx.Next()
+}
+
 func RequireRole(requiredRole users_enums.UserRole) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		userInterface, exists := ctx.Get("user")
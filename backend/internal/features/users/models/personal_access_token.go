@@ -0,0 +1,25 @@
+package users_models
+
+import (
+	users_enums "logbull/internal/features/users/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PersonalAccessToken struct {
+	ID          uuid.UUID                             `json:"id"          gorm:"column:id"`
+	UserID      uuid.UUID                             `json:"userId"      gorm:"column:user_id"`
+	Name        string                                `json:"name"        gorm:"column:name"`
+	TokenPrefix string                                `json:"tokenPrefix" gorm:"column:token_prefix"`
+	TokenHash   string                                `json:"-"           gorm:"column:token_hash"` // Never expose in JSON
+	Status      users_enums.PersonalAccessTokenStatus `json:"status"      gorm:"column:status"`
+	CreatedAt   time.Time                             `json:"createdAt"   gorm:"column:created_at"`
+	RevokedAt   *time.Time                            `json:"revokedAt"   gorm:"column:revoked_at"`
+
+	Token string `json:"token,omitempty" gorm:"-"` // Temporary field only populated during creation
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
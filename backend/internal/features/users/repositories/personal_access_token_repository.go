@@ -0,0 +1,76 @@
+package users_repositories
+
+import (
+	users_enums "logbull/internal/features/users/enums"
+	users_models "logbull/internal/features/users/models"
+	"logbull/internal/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepository struct{}
+
+func (r *PersonalAccessTokenRepository) CreateToken(token *users_models.PersonalAccessToken) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+
+	return storage.GetDb().Create(token).Error
+}
+
+func (r *PersonalAccessTokenRepository) GetTokensByUserID(
+	userID uuid.UUID,
+) ([]*users_models.PersonalAccessToken, error) {
+	var tokens []*users_models.PersonalAccessToken
+
+	err := storage.GetDb().
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+
+	return tokens, err
+}
+
+func (r *PersonalAccessTokenRepository) GetTokenByID(tokenID uuid.UUID) (*users_models.PersonalAccessToken, error) {
+	var token users_models.PersonalAccessToken
+
+	if err := storage.GetDb().Where("id = ?", tokenID).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *PersonalAccessTokenRepository) GetActiveTokenByHash(
+	tokenHash string,
+) (*users_models.PersonalAccessToken, error) {
+	var token users_models.PersonalAccessToken
+
+	err := storage.GetDb().
+		Where("token_hash = ? AND status = ?", tokenHash, users_enums.PersonalAccessTokenStatusActive).
+		First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *PersonalAccessTokenRepository) RevokeToken(tokenID uuid.UUID) error {
+	return storage.GetDb().Model(&users_models.PersonalAccessToken{}).
+		Where("id = ?", tokenID).
+		Updates(map[string]any{
+			"status":     users_enums.PersonalAccessTokenStatusRevoked,
+			"revoked_at": time.Now().UTC(),
+		}).Error
+}
@@ -1,17 +1,28 @@
 package users_services
 
 import (
+	"time"
+
+	"logbull/internal/cache"
+	"logbull/internal/config"
 	user_repositories "logbull/internal/features/users/repositories"
+	cache_utils "logbull/internal/util/cache"
 )
 
 var secretKeyRepository = &user_repositories.SecretKeyRepository{}
 var userRepository = &user_repositories.UserRepository{}
 var usersSettingsRepository = &user_repositories.UsersSettingsRepository{}
+var personalAccessTokenRepository = &user_repositories.PersonalAccessTokenRepository{}
 
 var userService = &UserService{
-	userRepository:      userRepository,
-	secretKeyRepository: secretKeyRepository,
-	settingsService:     settingsService,
+	userRepository:         userRepository,
+	secretKeyRepository:    secretKeyRepository,
+	settingsService:        settingsService,
+	sessionActivityService: sessionActivityService,
+}
+var sessionActivityService = &SessionActivityService{
+	cache_utils.NewCacheUtil[time.Time](cache.GetCache(), "lb_session_activity:"),
+	time.Duration(config.GetEnv().SessionInactivityTimeoutMinutes) * time.Minute,
 }
 var settingsService = &SettingsService{
 	userSettingsRepository: usersSettingsRepository,
@@ -19,11 +30,23 @@ var settingsService = &SettingsService{
 var managementService = &UserManagementService{
 	userRepository: userRepository,
 }
+var personalAccessTokenService = &PersonalAccessTokenService{
+	tokenRepository: personalAccessTokenRepository,
+	userRepository:  userRepository,
+}
+var queryTokenService = &QueryTokenService{
+	userRepository:      userRepository,
+	secretKeyRepository: secretKeyRepository,
+}
 
 func GetUserService() *UserService {
 	return userService
 }
 
+func GetSessionActivityService() *SessionActivityService {
+	return sessionActivityService
+}
+
 func GetSettingsService() *SettingsService {
 	return settingsService
 }
@@ -31,3 +54,11 @@ func GetSettingsService() *SettingsService {
 func GetManagementService() *UserManagementService {
 	return managementService
 }
+
+func GetPersonalAccessTokenService() *PersonalAccessTokenService {
+	return personalAccessTokenService
+}
+
+func GetQueryTokenService() *QueryTokenService {
+	return queryTokenService
+}
@@ -0,0 +1,157 @@
+package users_services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	users_dto "logbull/internal/features/users/dto"
+	users_enums "logbull/internal/features/users/enums"
+	users_interfaces "logbull/internal/features/users/interfaces"
+	users_models "logbull/internal/features/users/models"
+	users_repositories "logbull/internal/features/users/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PersonalAccessTokenPrefix = "lbp_"
+	PersonalAccessTokenLength = 40
+)
+
+// PersonalAccessTokenService manages long-lived tokens for automation.
+// Tokens act on behalf of their owner but are only accepted by read-only
+// query endpoints, so project access is still resolved from the owner's
+// memberships on every request.
+type PersonalAccessTokenService struct {
+	tokenRepository *users_repositories.PersonalAccessTokenRepository
+	userRepository  *users_repositories.UserRepository
+	auditLogWriter  users_interfaces.AuditLogWriter
+}
+
+func (s *PersonalAccessTokenService) SetAuditLogWriter(writer users_interfaces.AuditLogWriter) {
+	s.auditLogWriter = writer
+}
+
+func (s *PersonalAccessTokenService) CreateToken(
+	request *users_dto.CreatePersonalAccessTokenRequestDTO,
+	user *users_models.User,
+) (*users_models.PersonalAccessToken, error) {
+	if strings.TrimSpace(request.Name) == "" {
+		return nil, errors.New("token name is required")
+	}
+
+	fullToken, tokenPrefix, tokenHash, err := s.generateSecureToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := &users_models.PersonalAccessToken{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		Name:        strings.TrimSpace(request.Name),
+		TokenPrefix: tokenPrefix,
+		TokenHash:   tokenHash,
+		Status:      users_enums.PersonalAccessTokenStatusActive,
+	}
+
+	if err := s.tokenRepository.CreateToken(token); err != nil {
+		return nil, fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	s.auditLogWriter.WriteAuditLog(
+		fmt.Sprintf("Personal access token created: %s", token.Name),
+		&user.ID,
+		nil,
+	)
+
+	token.Token = fullToken
+
+	return token, nil
+}
+
+func (s *PersonalAccessTokenService) GetUserTokens(
+	user *users_models.User,
+) ([]*users_models.PersonalAccessToken, error) {
+	return s.tokenRepository.GetTokensByUserID(user.ID)
+}
+
+func (s *PersonalAccessTokenService) RevokeToken(tokenID uuid.UUID, user *users_models.User) error {
+	token, err := s.tokenRepository.GetTokenByID(tokenID)
+	if err != nil {
+		return errors.New("personal access token not found")
+	}
+
+	if token.UserID != user.ID {
+		return errors.New("personal access token not found")
+	}
+
+	if token.Status == users_enums.PersonalAccessTokenStatusRevoked {
+		return nil
+	}
+
+	if err := s.tokenRepository.RevokeToken(tokenID); err != nil {
+		return fmt.Errorf("failed to revoke personal access token: %w", err)
+	}
+
+	s.auditLogWriter.WriteAuditLog(
+		fmt.Sprintf("Personal access token revoked: %s", token.Name),
+		&user.ID,
+		nil,
+	)
+
+	return nil
+}
+
+func (s *PersonalAccessTokenService) IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+func (s *PersonalAccessTokenService) GetUserFromToken(token string) (*users_models.User, error) {
+	if !s.IsPersonalAccessToken(token) {
+		return nil, errors.New("invalid personal access token")
+	}
+
+	accessToken, err := s.tokenRepository.GetActiveTokenByHash(s.hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+
+	if accessToken == nil {
+		return nil, errors.New("invalid personal access token")
+	}
+
+	user, err := s.userRepository.GetUserByID(accessToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActiveUser() {
+		return nil, errors.New("user account is deactivated")
+	}
+
+	return user, nil
+}
+
+func (s *PersonalAccessTokenService) generateSecureToken() (fullToken, prefix, hash string, err error) {
+	tokenBytes := make([]byte, PersonalAccessTokenLength/2) // hex encoding doubles the length
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", "", "", err
+	}
+
+	tokenSuffix := hex.EncodeToString(tokenBytes)
+	fullToken = PersonalAccessTokenPrefix + tokenSuffix
+	prefix = PersonalAccessTokenPrefix + tokenSuffix[:6] + "..."
+	hash = s.hashToken(fullToken)
+
+	return fullToken, prefix, hash, nil
+}
+
+func (s *PersonalAccessTokenService) hashToken(token string) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(token))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
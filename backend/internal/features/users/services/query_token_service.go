@@ -0,0 +1,154 @@
+package users_services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	users_dto "logbull/internal/features/users/dto"
+	users_models "logbull/internal/features/users/models"
+	users_repositories "logbull/internal/features/users/repositories"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	QueryTokenPrefix = "lbq_"
+
+	queryTokenType = "query"
+)
+
+// QueryTokenService mints short-lived tokens for embedding dashboards. A token
+// acts on behalf of the user who minted it, but only for the query endpoints
+// of its project and, optionally, a single saved query. Tokens are signed, not
+// stored, so they can't be revoked and must stay short-lived
+type QueryTokenService struct {
+	userRepository      *users_repositories.UserRepository
+	secretKeyRepository *users_repositories.SecretKeyRepository
+}
+
+func (s *QueryTokenService) IsQueryToken(token string) bool {
+	return strings.HasPrefix(token, QueryTokenPrefix)
+}
+
+func (s *QueryTokenService) GenerateQueryToken(
+	user *users_models.User,
+	scope *users_dto.QueryTokenScope,
+) (string, error) {
+	secretKey, err := s.secretKeyRepository.GetSecretKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	claims := jwt.MapClaims{
+		"sub":                  user.ID.String(),
+		"typ":                  queryTokenType,
+		"pid":                  scope.ProjectID.String(),
+		"exp":                  scope.ExpiresAt.Unix(),
+		"iat":                  time.Now().UTC().Unix(),
+		"passwordCreationTime": user.PasswordCreationTime.Unix(),
+	}
+	if scope.SavedQueryID != nil {
+		claims["qid"] = scope.SavedQueryID.String()
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secretKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return QueryTokenPrefix + token, nil
+}
+
+// GetUserFromToken returns the user who minted the token together with what
+// the token may read
+func (s *QueryTokenService) GetUserFromToken(
+	token string,
+) (*users_models.User, *users_dto.QueryTokenScope, error) {
+	if !s.IsQueryToken(token) {
+		return nil, nil, errors.New("invalid query token")
+	}
+
+	secretKey, err := s.secretKeyRepository.GetSecretKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	parsedToken, err := jwt.Parse(
+		strings.TrimPrefix(token, QueryTokenPrefix),
+		func(token *jwt.Token) (any, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return []byte(secretKey), nil
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	// Parsing checks the expiration only when it is present, query tokens must have it
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || !parsedToken.Valid || claims["typ"] != queryTokenType || !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, nil, errors.New("invalid token claims")
+	}
+
+	scope, userID, err := parseQueryTokenClaims(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !user.IsActiveUser() {
+		return nil, nil, errors.New("user account is deactivated")
+	}
+
+	// Changing the password invalidates query tokens like sessions
+	passwordCreationTime, ok := claims["passwordCreationTime"].(float64)
+	if !ok || int64(passwordCreationTime) != user.PasswordCreationTime.Unix() {
+		return nil, nil, errors.New("password has been changed, please mint a new token")
+	}
+
+	return user, scope, nil
+}
+
+func parseQueryTokenClaims(claims jwt.MapClaims) (*users_dto.QueryTokenScope, uuid.UUID, error) {
+	userIDStr, _ := claims["sub"].(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, uuid.Nil, errors.New("invalid token claims")
+	}
+
+	projectIDStr, _ := claims["pid"].(string)
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		return nil, uuid.Nil, errors.New("invalid token claims")
+	}
+
+	expiresAt, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, uuid.Nil, errors.New("invalid token claims")
+	}
+
+	scope := &users_dto.QueryTokenScope{
+		ProjectID: projectID,
+		ExpiresAt: time.Unix(int64(expiresAt), 0).UTC(),
+	}
+
+	if savedQueryIDStr, ok := claims["qid"].(string); ok {
+		savedQueryID, err := uuid.Parse(savedQueryIDStr)
+		if err != nil {
+			return nil, uuid.Nil, errors.New("invalid token claims")
+		}
+
+		scope.SavedQueryID = &savedQueryID
+	}
+
+	return scope, userID, nil
+}
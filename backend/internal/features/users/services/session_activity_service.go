@@ -0,0 +1,79 @@
+package users_services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	cache_utils "logbull/internal/util/cache"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// SessionActivityService expires sign-in sessions which were not used for the
+// inactivity timeout, even when their token is still valid. The last activity
+// of each session is kept in the cache until the timeout passes, a session
+// without one counts from the time its token was issued
+type SessionActivityService struct {
+	activityCache     *cache_utils.CacheUtil[time.Time]
+	inactivityTimeout time.Duration
+}
+
+func (s *SessionActivityService) SetInactivityTimeout(inactivityTimeout time.Duration) {
+	s.inactivityTimeout = inactivityTimeout
+}
+
+func (s *SessionActivityService) GetInactivityTimeout() time.Duration {
+	return s.inactivityTimeout
+}
+
+// RecordActivity starts a new inactivity window of the session, or returns an
+// error when the previous one has already passed. The token has to be verified
+func (s *SessionActivityService) RecordActivity(token string) error {
+	if s.inactivityTimeout <= 0 {
+		return nil
+	}
+
+	sessionKey := getSessionKey(token)
+	now := time.Now().UTC()
+
+	lastActivity := s.activityCache.Get(sessionKey)
+	if lastActivity == nil {
+		issuedAt, err := getTokenIssuedAt(token)
+		if err != nil {
+			return err
+		}
+
+		lastActivity = &issuedAt
+	}
+
+	if now.Sub(*lastActivity) > s.inactivityTimeout {
+		return errors.New("session expired due to inactivity, please sign in again")
+	}
+
+	s.activityCache.SetWithExpiry(sessionKey, &now, s.inactivityTimeout)
+
+	return nil
+}
+
+// getSessionKey keeps tokens themselves out of the cache
+func getSessionKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func getTokenIssuedAt(token string) (time.Time, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return time.Time{}, fmt.Errorf("invalid token: %w", err)
+	}
+
+	issuedAtUnix, ok := claims["iat"].(float64)
+	if !ok {
+		return time.Time{}, errors.New("invalid token claims: missing issue time")
+	}
+
+	return time.Unix(int64(issuedAtUnix), 0).UTC(), nil
+}
@@ -22,6 +22,8 @@ type UserService struct {
 	settingsService     *SettingsService
 	// audit log is never nil, DI always set it
 	auditLogWriter users_interfaces.AuditLogWriter
+	// nil disables the inactivity timeout of sessions
+	sessionActivityService *SessionActivityService
 }
 
 func NewUserService(
@@ -166,6 +168,12 @@ func (s *UserService) GetUserFromToken(token string) (*users_models.User, error)
 	}
 
 	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
+		// Sessions carry no type, typed tokens (query, ingest) are signed with the
+		// same key but must never pass as a session
+		if _, hasType := claims["typ"]; hasType {
+			return nil, errors.New("invalid token claims")
+		}
+
 		userIDStr, ok := claims["sub"].(string)
 		if !ok {
 			return nil, errors.New("invalid token claims")
@@ -205,6 +213,16 @@ func (s *UserService) GetUserFromToken(token string) (*users_models.User, error)
 	return nil, errors.New("invalid token")
 }
 
+// RecordSessionActivity keeps the session of a verified token alive, sessions
+// idle for longer than the inactivity timeout are rejected
+func (s *UserService) RecordSessionActivity(token string) error {
+	if s.sessionActivityService == nil {
+		return nil
+	}
+
+	return s.sessionActivityService.RecordActivity(token)
+}
+
 func (s *UserService) GenerateAccessToken(user *users_models.User) (*users_dto.SignInResponseDTO, error) {
 	secretKey, err := s.secretKeyRepository.GetSecretKey()
 	if err != nil {
@@ -105,6 +105,21 @@ func (c *CacheUtil[T]) Set(key string, item *T) {
 	c.client.Do(ctx, c.client.B().Set().Key(fullKey).Value(string(data)).Ex(c.expiry).Build())
 }
 
+// SetWithExpiry stores the item like Set, with its own expiry instead of the
+// expiry of the cache
+func (c *CacheUtil[T]) SetWithExpiry(key string, item *T, expiry time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return
+	}
+
+	fullKey := c.prefix + key
+	c.client.Do(ctx, c.client.B().Set().Key(fullKey).Value(string(data)).Px(expiry).Build())
+}
+
 func (c *CacheUtil[T]) Invalidate(key string) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
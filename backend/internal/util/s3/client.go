@@ -0,0 +1,293 @@
+package s3_utils
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DefaultRegion = "us-east-1"
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// Client talks to S3 compatible storage (AWS S3, MinIO, R2...) with path style
+// requests signed by AWS Signature Version 4
+type Client struct {
+	httpClient      *http.Client
+	endpoint        string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+type listObjectsResponse struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func NewClient(
+	httpClient *http.Client,
+	endpoint, region, accessKeyID, secretAccessKey string,
+) *Client {
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	return &Client{
+		httpClient:      httpClient,
+		endpoint:        strings.TrimRight(endpoint, "/"),
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+	}
+}
+
+func (c *Client) PutObject(bucket, key, contentType string, data []byte) error {
+	response, err := c.do(http.MethodPut, bucket, key, nil, contentType, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return readFailure("upload of object", response)
+	}
+
+	return nil
+}
+
+// GetObject returns ErrObjectNotFound when the key does not exist
+func (c *Client) GetObject(bucket, key string) ([]byte, error) {
+	response, err := c.do(http.MethodGet, bucket, key, nil, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, readFailure("download of object", response)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+
+	return data, nil
+}
+
+// DeleteObject succeeds when the key does not exist
+func (c *Client) DeleteObject(bucket, key string) error {
+	response, err := c.do(http.MethodDelete, bucket, key, nil, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return readFailure("deletion of object", response)
+	}
+
+	return nil
+}
+
+// ListObjectKeys returns every key starting with prefix, following the pages
+// of ListObjectsV2
+func (c *Client) ListObjectKeys(bucket, prefix string) ([]string, error) {
+	keys := []string{}
+	continuationToken := ""
+
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		response, err := c.do(http.MethodGet, bucket, "", query, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		if response.StatusCode < 200 || response.StatusCode >= 300 {
+			err := readFailure("listing of objects", response)
+			_ = response.Body.Close()
+			return nil, err
+		}
+
+		var listResponse listObjectsResponse
+		err = xml.NewDecoder(response.Body).Decode(&listResponse)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse objects list: %w", err)
+		}
+
+		for _, object := range listResponse.Contents {
+			keys = append(keys, object.Key)
+		}
+
+		if !listResponse.IsTruncated || listResponse.NextContinuationToken == "" {
+			return keys, nil
+		}
+		continuationToken = listResponse.NextContinuationToken
+	}
+}
+
+func (c *Client) do(
+	method, bucket, key string,
+	query url.Values,
+	contentType string,
+	payload []byte,
+) (*http.Response, error) {
+	canonicalURI := "/" + uriEncode(bucket, false)
+	if key != "" {
+		canonicalURI += "/" + uriEncode(key, true)
+	}
+	canonicalQuery := canonicalQueryString(query)
+
+	requestURL := c.endpoint + canonicalURI
+	if canonicalQuery != "" {
+		requestURL += "?" + canonicalQuery
+	}
+
+	request, err := http.NewRequest(method, requestURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	c.signRequest(request, canonicalURI, canonicalQuery, payload, time.Now().UTC())
+
+	return c.httpClient.Do(request)
+}
+
+func (c *Client) signRequest(
+	request *http.Request,
+	canonicalURI, canonicalQuery string,
+	payload []byte,
+	now time.Time,
+) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + request.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	if contentType := request.Header.Get("Content-Type"); contentType != "" {
+		signedHeaders = "content-type;" + signedHeaders
+		canonicalHeaders = "content-type:" + contentType + "\n" + canonicalHeaders
+	}
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalURI,
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.secretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, c.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+// canonicalQueryString sorts the parameters by name and encodes them the way
+// Signature Version 4 expects, the same string is sent in the request
+func canonicalQueryString(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parameters := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, value := range query[name] {
+			parameters = append(parameters, uriEncode(name, false)+"="+uriEncode(value, false))
+		}
+	}
+
+	return strings.Join(parameters, "&")
+}
+
+// uriEncode encodes everything except unreserved characters, as required by
+// Signature Version 4. Slashes are kept in object keys
+func uriEncode(value string, keepSlash bool) string {
+	var builder strings.Builder
+
+	for _, b := range []byte(value) {
+		isUnreserved := (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~'
+
+		if isUnreserved || (keepSlash && b == '/') {
+			builder.WriteByte(b)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+
+	return builder.String()
+}
+
+func readFailure(operation string, response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("%s failed with status %d: %s", operation, response.StatusCode, string(body))
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -115,6 +115,36 @@ func MakePutRequestAndUnmarshal(
 	)
 }
 
+func MakePatchRequest(
+	t *testing.T,
+	router *gin.Engine,
+	url, authToken string,
+	body any,
+	expectedStatus int,
+) *TestResponse {
+	return makeAuthenticatedRequest(t, router, "PATCH", url, authToken, body, expectedStatus)
+}
+
+func MakePatchRequestAndUnmarshal(
+	t *testing.T,
+	router *gin.Engine,
+	url, authToken string,
+	body any,
+	expectedStatus int,
+	responseStruct any,
+) *TestResponse {
+	return makeAuthenticatedRequestAndUnmarshal(
+		t,
+		router,
+		"PATCH",
+		url,
+		authToken,
+		body,
+		expectedStatus,
+		responseStruct,
+	)
+}
+
 func MakeDeleteRequest(
 	t *testing.T,
 	router *gin.Engine,
@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	maxRedirects   = 5
+	connectTimeout = 10 * time.Second
+)
+
+// nonPublicNetworks are reserved ranges the net.IP helpers do not cover
+var nonPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+)
+
+// DestinationGuard keeps requests to user-set urls (webhooks, export sinks)
+// away from the backend's own network. Loopback, private, link-local (e.g.
+// cloud metadata) and reserved addresses are refused unless private networks
+// are allowed. Urls are checked when they are saved or used, on redirects and
+// again for every connection, so a DNS answer changing after the first check
+// does not get through
+type DestinationGuard struct {
+	isPrivateNetworkAllowed atomic.Bool
+}
+
+func NewDestinationGuard(isPrivateNetworkAllowed bool) *DestinationGuard {
+	guard := &DestinationGuard{}
+	guard.isPrivateNetworkAllowed.Store(isPrivateNetworkAllowed)
+
+	return guard
+}
+
+func (g *DestinationGuard) SetPrivateNetworkAllowed(isPrivateNetworkAllowed bool) {
+	g.isPrivateNetworkAllowed.Store(isPrivateNetworkAllowed)
+}
+
+func (g *DestinationGuard) IsPrivateNetworkAllowed() bool {
+	return g.isPrivateNetworkAllowed.Load()
+}
+
+// CheckURL accepts absolute http(s) urls whose host resolves to allowed
+// addresses only
+func (g *DestinationGuard) CheckURL(ctx context.Context, destinationURL *url.URL) error {
+	if (destinationURL.Scheme != "http" && destinationURL.Scheme != "https") || destinationURL.Hostname() == "" {
+		return errors.New("must be an absolute http or https url")
+	}
+
+	if g.IsPrivateNetworkAllowed() {
+		return nil
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, destinationURL.Hostname())
+	if err != nil || len(addresses) == 0 {
+		return fmt.Errorf("cannot resolve host %s", destinationURL.Hostname())
+	}
+
+	for _, address := range addresses {
+		if !isPublicIP(address.IP) {
+			return fmt.Errorf("%s is not a public address", destinationURL.Hostname())
+		}
+	}
+
+	return nil
+}
+
+// NewHTTPClient returns a client which connects to addresses allowed by the
+// guard only. Proxies from the environment are not used, they would connect
+// in place of the client and bypass the check
+func (g *DestinationGuard) NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: connectTimeout, Control: g.controlConnection}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("redirected more than %d times", maxRedirects)
+			}
+
+			if err := g.CheckURL(request.Context(), request.URL); err != nil {
+				return fmt.Errorf("redirect to %s refused: %w", request.URL.Host, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// controlConnection runs for the address actually dialed, after resolution
+func (g *DestinationGuard) controlConnection(_, address string, _ syscall.RawConn) error {
+	if g.IsPrivateNetworkAllowed() {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("address %s is not a public address", host)
+	}
+
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
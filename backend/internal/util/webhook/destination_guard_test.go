@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckURL_WithPrivateOrLoopbackHost_ReturnsError(t *testing.T) {
+	guard := NewDestinationGuard(false)
+
+	refusedURLs := []string{
+		"http://127.0.0.1/hook",
+		"http://localhost:8080/hook",
+		"http://10.0.0.1/hook",
+		"http://192.168.1.10/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+		"ftp://203.0.113.10/hook",
+	}
+
+	for _, refusedURL := range refusedURLs {
+		parsedURL, err := url.Parse(refusedURL)
+		assert.NoError(t, err)
+		assert.Error(t, guard.CheckURL(context.Background(), parsedURL), refusedURL)
+	}
+
+	publicURL, _ := url.Parse("https://203.0.113.10/hook")
+	assert.NoError(t, guard.CheckURL(context.Background(), publicURL))
+
+	guard.SetPrivateNetworkAllowed(true)
+	loopbackURL, _ := url.Parse("http://127.0.0.1/hook")
+	assert.NoError(t, guard.CheckURL(context.Background(), loopbackURL))
+}
+
+func Test_Send_WhenDestinationIsLoopback_RefusesToConnect(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{MaxAttempts: 1}, NewDestinationGuard(false), slog.Default())
+
+	err := sender.Send("test", server.URL, testEvent{Event: "TEST_EVENT"})
+
+	assert.ErrorContains(t, err, "is not a public address")
+	assert.Zero(t, calls.Load())
+}
+
+func Test_Send_WhenRedirectedToRefusedDestination_DoesNotFollow(t *testing.T) {
+	var targetCalls atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targetCalls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+
+	// The guard allows the first request only, as if a public webhook
+	// redirected to the backend's network
+	guard := NewDestinationGuard(true)
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		guard.SetPrivateNetworkAllowed(false)
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirecting.Close()
+
+	sender := NewSender(Config{MaxAttempts: 1}, guard, slog.Default())
+
+	err := sender.Send("test", redirecting.URL, testEvent{Event: "TEST_EVENT"})
+
+	assert.ErrorContains(t, err, "refused")
+	assert.Zero(t, targetCalls.Load())
+}
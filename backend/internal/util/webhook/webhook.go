@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout          = 10 * time.Second
+	defaultMaxAttempts      = 3
+	defaultInitialBackoff   = 1 * time.Second
+	defaultMaxBackoff       = 30 * time.Second
+	defaultDeadLettersLimit = 100
+)
+
+// Config of a Sender, zero values mean the defaults
+type Config struct {
+	// Timeout of each attempt (10 seconds)
+	Timeout time.Duration
+	// MaxAttempts including the first one (3)
+	MaxAttempts int
+	// InitialBackoff before the first retry, doubled for each next one up to
+	// MaxBackoff (1 and 30 seconds)
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// DeadLettersLimit is how many failed deliveries are kept, the oldest are
+	// dropped first (100)
+	DeadLettersLimit int
+}
+
+// DeadLetter is a delivery which failed permanently
+type DeadLetter struct {
+	Name     string          `json:"name"`
+	URL      string          `json:"url"`
+	Payload  json.RawMessage `json:"payload"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	FailedAt time.Time       `json:"failedAt"`
+}
+
+// Sender posts JSON payloads to webhooks. Network errors, 429 and 5xx responses
+// are retried with exponential backoff, other responses are final. Deliveries
+// which fail permanently are kept in memory of the instance as dead letters.
+// Webhook urls are set by users, so connections go through the guard
+type Sender struct {
+	httpClient *http.Client
+	config     Config
+	logger     *slog.Logger
+
+	mu          sync.Mutex
+	deadLetters []DeadLetter
+}
+
+func NewSender(config Config, guard *DestinationGuard, logger *slog.Logger) *Sender {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.DeadLettersLimit <= 0 {
+		config.DeadLettersLimit = defaultDeadLettersLimit
+	}
+
+	return &Sender{
+		httpClient: guard.NewHTTPClient(config.Timeout),
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Send posts the payload to the webhook and returns the error of the last
+// attempt when the delivery fails. The name tells which webhook the errors and
+// dead letters belong to, e.g. "first log"
+func (s *Sender) Send(name, webhookURL string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s webhook payload: %w", name, err)
+	}
+
+	backoff := s.config.InitialBackoff
+
+	attempt := 1
+	for ; ; attempt++ {
+		var isRetryable bool
+		if isRetryable, err = s.post(name, webhookURL, body); err == nil {
+			return nil
+		}
+
+		if !isRetryable || attempt >= s.config.MaxAttempts {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff = min(backoff*2, s.config.MaxBackoff)
+	}
+
+	s.addDeadLetter(DeadLetter{
+		Name:     name,
+		URL:      webhookURL,
+		Payload:  body,
+		Attempts: attempt,
+		Error:    err.Error(),
+		FailedAt: time.Now().UTC(),
+	})
+
+	return err
+}
+
+// GetDeadLetters returns the permanently failed deliveries, the oldest first
+func (s *Sender) GetDeadLetters() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]DeadLetter(nil), s.deadLetters...)
+}
+
+func (s *Sender) addDeadLetter(deadLetter DeadLetter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters = append(s.deadLetters, deadLetter)
+	if overflow := len(s.deadLetters) - s.config.DeadLettersLimit; overflow > 0 {
+		s.deadLetters = append([]DeadLetter(nil), s.deadLetters[overflow:]...)
+	}
+}
+
+func (s *Sender) post(name, webhookURL string, body []byte) (bool, error) {
+	request, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create %s webhook request: %w", name, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return true, fmt.Errorf("failed to call %s webhook: %w", name, err)
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			s.logger.Error("failed to close webhook response body", "webhook", name, "error", closeErr)
+		}
+	}()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		isRetryable := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+
+		return isRetryable, fmt.Errorf(
+			"%s webhook returned status %d: %s",
+			name,
+			response.StatusCode,
+			string(responseBody),
+		)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	Event string `json:"event"`
+}
+
+func Test_Send_WhenWebhookAccepts_DeliversPayloadOnce(t *testing.T) {
+	var calls atomic.Int32
+	var receivedEvent testEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&receivedEvent))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+
+	err := sender.Send("test", server.URL, testEvent{Event: "TEST_EVENT"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, "TEST_EVENT", receivedEvent.Event)
+	assert.Empty(t, sender.GetDeadLetters())
+}
+
+func Test_Send_WhenWebhookFailsTemporarily_RetriesUntilDelivered(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+
+	err := sender.Send("test", server.URL, testEvent{Event: "TEST_EVENT"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Empty(t, sender.GetDeadLetters())
+}
+
+func Test_Send_WhenWebhookKeepsFailing_AddsDeadLetterAfterLastAttempt(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+
+	err := sender.Send("test", server.URL, testEvent{Event: "TEST_EVENT"})
+
+	assert.ErrorContains(t, err, "test webhook returned status 500")
+	assert.Equal(t, int32(3), calls.Load())
+
+	deadLetters := sender.GetDeadLetters()
+	if assert.Len(t, deadLetters, 1) {
+		assert.Equal(t, "test", deadLetters[0].Name)
+		assert.Equal(t, server.URL, deadLetters[0].URL)
+		assert.Equal(t, 3, deadLetters[0].Attempts)
+		assert.JSONEq(t, `{"event":"TEST_EVENT"}`, string(deadLetters[0].Payload))
+		assert.Contains(t, deadLetters[0].Error, "status 500")
+		assert.WithinDuration(t, time.Now().UTC(), deadLetters[0].FailedAt, 5*time.Second)
+	}
+}
+
+func Test_Send_WhenWebhookRejectsPayload_AddsDeadLetterWithoutRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+
+	err := sender.Send("test", server.URL, testEvent{Event: "TEST_EVENT"})
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	if deadLetters := sender.GetDeadLetters(); assert.Len(t, deadLetters, 1) {
+		assert.Equal(t, 1, deadLetters[0].Attempts)
+	}
+}
+
+func Test_Send_WhenWebhookUnreachable_RetriesAndAddsDeadLetter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	unreachableURL := server.URL
+	server.Close()
+
+	sender := newTestSender(2)
+
+	err := sender.Send("test", unreachableURL, testEvent{Event: "TEST_EVENT"})
+
+	assert.ErrorContains(t, err, "failed to call test webhook")
+	if deadLetters := sender.GetDeadLetters(); assert.Len(t, deadLetters, 1) {
+		assert.Equal(t, 2, deadLetters[0].Attempts)
+	}
+}
+
+func Test_GetDeadLetters_WhenLimitExceeded_KeepsNewest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{MaxAttempts: 1, DeadLettersLimit: 2}, NewDestinationGuard(true), slog.Default())
+
+	for _, event := range []string{"FIRST", "SECOND", "THIRD"} {
+		assert.Error(t, sender.Send("test", server.URL, testEvent{Event: event}))
+	}
+
+	deadLetters := sender.GetDeadLetters()
+	if assert.Len(t, deadLetters, 2) {
+		assert.JSONEq(t, `{"event":"SECOND"}`, string(deadLetters[0].Payload))
+		assert.JSONEq(t, `{"event":"THIRD"}`, string(deadLetters[1].Payload))
+	}
+}
+
+func newTestSender(maxAttempts int) *Sender {
+	return NewSender(Config{
+		Timeout:        2 * time.Second,
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+	}, NewDestinationGuard(true), slog.Default())
+}
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create personal_access_tokens table
+CREATE TABLE personal_access_tokens (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID NOT NULL,
+    name         TEXT NOT NULL,
+    token_prefix TEXT NOT NULL,
+    token_hash   TEXT NOT NULL,
+    status       TEXT NOT NULL DEFAULT 'ACTIVE',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at   TIMESTAMPTZ
+);
+
+ALTER TABLE personal_access_tokens
+    ADD CONSTRAINT fk_personal_access_tokens_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE personal_access_tokens
+    ADD CONSTRAINT uk_personal_access_tokens_token_hash
+    UNIQUE (token_hash);
+
+CREATE INDEX idx_personal_access_tokens_user_id ON personal_access_tokens (user_id, created_at);
+CREATE INDEX idx_personal_access_tokens_token_hash ON personal_access_tokens (token_hash);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_personal_access_tokens_token_hash;
+DROP INDEX IF EXISTS idx_personal_access_tokens_user_id;
+
+ALTER TABLE personal_access_tokens DROP CONSTRAINT IF EXISTS uk_personal_access_tokens_token_hash;
+ALTER TABLE personal_access_tokens DROP CONSTRAINT IF EXISTS fk_personal_access_tokens_user_id;
+
+DROP TABLE IF EXISTS personal_access_tokens;
+
+-- +goose StatementEnd
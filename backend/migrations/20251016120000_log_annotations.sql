@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create log_annotations table
+CREATE TABLE log_annotations (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id  UUID NOT NULL,
+    log_id      UUID NOT NULL,
+    note        TEXT NOT NULL DEFAULT '',
+    reviewer_id UUID,
+    reviewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE log_annotations
+    ADD CONSTRAINT fk_log_annotations_project_id
+    FOREIGN KEY (project_id)
+    REFERENCES projects (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE log_annotations
+    ADD CONSTRAINT fk_log_annotations_reviewer_id
+    FOREIGN KEY (reviewer_id)
+    REFERENCES users (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE log_annotations
+    ADD CONSTRAINT uk_log_annotations_project_log
+    UNIQUE (project_id, log_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE log_annotations DROP CONSTRAINT IF EXISTS uk_log_annotations_project_log;
+ALTER TABLE log_annotations DROP CONSTRAINT IF EXISTS fk_log_annotations_reviewer_id;
+ALTER TABLE log_annotations DROP CONSTRAINT IF EXISTS fk_log_annotations_project_id;
+
+DROP TABLE IF EXISTS log_annotations;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN level_field TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS level_field;
+
+-- +goose StatementEnd
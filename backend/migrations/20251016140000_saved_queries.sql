@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create saved_queries table
+CREATE TABLE saved_queries (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id  UUID NOT NULL,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    query       TEXT NOT NULL,
+    created_by  UUID,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE saved_queries
+    ADD CONSTRAINT fk_saved_queries_project_id
+    FOREIGN KEY (project_id)
+    REFERENCES projects (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE saved_queries
+    ADD CONSTRAINT fk_saved_queries_created_by
+    FOREIGN KEY (created_by)
+    REFERENCES users (id)
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_saved_queries_project_id ON saved_queries (project_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_saved_queries_project_id;
+
+ALTER TABLE saved_queries DROP CONSTRAINT IF EXISTS fk_saved_queries_created_by;
+ALTER TABLE saved_queries DROP CONSTRAINT IF EXISTS fk_saved_queries_project_id;
+
+DROP TABLE IF EXISTS saved_queries;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create project_favorites table
+CREATE TABLE project_favorites (
+    user_id    UUID NOT NULL,
+    project_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, project_id)
+);
+
+ALTER TABLE project_favorites
+    ADD CONSTRAINT fk_project_favorites_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE project_favorites
+    ADD CONSTRAINT fk_project_favorites_project_id
+    FOREIGN KEY (project_id)
+    REFERENCES projects (id)
+    ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE project_favorites DROP CONSTRAINT IF EXISTS fk_project_favorites_project_id;
+ALTER TABLE project_favorites DROP CONSTRAINT IF EXISTS fk_project_favorites_user_id;
+
+DROP TABLE IF EXISTS project_favorites;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN masked_fields_raw TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS masked_fields_raw;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN cleanup_webhook_url    TEXT NOT NULL DEFAULT '',
+    ADD COLUMN cleanup_notice_minutes INT  NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS cleanup_webhook_url,
+    DROP COLUMN IF EXISTS cleanup_notice_minutes;
+
+-- +goose StatementEnd
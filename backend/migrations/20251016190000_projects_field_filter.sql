@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN field_filter_mode   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN filtered_fields_raw TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS field_filter_mode,
+    DROP COLUMN IF EXISTS filtered_fields_raw;
+
+-- +goose StatementEnd
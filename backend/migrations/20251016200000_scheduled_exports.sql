@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create scheduled_exports table
+CREATE TABLE scheduled_exports (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id     UUID NOT NULL,
+    saved_query_id UUID NOT NULL,
+    schedule       TEXT NOT NULL,
+    format         TEXT NOT NULL,
+    bucket         TEXT NOT NULL,
+    key_prefix     TEXT NOT NULL DEFAULT '',
+    next_run_at    TIMESTAMPTZ NOT NULL,
+    last_run_at    TIMESTAMPTZ,
+    last_error     TEXT NOT NULL DEFAULT '',
+    created_by     UUID,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE scheduled_exports
+    ADD CONSTRAINT fk_scheduled_exports_project_id
+    FOREIGN KEY (project_id)
+    REFERENCES projects (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE scheduled_exports
+    ADD CONSTRAINT fk_scheduled_exports_saved_query_id
+    FOREIGN KEY (saved_query_id)
+    REFERENCES saved_queries (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE scheduled_exports
+    ADD CONSTRAINT fk_scheduled_exports_created_by
+    FOREIGN KEY (created_by)
+    REFERENCES users (id)
+    ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_scheduled_exports_saved_query_id ON scheduled_exports (saved_query_id);
+CREATE INDEX idx_scheduled_exports_next_run_at ON scheduled_exports (next_run_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_scheduled_exports_next_run_at;
+DROP INDEX IF EXISTS idx_scheduled_exports_saved_query_id;
+
+ALTER TABLE scheduled_exports DROP CONSTRAINT IF EXISTS fk_scheduled_exports_created_by;
+ALTER TABLE scheduled_exports DROP CONSTRAINT IF EXISTS fk_scheduled_exports_saved_query_id;
+ALTER TABLE scheduled_exports DROP CONSTRAINT IF EXISTS fk_scheduled_exports_project_id;
+
+DROP TABLE IF EXISTS scheduled_exports;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN encrypted_fields_raw TEXT NOT NULL DEFAULT '',
+    ADD COLUMN field_encryption_key TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS encrypted_fields_raw,
+    DROP COLUMN IF EXISTS field_encryption_key;
+
+-- +goose StatementEnd
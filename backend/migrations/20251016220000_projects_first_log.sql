@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN first_log_webhook_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN first_log_received_at TIMESTAMPTZ;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS first_log_webhook_url,
+    DROP COLUMN IF EXISTS first_log_received_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create project_user_preferences table
+CREATE TABLE project_user_preferences (
+    user_id            UUID PRIMARY KEY,
+    default_project_id UUID,
+    last_project_id    UUID,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE project_user_preferences
+    ADD CONSTRAINT fk_project_user_preferences_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE project_user_preferences
+    ADD CONSTRAINT fk_project_user_preferences_default_project_id
+    FOREIGN KEY (default_project_id)
+    REFERENCES projects (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE project_user_preferences
+    ADD CONSTRAINT fk_project_user_preferences_last_project_id
+    FOREIGN KEY (last_project_id)
+    REFERENCES projects (id)
+    ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE project_user_preferences DROP CONSTRAINT IF EXISTS fk_project_user_preferences_last_project_id;
+ALTER TABLE project_user_preferences DROP CONSTRAINT IF EXISTS fk_project_user_preferences_default_project_id;
+ALTER TABLE project_user_preferences DROP CONSTRAINT IF EXISTS fk_project_user_preferences_user_id;
+
+DROP TABLE IF EXISTS project_user_preferences;
+
+-- +goose StatementEnd
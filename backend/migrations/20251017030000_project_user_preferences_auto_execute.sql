@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE project_user_preferences
+    ADD COLUMN is_auto_execute_on_open BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE project_user_preferences
+    DROP COLUMN IF EXISTS is_auto_execute_on_open;
+
+-- +goose StatementEnd
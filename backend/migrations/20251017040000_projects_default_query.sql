@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN default_saved_query_id UUID,
+    ADD COLUMN default_time_range_minutes INT NOT NULL DEFAULT 0;
+
+ALTER TABLE projects
+    ADD CONSTRAINT fk_projects_default_saved_query_id
+    FOREIGN KEY (default_saved_query_id)
+    REFERENCES saved_queries (id)
+    ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS fk_projects_default_saved_query_id;
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS default_time_range_minutes,
+    DROP COLUMN IF EXISTS default_saved_query_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE api_keys
+    ADD COLUMN scope TEXT NOT NULL DEFAULT 'INGEST',
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    ADD COLUMN last_used_at TIMESTAMPTZ;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS scope;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Existing keys were hashed with plain SHA-256, they are rehashed with the
+-- configured algorithm when they are next used
+ALTER TABLE api_keys
+    ADD COLUMN hash_algorithm TEXT NOT NULL DEFAULT 'SHA256';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS hash_algorithm;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    ADD COLUMN allowed_levels_raw       TEXT NOT NULL DEFAULT '',
+    ADD COLUMN disallowed_level_mapping TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS allowed_levels_raw,
+    DROP COLUMN IF EXISTS disallowed_level_mapping;
+
+-- +goose StatementEnd
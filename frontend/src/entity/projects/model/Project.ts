@@ -16,4 +16,8 @@ export interface Project {
   maxLogsSizeMb: number;
   maxLogsLifeDays: number;
   maxLogSizeKb: number;
+  archiveAfterDays: number;
+
+  // Update option: confirms setting enabled quotas to 0 (unlimited)
+  confirmQuotaDisable?: boolean;
 }
@@ -5,4 +5,5 @@ export interface ProjectResponse {
   name: string;
   createdAt: Date;
   userRole?: ProjectRole;
+  favorite: boolean;
 }
@@ -4,4 +4,6 @@ export interface ConditionNode {
   field: string;
   operator: QueryOperator;
   value: string | number | boolean | string[] | null;
+  // string operators match regardless of case when set, case sensitive by default
+  caseInsensitive?: boolean;
 }
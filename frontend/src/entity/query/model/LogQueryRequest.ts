@@ -7,4 +7,5 @@ export interface LogQueryRequest {
   limit?: number;
   offset?: number;
   sortOrder?: 'asc' | 'desc';
+  includeArchived?: boolean;
 }
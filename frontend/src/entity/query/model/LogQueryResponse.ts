@@ -3,6 +3,8 @@ import type { LogItem } from './LogItem';
 export interface LogQueryResponse {
   logs: LogItem[];
   total: number;
+  // 'gte' when total is a lower bound of a very large result
+  totalRelation: 'eq' | 'gte';
   limit: number;
   offset: number;
   executedIn: string;
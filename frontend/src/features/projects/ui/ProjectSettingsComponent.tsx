@@ -280,6 +280,8 @@ export function ProjectSettingsComponent({ projectResponse, user, contentHeight
         maxLogsSizeMb: formProject.maxLogsSizeMb ?? project.maxLogsSizeMb,
         maxLogsLifeDays: formProject.maxLogsLifeDays ?? project.maxLogsLifeDays,
         maxLogSizeKb: formProject.maxLogSizeKb ?? project.maxLogSizeKb,
+        // Quotas are edited explicitly here, so setting one to 0 (unlimited) is intended
+        confirmQuotaDisable: true,
       };
       const updatedProject = await projectApi.updateProject(project.id, updateData);
       setProject(updatedProject);
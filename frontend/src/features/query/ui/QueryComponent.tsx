@@ -270,8 +270,12 @@ export const QueryComponentComponent = ({ projectId, contentHeight }: Props): Re
       if (!isLoadMore) {
         const queryType = currentQuery ? 'matching your query' : '(showing all logs)';
         const executedInMs = Math.round(parseFloat(response.executedIn));
+        const totalLabel =
+          response.totalRelation === 'gte'
+            ? `${response.total.toLocaleString()}+`
+            : `${response.total}`;
         message.success(
-          `Found ${response.total} logs ${queryType} (${executedInMs.toLocaleString()} ms)`,
+          `Found ${totalLabel} logs ${queryType} (${executedInMs.toLocaleString()} ms)`,
         );
         setHasSearched(true);
       }